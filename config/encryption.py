@@ -0,0 +1,134 @@
+"""
+Settings encryption at rest.
+With a master password set, the settings file (alert rules, API keys and the
+rest of the configuration) is stored encrypted with AES-256-GCM under a key
+derived from the password with scrypt, and the password is asked for on startup.
+"""
+
+import base64
+import hashlib
+import json
+import os
+
+try:
+    from cryptography.exceptions import InvalidTag
+    from cryptography.hazmat.primitives.ciphers.aead import AESGCM
+
+    ENCRYPTION_AVAILABLE = True
+except ImportError:
+    ENCRYPTION_AVAILABLE = False
+
+# Marker of an encrypted settings file
+ENVELOPE_FORMAT = "crypto-monitor-encrypted"
+CIPHER_NAME = "aes-256-gcm"
+
+# scrypt cost parameters, stored in the file so they can be raised later
+SCRYPT_N = 2**15
+SCRYPT_R = 8
+SCRYPT_P = 1
+KEY_LENGTH = 32
+SALT_LENGTH = 16
+NONCE_LENGTH = 12
+
+
+class DecryptionError(Exception):
+    """Wrong password, or an encrypted file that is damaged."""
+
+
+def is_encrypted(text: str) -> bool:
+    """Whether settings file content is an encrypted envelope."""
+    try:
+        data = json.loads(text)
+    except json.JSONDecodeError:
+        return False
+    return isinstance(data, dict) and data.get("format") == ENVELOPE_FORMAT
+
+
+def derive_key(
+    password: str, salt: bytes, n: int = SCRYPT_N, r: int = SCRYPT_R, p: int = SCRYPT_P
+) -> bytes:
+    """AES key of a password; deliberately slow to resist guessing."""
+    return hashlib.scrypt(
+        password.encode("utf-8"),
+        salt=salt,
+        n=n,
+        r=r,
+        p=p,
+        maxmem=128 * n * r * p + 1024 * 1024,
+        dklen=KEY_LENGTH,
+    )
+
+
+def _b64(data: bytes) -> str:
+    return base64.b64encode(data).decode("ascii")
+
+
+class SettingsCipher:
+    """
+    Encrypts settings file content under one master password.
+
+    The key is derived once per password and salt, and every encryption
+    uses a fresh nonce.
+    """
+
+    def __init__(self, password: str, salt: bytes | None = None, kdf_params: dict | None = None):
+        if not ENCRYPTION_AVAILABLE:
+            raise RuntimeError("Encryption requires the cryptography package")
+        self._salt = salt or os.urandom(SALT_LENGTH)
+        self._kdf_params = kdf_params or {"n": SCRYPT_N, "r": SCRYPT_R, "p": SCRYPT_P}
+        self._aead = AESGCM(derive_key(password, self._salt, **self._kdf_params))
+
+    @classmethod
+    def unlock(cls, password: str, text: str) -> "SettingsCipher":
+        """
+        Cipher of the password an envelope was written with.
+
+        Raises:
+            DecryptionError: If the password is wrong or the envelope damaged
+        """
+        try:
+            envelope = json.loads(text)
+            kdf_params = {key: int(envelope["kdf_params"][key]) for key in ("n", "r", "p")}
+            salt = base64.b64decode(envelope["salt"])
+        except (json.JSONDecodeError, KeyError, TypeError, ValueError) as e:
+            raise DecryptionError(f"Malformed encrypted settings: {e}") from e
+        cipher = cls(password, salt, kdf_params)
+        cipher.decrypt(text)
+        return cipher
+
+    def encrypt(self, plaintext: str) -> str:
+        """Envelope of plaintext, as written to the settings file."""
+        nonce = os.urandom(NONCE_LENGTH)
+        ciphertext = self._aead.encrypt(
+            nonce, plaintext.encode("utf-8"), ENVELOPE_FORMAT.encode("ascii")
+        )
+        envelope = {
+            "format": ENVELOPE_FORMAT,
+            "cipher": CIPHER_NAME,
+            "kdf": "scrypt",
+            "kdf_params": self._kdf_params,
+            "salt": _b64(self._salt),
+            "nonce": _b64(nonce),
+            "ciphertext": _b64(ciphertext),
+        }
+        return json.dumps(envelope, indent=2)
+
+    def decrypt(self, text: str) -> str:
+        """
+        Plaintext of an envelope.
+
+        Raises:
+            DecryptionError: If the password is wrong or the envelope damaged
+        """
+        try:
+            envelope = json.loads(text)
+            if envelope.get("cipher") != CIPHER_NAME:
+                raise DecryptionError(f"Unsupported cipher: {envelope.get('cipher')}")
+            nonce = base64.b64decode(envelope["nonce"])
+            ciphertext = base64.b64decode(envelope["ciphertext"])
+            plaintext = self._aead.decrypt(nonce, ciphertext, ENVELOPE_FORMAT.encode("ascii"))
+        except InvalidTag as e:
+            raise DecryptionError("Wrong password or damaged settings file") from e
+        except (json.JSONDecodeError, AttributeError, KeyError, TypeError, ValueError) as e:
+            raise DecryptionError(f"Malformed encrypted settings: {e}") from e
+        return plaintext.decode("utf-8")
@@ -0,0 +1,119 @@
+"""
+Secret storage in the OS keychain.
+Proxy passwords and API keys are kept in Windows Credential Manager, the
+macOS Keychain or the Secret Service (libsecret) through keyring, and left
+empty in the settings file. Without a usable keychain they stay in the
+settings file as before.
+"""
+
+import logging
+
+logger = logging.getLogger(__name__)
+
+SERVICE_NAME = "crypto-monitor"
+
+# Secret settings as (section, field); section None for top-level fields
+SECRET_FIELDS = (
+    ("proxy", "password"),
+    ("okx_api", "api_key"),
+    ("okx_api", "secret_key"),
+    ("okx_api", "passphrase"),
+    (None, "etherscan_api_key"),
+    ("local_api", "token"),
+    ("telegram", "bot_token"),
+    ("matrix", "access_token"),
+    ("wecom", "webhook_url"),
+    ("dingtalk", "webhook_url"),
+    ("dingtalk", "secret"),
+    ("feishu", "webhook_url"),
+    ("feishu", "secret"),
+    ("gotify", "app_token"),
+    ("xmpp", "password"),
+    ("email", "password"),
+)
+
+
+def secret_name(section: str | None, name: str) -> str:
+    """Keychain entry name of a secret setting, e.g. "okx_api.secret_key"."""
+    return f"{section}.{name}" if section else name
+
+
+class SecretStore:
+    """Reads and writes secrets with a keyring backend; inert without one."""
+
+    def __init__(self, backend=None):
+        self._backend = backend
+        # Values known to be in the keychain, so unchanged secrets aren't written again
+        self._stored: dict[str, str] = {}
+        # Entries the keychain failed to return, e.g. while locked. Their settings stay
+        # empty, which must not be taken for the user clearing them
+        self._unreadable: set[str] = set()
+
+    @property
+    def is_available(self) -> bool:
+        return self._backend is not None
+
+    def get(self, name: str) -> str | None:
+        """Secret from the keychain; None if missing or unreadable."""
+        if self._backend is None:
+            return None
+        try:
+            value = self._backend.get_password(SERVICE_NAME, name)
+        except Exception as e:
+            logger.warning(f"Failed to read {name} from keychain: {e}")
+            self._unreadable.add(name)
+            return None
+        self._unreadable.discard(name)
+        if value is not None:
+            self._stored[name] = value
+        return value
+
+    def set(self, name: str, value: str) -> bool:
+        """
+        Store a secret, deleting the entry when empty. Returns True on success.
+
+        An entry that couldn't be read is left alone until a new value is set.
+        """
+        if self._backend is None:
+            return False
+        if not value and name in self._unreadable:
+            return False
+        if name in self._stored and self._stored[name] == value:
+            return True
+        try:
+            if value:
+                self._backend.set_password(SERVICE_NAME, name, value)
+            elif self._backend.get_password(SERVICE_NAME, name) is not None:
+                self._backend.delete_password(SERVICE_NAME, name)
+        except Exception as e:
+            logger.warning(f"Failed to write {name} to keychain: {e}")
+            return False
+        self._unreadable.discard(name)
+        self._stored[name] = value
+        return True
+
+
+def _load_backend():
+    try:
+        import keyring
+        from keyring.backends import fail
+
+        if isinstance(keyring.get_keyring(), fail.Keyring):
+            logger.info("No keychain available, secrets stay in the settings file")
+            return None
+        return keyring
+    except Exception as e:
+        logger.info(f"Keychain unavailable, secrets stay in the settings file: {e}")
+        return None
+
+
+# Global secret store instance
+_secret_store: SecretStore | None = None
+
+
+def get_secret_store() -> SecretStore:
+    """Get the global secret store instance."""
+    global _secret_store
+    if _secret_store is None:
+        _secret_store = SecretStore(_load_backend())
+    return _secret_store
@@ -9,13 +9,17 @@ import logging
 import os
 import time
 import uuid
-from dataclasses import asdict, dataclass, field
+from dataclasses import asdict, dataclass, field, replace
 from pathlib import Path
 from typing import Any
 
 from core.i18n import load_language
+from core.redaction import set_known_secrets
 
+from .encryption import DecryptionError, SettingsCipher, is_encrypted
 from .migration import ConfigVersion, MigrationManager
+from .secrets import SECRET_FIELDS, get_secret_store, secret_name
+from .store import ConfigStore
 
 logger = logging.getLogger(__name__)
 
@@ -29,7 +33,7 @@ class ProxyConfig:
     host: str = "127.0.0.1"
     port: int = 7890
     username: str = ""
-    password: str = ""
+    password: str = field(default="", repr=False)
 
     def get_proxy_url(self) -> str | None:
         """Get proxy URL string for requests."""
@@ -55,6 +59,24 @@ class CompactModeConfig:
     window_y: int = 100
 
 
+@dataclass
+class WsConnectionTuning:
+    """Transport options of one exchange WebSocket connection."""
+
+    compression: bool = True  # permessage-deflate; some proxies mangle compressed frames
+    read_buffer_kb: int = 0  # Socket receive buffer; 0 keeps the system default
+    write_buffer_kb: int = 0  # Socket send buffer; 0 keeps the system default
+
+
+def _default_ws_connections() -> dict[str, WsConnectionTuning]:
+    # Compression follows each library's default: websockets negotiates it, aiohttp does not
+    return {
+        "okx": WsConnectionTuning(),
+        "okx_private": WsConnectionTuning(),
+        "binance": WsConnectionTuning(compression=False),
+    }
+
+
 @dataclass
 class WebSocketConfig:
     """WebSocket configuration."""
@@ -65,6 +87,154 @@ class WebSocketConfig:
     backoff_factor: float = 2.0
     heartbeat_timeout: int = 60
     connection_timeout: int = 60
+    # Transport options by connection: "okx", "okx_private" and "binance"
+    connections: dict[str, WsConnectionTuning] = field(default_factory=_default_ws_connections)
+
+    def __post_init__(self):
+        """Convert loaded connection options and fill in missing connections."""
+        connections = _default_ws_connections()
+        for name, tuning in self.connections.items():
+            if isinstance(tuning, dict):
+                tuning = WsConnectionTuning(**tuning)
+            if isinstance(tuning, WsConnectionTuning):
+                connections[name] = tuning
+        self.connections = connections
+
+    def tuning(self, connection: str) -> WsConnectionTuning:
+        """Transport options of a connection."""
+        return self.connections.get(connection) or WsConnectionTuning()
+
+
+@dataclass
+class OkxApiConfig:
+    """OKX account API credentials (read-only keys recommended)."""
+
+    enabled: bool = False
+    api_key: str = field(default="", repr=False)
+    secret_key: str = field(default="", repr=False)
+    passphrase: str = field(default="", repr=False)
+    notify_orders: bool = True  # Notify when resting orders fill or are cancelled
+    notify_transfers: bool = True  # Notify when deposits arrive or withdrawals complete
+    # Safety switch: orders are only sent when enabled (requires a trade-enabled key)
+    trading_enabled: bool = False
+    # Connect to OKX's demo trading environment, which needs a demo trading API key
+    demo: bool = False
+    # Distance-to-liquidation warning thresholds in percent, loosest first
+    liquidation_levels: list[float] = field(default_factory=lambda: [20.0, 10.0, 5.0])
+
+    def is_configured(self) -> bool:
+        """Check if account access is enabled and all credentials are set."""
+        return self.enabled and bool(self.api_key and self.secret_key and self.passphrase)
+
+
+@dataclass
+class LocalApiConfig:
+    """Local HTTP API serving prices to home automation and other tools."""
+
+    enabled: bool = False
+    port: int = 8765
+    allow_lan: bool = False  # Listen on all interfaces instead of this machine only
+    token: str = field(default="", repr=False)  # Required as a bearer token when set
+    allow_control: bool = False  # Accept commands changing pairs and alerts; needs a token
+
+
+@dataclass
+class TelegramConfig:
+    """Telegram bot receiving notifications and, if enabled, taking commands."""
+
+    enabled: bool = False
+    bot_token: str = field(default="", repr=False)
+    chat_id: str = ""  # Chat the bot sends to and takes commands from
+    commands_enabled: bool = False
+
+
+@dataclass
+class MatrixConfig:
+    """Matrix room receiving notifications."""
+
+    enabled: bool = False
+    homeserver: str = "https://matrix.org"
+    access_token: str = field(default="", repr=False)
+    room_id: str = ""  # e.g. "!abc123:matrix.org"
+
+
+@dataclass
+class WeComConfig:
+    """WeChat Work (企业微信) group robot receiving notifications."""
+
+    enabled: bool = False
+    webhook_url: str = field(default="", repr=False)  # The robot's URL, key included
+
+
+@dataclass
+class DingTalkConfig:
+    """DingTalk custom robot receiving notifications."""
+
+    enabled: bool = False
+    webhook_url: str = field(default="", repr=False)  # Includes the access token
+    secret: str = field(default="", repr=False)  # "SEC..." when the robot signs requests
+
+
+@dataclass
+class FeishuConfig:
+    """Feishu (Lark) custom bot receiving notifications."""
+
+    enabled: bool = False
+    webhook_url: str = field(default="", repr=False)
+    secret: str = field(default="", repr=False)  # Set when signature verification is on
+
+
+@dataclass
+class GotifyConfig:
+    """Self-hosted Gotify server receiving notifications."""
+
+    enabled: bool = False
+    server_url: str = ""  # e.g. "https://push.example.com"
+    app_token: str = field(default="", repr=False)
+    priority: int = 5  # 0-10; Gotify's Android app alerts loudly from 8 up
+
+
+@dataclass
+class XmppConfig:
+    """XMPP account sending notifications as chat messages; needs slixmpp."""
+
+    enabled: bool = False
+    jid: str = ""  # Account sending the messages, e.g. "monitor@example.com"
+    password: str = field(default="", repr=False)
+    recipient: str = ""  # Address receiving them
+
+
+@dataclass
+class EmailConfig:
+    """SMTP account sending notifications as emails."""
+
+    enabled: bool = False
+    smtp_host: str = ""  # e.g. "smtp.gmail.com"
+    smtp_port: int = 465  # 465 connects over SSL, other ports upgrade with STARTTLS
+    username: str = ""
+    password: str = field(default="", repr=False)
+    sender: str = ""  # From address; the username when empty
+    recipient: str = ""
+
+
+@dataclass
+class DigestConfig:
+    """Scheduled summary of the monitored pairs' prices sent as a notification."""
+
+    enabled: bool = False
+    cadence: str = "daily"  # "hourly" or "daily"
+    hour: int = 8  # Daily digests only
+    minute: int = 0
+    # One line per pair; placeholders: {pair} {name} {price} {change} {high} {low} {volume}
+    template: str = "{name}  {price}  {change}"
+
+
+@dataclass
+class EscalationStep:
+    """Channel an unacknowledged alert is forwarded to, some minutes after it fired."""
+
+    channel: str = "Telegram"  # Name of a remote notification channel
+    after_minutes: int = 2
 
 
 @dataclass
@@ -73,14 +243,24 @@ class PriceAlert:
 
     id: str = ""  # Unique identifier (UUID)
     pair: str = ""  # Trading pair, e.g., "BTC-USDT"
-    alert_type: str = "price_above"  # "price_above" | "price_below" | "price_touch"
-    target_price: float = 0.0  # Target price
+    alert_type: str = "price_above"  # "price_above" | "price_below" | "price_touch" | "rsi_*"
+    target_price: float = 0.0  # Target price (indicator level for indicator alerts)
     repeat_mode: str = "once"  # "once" | "repeat"
     enabled: bool = True  # Whether the alert is enabled
     cooldown_seconds: int = 60  # Cooldown time (only for repeat mode)
     last_triggered: float | None = None  # Last triggered timestamp
     last_triggered_value: float | None = None  # Last value that triggered a step alert
+    acknowledged_at: float | None = None  # When the last firing was acknowledged
     created_at: float = 0.0  # Creation timestamp
+    timeframe: str = "1h"  # Candle interval of moving average cross alerts
+    ema_period: int = 20  # EMA compared with the price in price/EMA cross alerts
+    # Channels to escalate to, in order, until the alert is acknowledged. With
+    # steps, the alert first only shows on the desktop.
+    escalation: list[EscalationStep] = field(default_factory=list)
+    # Own notification text with {pair} {symbol} {price} {change} {rule} {target};
+    # the built-in text when empty
+    message_template: str = ""
+    on_candle_close: bool = False  # Judge price alerts on timeframe candle closes, not ticks
 
     def __post_init__(self):
         """Initialize default values if not set."""
@@ -102,17 +282,258 @@ class PriceAlert:
             cooldown_seconds=data.get("cooldown_seconds", 60),
             last_triggered=data.get("last_triggered"),
             last_triggered_value=data.get("last_triggered_value"),
+            acknowledged_at=data.get("acknowledged_at"),
+            created_at=data.get("created_at", time.time()),
+            timeframe=data.get("timeframe", "1h"),
+            ema_period=data.get("ema_period", 20),
+            escalation=[
+                EscalationStep(**step)
+                for step in data.get("escalation", [])
+                if isinstance(step, dict)
+            ],
+            message_template=data.get("message_template", ""),
+            on_candle_close=data.get("on_candle_close", False),
+        )
+
+    @property
+    def awaiting_acknowledgement(self) -> bool:
+        """Whether the alert fired since it was last acknowledged."""
+        if self.last_triggered is None:
+            return False
+        return self.acknowledged_at is None or self.acknowledged_at < self.last_triggered
+
+
+DEFAULT_PORTFOLIO_ID = "default"
+
+
+@dataclass
+class Portfolio:
+    """A named portfolio with its own holdings and alerts."""
+
+    id: str = ""  # Unique identifier (UUID)
+    name: str = ""
+    created_at: float = 0.0  # Creation timestamp
+
+    def __post_init__(self):
+        """Initialize default values if not set."""
+        if not self.id:
+            self.id = str(uuid.uuid4())
+        if self.created_at == 0.0:
+            self.created_at = time.time()
+
+    @staticmethod
+    def from_dict(data: dict[str, Any]) -> "Portfolio":
+        """Create Portfolio from dictionary."""
+        return Portfolio(
+            id=data.get("id", str(uuid.uuid4())),
+            name=data.get("name", ""),
             created_at=data.get("created_at", time.time()),
         )
 
 
+def _default_portfolio() -> Portfolio:
+    """Portfolio that holds transactions recorded before portfolios existed."""
+    return Portfolio(id=DEFAULT_PORTFOLIO_ID, name="Default")
+
+
+SPECIAL_PAIR_PREFIXES = ("chain:", "pool:", "oracle:", "virtual:", "index:")
+
+
+def normalize_pair(pair: str) -> str:
+    """Uppercase exchange pairs; chain, pool, oracle, virtual and index pairs keep their case."""
+    pair = pair.strip()
+    if not pair.lower().startswith(SPECIAL_PAIR_PREFIXES):
+        pair = pair.upper()
+    return pair
+
+
+@dataclass
+class Watchlist:
+    """A named group of pairs, e.g. "Majors" or "DeFi", subscribed as a whole."""
+
+    id: str = ""  # Unique identifier (UUID)
+    name: str = ""
+    pairs: list[str] = field(default_factory=list)
+    created_at: float = 0.0  # Creation timestamp
+
+    def __post_init__(self):
+        """Initialize default values if not set."""
+        if not self.id:
+            self.id = str(uuid.uuid4())
+        if self.created_at == 0.0:
+            self.created_at = time.time()
+
+    @staticmethod
+    def from_dict(data: dict[str, Any]) -> "Watchlist":
+        """Create Watchlist from dictionary."""
+        pairs = data.get("pairs", [])
+        return Watchlist(
+            id=data.get("id", str(uuid.uuid4())),
+            name=data.get("name", ""),
+            pairs=[p for p in pairs if isinstance(p, str)] if isinstance(pairs, list) else [],
+            created_at=data.get("created_at", time.time()),
+        )
+
+
+@dataclass
+class PortfolioTransaction:
+    """Portfolio transaction (trade) record."""
+
+    id: str = ""  # Unique identifier (UUID)
+    portfolio_id: str = DEFAULT_PORTFOLIO_ID  # Portfolio the transaction belongs to
+    pair: str = ""  # Trading pair, e.g., "BTC-USDT"
+    side: str = "buy"  # "buy" | "sell"
+    quantity: float = 0.0  # Amount of base asset
+    price: float = 0.0  # Execution price in quote asset
+    fee: float = 0.0  # Fee amount
+    fee_asset: str = ""  # Asset the fee was charged in
+    timestamp: float = 0.0  # Execution timestamp
+    source: str = "manual"  # "manual" | "okx_csv" | "binance_csv"
+    external_id: str = ""  # Exchange trade ID, used to skip duplicate imports
+    note: str = ""
+
+    def __post_init__(self):
+        """Initialize default values if not set."""
+        if not self.id:
+            self.id = str(uuid.uuid4())
+        if self.timestamp == 0.0:
+            self.timestamp = time.time()
+
+    @property
+    def base_asset(self) -> str:
+        """Base asset of the traded pair, e.g., "BTC"."""
+        return self.pair.split("-")[0]
+
+    @property
+    def quote_asset(self) -> str:
+        """Quote asset of the traded pair, e.g., "USDT"."""
+        parts = self.pair.split("-")
+        return parts[1] if len(parts) > 1 else ""
+
+    @staticmethod
+    def from_dict(data: dict[str, Any]) -> "PortfolioTransaction":
+        """Create PortfolioTransaction from dictionary."""
+        return PortfolioTransaction(
+            id=data.get("id", str(uuid.uuid4())),
+            portfolio_id=data.get("portfolio_id", DEFAULT_PORTFOLIO_ID),
+            pair=data.get("pair", ""),
+            side=data.get("side", "buy"),
+            quantity=data.get("quantity", 0.0),
+            price=data.get("price", 0.0),
+            fee=data.get("fee", 0.0),
+            fee_asset=data.get("fee_asset", ""),
+            timestamp=data.get("timestamp", time.time()),
+            source=data.get("source", "manual"),
+            external_id=data.get("external_id", ""),
+            note=data.get("note", ""),
+        )
+
+
+@dataclass
+class PortfolioAlert:
+    """Alert on aggregate portfolio metrics."""
+
+    id: str = ""  # Unique identifier (UUID)
+    portfolio_id: str = DEFAULT_PORTFOLIO_ID  # Portfolio the alert watches
+    # "value_above" | "value_below" | "daily_drawdown" | "position_share"
+    alert_type: str = "value_above"
+    threshold: float = 0.0  # Value in fiat currency, or percentage for drawdown/share
+    currency: str = "USD"  # Currency of value thresholds
+    asset: str = ""  # Asset for "position_share" (empty = any asset)
+    repeat_mode: str = "once"  # "once" | "repeat"
+    enabled: bool = True
+    cooldown_seconds: int = 3600  # Cooldown time (only for repeat mode)
+    last_triggered: float | None = None  # Last triggered timestamp
+    created_at: float = 0.0  # Creation timestamp
+
+    def __post_init__(self):
+        """Initialize default values if not set."""
+        if not self.id:
+            self.id = str(uuid.uuid4())
+        if self.created_at == 0.0:
+            self.created_at = time.time()
+
+    @staticmethod
+    def from_dict(data: dict[str, Any]) -> "PortfolioAlert":
+        """Create PortfolioAlert from dictionary."""
+        return PortfolioAlert(
+            id=data.get("id", str(uuid.uuid4())),
+            portfolio_id=data.get("portfolio_id", DEFAULT_PORTFOLIO_ID),
+            alert_type=data.get("alert_type", "value_above"),
+            threshold=data.get("threshold", 0.0),
+            currency=data.get("currency", "USD"),
+            asset=data.get("asset", ""),
+            repeat_mode=data.get("repeat_mode", "once"),
+            enabled=data.get("enabled", True),
+            cooldown_seconds=data.get("cooldown_seconds", 3600),
+            last_triggered=data.get("last_triggered"),
+            created_at=data.get("created_at", time.time()),
+        )
+
+
+@dataclass
+class DcaPlan:
+    """Recurring buy plan with a hypothetical accumulated position."""
+
+    id: str = ""  # Unique identifier (UUID)
+    pair: str = ""  # Trading pair, e.g., "BTC-USDT"
+    amount: float = 0.0  # Amount to invest per run, in quote asset
+    cadence: str = "weekly"  # "daily" | "weekly" | "monthly"
+    hour: int = 9  # Local hour of day to run (0-23)
+    weekday: int = 0  # Day of week for weekly plans (0 = Monday)
+    day_of_month: int = 1  # Day of month for monthly plans (1-28)
+    enabled: bool = True
+    next_run: float = 0.0  # Next scheduled run timestamp (0 = not scheduled yet)
+    runs: int = 0  # Number of completed runs
+    total_invested: float = 0.0  # Sum of amounts invested, in quote asset
+    total_quantity: float = 0.0  # Base asset accumulated at the run-time prices
+    last_run: float | None = None  # Last run timestamp
+    created_at: float = 0.0  # Creation timestamp
+
+    def __post_init__(self):
+        """Initialize default values if not set."""
+        if not self.id:
+            self.id = str(uuid.uuid4())
+        if self.created_at == 0.0:
+            self.created_at = time.time()
+
+    @property
+    def average_price(self) -> float:
+        """Average purchase price of the hypothetical position."""
+        return self.total_invested / self.total_quantity if self.total_quantity > 0 else 0.0
+
+    @staticmethod
+    def from_dict(data: dict[str, Any]) -> "DcaPlan":
+        """Create DcaPlan from dictionary."""
+        return DcaPlan(
+            id=data.get("id", str(uuid.uuid4())),
+            pair=data.get("pair", ""),
+            amount=data.get("amount", 0.0),
+            cadence=data.get("cadence", "weekly"),
+            hour=data.get("hour", 9),
+            weekday=data.get("weekday", 0),
+            day_of_month=data.get("day_of_month", 1),
+            enabled=data.get("enabled", True),
+            next_run=data.get("next_run", 0.0),
+            runs=data.get("runs", 0),
+            total_invested=data.get("total_invested", 0.0),
+            total_quantity=data.get("total_quantity", 0.0),
+            last_run=data.get("last_run"),
+            created_at=data.get("created_at", time.time()),
+        )
+
+
+# Per-pair card update intervals offered to users, in seconds (0 = realtime)
+PAIR_UPDATE_INTERVALS = (0, 1, 5, 30)
+
+
 @dataclass
 class AppSettings:
     """Application settings."""
 
     # Current version
     version: str = "2.3.0"  # Bump version
-    data_source: str = "OKX"  # "OKX" or "Binance"
+    data_source: str = "OKX"  # "OKX", "Binance" or "Simulated"
 
     # Basic settings
     # Basic settings
@@ -131,25 +552,108 @@ class AppSettings:
     auto_scroll: bool = False  # Auto-cycle pages
     scroll_interval: int = 30  # Auto-scroll interval in seconds
     minimalist_view: bool = False  # Minimalist view mode (hide chrome when not hovered)
+    update_interval_ms: int = 500  # Cards are refreshed in batches at this interval (250-2000)
+    pair_update_intervals: dict = field(default_factory=dict)  # Pair -> seconds (absent = realtime)
+    pair_aliases: dict = field(default_factory=dict)  # Pair -> display name, e.g. "Bitcoin"
+    # Pair -> {"decimals", "show_volume", "invert", "color_threshold"}, non-defaults only
+    pair_display_prefs: dict = field(default_factory=dict)
+    # Pair -> [{"direction", "threshold", "color", "flash"}], see core.highlight_rules
+    pair_highlight_rules: dict = field(default_factory=dict)
+    pinned_pairs: list = field(default_factory=list)  # Pairs shown in the system tray, in order
+    mini_ticker_enabled: bool = False  # Show the always-on-top mini ticker window
+    mini_ticker_pair: str = ""  # Pair streamed to the mini ticker (empty = first tray pair)
+    mini_ticker_x: int = -1  # Mini ticker position (-1 = next to the main window)
+    mini_ticker_y: int = -1
+    taskbar_badge: bool = True  # Show the first tray pair's trend on the taskbar button (Windows)
+    menu_bar_price: bool = True  # Show the first tray pair's price in the menu bar (macOS)
+    hotkeys_enabled: bool = False  # Register global hotkeys
+    hotkeys: dict = field(default_factory=dict)  # Action -> shortcut (absent = default, "" = off)
+    sparkline_max_points: int = 1440  # Minutes of chart history kept per pair
+    candle_max_count: int = 300  # Candles kept per pair and interval
+    history_budget_mb: int = 64  # Memory budget shared by all history buffers
     crypto_pairs: list = field(default_factory=lambda: ["BTC-USDT", "ETH-USDT"])
     proxy: ProxyConfig = field(default_factory=ProxyConfig)
     window_x: int = 100
     window_y: int = 100
     always_on_top: bool = False
     language: str = "auto"  # "auto", "en_US", "zh_CN", etc.
-    price_change_basis: str = "24h_rolling"  # "24h_rolling" or "utc_0"
+    # "24h_rolling", or the day starting at midnight "utc_0", "utc_8" or "local"
+    price_change_basis: str = "24h_rolling"
+    fiat_currency: str = "USD"  # Display/valuation currency: "USD", "EUR", "CNY", "JPY"
+    # Separators in notifications, tray and exports: "plain", "auto" (per language),
+    # "comma" (1,234.5), "dot" (1.234,5) or "space" (1 234,5)
+    number_format: str = "plain"
 
     # V2.0.0 features
     compact_mode: CompactModeConfig = field(default_factory=CompactModeConfig)
 
     # V2.1.0 features
     websocket: WebSocketConfig = field(default_factory=WebSocketConfig)
+    okx_api: OkxApiConfig = field(default_factory=OkxApiConfig)
+    local_api: LocalApiConfig = field(default_factory=LocalApiConfig)
+    telegram: TelegramConfig = field(default_factory=TelegramConfig)
+    matrix: MatrixConfig = field(default_factory=MatrixConfig)
+    wecom: WeComConfig = field(default_factory=WeComConfig)
+    dingtalk: DingTalkConfig = field(default_factory=DingTalkConfig)
+    feishu: FeishuConfig = field(default_factory=FeishuConfig)
+    gotify: GotifyConfig = field(default_factory=GotifyConfig)
+    xmpp: XmppConfig = field(default_factory=XmppConfig)
+    email: EmailConfig = field(default_factory=EmailConfig)
+    digest: DigestConfig = field(default_factory=DigestConfig)
 
     # V2.2.0 features
     alerts: list[PriceAlert] = field(default_factory=list)
     # V2.2.0 features
     alerts: list[PriceAlert] = field(default_factory=list)
+    muted_pairs: dict = field(default_factory=dict)  # Pair -> timestamp its alerts are muted until
     sound_mode: str = "system"  # "off", "system", "chime"
+    anomaly_alerts: bool = False  # Notify on price spikes far outside recent ticks
+    anomaly_threshold: float = 6.0  # Standard deviations from the short-term mean
+    fear_greed_alerts: bool = False  # Notify when the Fear & Greed Index turns extreme
+    fear_greed_low: int = 20  # Extreme fear at or below
+    fear_greed_high: int = 80  # Extreme greed at or above
+    fee_alerts: bool = False  # Notify when network fees drop below the targets
+    btc_fee_target: float = 5.0  # sat/vB
+    eth_gas_target: float = 2.0  # gwei
+    etherscan_api_key: str = ""  # Optional; a public RPC node is used without it
+    listing_alerts: str = "watched"  # New exchange listings: "off", "watched", "all"
+    delisting_alerts: bool = True  # Warn when a watched pair is suspended or delisted
+    funding_alerts: bool = False  # Notify when the funding rate spread between venues widens
+    funding_spread_threshold: float = 0.05  # Percent per 8 hours
+    news_alerts: bool = False  # Notify on news about the monitored assets
+    news_feeds: list = field(default_factory=list)  # RSS/Atom URLs besides the built-in feeds
+    rpc_endpoints: dict = field(default_factory=dict)  # Network -> JSON-RPC URL overrides
+    cert_pinning: bool = False  # Check exchange TLS keys against the pinned ones on start
+    cert_pins: dict = field(default_factory=dict)  # Host -> accepted base64 SPKI SHA-256 pins
+    clock_drift_threshold: float = 2.0  # Seconds of system clock drift that warn; 0 turns off
+    crash_reports: bool = False  # Keep anonymous crash reports and offer to send them
+    # Daily volatility in percent of the simulated data source's random walk
+    simulation_default_volatility: float = 5.0
+    simulation_volatility: dict = field(default_factory=dict)  # Pair -> volatility override
+    unlock_alerts: bool = False  # Notify ahead of large token unlocks of watched assets
+    unlock_lead_hours: int = 24  # How long before the unlock to notify
+    unlock_min_supply_pct: float = 1.0  # Minimum unlock size, percent of circulating supply
+    macro_alerts: bool = False  # Remind ahead of high-impact economic releases (FOMC, CPI)
+    macro_lead_minutes: int = 30
+    stablecoin_alerts: bool = False  # Notify on large USDT/USDC mints and burns
+    stablecoin_threshold: float = 100.0  # Millions of USD
+    # Whale addresses: {"chain": "BTC"|"ETH", "address", "label", "threshold" (in coins)}
+    watched_addresses: list = field(default_factory=list)
+    # Candle intervals built from ticks for sources without klines (e.g. DEX pairs)
+    tick_candle_intervals: list = field(
+        default_factory=lambda: ["1m", "5m", "15m", "30m", "1h", "4h"]
+    )
+
+    # Portfolio
+    portfolios: list[Portfolio] = field(default_factory=lambda: [_default_portfolio()])
+    active_portfolio: str = DEFAULT_PORTFOLIO_ID
+    transactions: list[PortfolioTransaction] = field(default_factory=list)
+    portfolio_alerts: list[PortfolioAlert] = field(default_factory=list)
+    dca_plans: list[DcaPlan] = field(default_factory=list)
+
+    # Watchlist groups; pairs of subscribed groups are merged into crypto_pairs
+    watchlists: list[Watchlist] = field(default_factory=list)
+    active_watchlists: list = field(default_factory=list)  # IDs of subscribed groups
 
 
 class SettingsManager:
@@ -168,7 +672,19 @@ class SettingsManager:
 
         self.config_dir = config_dir
         self.config_file = config_dir / "settings.json"
+        # No settings saved yet: a fresh install
+        self.first_run = not self.config_file.exists()
         self.settings = AppSettings()
+        # Proxy snapshot read by background threads; subscribe for changes
+        self.proxy_store: ConfigStore[ProxyConfig] = ConfigStore(ProxyConfig())
+        # Settings file content as last loaded or saved, to tell external edits from our own
+        self._disk_snapshot: str | None = None
+        # Keychain holding the secret settings, which are left empty in the file
+        self._secret_store = get_secret_store()
+        # Cipher of the master password, None while the file is stored in plain text
+        self._cipher: SettingsCipher | None = None
+        # The file is encrypted and the master password hasn't been entered yet
+        self.locked = False
 
         # Ensure config directory exists
         self.config_dir.mkdir(parents=True, exist_ok=True)
@@ -186,7 +702,15 @@ class SettingsManager:
         Returns:
             Loaded settings
         """
-        if auto_migrate:
+        if self._cipher is None and self._file_is_encrypted():
+            # Nothing can be read or saved until unlock() is given the password
+            logger.info("Settings are encrypted, waiting for the master password")
+            self.locked = True
+            load_language(self.settings.language)
+            return self.settings
+
+        # Encrypted files are always written by this version, so never need migrating
+        if auto_migrate and self._cipher is None:
             try:
                 migrated, message, backup_path = self.migration_manager.migrate_if_needed()
                 if migrated:
@@ -199,8 +723,11 @@ class SettingsManager:
 
         if self.config_file.exists():
             try:
-                with open(self.config_file, encoding="utf-8") as f:
-                    data = json.load(f)
+                text = self.config_file.read_text(encoding="utf-8")
+                plaintext = text
+                if self._cipher is not None and is_encrypted(text):
+                    plaintext = self._cipher.decrypt(text)
+                data = json.loads(plaintext)
 
                 # Parse proxy config
                 proxy_data = data.pop("proxy", {})
@@ -220,12 +747,122 @@ class SettingsManager:
                     websocket_data = {}
                 websocket_config = WebSocketConfig(**websocket_data)
 
+                # Parse OKX account API config
+                okx_api_data = data.pop("okx_api", {})
+                if not isinstance(okx_api_data, dict):
+                    okx_api_data = {}
+                okx_api_config = OkxApiConfig(**okx_api_data)
+
+                # Parse local API config
+                local_api_data = data.pop("local_api", {})
+                if not isinstance(local_api_data, dict):
+                    local_api_data = {}
+                local_api_config = LocalApiConfig(**local_api_data)
+
+                # Parse Telegram config
+                telegram_data = data.pop("telegram", {})
+                if not isinstance(telegram_data, dict):
+                    telegram_data = {}
+                telegram_config = TelegramConfig(**telegram_data)
+
+                # Parse Matrix config
+                matrix_data = data.pop("matrix", {})
+                if not isinstance(matrix_data, dict):
+                    matrix_data = {}
+                matrix_config = MatrixConfig(**matrix_data)
+
+                # Parse WeChat Work config
+                wecom_data = data.pop("wecom", {})
+                if not isinstance(wecom_data, dict):
+                    wecom_data = {}
+                wecom_config = WeComConfig(**wecom_data)
+
+                # Parse DingTalk config
+                dingtalk_data = data.pop("dingtalk", {})
+                if not isinstance(dingtalk_data, dict):
+                    dingtalk_data = {}
+                dingtalk_config = DingTalkConfig(**dingtalk_data)
+
+                # Parse Feishu config
+                feishu_data = data.pop("feishu", {})
+                if not isinstance(feishu_data, dict):
+                    feishu_data = {}
+                feishu_config = FeishuConfig(**feishu_data)
+
+                # Parse Gotify config
+                gotify_data = data.pop("gotify", {})
+                if not isinstance(gotify_data, dict):
+                    gotify_data = {}
+                gotify_config = GotifyConfig(**gotify_data)
+
+                # Parse XMPP config
+                xmpp_data = data.pop("xmpp", {})
+                if not isinstance(xmpp_data, dict):
+                    xmpp_data = {}
+                xmpp_config = XmppConfig(**xmpp_data)
+
+                # Parse Email config
+                email_data = data.pop("email", {})
+                if not isinstance(email_data, dict):
+                    email_data = {}
+                email_config = EmailConfig(**email_data)
+
+                # Parse price digest config
+                digest_data = data.pop("digest", {})
+                if not isinstance(digest_data, dict):
+                    digest_data = {}
+                digest_config = DigestConfig(**digest_data)
+
                 # Parse alerts config (V2.2.0+)
                 alerts_data = data.pop("alerts", [])
                 if not isinstance(alerts_data, list):
                     alerts_data = []
                 alerts_list = [PriceAlert.from_dict(a) for a in alerts_data if isinstance(a, dict)]
 
+                # Parse portfolios
+                portfolios_data = data.pop("portfolios", [])
+                if not isinstance(portfolios_data, list):
+                    portfolios_data = []
+                portfolios_list = [
+                    Portfolio.from_dict(p) for p in portfolios_data if isinstance(p, dict)
+                ] or [_default_portfolio()]
+
+                # Parse portfolio transactions
+                transactions_data = data.pop("transactions", [])
+                if not isinstance(transactions_data, list):
+                    transactions_data = []
+                transactions_list = [
+                    PortfolioTransaction.from_dict(t)
+                    for t in transactions_data
+                    if isinstance(t, dict)
+                ]
+
+                # Parse portfolio alerts
+                portfolio_alerts_data = data.pop("portfolio_alerts", [])
+                if not isinstance(portfolio_alerts_data, list):
+                    portfolio_alerts_data = []
+                portfolio_alerts_list = [
+                    PortfolioAlert.from_dict(a)
+                    for a in portfolio_alerts_data
+                    if isinstance(a, dict)
+                ]
+
+                # Parse DCA plans
+                dca_plans_data = data.pop("dca_plans", [])
+                if not isinstance(dca_plans_data, list):
+                    dca_plans_data = []
+                dca_plans_list = [
+                    DcaPlan.from_dict(p) for p in dca_plans_data if isinstance(p, dict)
+                ]
+
+                # Parse watchlist groups
+                watchlists_data = data.pop("watchlists", [])
+                if not isinstance(watchlists_data, list):
+                    watchlists_data = []
+                watchlists_list = [
+                    Watchlist.from_dict(w) for w in watchlists_data if isinstance(w, dict)
+                ]
+
                 # Only keep recognized fields in data
                 recognized_fields = {
                     "version",
@@ -242,6 +879,23 @@ class SettingsManager:
                     "crypto_pairs",
                     "display_limit",
                     "minimalist_view",
+                    "update_interval_ms",
+                    "pair_update_intervals",
+                    "pair_aliases",
+                    "pair_display_prefs",
+                    "pair_highlight_rules",
+                    "pinned_pairs",
+                    "mini_ticker_enabled",
+                    "mini_ticker_pair",
+                    "mini_ticker_x",
+                    "mini_ticker_y",
+                    "taskbar_badge",
+                    "menu_bar_price",
+                    "hotkeys_enabled",
+                    "hotkeys",
+                    "sparkline_max_points",
+                    "candle_max_count",
+                    "history_budget_mb",
                     "auto_scroll",
                     "scroll_interval",
                     "window_x",
@@ -257,6 +911,42 @@ class SettingsManager:
                     "language",
                     "price_change_basis",
                     "sound_mode",
+                    "fiat_currency",
+                    "number_format",
+                    "active_portfolio",
+                    "anomaly_alerts",
+                    "anomaly_threshold",
+                    "tick_candle_intervals",
+                    "fear_greed_alerts",
+                    "fear_greed_low",
+                    "fear_greed_high",
+                    "fee_alerts",
+                    "btc_fee_target",
+                    "eth_gas_target",
+                    "etherscan_api_key",
+                    "listing_alerts",
+                    "delisting_alerts",
+                    "funding_alerts",
+                    "funding_spread_threshold",
+                    "news_alerts",
+                    "news_feeds",
+                    "rpc_endpoints",
+                    "cert_pinning",
+                    "cert_pins",
+                    "clock_drift_threshold",
+                    "muted_pairs",
+                    "crash_reports",
+                    "simulation_default_volatility",
+                    "simulation_volatility",
+                    "unlock_alerts",
+                    "unlock_lead_hours",
+                    "unlock_min_supply_pct",
+                    "macro_alerts",
+                    "macro_lead_minutes",
+                    "stablecoin_alerts",
+                    "stablecoin_threshold",
+                    "watched_addresses",
+                    "active_watchlists",
                 }
                 filtered_data = {k: v for k, v in data.items() if k in recognized_fields}
 
@@ -265,31 +955,181 @@ class SettingsManager:
                     proxy=proxy_config,
                     compact_mode=compact_mode_config,
                     websocket=websocket_config,
+                    okx_api=okx_api_config,
+                    local_api=local_api_config,
+                    telegram=telegram_config,
+                    matrix=matrix_config,
+                    wecom=wecom_config,
+                    dingtalk=dingtalk_config,
+                    feishu=feishu_config,
+                    gotify=gotify_config,
+                    xmpp=xmpp_config,
+                    email=email_config,
+                    digest=digest_config,
                     alerts=alerts_list,
+                    portfolios=portfolios_list,
+                    transactions=transactions_list,
+                    portfolio_alerts=portfolio_alerts_list,
+                    dca_plans=dca_plans_list,
+                    watchlists=watchlists_list,
                     **filtered_data,
                 )
-            except (json.JSONDecodeError, TypeError, KeyError) as e:
+                self._disk_snapshot = text
+            except (json.JSONDecodeError, TypeError, KeyError, DecryptionError) as e:
                 logger.error(f"Error loading settings: {e}")
                 logger.warning("   Resetting to default settings")
                 self.settings = AppSettings()
 
+        if self._read_secrets():
+            # Secrets of older files are moved to the keychain
+            logger.info("Moving secrets from the settings file to the keychain")
+            self.save()
+
+        self._publish_proxy()
+        self._publish_secrets()
+
         # Initialize language loader
         load_language(self.settings.language)
 
         return self.settings
 
     def save(self) -> None:
-        """Save settings to file."""
+        """Save settings to file, encrypted if a master password is set."""
+        if self.locked:
+            # Writing the defaults would replace the encrypted settings
+            logger.warning("Settings are locked, not saving")
+            return
+        self._publish_secrets()
         data = asdict(self.settings)
+        self._strip_secrets(data)
+        text = json.dumps(data, indent=2, ensure_ascii=False)
+        if self._cipher is not None:
+            text = self._cipher.encrypt(text)
 
         with open(self.config_file, "w", encoding="utf-8") as f:
-            json.dump(data, f, indent=2, ensure_ascii=False)
+            f.write(text)
+        self._disk_snapshot = text
+
+    def _read_secrets(self) -> bool:
+        """
+        Fill the empty secret settings from the keychain.
+
+        Returns:
+            True if the loaded file holds secrets that belong in the keychain
+        """
+        in_file = False
+        for section, name in SECRET_FIELDS:
+            target = getattr(self.settings, section) if section else self.settings
+            if getattr(target, name):
+                in_file = True
+                continue
+            value = self._secret_store.get(secret_name(section, name))
+            if value:
+                setattr(target, name, value)
+        return in_file and self._secret_store.is_available
+
+    def _publish_secrets(self) -> None:
+        """Hand the secret values to the log redaction, so they're masked wherever they appear."""
+        values = []
+        for section, name in SECRET_FIELDS:
+            target = getattr(self.settings, section) if section else self.settings
+            values.append(getattr(target, name))
+        set_known_secrets(values)
+
+    def _strip_secrets(self, data: dict) -> None:
+        """Store the secrets of serialized settings in the keychain and blank them in data."""
+        for section, name in SECRET_FIELDS:
+            container = data[section] if section else data
+            if self._secret_store.set(secret_name(section, name), container.get(name, "")):
+                container[name] = ""
+
+    def _file_is_encrypted(self) -> bool:
+        try:
+            return is_encrypted(self.config_file.read_text(encoding="utf-8"))
+        except OSError:
+            return False
+
+    @property
+    def has_master_password(self) -> bool:
+        return self._cipher is not None
+
+    def unlock(self, password: str) -> bool:
+        """
+        Decrypt and load the settings with the master password.
+
+        Returns:
+            True if the password was right
+        """
+        try:
+            text = self.config_file.read_text(encoding="utf-8")
+            cipher = SettingsCipher.unlock(password, text)
+        except (OSError, RuntimeError, DecryptionError) as e:
+            logger.warning(f"Failed to unlock settings: {e}")
+            return False
+        self._cipher = cipher
+        self.locked = False
+        self.load(auto_migrate=False)
+        logger.info("Settings unlocked")
+        return True
+
+    def set_master_password(self, password: str) -> None:
+        """
+        Encrypt the settings file with a new master password; empty to store it in plain text.
+
+        Raises:
+            RuntimeError: If encryption isn't available
+        """
+        self._cipher = SettingsCipher(password) if password else None
+        self.save()
+        logger.info("Master password set" if password else "Master password removed")
+
+    def reload_if_changed(self) -> bool:
+        """
+        Reload the settings file if it was edited outside the app.
+
+        A file that is not valid JSON (e.g. saved halfway) is ignored, keeping
+        the current settings until it is fixed.
+
+        Returns:
+            True if changed settings were loaded
+        """
+        try:
+            text = self.config_file.read_text(encoding="utf-8")
+        except OSError as e:
+            logger.warning(f"Failed to read settings file: {e}")
+            return False
+        if self.locked or text == self._disk_snapshot:
+            return False
+        try:
+            json.loads(text)
+        except json.JSONDecodeError as e:
+            logger.warning(f"Ignoring invalid settings file: {e}")
+            return False
+
+        previous, snapshot = self.settings, self._disk_snapshot
+        self.load(auto_migrate=False)
+        if self._disk_snapshot == snapshot:
+            # load() fell back to defaults; keep what we had instead
+            logger.warning("Ignoring settings file that failed to parse")
+            self.settings = previous
+            self._publish_proxy()
+            load_language(self.settings.language)
+            return False
+        self._apply_proxy_env()
+        logger.info("Settings reloaded from file")
+        return True
+
+    def update_ws_connections(self, connections: dict[str, WsConnectionTuning]) -> None:
+        """Update the transport options of the exchange WebSocket connections."""
+        self.settings.websocket.connections = dict(connections)
+        self.save()
 
     def update_proxy(self, proxy: ProxyConfig) -> None:
         """Update proxy configuration."""
         self.settings.proxy = proxy
         self.save()
         self._apply_proxy_env()
+        self._publish_proxy()
 
     def update_opacity(self, opacity: int) -> None:
         """Update background opacity setting."""
@@ -308,8 +1148,7 @@ class SettingsManager:
 
     def add_pair(self, pair: str) -> bool:
         """Add a new crypto pair. Returns True if added."""
-        if not pair.lower().startswith("chain:"):
-            pair = pair.upper()
+        pair = normalize_pair(pair)
 
         if pair not in self.settings.crypto_pairs:
             self.settings.crypto_pairs.append(pair)
@@ -317,18 +1156,40 @@ class SettingsManager:
             return True
         return False
 
+    def add_pairs(self, pairs: list[str]) -> list[str]:
+        """Add several crypto pairs, saving once. Returns the pairs added."""
+        added = []
+        for pair in pairs:
+            pair = normalize_pair(pair)
+            if pair not in self.settings.crypto_pairs:
+                self.settings.crypto_pairs.append(pair)
+                added.append(pair)
+        if added:
+            self.save()
+        return added
+
     def remove_pair(self, pair: str) -> bool:
         """Remove a crypto pair. Returns True if removed."""
-        # Handle case sensitivity for chain pairs
-        if not pair.lower().startswith("chain:"):
-            pair = pair.upper()
+        pair = normalize_pair(pair)
 
         if pair in self.settings.crypto_pairs:
             self.settings.crypto_pairs.remove(pair)
+            self._clear_pair_preferences(pair)
             self.save()
             return True
         return False
 
+    def _clear_pair_preferences(self, pair: str) -> None:
+        """Forget the per-pair preferences of a pair that is no longer monitored."""
+        self.settings.pair_update_intervals.pop(pair, None)
+        self.settings.pair_aliases.pop(pair, None)
+        self.settings.pair_display_prefs.pop(pair, None)
+        self.settings.pair_highlight_rules.pop(pair, None)
+        if pair in self.settings.pinned_pairs:
+            self.settings.pinned_pairs.remove(pair)
+        if self.settings.mini_ticker_pair == pair:
+            self.settings.mini_ticker_pair = ""
+
     def update_theme(self, theme_mode: str) -> None:
         """Update theme mode."""
         self.settings.theme_mode = theme_mode
@@ -378,12 +1239,173 @@ class SettingsManager:
         self.settings.minimalist_view = enabled
         self.save()
 
+    def update_pair_update_interval(self, pair: str, seconds: int) -> None:
+        """Set how often a pair's card updates; 0 for realtime."""
+        if seconds:
+            self.settings.pair_update_intervals[pair] = seconds
+        else:
+            self.settings.pair_update_intervals.pop(pair, None)
+        self.save()
+
+    def update_pair_alias(self, pair: str, alias: str) -> None:
+        """Set the name a pair is displayed with; empty for its default name."""
+        alias = alias.strip()
+        if alias:
+            self.settings.pair_aliases[pair] = alias
+        else:
+            self.settings.pair_aliases.pop(pair, None)
+        self.save()
+
+    def update_pair_display_prefs(self, pair: str, prefs: dict) -> None:
+        """Store a pair's display preferences; empty for the defaults."""
+        if prefs:
+            self.settings.pair_display_prefs[pair] = prefs
+        else:
+            self.settings.pair_display_prefs.pop(pair, None)
+        self.save()
+
+    def update_pair_highlight_rules(self, pair: str, rules: list[dict]) -> None:
+        """Store a pair's highlight rules; empty to remove them."""
+        if rules:
+            self.settings.pair_highlight_rules[pair] = rules
+        else:
+            self.settings.pair_highlight_rules.pop(pair, None)
+        self.save()
+
+    def update_pair_pinned(self, pair: str, pinned: bool) -> bool:
+        """Pin a pair to the system tray or unpin it. Returns True if changed."""
+        if (pair in self.settings.pinned_pairs) == pinned:
+            return False
+        if pinned:
+            self.settings.pinned_pairs.append(pair)
+        else:
+            self.settings.pinned_pairs.remove(pair)
+        self.save()
+        return True
+
+    def update_mini_ticker(self, enabled: bool, x: int, y: int) -> None:
+        """Update whether the mini ticker is shown and where."""
+        self.settings.mini_ticker_enabled = enabled
+        self.settings.mini_ticker_x = x
+        self.settings.mini_ticker_y = y
+        self.save()
+
+    def update_taskbar_badge(self, enabled: bool) -> None:
+        """Update whether the taskbar button shows the first tray pair's trend."""
+        self.settings.taskbar_badge = enabled
+        self.save()
+
+    def update_menu_bar_price(self, enabled: bool) -> None:
+        """Update whether the menu bar shows the first tray pair's price."""
+        self.settings.menu_bar_price = enabled
+        self.save()
+
+    def update_mini_ticker_pair(self, pair: str) -> None:
+        """Set the pair streamed to the mini ticker; empty for the first tray pair."""
+        self.settings.mini_ticker_pair = pair
+        self.save()
+
+    def update_hotkeys(self, enabled: bool, hotkeys: dict) -> None:
+        """Update whether global hotkeys are registered and their shortcuts."""
+        self.settings.hotkeys_enabled = enabled
+        self.settings.hotkeys = hotkeys
+        self.save()
+
+    def update_history_limits(self, sparkline_points: int, candles: int, budget_mb: int) -> None:
+        """Update the per-pair caps and the memory budget of history buffers."""
+        self.settings.sparkline_max_points = sparkline_points
+        self.settings.candle_max_count = candles
+        self.settings.history_budget_mb = budget_mb
+        self.save()
+
+    def update_update_interval(self, interval_ms: int) -> None:
+        """Update the batched card refresh interval."""
+        if 250 <= interval_ms <= 2000:
+            self.settings.update_interval_ms = interval_ms
+            self.save()
+
     def update_language(self, language: str) -> None:
         """Update language setting."""
         self.settings.language = language
         load_language(language)
         self.save()
 
+    def update_okx_api(self, config: OkxApiConfig) -> None:
+        """Update OKX account API credentials."""
+        self.settings.okx_api = config
+        self.save()
+
+    def update_local_api(self, config: LocalApiConfig) -> None:
+        """Update the local HTTP API settings."""
+        self.settings.local_api = config
+        self.save()
+
+    def update_telegram(self, config: TelegramConfig) -> None:
+        """Update the Telegram bot settings."""
+        self.settings.telegram = config
+        self.save()
+
+    def update_matrix(self, config: MatrixConfig) -> None:
+        """Update the Matrix notification settings."""
+        self.settings.matrix = config
+        self.save()
+
+    def update_wecom(self, config: WeComConfig) -> None:
+        """Update the WeChat Work robot settings."""
+        self.settings.wecom = config
+        self.save()
+
+    def update_dingtalk(self, config: DingTalkConfig) -> None:
+        """Update the DingTalk robot settings."""
+        self.settings.dingtalk = config
+        self.save()
+
+    def update_feishu(self, config: FeishuConfig) -> None:
+        """Update the Feishu bot settings."""
+        self.settings.feishu = config
+        self.save()
+
+    def update_gotify(self, config: GotifyConfig) -> None:
+        """Update the Gotify server settings."""
+        self.settings.gotify = config
+        self.save()
+
+    def update_xmpp(self, config: XmppConfig) -> None:
+        """Update the XMPP account settings."""
+        self.settings.xmpp = config
+        self.save()
+
+    def update_email(self, config: EmailConfig) -> None:
+        """Update email notification settings."""
+        self.settings.email = config
+        self.save()
+
+    def update_digest(self, config: DigestConfig) -> None:
+        """Update price digest settings."""
+        self.settings.digest = config
+        self.save()
+
+    def update_cert_pinning(self, enabled: bool) -> None:
+        """Update whether exchange certificates are pinned."""
+        self.settings.cert_pinning = enabled
+        self.save()
+
+    def update_crash_reports(self, enabled: bool) -> None:
+        """Update whether anonymous crash reports are kept and offered for sending."""
+        self.settings.crash_reports = enabled
+        self.save()
+
+    def update_cert_pins(self, pins: dict[str, list[str]]) -> None:
+        """Replace the pinned certificate keys."""
+        self.settings.cert_pins = pins
+        self.save()
+
+    def update_simulation(self, default_volatility: float, overrides: dict[str, float]) -> None:
+        """Update the volatilities of the simulated data source."""
+        self.settings.simulation_default_volatility = default_volatility
+        self.settings.simulation_volatility = overrides
+        self.save()
+
     def update_data_source(self, source: str) -> None:
         """Update data source setting."""
         self.settings.data_source = source
@@ -394,6 +1416,96 @@ class SettingsManager:
         self.settings.price_change_basis = basis
         self.save()
 
+    def update_fiat_currency(self, currency: str) -> None:
+        """Update display/valuation fiat currency."""
+        self.settings.fiat_currency = currency.upper()
+        self.save()
+
+    def update_number_format(self, number_format: str) -> None:
+        """Update the number format of notifications, tray and exports."""
+        self.settings.number_format = number_format
+        self.save()
+
+    def update_anomaly_detection(self, enabled: bool, threshold: float) -> None:
+        """Update price anomaly (spike) notification settings."""
+        self.settings.anomaly_alerts = enabled
+        self.settings.anomaly_threshold = threshold
+        self.save()
+
+    def update_fear_greed_alerts(self, enabled: bool, low: int, high: int) -> None:
+        """Update Fear & Greed Index extreme value notification settings."""
+        self.settings.fear_greed_alerts = enabled
+        self.settings.fear_greed_low = low
+        self.settings.fear_greed_high = high
+        self.save()
+
+    def update_fee_alerts(self, enabled: bool, btc_target: float, eth_target: float) -> None:
+        """Update low network fee notification settings."""
+        self.settings.fee_alerts = enabled
+        self.settings.btc_fee_target = btc_target
+        self.settings.eth_gas_target = eth_target
+        self.save()
+
+    def update_listing_alerts(self, mode: str) -> None:
+        """Update which new exchange listings trigger notifications."""
+        self.settings.listing_alerts = mode
+        self.save()
+
+    def update_delisting_alerts(self, enabled: bool) -> None:
+        """Update whether suspended or delisted watched pairs trigger warnings."""
+        self.settings.delisting_alerts = enabled
+        self.save()
+
+    def update_funding_alerts(self, enabled: bool, threshold: float) -> None:
+        """Update funding rate arbitrage notification settings."""
+        self.settings.funding_alerts = enabled
+        self.settings.funding_spread_threshold = threshold
+        self.save()
+
+    def update_unlock_alerts(self, enabled: bool, lead_hours: int, min_supply_pct: float) -> None:
+        """Update token unlock notification settings."""
+        self.settings.unlock_alerts = enabled
+        self.settings.unlock_lead_hours = lead_hours
+        self.settings.unlock_min_supply_pct = min_supply_pct
+        self.save()
+
+    def update_macro_alerts(self, enabled: bool, lead_minutes: int) -> None:
+        """Update economic calendar reminder settings."""
+        self.settings.macro_alerts = enabled
+        self.settings.macro_lead_minutes = lead_minutes
+        self.save()
+
+    def update_stablecoin_alerts(self, enabled: bool, threshold: float) -> None:
+        """Update stablecoin mint/burn notification settings."""
+        self.settings.stablecoin_alerts = enabled
+        self.settings.stablecoin_threshold = threshold
+        self.save()
+
+    def update_watched_addresses(self, addresses: list[dict]) -> None:
+        """Update the watched whale addresses."""
+        self.settings.watched_addresses = list(addresses)
+        self.save()
+
+    def update_news_alerts(self, enabled: bool) -> None:
+        """Update whether news about the monitored assets triggers notifications."""
+        self.settings.news_alerts = enabled
+        self.save()
+
+    def update_news_feeds(self, feeds: list[str]) -> None:
+        """Update the user-supplied news feed URLs."""
+        self.settings.news_feeds = list(feeds)
+        self.save()
+
+    def update_rpc_endpoints(self, endpoints: dict[str, str]) -> None:
+        """Update the JSON-RPC endpoints of on-chain sources; empty URLs use the defaults."""
+        self.settings.rpc_endpoints = {k: v for k, v in endpoints.items() if v}
+        self.save()
+
+    def update_tick_candle_intervals(self, intervals: list[str]) -> None:
+        """Update the candle intervals aggregated from ticks."""
+        self.settings.tick_candle_intervals = list(intervals)
+        self.save()
+
     # Alert management methods
     def add_alert(self, alert: PriceAlert) -> None:
         """Add a new price alert."""
@@ -418,6 +1530,11 @@ class SettingsManager:
                 return True
         return False
 
+    def update_muted_pairs(self, muted_pairs: dict[str, float]) -> None:
+        """Replace the pairs whose alerts are muted, with the time each mute ends."""
+        self.settings.muted_pairs = muted_pairs
+        self.save()
+
     def get_alerts_for_pair(self, pair: str) -> list[PriceAlert]:
         """Get all alerts for a specific trading pair."""
         return [a for a in self.settings.alerts if a.pair == pair]
@@ -426,6 +1543,239 @@ class SettingsManager:
         """Get all enabled alerts."""
         return [a for a in self.settings.alerts if a.enabled]
 
+    # Portfolio transaction methods
+    def add_transactions(self, transactions: list[PortfolioTransaction]) -> int:
+        """
+        Add portfolio transactions, skipping exchange trades already in the same portfolio.
+
+        Returns:
+            Number of transactions actually added
+        """
+        known_ids = {
+            (t.portfolio_id, t.source, t.external_id)
+            for t in self.settings.transactions
+            if t.external_id
+        }
+        added = 0
+        for tx in transactions:
+            key = (tx.portfolio_id, tx.source, tx.external_id)
+            if tx.external_id and key in known_ids:
+                continue
+            self.settings.transactions.append(tx)
+            known_ids.add(key)
+            added += 1
+
+        if added:
+            self.settings.transactions.sort(key=lambda t: t.timestamp)
+            self.save()
+        return added
+
+    def remove_transaction(self, transaction_id: str) -> bool:
+        """Remove a portfolio transaction by ID. Returns True if removed."""
+        for i, tx in enumerate(self.settings.transactions):
+            if tx.id == transaction_id:
+                self.settings.transactions.pop(i)
+                self.save()
+                return True
+        return False
+
+    def get_transactions_for_pair(self, pair: str) -> list[PortfolioTransaction]:
+        """Get all transactions for a specific trading pair."""
+        return [t for t in self.settings.transactions if t.pair == pair]
+
+    # Portfolio alert methods
+    def add_portfolio_alert(self, alert: PortfolioAlert) -> None:
+        """Add a new portfolio alert."""
+        self.settings.portfolio_alerts.append(alert)
+        self.save()
+
+    def remove_portfolio_alert(self, alert_id: str) -> bool:
+        """Remove a portfolio alert by ID. Returns True if removed."""
+        for i, alert in enumerate(self.settings.portfolio_alerts):
+            if alert.id == alert_id:
+                self.settings.portfolio_alerts.pop(i)
+                self.save()
+                return True
+        return False
+
+    def update_portfolio_alert(self, alert: PortfolioAlert) -> bool:
+        """Update an existing portfolio alert. Returns True if updated."""
+        for i, existing in enumerate(self.settings.portfolio_alerts):
+            if existing.id == alert.id:
+                self.settings.portfolio_alerts[i] = alert
+                self.save()
+                return True
+        return False
+
+    # DCA plan methods
+    def add_dca_plan(self, plan: DcaPlan) -> None:
+        """Add a new DCA plan."""
+        self.settings.dca_plans.append(plan)
+        self.save()
+
+    def remove_dca_plan(self, plan_id: str) -> bool:
+        """Remove a DCA plan by ID. Returns True if removed."""
+        for i, plan in enumerate(self.settings.dca_plans):
+            if plan.id == plan_id:
+                self.settings.dca_plans.pop(i)
+                self.save()
+                return True
+        return False
+
+    def update_dca_plan(self, plan: DcaPlan) -> bool:
+        """Update an existing DCA plan. Returns True if updated."""
+        for i, existing in enumerate(self.settings.dca_plans):
+            if existing.id == plan.id:
+                self.settings.dca_plans[i] = plan
+                self.save()
+                return True
+        return False
+
+    # Portfolio methods
+    def get_portfolio(self, portfolio_id: str) -> Portfolio | None:
+        """Get a portfolio by ID."""
+        for portfolio in self.settings.portfolios:
+            if portfolio.id == portfolio_id:
+                return portfolio
+        return None
+
+    def add_portfolio(self, portfolio: Portfolio) -> None:
+        """Add a new portfolio."""
+        self.settings.portfolios.append(portfolio)
+        self.save()
+
+    def rename_portfolio(self, portfolio_id: str, name: str) -> bool:
+        """Rename a portfolio. Returns True if renamed."""
+        portfolio = self.get_portfolio(portfolio_id)
+        if portfolio is None:
+            return False
+        portfolio.name = name
+        self.save()
+        return True
+
+    def remove_portfolio(self, portfolio_id: str) -> bool:
+        """
+        Remove a portfolio along with its transactions and alerts.
+
+        The last remaining portfolio cannot be removed.
+
+        Returns:
+            True if removed
+        """
+        portfolio = self.get_portfolio(portfolio_id)
+        if portfolio is None or len(self.settings.portfolios) <= 1:
+            return False
+
+        self.settings.portfolios.remove(portfolio)
+        self.settings.transactions = [
+            t for t in self.settings.transactions if t.portfolio_id != portfolio_id
+        ]
+        self.settings.portfolio_alerts = [
+            a for a in self.settings.portfolio_alerts if a.portfolio_id != portfolio_id
+        ]
+        if self.settings.active_portfolio == portfolio_id:
+            self.settings.active_portfolio = self.settings.portfolios[0].id
+        self.save()
+        return True
+
+    def update_active_portfolio(self, portfolio_id: str) -> None:
+        """Update the portfolio shown and used for new transactions."""
+        self.settings.active_portfolio = portfolio_id
+        self.save()
+
+    # Watchlist group methods
+    def get_watchlist(self, watchlist_id: str) -> Watchlist | None:
+        """Get a watchlist group by ID."""
+        for watchlist in self.settings.watchlists:
+            if watchlist.id == watchlist_id:
+                return watchlist
+        return None
+
+    def add_watchlist(self, watchlist: Watchlist) -> None:
+        """Add a new watchlist group."""
+        watchlist.pairs = list(dict.fromkeys(normalize_pair(p) for p in watchlist.pairs))
+        self.settings.watchlists.append(watchlist)
+        self.save()
+
+    def rename_watchlist(self, watchlist_id: str, name: str) -> bool:
+        """Rename a watchlist group. Returns True if renamed."""
+        watchlist = self.get_watchlist(watchlist_id)
+        if watchlist is None:
+            return False
+        watchlist.name = name
+        self.save()
+        return True
+
+    def update_watchlist_pairs(self, watchlist_id: str, pairs: list[str]) -> bool:
+        """
+        Replace the pairs of a watchlist group.
+
+        If the group is subscribed, its added pairs are monitored and its removed
+        pairs dropped, unless another subscribed group still holds them.
+        """
+        watchlist = self.get_watchlist(watchlist_id)
+        if watchlist is None:
+            return False
+        active = watchlist_id in self.settings.active_watchlists
+        if active:
+            self._unmerge_watchlist(watchlist)
+        watchlist.pairs = list(dict.fromkeys(normalize_pair(p) for p in pairs))
+        if active:
+            self._merge_watchlist(watchlist)
+        self.save()
+        return True
+
+    def remove_watchlist(self, watchlist_id: str) -> bool:
+        """Remove a watchlist group, unsubscribing it first. Returns True if removed."""
+        watchlist = self.get_watchlist(watchlist_id)
+        if watchlist is None:
+            return False
+        if watchlist_id in self.settings.active_watchlists:
+            self._unmerge_watchlist(watchlist)
+            self.settings.active_watchlists.remove(watchlist_id)
+        self.settings.watchlists.remove(watchlist)
+        self.save()
+        return True
+
+    def set_watchlist_active(self, watchlist_id: str, active: bool) -> bool:
+        """
+        Subscribe or unsubscribe a watchlist group.
+
+        Subscribing adds the group's pairs to the monitored pairs; unsubscribing
+        removes those no other subscribed group holds.
+
+        Returns:
+            True if the subscription changed
+        """
+        watchlist = self.get_watchlist(watchlist_id)
+        if watchlist is None or (watchlist_id in self.settings.active_watchlists) == active:
+            return False
+        if active:
+            self.settings.active_watchlists.append(watchlist_id)
+            self._merge_watchlist(watchlist)
+        else:
+            self._unmerge_watchlist(watchlist)
+            self.settings.active_watchlists.remove(watchlist_id)
+        self.save()
+        return True
+
+    def _merge_watchlist(self, watchlist: Watchlist) -> None:
+        for pair in watchlist.pairs:
+            if pair not in self.settings.crypto_pairs:
+                self.settings.crypto_pairs.append(pair)
+
+    def _unmerge_watchlist(self, watchlist: Watchlist) -> None:
+        kept = {
+            pair
+            for other in self.settings.watchlists
+            if other.id != watchlist.id and other.id in self.settings.active_watchlists
+            for pair in other.pairs
+        }
+        dropped = set(watchlist.pairs) - kept
+        self.settings.crypto_pairs = [p for p in self.settings.crypto_pairs if p not in dropped]
+        for pair in dropped:
+            self._clear_pair_preferences(pair)
+
     def _apply_proxy_env(self) -> None:
         """Apply proxy settings to environment variables."""
         proxy_url = self.settings.proxy.get_proxy_url()
@@ -440,6 +1790,10 @@ class SettingsManager:
             for key in ["HTTP_PROXY", "HTTPS_PROXY", "http_proxy", "https_proxy"]:
                 os.environ.pop(key, None)
 
+    def _publish_proxy(self) -> None:
+        """Hand a copy of the proxy settings to the store, so later edits can't leak into it."""
+        self.proxy_store.set(replace(self.settings.proxy))
+
     def force_migration(self) -> bool:
         """
         Force migration to current version.
@@ -447,6 +1801,9 @@ class SettingsManager:
         Returns:
             True if migration was performed, False otherwise
         """
+        if self._cipher is not None or self.locked:
+            logger.info("ℹ️  Encrypted settings are always current, nothing to migrate")
+            return False
         try:
             migrated, message, backup_path = self.migration_manager.migrate_if_needed(force=True)
             if migrated:
@@ -538,12 +1895,114 @@ class SettingsManager:
             websocket_data = {}
         websocket_config = WebSocketConfig(**websocket_data)
 
+        # Parse OKX account API config
+        okx_api_data = data.pop("okx_api", {})
+        if not isinstance(okx_api_data, dict):
+            okx_api_data = {}
+        okx_api_config = OkxApiConfig(**okx_api_data)
+
+        # Parse local API config
+        local_api_data = data.pop("local_api", {})
+        if not isinstance(local_api_data, dict):
+            local_api_data = {}
+        local_api_config = LocalApiConfig(**local_api_data)
+
+        # Parse Telegram config
+        telegram_data = data.pop("telegram", {})
+        if not isinstance(telegram_data, dict):
+            telegram_data = {}
+        telegram_config = TelegramConfig(**telegram_data)
+
+        # Parse Matrix config
+        matrix_data = data.pop("matrix", {})
+        if not isinstance(matrix_data, dict):
+            matrix_data = {}
+        matrix_config = MatrixConfig(**matrix_data)
+
+        # Parse WeChat Work config
+        wecom_data = data.pop("wecom", {})
+        if not isinstance(wecom_data, dict):
+            wecom_data = {}
+        wecom_config = WeComConfig(**wecom_data)
+
+        # Parse DingTalk config
+        dingtalk_data = data.pop("dingtalk", {})
+        if not isinstance(dingtalk_data, dict):
+            dingtalk_data = {}
+        dingtalk_config = DingTalkConfig(**dingtalk_data)
+
+        # Parse Feishu config
+        feishu_data = data.pop("feishu", {})
+        if not isinstance(feishu_data, dict):
+            feishu_data = {}
+        feishu_config = FeishuConfig(**feishu_data)
+
+        # Parse Gotify config
+        gotify_data = data.pop("gotify", {})
+        if not isinstance(gotify_data, dict):
+            gotify_data = {}
+        gotify_config = GotifyConfig(**gotify_data)
+
+        # Parse XMPP config
+        xmpp_data = data.pop("xmpp", {})
+        if not isinstance(xmpp_data, dict):
+            xmpp_data = {}
+        xmpp_config = XmppConfig(**xmpp_data)
+
+        # Parse Email config
+        email_data = data.pop("email", {})
+        if not isinstance(email_data, dict):
+            email_data = {}
+        email_config = EmailConfig(**email_data)
+
+        # Parse price digest config
+        digest_data = data.pop("digest", {})
+        if not isinstance(digest_data, dict):
+            digest_data = {}
+        digest_config = DigestConfig(**digest_data)
+
         # Parse alerts config
         alerts_data = data.pop("alerts", [])
         if not isinstance(alerts_data, list):
             alerts_data = []
         alerts_list = [PriceAlert.from_dict(a) for a in alerts_data if isinstance(a, dict)]
 
+        # Parse portfolios
+        portfolios_data = data.pop("portfolios", [])
+        if not isinstance(portfolios_data, list):
+            portfolios_data = []
+        portfolios_list = [
+            Portfolio.from_dict(p) for p in portfolios_data if isinstance(p, dict)
+        ] or [_default_portfolio()]
+
+        # Parse portfolio transactions
+        transactions_data = data.pop("transactions", [])
+        if not isinstance(transactions_data, list):
+            transactions_data = []
+        transactions_list = [
+            PortfolioTransaction.from_dict(t) for t in transactions_data if isinstance(t, dict)
+        ]
+
+        # Parse portfolio alerts
+        portfolio_alerts_data = data.pop("portfolio_alerts", [])
+        if not isinstance(portfolio_alerts_data, list):
+            portfolio_alerts_data = []
+        portfolio_alerts_list = [
+            PortfolioAlert.from_dict(a) for a in portfolio_alerts_data if isinstance(a, dict)
+        ]
+
+        # Parse DCA plans
+        dca_plans_data = data.pop("dca_plans", [])
+        if not isinstance(dca_plans_data, list):
+            dca_plans_data = []
+        dca_plans_list = [DcaPlan.from_dict(p) for p in dca_plans_data if isinstance(p, dict)]
+
+        # Parse watchlist groups
+        watchlists_data = data.pop("watchlists", [])
+        if not isinstance(watchlists_data, list):
+            watchlists_data = []
+        watchlists_list = [Watchlist.from_dict(w) for w in watchlists_data if isinstance(w, dict)]
+
         # Only keep recognized fields
         recognized_fields = {
             "version",
@@ -560,6 +2019,23 @@ class SettingsManager:
             "crypto_pairs",
             "display_limit",
             "minimalist_view",
+            "update_interval_ms",
+            "pair_update_intervals",
+            "pair_aliases",
+            "pair_display_prefs",
+            "pair_highlight_rules",
+            "pinned_pairs",
+            "mini_ticker_enabled",
+            "mini_ticker_pair",
+            "mini_ticker_x",
+            "mini_ticker_y",
+            "taskbar_badge",
+            "menu_bar_price",
+            "hotkeys_enabled",
+            "hotkeys",
+            "sparkline_max_points",
+            "candle_max_count",
+            "history_budget_mb",
             "auto_scroll",
             "scroll_interval",
             "window_x",
@@ -575,6 +2051,42 @@ class SettingsManager:
             "language",
             "price_change_basis",
             "sound_mode",
+            "fiat_currency",
+            "number_format",
+            "active_portfolio",
+            "anomaly_alerts",
+            "anomaly_threshold",
+            "tick_candle_intervals",
+            "fear_greed_alerts",
+            "fear_greed_low",
+            "fear_greed_high",
+            "fee_alerts",
+            "btc_fee_target",
+            "eth_gas_target",
+            "etherscan_api_key",
+            "listing_alerts",
+            "delisting_alerts",
+            "funding_alerts",
+            "funding_spread_threshold",
+            "news_alerts",
+            "news_feeds",
+            "rpc_endpoints",
+            "cert_pinning",
+            "cert_pins",
+            "clock_drift_threshold",
+            "muted_pairs",
+            "crash_reports",
+            "simulation_default_volatility",
+            "simulation_volatility",
+            "unlock_alerts",
+            "unlock_lead_hours",
+            "unlock_min_supply_pct",
+            "macro_alerts",
+            "macro_lead_minutes",
+            "stablecoin_alerts",
+            "stablecoin_threshold",
+            "watched_addresses",
+            "active_watchlists",
         }
         filtered_data = {k: v for k, v in data.items() if k in recognized_fields}
 
@@ -583,7 +2095,23 @@ class SettingsManager:
             proxy=proxy_config,
             compact_mode=compact_mode_config,
             websocket=websocket_config,
+            okx_api=okx_api_config,
+            local_api=local_api_config,
+            telegram=telegram_config,
+            matrix=matrix_config,
+            wecom=wecom_config,
+            dingtalk=dingtalk_config,
+            feishu=feishu_config,
+            gotify=gotify_config,
+            xmpp=xmpp_config,
+            email=email_config,
+            digest=digest_config,
             alerts=alerts_list,
+            portfolios=portfolios_list,
+            transactions=transactions_list,
+            portfolio_alerts=portfolio_alerts_list,
+            dca_plans=dca_plans_list,
+            watchlists=watchlists_list,
             **filtered_data,
         )
 
@@ -594,6 +2122,7 @@ class SettingsManager:
         # Apply immediate effects if needed (like load_language)
         load_language(self.settings.language)
         self._apply_proxy_env()
+        self._publish_proxy()
 
 
 # Global settings instance
@@ -0,0 +1,58 @@
+"""
+Thread-safe holder for configuration shared with background threads.
+Readers take one immutable snapshot and use it for the whole operation, so a
+concurrent change can't mix old and new fields; writers swap in a new
+snapshot and notify subscribers.
+"""
+
+import logging
+import threading
+from collections.abc import Callable
+from typing import Generic, TypeVar
+
+logger = logging.getLogger(__name__)
+
+T = TypeVar("T")
+
+
+class ConfigStore(Generic[T]):
+    """Current value of a configuration section, replaced atomically on change."""
+
+    def __init__(self, value: T):
+        self._value = value
+        self._lock = threading.Lock()
+        self._subscribers: list[Callable[[T], None]] = []
+
+    def get(self) -> T:
+        """Current snapshot; treat it as read-only."""
+        with self._lock:
+            return self._value
+
+    def set(self, value: T) -> bool:
+        """
+        Replace the snapshot and notify subscribers if it changed.
+
+        Subscribers run in the calling thread, after the lock is released.
+        Returns True if the value changed.
+        """
+        with self._lock:
+            if value == self._value:
+                return False
+            self._value = value
+            subscribers = list(self._subscribers)
+        for callback in subscribers:
+            try:
+                callback(value)
+            except Exception as e:
+                logger.error(f"Config change subscriber failed: {e}", exc_info=True)
+        return True
+
+    def subscribe(self, callback: Callable[[T], None]):
+        """Call callback with the new snapshot after every change."""
+        with self._lock:
+            self._subscribers.append(callback)
+
+    def unsubscribe(self, callback: Callable[[T], None]):
+        with self._lock:
+            if callback in self._subscribers:
+                self._subscribers.remove(callback)
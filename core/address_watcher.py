@@ -0,0 +1,242 @@
+"""
+Whale address watcher.
+Polls the recent transactions of user-registered BTC and ETH addresses
+through public block explorer APIs and reports large incoming or outgoing
+transfers.
+
+BTC uses mempool.space, so unconfirmed transactions are seen too. ETH uses
+Etherscan when an API key is set and the keyless Blockscout API otherwise;
+only native ETH transfers are covered, not tokens.
+"""
+
+import logging
+import re
+import threading
+import time
+from dataclasses import dataclass
+
+import requests
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from config.settings import get_settings_manager
+from core.utils.network import get_proxy_config
+
+logger = logging.getLogger(__name__)
+
+SUPPORTED_CHAINS = ("BTC", "ETH")
+# Default transfer size to notify about, in the chain's coin
+DEFAULT_THRESHOLDS = {"BTC": 100.0, "ETH": 1000.0}
+
+SATS_PER_BTC = 100_000_000
+WEI_PER_ETH = 10**18
+
+# Block explorer pages of an address
+ADDRESS_URLS = {
+    "BTC": "https://mempool.space/address/",
+    "ETH": "https://etherscan.io/address/",
+}
+
+
+@dataclass
+class AddressTransfer:
+    """A transaction moving coins into or out of a watched address."""
+
+    chain: str
+    address: str
+    label: str
+    tx_id: str
+    amount: float  # Net change of the address' balance; negative when outgoing
+    timestamp: float  # Seconds, 0 while unconfirmed
+
+    @property
+    def incoming(self) -> bool:
+        return self.amount > 0
+
+
+def is_valid_address(chain: str, address: str) -> bool:
+    """Whether an address looks valid for the chain (format only, no checksum)."""
+    if chain == "BTC":
+        return bool(
+            re.fullmatch(r"(bc1[02-9ac-hj-np-z]{11,71}|[13][1-9A-HJ-NP-Za-km-z]{25,34})", address)
+        )
+    if chain == "ETH":
+        return bool(re.fullmatch(r"0x[0-9a-fA-F]{40}", address))
+    return False
+
+
+def btc_net_amount(tx: dict, address: str) -> float:
+    """Net BTC a mempool.space transaction moves into (+) or out of (-) an address."""
+    received = sum(
+        out.get("value", 0)
+        for out in tx.get("vout", [])
+        if out.get("scriptpubkey_address") == address
+    )
+    sent = sum(
+        (vin.get("prevout") or {}).get("value", 0)
+        for vin in tx.get("vin", [])
+        if (vin.get("prevout") or {}).get("scriptpubkey_address") == address
+    )
+    return (received - sent) / SATS_PER_BTC
+
+
+def eth_net_amount(tx: dict, address: str) -> float:
+    """Net ETH an Etherscan-style transaction record moves into (+) or out of (-) an address."""
+    if tx.get("isError") == "1":
+        return 0.0
+    value = int(tx.get("value") or 0) / WEI_PER_ETH
+    address = address.lower()
+    incoming = str(tx.get("to", "")).lower() == address
+    outgoing = str(tx.get("from", "")).lower() == address
+    if incoming and outgoing:
+        return 0.0
+    return value if incoming else -value if outgoing else 0.0
+
+
+class AddressWatcher(QObject):
+    """
+    Polls the watched addresses and emits transfers at or above each
+    address' threshold.
+
+    Transactions present at the first successful poll of an address are
+    remembered without being emitted, so old activity is not replayed.
+    """
+
+    transfer_detected = pyqtSignal(object)  # AddressTransfer
+
+    MEMPOOL_URL = "https://mempool.space/api/address/{address}/txs"
+    ETHERSCAN_URL = "https://api.etherscan.io/v2/api"
+    BLOCKSCOUT_URL = "https://eth.blockscout.com/api"
+    REFRESH_INTERVAL_MS = 2 * 60 * 1000
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._seen: dict[str, set[str]] = {}  # "chain:address" -> transaction IDs
+        self._fetching = False
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.REFRESH_INTERVAL_MS)
+        self._timer.timeout.connect(self.refresh)
+
+    def start(self):
+        """Start polling."""
+        if not self._timer.isActive():
+            self._timer.start()
+        self.refresh()
+
+    def stop(self):
+        """Stop polling."""
+        self._timer.stop()
+
+    def refresh(self):
+        """Poll the watched addresses in a background thread."""
+        watched = list(self._settings_manager.settings.watched_addresses)
+        if self._fetching or not watched:
+            return
+        self._fetching = True
+        threading.Thread(target=self._fetch, args=(watched,), daemon=True).start()
+
+    def _fetch(self, watched: list[dict]):
+        try:
+            results = []
+            for entry in watched:
+                chain = entry.get("chain", "")
+                address = entry.get("address", "")
+                try:
+                    transfers = self._fetch_transfers(chain, address, entry.get("label", ""))
+                except Exception as e:
+                    logger.debug(f"Address {chain} {address} request failed: {e}")
+                    continue
+                threshold = entry.get("threshold", DEFAULT_THRESHOLDS.get(chain, 0.0))
+                results.append((chain, address, transfers, threshold))
+        finally:
+            self._fetching = False
+        for chain, address, transfers, threshold in results:
+            for transfer in self.process(chain, address, transfers, threshold):
+                logger.info(f"Large transfer {transfer.amount:+} {chain} at {address}")
+                self.transfer_detected.emit(transfer)
+
+    def _fetch_transfers(self, chain: str, address: str, label: str) -> list[AddressTransfer]:
+        if chain == "BTC":
+            response = requests.get(
+                self.MEMPOOL_URL.format(address=address), proxies=get_proxy_config(), timeout=10
+            )
+            response.raise_for_status()
+            return [
+                AddressTransfer(
+                    chain=chain,
+                    address=address,
+                    label=label,
+                    tx_id=tx["txid"],
+                    amount=btc_net_amount(tx, address),
+                    timestamp=float((tx.get("status") or {}).get("block_time") or 0),
+                )
+                for tx in response.json()
+            ]
+        if chain == "ETH":
+            return [
+                AddressTransfer(
+                    chain=chain,
+                    address=address,
+                    label=label,
+                    tx_id=tx["hash"],
+                    amount=eth_net_amount(tx, address),
+                    timestamp=float(tx.get("timeStamp") or 0),
+                )
+                for tx in self._fetch_eth_txs(address)
+            ]
+        raise ValueError(f"Unsupported chain {chain}")
+
+    def _fetch_eth_txs(self, address: str) -> list[dict]:
+        params = {
+            "module": "account",
+            "action": "txlist",
+            "address": address,
+            "sort": "desc",
+            "page": 1,
+            "offset": 25,
+        }
+        api_key = self._settings_manager.settings.etherscan_api_key
+        if api_key:
+            url = self.ETHERSCAN_URL
+            params.update({"chainid": 1, "apikey": api_key})
+        else:
+            url = self.BLOCKSCOUT_URL
+        response = requests.get(url, params=params, proxies=get_proxy_config(), timeout=10)
+        response.raise_for_status()
+        result = response.json().get("result")
+        # "No transactions found" comes back as a message with an empty or string result
+        return result if isinstance(result, list) else []
+
+    def process(
+        self, chain: str, address: str, transfers: list[AddressTransfer], threshold: float
+    ) -> list[AddressTransfer]:
+        """
+        Remember the transactions of an address.
+
+        Returns:
+            New transfers of at least threshold coins; nothing for the first batch
+        """
+        key = f"{chain}:{address}"
+        first = key not in self._seen
+        seen = self._seen.setdefault(key, set())
+        large = []
+        for transfer in sorted(transfers, key=lambda t: t.timestamp or time.time()):
+            if transfer.tx_id in seen:
+                continue
+            seen.add(transfer.tx_id)
+            if not first and abs(transfer.amount) >= threshold:
+                large.append(transfer)
+        return large
+
+
+# Global address watcher instance
+_address_watcher: AddressWatcher | None = None
+
+
+def get_address_watcher() -> AddressWatcher:
+    """Get the global address watcher instance."""
+    global _address_watcher
+    if _address_watcher is None:
+        _address_watcher = AddressWatcher()
+    return _address_watcher
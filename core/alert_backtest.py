@@ -0,0 +1,210 @@
+"""
+Alert backtest preview.
+Replays an alert that is still being set up over the last days of candles,
+to show how often it would have fired before it is saved. Intra-candle
+moves are approximated from each candle's open, high, low and close.
+"""
+
+import logging
+import math
+import threading
+from dataclasses import dataclass
+
+from PyQt6.QtCore import QObject, pyqtSignal
+
+from config.settings import PriceAlert
+from core.alert_manager import CANDLE_CLOSE_ALERT_TYPES, TRAILING_ALERT_TYPES, ladder_rung
+from core.candle_aggregator import get_candle_aggregator
+from core.indicators import MAX_CANDLES, CandleSeries, interval_seconds
+
+logger = logging.getLogger(__name__)
+
+PREVIEW_DAYS = (1, 3, 7, 30)
+PREVIEW_INTERVALS = ("15m", "1h", "4h", "1d")  # Finest first
+BACKTEST_ALERT_TYPES = CANDLE_CLOSE_ALERT_TYPES | {"price_ladder_pct"} | set(TRAILING_ALERT_TYPES)
+
+
+@dataclass(slots=True)
+class BacktestResult:
+    """Firings of an alert over past candles."""
+
+    fires: list[tuple[float, float]]  # (timestamp in seconds, price)
+    candles: int
+    interval: str
+    days: int
+
+
+def preview_interval(alert: PriceAlert, days: int) -> tuple[str, int]:
+    """
+    Candle interval and count covering the given days within one request.
+
+    Alerts judged on candle closes are replayed on their own timeframe.
+    """
+    if alert.on_candle_close and alert.alert_type in CANDLE_CLOSE_ALERT_TYPES:
+        interval = alert.timeframe
+    else:
+        interval = PREVIEW_INTERVALS[-1]
+        for candidate in PREVIEW_INTERVALS:
+            if days * 86400 / interval_seconds(candidate) <= MAX_CANDLES:
+                interval = candidate
+                break
+    count = math.ceil(days * 86400 / interval_seconds(interval))
+    return interval, max(1, min(count, MAX_CANDLES))
+
+
+def price_path(klines: list[dict], interval: str, closes_only: bool) -> list[tuple[float, float]]:
+    """
+    Prices in the order they most likely traded, as (timestamp, price).
+
+    A rising candle is taken to dip to its low before reaching its high,
+    a falling one the other way round.
+    """
+    series = CandleSeries(interval, max(len(klines), 1))
+    series.load(klines)
+    seconds = series.seconds
+    path = []
+    for candle in series.candles():
+        if closes_only:
+            path.append((candle.open_time + seconds, candle.close))
+            continue
+        if candle.close >= candle.open:
+            prices = (candle.open, candle.low, candle.high, candle.close)
+        else:
+            prices = (candle.open, candle.high, candle.low, candle.close)
+        for index, price in enumerate(prices):
+            path.append((candle.open_time + seconds * index / 4, price))
+    return path
+
+
+def _step(price: float, step: float) -> int:
+    return math.floor(price / step)
+
+
+def simulate_alert(
+    alert: PriceAlert, path: list[tuple[float, float]]
+) -> list[tuple[float, float]]:
+    """
+    Firings of an alert along a price path, with its repeat mode and cooldown.
+
+    The alert itself is not changed.
+    """
+    fires: list[tuple[float, float]] = []
+    target = alert.target_price
+    if target <= 0:
+        return fires
+    previous = None
+    last_fire = None
+    state = None  # Crossed step, ladder rung or trailing extreme
+
+    for timestamp, price in path:
+        if alert.alert_type in ("price_ladder_pct", *TRAILING_ALERT_TYPES) and state is None:
+            # Armed at the first price, like the live alert
+            state = price
+            previous = price
+            continue
+        if alert.alert_type == "trailing_high":
+            state = max(state, price)
+        elif alert.alert_type == "trailing_low":
+            state = min(state, price)
+
+        cooling = (
+            alert.repeat_mode == "repeat"
+            and last_fire is not None
+            and timestamp - last_fire < alert.cooldown_seconds
+        )
+        fired = False
+        if not cooling:
+            if alert.alert_type == "price_above":
+                fired = price > target
+            elif alert.alert_type == "price_below":
+                fired = price < target
+            elif alert.alert_type == "price_touch":
+                crossed = previous is not None and (
+                    previous < target < price or price < target < previous
+                )
+                fired = price == target or crossed
+            elif alert.alert_type == "price_multiple" and previous is not None:
+                boundary = max(_step(price, target), _step(previous, target))
+                if _step(price, target) != _step(previous, target) and boundary != state:
+                    fired = True
+                    state = boundary
+            elif alert.alert_type == "price_ladder_pct":
+                rung = ladder_rung(state, price, target)
+                if rung:
+                    fired = True
+                    state = state * (1 + target / 100) ** rung
+            elif alert.alert_type == "trailing_high":
+                fired = price <= state * (1 - target / 100)
+            elif alert.alert_type == "trailing_low":
+                fired = price >= state * (1 + target / 100)
+
+        previous = price
+        if not fired:
+            continue
+        fires.append((timestamp, price))
+        last_fire = timestamp
+        if alert.alert_type in TRAILING_ALERT_TYPES:
+            state = price
+        if alert.repeat_mode == "once":
+            break
+    return fires
+
+
+def backtest_alert(
+    alert: PriceAlert, klines: list[dict], interval: str, days: int
+) -> BacktestResult:
+    """Replay an alert over klines as returned by fetch_klines."""
+    closes_only = alert.on_candle_close and alert.alert_type in CANDLE_CLOSE_ALERT_TYPES
+    path = price_path(klines, interval, closes_only)
+    return BacktestResult(simulate_alert(alert, path), len(klines), interval, days)
+
+
+class AlertBacktester(QObject):
+    """Fetches the candles of a preview in the background."""
+
+    finished = pyqtSignal(object, str)  # BacktestResult or None, error message
+
+    def __init__(self, client_factory, parent: QObject | None = None):
+        super().__init__(parent)
+        self._client_factory = client_factory
+        self._running = False
+
+    @property
+    def running(self) -> bool:
+        return self._running
+
+    def start(self, alert: PriceAlert, days: int):
+        if self._running or alert.alert_type not in BACKTEST_ALERT_TYPES:
+            return
+        self._running = True
+        threading.Thread(target=self._run, args=(alert, days), daemon=True).start()
+
+    def _run(self, alert: PriceAlert, days: int):
+        interval, limit = preview_interval(alert, days)
+        client = None
+        result, error = None, ""
+        try:
+            client = self._client_factory()
+            klines = client.fetch_klines(alert.pair, interval, limit)
+            if not klines:
+                # Sources without klines fall back to candles built from ticks
+                klines = get_candle_aggregator().fetch_klines(alert.pair, interval, limit)
+            if klines:
+                result = backtest_alert(alert, klines, interval, days)
+                logger.info(
+                    f"Backtest of {alert.pair} {alert.alert_type}: "
+                    f"{len(result.fires)} firings over {days}d"
+                )
+            else:
+                error = "No candles"
+        except Exception as e:
+            logger.warning(f"Backtest of {alert.pair} failed: {e}")
+            error = str(e)
+        finally:
+            self._running = False
+            if client is not None and hasattr(client, "stop"):
+                try:
+                    client.stop()
+                except Exception:
+                    pass
+        self.finished.emit(result, error)
@@ -3,13 +3,47 @@ Price Alert Manager for Crypto Monitor.
 Monitors prices and triggers notifications when alert conditions are met.
 """
 
+import math
 import time
 
 from PyQt6.QtCore import QObject, pyqtSignal
 
 from config.settings import PriceAlert, get_settings_manager
+from core.escalation import get_escalation_manager
+from core.indicators import MA_CROSS_PERIODS, BandBreach, detect_cross, get_indicator_engine
+from core.message_template import render_alert_message
 from core.notifier import get_notification_service
 
+# Alert types firing when two lines cross, and the direction they fire on
+MA_CROSS_ALERT_TYPES = {
+    "golden_cross": "above",
+    "death_cross": "below",
+    "ema_cross_above": "above",
+    "ema_cross_below": "below",
+}
+
+# Trailing alerts: the running extreme they track, and the way the price must retrace from it
+TRAILING_ALERT_TYPES = {
+    "trailing_high": "down",
+    "trailing_low": "up",
+}
+
+# Price alerts that can be judged on candle closes instead of every tick
+CANDLE_CLOSE_ALERT_TYPES = {"price_above", "price_below", "price_touch", "price_multiple"}
+
+
+def ladder_rung(reference, price, step_pct):
+    """
+    Rungs a price is from a reference on a ladder of step_pct percent steps,
+    e.g. 1 once it is 2% above with 2% steps and -1 once it is 2% below.
+    Steps compound, so one up and one down lead back to the reference.
+    """
+    if reference <= 0 or price <= 0 or step_pct <= 0:
+        return 0
+    # Rounded so a price right on a rung counts despite float error
+    rungs = round(math.log(price / reference) / math.log1p(step_pct / 100), 9)
+    return math.floor(rungs) if rungs > 0 else math.ceil(rungs)
+
 
 class AlertManager(QObject):
     """
@@ -20,14 +54,29 @@ class AlertManager(QObject):
     """
 
     alert_triggered = pyqtSignal(str, str, float, float)  # pair, alert_type, target, current
+    alert_acknowledged = pyqtSignal(str, str)  # alert_id, pair
+    pair_mute_changed = pyqtSignal(str, float)  # pair, muted until (0 when unmuted)
 
     def __init__(self, parent=None):
         super().__init__(parent)
         self._settings_manager = get_settings_manager()
         self._notification_service = get_notification_service()
+        self._indicator_engine = get_indicator_engine()
         self._current_prices = {}
-
-    def check_alerts(self, pair, price, percentage_str="0.00%"):
+        self._cross_diffs: dict[str, float] = {}  # alert_id -> last line difference
+        self._crosses: dict[str, str | None] = {}  # alert_id -> cross seen on this tick
+        # (pair, interval) -> BandBreach of a candle closed on this tick. The engine
+        # emits breaches while processing a tick, before its alerts are checked.
+        self._band_breaches: dict[tuple[str, str], BandBreach] = {}
+        self._indicator_engine.band_breached.connect(self._on_band_breached)
+        # (pair, interval) -> (close, previous close) of a candle closed on this tick
+        self._closed_candles: dict[tuple[str, str], tuple[float, float | None]] = {}
+        self._last_closes: dict[tuple[str, str], float] = {}
+        self._indicator_engine.candle_closed.connect(self._on_candle_closed)
+        # Clicking an alert's notification acknowledges it
+        self._notification_service.notification_clicked.connect(self.acknowledge_pair)
+
+    def check_alerts(self, pair, price, percentage_str="0.00%", indicators=None):
         """
         Check if any alerts should be triggered for the given price.
 
@@ -35,6 +84,7 @@ class AlertManager(QObject):
             pair: Trading pair, e.g., "BTC-USDT"
             price: Current price as string or float
             percentage_str: Current 24h change percentage as string
+            indicators: Current IndicatorSnapshot of the pair (for indicator alerts)
         """
         try:
             # Handle both string and float inputs
@@ -58,34 +108,107 @@ class AlertManager(QObject):
         previous_percentage = self._current_percentages.get(pair)
         self._current_percentages[pair] = percentage_val
 
-        # Get enabled alerts for this pair
+        # Get enabled alerts for this pair. Muted pairs keep their crossover and trailing
+        # state up to date but aren't checked, so they can't fire
         alerts = self._settings_manager.get_alerts_for_pair(pair)
+        muted = self.muted_until(pair) is not None
 
         for alert in alerts:
             if not alert.enabled:
                 continue
 
-            if self._should_trigger(
+            alert_price, alert_previous = current_price, previous_price
+            if alert.on_candle_close and alert.alert_type in CANDLE_CLOSE_ALERT_TYPES:
+                # Judged once per candle against its close, so wicks don't fire it
+                self._indicator_engine.watch(alert.pair, alert.timeframe)
+                closed = self._closed_candles.get((alert.pair, alert.timeframe))
+                if closed is None:
+                    continue
+                alert_price, alert_previous = closed
+            elif alert.alert_type in MA_CROSS_ALERT_TYPES:
+                self._crosses[alert.id] = self._update_cross(alert, current_price)
+            elif alert.alert_type == "bollinger_breach":
+                self._indicator_engine.watch(alert.pair, alert.timeframe)
+            elif alert.alert_type == "price_ladder_pct" and alert.last_triggered_value is None:
+                # The ladder starts from the first price seen after arming
+                alert.last_triggered_value = current_price
+                self._settings_manager.update_alert(alert)
+                continue
+            elif alert.alert_type in TRAILING_ALERT_TYPES:
+                if alert.last_triggered_value is None:
+                    # Armed at the first price seen
+                    alert.last_triggered_value = current_price
+                    self._settings_manager.update_alert(alert)
+                    continue
+                # New extremes go to disk with the next save rather than on every tick
+                if alert.alert_type == "trailing_high":
+                    alert.last_triggered_value = max(alert.last_triggered_value, current_price)
+                else:
+                    alert.last_triggered_value = min(alert.last_triggered_value, current_price)
+
+            if not muted and self._should_trigger(
                 alert,
-                current_price,
-                previous_price,
+                alert_price,
+                alert_previous,
                 percentage_val,
                 previous_percentage,
+                indicators,
             ):
                 self._trigger_alert(
                     alert,
-                    current_price,
-                    previous_price,
+                    alert_price,
+                    alert_previous,
                     percentage_val,
                     previous_percentage,
+                    indicators,
                 )
 
+        for key in [key for key in self._band_breaches if key[0] == pair]:
+            del self._band_breaches[key]
+        for key in [key for key in self._closed_candles if key[0] == pair]:
+            del self._closed_candles[key]
+
+    def _on_band_breached(self, breach: BandBreach):
+        self._band_breaches[(breach.pair, breach.interval)] = breach
+
+    def _on_candle_closed(self, pair: str, interval: str, close: float):
+        key = (pair, interval)
+        self._closed_candles[key] = (close, self._last_closes.get(key))
+        self._last_closes[key] = close
+
     def reset(self):
         """Reset all price history. Call this when switching data sources."""
         self._current_prices.clear()
+        self._cross_diffs.clear()
+        self._crosses.clear()
+        self._band_breaches.clear()
+        self._closed_candles.clear()
+        self._last_closes.clear()
         if hasattr(self, "_current_percentages"):
             self._current_percentages.clear()
 
+    def _update_cross(self, alert, current_price):
+        """
+        Track the lines compared by a moving average cross alert.
+
+        Returns:
+            "above" or "below" if the lines crossed since the last tick, otherwise None
+        """
+        if alert.alert_type in ("golden_cross", "death_cross"):
+            fast_period, slow_period = MA_CROSS_PERIODS
+            fast = self._indicator_engine.get_ema(alert.pair, alert.timeframe, fast_period)
+        else:
+            slow_period = alert.ema_period
+            fast = current_price
+        slow = self._indicator_engine.get_ema(alert.pair, alert.timeframe, slow_period)
+        if fast is None or slow is None:
+            return None
+
+        diff = fast - slow
+        previous = self._cross_diffs.get(alert.id)
+        self._cross_diffs[alert.id] = diff
+        return detect_cross(previous, diff)
+
     def _should_trigger(
         self,
         alert,
@@ -93,6 +216,7 @@ class AlertManager(QObject):
         previous_price=None,
         current_pct=0.0,
         previous_pct=None,
+        indicators=None,
     ):
         """
         Check if an alert should be triggered.
@@ -168,6 +292,32 @@ class AlertManager(QObject):
             )
             return is_new_boundary
 
+        elif alert.alert_type == "price_ladder_pct":
+            if alert.last_triggered_value is None:
+                return False
+            return ladder_rung(alert.last_triggered_value, current_price, alert.target_price) != 0
+
+        elif alert.alert_type in TRAILING_ALERT_TYPES:
+            extreme = alert.last_triggered_value
+            if extreme is None or alert.target_price <= 0:
+                return False
+            if alert.alert_type == "trailing_high":
+                return current_price <= extreme * (1 - alert.target_price / 100)
+            return current_price >= extreme * (1 + alert.target_price / 100)
+
+        elif alert.alert_type in ("rsi_above", "rsi_below"):
+            if indicators is None or indicators.rsi is None:
+                return False
+            if alert.alert_type == "rsi_above":
+                return indicators.rsi > alert.target_price
+            return indicators.rsi < alert.target_price
+
+        elif alert.alert_type in MA_CROSS_ALERT_TYPES:
+            return self._crosses.get(alert.id) == MA_CROSS_ALERT_TYPES[alert.alert_type]
+
+        elif alert.alert_type == "bollinger_breach":
+            return (alert.pair, alert.timeframe) in self._band_breaches
+
         return False
 
     def _trigger_alert(
@@ -177,6 +327,7 @@ class AlertManager(QObject):
         previous_price=None,
         current_pct=0.0,
         previous_pct=None,
+        indicators=None,
     ):
         """
         Trigger an alert notification.
@@ -191,16 +342,46 @@ class AlertManager(QObject):
             elif current_price < previous_price:
                 notif_alert_type = "price_below"  # Treated as crossing below
 
-        # Send notification
-        self._notification_service.send_price_alert(
-            pair=alert.pair,
-            alert_type=notif_alert_type,
-            target_price=alert.target_price,
-            current_price=current_price,
-            current_pct=current_pct,
-            previous_price=previous_price,
-            previous_pct=previous_pct,
-        )
+        # Send notification; alerts with escalation steps reach the remote channels through them
+        remote = not alert.escalation
+        custom_message = render_alert_message(alert, current_price, current_pct)
+        if alert.alert_type in MA_CROSS_ALERT_TYPES:
+            self._notification_service.send_ma_cross_alert(
+                pair=alert.pair,
+                alert_type=alert.alert_type,
+                timeframe=alert.timeframe,
+                ema_period=alert.ema_period,
+                current_price=current_price,
+                remote=remote,
+                custom_message=custom_message,
+            )
+        elif alert.alert_type == "bollinger_breach":
+            breach = self._band_breaches[(alert.pair, alert.timeframe)]
+            self._notification_service.send_bollinger_alert(
+                pair=alert.pair,
+                side=breach.side,
+                timeframe=breach.interval,
+                close=breach.close,
+                band=breach.band,
+                remote=remote,
+                custom_message=custom_message,
+            )
+        else:
+            if alert.alert_type == "price_ladder_pct" or alert.alert_type in TRAILING_ALERT_TYPES:
+                # The move is measured from the ladder's rung or the trailing extreme
+                previous_price = alert.last_triggered_value
+            self._notification_service.send_price_alert(
+                pair=alert.pair,
+                alert_type=notif_alert_type,
+                target_price=alert.target_price,
+                current_price=current_price,
+                current_pct=current_pct,
+                previous_price=previous_price,
+                previous_pct=previous_pct,
+                indicator_value=indicators.rsi if indicators else None,
+                remote=remote,
+                custom_message=custom_message,
+            )
 
         # Update alert state
         alert.last_triggered = time.time()
@@ -227,6 +408,14 @@ class AlertManager(QObject):
                 else curr_step
             )
             alert.last_triggered_value = max(curr_step, prev_step)
+        elif alert.alert_type == "price_ladder_pct":
+            # Move to the rung reached, so the next step is measured from there
+            reference = alert.last_triggered_value
+            rung = ladder_rung(reference, current_price, alert.target_price)
+            alert.last_triggered_value = reference * (1 + alert.target_price / 100) ** rung
+        elif alert.alert_type in TRAILING_ALERT_TYPES:
+            # Repeating trailing alerts track a new extreme from here
+            alert.last_triggered_value = current_price
 
         if alert.repeat_mode == "once":
             # Disable one-time alerts after triggering
@@ -235,6 +424,9 @@ class AlertManager(QObject):
         # Save updated alert
         self._settings_manager.update_alert(alert)
 
+        if alert.escalation:
+            get_escalation_manager().start(alert, current_price)
+
         # Emit signal
         self.alert_triggered.emit(alert.pair, alert.alert_type, alert.target_price, current_price)
 
@@ -278,6 +470,75 @@ class AlertManager(QObject):
         """Get all alerts for a specific pair."""
         return self._settings_manager.get_alerts_for_pair(pair)
 
+    def acknowledge_alert(self, alert_id):
+        """
+        Acknowledge a fired alert, which also stops its escalation.
+
+        Returns:
+            True if the alert was waiting for acknowledgement
+        """
+        for alert in self._settings_manager.settings.alerts:
+            if alert.id == alert_id:
+                if not alert.awaiting_acknowledgement:
+                    return False
+                alert.acknowledged_at = time.time()
+                self._settings_manager.update_alert(alert)
+                if alert.escalation:
+                    get_escalation_manager().stop(alert.id)
+                self.alert_acknowledged.emit(alert.id, alert.pair)
+                return True
+        return False
+
+    def acknowledge_pair(self, pair):
+        """Acknowledge a pair's fired alerts; returns how many were waiting."""
+        return sum(
+            self.acknowledge_alert(alert.id)
+            for alert in self._settings_manager.get_alerts_for_pair(pair)
+        )
+
+    def acknowledge_all(self):
+        """Acknowledge every fired alert; returns how many were waiting."""
+        alerts = list(self._settings_manager.settings.alerts)
+        return sum(self.acknowledge_alert(alert.id) for alert in alerts)
+
+    def mute_pair(self, pair, duration_seconds):
+        """
+        Hold back a pair's alerts for a while.
+
+        Returns:
+            The timestamp the mute ends at
+        """
+        until = time.time() + duration_seconds
+        muted_pairs = dict(self._settings_manager.settings.muted_pairs)
+        muted_pairs[pair] = until
+        self._settings_manager.update_muted_pairs(muted_pairs)
+        # Escalations already under way are muted too
+        for alert in self._settings_manager.get_alerts_for_pair(pair):
+            if alert.escalation:
+                get_escalation_manager().stop(alert.id)
+        self.pair_mute_changed.emit(pair, until)
+        return until
+
+    def unmute_pair(self, pair):
+        """Let a pair's alerts fire again. Returns True if it was muted."""
+        muted_pairs = dict(self._settings_manager.settings.muted_pairs)
+        if muted_pairs.pop(pair, None) is None:
+            return False
+        self._settings_manager.update_muted_pairs(muted_pairs)
+        self.pair_mute_changed.emit(pair, 0.0)
+        return True
+
+    def muted_until(self, pair):
+        """Timestamp a pair's mute ends at, or None when it isn't muted."""
+        until = self._settings_manager.settings.muted_pairs.get(pair)
+        if until is None:
+            return None
+        if until <= time.time():
+            # Expired mutes end on the next check
+            self.unmute_pair(pair)
+            return None
+        return until
+
     def toggle_alert(self, alert_id):
         """Toggle alert enabled state."""
         for alert in self._settings_manager.settings.alerts:
@@ -0,0 +1,112 @@
+"""
+Price feed anomaly detection.
+Flags ticks that deviate by many standard deviations from the short-term
+mean of recent ticks, e.g. flash crashes or bad prints.
+"""
+
+import logging
+import time
+from collections import deque
+from dataclasses import dataclass
+
+from PyQt6.QtCore import QObject, pyqtSignal
+
+from config.settings import get_settings_manager
+
+logger = logging.getLogger(__name__)
+
+WINDOW_SIZE = 120  # Recent ticks forming the short-term mean
+MIN_SAMPLES = 30
+# Ignore deviations smaller than this fraction of the mean (flat feeds have a tiny std)
+MIN_DEVIATION = 0.005
+COOLDOWN_SECONDS = 300
+
+
+@dataclass
+class PriceAnomaly:
+    """A tick far outside the recent price distribution."""
+
+    pair: str
+    price: float
+    mean: float
+    std: float
+    z_score: float
+    timestamp: float
+
+    @property
+    def deviation_pct(self) -> float:
+        return (self.price - self.mean) / self.mean * 100
+
+
+def z_score(prices: list[float], price: float) -> tuple[float, float, float] | None:
+    """
+    Standard score of price against a sample.
+
+    Returns:
+        (z_score, mean, std), or None if the sample has no spread
+    """
+    mean = sum(prices) / len(prices)
+    std = (sum((p - mean) ** 2 for p in prices) / len(prices)) ** 0.5
+    if std <= 0:
+        return None
+    return (price - mean) / std, mean, std
+
+
+class AnomalyDetector(QObject):
+    """Keeps a rolling window of ticks per pair and emits anomalies."""
+
+    anomaly_detected = pyqtSignal(object)  # PriceAnomaly
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._windows: dict[str, deque[float]] = {}
+        self._last_anomaly: dict[str, float] = {}
+
+    def check_price(self, pair: str, price: float) -> PriceAnomaly | None:
+        """Add a tick and check it against the preceding ones."""
+        if price <= 0:
+            return None
+        window = self._windows.setdefault(pair, deque(maxlen=WINDOW_SIZE))
+        anomaly = None
+        if len(window) >= MIN_SAMPLES:
+            anomaly = self._evaluate(pair, list(window), price)
+        # Sustained moves enter the window and stop being anomalies
+        window.append(price)
+        return anomaly
+
+    def _evaluate(self, pair: str, prices: list[float], price: float) -> PriceAnomaly | None:
+        result = z_score(prices, price)
+        if result is None:
+            return None
+        score, mean, std = result
+        threshold = self._settings_manager.settings.anomaly_threshold
+        if abs(score) < threshold or abs(price - mean) < mean * MIN_DEVIATION:
+            return None
+
+        now = time.time()
+        if now - self._last_anomaly.get(pair, 0.0) < COOLDOWN_SECONDS:
+            return None
+        self._last_anomaly[pair] = now
+
+        anomaly = PriceAnomaly(pair, price, mean, std, score, now)
+        logger.warning(f"Price anomaly on {pair}: {price} (z={score:.1f}, mean={mean})")
+        self.anomaly_detected.emit(anomaly)
+        return anomaly
+
+    def reset(self):
+        """Forget recent ticks, e.g. after switching data sources."""
+        self._windows.clear()
+        self._last_anomaly.clear()
+
+
+# Global anomaly detector instance
+_anomaly_detector: AnomalyDetector | None = None
+
+
+def get_anomaly_detector() -> AnomalyDetector:
+    """Get the global anomaly detector instance."""
+    global _anomaly_detector
+    if _anomaly_detector is None:
+        _anomaly_detector = AnomalyDetector()
+    return _anomaly_detector
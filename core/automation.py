@@ -0,0 +1,183 @@
+"""
+Automation commands.
+A small command set for OS automation tools such as Apple Shortcuts, Windows
+shortcuts or scripts, given as a crypto-monitor:// link or on the command line:
+
+    crypto-monitor://show                 Bring the window up
+    crypto-monitor://price/BTC-USDT       Current price of a monitored pair
+    crypto-monitor://add/ETH-USDT         Start monitoring a pair
+    crypto-monitor://alerts/toggle        Pause or resume alerts (also /on and /off)
+
+The same commands work as words, e.g. `crypto-monitor --command "price BTC-USDT"`.
+A second launch hands its command to the running instance over a local socket
+and prints the reply.
+"""
+
+import getpass
+import logging
+from dataclasses import dataclass
+from urllib.parse import unquote, urlsplit
+
+from PyQt6.QtCore import QObject, pyqtSignal
+from PyQt6.QtNetwork import QLocalServer, QLocalSocket
+
+from config.settings import get_settings_manager
+from core.control_api import check_pair
+from core.notifier import get_notification_service
+
+logger = logging.getLogger(__name__)
+
+SCHEME = "crypto-monitor"
+SEND_TIMEOUT_MS = 3000
+
+ALERT_SWITCHES = ("toggle", "on", "off")
+
+
+def server_name() -> str:
+    """Local socket name of the running instance, per user."""
+    return f"{SCHEME}-{getpass.getuser()}"
+
+
+@dataclass
+class Command:
+    action: str  # "show", "price", "add" or "alerts"
+    argument: str = ""
+    from_link: bool = False  # Given as a crypto-monitor:// link rather than typed
+
+
+def parse_command(text: str) -> Command:
+    """
+    Command of a crypto-monitor:// link or of words like "price BTC-USDT".
+
+    Raises:
+        ValueError: If it isn't a known command
+    """
+    text = text.strip()
+    from_link = text.lower().startswith(f"{SCHEME}:")
+    if from_link:
+        url = urlsplit(text)
+        words = [unquote(word) for word in f"{url.netloc}/{url.path}".split("/") if word]
+    else:
+        words = text.split()
+    if not words:
+        return Command("show", from_link=from_link)
+
+    action = words[0].lower()
+    argument = words[1] if len(words) > 1 else ""
+    if action == "show":
+        return Command(action, from_link=from_link)
+    if action in ("price", "add"):
+        if not argument:
+            raise ValueError(f"{action} needs a pair, e.g. {action} BTC-USDT")
+        return Command(action, check_pair(argument), from_link)
+    if action == "alerts":
+        argument = argument.lower() or "toggle"
+        if argument not in ALERT_SWITCHES:
+            raise ValueError(f"alerts takes {', '.join(ALERT_SWITCHES)}")
+        return Command(action, argument, from_link)
+    raise ValueError(f"unknown command: {action}")
+
+
+def send_command(text: str, timeout_ms: int = SEND_TIMEOUT_MS) -> str | None:
+    """Hand a command to the running instance; its reply, or None if none is running."""
+    socket = QLocalSocket()
+    socket.connectToServer(server_name())
+    if not socket.waitForConnected(timeout_ms):
+        return None
+    socket.write(text.encode() + b"\n")
+    socket.flush()
+    reply = b""
+    while socket.waitForReadyRead(timeout_ms):
+        reply += bytes(socket.readAll())
+        if reply.endswith(b"\n"):
+            break
+    socket.disconnectFromServer()
+    return reply.decode(errors="replace").strip()
+
+
+class CommandServer(QObject):
+    """Listens for the commands of later launches and answers them."""
+
+    def __init__(self, handler, parent: QObject | None = None):
+        """
+        Args:
+            handler: Called with each Command, returns the reply text
+        """
+        super().__init__(parent)
+        self._handler = handler
+        self._server = QLocalServer(self)
+        # Only this user may connect; by default the umask decides on Unix
+        self._server.setSocketOptions(QLocalServer.SocketOption.UserAccessOption)
+        self._server.newConnection.connect(self._on_new_connection)
+
+    def listen(self) -> bool:
+        name = server_name()
+        if not self._server.listen(name):
+            # A socket file left behind by a crash blocks the name on Unix
+            QLocalServer.removeServer(name)
+            if not self._server.listen(name):
+                logger.warning(f"Automation commands unavailable: {self._server.errorString()}")
+                return False
+        return True
+
+    def _on_new_connection(self):
+        socket = self._server.nextPendingConnection()
+        if socket is None:
+            return
+        socket.readyRead.connect(lambda: self._on_ready_read(socket))
+        socket.disconnected.connect(socket.deleteLater)
+
+    def _on_ready_read(self, socket):
+        if not socket.canReadLine():
+            return
+        text = bytes(socket.readLine()).decode(errors="replace")
+        socket.write(self.answer(text).encode() + b"\n")
+        socket.flush()
+        socket.disconnectFromServer()
+
+    def answer(self, text: str) -> str:
+        try:
+            command = parse_command(text)
+        except ValueError as e:
+            return f"error: {e}"
+        logger.info(f"Automation command: {command.action} {command.argument}".rstrip())
+        return self._handler(command)
+
+
+class AutomationCommands(QObject):
+    """Carries out commands; changes to the window are left to its slots."""
+
+    show_requested = pyqtSignal()
+    add_pairs_requested = pyqtSignal(list)
+
+    def __init__(self, market_controller, parent: QObject | None = None):
+        super().__init__(parent)
+        self._market_controller = market_controller
+        self._settings_manager = get_settings_manager()
+
+    def execute(self, command: Command) -> str:
+        if command.action == "show":
+            self.show_requested.emit()
+            return "ok"
+        if command.action == "price":
+            return self._price(command.argument)
+        if command.action == "add":
+            if command.argument in self._settings_manager.settings.crypto_pairs:
+                return f"{command.argument} is already monitored"
+            self.add_pairs_requested.emit([command.argument])
+            return f"added {command.argument}"
+        return self._switch_alerts(command.argument)
+
+    def _price(self, pair: str) -> str:
+        if pair not in self._settings_manager.settings.crypto_pairs:
+            return f"error: {pair} isn't monitored"
+        for row in self._market_controller.get_snapshot_rows():
+            if row.pair == pair:
+                return f"{row.name} {row.price_text} {row.percentage_text}"
+        return f"error: no price for {pair} yet"
+
+    def _switch_alerts(self, switch: str) -> str:
+        notifier = get_notification_service()
+        paused = not notifier.is_paused if switch == "toggle" else switch == "off"
+        notifier.set_paused(paused)
+        return "alerts off" if paused else "alerts on"
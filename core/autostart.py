@@ -0,0 +1,188 @@
+"""
+Launch at login.
+Registers the app to start with the user session: a Run registry value on
+Windows, a LaunchAgent on macOS and an XDG autostart entry on Linux.
+The entry can pass --minimized so the app starts in the system tray.
+"""
+
+import logging
+import os
+import plistlib
+import shlex
+import sys
+from pathlib import Path
+
+logger = logging.getLogger(__name__)
+
+APP_NAME = "Crypto Monitor"
+REGISTRY_VALUE = "CryptoMonitor"
+LAUNCH_AGENT_LABEL = "com.shiquda.crypto-monitor"
+RUN_KEY = r"Software\Microsoft\Windows\CurrentVersion\Run"
+MINIMIZED_ARG = "--minimized"
+
+
+def launch_command(minimized: bool = False) -> list[str]:
+    """Command line that starts this installation of the app."""
+    if getattr(sys, "frozen", False):
+        # PyInstaller bundle
+        command = [sys.executable]
+    else:
+        main_script = Path(__file__).resolve().parent.parent / "main.py"
+        command = [sys.executable, str(main_script)]
+    if minimized:
+        command.append(MINIMIZED_ARG)
+    return command
+
+
+def build_desktop_entry(command: list[str]) -> str:
+    """XDG autostart .desktop file starting the command."""
+    return (
+        "[Desktop Entry]\n"
+        "Type=Application\n"
+        f"Name={APP_NAME}\n"
+        f"Exec={shlex.join(command)}\n"
+        "X-GNOME-Autostart-enabled=true\n"
+        "Terminal=false\n"
+    )
+
+
+def build_launch_agent(command: list[str]) -> bytes:
+    """macOS LaunchAgent plist starting the command at login."""
+    return plistlib.dumps(
+        {
+            "Label": LAUNCH_AGENT_LABEL,
+            "ProgramArguments": command,
+            "RunAtLoad": True,
+            "ProcessType": "Interactive",
+        }
+    )
+
+
+def windows_command_line(command: list[str]) -> str:
+    """Command line for the Run registry value, with each argument quoted."""
+    return " ".join(f'"{arg}"' if " " in arg or not arg else arg for arg in command)
+
+
+class AutostartManager:
+    """Enables or disables launching the app at login for the current user."""
+
+    def __init__(self, home: Path | None = None, platform: str | None = None):
+        self._home = home or Path.home()
+        self._platform = platform or sys.platform
+
+    @property
+    def is_supported(self) -> bool:
+        """Whether autostart can be managed on this platform."""
+        return self._platform in ("win32", "darwin") or self._platform.startswith("linux")
+
+    @property
+    def entry_path(self) -> Path | None:
+        """File holding the autostart entry (None on Windows, which uses the registry)."""
+        if self._platform == "darwin":
+            return self._home / "Library" / "LaunchAgents" / f"{LAUNCH_AGENT_LABEL}.plist"
+        if self._platform.startswith("linux"):
+            config_home = os.environ.get("XDG_CONFIG_HOME") or str(self._home / ".config")
+            return Path(config_home) / "autostart" / "crypto-monitor.desktop"
+        return None
+
+    def is_enabled(self) -> bool:
+        """Whether the app is registered to launch at login."""
+        try:
+            if self._platform == "win32":
+                return self._read_registry() is not None
+            path = self.entry_path
+            return path is not None and path.exists()
+        except OSError as e:
+            logger.warning(f"Failed to read autostart entry: {e}")
+            return False
+
+    def is_minimized(self) -> bool:
+        """Whether the registered entry starts the app minimized to the tray."""
+        try:
+            if self._platform == "win32":
+                entry = self._read_registry() or ""
+            else:
+                path = self.entry_path
+                entry = path.read_text(encoding="utf-8", errors="ignore") if path else ""
+        except OSError:
+            return False
+        return MINIMIZED_ARG in entry
+
+    def enable(self, minimized: bool = False) -> bool:
+        """Register the app to launch at login. Returns True on success."""
+        command = launch_command(minimized)
+        try:
+            if self._platform == "win32":
+                self._write_registry(windows_command_line(command))
+            elif self._platform == "darwin":
+                self._write_file(build_launch_agent(command))
+            elif self._platform.startswith("linux"):
+                self._write_file(build_desktop_entry(command).encode("utf-8"))
+            else:
+                logger.warning(f"Autostart not supported on {self._platform}")
+                return False
+        except OSError as e:
+            logger.error(f"Failed to enable autostart: {e}")
+            return False
+        logger.info(f"Autostart enabled{' (minimized)' if minimized else ''}")
+        return True
+
+    def disable(self) -> bool:
+        """Stop launching the app at login. Returns True on success."""
+        try:
+            if self._platform == "win32":
+                self._delete_registry()
+            else:
+                path = self.entry_path
+                if path is not None and path.exists():
+                    path.unlink()
+        except OSError as e:
+            logger.error(f"Failed to disable autostart: {e}")
+            return False
+        logger.info("Autostart disabled")
+        return True
+
+    def _write_file(self, content: bytes):
+        path = self.entry_path
+        path.parent.mkdir(parents=True, exist_ok=True)
+        path.write_bytes(content)
+
+    @staticmethod
+    def _read_registry() -> str | None:
+        import winreg
+
+        try:
+            with winreg.OpenKey(winreg.HKEY_CURRENT_USER, RUN_KEY) as key:
+                value, _type = winreg.QueryValueEx(key, REGISTRY_VALUE)
+                return value
+        except FileNotFoundError:
+            return None
+
+    @staticmethod
+    def _write_registry(command_line: str):
+        import winreg
+
+        with winreg.OpenKey(winreg.HKEY_CURRENT_USER, RUN_KEY, 0, winreg.KEY_SET_VALUE) as key:
+            winreg.SetValueEx(key, REGISTRY_VALUE, 0, winreg.REG_SZ, command_line)
+
+    @staticmethod
+    def _delete_registry():
+        import winreg
+
+        try:
+            with winreg.OpenKey(winreg.HKEY_CURRENT_USER, RUN_KEY, 0, winreg.KEY_SET_VALUE) as key:
+                winreg.DeleteValue(key, REGISTRY_VALUE)
+        except FileNotFoundError:
+            pass
+
+
+# Global autostart manager instance
+_autostart_manager: AutostartManager | None = None
+
+
+def get_autostart_manager() -> AutostartManager:
+    """Get the global autostart manager instance."""
+    global _autostart_manager
+    if _autostart_manager is None:
+        _autostart_manager = AutostartManager()
+    return _autostart_manager
@@ -18,6 +18,8 @@ class BaseExchangeClient(QObject):
     connection_state_changed = pyqtSignal(str, str, int)  # state, message, retry_count
     stats_updated = pyqtSignal(dict)  # connection statistics
     klines_ready = pyqtSignal(str, list)
+    trades_received = pyqtSignal(str, list)  # pair, [(price, size, timestamp)]
+    data_integrity = pyqtSignal(object)  # SequenceGap resynced after lost messages
     stopped = pyqtSignal()
 
     def __init__(self, parent: QObject | None = None):
@@ -0,0 +1,212 @@
+"""
+Synthetic load for the ticker pipeline.
+A fake exchange worker generates ticks at a fixed rate for a set of synthetic
+pairs. They pass through the worker's ticker buffer, the controller's
+processing and the UI batching like exchange ticks, and the throughput and
+latency of each stage are reported, so regressions in the event path can be
+measured.
+"""
+
+import asyncio
+import logging
+import math
+import random
+import time
+from dataclasses import dataclass, field
+
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from core.market_indices import change_percentage
+from core.models import TickerData
+from core.websocket_worker import BaseWebSocketWorker
+
+logger = logging.getLogger(__name__)
+
+BENCHMARK_QUOTE = "USDT"
+GENERATE_STEP = 0.005  # seconds between generator wake-ups
+PRICE_STEP = 0.0001  # relative move of each synthetic tick
+
+
+def synthetic_pairs(count: int) -> list[str]:
+    """Names of the synthetic pairs, e.g. "BENCH0-USDT"."""
+    return [f"BENCH{i}-{BENCHMARK_QUOTE}" for i in range(count)]
+
+
+@dataclass(slots=True)
+class SyntheticTicker(TickerData):
+    """Ticker stamped with the perf_counter time it was generated."""
+
+    generated_at: float = 0.0
+
+
+def percentile(values: list[float], pct: float) -> float:
+    """Nearest-rank percentile, 0.0 for no values."""
+    if not values:
+        return 0.0
+    ordered = sorted(values)
+    return ordered[max(0, math.ceil(len(ordered) * pct / 100) - 1)]
+
+
+class SyntheticTickWorker(BaseWebSocketWorker):
+    """Worker that generates ticks locally instead of reading an exchange feed."""
+
+    def __init__(self, pairs: list[str], rate: int, parent: QObject | None = None):
+        super().__init__(pairs, parent)
+        self.rate = rate
+        self.generated = 0
+        self._prices = {pair: 100.0 for pair in pairs}
+        self._generator: asyncio.Task | None = None
+
+    async def _connect_and_subscribe(self):
+        self._subscribed_pairs = set(self.pairs)
+        self._connection_start_time = time.time()
+        self._generator = asyncio.get_running_loop().create_task(self._generate())
+
+    async def _update_subscriptions(self):
+        self._subscribed_pairs = set(self.pairs)
+
+    async def _generate(self):
+        """Emit ticks evenly spread at the configured rate."""
+        started = time.perf_counter()
+        while self._running:
+            due = int((time.perf_counter() - started) * self.rate)
+            while self.generated < due:
+                self.generate_tick()
+            self._last_message_time = time.time()
+            self._update_stats_throttled()
+            await asyncio.sleep(GENERATE_STEP)
+
+    def generate_tick(self):
+        """Emit the next tick, cycling through the pairs."""
+        pair = self.pairs[self.generated % len(self.pairs)]
+        # Every tick moves the price, so none is skipped as unchanged downstream
+        price = self._prices[pair] * (1 + random.choice((-PRICE_STEP, PRICE_STEP)))
+        self._prices[pair] = price
+        self.generated += 1
+        self._emit_ticker(
+            pair,
+            SyntheticTicker(
+                pair=pair,
+                price=f"{price:.6f}",
+                percentage=change_percentage(price, 100.0),
+                generated_at=time.perf_counter(),
+            ),
+        )
+
+
+@dataclass
+class BenchmarkResult:
+    """Throughput and latency of one benchmark run; latencies in milliseconds."""
+
+    rate: int
+    pairs: int
+    duration: float
+    generated: int = 0
+    processed: int = 0
+    dropped: int = 0
+    batches: int = 0
+    batched_states: int = 0
+    process_latencies: list[float] = field(default_factory=list, repr=False)
+    batch_latencies: list[float] = field(default_factory=list, repr=False)
+
+    @property
+    def throughput(self) -> float:
+        """Ticks processed by the controller per second."""
+        return self.processed / self.duration if self.duration else 0.0
+
+    def summary(self) -> str:
+        lines = [
+            f"Target: {self.rate} msg/s over {self.pairs} pairs for {self.duration:.1f}s",
+            f"Generated: {self.generated}, processed: {self.processed} "
+            f"({self.throughput:.0f} msg/s), dropped in buffer: {self.dropped}",
+            f"UI batches: {self.batches}, states delivered: {self.batched_states}",
+        ]
+        for name, values in (
+            ("Tick to processed", self.process_latencies),
+            ("Tick to UI batch", self.batch_latencies),
+        ):
+            lines.append(
+                f"{name}: p50 {percentile(values, 50):.2f} ms, "
+                f"p95 {percentile(values, 95):.2f} ms, "
+                f"p99 {percentile(values, 99):.2f} ms, "
+                f"max {max(values, default=0.0):.2f} ms"
+            )
+        return "\n".join(lines)
+
+
+class PipelineBenchmark(QObject):
+    """Drives synthetic ticks through a controller for a fixed duration."""
+
+    finished = pyqtSignal(object)  # BenchmarkResult
+
+    def __init__(
+        self,
+        controller,
+        rate: int = 1000,
+        pairs: int = 50,
+        duration: float = 10.0,
+        parent: QObject | None = None,
+    ):
+        super().__init__(parent)
+        self._controller = controller
+        self._result = BenchmarkResult(rate=rate, pairs=pairs, duration=duration)
+        self._worker = SyntheticTickWorker(synthetic_pairs(pairs), rate)
+        # Generation time of each pair's latest processed tick, until it is batched
+        self._unbatched: dict[str, float] = {}
+        self._started_at = 0.0
+
+        self._timer = QTimer(self)
+        self._timer.setSingleShot(True)
+        self._timer.setInterval(int(duration * 1000))
+        self._timer.timeout.connect(self.finish)
+
+    @property
+    def worker(self) -> SyntheticTickWorker:
+        return self._worker
+
+    def start(self):
+        """Attach the synthetic feed and start generating."""
+        logger.info(
+            f"Benchmark: {self._result.rate} msg/s over {self._result.pairs} pairs "
+            f"for {self._result.duration:.1f}s"
+        )
+        self._controller.attach_tick_source(self._worker)
+        self._worker.ticker_updated.connect(self._on_processed)
+        self._controller.tickers_updated.connect(self._on_batch)
+        self._started_at = time.perf_counter()
+        self._worker.start()
+        self._timer.start()
+
+    def _on_processed(self, pair: str, data: SyntheticTicker):
+        # Connected after the controller, so this runs once it handled the tick
+        latency = time.perf_counter() - data.generated_at
+        self._result.processed += 1
+        self._result.process_latencies.append(latency * 1000)
+        self._unbatched[pair] = data.generated_at
+
+    def _on_batch(self, states: dict):
+        now = time.perf_counter()
+        self._result.batches += 1
+        for pair in states:
+            generated_at = self._unbatched.pop(pair, None)
+            if generated_at is not None:
+                self._result.batched_states += 1
+                self._result.batch_latencies.append((now - generated_at) * 1000)
+
+    def finish(self) -> BenchmarkResult:
+        """Stop generating, detach from the controller and report the result."""
+        self._timer.stop()
+        self._worker.stop()
+        self._worker.wait(2000)
+        self._controller.detach_tick_source(self._worker)
+        self._worker.ticker_updated.disconnect(self._on_processed)
+        self._controller.tickers_updated.disconnect(self._on_batch)
+
+        result = self._result
+        if self._started_at:
+            result.duration = time.perf_counter() - self._started_at
+        result.generated = self._worker.generated
+        result.dropped = self._worker.dropped_tickers
+        logger.info(f"Benchmark finished\n{result.summary()}")
+        self.finished.emit(result)
+        return result
@@ -10,7 +10,15 @@ from PyQt6.QtCore import QObject
 from config.settings import get_settings_manager
 from core.base_client import BaseExchangeClient
 from core.models import TickerData
-from core.utils.network import get_aiohttp_proxy_url, get_proxy_config
+from core.rate_limiter import get_rate_limiter
+from core.sequence_tracker import SequenceGap
+from core.utils import format_price
+from core.utils.network import (
+    apply_socket_buffers,
+    get_aiohttp_proxy_url,
+    get_proxy_config,
+    get_ws_tuning,
+)
 from core.websocket_worker import BaseWebSocketWorker
 from core.worker_controller import WorkerController
 
@@ -24,6 +32,7 @@ class BinanceWebSocketWorker(BaseWebSocketWorker):
     """
 
     WS_URL = "wss://stream.binance.com:9443/ws"
+    endpoint = WS_URL
 
     def __init__(self, pairs: list[str], parent: QObject | None = None):
         super().__init__(pairs, parent)
@@ -32,6 +41,7 @@ class BinanceWebSocketWorker(BaseWebSocketWorker):
         self._session: aiohttp.ClientSession | None = None
         self._ws: aiohttp.ClientWebSocketResponse | None = None
         self._read_task: asyncio.Task | None = None
+        self._request_id = 0  # ID of the last SUBSCRIBE/UNSUBSCRIBE request
 
     async def _send_ping(self):
         """Send ping to Binance."""
@@ -54,8 +64,13 @@ class BinanceWebSocketWorker(BaseWebSocketWorker):
 
         proxy_url = get_aiohttp_proxy_url()
 
+        tuning = get_ws_tuning("binance")
+
         self._session = aiohttp.ClientSession(trust_env=True)
-        self._ws = await self._session.ws_connect(self.WS_URL, proxy=proxy_url)
+        self._ws = await self._session.ws_connect(
+            self.WS_URL, proxy=proxy_url, compress=15 if tuning.compression else 0
+        )
+        apply_socket_buffers(self._ws.get_extra_info("socket"), tuning)
         self._connection_start_time = time.time()
 
         # Spawn read loop
@@ -71,6 +86,7 @@ class BinanceWebSocketWorker(BaseWebSocketWorker):
 
         try:
             proxy_url = get_aiohttp_proxy_url()
+            await get_rate_limiter().acquire_async("BINANCE")
 
             if self._session and not self._session.closed:
                 async with self._session.get(url, params=params, proxy=proxy_url) as response:
@@ -98,6 +114,33 @@ class BinanceWebSocketWorker(BaseWebSocketWorker):
         except Exception as e:
             logger.error(f"Failed to fetch klines async for {pair}: {e}")
 
+    async def _fetch_missed_trades(self, gap: SequenceGap) -> list[tuple[float, float, float]]:
+        """Aggregate trades of the gap, at most 1000."""
+        url = "https://api.binance.com/api/v3/aggTrades"
+        params = {
+            "symbol": gap.pair.replace("-", "").upper(),
+            "fromId": gap.expected,
+            "limit": min(gap.missing, 1000),
+        }
+        proxy_url = get_aiohttp_proxy_url()
+        await get_rate_limiter().acquire_async("BINANCE")
+
+        if self._session and not self._session.closed:
+            async with self._session.get(url, params=params, proxy=proxy_url) as response:
+                data = await response.json()
+        else:
+            async with aiohttp.ClientSession(trust_env=True) as session:
+                async with session.get(url, params=params, proxy=proxy_url) as response:
+                    data = await response.json()
+        if not isinstance(data, list):
+            raise RuntimeError(data.get("msg", "Unexpected response"))
+
+        return [
+            (float(item["p"]), float(item["q"]), item["T"] / 1000)
+            for item in data
+            if item["a"] < gap.received
+        ]
+
     async def _read_loop(self):
         """Read loop to handle incoming messages."""
         try:
@@ -106,10 +149,11 @@ class BinanceWebSocketWorker(BaseWebSocketWorker):
                     # aiohttp handles standard PING frames automatically (autoping=True).
                     msg = await self._ws.receive(timeout=1.0)
                     if msg.type == aiohttp.WSMsgType.TEXT:
-                        self._handle_message(msg.data)
+                        self._dispatch_message(msg.data)
                     elif msg.type == aiohttp.WSMsgType.PONG:
                         # Update heartbeat time when we receive a PONG from our manual ping
                         self._last_message_time = time.time()
+                        self._on_pong()
                     elif msg.type == aiohttp.WSMsgType.CLOSED:
                         break
                     elif msg.type == aiohttp.WSMsgType.ERROR:
@@ -120,6 +164,11 @@ class BinanceWebSocketWorker(BaseWebSocketWorker):
             logger.error(f"Binance read loop error: {e}")
             self._last_error = str(e)
 
+    def _server_close_code(self) -> int | None:
+        if self._ws is None or not self._ws.closed:
+            return None
+        return self._ws.close_code
+
     async def _update_subscriptions(self):
         """Update subscriptions incrementally."""
         current_pairs = set(self.pairs)
@@ -142,14 +191,11 @@ class BinanceWebSocketWorker(BaseWebSocketWorker):
                 streams = [f"{p.replace('-', '').lower()}@kline_1d" for p in new_pairs]
             else:
                 streams = [f"{p.replace('-', '').lower()}@ticker" for p in new_pairs]
+            streams += [f"{p.replace('-', '').lower()}@aggTrade" for p in new_pairs]
 
-            if streams:
-                subscribe_msg = {
-                    "method": "SUBSCRIBE",
-                    "params": streams,
-                    "id": int(time.time() * 1000),  # Unique ID
-                }
-                await self._ws.send_json(subscribe_msg)
+            await self._send_batched(
+                streams, lambda batch: self._send_stream_request("SUBSCRIBE", batch)
+            )
 
         # Unsubscribe from removed
         if removed_pairs:
@@ -157,23 +203,25 @@ class BinanceWebSocketWorker(BaseWebSocketWorker):
             streams_ticker = [f"{p.replace('-', '').lower()}@ticker" for p in removed_pairs]
             streams_kline = [f"{p.replace('-', '').lower()}@kline_1d" for p in removed_pairs]
 
+            streams_trades = [f"{p.replace('-', '').lower()}@aggTrade" for p in removed_pairs]
+
             # Combine unsub requests
-            streams = streams_ticker + streams_kline
-
-            if streams:
-                unsubscribe_msg = {
-                    "method": "UNSUBSCRIBE",
-                    "params": streams,
-                    "id": int(time.time() * 1000) + 1,
-                }
-                try:
-                    await self._ws.send_json(unsubscribe_msg)
-                except Exception:
-                    pass
+            streams = streams_ticker + streams_kline + streams_trades
+
+            try:
+                await self._send_batched(
+                    streams, lambda batch: self._send_stream_request("UNSUBSCRIBE", batch)
+                )
+            except Exception:
+                pass
 
         self._subscribed_pairs = current_pairs
         self._update_stats()
 
+    async def _send_stream_request(self, method: str, streams: list[str]):
+        self._request_id += 1
+        await self._ws.send_json({"method": method, "params": streams, "id": self._request_id})
+
     def _handle_message(self, message):
         try:
             self._last_message_time = time.time()
@@ -218,7 +266,15 @@ class BinanceWebSocketWorker(BaseWebSocketWorker):
                     symbol, price_str, percent_val, high_24h, low_24h, quote_volume
                 )
 
-            self._update_stats()
+            # Handle Aggregated Trade Event (VWAP)
+            elif data.get("e") == "aggTrade":
+                pair = self._symbol_map.get(data.get("s", "").lower())
+                if pair:
+                    trade = (float(data["p"]), float(data["q"]), data["T"] / 1000)
+                    self._track_sequence("aggTrade", pair, data["a"], data["a"])
+                    self.trades_received.emit(pair, [trade])
+
+            self._update_stats_throttled()
 
         except Exception as e:
             self._last_error = f"Message error: {e}"
@@ -234,8 +290,6 @@ class BinanceWebSocketWorker(BaseWebSocketWorker):
                 except Exception:
                     price = price_str
             else:
-                from core.utils import format_price
-
                 price = format_price(price_str)
 
             original_pair = self._symbol_map.get(symbol)
@@ -255,7 +309,7 @@ class BinanceWebSocketWorker(BaseWebSocketWorker):
                     quote_volume_24h=quote_volume,
                 )
 
-                self.ticker_updated.emit(original_pair, ticker_obj)
+                self._emit_ticker(original_pair, ticker_obj)
         except Exception as e:
             logger.error(f"Error processing ticker data: {e}")
 
@@ -293,19 +347,10 @@ class BinanceClient(BaseExchangeClient):
 
         def fetch():
             try:
-                settings = get_settings_manager().settings
-                proxies = {}
-                if settings.proxy.enabled:
-                    if settings.proxy.type == "http":
-                        proxy_url = settings.proxy.get_proxy_url()
-                        proxies = {"http": proxy_url, "https": proxy_url}
-                    elif settings.proxy.type == "socks5":
-                        proxy_url = settings.proxy.get_proxy_url()
-                        proxies = {"http": proxy_url, "https": proxy_url}
-
+                get_rate_limiter().acquire("BINANCE")
                 response = requests.get(
                     "https://api.binance.com/api/v3/exchangeInfo",
-                    proxies=proxies,
+                    proxies=get_proxy_config(),
                     timeout=10,
                 )
                 data = response.json()
@@ -396,6 +441,8 @@ class BinanceClient(BaseExchangeClient):
         self._worker.connection_state_changed.connect(self.connection_state_changed)
         self._worker.stats_updated.connect(self.stats_updated)
         self._worker.klines_ready.connect(self.klines_ready)
+        self._worker.trades_received.connect(self.trades_received)
+        self._worker.data_integrity.connect(self.data_integrity)
 
         WorkerController.get_instance().register_worker(self._worker)
         self._worker.start()
@@ -422,6 +469,7 @@ class BinanceClient(BaseExchangeClient):
                 "state": state,
                 "subscribed_pairs": len(self._pairs),
                 "worker_running": self._worker.isRunning(),
+                "dropped_tickers": self._worker.dropped_tickers,
             }
 
     def request_klines(self, pair: str, interval: str, limit: int = 24):
@@ -440,6 +488,7 @@ class BinanceClient(BaseExchangeClient):
 
         try:
             proxies = get_proxy_config()
+            get_rate_limiter().acquire("BINANCE")
             response = requests.get(url, params=params, proxies=proxies, timeout=5)
             response.raise_for_status()
             data = response.json()
@@ -0,0 +1,113 @@
+"""
+Candle aggregation from live ticks.
+Builds OHLCV bars from ticker prices and trades for sources without klines
+(e.g. DEX pairs) and serves them in the same format as fetch_klines.
+"""
+
+import logging
+import threading
+import time
+
+from config.settings import get_settings_manager
+from core.indicators import MAX_CANDLES, CandleSeries, interval_seconds
+
+logger = logging.getLogger(__name__)
+
+
+class CandleAggregator:
+    """Tick-built candle series of all monitored pairs at the configured intervals."""
+
+    def __init__(self):
+        self._settings_manager = get_settings_manager()
+        self._pairs: list[str] = []
+        self._series: dict[tuple[str, str], CandleSeries] = {}
+        self._max_candles = MAX_CANDLES
+        # fetch_klines is called from worker threads
+        self._lock = threading.Lock()
+
+    def _intervals(self) -> list[str]:
+        intervals = []
+        for interval in self._settings_manager.settings.tick_candle_intervals:
+            try:
+                interval_seconds(interval)
+            except ValueError:
+                logger.warning(f"Ignoring invalid tick candle interval: {interval}")
+                continue
+            intervals.append(interval)
+        return intervals
+
+    def set_pairs(self, pairs: list[str]):
+        """Drop series of pairs no longer monitored."""
+        with self._lock:
+            self._pairs = list(pairs)
+            for key in [key for key in self._series if key[0] not in self._pairs]:
+                del self._series[key]
+
+    def add_price(self, pair: str, price: float, timestamp: float | None = None):
+        """Apply a ticker price to every interval of the pair."""
+        if price <= 0:
+            return
+        self._update(pair, [(price, 0.0, time.time() if timestamp is None else timestamp)])
+
+    def add_trades(self, pair: str, trades: list):
+        """Add trades as (price, size, timestamp) tuples from the trades channel."""
+        self._update(pair, [trade for trade in trades if trade[0] > 0])
+
+    def _update(self, pair: str, ticks: list):
+        if pair not in self._pairs or not ticks:
+            return
+        with self._lock:
+            for interval in self._intervals():
+                series = self._series.get((pair, interval))
+                if series is None:
+                    series = CandleSeries(interval, self._max_candles)
+                    self._series[(pair, interval)] = series
+                for price, size, timestamp in ticks:
+                    series.update(price, timestamp, size)
+
+    def set_max_candles(self, max_candles: int):
+        """Cap the candles kept per series, trimming existing ones."""
+        with self._lock:
+            self._max_candles = max_candles
+            for series in self._series.values():
+                series.resize(max_candles)
+
+    def history_size(self) -> tuple[int, int]:
+        """Number of series and the candles they hold."""
+        with self._lock:
+            return len(self._series), sum(len(series) for series in self._series.values())
+
+    def fetch_klines(self, pair: str, interval: str, limit: int = MAX_CANDLES) -> list[dict]:
+        """
+        Aggregated candles in the format of BaseExchangeClient.fetch_klines.
+
+        Returns:
+            Up to limit candles, oldest first (timestamp in ms); empty if the
+            interval is not aggregated or no ticks arrived yet
+        """
+        with self._lock:
+            series = self._series.get((pair, interval))
+            candles = series.candles()[-limit:] if series else []
+        return [
+            {
+                "timestamp": c.open_time * 1000,
+                "open": c.open,
+                "high": c.high,
+                "low": c.low,
+                "close": c.close,
+                "volume": c.volume,
+            }
+            for c in candles
+        ]
+
+
+# Global candle aggregator instance
+_candle_aggregator: CandleAggregator | None = None
+
+
+def get_candle_aggregator() -> CandleAggregator:
+    """Get the global candle aggregator instance."""
+    global _candle_aggregator
+    if _candle_aggregator is None:
+        _candle_aggregator = CandleAggregator()
+    return _candle_aggregator
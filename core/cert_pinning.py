@@ -0,0 +1,154 @@
+"""
+TLS certificate pinning for exchange endpoints.
+When enabled, the public keys of the exchange endpoints are remembered the
+first time they are seen (or when the user trusts the current ones) and
+checked on every start through the same proxy the data flows through. A
+changed key points at a man-in-the-middle, or at a corporate proxy that
+re-signs TLS, which the user can trust explicitly.
+"""
+
+import base64
+import hashlib
+import logging
+import ssl
+import threading
+from dataclasses import dataclass, field
+
+from PyQt6.QtCore import QObject, pyqtSignal
+
+from config.settings import ProxyConfig, get_settings_manager
+from core.utils.network import open_tunnel
+
+logger = logging.getLogger(__name__)
+
+# Endpoints checked per data source, as (host, port)
+PINNED_ENDPOINTS = {
+    "OKX": (("www.okx.com", 443), ("ws.okx.com", 8443)),
+    "BINANCE": (("api.binance.com", 443), ("stream.binance.com", 9443)),
+}
+
+def spki_pin(der_cert: bytes) -> str:
+    """Base64 SHA-256 of a certificate's SubjectPublicKeyInfo, as used by HPKP."""
+    from cryptography import x509
+    from cryptography.hazmat.primitives.serialization import Encoding, PublicFormat
+
+    public_key = x509.load_der_x509_certificate(der_cert).public_key()
+    spki = public_key.public_bytes(Encoding.DER, PublicFormat.SubjectPublicKeyInfo)
+    return base64.b64encode(hashlib.sha256(spki).digest()).decode("ascii")
+
+
+def fetch_pin(host: str, port: int, proxy: ProxyConfig) -> str:
+    """Pin of the certificate an endpoint presents."""
+    context = ssl.create_default_context()
+    with open_tunnel(host, port, proxy) as sock:
+        with context.wrap_socket(sock, server_hostname=host) as tls:
+            der_cert = tls.getpeercert(binary_form=True)
+    return spki_pin(der_cert)
+
+
+@dataclass
+class PinCheckResult:
+    """Outcome of checking a set of endpoints against the known pins."""
+
+    learned: dict[str, str] = field(default_factory=dict)  # host -> pin seen for the first time
+    mismatched: list[str] = field(default_factory=list)  # hosts presenting an unknown key
+    failed: list[str] = field(default_factory=list)  # hosts that couldn't be reached
+
+
+def check_pins(endpoints, known: dict[str, list[str]], fetch) -> PinCheckResult:
+    """
+    Compare the endpoints' current pins with the known ones.
+
+    Args:
+        endpoints: (host, port) pairs to check
+        known: Host -> accepted pins
+        fetch: Callable (host, port) -> pin
+    """
+    result = PinCheckResult()
+    for host, port in endpoints:
+        try:
+            pin = fetch(host, port)
+        except Exception as e:
+            logger.warning(f"Certificate check of {host}:{port} failed: {e}")
+            result.failed.append(host)
+            continue
+        accepted = known.get(host)
+        if not accepted:
+            result.learned[host] = pin
+        elif pin not in accepted:
+            logger.warning(f"Certificate of {host} doesn't match the pinned key: {pin}")
+            result.mismatched.append(host)
+    return result
+
+
+class CertPinningService(QObject):
+    """Checks the pins of the current data source's endpoints in the background."""
+
+    # Hosts presenting a key other than the pinned one
+    pin_mismatch = pyqtSignal(list)
+    # Hosts whose current keys were stored by trust_current()
+    pins_updated = pyqtSignal(list)
+
+    _checked = pyqtSignal(object, bool)  # PinCheckResult, trusting (to main thread)
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._checking = False
+        self._checked.connect(self._apply_result)
+
+    @property
+    def enabled(self) -> bool:
+        return self._settings_manager.settings.cert_pinning
+
+    def check(self):
+        """Check the current data source's endpoints if pinning is enabled."""
+        if self.enabled:
+            self._start(trusting=False)
+
+    def trust_current(self):
+        """Replace the pins with the keys the endpoints present now, e.g. behind a TLS proxy."""
+        self._start(trusting=True)
+
+    def _start(self, trusting: bool):
+        if self._checking:
+            return
+        self._checking = True
+        settings = self._settings_manager.settings
+        endpoints = PINNED_ENDPOINTS.get(settings.data_source.upper(), ())
+        known = {} if trusting else dict(settings.cert_pins)
+        proxy = self._settings_manager.proxy_store.get()
+        threading.Thread(
+            target=self._run, args=(endpoints, known, proxy, trusting), daemon=True
+        ).start()
+
+    def _run(self, endpoints, known: dict[str, list[str]], proxy: ProxyConfig, trusting: bool):
+        try:
+            result = check_pins(endpoints, known, lambda host, port: fetch_pin(host, port, proxy))
+            self._checked.emit(result, trusting)
+        finally:
+            self._checking = False
+
+    def _apply_result(self, result: PinCheckResult, trusting: bool):
+        if result.learned:
+            pins = dict(self._settings_manager.settings.cert_pins)
+            for host, pin in result.learned.items():
+                pins[host] = [pin]
+            self._settings_manager.update_cert_pins(pins)
+            logger.info(f"Pinned certificates of {', '.join(result.learned)}")
+        if trusting:
+            self.pins_updated.emit(list(result.learned))
+        elif result.mismatched:
+            self.pin_mismatch.emit(result.mismatched)
+
+
+# Global certificate pinning service instance
+_cert_pinning_service: CertPinningService | None = None
+
+
+def get_cert_pinning_service() -> CertPinningService:
+    """Get the global certificate pinning service instance."""
+    global _cert_pinning_service
+    if _cert_pinning_service is None:
+        _cert_pinning_service = CertPinningService()
+    return _cert_pinning_service
@@ -0,0 +1,231 @@
+"""
+Chainlink oracle price source.
+Reads Chainlink price feed (aggregator) contracts over JSON-RPC so oracle
+prices can be monitored like another exchange and compared with the
+exchange prices of the same asset.
+
+A feed is stored in the pair list as "oracle:<network>:<feed address>".
+"""
+
+import logging
+import threading
+import time
+from dataclasses import dataclass
+
+from PyQt6.QtCore import QTimer
+
+from core.base_client import BaseExchangeClient
+from core.messages import message
+from core.models import TickerData
+from core.pool_client import (
+    DECIMALS_SELECTOR,
+    DailyStats,
+    RpcError,
+    decode_string,
+    decode_uint,
+    eth_call_batch,
+    get_rpc_url,
+)
+
+logger = logging.getLogger(__name__)
+
+ORACLE_PREFIX = "oracle:"
+
+# Function selectors
+LATEST_ROUND_DATA_SELECTOR = "0xfeaf968c"
+DESCRIPTION_SELECTOR = "0x7284e416"
+
+# Block explorers for opening a feed contract
+EXPLORER_URLS = {
+    "ethereum": "https://etherscan.io/address/",
+    "bsc": "https://bscscan.com/address/",
+    "base": "https://basescan.org/address/",
+    "arbitrum": "https://arbiscan.io/address/",
+    "polygon": "https://polygonscan.com/address/",
+}
+
+USD_QUOTES = ("USD", "USDT", "USDC")
+
+
+@dataclass
+class FeedInfo:
+    """Static data of a price feed."""
+
+    description: str  # e.g. "ETH / USD"
+    decimals: int
+
+    @property
+    def base(self) -> str:
+        return self.description.split("/")[0].strip().upper()
+
+    @property
+    def quote(self) -> str:
+        parts = self.description.split("/")
+        return parts[1].strip().upper() if len(parts) > 1 else ""
+
+
+def is_oracle_pair(pair: str) -> bool:
+    return pair.lower().startswith(ORACLE_PREFIX)
+
+
+def make_oracle_pair(network: str, address: str) -> str:
+    """Build the pair ID of a price feed."""
+    return f"{ORACLE_PREFIX}{network.strip().lower()}:{address.strip().lower()}"
+
+
+def parse_oracle_pair(pair: str) -> tuple[str, str]:
+    """
+    Split a feed pair ID.
+
+    Returns:
+        (network, address)
+    """
+    _prefix, network, address = (pair.split(":", 2) + ["", ""])[:3]
+    return network, address
+
+
+def get_explorer_url(pair: str) -> str:
+    """Block explorer page of a feed contract, or "" for unknown networks."""
+    network, address = parse_oracle_pair(pair)
+    base_url = EXPLORER_URLS.get(network)
+    return f"{base_url}{address}" if base_url and address else ""
+
+
+def decode_int(result: str, index: int = 0) -> int:
+    """The index-th word of an ABI-encoded result as a signed (int256) integer."""
+    value = decode_uint(result, index)
+    return value - 2**256 if value >= 2**255 else value
+
+
+class OracleComparator:
+    """Latest oracle prices by asset, to compare exchange prices against."""
+
+    def __init__(self):
+        self._prices: dict[str, tuple[float, str]] = {}  # base -> (price, quote)
+
+    def update(self, base: str, quote: str, price: float):
+        self._prices[base.upper()] = (price, quote.upper())
+
+    def deviation(self, pair: str, price: float) -> tuple[float, float] | None:
+        """
+        Compare an exchange price with the oracle price of the same asset.
+
+        Returns:
+            (oracle price, exchange premium over the oracle in percent), or None
+            when no comparable feed is known
+        """
+        base, _sep, quote = pair.upper().partition("-")
+        oracle = self._prices.get(base)
+        if oracle is None or not oracle[0]:
+            return None
+        oracle_price, oracle_quote = oracle
+        # Stablecoin quotes are treated as USD
+        if quote != oracle_quote and not (quote in USD_QUOTES and oracle_quote in USD_QUOTES):
+            return None
+        return oracle_price, (price - oracle_price) / oracle_price * 100
+
+
+class ChainlinkClient(BaseExchangeClient):
+    """
+    Polls the latest answer of the subscribed Chainlink feeds over RPC.
+
+    Feeds update on deviation or heartbeat, so polling faster than this gains
+    little; the change percentage and high/low are tracked by DailyStats.
+    """
+
+    POLL_INTERVAL_MS = 30000
+
+    def __init__(self, parent=None):
+        super().__init__(parent)
+        self._pairs: set[str] = set()
+        self._feed_info: dict[str, FeedInfo] = {}  # pair -> info
+        self._day_stats = DailyStats()
+        self._is_connected = False
+        self._fetching = False
+        self._timer = QTimer(self)
+        self._timer.timeout.connect(self._poll_data)
+        self._timer.setInterval(self.POLL_INTERVAL_MS)
+
+    def subscribe(self, pairs: list[str]):
+        self._pairs = {p for p in pairs if is_oracle_pair(p)}
+        if not self._pairs:
+            self._timer.stop()
+            return
+
+        logger.info(f"Subscribing to oracle feeds: {sorted(self._pairs)}")
+        if not self._timer.isActive():
+            self._timer.start()
+        self._poll_data()
+
+    def stop(self):
+        self._timer.stop()
+        self._pairs.clear()
+        self._is_connected = False
+        self.stopped.emit()
+
+    def reconnect(self):
+        self._poll_data()
+
+    def get_stats(self):
+        return {"type": "RPC Polling", "interval": "30s", "pairs": len(self._pairs)}
+
+    def fetch_klines(self, pair: str, interval: str, limit: int) -> list[dict]:
+        # Candles are aggregated from the polled answers
+        return []
+
+    @property
+    def is_connected(self) -> bool:
+        return self._is_connected
+
+    def _poll_data(self):
+        if self._fetching or not self._pairs:
+            return
+        self._fetching = True
+        threading.Thread(target=self._poll, args=(sorted(self._pairs),), daemon=True).start()
+
+    def _poll(self, pairs: list[str]):
+        try:
+            updated = 0
+            for pair in pairs:
+                try:
+                    ticker = self._read_feed(pair)
+                except (RpcError, ValueError) as e:
+                    logger.warning(f"Failed to read oracle feed {pair}: {e}")
+                    continue
+                self.ticker_updated.emit(pair, ticker)
+                updated += 1
+        finally:
+            self._fetching = False
+
+        connected = updated > 0
+        if connected != self._is_connected:
+            self._is_connected = connected
+            self.connection_status.emit(
+                connected, message("connected_rpc" if connected else "rpc_failed")
+            )
+
+    def _read_feed(self, pair: str) -> TickerData:
+        network, address = parse_oracle_pair(pair)
+        url = get_rpc_url(network)
+        if not url:
+            raise ValueError(f"No RPC endpoint for network '{network}'")
+
+        info = self._feed_info.get(pair)
+        if info is None:
+            decimals, description = eth_call_batch(
+                url, [(address, DECIMALS_SELECTOR), (address, DESCRIPTION_SELECTOR)]
+            )
+            info = FeedInfo(description=decode_string(description), decimals=decode_uint(decimals))
+            self._feed_info[pair] = info
+
+        (round_data,) = eth_call_batch(url, [(address, LATEST_ROUND_DATA_SELECTOR)])
+        answer = decode_int(round_data, 1)
+        updated_at = decode_uint(round_data, 3)
+        if answer <= 0:
+            raise ValueError(f"Invalid answer {answer}")
+        age = time.time() - updated_at
+        if age > 24 * 60 * 60:
+            logger.warning(f"Oracle feed {info.description} is stale ({age / 3600:.0f}h old)")
+
+        price = answer / 10**info.decimals
+        return self._day_stats.to_ticker(pair, price, info.base, info.quote)
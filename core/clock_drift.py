@@ -0,0 +1,115 @@
+"""
+System clock drift check.
+Asks an NTP server for the time at startup and warns when the system clock
+is off by more than a threshold, since changes against the daily open and
+alert timestamps are computed from the local clock. NTP uses UDP, so the
+check goes direct rather than through the proxy.
+"""
+
+import logging
+import socket
+import struct
+import threading
+import time
+
+from PyQt6.QtCore import QObject, pyqtSignal
+
+from config.settings import get_settings_manager
+
+logger = logging.getLogger(__name__)
+
+NTP_SERVERS = ("pool.ntp.org", "time.cloudflare.com", "time.google.com")
+NTP_PORT = 123
+NTP_EPOCH_OFFSET = 2208988800  # Seconds from 1900, the NTP epoch, to 1970
+
+
+def _ntp_time(raw: bytes) -> float:
+    seconds, fraction = struct.unpack("!II", raw)
+    return seconds - NTP_EPOCH_OFFSET + fraction / 2**32
+
+
+def parse_ntp_offset(data: bytes, sent_at: float, received_at: float) -> float:
+    """
+    Offset of the server's clock from the system clock in an SNTP reply.
+
+    Positive when the system clock is behind the server.
+    """
+    if len(data) < 48:
+        raise ValueError(f"NTP reply too short: {len(data)} bytes")
+    server_received = _ntp_time(data[32:40])
+    server_sent = _ntp_time(data[40:48])
+    return ((server_received - sent_at) + (server_sent - received_at)) / 2
+
+
+def ntp_offset(server: str, timeout: float = 3.0) -> float:
+    """Query an NTP server and return the system clock's offset from it in seconds."""
+    request = b"\x1b" + 47 * b"\0"  # Version 3, client mode
+    with socket.socket(socket.AF_INET, socket.SOCK_DGRAM) as sock:
+        sock.settimeout(timeout)
+        sent_at = time.time()
+        sock.sendto(request, (server, NTP_PORT))
+        data, _address = sock.recvfrom(512)
+        received_at = time.time()
+    return parse_ntp_offset(data, sent_at, received_at)
+
+
+class ClockDriftChecker(QObject):
+    """Measures the system clock's drift in the background."""
+
+    # Offset in seconds, when it exceeds the threshold
+    drift_detected = pyqtSignal(float)
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._checking = False
+        self._offset: float | None = None
+
+    @property
+    def offset(self) -> float | None:
+        """Last measured offset in seconds, None before a server answered."""
+        return self._offset
+
+    def check(self):
+        """Measure the drift in a background thread."""
+        if self._checking:
+            return
+        self._checking = True
+        threading.Thread(target=self._run, daemon=True).start()
+
+    def _run(self):
+        try:
+            self.measure()
+        finally:
+            self._checking = False
+
+    def measure(self) -> float | None:
+        """Ask the servers in turn; emits drift_detected if the first answer is off."""
+        for server in NTP_SERVERS:
+            try:
+                offset = ntp_offset(server)
+            except (OSError, ValueError) as e:
+                logger.debug(f"NTP query to {server} failed: {e}")
+                continue
+            self._offset = offset
+            threshold = self._settings_manager.settings.clock_drift_threshold
+            if threshold > 0 and abs(offset) > threshold:
+                logger.warning(f"System clock is off by {offset:+.1f}s according to {server}")
+                self.drift_detected.emit(offset)
+            else:
+                logger.info(f"System clock offset {offset:+.3f}s according to {server}")
+            return offset
+        logger.info("Clock drift not checked: no NTP server reachable")
+        return None
+
+
+# Global clock drift checker instance
+_clock_drift_checker: ClockDriftChecker | None = None
+
+
+def get_clock_drift_checker() -> ClockDriftChecker:
+    """Get the global clock drift checker instance."""
+    global _clock_drift_checker
+    if _clock_drift_checker is None:
+        _clock_drift_checker = ClockDriftChecker()
+    return _clock_drift_checker
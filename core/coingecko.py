@@ -0,0 +1,423 @@
+"""
+CoinGecko market data.
+Fetches market cap, rank, circulating supply and a 7-day sparkline for the
+monitored assets, which exchange tickers do not provide, and global market
+metrics (BTC dominance, total and altcoin market cap) as index tickers.
+Uses the keyless public API, so requests are cached and rate limited.
+"""
+
+import logging
+import threading
+import time
+from dataclasses import dataclass, field
+
+import requests
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from core.market_indices import (
+    ALT_MCAP_PAIR,
+    BTC_DOMINANCE_PAIR,
+    INDEX_NAMES,
+    TOTAL_MCAP_PAIR,
+    change_percentage,
+)
+from core.models import TickerData
+from core.utils.network import get_proxy_config
+
+logger = logging.getLogger(__name__)
+
+# Symbols shared by several coins; CoinGecko search would rank another coin first
+KNOWN_COIN_IDS = {
+    "BTC": "bitcoin",
+    "ETH": "ethereum",
+    "USDT": "tether",
+    "BNB": "binancecoin",
+    "SOL": "solana",
+    "XRP": "ripple",
+    "DOGE": "dogecoin",
+}
+
+
+@dataclass
+class CoinMarketData:
+    """Market data of one coin (amounts in USD)."""
+
+    coin_id: str
+    symbol: str
+    name: str
+    market_cap: float | None = None
+    market_cap_rank: int | None = None
+    market_cap_change_pct_24h: float | None = None
+    circulating_supply: float | None = None
+    total_supply: float | None = None
+    max_supply: float | None = None
+    sparkline_7d: list[float] = field(default_factory=list)  # Hourly prices, oldest first
+    updated_at: float = 0.0
+
+    @staticmethod
+    def from_dict(data: dict) -> "CoinMarketData":
+        """Parse an entry of the /coins/markets response."""
+        sparkline = (data.get("sparkline_in_7d") or {}).get("price") or []
+        return CoinMarketData(
+            coin_id=data["id"],
+            symbol=str(data.get("symbol", "")).upper(),
+            name=data.get("name", ""),
+            market_cap=data.get("market_cap"),
+            market_cap_rank=data.get("market_cap_rank"),
+            market_cap_change_pct_24h=data.get("market_cap_change_percentage_24h"),
+            circulating_supply=data.get("circulating_supply"),
+            total_supply=data.get("total_supply"),
+            max_supply=data.get("max_supply"),
+            sparkline_7d=[float(p) for p in sparkline if p is not None],
+            updated_at=time.time(),
+        )
+
+
+@dataclass
+class TrendingCoin:
+    """A coin on CoinGecko's trending list (most searched in the last 24 hours)."""
+
+    coin_id: str
+    symbol: str
+    name: str
+    market_cap_rank: int | None = None
+    price_change_pct_24h: float | None = None
+    thumb: str = ""  # Icon URL
+
+    @staticmethod
+    def from_dict(data: dict) -> "TrendingCoin":
+        """Parse an item of the /search/trending coins list."""
+        change = ((data.get("data") or {}).get("price_change_percentage_24h") or {}).get("usd")
+        return TrendingCoin(
+            coin_id=data["id"],
+            symbol=str(data.get("symbol", "")).upper(),
+            name=data.get("name", ""),
+            market_cap_rank=data.get("market_cap_rank"),
+            price_change_pct_24h=float(change) if change is not None else None,
+            thumb=data.get("thumb", ""),
+        )
+
+
+@dataclass
+class GlobalMetrics:
+    """Market-wide metrics (amounts in USD) and their values 24 hours ago."""
+
+    total_market_cap: float
+    btc_dominance: float  # Percent of the total market cap
+    previous_total_market_cap: float | None = None
+    previous_btc_dominance: float | None = None
+
+    @property
+    def alt_market_cap(self) -> float:
+        return self.total_market_cap * (1 - self.btc_dominance / 100)
+
+    @property
+    def previous_alt_market_cap(self) -> float | None:
+        if self.previous_total_market_cap is None or self.previous_btc_dominance is None:
+            return None
+        return self.previous_total_market_cap * (1 - self.previous_btc_dominance / 100)
+
+    @staticmethod
+    def from_global(data: dict, btc_change_pct: float | None) -> "GlobalMetrics":
+        """
+        Parse the /global response.
+
+        Args:
+            btc_change_pct: 24h change of the BTC market cap, used to derive
+                yesterday's dominance (the API only reports the current one)
+        """
+        total = float(data["total_market_cap"]["usd"])
+        dominance = float(data["market_cap_percentage"]["btc"])
+        metrics = GlobalMetrics(total_market_cap=total, btc_dominance=dominance)
+        total_change = data.get("market_cap_change_percentage_24h_usd")
+        if total_change is not None:
+            metrics.previous_total_market_cap = total / (1 + total_change / 100)
+            if btc_change_pct is not None:
+                previous_btc = total * dominance / 100 / (1 + btc_change_pct / 100)
+                metrics.previous_btc_dominance = (
+                    previous_btc / metrics.previous_total_market_cap * 100
+                )
+        return metrics
+
+    def to_tickers(self) -> list[TickerData]:
+        return [
+            TickerData(
+                pair=pair,
+                price=f"{value:.2f}",
+                percentage=change_percentage(value, previous),
+                display_name=INDEX_NAMES[pair],
+            )
+            for pair, value, previous in (
+                (BTC_DOMINANCE_PAIR, self.btc_dominance, self.previous_btc_dominance),
+                (TOTAL_MCAP_PAIR, self.total_market_cap, self.previous_total_market_cap),
+                (ALT_MCAP_PAIR, self.alt_market_cap, self.previous_alt_market_cap),
+            )
+        ]
+
+
+def get_base_symbol(pair: str) -> str | None:
+    """Base asset of a pair for CoinGecko lookups, or None for pairs without one."""
+    lower = pair.lower()
+    if lower.startswith(("virtual:", "pool:", "oracle:")):
+        return None
+    if lower.startswith("chain:"):
+        parts = pair.split(":")
+        return parts[3].upper() if len(parts) >= 4 and parts[3] else None
+    return pair.split("-")[0].upper() or None
+
+
+class CoinGeckoClient:
+    """
+    Minimal CoinGecko API client.
+
+    Spaces requests out to stay under the public rate limit and backs off
+    when the API answers 429.
+    """
+
+    BASE_URL = "https://api.coingecko.com/api/v3"
+    MIN_REQUEST_INTERVAL = 2.5  # ~24 requests per minute
+    DEFAULT_BACKOFF = 60
+    ID_CACHE_TTL = 24 * 60 * 60
+
+    def __init__(self):
+        self._session = requests.Session()
+        self._session.headers["Accept"] = "application/json"
+        self._lock = threading.Lock()
+        self._last_request = 0.0
+        self._blocked_until = 0.0
+        self._ids: dict[str, tuple[str | None, float]] = {}  # symbol -> (coin ID, resolved at)
+
+    def _get(self, path: str, params: dict | None = None):
+        """Rate-limited GET returning the decoded JSON, or None on failure."""
+        with self._lock:
+            now = time.time()
+            if now < self._blocked_until:
+                logger.debug(f"CoinGecko rate limited, skipping {path}")
+                return None
+            wait = self._last_request + self.MIN_REQUEST_INTERVAL - now
+            if wait > 0:
+                time.sleep(wait)
+            self._last_request = time.time()
+
+            try:
+                response = self._session.get(
+                    f"{self.BASE_URL}{path}",
+                    params=params,
+                    proxies=get_proxy_config(),
+                    timeout=10,
+                )
+                if response.status_code == 429:
+                    retry_after = response.headers.get("Retry-After", "")
+                    backoff = int(retry_after) if retry_after.isdigit() else self.DEFAULT_BACKOFF
+                    self._blocked_until = time.time() + backoff
+                    logger.warning(f"CoinGecko rate limit hit, backing off {backoff}s")
+                    return None
+                response.raise_for_status()
+                return response.json()
+            except Exception as e:
+                logger.warning(f"CoinGecko request {path} failed: {e}")
+                return None
+
+    def resolve_id(self, symbol: str) -> str | None:
+        """Coin ID of a ticker symbol, preferring the highest-ranked match."""
+        symbol = symbol.upper()
+        if symbol in KNOWN_COIN_IDS:
+            return KNOWN_COIN_IDS[symbol]
+        cached = self._ids.get(symbol)
+        if cached and time.time() - cached[1] < self.ID_CACHE_TTL:
+            return cached[0]
+
+        data = self._get("/search", {"query": symbol})
+        if data is None:
+            return cached[0] if cached else None
+        # Search results are ordered by market cap rank
+        coin_id = next(
+            (c["id"] for c in data.get("coins", []) if str(c.get("symbol", "")).upper() == symbol),
+            None,
+        )
+        self._ids[symbol] = (coin_id, time.time())
+        return coin_id
+
+    def fetch_global(self) -> dict | None:
+        """The data object of /global, or None if the request failed."""
+        data = self._get("/global")
+        return data.get("data") if isinstance(data, dict) else None
+
+    def fetch_trending(self) -> list[TrendingCoin] | None:
+        """Trending coins in rank order, or None if the request failed."""
+        data = self._get("/search/trending")
+        if not isinstance(data, dict):
+            return None
+        result = []
+        for entry in data.get("coins", []):
+            try:
+                result.append(TrendingCoin.from_dict(entry["item"]))
+            except (KeyError, TypeError, ValueError) as e:
+                logger.debug(f"Skipping malformed trending entry: {e}")
+        return result
+
+    def fetch_markets(self, coin_ids: list[str]) -> list[CoinMarketData] | None:
+        """Market data of the given coins, or None if the request failed."""
+        if not coin_ids:
+            return []
+        data = self._get(
+            "/coins/markets",
+            {
+                "vs_currency": "usd",
+                "ids": ",".join(coin_ids),
+                "sparkline": "true",
+                "per_page": 250,
+            },
+        )
+        return self._parse_markets(data)
+
+    def fetch_top_markets(self, limit: int = 50) -> list[CoinMarketData] | None:
+        """Market data of the largest coins by market cap, or None if the request failed."""
+        data = self._get(
+            "/coins/markets",
+            {"vs_currency": "usd", "order": "market_cap_desc", "per_page": limit, "page": 1},
+        )
+        return self._parse_markets(data)
+
+    @staticmethod
+    def _parse_markets(data) -> list[CoinMarketData] | None:
+        if not isinstance(data, list):
+            return None
+        result = []
+        for entry in data:
+            try:
+                result.append(CoinMarketData.from_dict(entry))
+            except (KeyError, TypeError, ValueError) as e:
+                logger.debug(f"Skipping malformed CoinGecko entry: {e}")
+        return result
+
+
+class CoinGeckoService(QObject):
+    """Keeps CoinGecko market data of the monitored pairs' base assets fresh."""
+
+    market_data_updated = pyqtSignal()
+    trending_updated = pyqtSignal(list)  # list[TrendingCoin]
+
+    REFRESH_INTERVAL_MS = 10 * 60 * 1000
+    TRENDING_CACHE_TTL = 10 * 60
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._client = CoinGeckoClient()
+        self._symbols: list[str] = []
+        self._data: dict[str, CoinMarketData] = {}  # symbol -> data
+        self._global: GlobalMetrics | None = None
+        self._fetching = False
+        self._trending: list[TrendingCoin] = []
+        self._trending_at = 0.0
+        self._fetching_trending = False
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.REFRESH_INTERVAL_MS)
+        self._timer.timeout.connect(self.refresh)
+
+    def start(self):
+        """Start periodic refresh."""
+        if not self._timer.isActive():
+            self._timer.start()
+
+    def stop(self):
+        """Stop periodic refresh."""
+        self._timer.stop()
+
+    def set_pairs(self, pairs: list[str]):
+        """Track the base assets of the given pairs and fetch new ones."""
+        symbols = []
+        for pair in pairs:
+            symbol = get_base_symbol(pair)
+            if symbol and symbol not in symbols:
+                symbols.append(symbol)
+        added = [s for s in symbols if s not in self._symbols]
+        self._symbols = symbols
+        if added or self._global is None:
+            self.refresh()
+
+    def refresh(self):
+        """Fetch market data in a background thread."""
+        if self._fetching:
+            return
+        self._fetching = True
+        threading.Thread(target=self._fetch, args=(list(self._symbols),), daemon=True).start()
+
+    def _fetch(self, symbols: list[str]):
+        try:
+            # Bitcoin is always fetched for the dominance change
+            ids = {KNOWN_COIN_IDS["BTC"]: "BTC"}
+            for symbol in symbols:
+                coin_id = self._client.resolve_id(symbol)
+                if coin_id:
+                    ids[coin_id] = symbol
+            markets = self._client.fetch_markets(list(ids))
+            if markets is None:
+                return
+            data = dict(self._data)
+            for market in markets:
+                data[ids.get(market.coin_id, market.symbol)] = market
+            self._data = data
+
+            global_data = self._client.fetch_global()
+            if global_data:
+                btc = data.get("BTC")
+                try:
+                    self._global = GlobalMetrics.from_global(
+                        global_data, btc.market_cap_change_pct_24h if btc else None
+                    )
+                except (KeyError, TypeError, ValueError, ZeroDivisionError) as e:
+                    logger.warning(f"Malformed CoinGecko global data: {e}")
+            logger.debug(f"CoinGecko market data updated for {len(markets)} coins")
+            self.market_data_updated.emit()
+        finally:
+            self._fetching = False
+
+    def load_trending(self):
+        """
+        Emit trending_updated with the trending coins, fetching them in a
+        background thread unless the cached list is still fresh.
+        """
+        if self._trending and time.time() - self._trending_at < self.TRENDING_CACHE_TTL:
+            self.trending_updated.emit(list(self._trending))
+            return
+        if self._fetching_trending:
+            return
+        self._fetching_trending = True
+        threading.Thread(target=self._fetch_trending, daemon=True).start()
+
+    def _fetch_trending(self):
+        try:
+            trending = self._client.fetch_trending()
+            if trending is not None:
+                self._trending = trending
+                self._trending_at = time.time()
+        finally:
+            self._fetching_trending = False
+        self.trending_updated.emit(list(self._trending))
+
+    def get_market_data(self, pair: str) -> CoinMarketData | None:
+        """Market data of a pair's base asset, or None if unknown."""
+        symbol = get_base_symbol(pair)
+        return self._data.get(symbol) if symbol else None
+
+    def get_global_metrics(self) -> GlobalMetrics | None:
+        """Latest global market metrics, or None before the first fetch."""
+        return self._global
+
+    def get_tickers(self) -> list[TickerData]:
+        """Global metrics as index tickers with their 24h change."""
+        return self._global.to_tickers() if self._global else []
+
+
+# Global CoinGecko service instance
+_coingecko_service: CoinGeckoService | None = None
+
+
+def get_coingecko_service() -> CoinGeckoService:
+    """Get the global CoinGecko service instance."""
+    global _coingecko_service
+    if _coingecko_service is None:
+        _coingecko_service = CoinGeckoService()
+    return _coingecko_service
@@ -0,0 +1,76 @@
+"""
+Settings file watcher.
+Reloads the settings when the file is edited outside the app (by hand or by
+dotfile sync), so the watchlist, proxy and alerts apply without a restart.
+"""
+
+import logging
+
+from PyQt6.QtCore import QFileSystemWatcher, QObject, QTimer, pyqtSignal
+
+from config.settings import get_settings_manager
+
+logger = logging.getLogger(__name__)
+
+# Editors and sync tools often write a file in several steps; wait for them to finish
+RELOAD_DELAY_MS = 500
+
+
+class ConfigWatcher(QObject):
+    """Watches the settings file and reloads it after external changes."""
+
+    # Emitted after changed settings were loaded from the file
+    config_reloaded = pyqtSignal()
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._watcher = QFileSystemWatcher(self)
+        self._watcher.fileChanged.connect(self._schedule_reload)
+        # Files replaced by a rename (atomic saves) are only noticed through their directory
+        self._watcher.directoryChanged.connect(self._schedule_reload)
+
+        self._reload_timer = QTimer(self)
+        self._reload_timer.setSingleShot(True)
+        self._reload_timer.setInterval(RELOAD_DELAY_MS)
+        self._reload_timer.timeout.connect(self._reload)
+
+    def start(self):
+        """Start watching the settings file."""
+        self._watcher.addPath(str(self._settings_manager.config_dir))
+        self._watch_file()
+
+    def stop(self):
+        """Stop watching."""
+        self._reload_timer.stop()
+        paths = self._watcher.files() + self._watcher.directories()
+        if paths:
+            self._watcher.removePaths(paths)
+
+    def _watch_file(self):
+        # A replaced file drops out of the watch list, so it is added again
+        path = str(self._settings_manager.config_file)
+        if self._settings_manager.config_file.exists() and path not in self._watcher.files():
+            self._watcher.addPath(path)
+
+    def _schedule_reload(self, _path: str):
+        self._reload_timer.start()
+
+    def _reload(self):
+        self._watch_file()
+        if not self._settings_manager.config_file.exists():
+            return
+        if self._settings_manager.reload_if_changed():
+            self.config_reloaded.emit()
+
+
+# Global config watcher instance
+_config_watcher: ConfigWatcher | None = None
+
+
+def get_config_watcher() -> ConfigWatcher:
+    """Get the global config watcher instance."""
+    global _config_watcher
+    if _config_watcher is None:
+        _config_watcher = ConfigWatcher()
+    return _config_watcher
@@ -0,0 +1,198 @@
+"""
+Inbound control webhooks.
+Adds routes to the local API that let other tools drive the monitor. They only
+answer when control is allowed in settings and an access token is set.
+
+    POST /api/control/pairs/add      {"pairs": ["BTC-USDT", ...]}
+    POST /api/control/pairs/remove   {"pairs": ["BTC-USDT", ...]}
+    POST /api/control/alerts/pause   Pause alert notifications
+    POST /api/control/alerts/resume  Resume alert notifications
+    POST /api/control/alerts/ack     Acknowledge all fired alerts
+    POST /api/control/alerts/{id}/ack       Acknowledge one fired alert
+    POST /api/control/pairs/{pair}/mute     {"minutes": 60} Mute a pair's alerts
+    POST /api/control/pairs/{pair}/unmute   Let a pair's alerts fire again
+    POST /api/control/snapshot       Save a CSV snapshot of the prices, and return it as text
+"""
+
+import json
+import logging
+import re
+from datetime import datetime
+
+from PyQt6.QtCore import QObject, pyqtSignal
+
+from config.settings import SPECIAL_PAIR_PREFIXES, get_settings_manager, normalize_pair
+from core.alert_manager import get_alert_manager
+from core.local_api import ApiRequest, ApiResponse, LocalApiServer
+from core.notifier import get_notification_service
+from core.snapshot import format_snapshot_text, write_snapshot_csv
+
+logger = logging.getLogger(__name__)
+
+_EXCHANGE_PAIR = re.compile(r"^[A-Z0-9]+-[A-Z0-9]+$")
+DEFAULT_MUTE_MINUTES = 60
+MAX_MUTE_MINUTES = 7 * 24 * 60
+
+
+def check_pair(pair: str) -> str:
+    """
+    A pair given by another tool, normalized.
+
+    Raises:
+        ValueError: If it isn't shaped like a pair
+    """
+    pair = normalize_pair(pair)
+    if not pair.lower().startswith(SPECIAL_PAIR_PREFIXES) and not _EXCHANGE_PAIR.match(pair):
+        raise ValueError(f"invalid pair: {pair}")
+    return pair
+
+
+def parse_pairs(body: bytes) -> list[str]:
+    """
+    Pairs of a command body, normalized.
+
+    Raises:
+        ValueError: If the body isn't a JSON object with a list of valid pairs
+    """
+    data = json.loads(body or b"{}")
+    pairs = data.get("pairs") if isinstance(data, dict) else None
+    if not isinstance(pairs, list) or not pairs:
+        raise ValueError('expected {"pairs": [...]}')
+    normalized = []
+    for pair in pairs:
+        if not isinstance(pair, str):
+            raise ValueError(f"invalid pair: {pair!r}")
+        pair = check_pair(pair)
+        if pair not in normalized:
+            normalized.append(pair)
+    return normalized
+
+
+def parse_mute_minutes(body: bytes) -> float:
+    """
+    Minutes of a mute command body; DEFAULT_MUTE_MINUTES without one.
+
+    Raises:
+        ValueError: If they aren't a number from over 0 up to MAX_MUTE_MINUTES
+    """
+    data = json.loads(body or b"{}")
+    minutes = data.get("minutes", DEFAULT_MUTE_MINUTES) if isinstance(data, dict) else None
+    if isinstance(minutes, bool) or not isinstance(minutes, (int, float)):
+        raise ValueError('expected {"minutes": 60}')
+    if not 0 < minutes <= MAX_MUTE_MINUTES:
+        raise ValueError(f"minutes must be between 0 and {MAX_MUTE_MINUTES}")
+    return float(minutes)
+
+
+class ControlApi(QObject):
+    """Carries out inbound commands on the main thread."""
+
+    add_pairs_requested = pyqtSignal(list)
+    remove_pair_requested = pyqtSignal(str)
+
+    def __init__(self, server: LocalApiServer, market_controller, parent: QObject | None = None):
+        super().__init__(parent)
+        self._server = server
+        self._market_controller = market_controller
+        self._settings_manager = get_settings_manager()
+
+        for path, handler in (
+            ("/api/control/pairs/add", self._add_pairs),
+            ("/api/control/pairs/remove", self._remove_pairs),
+            ("/api/control/alerts/pause", self._pause_alerts),
+            ("/api/control/alerts/resume", self._resume_alerts),
+            ("/api/control/alerts/ack", self._acknowledge_all),
+            ("/api/control/alerts/{id}/ack", self._acknowledge_alert),
+            ("/api/control/pairs/{pair}/mute", self._mute_pair),
+            ("/api/control/pairs/{pair}/unmute", self._unmute_pair),
+            ("/api/control/snapshot", self._take_snapshot),
+        ):
+            server.add_control_route("POST", path, handler)
+
+    def _add_pairs(self, request: ApiRequest) -> ApiResponse:
+        try:
+            pairs = parse_pairs(request.body)
+        except ValueError as e:
+            return ApiResponse(400, {"error": str(e)})
+
+        def add():
+            monitored = self._settings_manager.settings.crypto_pairs
+            new = [pair for pair in pairs if pair not in monitored]
+            if new:
+                self.add_pairs_requested.emit(new)
+            return new
+
+        added = self._server.call_in_main_thread(add)
+        logger.info(f"Control API added pairs: {added}")
+        return ApiResponse(200, {"added": added})
+
+    def _remove_pairs(self, request: ApiRequest) -> ApiResponse:
+        try:
+            pairs = parse_pairs(request.body)
+        except ValueError as e:
+            return ApiResponse(400, {"error": str(e)})
+
+        def remove():
+            monitored = self._settings_manager.settings.crypto_pairs
+            present = [pair for pair in pairs if pair in monitored]
+            for pair in present:
+                self.remove_pair_requested.emit(pair)
+            return present
+
+        removed = self._server.call_in_main_thread(remove)
+        logger.info(f"Control API removed pairs: {removed}")
+        return ApiResponse(200, {"removed": removed})
+
+    def _set_paused(self, paused: bool) -> ApiResponse:
+        self._server.call_in_main_thread(lambda: get_notification_service().set_paused(paused))
+        return ApiResponse(200, {"paused": paused})
+
+    def _pause_alerts(self, request: ApiRequest) -> ApiResponse:
+        return self._set_paused(True)
+
+    def _resume_alerts(self, request: ApiRequest) -> ApiResponse:
+        return self._set_paused(False)
+
+    def _acknowledge_all(self, request: ApiRequest) -> ApiResponse:
+        count = self._server.call_in_main_thread(get_alert_manager().acknowledge_all)
+        return ApiResponse(200, {"acknowledged": count})
+
+    def _acknowledge_alert(self, request: ApiRequest) -> ApiResponse:
+        alert_id = request.params["id"]
+        if not any(alert.id == alert_id for alert in self._settings_manager.settings.alerts):
+            return ApiResponse(404, {"error": "unknown alert"})
+        acknowledged = self._server.call_in_main_thread(
+            lambda: get_alert_manager().acknowledge_alert(alert_id)
+        )
+        return ApiResponse(200, {"acknowledged": 1 if acknowledged else 0})
+
+    def _mute_pair(self, request: ApiRequest) -> ApiResponse:
+        try:
+            pair = check_pair(request.params["pair"])
+            minutes = parse_mute_minutes(request.body)
+        except ValueError as e:
+            return ApiResponse(400, {"error": str(e)})
+        until = self._server.call_in_main_thread(
+            lambda: get_alert_manager().mute_pair(pair, minutes * 60)
+        )
+        logger.info(f"Control API muted {pair} for {minutes:g} minutes")
+        return ApiResponse(200, {"pair": pair, "muted_until": until})
+
+    def _unmute_pair(self, request: ApiRequest) -> ApiResponse:
+        try:
+            pair = check_pair(request.params["pair"])
+        except ValueError as e:
+            return ApiResponse(400, {"error": str(e)})
+        unmuted = self._server.call_in_main_thread(lambda: get_alert_manager().unmute_pair(pair))
+        return ApiResponse(200, {"pair": pair, "unmuted": unmuted})
+
+    def _take_snapshot(self, request: ApiRequest) -> ApiResponse:
+        rows = self._server.call_in_main_thread(self._market_controller.get_snapshot_rows)
+        if not rows:
+            return ApiResponse(409, {"error": "no prices yet"})
+        snapshot_dir = self._settings_manager.config_dir / "snapshots"
+        snapshot_dir.mkdir(parents=True, exist_ok=True)
+        timestamp = datetime.now().strftime("%Y%m%d_%H%M%S")
+        path = snapshot_dir / f"crypto-prices_{timestamp}.csv"
+        write_snapshot_csv(path, rows)
+        return ApiResponse(200, {"file": str(path), "text": format_snapshot_text(rows)})
@@ -0,0 +1,136 @@
+"""
+Crash handling.
+Uncaught exceptions in the main thread, worker threads and asyncio tasks are
+logged with their stack trace instead of taking the app down. Users who opt
+in get an anonymous report written on a crash, which they are offered to send
+as a pre-filled GitHub issue on the next start. Nothing is sent automatically.
+"""
+
+import logging
+import platform
+import sys
+import threading
+import time
+import traceback
+from pathlib import Path
+from urllib.parse import urlencode
+
+from config.settings import get_settings_manager
+from core.redaction import redact
+from core.version import __version__
+
+logger = logging.getLogger(__name__)
+
+ISSUE_URL = "https://github.com/shiquda/crypto-monitor/issues/new"
+# Keeps the pre-filled issue URL within what browsers and GitHub accept
+MAX_ISSUE_BODY = 6000
+
+
+def anonymize(text: str) -> str:
+    """Text with credentials masked and the home directory, which holds the user name, hidden."""
+    text = redact(text)
+    home = str(Path.home())
+    if len(home) > 1:
+        text = text.replace(home, "~")
+    return text
+
+
+def build_report(exc_type, exc, tb, where: str) -> str:
+    """Crash report with the app and system versions and the traceback; no settings or pairs."""
+    trace = "".join(traceback.format_exception(exc_type, exc, tb))
+    lines = [
+        f"Crypto Monitor {__version__}",
+        f"Python {platform.python_version()} on {platform.platform(terse=True)}",
+        f"Thread: {where}",
+        "",
+        trace.rstrip(),
+    ]
+    return anonymize("\n".join(lines)) + "\n"
+
+
+def issue_url(report: str) -> str:
+    """GitHub new-issue URL pre-filled with a report."""
+    last_line = report.rstrip().splitlines()[-1]
+    body = report if len(report) <= MAX_ISSUE_BODY else "...\n" + report[-MAX_ISSUE_BODY:]
+    query = urlencode({"title": f"Crash: {last_line[:80]}", "body": f"```\n{body}```"})
+    return f"{ISSUE_URL}?{query}"
+
+
+class CrashReporter:
+    """Logs uncaught exceptions and keeps the opted-in crash reports."""
+
+    def __init__(self, crash_dir: Path | None = None):
+        self._settings_manager = get_settings_manager()
+        self.crash_dir = crash_dir or self._settings_manager.config_dir / "crashes"
+        self._lock = threading.Lock()
+
+    @property
+    def enabled(self) -> bool:
+        return self._settings_manager.settings.crash_reports
+
+    def install(self):
+        """Handle uncaught exceptions of the main thread and of Python threads."""
+        sys.excepthook = self._excepthook
+        threading.excepthook = self._thread_excepthook
+
+    def report(self, exc: BaseException, where: str):
+        """Log an exception caught at a thread or task boundary, and keep a report if opted in."""
+        self.handle(type(exc), exc, exc.__traceback__, where)
+
+    def handle(self, exc_type, exc, tb, where: str):
+        logger.critical(f"Unhandled exception in {where}", exc_info=(exc_type, exc, tb))
+        try:
+            if self.enabled:
+                self._write(build_report(exc_type, exc, tb, where))
+        except Exception as e:
+            logger.error(f"Failed to write crash report: {e}")
+
+    def _write(self, report: str):
+        with self._lock:
+            self.crash_dir.mkdir(parents=True, exist_ok=True)
+            stamp = time.strftime("%Y%m%d-%H%M%S")
+            path = self.crash_dir / f"crash-{stamp}.txt"
+            counter = 1
+            while path.exists():
+                counter += 1
+                path = self.crash_dir / f"crash-{stamp}-{counter}.txt"
+            path.write_text(report, encoding="utf-8")
+        logger.info(f"Crash report written to {path}")
+
+    def pending_reports(self) -> list[Path]:
+        """Reports not yet sent or dismissed, oldest first."""
+        if not self.crash_dir.is_dir():
+            return []
+        return sorted(self.crash_dir.glob("crash-*.txt"))
+
+    def discard(self, paths: list[Path]):
+        """Delete reports once they're sent or dismissed."""
+        for path in paths:
+            try:
+                path.unlink()
+            except OSError as e:
+                logger.warning(f"Failed to delete crash report {path}: {e}")
+
+    def _excepthook(self, exc_type, exc, tb):
+        if issubclass(exc_type, KeyboardInterrupt):
+            sys.__excepthook__(exc_type, exc, tb)
+            return
+        self.handle(exc_type, exc, tb, "main")
+
+    def _thread_excepthook(self, args: threading.ExceptHookArgs):
+        if args.exc_type is SystemExit:
+            return
+        name = args.thread.name if args.thread else "thread"
+        self.handle(args.exc_type, args.exc_value, args.exc_traceback, name)
+
+
+# Global crash reporter instance
+_crash_reporter: CrashReporter | None = None
+
+
+def get_crash_reporter() -> CrashReporter:
+    """Get the global crash reporter instance."""
+    global _crash_reporter
+    if _crash_reporter is None:
+        _crash_reporter = CrashReporter()
+    return _crash_reporter
@@ -0,0 +1,316 @@
+"""
+Trade history CSV importer for portfolio transactions.
+Maps the differing column layouts of exchange exports (OKX, Binance)
+onto PortfolioTransaction records.
+"""
+
+import csv
+import io
+import logging
+import re
+from dataclasses import dataclass, field
+from datetime import datetime, timezone
+from pathlib import Path
+
+from config.settings import PortfolioTransaction
+
+logger = logging.getLogger(__name__)
+
+# Quote assets used to split concatenated symbols such as "BTCUSDT".
+# Longer symbols first so "FDUSD" wins over "USD".
+KNOWN_QUOTE_ASSETS = (
+    "FDUSD",
+    "USDT",
+    "USDC",
+    "BUSD",
+    "TUSD",
+    "DAI",
+    "BTC",
+    "ETH",
+    "BNB",
+    "EUR",
+    "TRY",
+    "BRL",
+    "JPY",
+    "USD",
+)
+
+_AMOUNT_WITH_UNIT = re.compile(
+    r"^\s*([-+]?[0-9][0-9,]*\.?[0-9]*(?:[eE][-+]?\d+)?)\s*([A-Za-z][A-Za-z0-9]*)?\s*$"
+)
+
+
+@dataclass(frozen=True)
+class CsvColumnMapping:
+    """
+    Column mapping for one exchange CSV export format.
+
+    Each canonical field maps to the header names it may appear under,
+    since exchanges rename columns between export versions.
+    """
+
+    name: str  # Format identifier, e.g., "okx"
+    source: str  # Value stored in PortfolioTransaction.source
+    time: tuple[str, ...]
+    pair: tuple[str, ...]
+    side: tuple[str, ...]
+    quantity: tuple[str, ...]
+    price: tuple[str, ...]
+    fee: tuple[str, ...] = ()
+    fee_asset: tuple[str, ...] = ()
+    trade_id: tuple[str, ...] = ()
+
+    REQUIRED = ("time", "pair", "side", "quantity", "price")
+    OPTIONAL = ("fee", "fee_asset", "trade_id")
+
+    def resolve(self, headers: list[str]) -> dict[str, str] | None:
+        """
+        Resolve canonical field names to actual CSV headers.
+
+        Returns:
+            Mapping of canonical field -> header, or None if a required column is missing
+        """
+        normalized = {h.strip().lower(): h for h in headers}
+        resolved = {}
+
+        for canonical in self.REQUIRED + self.OPTIONAL:
+            for candidate in getattr(self, canonical):
+                header = normalized.get(candidate.lower())
+                if header is not None:
+                    resolved[canonical] = header
+                    break
+            else:
+                if canonical in self.REQUIRED:
+                    return None
+
+        return resolved
+
+
+OKX_MAPPING = CsvColumnMapping(
+    name="okx",
+    source="okx_csv",
+    time=("Time", "Trade Time", "Filled Time"),
+    pair=("Symbol", "Instrument", "Instrument ID"),
+    side=("Action", "Side"),
+    quantity=("Amount", "Filled", "Fill Size", "Filled Amount"),
+    price=("Filled Price", "Fill Price", "Avg Price", "Price"),
+    fee=("Fee",),
+    fee_asset=("Fee Unit", "Fee Currency", "Fee Ccy"),
+    trade_id=("id", "Trade ID", "Trade id"),
+)
+
+BINANCE_MAPPING = CsvColumnMapping(
+    name="binance",
+    source="binance_csv",
+    time=("Date(UTC)", "Date(UTC+0)", "Time", "Date"),
+    pair=("Pair", "Market", "Symbol"),
+    side=("Side", "Type"),
+    quantity=("Executed", "Amount", "Quantity"),
+    price=("Price", "Average Price"),
+    fee=("Fee",),
+    fee_asset=("Fee Coin", "Fee Asset"),
+    trade_id=("Trade ID", "TradeId"),
+)
+
+MAPPINGS: dict[str, CsvColumnMapping] = {
+    OKX_MAPPING.name: OKX_MAPPING,
+    BINANCE_MAPPING.name: BINANCE_MAPPING,
+}
+
+
+@dataclass
+class CsvImportResult:
+    """Result of parsing a trade history CSV."""
+
+    format_name: str = ""
+    transactions: list[PortfolioTransaction] = field(default_factory=list)
+    skipped_rows: list[tuple[int, str]] = field(default_factory=list)  # (row number, reason)
+
+
+class CsvImportError(Exception):
+    """Raised when a CSV file cannot be imported."""
+
+    pass
+
+
+def detect_mapping(headers: list[str]) -> tuple[CsvColumnMapping, dict[str, str]] | None:
+    """Detect the export format from the CSV headers."""
+    for mapping in MAPPINGS.values():
+        resolved = mapping.resolve(headers)
+        if resolved is not None:
+            return mapping, resolved
+    return None
+
+
+def normalize_pair(raw: str) -> str:
+    """
+    Normalize an exchange symbol to "BASE-QUOTE".
+
+    Handles "BTC-USDT", "BTC/USDT", "BTC_USDT", "BTCUSDT" and
+    OKX derivatives such as "BTC-USDT-SWAP".
+    """
+    symbol = raw.strip().upper()
+    for separator in ("/", "_", " "):
+        symbol = symbol.replace(separator, "-")
+
+    parts = [p for p in symbol.split("-") if p]
+    if len(parts) >= 2:
+        return f"{parts[0]}-{parts[1]}"
+
+    for quote in KNOWN_QUOTE_ASSETS:
+        if symbol.endswith(quote) and len(symbol) > len(quote):
+            return f"{symbol[: -len(quote)]}-{quote}"
+
+    return symbol
+
+
+def split_amount(raw: str) -> tuple[float, str]:
+    """
+    Split a value that may carry a unit suffix, e.g. "0.0012BTC" -> (0.0012, "BTC").
+
+    Raises:
+        ValueError: If the value is not numeric
+    """
+    match = _AMOUNT_WITH_UNIT.match(raw or "")
+    if not match:
+        raise ValueError(f"Invalid amount: {raw!r}")
+    return float(match.group(1).replace(",", "")), (match.group(2) or "").upper()
+
+
+def parse_timestamp(raw: str) -> float:
+    """
+    Parse an export timestamp into epoch seconds.
+
+    Accepts epoch seconds/milliseconds and common date formats (assumed UTC).
+
+    Raises:
+        ValueError: If the value cannot be parsed
+    """
+    value = (raw or "").strip()
+    if not value:
+        raise ValueError("Empty timestamp")
+
+    if value.replace(".", "", 1).isdigit():
+        number = float(value)
+        # Millisecond timestamps are 13 digits
+        return number / 1000 if number > 1e11 else number
+
+    for fmt in (
+        "%Y-%m-%d %H:%M:%S",
+        "%Y-%m-%d %H:%M:%S.%f",
+        "%Y/%m/%d %H:%M:%S",
+        "%Y-%m-%d %H:%M",
+    ):
+        try:
+            dt = datetime.strptime(value, fmt)
+            return dt.replace(tzinfo=timezone.utc).timestamp()
+        except ValueError:
+            continue
+
+    try:
+        dt = datetime.fromisoformat(value.replace("Z", "+00:00"))
+    except ValueError as e:
+        raise ValueError(f"Invalid timestamp: {raw!r}") from e
+    if dt.tzinfo is None:
+        dt = dt.replace(tzinfo=timezone.utc)
+    return dt.timestamp()
+
+
+def _parse_side(raw: str) -> str:
+    side = raw.strip().lower()
+    if side in ("buy", "b", "long", "买入"):
+        return "buy"
+    if side in ("sell", "s", "short", "卖出"):
+        return "sell"
+    raise ValueError(f"Unknown side: {raw!r}")
+
+
+def _parse_row(
+    row: dict[str, str], mapping: CsvColumnMapping, columns: dict[str, str]
+) -> PortfolioTransaction:
+    """Convert one CSV row into a transaction."""
+    pair = normalize_pair(row[columns["pair"]])
+    quantity, _unit = split_amount(row[columns["quantity"]])
+    price, _unit = split_amount(row[columns["price"]])
+
+    fee = 0.0
+    fee_asset = ""
+    if "fee" in columns and row.get(columns["fee"], "").strip():
+        fee, fee_asset = split_amount(row[columns["fee"]])
+        # OKX reports fees as negative balance changes
+        fee = abs(fee)
+    if "fee_asset" in columns and row.get(columns["fee_asset"], "").strip():
+        fee_asset = row[columns["fee_asset"]].strip().upper()
+
+    if quantity == 0 or price <= 0:
+        raise ValueError("Quantity and price must be positive")
+
+    return PortfolioTransaction(
+        pair=pair,
+        side=_parse_side(row[columns["side"]]),
+        quantity=abs(quantity),
+        price=price,
+        fee=fee,
+        fee_asset=fee_asset,
+        timestamp=parse_timestamp(row[columns["time"]]),
+        source=mapping.source,
+        external_id=row.get(columns.get("trade_id", ""), "").strip(),
+    )
+
+
+def parse_csv_text(text: str, format_name: str | None = None) -> CsvImportResult:
+    """
+    Parse trade history CSV content.
+
+    Args:
+        text: CSV file content
+        format_name: Force a mapping ("okx" or "binance"); auto-detected if None
+
+    Returns:
+        Import result with parsed transactions and skipped rows
+
+    Raises:
+        CsvImportError: If the format is unknown or required columns are missing
+    """
+    # Exports from Excel often start with a BOM
+    reader = csv.DictReader(io.StringIO(text.lstrip("\ufeff")))
+    headers = reader.fieldnames or []
+
+    if format_name:
+        mapping = MAPPINGS.get(format_name.lower())
+        if mapping is None:
+            raise CsvImportError(f"Unknown CSV format: {format_name}")
+        columns = mapping.resolve(headers)
+        if columns is None:
+            raise CsvImportError(f"Missing required columns for {mapping.name} format")
+    else:
+        detected = detect_mapping(headers)
+        if detected is None:
+            raise CsvImportError("Unrecognized CSV format")
+        mapping, columns = detected
+
+    result = CsvImportResult(format_name=mapping.name)
+
+    # Row numbers are 1-based and account for the header line
+    for row_number, row in enumerate(reader, start=2):
+        try:
+            result.transactions.append(_parse_row(row, mapping, columns))
+        except (ValueError, KeyError, TypeError) as e:
+            result.skipped_rows.append((row_number, str(e)))
+
+    logger.info(
+        f"Parsed {len(result.transactions)} transactions from {mapping.name} CSV "
+        f"({len(result.skipped_rows)} rows skipped)"
+    )
+    return result
+
+
+def import_csv_file(path: str | Path, format_name: str | None = None) -> CsvImportResult:
+    """Read and parse a trade history CSV file."""
+    try:
+        with open(path, encoding="utf-8-sig", newline="") as f:
+            text = f.read()
+    except OSError as e:
+        raise CsvImportError(f"Failed to read {path}: {e}") from e
+
+    return parse_csv_text(text, format_name)
@@ -0,0 +1,65 @@
+"""
+Day boundary of the daily price change.
+The change is measured from the open at the start of the day, which can be
+UTC midnight, UTC+8 midnight or local midnight, or over a rolling 24 hours.
+Boundaries the exchange doesn't report are computed from the hourly candles.
+"""
+
+import time
+
+from core.indicators import Candle
+
+PRICE_CHANGE_BASES = ("24h_rolling", "utc_0", "utc_8", "local")
+
+DAY_SECONDS = 24 * 60 * 60
+
+# Bases whose day open each exchange sends with its tickers
+_NATIVE_BASES = {
+    "OKX": ("24h_rolling", "utc_0", "utc_8"),  # open24h, sodUtc0, sodUtc8
+    "BINANCE": ("24h_rolling", "utc_0"),  # 24hrTicker, kline_1d
+}
+
+
+def utc_offset(basis: str, timestamp: float) -> int | None:
+    """Offset from UTC of the basis' day, in seconds; None for the rolling 24h."""
+    if basis == "utc_0":
+        return 0
+    if basis == "utc_8":
+        return 8 * 60 * 60
+    if basis == "local":
+        return time.localtime(timestamp).tm_gmtoff
+    return None
+
+
+def day_start(basis: str, timestamp: float) -> int | None:
+    """Start of the basis' day containing timestamp; None for the rolling 24h."""
+    offset = utc_offset(basis, timestamp)
+    if offset is None:
+        return None
+    return int(timestamp + offset) // DAY_SECONDS * DAY_SECONDS - offset
+
+
+def needs_candle_open(basis: str, data_source: str) -> bool:
+    """Whether the day open must be taken from candles because the exchange lacks it."""
+    return basis not in _NATIVE_BASES.get(data_source.upper(), ("24h_rolling", "utc_0"))
+
+
+def day_open(candles: list[Candle], start: int, interval_seconds: int) -> float | None:
+    """
+    Open price at start, from the candle containing it.
+
+    Offsets that are not whole hours fall inside an hourly candle, whose open
+    is used as the closest available price.
+    """
+    for candle in reversed(candles):
+        if candle.open_time <= start:
+            if start < candle.open_time + interval_seconds and candle.open > 0:
+                return candle.open
+            return None
+    return None
+
+
+def format_change(price: float, open_price: float) -> str:
+    """Change from the open, formatted like the exchanges' percentages."""
+    pct = (price - open_price) / open_price * 100
+    return f"+{pct:.2f}%" if pct >= 0 else f"{pct:.2f}%"
@@ -0,0 +1,203 @@
+"""
+Dollar-cost averaging planner.
+Reminds the user of recurring buys at their scheduled time and tracks the
+position the plan would have accumulated at each reminder's price.
+"""
+
+import logging
+import time
+from calendar import monthrange
+from datetime import datetime, timedelta
+
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from config.settings import DcaPlan, get_settings_manager
+from core.notifier import get_notification_service
+from core.portfolio import get_portfolio_manager
+
+logger = logging.getLogger(__name__)
+
+CADENCES = ("daily", "weekly", "monthly")
+
+
+def next_run_time(plan: DcaPlan, after: datetime) -> datetime:
+    """
+    Get the first scheduled run of a plan strictly after a given time.
+
+    Monthly plans scheduled past the end of a short month run on its last day.
+    """
+    candidate = after.replace(hour=plan.hour, minute=0, second=0, microsecond=0)
+
+    if plan.cadence == "daily":
+        if candidate <= after:
+            candidate += timedelta(days=1)
+        return candidate
+
+    if plan.cadence == "weekly":
+        candidate += timedelta(days=(plan.weekday - candidate.weekday()) % 7)
+        if candidate <= after:
+            candidate += timedelta(days=7)
+        return candidate
+
+    # Monthly
+    year, month = candidate.year, candidate.month
+    for _attempt in range(2):
+        day = min(plan.day_of_month, monthrange(year, month)[1])
+        candidate = candidate.replace(year=year, month=month, day=day)
+        if candidate > after:
+            break
+        year, month = (year + 1, 1) if month == 12 else (year, month + 1)
+    return candidate
+
+
+def apply_dca_run(plan: DcaPlan, price: float, timestamp: float) -> float:
+    """
+    Record one hypothetical buy on a plan.
+
+    Returns:
+        Base asset quantity bought
+    """
+    quantity = plan.amount / price
+    plan.runs += 1
+    plan.total_invested += plan.amount
+    plan.total_quantity += quantity
+    plan.last_run = timestamp
+    return quantity
+
+
+class DcaPlanner(QObject):
+    """
+    Checks DCA plans every minute and sends reminders when they are due.
+
+    A due plan waits until its pair has a live price, so the hypothetical
+    position is always bought at a real market price. Runs missed while
+    the app was closed are caught up once, not once per missed period.
+    """
+
+    plan_executed = pyqtSignal(object, float)  # DcaPlan, price
+
+    CHECK_INTERVAL_MS = 60 * 1000
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._notification_service = get_notification_service()
+        self._portfolio_manager = get_portfolio_manager()
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.CHECK_INTERVAL_MS)
+        self._timer.timeout.connect(self.check_plans)
+
+    def start(self):
+        """Start periodic checks."""
+        if not self._timer.isActive():
+            self._timer.start()
+
+    def stop(self):
+        """Stop periodic checks."""
+        self._timer.stop()
+
+    def check_plans(self):
+        """Run every enabled plan whose scheduled time has passed."""
+        now = time.time()
+        for plan in self._settings_manager.settings.dca_plans:
+            if not plan.enabled or plan.amount <= 0:
+                continue
+
+            if not plan.next_run:
+                self._schedule(plan, now)
+                continue
+
+            if plan.next_run > now:
+                continue
+
+            price = self._portfolio_manager.get_price(plan.pair)
+            if not price:
+                logger.debug(f"DCA plan {plan.pair} due, waiting for a price")
+                continue
+
+            self._run_plan(plan, price, now)
+
+    def _schedule(self, plan: DcaPlan, after: float):
+        plan.next_run = next_run_time(plan, datetime.fromtimestamp(after)).timestamp()
+        self._settings_manager.update_dca_plan(plan)
+
+    def _run_plan(self, plan: DcaPlan, price: float, now: float):
+        quantity = apply_dca_run(plan, price, now)
+        logger.info(f"DCA plan {plan.pair}: {plan.amount:g} at {price} -> {quantity:g}")
+
+        self._notification_service.send_dca_reminder(
+            pair=plan.pair,
+            amount=plan.amount,
+            price=price,
+            total_quantity=plan.total_quantity,
+            average_price=plan.average_price,
+        )
+
+        self._schedule(plan, now)
+        self.plan_executed.emit(plan, price)
+
+    def add_plan(
+        self,
+        pair: str,
+        amount: float,
+        cadence: str = "weekly",
+        hour: int = 9,
+        weekday: int = 0,
+        day_of_month: int = 1,
+    ) -> DcaPlan:
+        """Add a new plan and schedule its first run."""
+        plan = DcaPlan(
+            pair=pair,
+            amount=amount,
+            cadence=cadence,
+            hour=hour,
+            weekday=weekday,
+            day_of_month=day_of_month,
+        )
+        plan.next_run = next_run_time(plan, datetime.now()).timestamp()
+        self._settings_manager.add_dca_plan(plan)
+        return plan
+
+    def set_plan_enabled(self, plan_id: str, enabled: bool) -> bool:
+        """
+        Enable or pause a plan. Returns True if the plan exists.
+
+        Re-enabled plans are rescheduled from now, so runs skipped while
+        paused are not caught up.
+        """
+        for plan in self._settings_manager.settings.dca_plans:
+            if plan.id == plan_id:
+                plan.enabled = enabled
+                if enabled:
+                    plan.next_run = next_run_time(plan, datetime.now()).timestamp()
+                self._settings_manager.update_dca_plan(plan)
+                return True
+        return False
+
+    def remove_plan(self, plan_id: str) -> bool:
+        """Remove a plan by ID."""
+        return self._settings_manager.remove_dca_plan(plan_id)
+
+    def get_plans(self) -> list[DcaPlan]:
+        """Get all DCA plans."""
+        return self._settings_manager.settings.dca_plans
+
+    def get_plan_value(self, plan: DcaPlan) -> float | None:
+        """Current value of a plan's hypothetical position, in quote asset."""
+        price = self._portfolio_manager.get_price(plan.pair)
+        if price is None:
+            return None
+        return plan.total_quantity * price
+
+
+# Global DCA planner instance
+_dca_planner: DcaPlanner | None = None
+
+
+def get_dca_planner() -> DcaPlanner:
+    """Get the global DCA planner instance."""
+    global _dca_planner
+    if _dca_planner is None:
+        _dca_planner = DcaPlanner()
+    return _dca_planner
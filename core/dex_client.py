@@ -7,7 +7,9 @@ from PyQt6.QtCore import QTimer
 
 from config.settings import get_settings_manager
 from core.base_client import BaseExchangeClient
+from core.messages import message
 from core.models import TickerData
+from core.redaction import redact
 
 logger = logging.getLogger(__name__)
 
@@ -35,7 +37,7 @@ class DexScreenerClient(BaseExchangeClient):
         if settings.proxy.enabled:
             proxy_url = settings.proxy.get_proxy_url()
             if proxy_url:
-                logger.debug(f"Configuring proxy for DexScreenerClient: {proxy_url}")
+                logger.debug(f"Configuring proxy for DexScreenerClient: {redact(proxy_url)}")
                 self._session.proxies = {"http": proxy_url, "https": proxy_url}
         else:
             self._session.proxies = {}
@@ -53,7 +55,7 @@ class DexScreenerClient(BaseExchangeClient):
             logger.info("Starting DEX polling timer")
             self._timer.start()
             self._is_connected = True
-            self.connection_status.emit(True, "Connected (Polling)")
+            self.connection_status.emit(True, message("connected_polling"))
             self._poll_data()
         else:
             logger.info(
@@ -249,7 +251,7 @@ class DexScreenerClient(BaseExchangeClient):
 
             if resp.status_code != 200:
                 logger.warning(f"Polling failed with status code: {resp.status_code}")
-                self.connection_status.emit(False, f"HTTP Error: {resp.status_code}")
+                self.connection_status.emit(False, message("http_error", status=resp.status_code))
                 return
 
             data = resp.json()
@@ -349,4 +351,4 @@ class DexScreenerClient(BaseExchangeClient):
 
         except Exception as e:
             logger.error(f"Polling error details: {e}", exc_info=True)
-            self.connection_status.emit(False, f"Polling Error: {str(e)}")
+            self.connection_status.emit(False, message("polling_error", error=e))
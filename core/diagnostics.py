@@ -0,0 +1,36 @@
+"""
+Connection diagnostics.
+Collects the details of every live WebSocket connection (endpoint, proxy,
+uptime, last error, message rate and ping round trip) for the diagnostics
+view, to help pin down where a connection through a proxy gets stuck.
+"""
+
+from core.websocket_worker import BaseWebSocketWorker, ConnectionInfo
+from core.worker_controller import WorkerController
+
+
+def connection_details() -> list[ConnectionInfo]:
+    """Details of the running WebSocket workers."""
+    return [
+        worker.connection_info()
+        for worker in WorkerController.get_instance().active_workers()
+        if isinstance(worker, BaseWebSocketWorker)
+    ]
+
+
+def format_uptime(seconds: float) -> str:
+    """Uptime as e.g. "2h 05m", "3m 07s" or "-" when not connected."""
+    if seconds <= 0:
+        return "-"
+    seconds = int(seconds)
+    hours, rest = divmod(seconds, 3600)
+    minutes, secs = divmod(rest, 60)
+    if hours:
+        return f"{hours}h {minutes:02d}m"
+    if minutes:
+        return f"{minutes}m {secs:02d}s"
+    return f"{secs}s"
+
+
+def format_rtt(rtt_ms: float | None) -> str:
+    return f"{rtt_ms:.0f} ms" if rtt_ms is not None else "-"
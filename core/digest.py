@@ -0,0 +1,142 @@
+"""
+Price digests.
+Sends a summary of the monitored pairs' prices and changes every hour or
+once a day at a chosen time, through the desktop and the remote channels.
+Each pair is one line rendered from a user template.
+"""
+
+import logging
+from collections.abc import Callable
+from datetime import datetime, timedelta
+
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from config.settings import DigestConfig, get_settings_manager
+from core.i18n import _
+from core.message_template import fill_template
+from core.notifier import get_notification_service
+from core.snapshot import SnapshotRow
+
+logger = logging.getLogger(__name__)
+
+CADENCES = ("hourly", "daily")
+DEFAULT_TEMPLATE = DigestConfig().template
+PLACEHOLDERS = ("pair", "name", "price", "change", "high", "low", "volume")
+
+
+def next_digest_time(config: DigestConfig, after: datetime) -> datetime:
+    """First scheduled digest strictly after a given time."""
+    candidate = after.replace(minute=config.minute, second=0, microsecond=0)
+    if config.cadence == "hourly":
+        if candidate <= after:
+            candidate += timedelta(hours=1)
+        return candidate
+
+    candidate = candidate.replace(hour=config.hour)
+    if candidate <= after:
+        candidate += timedelta(days=1)
+    return candidate
+
+
+def render_line(template: str, row: SnapshotRow) -> str:
+    """
+    A pair's digest line.
+
+    Unknown placeholders stay as typed; a malformed template falls back to
+    the default one rather than dropping the pair.
+    """
+    values = dict(
+        pair=row.pair,
+        name=row.name,
+        price=row.price_text,
+        change=row.percentage_text,
+        high=row.high_24h,
+        low=row.low_24h,
+        volume=row.volume_24h,
+    )
+    try:
+        return fill_template(template, values)
+    except ValueError:
+        return fill_template(DEFAULT_TEMPLATE, values)
+
+
+def render_digest(config: DigestConfig, rows: list[SnapshotRow], now: datetime) -> tuple[str, str]:
+    """Title and message of a digest."""
+    label = _("Hourly Digest") if config.cadence == "hourly" else _("Daily Digest")
+    title = f"📊 {label} · {now.strftime('%H:%M')}"
+    template = config.template or DEFAULT_TEMPLATE
+    return title, "\n".join(render_line(template, row) for row in rows)
+
+
+class DigestScheduler(QObject):
+    """
+    Checks every minute whether a digest is due.
+
+    The schedule lives in memory: digests missed while the app was closed
+    are not caught up, and changing the cadence or time reschedules.
+    """
+
+    digest_sent = pyqtSignal(str, str)  # title, message
+
+    CHECK_INTERVAL_MS = 60 * 1000
+
+    def __init__(
+        self, rows_provider: Callable[[], list[SnapshotRow]], parent: QObject | None = None
+    ):
+        super().__init__(parent)
+        self._rows_provider = rows_provider
+        self._settings_manager = get_settings_manager()
+        self._next_run: datetime | None = None
+        self._scheduled_for: tuple[str, int, int] | None = None  # cadence, hour, minute
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.CHECK_INTERVAL_MS)
+        self._timer.timeout.connect(self.check)
+
+    @property
+    def next_run(self) -> datetime | None:
+        return self._next_run
+
+    def start(self):
+        """Start periodic checks."""
+        if not self._timer.isActive():
+            self._timer.start()
+
+    def stop(self):
+        """Stop periodic checks."""
+        self._timer.stop()
+
+    def check(self, now: datetime | None = None):
+        """Send the digest if its time has come."""
+        if now is None:
+            now = datetime.now()
+        config = self._settings_manager.settings.digest
+        if not config.enabled:
+            self._next_run = None
+            return
+
+        schedule = (config.cadence, config.hour, config.minute)
+        if self._next_run is None or schedule != self._scheduled_for:
+            self._schedule(config, now)
+            return
+        if now < self._next_run:
+            return
+
+        rows = self._rows_provider()
+        if rows:
+            self.send(config, rows, now)
+        else:
+            logger.debug("Digest skipped: no prices yet")
+        self._schedule(config, now)
+
+    def send(self, config: DigestConfig, rows: list[SnapshotRow], now: datetime):
+        """Render and send a digest of the given rows."""
+        title, message = render_digest(config, rows, now)
+        get_notification_service().send_digest(title, message)
+        logger.info(f"Sent {config.cadence} digest of {len(rows)} pairs")
+        self.digest_sent.emit(title, message)
+
+    def _schedule(self, config: DigestConfig, after: datetime):
+        self._next_run = next_digest_time(config, after)
+        self._scheduled_for = (config.cadence, config.hour, config.minute)
+        logger.debug(f"Next digest at {self._next_run:%Y-%m-%d %H:%M}")
@@ -0,0 +1,94 @@
+"""
+Per-pair display preferences.
+Users can fix a pair's decimal places, show its 24h volume, invert its quote
+(e.g. show BTC-USDT as USDT per BTC) and set a color threshold below which
+moves are shown neutral. The preferences are resolved into ready-to-render
+hints that travel with the pair's price state.
+"""
+
+from dataclasses import dataclass
+
+from core.fx_rates import format_fiat
+from core.utils import format_compact, format_price
+
+# Choices offered in the card menu
+DECIMAL_CHOICES = (None, 0, 2, 4, 6, 8)  # None = automatic precision
+COLOR_THRESHOLDS = (0.0, 0.5, 1.0, 2.0, 5.0)  # Percent; 0 colors every move
+
+DEFAULT_DISPLAY_PREFS = {
+    "decimals": None,
+    "show_volume": False,
+    "invert": False,
+    "color_threshold": 0.0,
+}
+
+
+@dataclass(slots=True)
+class DisplayHints:
+    """How a pair's card renders its state, resolved from the pair's preferences."""
+
+    price_text: str
+    percentage_text: str
+    volume_text: str | None = None  # Compact 24h quote volume, when shown
+    symbol: str | None = None  # Replaces the default name, e.g. "USDT/BTC" when inverted
+    neutral: bool = False  # Move is below the color threshold
+
+
+def clean_display_prefs(prefs: dict) -> dict:
+    """Known preferences that differ from the defaults."""
+    return {
+        key: value
+        for key, value in prefs.items()
+        if key in DEFAULT_DISPLAY_PREFS and value != DEFAULT_DISPLAY_PREFS[key]
+    }
+
+
+def parse_percentage(percentage: str) -> float:
+    """Change in percent of a string like "+1.50%"; 0 if unparseable."""
+    try:
+        return float(percentage.strip("%").replace("+", ""))
+    except ValueError:
+        return 0.0
+
+
+def resolve_display_hints(
+    pair: str,
+    prefs: dict,
+    price: float,
+    percentage: str,
+    quote_volume: str = "0",
+    fiat_price: float | None = None,
+    fiat_currency: str = "USD",
+) -> DisplayHints:
+    """Apply a pair's display preferences to its latest price."""
+    decimals = prefs.get("decimals")
+    change = parse_percentage(percentage)
+    symbol = None
+
+    base, _sep, quote = pair.partition("-")
+    if prefs.get("invert") and quote and price > 0:
+        # Quote per base becomes base per quote; the change inverts with it
+        price = 1 / price
+        change = (1 / (1 + change / 100) - 1) * 100 if change > -100 else 0.0
+        percentage = f"+{change:.2f}%" if change >= 0 else f"{change:.2f}%"
+        symbol = f"{quote}/{base}"
+        price_text = format_price(price, decimals)
+    elif fiat_price is not None:
+        price_text = format_fiat(fiat_price, fiat_currency, decimals)
+    else:
+        price_text = format_price(price, decimals)
+
+    volume_text = None
+    if prefs.get("show_volume"):
+        try:
+            volume_text = format_compact(float(quote_volume))
+        except ValueError:
+            volume_text = None
+
+    return DisplayHints(
+        price_text=price_text,
+        percentage_text=percentage,
+        volume_text=volume_text,
+        symbol=symbol,
+        neutral=abs(change) < prefs.get("color_threshold", 0.0),
+    )
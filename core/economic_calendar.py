@@ -0,0 +1,176 @@
+"""
+Economic calendar.
+Fetches this week's macro releases (FOMC decisions, CPI, payrolls, ...) from
+the Forex Factory calendar feed and reminds ahead of high-impact events,
+since crypto volatility clusters around them.
+"""
+
+import logging
+import threading
+import time
+from dataclasses import dataclass
+from datetime import datetime
+
+import requests
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from config.settings import get_settings_manager
+from core.utils.network import get_proxy_config
+
+logger = logging.getLogger(__name__)
+
+# Crypto mostly reacts to US releases
+CALENDAR_COUNTRIES = ("USD",)
+HIGH_IMPACT = "High"
+
+
+@dataclass
+class EconomicEvent:
+    """A scheduled macro release."""
+
+    title: str  # e.g. "CPI m/m"
+    country: str  # Currency code, e.g. "USD"
+    timestamp: float  # Seconds
+    impact: str  # "High", "Medium", "Low" or "Holiday"
+    forecast: str = ""
+    previous: str = ""
+
+    @property
+    def key(self) -> str:
+        return f"{self.country}:{self.title}:{self.timestamp:.0f}"
+
+    def minutes_until(self, now: float) -> float:
+        return (self.timestamp - now) / 60
+
+
+def parse_calendar(data: list[dict]) -> list[EconomicEvent]:
+    """Parse the calendar feed, skipping malformed entries; sorted by time."""
+    events = []
+    for entry in data:
+        try:
+            timestamp = datetime.fromisoformat(entry["date"]).timestamp()
+        except (KeyError, TypeError, ValueError):
+            continue
+        events.append(
+            EconomicEvent(
+                title=str(entry.get("title", "")).strip(),
+                country=str(entry.get("country", "")).upper(),
+                timestamp=timestamp,
+                impact=str(entry.get("impact", "")),
+                forecast=str(entry.get("forecast") or ""),
+                previous=str(entry.get("previous") or ""),
+            )
+        )
+    return sorted(events, key=lambda e: e.timestamp)
+
+
+class EconomicCalendarService(QObject):
+    """
+    Keeps the week's calendar and emits a reminder once per high-impact event
+    when it comes within the lead time.
+    """
+
+    event_upcoming = pyqtSignal(object)  # EconomicEvent
+
+    CALENDAR_URL = "https://nfs.faireconomy.media/ff_calendar_thisweek.json"
+    # The feed is rate limited and only changes when the week rolls over
+    REFRESH_INTERVAL_MS = 6 * 60 * 60 * 1000
+    CHECK_INTERVAL_MS = 60 * 1000
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._events: list[EconomicEvent] = []
+        self._reminded: set[str] = set()
+        self._fetching = False
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.REFRESH_INTERVAL_MS)
+        self._timer.timeout.connect(self.refresh)
+
+        self._check_timer = QTimer(self)
+        self._check_timer.setInterval(self.CHECK_INTERVAL_MS)
+        self._check_timer.timeout.connect(self._check_reminders)
+
+    def start(self):
+        """Start periodic refresh and reminder checks, if macro alerts are on."""
+        if not self._is_needed():
+            return
+        if not self._timer.isActive():
+            self._timer.start()
+            self._check_timer.start()
+        self.refresh()
+
+    def stop(self):
+        """Stop refreshing and reminding."""
+        self._timer.stop()
+        self._check_timer.stop()
+
+    def apply_settings(self):
+        """Start or stop after macro alerts were switched."""
+        if not self._is_needed():
+            self.stop()
+        elif not self._timer.isActive():
+            self.start()
+
+    def _is_needed(self) -> bool:
+        return self._settings_manager.settings.macro_alerts
+
+    def refresh(self):
+        """Fetch the calendar in a background thread."""
+        if self._fetching or not self._is_needed():
+            return
+        self._fetching = True
+        threading.Thread(target=self._fetch, daemon=True).start()
+
+    def _fetch(self):
+        try:
+            response = requests.get(self.CALENDAR_URL, proxies=get_proxy_config(), timeout=10)
+            response.raise_for_status()
+            data = response.json()
+            if not isinstance(data, list):
+                raise ValueError("Unexpected response")
+            self._events = parse_calendar(data)
+        except Exception as e:
+            logger.warning(f"Economic calendar request failed: {e}")
+            return
+        finally:
+            self._fetching = False
+        logger.debug(f"Economic calendar updated ({len(self._events)} events)")
+
+    def due_reminders(self, now: float, lead_minutes: float) -> list[EconomicEvent]:
+        """
+        High-impact events starting within lead_minutes.
+
+        Returns:
+            Events not reminded of before
+        """
+        due = []
+        for event in self._events:
+            if event.impact != HIGH_IMPACT or event.country not in CALENDAR_COUNTRIES:
+                continue
+            if not 0 < event.minutes_until(now) <= lead_minutes:
+                continue
+            if event.key in self._reminded:
+                continue
+            self._reminded.add(event.key)
+            due.append(event)
+        return due
+
+    def _check_reminders(self):
+        lead_minutes = self._settings_manager.settings.macro_lead_minutes
+        for event in self.due_reminders(time.time(), lead_minutes):
+            logger.info(f"Upcoming {event.country} event: {event.title}")
+            self.event_upcoming.emit(event)
+
+
+# Global economic calendar service instance
+_economic_calendar: EconomicCalendarService | None = None
+
+
+def get_economic_calendar() -> EconomicCalendarService:
+    """Get the global economic calendar service instance."""
+    global _economic_calendar
+    if _economic_calendar is None:
+        _economic_calendar = EconomicCalendarService()
+    return _economic_calendar
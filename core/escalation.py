@@ -0,0 +1,125 @@
+"""
+Alert escalation.
+An alert with escalation steps fires on the desktop only. While nobody
+acknowledges it, each step then forwards it to one remote channel once its
+minutes have passed, e.g. Telegram after 2 minutes and email after 10.
+Acknowledging the alert in the alert manager stops its escalation.
+"""
+
+import logging
+import time
+from dataclasses import dataclass
+
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from config.settings import EscalationStep, PriceAlert
+from core.i18n import _
+from core.notification_channels import get_remote_notifier
+from core.notifier import get_notification_service
+from core.number_format import get_number_formatter
+
+logger = logging.getLogger(__name__)
+
+CHECK_INTERVAL_MS = 15_000
+
+
+@dataclass(slots=True)
+class PendingEscalation:
+    """A fired alert waiting for acknowledgement."""
+
+    alert_id: str
+    pair: str
+    price: float  # Price the alert fired at
+    triggered_at: float
+    steps: list[EscalationStep]  # Sorted by delay
+    next_step: int = 0
+
+
+def escalation_message(pending: PendingEscalation) -> tuple[str, str]:
+    """Title and message forwarded by an escalation step."""
+    symbol = pending.pair.split("-")[0]
+    clock = time.strftime("%H:%M", time.localtime(pending.triggered_at))
+    price = get_number_formatter().price(pending.price)
+    title = f"{symbol} ⏰ {_('Unacknowledged Alert')}"
+    message = f"{pending.pair} {_('alert fired at')} {clock}\n{_('Price:')} ${price}"
+    return title, message
+
+
+class EscalationManager(QObject):
+    """Escalates fired alerts step by step until they are acknowledged."""
+
+    escalated = pyqtSignal(str, str)  # pair, channel name
+
+    def __init__(self, remote_notifier, notification_service, parent: QObject | None = None):
+        super().__init__(parent)
+        self._remote_notifier = remote_notifier
+        self._notification_service = notification_service
+        self._pending: dict[str, PendingEscalation] = {}  # alert_id -> escalation
+        self._timer = QTimer(self)
+        self._timer.setInterval(CHECK_INTERVAL_MS)
+        self._timer.timeout.connect(self.check)
+
+    def start(self, alert: PriceAlert, price: float, now: float | None = None):
+        """Begin escalating a fired alert; firing again restarts its chain."""
+        if not alert.escalation:
+            return
+        steps = sorted(alert.escalation, key=lambda step: step.after_minutes)
+        self._pending[alert.id] = PendingEscalation(
+            alert.id, alert.pair, price, now if now is not None else time.time(), steps
+        )
+        if not self._timer.isActive():
+            self._timer.start()
+
+    def check(self, now: float | None = None):
+        """Run the steps that are due."""
+        if now is None:
+            now = time.time()
+        due: list[tuple[PendingEscalation, EscalationStep]] = []
+        for alert_id, pending in list(self._pending.items()):
+            while pending.next_step < len(pending.steps):
+                step = pending.steps[pending.next_step]
+                if now - pending.triggered_at < step.after_minutes * 60:
+                    break
+                due.append((pending, step))
+                pending.next_step += 1
+            if pending.next_step >= len(pending.steps):
+                del self._pending[alert_id]
+        if not self._pending:
+            self._timer.stop()
+
+        for pending, step in due:
+            self._escalate(pending, step)
+
+    def _escalate(self, pending: PendingEscalation, step: EscalationStep):
+        if self._notification_service.is_paused:
+            logger.debug(f"Escalation of {pending.pair} to {step.channel} skipped while muted")
+            return
+        title, message = escalation_message(pending)
+        if not self._remote_notifier.send_to(step.channel, title, message):
+            logger.warning(f"Cannot escalate {pending.pair}: {step.channel} is not set up")
+            return
+        logger.info(f"Escalated {pending.pair} alert to {step.channel}")
+        self.escalated.emit(pending.pair, step.channel)
+
+    def stop(self, alert_id: str) -> bool:
+        """Stop escalating an alert; returns whether it was escalating."""
+        pending = self._pending.pop(alert_id, None)
+        if pending is not None:
+            logger.info(f"Stopped escalating {pending.pair} alert")
+        return pending is not None
+
+    def pending_pairs(self) -> list[str]:
+        """Pairs with an alert still escalating."""
+        return sorted({pending.pair for pending in self._pending.values()})
+
+
+# Global escalation manager instance
+_escalation_manager: EscalationManager | None = None
+
+
+def get_escalation_manager() -> EscalationManager:
+    """Get the global escalation manager instance."""
+    global _escalation_manager
+    if _escalation_manager is None:
+        _escalation_manager = EscalationManager(get_remote_notifier(), get_notification_service())
+    return _escalation_manager
@@ -0,0 +1,109 @@
+"""
+Network fault injection for testing.
+A developer mode (--chaos) that randomly drops WebSocket connections, delays
+and reorders incoming messages and corrupts frames, to exercise the
+reconnect, heartbeat and backpressure handling without a flaky network.
+"""
+
+import logging
+import random
+
+logger = logging.getLogger(__name__)
+
+
+class FaultInjector:
+    """
+    Decides which faults to inject; inert unless one of the rates is set.
+
+    Args:
+        drop_rate: Chance per second that a live connection is dropped
+        max_delay: Messages are held back a random time up to this, in seconds
+        corrupt_rate: Chance that a message is truncated or garbled
+        seed: Seed for reproducible runs
+    """
+
+    def __init__(
+        self,
+        drop_rate: float = 0.0,
+        max_delay: float = 0.0,
+        corrupt_rate: float = 0.0,
+        seed: int | None = None,
+    ):
+        self.drop_rate = drop_rate
+        self.max_delay = max_delay
+        self.corrupt_rate = corrupt_rate
+        self._rng = random.Random(seed)
+        self.injected = {"drops": 0, "delays": 0, "corruptions": 0}
+
+    @property
+    def enabled(self) -> bool:
+        return self.drop_rate > 0 or self.max_delay > 0 or self.corrupt_rate > 0
+
+    def should_drop(self) -> bool:
+        """Whether to drop the connection now; asked once per second."""
+        if self.drop_rate > 0 and self._rng.random() < self.drop_rate:
+            self.injected["drops"] += 1
+            return True
+        return False
+
+    def delay(self) -> float:
+        """Seconds to hold the next message back."""
+        if self.max_delay <= 0:
+            return 0.0
+        self.injected["delays"] += 1
+        return self._rng.uniform(0, self.max_delay)
+
+    def corrupt(self, raw: str | bytes) -> str | bytes:
+        """The message, truncated or with a byte garbled now and then."""
+        if self.corrupt_rate <= 0 or not raw or self._rng.random() >= self.corrupt_rate:
+            return raw
+        self.injected["corruptions"] += 1
+        index = self._rng.randrange(len(raw))
+        if self._rng.random() < 0.5:
+            return raw[:index]
+        garbage = "\x00" if isinstance(raw, str) else b"\x00"
+        return raw[:index] + garbage + raw[index + 1 :]
+
+
+def parse_fault_spec(spec: str) -> FaultInjector:
+    """
+    Build an injector from a spec like "drop=0.01,delay=0.5,corrupt=0.02,seed=1".
+
+    Raises:
+        ValueError: On unknown keys or malformed values
+    """
+    options = {"drop": 0.0, "delay": 0.0, "corrupt": 0.0}
+    seed = None
+    for entry in filter(None, (part.strip() for part in spec.split(","))):
+        key, sep, value = entry.partition("=")
+        key = key.strip().lower()
+        if not sep:
+            raise ValueError(f"Expected key=value, got '{entry}'")
+        if key == "seed":
+            seed = int(value)
+        elif key in options:
+            options[key] = float(value)
+            if options[key] < 0:
+                raise ValueError(f"'{key}' must not be negative")
+        else:
+            raise ValueError(f"Unknown fault '{key}', expected drop, delay, corrupt or seed")
+    return FaultInjector(options["drop"], options["delay"], options["corrupt"], seed)
+
+
+# Global fault injector instance, inert unless enabled on the command line
+_fault_injector = FaultInjector()
+
+
+def get_fault_injector() -> FaultInjector:
+    """Get the global fault injector instance."""
+    return _fault_injector
+
+
+def enable_fault_injection(injector: FaultInjector) -> None:
+    """Use an injector for all WebSocket workers started from now on."""
+    global _fault_injector
+    _fault_injector = injector
+    logger.warning(
+        f"Fault injection enabled: drop={injector.drop_rate}/s, "
+        f"delay<={injector.max_delay}s, corrupt={injector.corrupt_rate}"
+    )
@@ -0,0 +1,198 @@
+"""
+Network fee monitor.
+Polls mempool.space for the recommended BTC fee rate and Etherscan (or a
+public RPC node) for the ETH gas price, exposes them as index tickers and
+flags when fees drop below the user's targets.
+"""
+
+import logging
+import threading
+from dataclasses import dataclass
+
+import requests
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from config.settings import get_settings_manager
+from core.market_indices import (
+    BTC_FEE_PAIR,
+    ETH_GAS_PAIR,
+    INDEX_NAMES,
+    change_percentage,
+    index_pairs_in_use,
+)
+from core.models import TickerData
+from core.utils.network import get_proxy_config
+
+logger = logging.getLogger(__name__)
+
+
+@dataclass
+class NetworkFees:
+    """Current fee levels; None where the source failed."""
+
+    btc_sat_vb: float | None = None  # Next-block (fastest) fee rate
+    eth_gwei: float | None = None  # Proposed gas price
+
+
+class FeeMonitor(QObject):
+    """Periodically fetches BTC and ETH network fees in a background thread."""
+
+    fees_updated = pyqtSignal(object)  # NetworkFees
+
+    MEMPOOL_URL = "https://mempool.space/api/v1/fees/recommended"
+    ETHERSCAN_URL = "https://api.etherscan.io/v2/api"
+    ETH_RPC_URL = "https://ethereum-rpc.publicnode.com"
+    REFRESH_INTERVAL_MS = 2 * 60 * 1000
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._fees: NetworkFees | None = None
+        self._previous: NetworkFees | None = None
+        self._below: dict[str, bool] = {}
+        self._fetching = False
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.REFRESH_INTERVAL_MS)
+        self._timer.timeout.connect(self.refresh)
+
+    def start(self):
+        """Start periodic refresh, if fees are watched or alerted on."""
+        if not self._is_needed():
+            return
+        if not self._timer.isActive():
+            self._timer.start()
+        self.refresh()
+
+    def stop(self):
+        """Stop periodic refresh."""
+        self._timer.stop()
+
+    def apply_settings(self):
+        """Start or stop refreshing after the alerts or watched pairs changed."""
+        if not self._is_needed():
+            self.stop()
+        elif not self._timer.isActive():
+            self.start()
+
+    def _is_needed(self) -> bool:
+        settings = self._settings_manager.settings
+        return settings.fee_alerts or index_pairs_in_use(settings, (BTC_FEE_PAIR, ETH_GAS_PAIR))
+
+    def refresh(self):
+        """Fetch current fees in a background thread."""
+        if self._fetching or not self._is_needed():
+            return
+        self._fetching = True
+        threading.Thread(target=self._fetch, daemon=True).start()
+
+    def _fetch(self):
+        try:
+            fees = NetworkFees(btc_sat_vb=self._fetch_btc(), eth_gwei=self._fetch_eth())
+        finally:
+            self._fetching = False
+        if fees.btc_sat_vb is None and fees.eth_gwei is None:
+            logger.warning("Failed to fetch network fees from all sources")
+            return
+        self._previous, self._fees = self._fees, fees
+        logger.debug(f"Network fees: BTC {fees.btc_sat_vb} sat/vB, ETH {fees.eth_gwei} gwei")
+        self.fees_updated.emit(fees)
+
+    def _fetch_btc(self) -> float | None:
+        try:
+            response = requests.get(self.MEMPOOL_URL, proxies=get_proxy_config(), timeout=10)
+            response.raise_for_status()
+            return float(response.json()["fastestFee"])
+        except Exception as e:
+            logger.debug(f"mempool.space fee request failed: {e}")
+            return None
+
+    def _fetch_eth(self) -> float | None:
+        api_key = self._settings_manager.settings.etherscan_api_key
+        if api_key:
+            try:
+                response = requests.get(
+                    self.ETHERSCAN_URL,
+                    params={
+                        "chainid": 1,
+                        "module": "gastracker",
+                        "action": "gasoracle",
+                        "apikey": api_key,
+                    },
+                    proxies=get_proxy_config(),
+                    timeout=10,
+                )
+                response.raise_for_status()
+                return float(response.json()["result"]["ProposeGasPrice"])
+            except Exception as e:
+                logger.debug(f"Etherscan gas oracle request failed: {e}")
+
+        # Keyless fallback: the node's suggested gas price
+        try:
+            response = requests.post(
+                self.ETH_RPC_URL,
+                json={"jsonrpc": "2.0", "method": "eth_gasPrice", "params": [], "id": 1},
+                proxies=get_proxy_config(),
+                timeout=10,
+            )
+            response.raise_for_status()
+            return int(response.json()["result"], 16) / 1e9
+        except Exception as e:
+            logger.debug(f"ETH RPC gas price request failed: {e}")
+            return None
+
+    def check_targets(
+        self, fees: NetworkFees, btc_target: float, eth_target: float
+    ) -> list[tuple[str, float, float]]:
+        """
+        Track which fees are below their targets.
+
+        Returns:
+            (network, fee, target) for each fee that just dropped below its target
+        """
+        dropped = []
+        for network, fee, target in (
+            ("BTC", fees.btc_sat_vb, btc_target),
+            ("ETH", fees.eth_gwei, eth_target),
+        ):
+            if fee is None:
+                continue
+            below = fee <= target
+            if below and not self._below.get(network, False):
+                dropped.append((network, fee, target))
+            self._below[network] = below
+        return dropped
+
+    def get_tickers(self) -> list[TickerData]:
+        """Fee levels as index tickers, with the change since the previous reading."""
+        if self._fees is None:
+            return []
+        previous = self._previous or NetworkFees()
+        tickers = []
+        for pair, fee, previous_fee in (
+            (BTC_FEE_PAIR, self._fees.btc_sat_vb, previous.btc_sat_vb),
+            (ETH_GAS_PAIR, self._fees.eth_gwei, previous.eth_gwei),
+        ):
+            if fee is None:
+                continue
+            tickers.append(
+                TickerData(
+                    pair=pair,
+                    price=f"{fee:g}",
+                    percentage=change_percentage(fee, previous_fee),
+                    display_name=INDEX_NAMES[pair],
+                )
+            )
+        return tickers
+
+
+# Global fee monitor instance
+_fee_monitor: FeeMonitor | None = None
+
+
+def get_fee_monitor() -> FeeMonitor:
+    """Get the global fee monitor instance."""
+    global _fee_monitor
+    if _fee_monitor is None:
+        _fee_monitor = FeeMonitor()
+    return _fee_monitor
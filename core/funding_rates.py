@@ -0,0 +1,263 @@
+"""
+Perpetual funding rates across exchanges.
+Fetches the current funding rate of the same perpetual swap on OKX, Binance
+and Bybit so the spread between venues can be compared and funding
+arbitrage opportunities flagged.
+"""
+
+import logging
+import threading
+import time
+from dataclasses import dataclass, field
+
+import requests
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from core.rate_limiter import get_rate_limiter
+from core.utils.network import get_proxy_config
+
+logger = logging.getLogger(__name__)
+
+# Rates are compared per this many hours, since venues settle at different intervals
+BASE_INTERVAL_HOURS = 8
+PERP_QUOTES = ("USDT", "USDC")
+
+
+@dataclass
+class FundingRate:
+    """Current funding rate of a perpetual on one exchange."""
+
+    exchange: str
+    rate: float  # Fraction per funding interval, e.g. 0.0001 = 0.01%
+    interval_hours: float = BASE_INTERVAL_HOURS
+    next_funding_time: int = 0  # Milliseconds
+
+    @property
+    def normalized_rate(self) -> float:
+        """Rate scaled to BASE_INTERVAL_HOURS."""
+        return self.rate * BASE_INTERVAL_HOURS / self.interval_hours
+
+
+@dataclass
+class FundingComparison:
+    """Funding rates of one pair's perpetual on several exchanges."""
+
+    pair: str
+    rates: list[FundingRate] = field(default_factory=list)
+
+    @property
+    def highest(self) -> FundingRate | None:
+        return max(self.rates, key=lambda r: r.normalized_rate, default=None)
+
+    @property
+    def lowest(self) -> FundingRate | None:
+        return min(self.rates, key=lambda r: r.normalized_rate, default=None)
+
+    @property
+    def spread(self) -> float:
+        """Normalized rate difference between the highest and lowest venue."""
+        if len(self.rates) < 2:
+            return 0.0
+        return self.highest.normalized_rate - self.lowest.normalized_rate
+
+    def is_arbitrage(self, threshold_pct: float) -> bool:
+        """Whether the spread (in percent per BASE_INTERVAL_HOURS) reaches the threshold."""
+        return len(self.rates) >= 2 and self.spread * 100 >= threshold_pct
+
+
+def get_perp_symbol(pair: str) -> tuple[str, str] | None:
+    """
+    Base and quote of the USDT/USDC-margined perpetual of a pair.
+
+    Returns None for pairs without a perpetual (DEX, virtual and index pairs).
+    """
+    if ":" in pair:
+        return None
+    base, _sep, quote = pair.upper().partition("-")
+    if not base or not quote:
+        return None
+    return base, quote if quote in PERP_QUOTES else "USDT"
+
+
+class FundingRateClient:
+    """Fetches current funding rates from the public APIs of each exchange."""
+
+    OKX_URL = "https://www.okx.com/api/v5/public/funding-rate"
+    BINANCE_URL = "https://fapi.binance.com/fapi/v1/premiumIndex"
+    BINANCE_INFO_URL = "https://fapi.binance.com/fapi/v1/fundingInfo"
+    BYBIT_URL = "https://api.bybit.com/v5/market/tickers"
+    BYBIT_INFO_URL = "https://api.bybit.com/v5/market/instruments-info"
+    INFO_CACHE_TTL = 60 * 60
+
+    def __init__(self):
+        self._binance_intervals: dict[str, float] = {}  # symbol -> hours
+        self._binance_intervals_at = 0.0
+
+    def _get(self, url: str, params: dict | None = None):
+        get_rate_limiter().acquire_url(url)
+        response = requests.get(url, params=params, proxies=get_proxy_config(), timeout=10)
+        response.raise_for_status()
+        return response.json()
+
+    def fetch_all(self, base: str, quote: str) -> list[FundingRate]:
+        """Rates from every exchange listing the perpetual; failures are skipped."""
+        rates = []
+        for exchange, fetch in (
+            ("OKX", self.fetch_okx),
+            ("Binance", self.fetch_binance),
+            ("Bybit", self.fetch_bybit),
+        ):
+            try:
+                rate = fetch(base, quote)
+            except Exception as e:
+                logger.debug(f"{exchange} funding rate for {base}-{quote} failed: {e}")
+                continue
+            if rate is not None:
+                rates.append(rate)
+        return rates
+
+    def fetch_okx(self, base: str, quote: str) -> FundingRate | None:
+        data = self._get(self.OKX_URL, {"instId": f"{base}-{quote}-SWAP"}).get("data") or []
+        if not data:
+            return None
+        entry = data[0]
+        funding_time = int(entry.get("fundingTime") or 0)
+        next_time = int(entry.get("nextFundingTime") or 0)
+        interval = (next_time - funding_time) / 3_600_000 if next_time > funding_time else 0
+        return FundingRate(
+            exchange="OKX",
+            rate=float(entry["fundingRate"]),
+            interval_hours=interval or BASE_INTERVAL_HOURS,
+            next_funding_time=funding_time,
+        )
+
+    def fetch_binance(self, base: str, quote: str) -> FundingRate | None:
+        symbol = f"{base}{quote}"
+        data = self._get(self.BINANCE_URL, {"symbol": symbol})
+        if not isinstance(data, dict) or "lastFundingRate" not in data:
+            return None
+        return FundingRate(
+            exchange="Binance",
+            rate=float(data["lastFundingRate"]),
+            interval_hours=self._binance_interval(symbol),
+            next_funding_time=int(data.get("nextFundingTime") or 0),
+        )
+
+    def _binance_interval(self, symbol: str) -> float:
+        # fundingInfo only lists symbols whose interval differs from the default
+        if time.time() - self._binance_intervals_at > self.INFO_CACHE_TTL:
+            try:
+                self._binance_intervals = {
+                    info["symbol"]: float(info["fundingIntervalHours"])
+                    for info in self._get(self.BINANCE_INFO_URL)
+                }
+                self._binance_intervals_at = time.time()
+            except Exception as e:
+                logger.debug(f"Binance funding info request failed: {e}")
+        return self._binance_intervals.get(symbol, BASE_INTERVAL_HOURS)
+
+    def fetch_bybit(self, base: str, quote: str) -> FundingRate | None:
+        params = {"category": "linear", "symbol": f"{base}{quote}"}
+        tickers = self._get(self.BYBIT_URL, params).get("result", {}).get("list") or []
+        if not tickers or not tickers[0].get("fundingRate"):
+            return None
+        instruments = self._get(self.BYBIT_INFO_URL, params).get("result", {}).get("list") or []
+        interval_minutes = instruments[0].get("fundingInterval") if instruments else None
+        return FundingRate(
+            exchange="Bybit",
+            rate=float(tickers[0]["fundingRate"]),
+            interval_hours=int(interval_minutes) / 60 if interval_minutes else BASE_INTERVAL_HOURS,
+            next_funding_time=int(tickers[0].get("nextFundingTime") or 0),
+        )
+
+
+class FundingRateService(QObject):
+    """Periodically compares the funding rates of the monitored pairs' perpetuals."""
+
+    rates_updated = pyqtSignal()
+
+    REFRESH_INTERVAL_MS = 5 * 60 * 1000
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._client = FundingRateClient()
+        self._pairs: list[str] = []
+        self._comparisons: dict[str, FundingComparison] = {}
+        self._arbitrage: dict[str, bool] = {}
+        self._fetching = False
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.REFRESH_INTERVAL_MS)
+        self._timer.timeout.connect(self.refresh)
+
+    def start(self):
+        """Start periodic refresh."""
+        if not self._timer.isActive():
+            self._timer.start()
+
+    def stop(self):
+        """Stop periodic refresh."""
+        self._timer.stop()
+
+    def set_pairs(self, pairs: list[str]):
+        """Track the perpetuals of the given pairs and fetch new ones."""
+        tracked = [pair for pair in pairs if get_perp_symbol(pair)]
+        added = [pair for pair in tracked if pair not in self._pairs]
+        self._pairs = tracked
+        for pair in [pair for pair in self._comparisons if pair not in tracked]:
+            del self._comparisons[pair]
+            self._arbitrage.pop(pair, None)
+        if added:
+            self.refresh()
+
+    def refresh(self):
+        """Fetch funding rates in a background thread."""
+        if self._fetching or not self._pairs:
+            return
+        self._fetching = True
+        threading.Thread(target=self._fetch, args=(list(self._pairs),), daemon=True).start()
+
+    def _fetch(self, pairs: list[str]):
+        try:
+            comparisons = dict(self._comparisons)
+            for pair in pairs:
+                base, quote = get_perp_symbol(pair)
+                rates = self._client.fetch_all(base, quote)
+                if rates:
+                    comparisons[pair] = FundingComparison(pair=pair, rates=rates)
+            self._comparisons = comparisons
+        finally:
+            self._fetching = False
+        logger.debug(f"Funding rates updated for {len(pairs)} pairs")
+        self.rates_updated.emit()
+
+    def get_comparison(self, pair: str) -> FundingComparison | None:
+        """Latest funding rates of a pair's perpetual, or None if unknown."""
+        return self._comparisons.get(pair)
+
+    def check_arbitrage(self, threshold_pct: float) -> list[FundingComparison]:
+        """
+        Track which pairs have a funding spread at or above the threshold.
+
+        Returns:
+            Comparisons whose spread just reached the threshold
+        """
+        opened = []
+        for pair, comparison in self._comparisons.items():
+            arbitrage = comparison.is_arbitrage(threshold_pct)
+            if arbitrage and not self._arbitrage.get(pair, False):
+                opened.append(comparison)
+            self._arbitrage[pair] = arbitrage
+        return opened
+
+
+# Global funding rate service instance
+_funding_rate_service: FundingRateService | None = None
+
+
+def get_funding_rate_service() -> FundingRateService:
+    """Get the global funding rate service instance."""
+    global _funding_rate_service
+    if _funding_rate_service is None:
+        _funding_rate_service = FundingRateService()
+    return _funding_rate_service
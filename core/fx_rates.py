@@ -0,0 +1,182 @@
+"""
+Fiat exchange rate service.
+Fetches USD-based FX rates (ECB reference rates) so USD-quoted prices
+can be displayed and valued in the user's chosen fiat currency.
+"""
+
+import logging
+import threading
+import time
+
+import requests
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from core.utils.network import get_proxy_config
+
+logger = logging.getLogger(__name__)
+
+# Supported display currencies and their symbols
+SUPPORTED_FIAT_CURRENCIES = {
+    "USD": "$",
+    "EUR": "€",
+    "CNY": "¥",
+    "JPY": "¥",
+}
+
+# Quote assets treated as 1:1 with USD
+USD_PEGGED_ASSETS = {"USD", "USDT", "USDC", "FDUSD", "BUSD", "TUSD", "DAI"}
+
+
+class FxRateService(QObject):
+    """
+    Periodically refreshes fiat FX rates in a background thread.
+
+    Rates are expressed as units of fiat currency per 1 USD.
+    """
+
+    rates_updated = pyqtSignal(dict)  # currency -> rate per USD
+
+    # ECB reference rates via Frankfurter, with a keyless fallback
+    PRIMARY_URL = "https://api.frankfurter.app/latest"
+    FALLBACK_URL = "https://open.er-api.com/v6/latest/USD"
+    REFRESH_INTERVAL_MS = 60 * 60 * 1000  # ECB publishes once per working day
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._rates: dict[str, float] = {"USD": 1.0}
+        self._last_updated = 0.0
+        self._fetching = False
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.REFRESH_INTERVAL_MS)
+        self._timer.timeout.connect(self.refresh)
+
+    def start(self):
+        """Start periodic refresh."""
+        if not self._timer.isActive():
+            self._timer.start()
+        self.refresh()
+
+    def stop(self):
+        """Stop periodic refresh."""
+        self._timer.stop()
+
+    def refresh(self):
+        """Fetch latest rates in a background thread."""
+        if self._fetching:
+            return
+        self._fetching = True
+        threading.Thread(target=self._fetch, daemon=True).start()
+
+    def _fetch(self):
+        try:
+            rates = self._fetch_primary() or self._fetch_fallback()
+            if rates:
+                rates["USD"] = 1.0
+                self._rates = rates
+                self._last_updated = time.time()
+                logger.info(f"FX rates updated: {rates}")
+                self.rates_updated.emit(dict(rates))
+            else:
+                logger.warning("Failed to fetch FX rates from all sources")
+        finally:
+            self._fetching = False
+
+    def _fetch_primary(self) -> dict[str, float] | None:
+        targets = ",".join(c for c in SUPPORTED_FIAT_CURRENCIES if c != "USD")
+        try:
+            response = requests.get(
+                self.PRIMARY_URL,
+                params={"from": "USD", "to": targets},
+                proxies=get_proxy_config(),
+                timeout=10,
+            )
+            response.raise_for_status()
+            data = response.json().get("rates", {})
+            return {k: float(v) for k, v in data.items() if k in SUPPORTED_FIAT_CURRENCIES}
+        except Exception as e:
+            logger.debug(f"Primary FX source failed: {e}")
+            return None
+
+    def _fetch_fallback(self) -> dict[str, float] | None:
+        try:
+            response = requests.get(self.FALLBACK_URL, proxies=get_proxy_config(), timeout=10)
+            response.raise_for_status()
+            data = response.json().get("rates", {})
+            return {k: float(v) for k, v in data.items() if k in SUPPORTED_FIAT_CURRENCIES}
+        except Exception as e:
+            logger.debug(f"Fallback FX source failed: {e}")
+            return None
+
+    def get_rate(self, currency: str) -> float | None:
+        """Get units of `currency` per 1 USD, or None if unknown."""
+        return self._rates.get(currency.upper())
+
+    def set_rates(self, rates: dict[str, float]):
+        """Override rates (used when restoring cached values and in tests)."""
+        self._rates = {"USD": 1.0, **{k.upper(): float(v) for k, v in rates.items()}}
+        self._last_updated = time.time()
+
+    @property
+    def last_updated(self) -> float:
+        """Timestamp of the last successful update (0 if never)."""
+        return self._last_updated
+
+    def convert_usd(self, amount: float, currency: str) -> float | None:
+        """Convert a USD amount into the target fiat currency."""
+        rate = self.get_rate(currency)
+        if rate is None:
+            return None
+        return amount * rate
+
+    def convert_from_quote(self, amount: float, quote_asset: str, currency: str) -> float | None:
+        """
+        Convert an amount denominated in a quote asset into fiat.
+
+        Only USD-pegged quote assets (USDT, USDC, ...) can be converted directly.
+        """
+        quote = quote_asset.upper()
+        if quote in USD_PEGGED_ASSETS:
+            return self.convert_usd(amount, currency)
+        if quote == currency.upper():
+            return amount
+        rate = self.get_rate(quote)
+        if rate:
+            # Fiat-quoted pair, e.g., BTC-EUR
+            return self.convert_usd(amount / rate, currency)
+        return None
+
+
+def get_quote_asset(pair: str) -> str:
+    """Get the quote asset of a pair; DEX pairs are priced in USD."""
+    if pair.lower().startswith("chain:"):
+        return "USD"
+    if pair.lower().startswith(("virtual:", "index:", "pool:", "oracle:")):
+        # Virtual pairs can be ratios, indices have no currency and pools and
+        # oracle feeds are priced in their quote token
+        return ""
+    parts = pair.split("-")
+    return parts[1].upper() if len(parts) > 1 else ""
+
+
+def format_fiat(amount: float, currency: str, precision: int | None = None) -> str:
+    """Format an amount with its currency symbol, e.g. "€1234.56"."""
+    from core.utils import format_price
+
+    symbol = SUPPORTED_FIAT_CURRENCIES.get(currency.upper(), "")
+    if precision is None and currency.upper() == "JPY" and abs(amount) >= 100:
+        precision = 0
+    text = format_price(amount, precision)
+    return f"{symbol}{text}" if symbol else f"{text} {currency.upper()}"
+
+
+# Global FX rate service instance
+_fx_rate_service: FxRateService | None = None
+
+
+def get_fx_rate_service() -> FxRateService:
+    """Get the global FX rate service instance."""
+    global _fx_rate_service
+    if _fx_rate_service is None:
+        _fx_rate_service = FxRateService()
+    return _fx_rate_service
@@ -0,0 +1,89 @@
+"""
+Per-pair highlight rules.
+A rule colors a pair, and optionally flashes it, once its move passes a
+threshold, e.g. red when down more than 5%. Rules are matched with every tick
+and the resulting highlight travels with the pair's price state, so the cards,
+the tray and the mini ticker all show the same thing.
+"""
+
+from dataclasses import dataclass
+
+RULE_DIRECTIONS = ("up", "down")
+# Thresholds offered in the card menu, in percent
+RULE_THRESHOLDS = (2.0, 5.0, 10.0)
+
+
+@dataclass(slots=True)
+class HighlightRule:
+    """Highlight a pair when it moves at least threshold percent in a direction."""
+
+    direction: str  # "up" or "down"
+    threshold: float  # Percent, compared with the absolute change
+    color: str = ""  # Empty = the color schema's up or down color
+    flash: bool = False
+
+    def matches(self, change: float) -> bool:
+        if self.direction == "up":
+            return change >= self.threshold
+        return change <= -self.threshold
+
+    def to_dict(self) -> dict:
+        data = {"direction": self.direction, "threshold": self.threshold}
+        if self.color:
+            data["color"] = self.color
+        if self.flash:
+            data["flash"] = True
+        return data
+
+    @staticmethod
+    def from_dict(data: dict) -> "HighlightRule | None":
+        """Rule from its settings entry; None if malformed."""
+        direction = data.get("direction")
+        try:
+            threshold = float(data.get("threshold", 0))
+        except (TypeError, ValueError):
+            return None
+        if direction not in RULE_DIRECTIONS or threshold <= 0:
+            return None
+        color = data.get("color", "")
+        return HighlightRule(
+            direction, threshold, color if isinstance(color, str) else "", bool(data.get("flash"))
+        )
+
+
+@dataclass(slots=True)
+class Highlight:
+    """The rule a pair currently triggers, resolved to a color."""
+
+    direction: str
+    threshold: float
+    color: str
+    flash: bool
+
+
+def parse_rules(data) -> list[HighlightRule]:
+    """Valid rules of a pair's settings entry."""
+    if not isinstance(data, list):
+        return []
+    rules = (HighlightRule.from_dict(item) for item in data if isinstance(item, dict))
+    return [rule for rule in rules if rule is not None]
+
+
+def schema_color(direction: str, color_schema: str = "standard") -> str:
+    """Up or down color of a color schema (standard: green up, red down)."""
+    up, down = "#4CAF50", "#F44336"
+    if color_schema != "standard":
+        up, down = down, up
+    return up if direction == "up" else down
+
+
+def match_highlight(
+    rules: list[HighlightRule], change: float, color_schema: str = "standard"
+) -> Highlight | None:
+    """Highlight of the triggered rule with the highest threshold, if any."""
+    triggered = [rule for rule in rules if rule.matches(change)]
+    if not triggered:
+        return None
+    rule = max(triggered, key=lambda r: r.threshold)
+    color = rule.color or schema_color(rule.direction, color_schema)
+    return Highlight(rule.direction, rule.threshold, color, rule.flash)
@@ -0,0 +1,129 @@
+"""
+Memory budget for in-memory price history.
+Sparkline buffers and candle series (indicator seeds and tick-built candles)
+each keep a capped history per pair. When the buffers of a large watchlist
+would outgrow the configured budget at those caps, every cap is scaled down
+evenly, dropping the oldest entries first.
+"""
+
+import logging
+from dataclasses import dataclass
+
+from PyQt6.QtCore import QObject, QTimer
+
+from config.settings import get_settings_manager
+from core.candle_aggregator import CandleAggregator, get_candle_aggregator
+from core.indicators import MA_CROSS_PERIODS, IndicatorEngine, get_indicator_engine
+from core.sparkline import SparklineService, get_sparkline_service
+
+logger = logging.getLogger(__name__)
+
+# Approximate footprint of one entry, including its slot in the deque
+POINT_BYTES = 120  # (minute, price) tuple of a sparkline
+CANDLE_BYTES = 400  # Candle dataclass instance
+
+# Caps never go below these, so charts and the slow cross EMA stay usable
+MIN_SPARKLINE_POINTS = 60
+MIN_CANDLES = MA_CROSS_PERIODS[-1]
+
+BYTES_PER_MB = 1024 * 1024
+
+
+@dataclass
+class HistoryUsage:
+    """Estimated memory held by history buffers."""
+
+    buffers: int
+    entries: int
+    bytes: int
+    budget_bytes: int
+    sparkline_points: int  # Effective per-pair caps
+    candles: int
+
+
+class HistoryBudget(QObject):
+    """Periodically applies the configured caps and budget to all history buffers."""
+
+    CHECK_INTERVAL_MS = 60 * 1000
+
+    def __init__(
+        self,
+        sparklines: SparklineService,
+        indicators: IndicatorEngine,
+        aggregator: CandleAggregator,
+        parent: QObject | None = None,
+    ):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._sparklines = sparklines
+        self._indicators = indicators
+        self._aggregator = aggregator
+        self._caps = (0, 0)
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.CHECK_INTERVAL_MS)
+        self._timer.timeout.connect(self.enforce)
+
+    def start(self):
+        """Start periodic enforcement."""
+        if not self._timer.isActive():
+            self._timer.start()
+        self.enforce()
+
+    def stop(self):
+        """Stop periodic enforcement."""
+        self._timer.stop()
+
+    def enforce(self) -> HistoryUsage:
+        """Resize all buffers to the caps that fit the budget and report usage."""
+        settings = self._settings_manager.settings
+        sparkline_buffers, _ = self._sparklines.history_size()
+        candle_buffers = self._indicators.history_size()[0] + self._aggregator.history_size()[0]
+        full_bytes = (
+            sparkline_buffers * settings.sparkline_max_points * POINT_BYTES
+            + candle_buffers * settings.candle_max_count * CANDLE_BYTES
+        )
+        budget_bytes = settings.history_budget_mb * BYTES_PER_MB
+        scale = min(1.0, budget_bytes / full_bytes) if full_bytes else 1.0
+        points = max(MIN_SPARKLINE_POINTS, int(settings.sparkline_max_points * scale))
+        candles = max(MIN_CANDLES, int(settings.candle_max_count * scale))
+
+        if (points, candles) != self._caps:
+            if scale < 1.0:
+                logger.info(
+                    f"History over budget, capping at {points} points / {candles} candles per pair"
+                )
+            self._caps = (points, candles)
+            self._sparklines.set_max_points(points)
+            self._indicators.set_max_candles(candles)
+            self._aggregator.set_max_candles(candles)
+        return self.usage()
+
+    def usage(self) -> HistoryUsage:
+        """Current estimated usage of all history buffers."""
+        sparkline_buffers, points = self._sparklines.history_size()
+        indicator_series, indicator_candles = self._indicators.history_size()
+        aggregated_series, aggregated_candles = self._aggregator.history_size()
+        candles = indicator_candles + aggregated_candles
+        return HistoryUsage(
+            buffers=sparkline_buffers + indicator_series + aggregated_series,
+            entries=points + candles,
+            bytes=points * POINT_BYTES + candles * CANDLE_BYTES,
+            budget_bytes=self._settings_manager.settings.history_budget_mb * BYTES_PER_MB,
+            sparkline_points=self._caps[0],
+            candles=self._caps[1],
+        )
+
+
+# Global history budget instance
+_history_budget: HistoryBudget | None = None
+
+
+def get_history_budget() -> HistoryBudget:
+    """Get the global history budget instance."""
+    global _history_budget
+    if _history_budget is None:
+        _history_budget = HistoryBudget(
+            get_sparkline_service(), get_indicator_engine(), get_candle_aggregator()
+        )
+    return _history_budget
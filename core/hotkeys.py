@@ -0,0 +1,150 @@
+"""
+Global hotkeys.
+Registers OS-level shortcuts (show/hide window, pause alerts, cycle the mini
+ticker pair) with pynput, so they work while the app is unfocused.
+Shortcuts are written like "Ctrl+Alt+M"; an empty shortcut is disabled.
+"""
+
+import logging
+
+from PyQt6.QtCore import QObject, pyqtSignal
+
+from config.settings import get_settings_manager
+
+logger = logging.getLogger(__name__)
+
+try:
+    from pynput import keyboard
+
+    HOTKEYS_AVAILABLE = True
+except Exception as e:
+    # Missing package, or no display server / accessibility permission
+    HOTKEYS_AVAILABLE = False
+    logger.info(f"Global hotkeys unavailable: {e}")
+
+TOGGLE_WINDOW = "toggle_window"
+PAUSE_ALERTS = "pause_alerts"
+CYCLE_PAIR = "cycle_pair"
+
+DEFAULT_HOTKEYS = {
+    TOGGLE_WINDOW: "Ctrl+Alt+M",
+    PAUSE_ALERTS: "Ctrl+Alt+P",
+    CYCLE_PAIR: "Ctrl+Alt+N",
+}
+
+# Modifier names accepted in shortcuts, and their pynput names
+_MODIFIERS = {
+    "ctrl": "<ctrl>",
+    "control": "<ctrl>",
+    "alt": "<alt>",
+    "option": "<alt>",
+    "shift": "<shift>",
+    "cmd": "<cmd>",
+    "win": "<cmd>",
+    "super": "<cmd>",
+    "meta": "<cmd>",
+}
+_NAMED_KEYS = {"space", "tab", "enter", "esc", "home", "end", "up", "down", "left", "right"}
+_NAMED_KEYS.update(f"f{n}" for n in range(1, 13))
+
+
+def to_pynput(shortcut: str) -> str | None:
+    """
+    Convert a shortcut like "Ctrl+Alt+M" to pynput's "<ctrl>+<alt>+m".
+
+    Returns:
+        The pynput hotkey, or None unless it is modifiers and exactly one key
+    """
+    parts = [part.strip().lower() for part in shortcut.split("+")]
+    if not all(parts):
+        return None
+    *modifiers, key = parts
+    if not modifiers or key in _MODIFIERS:
+        return None
+    converted = []
+    for modifier in modifiers:
+        name = _MODIFIERS.get(modifier)
+        if name is None or name in converted:
+            return None
+        converted.append(name)
+    if key in _NAMED_KEYS:
+        key = f"<{key}>"
+    elif len(key) != 1 or not key.isprintable():
+        return None
+    return "+".join([*converted, key])
+
+
+def normalize_shortcut(shortcut: str) -> str | None:
+    """Canonical form of a shortcut, e.g. "alt + ctrl + m" -> "Ctrl+Alt+M"; None if invalid."""
+    hotkey = to_pynput(shortcut)
+    if hotkey is None:
+        return None
+    order = ["<ctrl>", "<alt>", "<shift>", "<cmd>"]
+    *modifiers, key = hotkey.split("+")
+    names = {"<ctrl>": "Ctrl", "<alt>": "Alt", "<shift>": "Shift", "<cmd>": "Cmd"}
+    parts = [names[m] for m in sorted(modifiers, key=order.index)]
+    parts.append(key.strip("<>").upper() if len(key) == 1 else key.strip("<>").title())
+    return "+".join(parts)
+
+
+class HotkeyService(QObject):
+    """Listens for the configured global hotkeys in a pynput thread."""
+
+    # Action name (TOGGLE_WINDOW, PAUSE_ALERTS or CYCLE_PAIR); emitted from the
+    # listener thread, so receivers in the GUI thread get it queued
+    action_triggered = pyqtSignal(str)
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._listener = None
+
+    @property
+    def is_available(self) -> bool:
+        """Whether global hotkeys can be registered on this system."""
+        return HOTKEYS_AVAILABLE
+
+    def start(self):
+        """Register the configured hotkeys, if enabled."""
+        settings = self._settings_manager.settings
+        if not settings.hotkeys_enabled or not HOTKEYS_AVAILABLE or self._listener:
+            return
+        bindings = {}
+        for action in DEFAULT_HOTKEYS:
+            shortcut = settings.hotkeys.get(action, DEFAULT_HOTKEYS[action])
+            hotkey = to_pynput(shortcut) if shortcut else None
+            if hotkey and hotkey not in bindings:
+                bindings[hotkey] = lambda a=action: self.action_triggered.emit(a)
+        if not bindings:
+            return
+        try:
+            self._listener = keyboard.GlobalHotKeys(bindings)
+            self._listener.daemon = True
+            self._listener.start()
+            logger.info(f"Registered {len(bindings)} global hotkeys")
+        except Exception as e:
+            self._listener = None
+            logger.warning(f"Failed to register global hotkeys: {e}")
+
+    def stop(self):
+        """Unregister the hotkeys."""
+        if self._listener:
+            self._listener.stop()
+            self._listener = None
+
+    def restart(self):
+        """Re-register the hotkeys after the settings changed."""
+        self.stop()
+        self.start()
+
+
+# Global hotkey service instance
+_hotkey_service: HotkeyService | None = None
+
+
+def get_hotkey_service() -> HotkeyService:
+    """Get the global hotkey service instance."""
+    global _hotkey_service
+    if _hotkey_service is None:
+        _hotkey_service = HotkeyService()
+    return _hotkey_service
@@ -0,0 +1,499 @@
+"""
+Technical indicator engine.
+Keeps a rolling candle series per pair, seeded from exchange klines and
+advanced by live ticker prices, and computes RSI, MACD and EMAs from it.
+"""
+
+import logging
+import math
+import time
+from collections import deque
+from dataclasses import dataclass
+
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from core.utils.dispatcher import get_dispatcher
+
+logger = logging.getLogger(__name__)
+
+# Candle interval used for the indicators shown with the ticker
+DEFAULT_INTERVAL = "1h"
+
+INTERVAL_SECONDS = {
+    "15m": 15 * 60,
+    "1h": 60 * 60,
+    "4h": 4 * 60 * 60,
+    "1d": 24 * 60 * 60,
+}
+
+# Enough history for EMA-200 to settle
+MAX_CANDLES = 300
+
+# EMAs compared for golden and death crosses
+MA_CROSS_PERIODS = (50, 200)
+
+# Bollinger Bands: moving average period and band width in standard deviations
+BOLLINGER_PERIOD = 20
+BOLLINGER_STD = 2.0
+
+ATR_PERIOD = 14
+# Candles of log returns in the realized volatility window (one day of 1h candles)
+VOLATILITY_WINDOW = 24
+
+
+@dataclass
+class Candle:
+    """One OHLCV candle. open_time is in seconds."""
+
+    open_time: int
+    open: float
+    high: float
+    low: float
+    close: float
+    volume: float = 0.0
+
+
+@dataclass
+class IndicatorSnapshot:
+    """Latest indicator values of a pair. Fields are None until enough candles exist."""
+
+    interval: str = DEFAULT_INTERVAL
+    rsi: float | None = None
+    macd: float | None = None
+    macd_signal: float | None = None
+    macd_histogram: float | None = None
+    ema_20: float | None = None
+    ema_50: float | None = None
+    ema_200: float | None = None
+    bb_upper: float | None = None
+    bb_middle: float | None = None
+    bb_lower: float | None = None
+    atr: float | None = None  # Average true range, in quote currency
+    atr_pct: float | None = None  # ATR relative to the last close
+    realized_volatility: float | None = None  # Annualized, in percent
+    move_atr: float | None = None  # Change of the current candle in ATRs
+
+
+@dataclass
+class BandBreach:
+    """A candle that closed outside the Bollinger Bands."""
+
+    pair: str
+    interval: str
+    side: str  # "upper" | "lower"
+    close: float
+    band: float  # Value of the band the close was beyond
+    open_time: int  # Open time of the closed candle, in seconds
+
+
+def interval_seconds(interval: str) -> int:
+    """
+    Length of a kline interval such as "5m", "1h" or "1d".
+
+    Raises:
+        ValueError: If the interval is malformed
+    """
+    units = {"s": 1, "m": 60, "h": 60 * 60, "d": 24 * 60 * 60, "w": 7 * 24 * 60 * 60}
+    count, unit = interval[:-1], interval[-1:].lower()
+    if not count.isdigit() or int(count) <= 0 or unit not in units:
+        raise ValueError(f"Invalid interval: {interval}")
+    return int(count) * units[unit]
+
+
+def ema_series(values: list[float], period: int) -> list[float]:
+    """
+    Exponential moving average, seeded with the simple average of the first period values.
+
+    Returns:
+        One value per input from index period - 1 on (empty if too few values)
+    """
+    if period <= 0 or len(values) < period:
+        return []
+    alpha = 2 / (period + 1)
+    current = sum(values[:period]) / period
+    result = [current]
+    for value in values[period:]:
+        current = alpha * value + (1 - alpha) * current
+        result.append(current)
+    return result
+
+
+def ema(values: list[float], period: int) -> float | None:
+    """Latest EMA value, or None if there are too few values."""
+    series = ema_series(values, period)
+    return series[-1] if series else None
+
+
+def rsi(closes: list[float], period: int = 14) -> float | None:
+    """Relative strength index with Wilder's smoothing."""
+    if len(closes) <= period:
+        return None
+    changes = [b - a for a, b in zip(closes, closes[1:])]
+    avg_gain = sum(max(c, 0.0) for c in changes[:period]) / period
+    avg_loss = sum(max(-c, 0.0) for c in changes[:period]) / period
+    for change in changes[period:]:
+        avg_gain = (avg_gain * (period - 1) + max(change, 0.0)) / period
+        avg_loss = (avg_loss * (period - 1) + max(-change, 0.0)) / period
+    if avg_loss == 0:
+        return 100.0 if avg_gain > 0 else 50.0
+    return 100 - 100 / (1 + avg_gain / avg_loss)
+
+
+def macd(
+    closes: list[float], fast: int = 12, slow: int = 26, signal: int = 9
+) -> tuple[float, float, float] | None:
+    """
+    Moving average convergence divergence.
+
+    Returns:
+        (macd, signal, histogram), or None if there are too few values
+    """
+    slow_ema = ema_series(closes, slow)
+    if not slow_ema:
+        return None
+    fast_ema = ema_series(closes, fast)[slow - fast :]
+    macd_line = [f - s for f, s in zip(fast_ema, slow_ema)]
+    signal_line = ema_series(macd_line, signal)
+    if not signal_line:
+        return None
+    return macd_line[-1], signal_line[-1], macd_line[-1] - signal_line[-1]
+
+
+def bollinger_bands(
+    closes: list[float], period: int = BOLLINGER_PERIOD, num_std: float = BOLLINGER_STD
+) -> tuple[float, float, float] | None:
+    """
+    Bollinger Bands from the last period closes (population standard deviation).
+
+    Returns:
+        (lower, middle, upper), or None if there are too few values
+    """
+    if len(closes) < period:
+        return None
+    window = closes[-period:]
+    middle = sum(window) / period
+    std = (sum((c - middle) ** 2 for c in window) / period) ** 0.5
+    return middle - num_std * std, middle, middle + num_std * std
+
+
+def band_breach_side(closes: list[float]) -> tuple[str, float] | None:
+    """
+    Check whether the last close lies outside the Bollinger Bands including it.
+
+    Returns:
+        ("upper" | "lower", band value), or None if the close is inside the bands
+    """
+    bands = bollinger_bands(closes)
+    if bands is None:
+        return None
+    lower, _middle, upper = bands
+    if closes[-1] > upper:
+        return "upper", upper
+    if closes[-1] < lower:
+        return "lower", lower
+    return None
+
+
+def atr(candles: list[Candle], period: int = ATR_PERIOD) -> float | None:
+    """Average true range with Wilder's smoothing."""
+    if len(candles) <= period:
+        return None
+    true_ranges = [
+        max(c.high - c.low, abs(c.high - prev.close), abs(c.low - prev.close))
+        for prev, c in zip(candles, candles[1:])
+    ]
+    value = sum(true_ranges[:period]) / period
+    for true_range in true_ranges[period:]:
+        value = (value * (period - 1) + true_range) / period
+    return value
+
+
+def realized_volatility(
+    closes: list[float], interval: str = DEFAULT_INTERVAL, window: int = VOLATILITY_WINDOW
+) -> float | None:
+    """
+    Annualized standard deviation of the last window log returns, in percent.
+
+    Returns:
+        The volatility, or None if there are too few (or non-positive) closes
+    """
+    closes = closes[-(window + 1) :]
+    if len(closes) < window + 1 or min(closes) <= 0:
+        return None
+    returns = [math.log(b / a) for a, b in zip(closes, closes[1:])]
+    mean = sum(returns) / len(returns)
+    variance = sum((r - mean) ** 2 for r in returns) / (len(returns) - 1)
+    periods_per_year = 365 * 24 * 60 * 60 / INTERVAL_SECONDS[interval]
+    return math.sqrt(variance * periods_per_year) * 100
+
+
+def detect_cross(previous_diff: float | None, current_diff: float) -> str | None:
+    """
+    Detect a line crossing another from their differences at two points in time.
+
+    Returns:
+        "above" or "below", or None if the lines did not cross
+    """
+    if previous_diff is None:
+        return None
+    if previous_diff <= 0 < current_diff:
+        return "above"
+    if previous_diff >= 0 > current_diff:
+        return "below"
+    return None
+
+
+def compute_indicators(
+    candles: list[Candle], interval: str = DEFAULT_INTERVAL
+) -> IndicatorSnapshot:
+    """Compute all indicators from candles, oldest first."""
+    closes = [c.close for c in candles]
+    snapshot = IndicatorSnapshot(interval=interval, rsi=rsi(closes))
+    macd_values = macd(closes)
+    if macd_values:
+        snapshot.macd, snapshot.macd_signal, snapshot.macd_histogram = macd_values
+    snapshot.ema_20 = ema(closes, 20)
+    snapshot.ema_50 = ema(closes, 50)
+    snapshot.ema_200 = ema(closes, 200)
+    bands = bollinger_bands(closes)
+    if bands:
+        snapshot.bb_lower, snapshot.bb_middle, snapshot.bb_upper = bands
+    snapshot.realized_volatility = realized_volatility(closes, interval)
+    snapshot.atr = atr(candles)
+    if snapshot.atr:
+        snapshot.atr_pct = snapshot.atr / closes[-1] * 100
+        snapshot.move_atr = (closes[-1] - closes[-2]) / snapshot.atr
+    return snapshot
+
+
+class CandleSeries:
+    """Rolling candles of one pair and interval."""
+
+    def __init__(self, interval: str, max_candles: int = MAX_CANDLES):
+        self.interval = interval
+        self.seconds = interval_seconds(interval)
+        self._candles: deque[Candle] = deque(maxlen=max_candles)
+
+    def __len__(self) -> int:
+        return len(self._candles)
+
+    def resize(self, max_candles: int):
+        """Change the capacity, dropping the oldest candles beyond it."""
+        if self._candles.maxlen != max_candles:
+            self._candles = deque(self._candles, maxlen=max_candles)
+
+    def load(self, klines: list[dict]):
+        """Replace the series with klines as returned by fetch_klines (timestamp in ms)."""
+        self._candles.clear()
+        for k in sorted(klines, key=lambda k: k["timestamp"]):
+            self._candles.append(
+                Candle(
+                    open_time=int(k["timestamp"]) // 1000,
+                    open=float(k["open"]),
+                    high=float(k["high"]),
+                    low=float(k["low"]),
+                    close=float(k["close"]),
+                    volume=float(k.get("volume", 0.0)),
+                )
+            )
+
+    def update(self, price: float, timestamp: float, volume: float = 0.0) -> bool:
+        """
+        Apply a live price to the current candle, opening a new one when the interval rolls.
+
+        Args:
+            volume: Traded size to add to the candle (0 for ticker prices)
+
+        Returns:
+            True if a new candle was opened
+        """
+        open_time = int(timestamp) // self.seconds * self.seconds
+        last = self._candles[-1] if self._candles else None
+        if last and open_time <= last.open_time:
+            last.close = price
+            last.high = max(last.high, price)
+            last.low = min(last.low, price)
+            last.volume += volume
+            return False
+        self._candles.append(Candle(open_time, price, price, price, price, volume))
+        return True
+
+    def candles(self) -> list[Candle]:
+        return list(self._candles)
+
+    def closes(self) -> list[float]:
+        return [c.close for c in self._candles]
+
+
+class IndicatorEngine(QObject):
+    """
+    Maintains candle series for the subscribed pairs and recomputes indicators on each tick.
+
+    Series are seeded from the exchange client's klines in a background thread
+    and re-seeded periodically so gaps (e.g. after sleep) are filled.
+    """
+
+    indicators_updated = pyqtSignal(str, object)  # pair, IndicatorSnapshot
+    band_breached = pyqtSignal(object)  # BandBreach
+    candle_closed = pyqtSignal(str, str, float)  # pair, interval, close
+    _klines_loaded = pyqtSignal(str, str, list)  # pair, interval, klines
+
+    REFRESH_INTERVAL_MS = 30 * 60 * 1000
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._client = None
+        self._pairs: list[str] = []
+        self._series: dict[tuple[str, str], CandleSeries] = {}
+        self._snapshots: dict[str, IndicatorSnapshot] = {}
+        self._max_candles = MAX_CANDLES
+
+        self._klines_loaded.connect(self._on_klines_loaded)
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.REFRESH_INTERVAL_MS)
+        self._timer.timeout.connect(self.reload)
+
+    def start(self):
+        """Start periodic re-seeding."""
+        if not self._timer.isActive():
+            self._timer.start()
+
+    def stop(self):
+        """Stop periodic re-seeding."""
+        self._timer.stop()
+
+    def set_client(self, client):
+        """Use a new exchange client for klines and re-seed every series."""
+        self._client = client
+        self._snapshots.clear()
+        self.reload()
+
+    def set_pairs(self, pairs: list[str]):
+        """Track the given pairs, dropping series of pairs no longer monitored."""
+        self._pairs = list(pairs)
+        for key in [key for key in self._series if key[0] not in self._pairs]:
+            del self._series[key]
+        for pair in list(self._snapshots):
+            if pair not in self._pairs:
+                del self._snapshots[pair]
+        for pair in self._pairs:
+            if (pair, DEFAULT_INTERVAL) not in self._series:
+                self._series[(pair, DEFAULT_INTERVAL)] = CandleSeries(
+                    DEFAULT_INTERVAL, self._max_candles
+                )
+                self._load(pair, DEFAULT_INTERVAL)
+
+    def watch(self, pair: str, interval: str):
+        """Also keep a series of another interval for a tracked pair."""
+        if interval not in INTERVAL_SECONDS or pair not in self._pairs:
+            return
+        if (pair, interval) not in self._series:
+            self._series[(pair, interval)] = CandleSeries(interval, self._max_candles)
+            self._load(pair, interval)
+
+    def set_max_candles(self, max_candles: int):
+        """Cap the candles kept per series, trimming existing ones."""
+        self._max_candles = max_candles
+        for series in self._series.values():
+            series.resize(max_candles)
+
+    def history_size(self) -> tuple[int, int]:
+        """Number of series and the candles they hold."""
+        return len(self._series), sum(len(series) for series in self._series.values())
+
+    def reload(self):
+        """Re-seed all series from the exchange."""
+        for pair, interval in list(self._series):
+            self._load(pair, interval)
+
+    def _load(self, pair: str, interval: str):
+        client = self._client
+        if client is None:
+            return
+
+        def fetch():
+            try:
+                klines = client.fetch_klines(pair, interval, MAX_CANDLES)
+            except Exception as e:
+                logger.warning(f"Failed to load candles for {pair} ({interval}): {e}")
+                return
+            if klines:
+                self._klines_loaded.emit(pair, interval, klines)
+
+        get_dispatcher().submit(fetch)
+
+    def _on_klines_loaded(self, pair: str, interval: str, klines: list):
+        series = self._series.get((pair, interval))
+        if series is None:
+            return
+        series.load(klines)
+        logger.debug(f"Loaded {len(series)} candles for {pair} ({interval})")
+        if interval == DEFAULT_INTERVAL:
+            self._recompute(pair)
+
+    def update_price(
+        self, pair: str, price: float, timestamp: float | None = None
+    ) -> IndicatorSnapshot | None:
+        """Feed a live price into every series of the pair."""
+        if price <= 0:
+            return None
+        timestamp = time.time() if timestamp is None else timestamp
+        for (series_pair, _interval), series in self._series.items():
+            if series_pair == pair and series.update(price, timestamp) and len(series) > 1:
+                self.candle_closed.emit(pair, series.interval, series.candles()[-2].close)
+                self._check_band_breach(pair, series)
+        return self._recompute(pair)
+
+    def _check_band_breach(self, pair: str, series: CandleSeries):
+        """Check the candle that just closed against the Bollinger Bands."""
+        candles = series.candles()[:-1]
+        breach = band_breach_side([c.close for c in candles])
+        if breach is None:
+            return
+        side, band = breach
+        closed = candles[-1]
+        logger.info(f"{pair} closed outside the {side} Bollinger Band ({series.interval})")
+        self.band_breached.emit(
+            BandBreach(pair, series.interval, side, closed.close, band, closed.open_time)
+        )
+
+    def _recompute(self, pair: str) -> IndicatorSnapshot | None:
+        series = self._series.get((pair, DEFAULT_INTERVAL))
+        if series is None:
+            return None
+        snapshot = compute_indicators(series.candles(), DEFAULT_INTERVAL)
+        self._snapshots[pair] = snapshot
+        self.indicators_updated.emit(pair, snapshot)
+        return snapshot
+
+    def get_snapshot(self, pair: str) -> IndicatorSnapshot | None:
+        """Latest indicators of a pair, or None if it is not tracked."""
+        return self._snapshots.get(pair)
+
+    def get_series(self, pair: str, interval: str = DEFAULT_INTERVAL) -> CandleSeries | None:
+        return self._series.get((pair, interval))
+
+    def get_ema(self, pair: str, interval: str, period: int) -> float | None:
+        """
+        Current EMA of a pair on any interval.
+
+        The first request for an untracked interval starts loading it and returns None.
+        """
+        series = self._series.get((pair, interval))
+        if series is None:
+            self.watch(pair, interval)
+            return None
+        return ema(series.closes(), period)
+
+
+# Global indicator engine instance
+_indicator_engine: IndicatorEngine | None = None
+
+
+def get_indicator_engine() -> IndicatorEngine:
+    """Get the global indicator engine instance."""
+    global _indicator_engine
+    if _indicator_engine is None:
+        _indicator_engine = IndicatorEngine()
+    return _indicator_engine
@@ -0,0 +1,233 @@
+"""
+Trading pair status monitor.
+Checks the watched pairs' instrument status on the exchange every half hour
+and reports pairs that are suspended or scheduled for delisting, before
+their price feed goes quiet.
+"""
+
+import logging
+import threading
+from dataclasses import dataclass
+
+import requests
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from config.settings import get_settings_manager
+from core.rate_limiter import get_rate_limiter
+from core.utils.network import get_proxy_config
+
+logger = logging.getLogger(__name__)
+
+LIVE = "live"
+SUSPENDED = "suspended"  # Trading halted, may resume
+DELISTING = "delisting"  # Still trading, with a delisting time set
+DELISTED = "delisted"  # No longer listed
+AT_RISK_STATES = (SUSPENDED, DELISTING, DELISTED)
+
+
+@dataclass(slots=True)
+class InstrumentStatus:
+    """Exchange status of one watched pair."""
+
+    exchange: str  # "OKX" | "Binance"
+    pair: str
+    state: str  # LIVE, SUSPENDED, DELISTING or DELISTED
+    raw_state: str = ""  # The exchange's own status, e.g. "BREAK"
+    delist_at: float | None = None  # Seconds; when a delisting is scheduled
+
+    @property
+    def at_risk(self) -> bool:
+        return self.state in AT_RISK_STATES
+
+
+def okx_inst_type(pair: str) -> str:
+    return "SWAP" if pair.upper().endswith("-SWAP") else "SPOT"
+
+
+def parse_okx_instrument(pair: str, item: dict | None) -> InstrumentStatus:
+    """Status of an OKX instrument; a missing one has been delisted."""
+    if item is None:
+        return InstrumentStatus("OKX", pair, DELISTED)
+    raw_state = item.get("state", "")
+    exp_time = item.get("expTime") or ""
+    delist_at = int(exp_time) / 1000 if exp_time.isdigit() else None
+    if raw_state == "suspend":
+        state = SUSPENDED
+    elif delist_at is not None:
+        # Spot and swap instruments only get an expiry when taken offline
+        state = DELISTING
+    else:
+        state = LIVE
+    return InstrumentStatus("OKX", pair, state, raw_state, delist_at)
+
+
+def parse_binance_symbol(pair: str, item: dict | None) -> InstrumentStatus:
+    """Status of a Binance spot symbol; a missing one has been delisted."""
+    if item is None:
+        return InstrumentStatus("Binance", pair, DELISTED)
+    raw_state = item.get("status", "")
+    # BREAK is the status of halted and delisted-but-not-removed symbols
+    state = LIVE if raw_state in ("TRADING", "PRE_TRADING", "POST_TRADING") else SUSPENDED
+    return InstrumentStatus("Binance", pair, state, raw_state)
+
+
+def exchange_pairs(pairs: list[str]) -> list[str]:
+    """Pairs traded on the exchange itself, without DEX, virtual and index pairs."""
+    return [pair for pair in pairs if ":" not in pair and "-" in pair]
+
+
+class InstrumentStatusMonitor(QObject):
+    """
+    Polls the watched pairs' status and emits pairs whose status turns risky.
+
+    Each pair is reported once per state, so a suspension is not repeated
+    every poll, but a later delisting of the same pair is.
+    """
+
+    status_changed = pyqtSignal(object)  # InstrumentStatus
+
+    OKX_URL = "https://www.okx.com/api/v5/public/instruments"
+    BINANCE_URL = "https://api.binance.com/api/v3/exchangeInfo"
+    REFRESH_INTERVAL_MS = 30 * 60 * 1000
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._pairs: list[str] = []
+        self._states: dict[tuple[str, str], str] = {}  # (exchange, pair) -> state
+        self._fetching = False
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.REFRESH_INTERVAL_MS)
+        self._timer.timeout.connect(self.refresh)
+
+    def start(self):
+        """Start polling, if delisting alerts are on."""
+        if not self._is_needed():
+            return
+        if not self._timer.isActive():
+            self._timer.start()
+        self.refresh()
+
+    def stop(self):
+        """Stop polling."""
+        self._timer.stop()
+
+    def apply_settings(self):
+        """Start or stop polling after delisting alerts were switched."""
+        if not self._is_needed():
+            self.stop()
+        elif not self._timer.isActive():
+            self.start()
+
+    def _is_needed(self) -> bool:
+        # Alerts are the only consumer of the states
+        return self._settings_manager.settings.delisting_alerts
+
+    def set_pairs(self, pairs: list[str]):
+        """Set the watched pairs; newly added ones are checked right away."""
+        pairs = exchange_pairs(pairs)
+        added = set(pairs) - set(self._pairs)
+        self._pairs = pairs
+        if added and self._timer.isActive():
+            self.refresh()
+
+    def get_state(self, exchange: str, pair: str) -> str | None:
+        """Last known state of a pair, or None before it was checked."""
+        return self._states.get((exchange, pair))
+
+    def refresh(self):
+        """Check the pairs in a background thread."""
+        if not self._is_needed():
+            return
+        source = self._settings_manager.settings.data_source.upper()
+        if self._fetching or not self._pairs or source not in ("OKX", "BINANCE"):
+            return
+        self._fetching = True
+        threading.Thread(target=self._fetch, args=(source, list(self._pairs)), daemon=True).start()
+
+    def _fetch(self, source: str, pairs: list[str]):
+        try:
+            if source == "OKX":
+                statuses = self._fetch_okx(pairs)
+            else:
+                statuses = self._fetch_binance(pairs)
+        finally:
+            self._fetching = False
+        for status in self.process(statuses):
+            logger.warning(f"{status.pair} on {status.exchange} is {status.state}")
+            self.status_changed.emit(status)
+
+    def process(self, statuses: list[InstrumentStatus]) -> list[InstrumentStatus]:
+        """Remember the statuses and return the ones newly at risk."""
+        changed = []
+        for status in statuses:
+            key = (status.exchange, status.pair)
+            previous = self._states.get(key)
+            self._states[key] = status.state
+            if status.at_risk and status.state != previous:
+                changed.append(status)
+        return changed
+
+    def _fetch_okx(self, pairs: list[str]) -> list[InstrumentStatus]:
+        statuses = []
+        for inst_type in sorted({okx_inst_type(pair) for pair in pairs}):
+            try:
+                get_rate_limiter().acquire("OKX")
+                response = requests.get(
+                    self.OKX_URL,
+                    params={"instType": inst_type},
+                    proxies=get_proxy_config(),
+                    timeout=10,
+                )
+                response.raise_for_status()
+                data = response.json()
+                if data.get("code") != "0":
+                    raise RuntimeError(data.get("msg") or data.get("code"))
+            except Exception as e:
+                logger.debug(f"OKX {inst_type} instruments request failed: {e}")
+                continue
+            instruments = {item.get("instId"): item for item in data.get("data", [])}
+            statuses += [
+                parse_okx_instrument(pair, instruments.get(pair.upper()))
+                for pair in pairs
+                if okx_inst_type(pair) == inst_type
+            ]
+        return statuses
+
+    def _fetch_binance(self, pairs: list[str]) -> list[InstrumentStatus]:
+        statuses = []
+        for pair in pairs:
+            symbol = pair.replace("-", "").upper()
+            try:
+                get_rate_limiter().acquire("BINANCE")
+                response = requests.get(
+                    self.BINANCE_URL,
+                    params={"symbol": symbol},
+                    proxies=get_proxy_config(),
+                    timeout=10,
+                )
+                body = response.json()
+            except Exception as e:
+                logger.debug(f"Binance exchange info request for {symbol} failed: {e}")
+                continue
+            if response.status_code == 400 and body.get("code") == -1121:
+                # Invalid symbol: no longer listed
+                statuses.append(parse_binance_symbol(pair, None))
+                continue
+            symbols = body.get("symbols", []) if response.ok else []
+            if symbols:
+                statuses.append(parse_binance_symbol(pair, symbols[0]))
+        return statuses
+
+
+# Global instrument status monitor instance
+_instrument_status_monitor: InstrumentStatusMonitor | None = None
+
+
+def get_instrument_status_monitor() -> InstrumentStatusMonitor:
+    """Get the global instrument status monitor instance."""
+    global _instrument_status_monitor
+    if _instrument_status_monitor is None:
+        _instrument_status_monitor = InstrumentStatusMonitor()
+    return _instrument_status_monitor
@@ -0,0 +1,128 @@
+"""
+Position liquidation-risk monitor.
+Computes each position's distance to its liquidation price and raises
+escalating warnings as the mark price approaches it.
+"""
+
+import logging
+
+from PyQt6.QtCore import QObject, pyqtSignal
+
+from config.settings import get_settings_manager
+from core.notifier import get_notification_service
+from core.okx_account import AccountPosition, AccountSnapshot, get_okx_account_service
+
+logger = logging.getLogger(__name__)
+
+# A position must recover this many percentage points past a level before
+# that level can fire again, so a price hovering at the boundary doesn't spam.
+RECOVERY_MARGIN = 1.0
+
+
+def liquidation_distance(position: AccountPosition) -> float | None:
+    """
+    Distance from mark price to liquidation price, as a percentage of mark price.
+
+    Returns None when the position has no liquidation price (e.g., fully collateralized).
+    """
+    if not position.mark_price or not position.liq_price or position.liq_price <= 0:
+        return None
+    return abs(position.mark_price - position.liq_price) / position.mark_price * 100
+
+
+def risk_level(distance: float, levels: list[float]) -> int:
+    """
+    Map a distance to a severity level.
+
+    Args:
+        distance: Distance to liquidation in percent
+        levels: Warning thresholds in percent, e.g. [20, 10, 5]
+
+    Returns:
+        0 if outside all thresholds, otherwise 1 + index of the tightest threshold crossed
+    """
+    level = 0
+    for i, threshold in enumerate(sorted(levels, reverse=True)):
+        if distance <= threshold:
+            level = i + 1
+    return level
+
+
+class LiquidationMonitor(QObject):
+    """
+    Watches account positions for liquidation risk.
+
+    A warning is sent each time a position escalates to a tighter level;
+    de-escalation is tracked silently.
+    """
+
+    risk_changed = pyqtSignal(str, int, float)  # position_id, level, distance %
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._notification_service = get_notification_service()
+        self._levels: dict[str, int] = {}  # position_id -> current level
+
+        get_okx_account_service().account_updated.connect(self.check_positions)
+
+    def get_level(self, position_id: str) -> int:
+        return self._levels.get(position_id, 0)
+
+    def check_positions(self, snapshot: AccountSnapshot):
+        """Evaluate liquidation risk for all open positions."""
+        levels = self._settings_manager.settings.okx_api.liquidation_levels
+        thresholds = sorted(levels, reverse=True)
+        if not thresholds:
+            return
+
+        open_ids = set()
+        for position in snapshot.positions:
+            open_ids.add(position.position_id)
+            distance = liquidation_distance(position)
+            if distance is None:
+                continue
+
+            previous = self._levels.get(position.position_id, 0)
+            level = risk_level(distance, thresholds)
+
+            # Hysteresis: only step down once clearly past the previous threshold
+            if level < previous and distance <= thresholds[previous - 1] + RECOVERY_MARGIN:
+                level = previous
+
+            if level == previous:
+                continue
+
+            self._levels[position.position_id] = level
+            self.risk_changed.emit(position.position_id, level, distance)
+
+            if level > previous:
+                logger.warning(
+                    f"Liquidation risk {position.inst_id} {position.pos_side}: "
+                    f"{distance:.2f}% from liquidation (level {level})"
+                )
+                self._notification_service.send_liquidation_warning(
+                    inst_id=position.inst_id,
+                    pos_side=position.pos_side,
+                    distance=distance,
+                    mark_price=position.mark_price,
+                    liq_price=position.liq_price,
+                    critical=level == len(thresholds),
+                )
+
+        # Forget closed positions
+        for position_id in list(self._levels):
+            if position_id not in open_ids:
+                del self._levels[position_id]
+
+
+# Global liquidation monitor instance
+_liquidation_monitor: LiquidationMonitor | None = None
+
+
+def get_liquidation_monitor() -> LiquidationMonitor:
+    """Get the global liquidation monitor instance."""
+    global _liquidation_monitor
+    if _liquidation_monitor is None:
+        _liquidation_monitor = LiquidationMonitor()
+    return _liquidation_monitor
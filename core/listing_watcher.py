@@ -0,0 +1,241 @@
+"""
+Exchange listing announcement watcher.
+Polls the OKX and Binance announcement feeds for new listing posts and
+reports the tokens they mention.
+"""
+
+import logging
+import re
+import threading
+from dataclasses import dataclass, field
+
+import requests
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from config.settings import get_settings_manager
+from core.rate_limiter import get_rate_limiter
+from core.utils.network import get_proxy_config
+
+logger = logging.getLogger(__name__)
+
+# Capitalized words in titles that are not token symbols
+NON_SYMBOL_WORDS = {
+    "OKX",
+    "BINANCE",
+    "USDT",
+    "USDC",
+    "FDUSD",
+    "TRY",
+    "EUR",
+    "BTC",
+    "ETH",
+    "BNB",
+    "HODLER",
+    "AIRDROPS",
+    "ALPHA",
+    "SPOT",
+    "PERPETUAL",
+    "FUTURES",
+    "MARGIN",
+    "UTC",
+    "AM",
+    "PM",
+    "AND",
+    "THE",
+    "NEW",
+    "TO",
+    "FOR",
+    "WILL",
+    "LIST",
+}
+
+_PARENTHESIZED_RE = re.compile(r"\(([A-Z0-9]{2,12})\)")
+_CAPS_WORD_RE = re.compile(r"\b[A-Z][A-Z0-9]{1,11}\b")
+
+
+@dataclass
+class ListingAnnouncement:
+    """A new listing post of an exchange."""
+
+    exchange: str  # "OKX" | "Binance"
+    id: str
+    title: str
+    url: str
+    published_at: float  # Seconds
+    symbols: list[str] = field(default_factory=list)
+
+
+def is_listing_title(title: str) -> bool:
+    lower = title.lower()
+    return "list" in lower and "delist" not in lower
+
+
+def extract_symbols(title: str) -> list[str]:
+    """
+    Token symbols mentioned in an announcement title.
+
+    Symbols in parentheses, e.g. "Binance Will List Foo (FOO)", are preferred;
+    otherwise capitalized words that are not common title words are used.
+    """
+    symbols = _PARENTHESIZED_RE.findall(title)
+    if not symbols:
+        symbols = [w for w in _CAPS_WORD_RE.findall(title) if w not in NON_SYMBOL_WORDS]
+    return list(dict.fromkeys(s for s in symbols if not s.isdigit()))
+
+
+class ListingWatcher(QObject):
+    """
+    Polls exchange announcement feeds and emits new listing posts.
+
+    Posts present at the first successful poll of each exchange are remembered
+    without being emitted, so starting the app does not replay old listings.
+    """
+
+    listing_detected = pyqtSignal(object)  # ListingAnnouncement
+
+    OKX_URL = "https://www.okx.com/api/v5/support/announcements"
+    BINANCE_URL = "https://www.binance.com/bapi/composite/v1/public/cms/article/list/query"
+    BINANCE_ARTICLE_URL = "https://www.binance.com/en/support/announcement/{code}"
+    BINANCE_NEW_LISTINGS_CATALOG = 48
+    REFRESH_INTERVAL_MS = 5 * 60 * 1000
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._seen: set[str] = set()
+        self._initialized: set[str] = set()  # Exchanges polled successfully before
+        self._fetching = False
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.REFRESH_INTERVAL_MS)
+        self._timer.timeout.connect(self.refresh)
+
+    def start(self):
+        """Start polling, if listing alerts are on."""
+        if not self._is_needed():
+            return
+        if not self._timer.isActive():
+            self._timer.start()
+        self.refresh()
+
+    def stop(self):
+        """Stop polling."""
+        self._timer.stop()
+
+    def apply_settings(self):
+        """Start or stop polling after listing alerts were switched."""
+        if not self._is_needed():
+            self.stop()
+        elif not self._timer.isActive():
+            self.start()
+
+    def _is_needed(self) -> bool:
+        # Alerts are the only consumer of the announcements
+        return self._settings_manager.settings.listing_alerts != "off"
+
+    def refresh(self):
+        """Poll the feeds in a background thread."""
+        if self._fetching or not self._is_needed():
+            return
+        self._fetching = True
+        threading.Thread(target=self._fetch, daemon=True).start()
+
+    def _fetch(self):
+        try:
+            announcements = self._fetch_okx() + self._fetch_binance()
+        finally:
+            self._fetching = False
+        new = self.process(announcements)
+        for announcement in new:
+            logger.info(f"New listing on {announcement.exchange}: {announcement.title}")
+            self.listing_detected.emit(announcement)
+
+    def process(self, announcements: list[ListingAnnouncement]) -> list[ListingAnnouncement]:
+        """
+        Remember announcements and return the ones not seen before.
+
+        Nothing is returned for an exchange's first batch, which only seeds the seen set.
+        """
+        new = []
+        for announcement in sorted(announcements, key=lambda a: a.published_at):
+            key = f"{announcement.exchange}:{announcement.id}"
+            if key in self._seen:
+                continue
+            self._seen.add(key)
+            if announcement.exchange in self._initialized and is_listing_title(
+                announcement.title
+            ):
+                new.append(announcement)
+        self._initialized.update(a.exchange for a in announcements)
+        return new
+
+    def _fetch_okx(self) -> list[ListingAnnouncement]:
+        try:
+            get_rate_limiter().acquire("OKX")
+            response = requests.get(
+                self.OKX_URL,
+                params={"annType": "announcements-new-listings"},
+                proxies=get_proxy_config(),
+                timeout=10,
+            )
+            response.raise_for_status()
+            data = response.json().get("data", [])
+            details = data[0].get("details", []) if data else []
+            return [
+                ListingAnnouncement(
+                    exchange="OKX",
+                    id=item.get("url", item["title"]),
+                    title=item["title"],
+                    url=item.get("url", ""),
+                    published_at=int(item.get("pTime", 0)) / 1000,
+                    symbols=extract_symbols(item["title"]),
+                )
+                for item in details
+            ]
+        except Exception as e:
+            logger.debug(f"OKX announcements request failed: {e}")
+            return []
+
+    def _fetch_binance(self) -> list[ListingAnnouncement]:
+        try:
+            get_rate_limiter().acquire("BINANCE")
+            response = requests.get(
+                self.BINANCE_URL,
+                params={
+                    "type": 1,
+                    "catalogId": self.BINANCE_NEW_LISTINGS_CATALOG,
+                    "pageNo": 1,
+                    "pageSize": 20,
+                },
+                proxies=get_proxy_config(),
+                timeout=10,
+            )
+            response.raise_for_status()
+            catalogs = response.json().get("data", {}).get("catalogs", [])
+            articles = catalogs[0].get("articles", []) if catalogs else []
+            return [
+                ListingAnnouncement(
+                    exchange="Binance",
+                    id=str(item["id"]),
+                    title=item["title"],
+                    url=self.BINANCE_ARTICLE_URL.format(code=item.get("code", "")),
+                    published_at=int(item.get("releaseDate", 0)) / 1000,
+                    symbols=extract_symbols(item["title"]),
+                )
+                for item in articles
+            ]
+        except Exception as e:
+            logger.debug(f"Binance announcements request failed: {e}")
+            return []
+
+
+# Global listing watcher instance
+_listing_watcher: ListingWatcher | None = None
+
+
+def get_listing_watcher() -> ListingWatcher:
+    """Get the global listing watcher instance."""
+    global _listing_watcher
+    if _listing_watcher is None:
+        _listing_watcher = ListingWatcher()
+    return _listing_watcher
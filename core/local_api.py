@@ -0,0 +1,354 @@
+"""
+Local HTTP API.
+Serves the monitored prices as JSON on this machine, or the LAN if allowed, so
+home automation and other tools can use them without a broker:
+
+    GET /api/pairs          All monitored pairs
+    GET /api/pairs/{pair}   One pair, e.g. /api/pairs/BTC-USDT
+    GET /api/ha/{pair}      One pair shaped for Home Assistant's RESTful sensor
+
+Other modules add their own routes, see core/stream_deck.py, core/overlay.py
+and core/control_api.py.
+When a token is set, requests must send it as "Authorization: Bearer <token>"
+or as a ?token= query parameter.
+"""
+
+import hmac
+import json
+import logging
+import re
+import threading
+import time
+from collections.abc import Generator
+from dataclasses import dataclass, field
+from http.server import BaseHTTPRequestHandler, ThreadingHTTPServer
+from urllib.parse import parse_qsl, unquote, urlsplit
+
+from PyQt6.QtCore import QObject, pyqtSignal
+
+from config.settings import LocalApiConfig, get_settings_manager
+from core.display_prefs import parse_percentage
+from core.number_format import get_number_formatter
+from core.price_tracker import PriceState
+from core.tray_summary import build_tray_entry
+
+logger = logging.getLogger(__name__)
+
+_PARAM = re.compile(r"^\{(\w+)\}$")
+
+MAIN_THREAD_TIMEOUT = 10  # seconds a handler waits for the app to carry out a command
+
+
+@dataclass
+class ApiRequest:
+    method: str
+    path: str
+    params: dict[str, str] = field(default_factory=dict)  # Values of the route's {name} segments
+    query: dict[str, str] = field(default_factory=dict)
+    body: bytes = b""
+
+
+@dataclass
+class ApiResponse:
+    status: int
+    body: object = None  # Sent as JSON unless a content type is given
+    content_type: str = ""  # Type of a text body, e.g. "text/html"
+    events: Generator[str, None, None] | None = None  # Server-sent events, streamed until done
+
+
+class _MainThreadCall:
+    def __init__(self, function):
+        self.function = function
+        self.result = None
+        self.error: Exception | None = None
+        self.done = threading.Event()
+
+    def run(self):
+        try:
+            self.result = self.function()
+        except Exception as e:
+            self.error = e
+        finally:
+            self.done.set()
+
+
+def _float(value) -> float | None:
+    try:
+        return float(value)
+    except (TypeError, ValueError):
+        return None
+
+
+def quote_from_state(pair: str, state: PriceState) -> dict:
+    """JSON-ready quote of a pair's latest state, with texts formatted as its card shows them."""
+    entry = build_tray_entry(pair, state, get_number_formatter())
+    return {
+        "pair": pair,
+        "name": entry.name,
+        "price": state.current_price,
+        "price_text": entry.price_text,
+        "change_24h": parse_percentage(state.percentage),
+        "change_text": entry.percentage_text,
+        "high_24h": _float(state.high_24h),
+        "low_24h": _float(state.low_24h),
+        "volume_24h": _float(state.quote_volume_24h),
+        "quote": state.quote_token or pair.rsplit("-", 1)[-1],
+        "updated_at": time.strftime("%Y-%m-%dT%H:%M:%S%z"),
+    }
+
+
+def home_assistant_sensor(quote: dict) -> dict:
+    """A quote as Home Assistant's RESTful sensor reads it: a state and its attributes."""
+    attributes = {key: value for key, value in quote.items() if key != "price"}
+    attributes["unit_of_measurement"] = quote["quote"]
+    attributes["friendly_name"] = quote["name"]
+    return {"state": quote["price"], "attributes": attributes}
+
+
+class LocalApiServer(QObject):
+    """HTTP server answering from the latest quotes, on a background thread."""
+
+    _call_requested = pyqtSignal(object)  # _MainThreadCall, queued to the main thread
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._call_requested.connect(self._run_call)
+        self._settings_manager = get_settings_manager()
+        self._routes: list[tuple[str, tuple[str, ...], object]] = []
+        self._quotes: dict[str, dict] = {}
+        self._lock = threading.Lock()
+        self._server: ThreadingHTTPServer | None = None
+        self._config: LocalApiConfig | None = None  # Config the server runs with
+
+        self.add_route("GET", "/api/pairs", self._list_pairs)
+        self.add_route("GET", "/api/pairs/{pair}", self._get_pair)
+        self.add_route("GET", "/api/ha/{pair}", self._get_home_assistant_sensor)
+
+    @property
+    def config(self) -> LocalApiConfig:
+        return self._settings_manager.settings.local_api
+
+    @property
+    def is_running(self) -> bool:
+        return self._server is not None
+
+    def add_route(self, method: str, pattern: str, handler):
+        """
+        Answer requests matching a path pattern.
+
+        Args:
+            method: HTTP method
+            pattern: Path whose {name} segments match any value, e.g. "/api/pairs/{pair}"
+            handler: Called with the ApiRequest, returns an ApiResponse
+        """
+        parts = tuple(part for part in pattern.split("/") if part)
+        self._routes.append((method.upper(), parts, handler))
+
+    def add_control_route(self, method: str, pattern: str, handler):
+        """
+        Answer a route that changes the app, only while control is allowed and a token is set.
+
+        Without a token any local web page could call it.
+        """
+
+        def guarded(request: ApiRequest) -> ApiResponse:
+            config = self.config
+            if not config.allow_control or not config.token:
+                return ApiResponse(403, {"error": "control is disabled"})
+            return handler(request)
+
+        self.add_route(method, pattern, guarded)
+
+    def update_quote(self, pair: str, state: PriceState):
+        """Keep a pair's latest state to serve; called on every tick."""
+        quote = quote_from_state(pair, state)
+        with self._lock:
+            self._quotes[pair] = quote
+
+    def set_pairs(self, pairs: list[str]):
+        """Forget the quotes of pairs no longer monitored."""
+        with self._lock:
+            self._quotes = {pair: quote for pair, quote in self._quotes.items() if pair in pairs}
+
+    def quote(self, pair: str) -> dict | None:
+        with self._lock:
+            return self._quotes.get(pair.upper())
+
+    def quotes(self) -> list[dict]:
+        with self._lock:
+            return list(self._quotes.values())
+
+    def call_in_main_thread(self, function, timeout: float = MAIN_THREAD_TIMEOUT):
+        """
+        Run a function on the main thread and return its result, for handlers changing the app.
+
+        Raises:
+            TimeoutError: If the main thread doesn't get to it in time
+        """
+        call = _MainThreadCall(function)
+        self._call_requested.emit(call)
+        if not call.done.wait(timeout):
+            raise TimeoutError("main thread didn't respond")
+        if call.error:
+            raise call.error
+        return call.result
+
+    def _run_call(self, call: _MainThreadCall):
+        call.run()
+
+    def authorized(self, headers, query: dict[str, str]) -> bool:
+        token = self.config.token
+        if not token:
+            return True
+        sent = query.get("token", "")
+        authorization = headers.get("Authorization", "")
+        if authorization.startswith("Bearer "):
+            sent = authorization[len("Bearer ") :].strip()
+        return hmac.compare_digest(sent.encode(), token.encode())
+
+    def dispatch(self, method: str, target: str, headers=None, body: bytes = b"") -> ApiResponse:
+        """Answer a request, given its method, path with query, headers and body."""
+        url = urlsplit(target)
+        query = dict(parse_qsl(url.query))
+        if not self.authorized(headers or {}, query):
+            return ApiResponse(401, {"error": "unauthorized"})
+
+        segments = tuple(unquote(part) for part in url.path.split("/") if part)
+        path_matched = False
+        for route_method, parts, handler in self._routes:
+            params = self._match(parts, segments)
+            if params is None:
+                continue
+            path_matched = True
+            if route_method != method.upper():
+                continue
+            request = ApiRequest(method.upper(), url.path, params, query, body)
+            try:
+                return handler(request)
+            except Exception as e:
+                logger.error(f"Local API {method} {url.path} failed: {e}")
+                return ApiResponse(500, {"error": "internal error"})
+        if path_matched:
+            return ApiResponse(405, {"error": "method not allowed"})
+        return ApiResponse(404, {"error": "not found"})
+
+    @staticmethod
+    def _match(parts: tuple[str, ...], segments: tuple[str, ...]) -> dict[str, str] | None:
+        if len(parts) != len(segments):
+            return None
+        params = {}
+        for part, segment in zip(parts, segments, strict=True):
+            name = _PARAM.match(part)
+            if name:
+                params[name.group(1)] = segment
+            elif part != segment:
+                return None
+        return params
+
+    def _list_pairs(self, request: ApiRequest) -> ApiResponse:
+        return ApiResponse(200, {"pairs": self.quotes()})
+
+    def _get_pair(self, request: ApiRequest) -> ApiResponse:
+        quote = self.quote(request.params["pair"])
+        if quote is None:
+            return ApiResponse(404, {"error": "unknown pair"})
+        return ApiResponse(200, quote)
+
+    def _get_home_assistant_sensor(self, request: ApiRequest) -> ApiResponse:
+        quote = self.quote(request.params["pair"])
+        if quote is None:
+            return ApiResponse(404, {"error": "unknown pair"})
+        return ApiResponse(200, home_assistant_sensor(quote))
+
+    def start(self):
+        """Listen if enabled in settings."""
+        config = self.config
+        if not config.enabled or self._server:
+            return
+        host = "0.0.0.0" if config.allow_lan else "127.0.0.1"
+        try:
+            self._server = ThreadingHTTPServer((host, config.port), self._handler_class())
+        except OSError as e:
+            logger.error(f"Local API can't listen on {host}:{config.port}: {e}")
+            return
+        self._server.daemon_threads = True
+        self._config = config
+        threading.Thread(target=self._server.serve_forever, name="local-api", daemon=True).start()
+        if config.allow_lan and not config.token:
+            logger.warning("Local API is open to the LAN without a token")
+        logger.info(f"Local API listening on {host}:{config.port}")
+
+    def stop(self):
+        if not self._server:
+            return
+        server, self._server = self._server, None
+        self._config = None
+        server.shutdown()
+        server.server_close()
+        logger.info("Local API stopped")
+
+    def apply_settings(self):
+        """Start, stop or restart the server after its settings changed."""
+        if self._config == self.config:
+            return
+        self.stop()
+        self.start()
+
+    def _handler_class(self):
+        api = self
+
+        class Handler(BaseHTTPRequestHandler):
+            server_version = "CryptoMonitor"
+
+            def _respond(self):
+                length = int(self.headers.get("Content-Length") or 0)
+                body = self.rfile.read(length) if length else b""
+                response = api.dispatch(self.command, self.path, self.headers, body)
+                if response.events is not None:
+                    self._stream(response)
+                    return
+                if response.content_type:
+                    content_type = f"{response.content_type}; charset=utf-8"
+                    payload = str(response.body).encode()
+                else:
+                    content_type = "application/json; charset=utf-8"
+                    payload = json.dumps(response.body, ensure_ascii=False).encode()
+                self.send_response(response.status)
+                self.send_header("Content-Type", content_type)
+                self.send_header("Content-Length", str(len(payload)))
+                self.end_headers()
+                self.wfile.write(payload)
+
+            def _stream(self, response: ApiResponse):
+                self.close_connection = True
+                self.send_response(response.status)
+                self.send_header("Content-Type", "text/event-stream; charset=utf-8")
+                self.send_header("Cache-Control", "no-cache")
+                self.end_headers()
+                try:
+                    for event in response.events:
+                        self.wfile.write(event.encode())
+                        self.wfile.flush()
+                except (BrokenPipeError, ConnectionResetError):
+                    pass  # The page was closed
+                finally:
+                    response.events.close()
+
+            do_GET = do_POST = do_PUT = do_DELETE = _respond
+
+            def log_message(self, format, *args):
+                logger.debug(f"Local API {self.address_string()}: {format % args}")
+
+        return Handler
+
+
+# Global local API server instance
+_local_api_server: LocalApiServer | None = None
+
+
+def get_local_api_server() -> LocalApiServer:
+    """Get the global local API server instance."""
+    global _local_api_server
+    if _local_api_server is None:
+        _local_api_server = LocalApiServer()
+    return _local_api_server
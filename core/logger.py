@@ -8,6 +8,8 @@ import os
 import sys
 from pathlib import Path
 
+from core.redaction import RedactingFilter
+
 
 def setup_logging(log_dir: Path | None = None, log_level: int = logging.INFO) -> None:
     """
@@ -49,6 +51,11 @@ def setup_logging(log_dir: Path | None = None, log_level: int = logging.INFO) ->
     console_handler.setFormatter(formatter)
     console_handler.setLevel(log_level)
 
+    # Credentials are masked before reaching either output
+    redacting_filter = RedactingFilter()
+    file_handler.addFilter(redacting_filter)
+    console_handler.addFilter(redacting_filter)
+
     # Configure root logger
     root_logger = logging.getLogger()
     root_logger.setLevel(log_level)
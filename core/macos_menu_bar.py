@@ -0,0 +1,159 @@
+"""
+macOS menu bar price.
+A native status item showing the first tray pair's price as text, which the
+tray icon can't. It is fed by the market data controller, so it keeps
+updating while the window is hidden. Clicking it opens the window.
+
+AppKit is reached through the Objective-C runtime with ctypes, so no extra
+package is needed; elsewhere the item is inert.
+"""
+
+import ctypes
+import ctypes.util
+import logging
+import sys
+
+from PyQt6.QtCore import QObject, pyqtSignal
+
+from core.tray_summary import TrayEntry
+
+logger = logging.getLogger(__name__)
+
+_VARIABLE_LENGTH = -1.0  # NSVariableStatusItemLength
+_TARGET_CLASS = b"CryptoMonitorStatusItemTarget"
+
+_objc = None
+# Keeps the click callback alive for as long as the Objective-C class may call it
+_click_imp = None
+_click_handler = None
+
+
+def menu_bar_text(entry: TrayEntry) -> str:
+    """Status item title of a tray entry, e.g. "BTC 61,250.50 +2.50%"."""
+    marker = "● " if entry.highlight else ""
+    return f"{marker}{entry.name} {entry.price_text} {entry.percentage_text}"
+
+
+def _load_runtime():
+    global _objc
+    if _objc is None:
+        _objc = ctypes.cdll.LoadLibrary(ctypes.util.find_library("objc"))
+        _objc.objc_getClass.restype = ctypes.c_void_p
+        _objc.objc_getClass.argtypes = [ctypes.c_char_p]
+        _objc.sel_registerName.restype = ctypes.c_void_p
+        _objc.sel_registerName.argtypes = [ctypes.c_char_p]
+        ctypes.cdll.LoadLibrary(ctypes.util.find_library("AppKit"))
+    return _objc
+
+
+def _send(receiver, selector: bytes, *args, restype=ctypes.c_void_p, argtypes=()):
+    """Send an Objective-C message, with objc_msgSend cast to the method's signature."""
+    objc = _load_runtime()
+    address = ctypes.cast(objc.objc_msgSend, ctypes.c_void_p).value
+    method = ctypes.CFUNCTYPE(restype, ctypes.c_void_p, ctypes.c_void_p, *argtypes)(address)
+    return method(receiver, objc.sel_registerName(selector), *args)
+
+
+def _ns_string(text: str):
+    cls = _load_runtime().objc_getClass(b"NSString")
+    return _send(cls, b"stringWithUTF8String:", text.encode(), argtypes=[ctypes.c_char_p])
+
+
+def _target_class():
+    """NSObject subclass whose clicked: method calls the current click handler."""
+    global _click_imp
+    objc = _load_runtime()
+    cls = objc.objc_getClass(_TARGET_CLASS)
+    if cls:
+        return cls
+    objc.objc_allocateClassPair.restype = ctypes.c_void_p
+    objc.objc_allocateClassPair.argtypes = [ctypes.c_void_p, ctypes.c_char_p, ctypes.c_size_t]
+    objc.class_addMethod.argtypes = [
+        ctypes.c_void_p,
+        ctypes.c_void_p,
+        ctypes.c_void_p,
+        ctypes.c_char_p,
+    ]
+    objc.objc_registerClassPair.argtypes = [ctypes.c_void_p]
+
+    def clicked(_self, _cmd, _sender):
+        if _click_handler is not None:
+            _click_handler()
+
+    _click_imp = ctypes.CFUNCTYPE(None, ctypes.c_void_p, ctypes.c_void_p, ctypes.c_void_p)(
+        clicked
+    )
+    cls = objc.objc_allocateClassPair(objc.objc_getClass(b"NSObject"), _TARGET_CLASS, 0)
+    objc.class_addMethod(
+        cls, objc.sel_registerName(b"clicked:"), ctypes.cast(_click_imp, ctypes.c_void_p), b"v@:@"
+    )
+    objc.objc_registerClassPair(cls)
+    return cls
+
+
+class MacMenuBarItem(QObject):
+    """Status item in the macOS menu bar with a line of text."""
+
+    clicked = pyqtSignal()
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._item = None
+        self._target = None
+        self._text = "Crypto Monitor"  # Until the first price arrives
+
+    @property
+    def available(self) -> bool:
+        return sys.platform == "darwin"
+
+    @property
+    def is_shown(self) -> bool:
+        return self._item is not None
+
+    def show(self) -> bool:
+        """Add the item to the menu bar; False where there is none."""
+        global _click_handler
+        if self._item is not None:
+            return True
+        if not self.available:
+            return False
+        try:
+            status_bar = _send(_load_runtime().objc_getClass(b"NSStatusBar"), b"systemStatusBar")
+            item = _send(
+                status_bar,
+                b"statusItemWithLength:",
+                _VARIABLE_LENGTH,
+                argtypes=[ctypes.c_double],
+            )
+            _send(item, b"retain")
+            target = _send(_send(_target_class(), b"alloc"), b"init")
+            button = _send(item, b"button")
+            _send(button, b"setTarget:", target, argtypes=[ctypes.c_void_p])
+            action = _load_runtime().sel_registerName(b"clicked:")
+            _send(button, b"setAction:", action, argtypes=[ctypes.c_void_p])
+        except (OSError, AttributeError) as e:
+            logger.warning(f"Menu bar price unavailable: {e}")
+            return False
+        self._item, self._target = item, target
+        _click_handler = self.clicked.emit
+        self.set_text(self._text)
+        return True
+
+    def set_text(self, text: str):
+        self._text = text
+        if self._item is None:
+            return
+        button = _send(self._item, b"button")
+        _send(button, b"setTitle:", _ns_string(text), argtypes=[ctypes.c_void_p])
+
+    def hide(self):
+        """Take the item out of the menu bar."""
+        global _click_handler
+        if self._item is None:
+            return
+        status_bar = _send(_load_runtime().objc_getClass(b"NSStatusBar"), b"systemStatusBar")
+        _send(status_bar, b"removeStatusItem:", self._item, argtypes=[ctypes.c_void_p])
+        _send(self._item, b"release")
+        _send(self._target, b"release")
+        self._item = self._target = None
+        _click_handler = None
@@ -1,34 +1,174 @@
 import logging
+import time
 
-from PyQt6.QtCore import QObject, pyqtSignal
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
 
-from config.settings import get_settings_manager
+from config.settings import ProxyConfig, get_settings_manager
+from core.address_watcher import AddressTransfer, get_address_watcher
 from core.alert_manager import get_alert_manager
+from core.anomaly_detector import PriceAnomaly, get_anomaly_detector
+from core.candle_aggregator import get_candle_aggregator
+from core.cert_pinning import get_cert_pinning_service
+from core.chainlink_client import OracleComparator, is_oracle_pair
+from core.clock_drift import get_clock_drift_checker
+from core.coingecko import get_base_symbol, get_coingecko_service
+from core.day_boundary import day_open, day_start, format_change, needs_candle_open
+from core.dca_planner import get_dca_planner
+from core.digest import DigestScheduler
+from core.display_prefs import clean_display_prefs, parse_percentage, resolve_display_hints
+from core.economic_calendar import EconomicEvent, get_economic_calendar
 from core.exchange_factory import ExchangeFactory
+from core.fee_monitor import NetworkFees, get_fee_monitor
+from core.funding_rates import get_funding_rate_service
+from core.fx_rates import get_fx_rate_service, get_quote_asset
+from core.highlight_rules import match_highlight, parse_rules
+from core.history_budget import get_history_budget
+from core.indicators import get_indicator_engine
+from core.instrument_status import InstrumentStatus, get_instrument_status_monitor
+from core.liquidation_monitor import get_liquidation_monitor
+from core.listing_watcher import ListingAnnouncement, get_listing_watcher
+from core.local_api import get_local_api_server
+from core.market_indices import FearGreedIndex, get_fear_greed_service, is_index_pair
 from core.models import TickerData
+from core.news_feed import NewsItem, get_news_feed_service
+from core.notifier import get_notification_service
+from core.number_format import get_number_formatter
+from core.okx_account import get_okx_account_service
+from core.order_monitor import get_order_monitor
+from core.overlay import get_overlay_server
+from core.portfolio import get_portfolio_manager
+from core.portfolio_alerts import get_portfolio_alert_manager
 from core.price_tracker import PriceState, PriceTracker
+from core.session_range import session_range
+from core.snapshot import SnapshotRow, build_snapshot_row
+from core.sparkline import get_sparkline_service
+from core.stablecoin_monitor import get_stablecoin_monitor
+from core.stream_deck import get_stream_deck_api
+from core.taskbar_badge import TaskbarBadge, build_taskbar_badge
+from core.token_unlocks import get_token_unlock_service
+from core.transfer_monitor import get_transfer_monitor
+from core.tray_summary import TrayEntry, build_tray_entry, mini_ticker_pair, tray_pairs
+from core.virtual_pairs import get_virtual_pair_engine, is_virtual_pair
+from core.vwap import get_vwap_tracker, vwap_distance_pct
+from core.yield_monitor import get_yield_monitor
 
 logger = logging.getLogger(__name__)
 
 
+def render_key(data: TickerData, state: PriceState) -> tuple:
+    """
+    The inputs of what a card shows for a tick, so unchanged ticks can be skipped.
+
+    Covers the values derived apart from the price, such as the FX rate, VWAP,
+    session range and oracle price, so their updates still reach the card.
+    """
+    return (
+        data.price,
+        data.percentage,
+        state.fiat_currency,
+        state.fiat_price,
+        state.display_hints,
+        state.highlight,
+        state.vwap,
+        state.session_high,
+        state.session_low,
+        state.oracle_price,
+    )
+
+
 class MarketDataController(QObject):
     """
     Controller for managing market data, signals, and alerts.
     Decouples data logic from the UI.
     """
 
-    ticker_updated = pyqtSignal(str, object)  # pair, PriceState
+    tickers_updated = pyqtSignal(dict)  # pair -> PriceState, pairs changed since the last batch
     connection_status_changed = pyqtSignal(bool, str)  # connected, message
     connection_state_changed = pyqtSignal(str, str, int)  # state, message, retry_count
     data_source_changed = pyqtSignal()
+    tray_updated = pyqtSignal(list)  # TrayEntry of the pinned pairs, sent even while UI is paused
+    mini_ticker_updated = pyqtSignal(object)  # TrayEntry of the mini ticker pair, on every tick
+    taskbar_badge_updated = pyqtSignal(object)  # TaskbarBadge of the first tray pair, or None
 
     def __init__(self, parent: QObject | None = None):
         super().__init__(parent)
         self._settings_manager = get_settings_manager()
         self._price_tracker = PriceTracker()
         self._alert_manager = get_alert_manager()
+        self._portfolio_manager = get_portfolio_manager()
+        self._portfolio_alert_manager = get_portfolio_alert_manager()
+        self._dca_planner = get_dca_planner()
+        self._digest_scheduler = DigestScheduler(self.get_snapshot_rows, self)
+        self._fx_service = get_fx_rate_service()
+        self._account_service = get_okx_account_service()
+        self._account_service.key_warning.connect(get_notification_service().send_key_warning)
+        self._cert_pinning = get_cert_pinning_service()
+        self._cert_pinning.pin_mismatch.connect(self._on_pin_mismatch)
+        self._clock_drift = get_clock_drift_checker()
+        self._clock_drift.drift_detected.connect(self._on_clock_drift)
+        self._order_monitor = get_order_monitor()
+        self._liquidation_monitor = get_liquidation_monitor()
+        self._transfer_monitor = get_transfer_monitor()
+        self._address_watcher = get_address_watcher()
+        self._address_watcher.transfer_detected.connect(self._on_address_transfer)
+        self._indicator_engine = get_indicator_engine()
+        self._vwap_tracker = get_vwap_tracker()
+        self._sparkline_service = get_sparkline_service()
+        self._candle_aggregator = get_candle_aggregator()
+        self._history_budget = get_history_budget()
+        self._oracle_prices = OracleComparator()
+        self._coingecko_service = get_coingecko_service()
+        self._coingecko_service.market_data_updated.connect(self._emit_index_tickers)
+        self._fear_greed = get_fear_greed_service()
+        self._fear_greed.index_updated.connect(self._on_fear_greed_updated)
+        self._fee_monitor = get_fee_monitor()
+        self._fee_monitor.fees_updated.connect(self._on_fees_updated)
+        self._stablecoin_monitor = get_stablecoin_monitor()
+        self._stablecoin_monitor.supply_updated.connect(self._on_stablecoin_supply_updated)
+        self._yield_monitor = get_yield_monitor()
+        self._yield_monitor.yields_updated.connect(self._emit_index_tickers)
+        self._listing_watcher = get_listing_watcher()
+        self._listing_watcher.listing_detected.connect(self._on_listing_detected)
+        self._instrument_status = get_instrument_status_monitor()
+        self._instrument_status.status_changed.connect(self._on_instrument_status_changed)
+        self._funding_rates = get_funding_rate_service()
+        self._funding_rates.rates_updated.connect(self._on_funding_rates_updated)
+        self._news_feed = get_news_feed_service()
+        self._news_feed.news_received.connect(self._on_news_received)
+        self._economic_calendar = get_economic_calendar()
+        self._economic_calendar.event_upcoming.connect(self._on_economic_event)
+        self._token_unlocks = get_token_unlock_service()
+        self._token_unlocks.unlocks_updated.connect(self._on_unlocks_updated)
+        # Unlocks are matched by CoinGecko ID, so newly resolved assets are checked too
+        self._coingecko_service.market_data_updated.connect(self._on_unlocks_updated)
+        self._anomaly_detector = get_anomaly_detector()
+        self._anomaly_detector.anomaly_detected.connect(self._on_anomaly_detected)
+        self._virtual_pairs = get_virtual_pair_engine()
+        self._virtual_pairs.ticker_updated.connect(self._on_ticker_update)
+        self._local_api = get_local_api_server()
+        get_stream_deck_api()
+        get_overlay_server()
+        self._settings_manager.proxy_store.subscribe(self._on_proxy_changed)
+        # Components of virtual pairs that are subscribed but not monitored themselves
+        self._hidden_pairs: set[str] = set()
         self._exchange_client = None
 
+        # Ticks are coalesced per pair and handed to the UI in one batch per interval
+        self._pending_states: dict[str, PriceState] = {}
+        # render_key of each pair's last queued state
+        self._last_queued: dict[str, tuple] = {}
+        self._last_emitted_at: dict[str, float] = {}
+        # Set while the window is hidden or minimized
+        self._ui_paused = False
+        # Pairs summarized in the system tray, and whether one ticked since the last summary
+        self._tray_pairs: list[str] = []
+        self._tray_dirty = False
+        # Pair streamed to the mini ticker unbatched, bypassing pause and update intervals
+        self._mini_pair: str | None = None
+        self._batch_timer = QTimer(self)
+        self._batch_timer.setInterval(self._settings_manager.settings.update_interval_ms)
+        self._batch_timer.timeout.connect(self._flush_tickers)
+
         self._init_client()
 
     def _init_client(self):
@@ -44,9 +184,13 @@ class MarketDataController(QObject):
 
         # Create new client
         self._exchange_client = ExchangeFactory.create_client(self)
+        self._indicator_engine.set_client(self._exchange_client)
+        self._vwap_tracker.set_client(self._exchange_client)
 
         # Connect signals
         self._exchange_client.ticker_updated.connect(self._on_ticker_update)
+        self._exchange_client.trades_received.connect(self._vwap_tracker.add_trades)
+        self._exchange_client.trades_received.connect(self._candle_aggregator.add_trades)
         self._exchange_client.connection_status.connect(self.connection_status_changed)
         self._exchange_client.connection_state_changed.connect(self.connection_state_changed)
 
@@ -57,6 +201,10 @@ class MarketDataController(QObject):
         if self._exchange_client:
             try:
                 self._exchange_client.ticker_updated.disconnect(self._on_ticker_update)
+                self._exchange_client.trades_received.disconnect(self._vwap_tracker.add_trades)
+                self._exchange_client.trades_received.disconnect(
+                    self._candle_aggregator.add_trades
+                )
                 self._exchange_client.connection_status.disconnect(self.connection_status_changed)
                 self._exchange_client.connection_state_changed.disconnect(
                     self.connection_state_changed
@@ -66,43 +214,534 @@ class MarketDataController(QObject):
 
     def start(self):
         """Start data fetching."""
+        self._fx_service.start()
+        self._portfolio_manager.start()
+        self._portfolio_alert_manager.start()
+        self._dca_planner.start()
+        self._digest_scheduler.start()
+        self._account_service.start()
+        self._transfer_monitor.start()
+        self._address_watcher.start()
+        self._indicator_engine.start()
+        self._history_budget.start()
+        self._coingecko_service.start()
+        self._fear_greed.start()
+        self._fee_monitor.start()
+        self._stablecoin_monitor.start()
+        self._yield_monitor.start()
+        self._listing_watcher.start()
+        self._instrument_status.start()
+        self._funding_rates.start()
+        self._news_feed.start()
+        self._token_unlocks.start()
+        self._economic_calendar.start()
+        self._local_api.start()
+        self._batch_timer.start()
         self.reload_pairs()
+        self._cert_pinning.check()
+        self._clock_drift.check()
 
     def stop(self):
         """Stop data fetching."""
+        self._fx_service.stop()
+        self._portfolio_manager.stop()
+        self._portfolio_alert_manager.stop()
+        self._dca_planner.stop()
+        self._digest_scheduler.stop()
+        self._account_service.stop()
+        self._transfer_monitor.stop()
+        self._address_watcher.stop()
+        self._indicator_engine.stop()
+        self._history_budget.stop()
+        self._coingecko_service.stop()
+        self._fear_greed.stop()
+        self._fee_monitor.stop()
+        self._stablecoin_monitor.stop()
+        self._yield_monitor.stop()
+        self._listing_watcher.stop()
+        self._instrument_status.stop()
+        self._funding_rates.stop()
+        self._news_feed.stop()
+        self._token_unlocks.stop()
+        self._economic_calendar.stop()
+        self._local_api.stop()
+        self._batch_timer.stop()
+        self._pending_states.clear()
         if self._exchange_client:
             self._exchange_client.stop()
 
     def reload_pairs(self):
         """Reload pairs from settings and subscribe."""
         pairs = self._settings_manager.settings.crypto_pairs
-        if self._exchange_client and pairs:
-            self._exchange_client.subscribe(pairs)
+        self._batch_timer.setInterval(self._settings_manager.settings.update_interval_ms)
+        self._virtual_pairs.set_pairs(pairs)
+        real_pairs = [
+            pair for pair in pairs if not is_virtual_pair(pair) and not is_index_pair(pair)
+        ]
+        components = self._virtual_pairs.component_pairs()
+        self._hidden_pairs = {pair for pair in components if pair not in real_pairs}
+        self._indicator_engine.set_pairs(pairs)
+        self._vwap_tracker.set_pairs(pairs)
+        self._sparkline_service.set_pairs(pairs)
+        self._candle_aggregator.set_pairs(pairs)
+        self._coingecko_service.set_pairs(pairs)
+        self._funding_rates.set_pairs(real_pairs)
+        self._instrument_status.set_pairs(real_pairs)
+        self._news_feed.set_pairs(pairs)
+        self._local_api.set_pairs(pairs)
+        # Index monitors poll while their pairs are watched
+        self._fear_greed.apply_settings()
+        self._fee_monitor.apply_settings()
+        self._stablecoin_monitor.apply_settings()
+        self._yield_monitor.apply_settings()
+        self._update_tray_pairs()
+        subscribed = real_pairs + sorted(self._hidden_pairs)
+        if self._mini_pair in subscribed:
+            # Subscribed first, so it streams as soon as the connection is up
+            subscribed.remove(self._mini_pair)
+            subscribed.insert(0, self._mini_pair)
+        if self._exchange_client and subscribed:
+            self._exchange_client.subscribe(subscribed)
+        self._emit_index_tickers()
 
     def _on_ticker_update(self, pair: str, data: TickerData):
         """Handle ticker update from exchange."""
+        self._virtual_pairs.update_component(pair, data)
+        if pair in self._hidden_pairs:
+            return
+
+        self._apply_day_boundary(pair, data)
+
         # Update price tracker
         state = self._price_tracker.update_price(pair, data)
 
+        # Convert to display currency
+        self._apply_fiat_conversion(pair, state)
+        self._apply_display_prefs(pair, state)
+        self._apply_highlight_rules(pair, state)
+
+        # Update portfolio prices
+        self._portfolio_manager.update_price(pair, state.current_price)
+        self._sparkline_service.add_price(pair, state.current_price)
+        self._candle_aggregator.add_price(pair, state.current_price)
+
+        # Update technical indicators
+        state.indicators = self._indicator_engine.update_price(pair, state.current_price)
+        state.vwap = self._vwap_tracker.get_vwap(pair)
+        state.vwap_distance_pct = vwap_distance_pct(state.current_price, state.vwap)
+        self._apply_session_range(pair, state)
+        self._apply_oracle_deviation(pair, data, state)
+
+        if pair == self._mini_pair:
+            self.mini_ticker_updated.emit(build_tray_entry(pair, state, get_number_formatter()))
+        if self._local_api.is_running:
+            self._local_api.update_quote(pair, state)
+
+        # Flag spikes far outside recent ticks
+        self._anomaly_detector.check_price(pair, state.current_price)
+
         # Check price alerts
-        self._alert_manager.check_alerts(pair, state.current_price, state.percentage)
+        self._alert_manager.check_alerts(
+            pair, state.current_price, state.percentage, state.indicators
+        )
+
+        # Queue for the next UI batch, skipping ticks that change nothing on screen
+        key = render_key(data, state)
+        if self._last_queued.get(pair) == key:
+            return
+        self._last_queued[pair] = key
+        self._pending_states[pair] = state
+        if pair in self._tray_pairs:
+            self._tray_dirty = True
+
+    def _flush_tickers(self):
+        """
+        Emit the latest state of every pair that ticked since the last batch.
+
+        Pairs with a slower update interval stay queued until it has elapsed,
+        so they are downsampled to their latest state.
+        """
+        if self._tray_dirty:
+            self._tray_dirty = False
+            self.tray_updated.emit(self.get_tray_entries())
+            self.taskbar_badge_updated.emit(self.get_taskbar_badge())
+        if self._ui_paused or not self._pending_states:
+            return
+        now = time.time()
+        intervals = self._settings_manager.settings.pair_update_intervals
+        states = {
+            pair: state
+            for pair, state in self._pending_states.items()
+            if now - self._last_emitted_at.get(pair, 0.0) >= intervals.get(pair, 0)
+        }
+        if not states:
+            return
+        for pair in states:
+            del self._pending_states[pair]
+            self._last_emitted_at[pair] = now
+        self.tickers_updated.emit(states)
+
+    def _on_anomaly_detected(self, anomaly: PriceAnomaly):
+        if self._settings_manager.settings.anomaly_alerts:
+            get_notification_service().send_anomaly_alert(
+                anomaly.pair, anomaly.price, anomaly.mean, anomaly.z_score
+            )
+
+    def _on_fear_greed_updated(self, index: FearGreedIndex):
+        settings = self._settings_manager.settings
+        entered = self._fear_greed.check_extreme(
+            index, settings.fear_greed_low, settings.fear_greed_high
+        )
+        if entered and settings.fear_greed_alerts:
+            get_notification_service().send_fear_greed_alert(index.value, index.classification)
+        self._emit_index_tickers()
+
+    def _on_fees_updated(self, fees: NetworkFees):
+        settings = self._settings_manager.settings
+        dropped = self._fee_monitor.check_targets(
+            fees, settings.btc_fee_target, settings.eth_gas_target
+        )
+        if settings.fee_alerts:
+            for network, fee, target in dropped:
+                get_notification_service().send_fee_alert(network, fee, target)
+        self._emit_index_tickers()
+
+    def _on_stablecoin_supply_updated(self):
+        settings = self._settings_manager.settings
+        changes = self._stablecoin_monitor.check_changes(settings.stablecoin_threshold * 1e6)
+        if settings.stablecoin_alerts:
+            for symbol, change, supply in changes:
+                get_notification_service().send_stablecoin_alert(symbol, change, supply)
+        self._emit_index_tickers()
+
+    def _on_address_transfer(self, transfer: AddressTransfer):
+        get_notification_service().send_address_alert(
+            transfer.chain, transfer.address, transfer.label, transfer.amount
+        )
+
+    def _on_listing_detected(self, announcement: ListingAnnouncement):
+        settings = self._settings_manager.settings
+        if settings.listing_alerts == "off":
+            return
+        if settings.listing_alerts == "watched":
+            watched = {get_base_symbol(pair) for pair in settings.crypto_pairs}
+            if not watched.intersection(announcement.symbols):
+                return
+        get_notification_service().send_listing_alert(
+            announcement.exchange, announcement.title, announcement.symbols
+        )
+
+    def _on_instrument_status_changed(self, status: InstrumentStatus):
+        if not self._settings_manager.settings.delisting_alerts:
+            return
+        get_notification_service().send_delisting_warning(
+            status.exchange, status.pair, status.state, status.delist_at
+        )
+
+    def _on_funding_rates_updated(self):
+        settings = self._settings_manager.settings
+        opened = self._funding_rates.check_arbitrage(settings.funding_spread_threshold)
+        if not settings.funding_alerts:
+            return
+        for comparison in opened:
+            high, low = comparison.highest, comparison.lowest
+            get_notification_service().send_funding_alert(
+                comparison.pair,
+                high.exchange,
+                high.normalized_rate * 100,
+                low.exchange,
+                low.normalized_rate * 100,
+            )
+
+    def _on_news_received(self, item: NewsItem):
+        if self._settings_manager.settings.news_alerts:
+            get_notification_service().send_news_alert(item.source, item.title, item.symbols)
+
+    def _on_unlocks_updated(self):
+        settings = self._settings_manager.settings
+        if not settings.unlock_alerts:
+            return
+        upcoming = self._token_unlocks.check_upcoming(
+            settings.crypto_pairs, settings.unlock_lead_hours, settings.unlock_min_supply_pct
+        )
+        now = time.time()
+        for pair, unlock in upcoming:
+            get_notification_service().send_unlock_alert(
+                pair, unlock.amount, unlock.supply_pct, unlock.hours_until(now), unlock.value
+            )
+
+    def _on_economic_event(self, event: EconomicEvent):
+        if self._settings_manager.settings.macro_alerts:
+            get_notification_service().send_macro_alert(
+                event.title,
+                event.country,
+                event.minutes_until(time.time()),
+                event.forecast,
+                event.previous,
+            )
+
+    def _emit_index_tickers(self):
+        """Feed the latest readings of monitored index pairs through the ticker pipeline."""
+        tickers = (
+            self._fee_monitor.get_tickers()
+            + self._coingecko_service.get_tickers()
+            + self._stablecoin_monitor.get_tickers()
+            + self._yield_monitor.get_tickers()
+        )
+        if self._fear_greed.index:
+            tickers.append(self._fear_greed.index.to_ticker())
+        pairs = self._settings_manager.settings.crypto_pairs
+        for ticker in tickers:
+            if ticker.pair in pairs:
+                self._on_ticker_update(ticker.pair, ticker)
 
-        # Emit signal for UI
-        self.ticker_updated.emit(pair, state)
+    def _apply_day_boundary(self, pair: str, data: TickerData):
+        """Measure the change from a day open the exchange doesn't report, using candles."""
+        settings = self._settings_manager.settings
+        basis = settings.price_change_basis
+        if ":" in pair or not needs_candle_open(basis, settings.data_source):
+            # DEX, pool, virtual and index pairs keep their own change
+            return
+        series = self._indicator_engine.get_series(pair)
+        if series is None:
+            return
+        open_price = day_open(series.candles(), day_start(basis, time.time()), series.seconds)
+        if open_price is None:
+            # Candles not loaded yet: the exchange's rolling change is shown meanwhile
+            return
+        try:
+            data.percentage = format_change(float(data.price), open_price)
+        except ValueError:
+            pass
+
+    def _apply_session_range(self, pair: str, state: PriceState):
+        """Fill in the day's high/low and where the price sits between them."""
+        series = self._indicator_engine.get_series(pair)
+        day = session_range(series.candles(), time.time()) if series else None
+        if day is None:
+            state.session_high = state.session_low = state.range_position = None
+            return
+        state.session_high = day.high
+        state.session_low = day.low
+        state.range_position = day.position(state.current_price)
+
+    def _apply_oracle_deviation(self, pair: str, data: TickerData, state: PriceState):
+        """Record oracle prices, and compare exchange prices with the asset's oracle price."""
+        if is_oracle_pair(pair):
+            self._oracle_prices.update(data.display_name, data.quote_token, state.current_price)
+            return
+        if ":" in pair:
+            # DEX, pool, virtual and index pairs are not compared
+            return
+        deviation = self._oracle_prices.deviation(pair, state.current_price)
+        state.oracle_price, state.oracle_deviation_pct = deviation or (None, None)
+
+    def _apply_display_prefs(self, pair: str, state: PriceState):
+        settings = self._settings_manager.settings
+        state.alias = settings.pair_aliases.get(pair, "")
+        prefs = settings.pair_display_prefs.get(pair)
+        state.display_hints = (
+            resolve_display_hints(
+                pair,
+                prefs,
+                state.current_price,
+                state.percentage,
+                state.quote_volume_24h,
+                state.fiat_price,
+                state.fiat_currency,
+            )
+            if prefs
+            else None
+        )
+
+    def _apply_highlight_rules(self, pair: str, state: PriceState):
+        """Match the pair's highlight rules against the change its card shows."""
+        settings = self._settings_manager.settings
+        rules = parse_rules(settings.pair_highlight_rules.get(pair))
+        if not rules:
+            state.highlight = None
+            return
+        hints = state.display_hints
+        change = parse_percentage(hints.percentage_text if hints else state.percentage)
+        state.highlight = match_highlight(rules, change, settings.color_schema)
+
+    def _apply_fiat_conversion(self, pair: str, state: PriceState):
+        """Fill in the fiat price when a non-USD display currency is selected."""
+        currency = self._settings_manager.settings.fiat_currency
+        state.fiat_currency = currency
+        if currency == "USD":
+            state.fiat_price = None
+            return
+        state.fiat_price = self._fx_service.convert_from_quote(
+            state.current_price, get_quote_asset(pair), currency
+        )
+
+    def set_ui_paused(self, paused: bool):
+        """
+        Hold UI batches while the window can't be seen.
+
+        Ticks are still processed, so alerts keep firing; each pair's latest
+        state is delivered as soon as the window is back.
+        """
+        if paused == self._ui_paused:
+            return
+        self._ui_paused = paused
+        logger.debug(f"UI updates {'paused' if paused else 'resumed'}")
+        if not paused:
+            self._flush_tickers()
+
+    def set_pair_alias(self, pair: str, alias: str):
+        """Rename a pair for display and send its state out with the new name."""
+        self._settings_manager.update_pair_alias(pair, alias)
+        self._requeue_state(pair)
+
+    def set_pair_display_prefs(self, pair: str, prefs: dict):
+        """Change a pair's display preferences and send its state out re-rendered."""
+        self._settings_manager.update_pair_display_prefs(pair, clean_display_prefs(prefs))
+        self._requeue_state(pair)
+
+    def set_pair_highlight_rules(self, pair: str, rules: list[dict]):
+        """Change a pair's highlight rules and send its state out re-matched."""
+        self._settings_manager.update_pair_highlight_rules(pair, rules)
+        self._requeue_state(pair)
+
+    def _requeue_state(self, pair: str):
+        """Send a pair's latest state to the UI again after its display settings changed."""
+        state = self._price_tracker.get_state(pair)
+        if state is not None:
+            self._apply_display_prefs(pair, state)
+            self._apply_highlight_rules(pair, state)
+            self._pending_states[pair] = state
+            self._tray_dirty = self._tray_dirty or pair in self._tray_pairs
+            if pair == self._mini_pair:
+                self._emit_mini_ticker()
+            self._flush_tickers()
+
+    def set_pair_pinned(self, pair: str, pinned: bool):
+        """Pin a pair to the system tray or unpin it, updating the tray right away."""
+        if self._settings_manager.update_pair_pinned(pair, pinned):
+            self._update_tray_pairs()
+            self._flush_tickers()
+
+    def set_mini_ticker_pair(self, pair: str):
+        """Stream another pair to the mini ticker; empty for the first tray pair."""
+        self._settings_manager.update_mini_ticker_pair(pair)
+        self._update_tray_pairs()
+
+    def cycle_mini_ticker_pair(self):
+        """Stream the next tray pair to the mini ticker."""
+        if not self._tray_pairs:
+            return
+        if self._mini_pair in self._tray_pairs:
+            index = (self._tray_pairs.index(self._mini_pair) + 1) % len(self._tray_pairs)
+        else:
+            index = 0
+        self.set_mini_ticker_pair(self._tray_pairs[index])
+
+    def _update_tray_pairs(self):
+        settings = self._settings_manager.settings
+        self._tray_pairs = tray_pairs(settings.pinned_pairs, settings.crypto_pairs)
+        self._tray_dirty = True
+        mini_pair = mini_ticker_pair(
+            settings.mini_ticker_pair, self._tray_pairs, settings.crypto_pairs
+        )
+        if mini_pair != self._mini_pair:
+            self._mini_pair = mini_pair
+            self._emit_mini_ticker()
+
+    def _emit_mini_ticker(self):
+        state = self._price_tracker.get_state(self._mini_pair) if self._mini_pair else None
+        self.mini_ticker_updated.emit(
+            build_tray_entry(self._mini_pair, state, get_number_formatter())
+            if state is not None
+            else None
+        )
+
+    def get_mini_ticker_pair(self) -> str | None:
+        """Pair currently streamed to the mini ticker."""
+        return self._mini_pair
+
+    def get_tray_pairs(self) -> list[str]:
+        """Pairs summarized in the system tray."""
+        return list(self._tray_pairs)
+
+    def get_tray_entries(self) -> list[TrayEntry]:
+        """Tray lines of the pinned pairs that have a price."""
+        entries = []
+        formatter = get_number_formatter()
+        for pair in self._tray_pairs:
+            state = self._price_tracker.get_state(pair)
+            if state is not None:
+                entries.append(build_tray_entry(pair, state, formatter))
+        return entries
+
+    def get_taskbar_badge(self) -> TaskbarBadge | None:
+        """Taskbar badge of the first tray pair, None until it has a price."""
+        if not self._tray_pairs:
+            return None
+        pair = self._tray_pairs[0]
+        state = self._price_tracker.get_state(pair)
+        if state is None or not state.current_price:
+            return None
+        name = build_tray_entry(pair, state).name
+        return build_taskbar_badge(pair, name, state)
+
+    def get_snapshot_rows(self) -> list[SnapshotRow]:
+        """Snapshot lines of the monitored pairs that have a price, in list order."""
+        rows = []
+        formatter = get_number_formatter()
+        for pair in self._settings_manager.settings.crypto_pairs:
+            state = self._price_tracker.get_state(pair)
+            if state is not None and state.current_price:
+                rows.append(build_snapshot_row(pair, state, formatter))
+        return rows
+
+    def attach_tick_source(self, source: QObject):
+        """Process ticks of an extra source, such as the synthetic benchmark feed."""
+        source.ticker_updated.connect(self._on_ticker_update)
+        if not self._batch_timer.isActive():
+            self._batch_timer.start()
+
+    def detach_tick_source(self, source: QObject):
+        """Stop processing ticks of a source added with attach_tick_source."""
+        source.ticker_updated.disconnect(self._on_ticker_update)
 
     def set_data_source(self):
         logger.info("Data source changed, switching client...")
         self._alert_manager.reset()
+        self._anomaly_detector.reset()
         self._price_tracker.clear_all()
+        self._last_queued.clear()
         self._init_client()
         self.reload_pairs()
         self.data_source_changed.emit()
+        self._cert_pinning.check()
+
+    def set_account(self):
+        """Handle OKX account credential change."""
+        self._account_service.restart()
+        self._transfer_monitor.stop()
+        self._transfer_monitor.start()
 
     def set_proxy(self):
         """Handle proxy configuration change."""
         if self._exchange_client:
             self._exchange_client.reconnect()
 
+    def _on_proxy_changed(self, proxy: ProxyConfig):
+        logger.info(f"Proxy {'enabled' if proxy.enabled else 'disabled'}, reconnecting...")
+        self.set_proxy()
+        self._cert_pinning.check()
+
+    def _on_pin_mismatch(self, hosts: list):
+        # Keep the API key away from a connection that may be intercepted
+        logger.warning(f"Certificate pin mismatch for {', '.join(hosts)}, stopping account access")
+        self._account_service.stop()
+        get_notification_service().send_pin_mismatch(hosts)
+
+    def _on_clock_drift(self, offset: float):
+        get_notification_service().send_clock_drift_warning(offset)
+
     def get_price_state(self, pair: str) -> PriceState | None:
         """Get current price state for a pair."""
         return self._price_tracker.get_state(pair)
@@ -110,6 +749,9 @@ class MarketDataController(QObject):
     def clear_pair_data(self, pair: str):
         """Clear data for a specific pair."""
         self._price_tracker.clear_pair(pair)
+        self._pending_states.pop(pair, None)
+        self._last_queued.pop(pair, None)
+        self._last_emitted_at.pop(pair, None)
 
     def get_current_price(self, pair: str) -> float:
         """Get current price for a pair (for alerts)."""
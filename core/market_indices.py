@@ -0,0 +1,215 @@
+"""
+Market-wide indices.
+Fetches indices that are not tied to a trading pair, such as the Crypto
+Fear & Greed Index, and exposes them as "index:<name>" tickers.
+"""
+
+import logging
+import threading
+from dataclasses import dataclass
+
+import requests
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from config.settings import AppSettings, get_settings_manager
+from core.models import TickerData
+from core.utils.network import get_proxy_config
+
+logger = logging.getLogger(__name__)
+
+INDEX_PREFIX = "index:"
+FEAR_GREED_PAIR = "index:FNG"
+BTC_FEE_PAIR = "index:BTC_FEE"  # sat/vB
+ETH_GAS_PAIR = "index:ETH_GAS"  # gwei
+BTC_DOMINANCE_PAIR = "index:BTC_DOM"  # Percent
+TOTAL_MCAP_PAIR = "index:TOTAL_MCAP"  # USD
+ALT_MCAP_PAIR = "index:ALT_MCAP"  # USD, excluding BTC
+USDT_SUPPLY_PAIR = "index:USDT_SUPPLY"  # USD
+USDC_SUPPLY_PAIR = "index:USDC_SUPPLY"  # USD
+HASHPRICE_PAIR = "index:HASHPRICE"  # USD per PH/s per day
+ETH_STAKING_PAIR = "index:ETH_STAKING"  # Percent APR
+
+# Display names of the index tickers
+INDEX_NAMES = {
+    FEAR_GREED_PAIR: "Fear & Greed",
+    BTC_FEE_PAIR: "BTC Fee",
+    ETH_GAS_PAIR: "ETH Gas",
+    BTC_DOMINANCE_PAIR: "BTC.D",
+    TOTAL_MCAP_PAIR: "Total MCap",
+    ALT_MCAP_PAIR: "Alt MCap",
+    USDT_SUPPLY_PAIR: "USDT Supply",
+    USDC_SUPPLY_PAIR: "USDC Supply",
+    HASHPRICE_PAIR: "Hashprice",
+    ETH_STAKING_PAIR: "ETH Staking APR",
+}
+
+# Pages opened when double-clicking an index card
+INDEX_URLS = {
+    FEAR_GREED_PAIR: "https://alternative.me/crypto/fear-and-greed-index/",
+    BTC_FEE_PAIR: "https://mempool.space/",
+    ETH_GAS_PAIR: "https://etherscan.io/gastracker",
+    BTC_DOMINANCE_PAIR: "https://www.coingecko.com/en/global-charts",
+    TOTAL_MCAP_PAIR: "https://www.coingecko.com/en/global-charts",
+    ALT_MCAP_PAIR: "https://www.coingecko.com/en/global-charts",
+    USDT_SUPPLY_PAIR: "https://tether.to/en/transparency/",
+    USDC_SUPPLY_PAIR: "https://www.circle.com/transparency",
+    HASHPRICE_PAIR: "https://mempool.space/mining",
+    ETH_STAKING_PAIR: "https://lido.fi/ethereum",
+}
+
+
+def is_index_pair(pair: str) -> bool:
+    return pair.lower().startswith(INDEX_PREFIX)
+
+
+def index_pairs_in_use(settings: AppSettings, pairs) -> bool:
+    """Whether any of the index pairs is on the watchlist or has an alert."""
+    return any(pair in settings.crypto_pairs for pair in pairs) or any(
+        alert.pair in pairs for alert in settings.alerts
+    )
+
+
+def change_percentage(value: float, previous: float | None) -> str:
+    """Format the change from previous like exchange tickers, e.g. "+1.23%"."""
+    if not previous:
+        return "0.00%"
+    pct = (value - previous) / previous * 100
+    return f"+{pct:.2f}%" if pct >= 0 else f"{pct:.2f}%"
+
+
+@dataclass
+class FearGreedIndex:
+    """One reading of the Crypto Fear & Greed Index (0 = extreme fear, 100 = extreme greed)."""
+
+    value: int
+    classification: str  # e.g. "Extreme Fear", "Greed"
+    timestamp: int
+    previous_value: int | None = None  # Reading of the day before
+
+    def to_ticker(self) -> TickerData:
+        return TickerData(
+            pair=FEAR_GREED_PAIR,
+            price=str(self.value),
+            percentage=change_percentage(self.value, self.previous_value),
+            display_name=INDEX_NAMES[FEAR_GREED_PAIR],
+        )
+
+
+def fear_greed_zone(value: int, low: int, high: int) -> str | None:
+    """Extreme zone of a reading: "fear" at or below low, "greed" at or above high."""
+    if value <= low:
+        return "fear"
+    if value >= high:
+        return "greed"
+    return None
+
+
+class FearGreedService(QObject):
+    """
+    Periodically fetches the Crypto Fear & Greed Index from alternative.me.
+
+    The index is published once a day; refreshing hourly picks up new
+    readings soon after release.
+    """
+
+    index_updated = pyqtSignal(object)  # FearGreedIndex
+
+    API_URL = "https://api.alternative.me/fng/"
+    REFRESH_INTERVAL_MS = 60 * 60 * 1000
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._index: FearGreedIndex | None = None
+        self._zone: str | None = None
+        self._zone_known = False
+        self._fetching = False
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.REFRESH_INTERVAL_MS)
+        self._timer.timeout.connect(self.refresh)
+
+    def start(self):
+        """Start periodic refresh, if the index is watched or alerted on."""
+        if not self._is_needed():
+            return
+        if not self._timer.isActive():
+            self._timer.start()
+        self.refresh()
+
+    def stop(self):
+        """Stop periodic refresh."""
+        self._timer.stop()
+
+    def apply_settings(self):
+        """Start or stop refreshing after the alerts or watched pairs changed."""
+        if not self._is_needed():
+            self.stop()
+        elif not self._timer.isActive():
+            self.start()
+
+    def _is_needed(self) -> bool:
+        settings = self._settings_manager.settings
+        return settings.fear_greed_alerts or index_pairs_in_use(settings, (FEAR_GREED_PAIR,))
+
+    def refresh(self):
+        """Fetch the latest reading in a background thread."""
+        if self._fetching or not self._is_needed():
+            return
+        self._fetching = True
+        threading.Thread(target=self._fetch, daemon=True).start()
+
+    def _fetch(self):
+        try:
+            response = requests.get(
+                self.API_URL, params={"limit": 2}, proxies=get_proxy_config(), timeout=10
+            )
+            response.raise_for_status()
+            entries = response.json().get("data", [])
+            index = FearGreedIndex(
+                value=int(entries[0]["value"]),
+                classification=entries[0].get("value_classification", ""),
+                timestamp=int(entries[0].get("timestamp", 0)),
+                previous_value=int(entries[1]["value"]) if len(entries) > 1 else None,
+            )
+        except Exception as e:
+            logger.warning(f"Failed to fetch Fear & Greed Index: {e}")
+            return
+        finally:
+            self._fetching = False
+        self._index = index
+        logger.debug(f"Fear & Greed Index: {index.value} ({index.classification})")
+        self.index_updated.emit(index)
+
+    def check_extreme(self, index: FearGreedIndex, low: int, high: int) -> bool:
+        """
+        Track the extreme zone of the index.
+
+        Returns:
+            True if the reading entered an extreme zone it was not in before
+        """
+        zone = fear_greed_zone(index.value, low, high)
+        if not self._zone_known and index.previous_value is not None:
+            # Judge the first reading against the day before, not against startup
+            self._zone = fear_greed_zone(index.previous_value, low, high)
+        self._zone_known = True
+        entered = zone is not None and zone != self._zone
+        self._zone = zone
+        return entered
+
+    @property
+    def index(self) -> FearGreedIndex | None:
+        """Latest reading, or None before the first fetch."""
+        return self._index
+
+
+# Global Fear & Greed service instance
+_fear_greed_service: FearGreedService | None = None
+
+
+def get_fear_greed_service() -> FearGreedService:
+    """Get the global Fear & Greed service instance."""
+    global _fear_greed_service
+    if _fear_greed_service is None:
+        _fear_greed_service = FearGreedService()
+    return _fear_greed_service
@@ -0,0 +1,101 @@
+"""
+Message templates.
+Notification text written by the user with {placeholders}, such as an
+alert's own message or a digest line. Unknown placeholders stay as typed,
+so a typo shows up in the notification instead of hiding it.
+"""
+
+import logging
+
+from config.settings import PriceAlert
+from core.i18n import _
+from core.number_format import get_number_formatter
+
+logger = logging.getLogger(__name__)
+
+ALERT_VARIABLES = ("pair", "symbol", "price", "change", "rule", "target")
+
+
+class _Placeholders(dict):
+    """Leaves unknown placeholders as they were typed."""
+
+    def __missing__(self, key: str) -> str:
+        return "{" + key + "}"
+
+
+def fill_template(template: str, values: dict[str, str]) -> str:
+    """
+    Fill a template's placeholders.
+
+    Raises:
+        ValueError: If the template is malformed, e.g. has an unclosed brace
+    """
+    try:
+        return template.format_map(_Placeholders(values))
+    except (ValueError, AttributeError, IndexError, TypeError) as e:
+        raise ValueError(f"Malformed template {template!r}: {e}") from e
+
+
+def _rule_label(alert_type: str) -> str:
+    labels = {
+        "price_above": _("Crosses Above"),
+        "price_below": _("Crosses Below"),
+        "price_touch": _("Touches"),
+        "price_multiple": _("Price Multiple"),
+        "price_change_pct": _("Change Step"),
+        "price_ladder_pct": _("Price Ladder"),
+        "trailing_high": _("Trailing Stop"),
+        "trailing_low": _("Trailing Rebound"),
+        "rsi_above": _("RSI Above"),
+        "rsi_below": _("RSI Below"),
+        "golden_cross": _("Golden Cross"),
+        "death_cross": _("Death Cross"),
+        "ema_cross_above": _("Crosses Above EMA"),
+        "ema_cross_below": _("Crosses Below EMA"),
+        "bollinger_breach": _("Band Breach"),
+    }
+    return labels.get(alert_type, _("Alert"))
+
+
+def describe_rule(alert: PriceAlert) -> str:
+    """Short description of what an alert watches, e.g. "Crosses Above 70,000"."""
+    label = _rule_label(alert.alert_type)
+    if alert.alert_type in ("price_above", "price_below", "price_touch", "price_multiple"):
+        rule = f"{label} {get_number_formatter().price(alert.target_price)}"
+        if alert.on_candle_close:
+            rule += f" · {alert.timeframe} {_('close')}"
+        return rule
+    percent_types = ("price_change_pct", "price_ladder_pct", "trailing_high", "trailing_low")
+    if alert.alert_type in percent_types:
+        return f"{label} {alert.target_price:g}%"
+    if alert.alert_type in ("rsi_above", "rsi_below"):
+        return f"{label} {alert.target_price:g}"
+    if alert.alert_type in ("ema_cross_above", "ema_cross_below"):
+        return f"{label} {alert.ema_period} · {alert.timeframe}"
+    if alert.alert_type in ("golden_cross", "death_cross", "bollinger_breach"):
+        return f"{label} · {alert.timeframe}"
+    return label
+
+
+def render_alert_message(alert: PriceAlert, price: float, change_pct: float) -> str | None:
+    """
+    An alert's own notification text, or None to use the built-in one.
+
+    The same text goes to the desktop and every remote channel.
+    """
+    if not alert.message_template.strip():
+        return None
+    fmt = get_number_formatter()
+    values = {
+        "pair": alert.pair,
+        "symbol": alert.pair.split("-")[0],
+        "price": fmt.price(price),
+        "change": f"{change_pct:+.2f}%",
+        "rule": describe_rule(alert),
+        "target": f"{alert.target_price:g}",
+    }
+    try:
+        return fill_template(alert.message_template, values)
+    except ValueError as e:
+        logger.warning(f"{alert.pair} alert uses the built-in message: {e}")
+        return None
@@ -0,0 +1,53 @@
+"""
+Backend message catalog.
+Status and error texts raised outside the UI (connection states, polling and
+update check errors) are looked up by code and rendered in the language the
+UI uses, through the same i18n files. Credentials in error details are masked.
+"""
+
+from core.i18n import _
+from core.redaction import redact
+
+# Code -> English text; the text is the i18n key, and {name} fields are filled in
+MESSAGES = {
+    "initializing": "Initializing connection...",
+    "connecting": "Connecting... (attempt {attempt})",
+    "connected": "Connected",
+    "connected_to": "Connected to {exchange}",
+    "connected_polling": "Connected (Polling)",
+    "connected_rpc": "Connected (RPC)",
+    "connection_closed": "Connection closed",
+    "connection_cancelled": "Connection cancelled",
+    "connection_failed": "Connection failed: {error}",
+    "heartbeat_timeout": "Heartbeat timeout after {seconds}s, reconnecting...",
+    "max_retries_exceeded": "Max retries exceeded: {error}",
+    "fatal_error": "Fatal error: {error}",
+    "worker_restarting": "Connection loop crashed, restarting in {seconds}s: {error}",
+    "websocket_error": "WebSocket error: {error}",
+    "http_error": "HTTP error: {status}",
+    "polling_error": "Polling error: {error}",
+    "rpc_failed": "RPC requests failed",
+    "github_api_error": "GitHub API error: {status}",
+    "key_can_withdraw": "This API key can withdraw funds. Use a key without withdrawal permission.",
+    "key_can_trade": "This API key can trade while trading is off. A read-only key is safer.",
+}
+
+
+def message(code: str, **params) -> str:
+    """
+    Text of a message code in the current language.
+
+    Unknown codes are returned as they are, so a missing entry shows up
+    instead of raising in a worker thread.
+    """
+    template = MESSAGES.get(code)
+    if template is None:
+        return code
+    text = _(template)
+    try:
+        text = text.format(**params)
+    except (KeyError, IndexError, ValueError):
+        # A translation with broken fields falls back to English
+        text = template.format(**params)
+    # Error details can carry proxy URLs or request parameters
+    return redact(text)
@@ -1,7 +1,8 @@
 from dataclasses import dataclass
 
 
-@dataclass
+# Slots keep the per-tick allocation small at high message rates
+@dataclass(slots=True)
 class TickerData:
     pair: str
     price: str
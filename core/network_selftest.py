@@ -0,0 +1,249 @@
+"""
+Network self-test.
+Checks the way to the exchange's WebSocket hop by hop - DNS, TLS and the
+WebSocket handshake, first directly and then through the proxy - to pinpoint
+where a connection that keeps "connecting" fails and suggest the setting to
+change.
+"""
+
+import base64
+import logging
+import os
+import socket
+import ssl
+import threading
+import time
+from dataclasses import dataclass, field
+
+from PyQt6.QtCore import QObject, pyqtSignal
+
+from config.settings import ProxyConfig
+from core.redaction import redact
+from core.utils.network import open_tunnel
+
+logger = logging.getLogger(__name__)
+
+# WebSocket endpoint per data source, as (host, port, path)
+WS_ENDPOINTS = {
+    "OKX": ("ws.okx.com", 8443, "/ws/v5/public"),
+    "BINANCE": ("stream.binance.com", 9443, "/ws"),
+}
+
+STEP_TIMEOUT = 8  # seconds
+
+# Steps in the order they run; the proxy steps only with a proxy enabled
+DNS = "dns"
+TLS = "tls"
+WEBSOCKET = "websocket"
+PROXY = "proxy"
+PROXY_TLS = "proxy_tls"
+PROXY_WEBSOCKET = "proxy_websocket"
+
+STEP_LABELS = {
+    DNS: "Resolve the exchange's address",
+    TLS: "Secure connection to the exchange",
+    WEBSOCKET: "WebSocket handshake",
+    PROXY: "Reach the proxy",
+    PROXY_TLS: "Secure connection through the proxy",
+    PROXY_WEBSOCKET: "WebSocket handshake through the proxy",
+}
+
+# Diagnosis code -> suggestion; the text is the i18n key
+SUGGESTIONS = {
+    "no_network": "This data source doesn't connect to an exchange, there is nothing to test.",
+    "ok_direct": (
+        "The exchange is reachable directly. If the app still keeps connecting, "
+        "try the other data source."
+    ),
+    "ok_proxy": "The exchange is reachable through the proxy.",
+    "disable_proxy": (
+        "Direct connections work but the proxy doesn't. Turn the proxy off, or fix its settings."
+    ),
+    "proxy_unreachable": (
+        "The proxy can't be reached. Check its host and port, and that the proxy app is running."
+    ),
+    "proxy_tunnel_failed": (
+        "The proxy is running but doesn't get through to the exchange. Check the proxy type, "
+        "user name and password, or the proxy's own connection."
+    ),
+    "tls_intercepted": (
+        "The exchange's certificate isn't trusted, so something intercepts secure connections, "
+        "e.g. antivirus web protection or a company proxy. Allow the exchange there."
+    ),
+    "dns_blocked": (
+        "The exchange's address doesn't resolve, your network may block it. "
+        "Set up a proxy in Settings > Network."
+    ),
+    "direct_blocked": (
+        "Connections to the exchange are blocked or time out. Set up a proxy in Settings > Network."
+    ),
+    "websocket_blocked": (
+        "Secure connections work but WebSocket connections are blocked. Set up a proxy in "
+        "Settings > Network."
+    ),
+}
+
+
+@dataclass
+class StepResult:
+    """Outcome of one check."""
+
+    step: str
+    ok: bool
+    detail: str = ""
+    seconds: float = 0.0
+    certificate_error: bool = False
+
+
+@dataclass
+class SelfTestReport:
+    results: list[StepResult] = field(default_factory=list)
+    diagnosis: str = ""
+
+    @property
+    def suggestion(self) -> str:
+        return SUGGESTIONS.get(self.diagnosis, "")
+
+
+def _passed(results: list[StepResult], steps: tuple[str, ...]) -> bool:
+    by_step = {result.step: result for result in results}
+    return all(step in by_step and by_step[step].ok for step in steps)
+
+
+def diagnose(results: list[StepResult], proxy_enabled: bool) -> str:
+    """Diagnosis code for a set of check results."""
+    if any(result.certificate_error for result in results):
+        return "tls_intercepted"
+    direct_ok = _passed(results, (DNS, TLS, WEBSOCKET))
+    if proxy_enabled:
+        if not _passed(results, (PROXY,)):
+            return "proxy_unreachable"
+        if _passed(results, (PROXY_TLS, PROXY_WEBSOCKET)):
+            return "ok_proxy"
+        return "disable_proxy" if direct_ok else "proxy_tunnel_failed"
+    if direct_ok:
+        return "ok_direct"
+    failed = next(result.step for result in results if not result.ok)
+    return {DNS: "dns_blocked", WEBSOCKET: "websocket_blocked"}.get(failed, "direct_blocked")
+
+
+def run_steps(steps, on_result=None) -> list[StepResult]:
+    """
+    Run checks in order, stopping at the first failure.
+
+    Args:
+        steps: (step, callable returning a detail text) pairs
+        on_result: Called with each StepResult as it finishes
+    """
+    results = []
+    for step, check in steps:
+        started = time.monotonic()
+        try:
+            result = StepResult(step, True, check())
+        except Exception as e:
+            result = StepResult(
+                step,
+                False,
+                redact(str(e) or e.__class__.__name__),
+                certificate_error=isinstance(e, ssl.SSLCertVerificationError),
+            )
+        result.seconds = time.monotonic() - started
+        results.append(result)
+        if on_result:
+            on_result(result)
+        if not result.ok:
+            break
+    return results
+
+
+def _resolve(host: str, port: int) -> str:
+    infos = socket.getaddrinfo(host, port, type=socket.SOCK_STREAM)
+    addresses = list(dict.fromkeys(info[4][0] for info in infos))
+    return ", ".join(addresses[:3])
+
+
+def _tls(host: str, port: int, proxy: ProxyConfig) -> str:
+    context = ssl.create_default_context()
+    with open_tunnel(host, port, proxy, STEP_TIMEOUT) as sock:
+        with context.wrap_socket(sock, server_hostname=host) as tls:
+            return tls.version() or ""
+
+
+def _websocket(host: str, port: int, path: str, proxy: ProxyConfig) -> str:
+    context = ssl.create_default_context()
+    key = base64.b64encode(os.urandom(16)).decode()
+    request = (
+        f"GET {path} HTTP/1.1\r\nHost: {host}:{port}\r\nUpgrade: websocket\r\n"
+        f"Connection: Upgrade\r\nSec-WebSocket-Key: {key}\r\nSec-WebSocket-Version: 13\r\n\r\n"
+    )
+    with open_tunnel(host, port, proxy, STEP_TIMEOUT) as sock:
+        with context.wrap_socket(sock, server_hostname=host) as tls:
+            tls.sendall(request.encode())
+            response = tls.recv(1024)
+    status_line = response.split(b"\r\n", 1)[0].decode("latin-1")
+    if " 101" not in status_line:
+        raise ConnectionError(f"Handshake rejected: {status_line or 'no response'}")
+    return status_line
+
+
+def _reach_proxy(proxy: ProxyConfig) -> str:
+    with socket.create_connection((proxy.host, proxy.port), timeout=STEP_TIMEOUT):
+        return f"{proxy.type}://{proxy.host}:{proxy.port}"
+
+
+def run_self_test(data_source: str, proxy: ProxyConfig, on_result=None) -> SelfTestReport:
+    """Check the data source's endpoint directly and, if enabled, through the proxy."""
+    endpoint = WS_ENDPOINTS.get(data_source.upper())
+    if endpoint is None:
+        return SelfTestReport(diagnosis="no_network")
+    host, port, path = endpoint
+    direct = ProxyConfig(enabled=False)
+
+    results = run_steps(
+        [
+            (DNS, lambda: _resolve(host, port)),
+            (TLS, lambda: _tls(host, port, direct)),
+            (WEBSOCKET, lambda: _websocket(host, port, path, direct)),
+        ],
+        on_result,
+    )
+    if proxy.enabled:
+        results += run_steps(
+            [
+                (PROXY, lambda: _reach_proxy(proxy)),
+                (PROXY_TLS, lambda: _tls(host, port, proxy)),
+                (PROXY_WEBSOCKET, lambda: _websocket(host, port, path, proxy)),
+            ],
+            on_result,
+        )
+    report = SelfTestReport(results, diagnose(results, proxy.enabled))
+    logger.info(f"Network self-test for {data_source}: {report.diagnosis}")
+    return report
+
+
+class NetworkSelfTest(QObject):
+    """Runs the self-test in the background, reporting each step as it finishes."""
+
+    step_finished = pyqtSignal(object)  # StepResult
+    finished = pyqtSignal(object)  # SelfTestReport
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._running = False
+
+    @property
+    def running(self) -> bool:
+        return self._running
+
+    def start(self, data_source: str, proxy: ProxyConfig):
+        if self._running:
+            return
+        self._running = True
+        threading.Thread(target=self._run, args=(data_source, proxy), daemon=True).start()
+
+    def _run(self, data_source: str, proxy: ProxyConfig):
+        try:
+            report = run_self_test(data_source, proxy, self.step_finished.emit)
+        finally:
+            self._running = False
+        self.finished.emit(report)
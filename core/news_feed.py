@@ -0,0 +1,304 @@
+"""
+Crypto news aggregation.
+Polls RSS/Atom feeds (CoinDesk, The Block and user-supplied feeds), tags
+each item with the asset symbols it mentions and reports new items about
+the monitored assets.
+"""
+
+import logging
+import re
+import threading
+import xml.etree.ElementTree as ET
+from dataclasses import dataclass, field
+from datetime import datetime
+from email.utils import parsedate_to_datetime
+from html import unescape
+from urllib.parse import urlparse
+
+import requests
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from config.settings import get_settings_manager
+from core.coingecko import get_base_symbol
+from core.utils.network import get_proxy_config
+
+logger = logging.getLogger(__name__)
+
+DEFAULT_FEEDS = {
+    "CoinDesk": "https://www.coindesk.com/arc/outboundfeeds/rss/",
+    "The Block": "https://www.theblock.co/rss.xml",
+}
+
+# Names that headlines use instead of the ticker symbol
+ASSET_NAMES = {
+    "BTC": ["Bitcoin"],
+    "ETH": ["Ethereum", "Ether"],
+    "SOL": ["Solana"],
+    "XRP": ["Ripple"],
+    "BNB": ["BNB Chain"],
+    "DOGE": ["Dogecoin"],
+    "ADA": ["Cardano"],
+    "AVAX": ["Avalanche"],
+    "DOT": ["Polkadot"],
+    "LINK": ["Chainlink"],
+    "TON": ["Toncoin"],
+    "TRX": ["Tron"],
+    "LTC": ["Litecoin"],
+    "USDT": ["Tether"],
+}
+
+ATOM_NS = "{http://www.w3.org/2005/Atom}"
+MAX_ITEMS = 200
+
+_TAG_RE = re.compile(r"<[^>]+>")
+
+
+@dataclass
+class NewsItem:
+    """One feed entry."""
+
+    source: str
+    id: str
+    title: str
+    url: str
+    published_at: float  # Seconds, 0 if the feed has no date
+    summary: str = ""
+    symbols: list[str] = field(default_factory=list)  # Monitored assets it mentions
+
+
+def _strip_html(text: str) -> str:
+    return " ".join(unescape(_TAG_RE.sub(" ", text)).split())
+
+
+def _parse_date(text: str | None) -> float:
+    """Timestamp of an RFC 822 (RSS) or ISO 8601 (Atom) date, or 0."""
+    if not text:
+        return 0.0
+    text = text.strip()
+    try:
+        return parsedate_to_datetime(text).timestamp()
+    except (TypeError, ValueError):
+        pass
+    try:
+        return datetime.fromisoformat(text.replace("Z", "+00:00")).timestamp()
+    except ValueError:
+        return 0.0
+
+
+def parse_feed(content: str | bytes, source: str) -> list[NewsItem]:
+    """
+    Parse an RSS 2.0 or Atom document.
+
+    Raises:
+        ValueError: If the document is not valid XML
+    """
+    try:
+        root = ET.fromstring(content)
+    except ET.ParseError as e:
+        raise ValueError(f"Invalid feed: {e}") from e
+
+    items = []
+    for entry in root.iter("item"):
+        title = _strip_html(entry.findtext("title", ""))
+        url = entry.findtext("link", "").strip()
+        items.append(
+            NewsItem(
+                source=source,
+                id=entry.findtext("guid", "").strip() or url or title,
+                title=title,
+                url=url,
+                published_at=_parse_date(entry.findtext("pubDate")),
+                summary=_strip_html(entry.findtext("description", "")),
+            )
+        )
+    for entry in root.iter(f"{ATOM_NS}entry"):
+        title = _strip_html(entry.findtext(f"{ATOM_NS}title", ""))
+        link = entry.find(f"{ATOM_NS}link[@rel='alternate']")
+        if link is None:
+            link = entry.find(f"{ATOM_NS}link")
+        url = link.get("href", "") if link is not None else ""
+        summary = entry.findtext(f"{ATOM_NS}summary") or entry.findtext(f"{ATOM_NS}content", "")
+        items.append(
+            NewsItem(
+                source=source,
+                id=entry.findtext(f"{ATOM_NS}id", "").strip() or url or title,
+                title=title,
+                url=url,
+                published_at=_parse_date(
+                    entry.findtext(f"{ATOM_NS}published") or entry.findtext(f"{ATOM_NS}updated")
+                ),
+                summary=_strip_html(summary),
+            )
+        )
+    return [item for item in items if item.title]
+
+
+def tag_symbols(text: str, symbols: list[str]) -> list[str]:
+    """
+    Symbols of the given assets mentioned in text.
+
+    Symbols match as whole uppercase words (also "$SOL"); known asset names
+    match case-insensitively.
+    """
+    tagged = []
+    for symbol in symbols:
+        if re.search(rf"(?<![A-Za-z0-9]){re.escape(symbol)}(?![A-Za-z0-9])", text):
+            tagged.append(symbol)
+            continue
+        for name in ASSET_NAMES.get(symbol, []):
+            if re.search(rf"\b{re.escape(name)}\b", text, re.IGNORECASE):
+                tagged.append(symbol)
+                break
+    return tagged
+
+
+def feed_source_name(url: str) -> str:
+    """Display name of a feed: the built-in name, or the host without "www."."""
+    for name, feed_url in DEFAULT_FEEDS.items():
+        if feed_url == url:
+            return name
+    host = urlparse(url).hostname or url
+    return host.removeprefix("www.")
+
+
+class NewsFeedService(QObject):
+    """
+    Polls the news feeds and emits new items about the monitored assets.
+
+    Items present at the first successful poll of each feed are remembered
+    without being emitted, so starting the app does not replay old news.
+    """
+
+    news_received = pyqtSignal(object)  # NewsItem
+
+    REFRESH_INTERVAL_MS = 10 * 60 * 1000
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._symbols: list[str] = []
+        self._items: list[NewsItem] = []  # Newest first
+        self._seen: set[str] = set()
+        self._initialized: set[str] = set()  # Feeds polled successfully before
+        self._fetching = False
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.REFRESH_INTERVAL_MS)
+        self._timer.timeout.connect(self.refresh)
+
+    def start(self):
+        """Start polling, if news alerts are on."""
+        if not self._is_needed():
+            return
+        if not self._timer.isActive():
+            self._timer.start()
+        self.refresh()
+
+    def stop(self):
+        """Stop polling."""
+        self._timer.stop()
+
+    def apply_settings(self):
+        """Start or stop polling after news alerts were switched."""
+        if not self._is_needed():
+            self.stop()
+        elif not self._timer.isActive():
+            self.start()
+
+    def _is_needed(self) -> bool:
+        # Alerts are the only consumer of the feeds
+        return self._settings_manager.settings.news_alerts
+
+    def set_pairs(self, pairs: list[str]):
+        """Track the base assets of the given pairs and re-tag the known items."""
+        symbols = []
+        for pair in pairs:
+            symbol = get_base_symbol(pair)
+            if symbol and symbol not in symbols:
+                symbols.append(symbol)
+        self._symbols = symbols
+        for item in self._items:
+            item.symbols = tag_symbols(f"{item.title} {item.summary}", symbols)
+
+    def get_feeds(self) -> list[str]:
+        """URLs of the built-in and user-supplied feeds."""
+        feeds = list(DEFAULT_FEEDS.values())
+        for url in self._settings_manager.settings.news_feeds:
+            if url not in feeds:
+                feeds.append(url)
+        return feeds
+
+    def refresh(self):
+        """Poll the feeds in a background thread."""
+        if self._fetching or not self._is_needed():
+            return
+        self._fetching = True
+        threading.Thread(target=self._fetch, args=(self.get_feeds(),), daemon=True).start()
+
+    def _fetch(self, feeds: list[str]):
+        try:
+            batches = [(url, self._fetch_feed(url)) for url in feeds]
+        finally:
+            self._fetching = False
+        for url, items in batches:
+            if items is None:
+                continue
+            for item in self.process(url, items):
+                logger.info(f"News about {', '.join(item.symbols)}: {item.title}")
+                self.news_received.emit(item)
+
+    def _fetch_feed(self, url: str) -> list[NewsItem] | None:
+        try:
+            response = requests.get(
+                url,
+                headers={"User-Agent": "Mozilla/5.0"},
+                proxies=get_proxy_config(),
+                timeout=10,
+            )
+            response.raise_for_status()
+            return parse_feed(response.content, feed_source_name(url))
+        except Exception as e:
+            logger.debug(f"News feed {url} failed: {e}")
+            return None
+
+    def process(self, feed: str, items: list[NewsItem]) -> list[NewsItem]:
+        """
+        Tag and remember the items of a feed.
+
+        Returns:
+            New items that mention a monitored asset; nothing for the feed's first batch
+        """
+        new = []
+        for item in sorted(items, key=lambda i: i.published_at):
+            key = f"{feed}:{item.id}"
+            if key in self._seen:
+                continue
+            self._seen.add(key)
+            item.symbols = tag_symbols(f"{item.title} {item.summary}", self._symbols)
+            self._items.append(item)
+            if feed in self._initialized and item.symbols:
+                new.append(item)
+        self._initialized.add(feed)
+        self._items.sort(key=lambda i: i.published_at, reverse=True)
+        del self._items[MAX_ITEMS:]
+        return new
+
+    def get_news(self, pair: str | None = None, limit: int = 20) -> list[NewsItem]:
+        """Latest items, optionally only those mentioning a pair's base asset."""
+        symbol = get_base_symbol(pair) if pair else None
+        items = self._items
+        if symbol:
+            items = [item for item in items if symbol in item.symbols]
+        return items[:limit]
+
+
+# Global news feed service instance
+_news_feed_service: NewsFeedService | None = None
+
+
+def get_news_feed_service() -> NewsFeedService:
+    """Get the global news feed service instance."""
+    global _news_feed_service
+    if _news_feed_service is None:
+        _news_feed_service = NewsFeedService()
+    return _news_feed_service
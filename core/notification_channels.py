@@ -0,0 +1,416 @@
+"""
+Remote notification channels.
+Forwards the desktop notifications to chat apps, so alerts reach the phone
+while away from the computer. Messages go out in the background; a channel
+that fails only logs.
+"""
+
+import asyncio
+import base64
+import hashlib
+import hmac
+import logging
+import smtplib
+import ssl
+import threading
+import time
+import uuid
+from email.message import EmailMessage
+from urllib.parse import quote, quote_plus
+
+import requests
+from PyQt6.QtCore import QObject, pyqtSignal
+
+from config.settings import (
+    AppSettings,
+    DingTalkConfig,
+    EmailConfig,
+    FeishuConfig,
+    GotifyConfig,
+    MatrixConfig,
+    TelegramConfig,
+    WeComConfig,
+    XmppConfig,
+    get_settings_manager,
+)
+from core.i18n import _
+from core.redaction import redact
+from core.utils.network import get_proxy_config
+
+logger = logging.getLogger(__name__)
+
+try:
+    import slixmpp
+
+    XMPP_AVAILABLE = True
+except ImportError:
+    XMPP_AVAILABLE = False
+
+REQUEST_TIMEOUT = 10  # seconds
+
+TELEGRAM_API_URL = "https://api.telegram.org/bot{token}/{method}"
+
+
+def telegram_request(token: str, method: str, payload: dict, timeout: float = REQUEST_TIMEOUT):
+    """
+    Call a Telegram Bot API method.
+
+    Returns:
+        The method's result
+
+    Raises:
+        requests.RequestException: If the request fails
+        RuntimeError: If Telegram rejects it
+    """
+    response = requests.post(
+        TELEGRAM_API_URL.format(token=token, method=method),
+        json=payload,
+        proxies=get_proxy_config(),
+        timeout=timeout,
+    )
+    data = response.json()
+    if not data.get("ok"):
+        raise RuntimeError(data.get("description") or f"HTTP {response.status_code}")
+    return data["result"]
+
+
+class NotificationChannel:
+    """A remote destination for notifications."""
+
+    name = ""
+
+    def send(self, title: str, message: str) -> None:
+        """Deliver a notification; raises on failure."""
+        raise NotImplementedError
+
+
+class TelegramChannel(NotificationChannel):
+    name = "Telegram"
+
+    def __init__(self, config: TelegramConfig):
+        self.config = config
+
+    def send(self, title: str, message: str) -> None:
+        telegram_request(
+            self.config.bot_token,
+            "sendMessage",
+            {"chat_id": self.config.chat_id, "text": f"{title}\n{message}"},
+        )
+
+
+class MatrixChannel(NotificationChannel):
+    name = "Matrix"
+
+    def __init__(self, config: MatrixConfig):
+        self.config = config
+
+    def send(self, title: str, message: str) -> None:
+        url = (
+            f"{self.config.homeserver.rstrip('/')}/_matrix/client/v3/rooms/"
+            f"{quote(self.config.room_id, safe='')}/send/m.room.message/{uuid.uuid4().hex}"
+        )
+        response = requests.put(
+            url,
+            json={"msgtype": "m.text", "body": f"{title}\n{message}"},
+            headers={"Authorization": f"Bearer {self.config.access_token}"},
+            proxies=get_proxy_config(),
+            timeout=REQUEST_TIMEOUT,
+        )
+        if not response.ok:
+            try:
+                error = response.json().get("error")
+            except ValueError:
+                error = None
+            raise RuntimeError(error or f"HTTP {response.status_code}")
+
+
+class WeComChannel(NotificationChannel):
+    """WeChat Work group robot."""
+
+    name = "WeChat Work"
+
+    def __init__(self, config: WeComConfig):
+        self.config = config
+
+    def send(self, title: str, message: str) -> None:
+        response = requests.post(
+            self.config.webhook_url,
+            json={"msgtype": "text", "text": {"content": f"{title}\n{message}"}},
+            proxies=get_proxy_config(),
+            timeout=REQUEST_TIMEOUT,
+        )
+        data = response.json()
+        if data.get("errcode") != 0:
+            raise RuntimeError(data.get("errmsg") or f"HTTP {response.status_code}")
+
+
+def dingtalk_sign(secret: str, timestamp_ms: int) -> str:
+    """Signature of a DingTalk robot request, URL-encoded for the query string."""
+    string_to_sign = f"{timestamp_ms}\n{secret}"
+    digest = hmac.new(secret.encode(), string_to_sign.encode(), hashlib.sha256).digest()
+    return quote_plus(base64.b64encode(digest).decode())
+
+
+def feishu_sign(secret: str, timestamp: int) -> str:
+    """Signature of a Feishu bot request; the timestamp and secret form the key."""
+    key = f"{timestamp}\n{secret}"
+    digest = hmac.new(key.encode(), b"", hashlib.sha256).digest()
+    return base64.b64encode(digest).decode()
+
+
+class DingTalkChannel(NotificationChannel):
+    name = "DingTalk"
+
+    def __init__(self, config: DingTalkConfig):
+        self.config = config
+
+    def send(self, title: str, message: str) -> None:
+        url = self.config.webhook_url
+        if self.config.secret:
+            timestamp_ms = int(time.time() * 1000)
+            sign = dingtalk_sign(self.config.secret, timestamp_ms)
+            url = f"{url}&timestamp={timestamp_ms}&sign={sign}"
+        response = requests.post(
+            url,
+            json={"msgtype": "text", "text": {"content": f"{title}\n{message}"}},
+            proxies=get_proxy_config(),
+            timeout=REQUEST_TIMEOUT,
+        )
+        data = response.json()
+        if data.get("errcode") != 0:
+            raise RuntimeError(data.get("errmsg") or f"HTTP {response.status_code}")
+
+
+class FeishuChannel(NotificationChannel):
+    name = "Feishu"
+
+    def __init__(self, config: FeishuConfig):
+        self.config = config
+
+    def send(self, title: str, message: str) -> None:
+        payload = {"msg_type": "text", "content": {"text": f"{title}\n{message}"}}
+        if self.config.secret:
+            timestamp = int(time.time())
+            payload["timestamp"] = str(timestamp)
+            payload["sign"] = feishu_sign(self.config.secret, timestamp)
+        response = requests.post(
+            self.config.webhook_url,
+            json=payload,
+            proxies=get_proxy_config(),
+            timeout=REQUEST_TIMEOUT,
+        )
+        data = response.json()
+        # Older bots answer with StatusCode instead of code
+        if data.get("code", data.get("StatusCode")) != 0:
+            raise RuntimeError(data.get("msg") or f"HTTP {response.status_code}")
+
+
+class GotifyChannel(NotificationChannel):
+    name = "Gotify"
+
+    def __init__(self, config: GotifyConfig):
+        self.config = config
+
+    def send(self, title: str, message: str) -> None:
+        response = requests.post(
+            f"{self.config.server_url.rstrip('/')}/message",
+            json={"title": title, "message": message, "priority": self.config.priority},
+            headers={"X-Gotify-Key": self.config.app_token},
+            proxies=get_proxy_config(),
+            timeout=REQUEST_TIMEOUT,
+        )
+        if not response.ok:
+            try:
+                error = response.json().get("errorDescription")
+            except ValueError:
+                error = None
+            raise RuntimeError(error or f"HTTP {response.status_code}")
+
+
+class XmppChannel(NotificationChannel):
+    """
+    Chat message from an XMPP account. Each notification logs in, sends and
+    disconnects, so no session is kept open. The proxy setting doesn't apply.
+    """
+
+    name = "XMPP"
+
+    def __init__(self, config: XmppConfig):
+        self.config = config
+
+    def send(self, title: str, message: str) -> None:
+        if not XMPP_AVAILABLE:
+            raise RuntimeError("XMPP needs the slixmpp package")
+        # Runs on a delivery thread, which has no event loop of its own
+        loop = asyncio.new_event_loop()
+        asyncio.set_event_loop(loop)
+        try:
+            loop.run_until_complete(self._send(f"{title}\n{message}"))
+        finally:
+            asyncio.set_event_loop(None)
+            loop.close()
+
+    async def _send(self, body: str):
+        client = slixmpp.ClientXMPP(self.config.jid, self.config.password)
+        done = asyncio.get_running_loop().create_future()
+        sent = False
+
+        def finish(error: str = ""):
+            if not done.done():
+                if error:
+                    done.set_exception(RuntimeError(error))
+                else:
+                    done.set_result(None)
+
+        def on_session_start(_event):
+            nonlocal sent
+            client.send_message(mto=self.config.recipient, mbody=body, mtype="chat")
+            sent = True
+            client.disconnect()
+
+        def on_disconnected(_event):
+            finish("" if sent else "disconnected before the message was sent")
+
+        client.add_event_handler("session_start", on_session_start)
+        client.add_event_handler("failed_auth", lambda _event: finish("authentication failed"))
+        client.add_event_handler(
+            "connection_failed", lambda error: finish(str(error) or "connection failed")
+        )
+        client.add_event_handler("disconnected", on_disconnected)
+        client.connect()
+        try:
+            await asyncio.wait_for(done, REQUEST_TIMEOUT)
+        except asyncio.TimeoutError:
+            client.disconnect()
+            raise RuntimeError("XMPP server didn't answer in time") from None
+
+
+class EmailChannel(NotificationChannel):
+    """Email through an SMTP server. The proxy setting doesn't apply."""
+
+    name = "Email"
+
+    def __init__(self, config: EmailConfig):
+        self.config = config
+
+    def send(self, title: str, message: str) -> None:
+        email = EmailMessage()
+        email["Subject"] = title
+        email["From"] = self.config.sender or self.config.username
+        email["To"] = self.config.recipient
+        email.set_content(message)
+
+        context = ssl.create_default_context()
+        host, port = self.config.smtp_host, self.config.smtp_port
+        if port == smtplib.SMTP_SSL_PORT:
+            server = smtplib.SMTP_SSL(host, port, timeout=REQUEST_TIMEOUT, context=context)
+        else:
+            server = smtplib.SMTP(host, port, timeout=REQUEST_TIMEOUT)
+        with server:
+            if port != smtplib.SMTP_SSL_PORT:
+                server.starttls(context=context)
+            if self.config.username:
+                server.login(self.config.username, self.config.password)
+            server.send_message(email)
+
+
+# Every channel type, in the order they are offered
+CHANNEL_TYPES = (
+    TelegramChannel,
+    MatrixChannel,
+    WeComChannel,
+    DingTalkChannel,
+    FeishuChannel,
+    GotifyChannel,
+    XmppChannel,
+    EmailChannel,
+)
+
+
+def configured_channels(settings: AppSettings) -> list[NotificationChannel]:
+    """Channels that are enabled and have what they need to send."""
+    channels: list[NotificationChannel] = []
+    telegram = settings.telegram
+    if telegram.enabled and telegram.bot_token and telegram.chat_id:
+        channels.append(TelegramChannel(telegram))
+    matrix = settings.matrix
+    if matrix.enabled and matrix.homeserver and matrix.access_token and matrix.room_id:
+        channels.append(MatrixChannel(matrix))
+    if settings.wecom.enabled and settings.wecom.webhook_url:
+        channels.append(WeComChannel(settings.wecom))
+    if settings.dingtalk.enabled and settings.dingtalk.webhook_url:
+        channels.append(DingTalkChannel(settings.dingtalk))
+    if settings.feishu.enabled and settings.feishu.webhook_url:
+        channels.append(FeishuChannel(settings.feishu))
+    gotify = settings.gotify
+    if gotify.enabled and gotify.server_url and gotify.app_token:
+        channels.append(GotifyChannel(gotify))
+    xmpp = settings.xmpp
+    if xmpp.enabled and xmpp.jid and xmpp.password and xmpp.recipient:
+        channels.append(XmppChannel(xmpp))
+    email = settings.email
+    if email.enabled and email.smtp_host and email.recipient:
+        channels.append(EmailChannel(email))
+    return channels
+
+
+def find_channel(settings: AppSettings, name: str) -> NotificationChannel | None:
+    """The configured channel with the given name, if it is usable."""
+    for channel in configured_channels(settings):
+        if channel.name == name:
+            return channel
+    return None
+
+
+class RemoteNotifier(QObject):
+    """Sends notifications to every configured channel."""
+
+    test_finished = pyqtSignal(str, str)  # channel name, error ("" when delivered)
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+
+    def send(self, title: str, message: str):
+        for channel in configured_channels(self._settings_manager.settings):
+            threading.Thread(
+                target=self._deliver, args=(channel, title, message), daemon=True
+            ).start()
+
+    def send_to(self, name: str, title: str, message: str) -> bool:
+        """Send through one channel only; False when it isn't configured."""
+        channel = find_channel(self._settings_manager.settings, name)
+        if channel is None:
+            return False
+        threading.Thread(target=self._deliver, args=(channel, title, message), daemon=True).start()
+        return True
+
+    def _deliver(self, channel: NotificationChannel, title: str, message: str):
+        try:
+            channel.send(title, message)
+        except Exception as e:
+            logger.warning(f"{channel.name} notification failed: {redact(str(e))}")
+
+    def send_test(self, channel: NotificationChannel):
+        """Send a test message in the background and report the outcome."""
+        threading.Thread(target=self._test, args=(channel,), daemon=True).start()
+
+    def _test(self, channel: NotificationChannel):
+        try:
+            channel.send(_("Crypto Monitor"), _("Notifications are working!"))
+            error = ""
+        except Exception as e:
+            error = redact(str(e)) or e.__class__.__name__
+        self.test_finished.emit(channel.name, error)
+
+
+# Global remote notifier instance
+_remote_notifier: RemoteNotifier | None = None
+
+
+def get_remote_notifier() -> RemoteNotifier:
+    """Get the global remote notifier instance."""
+    global _remote_notifier
+    if _remote_notifier is None:
+        _remote_notifier = RemoteNotifier()
+    return _remote_notifier
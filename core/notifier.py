@@ -10,6 +10,7 @@ import sys
 
 # Keeping imports clean
 import threading
+import time
 import webbrowser
 
 from PyQt6.QtCore import QObject, QThread, QUrl, pyqtSignal
@@ -17,6 +18,9 @@ from PyQt6.QtMultimedia import QAudioOutput, QMediaPlayer
 
 from config.settings import get_settings_manager
 from core.i18n import _
+from core.messages import message as backend_message
+from core.notification_channels import get_remote_notifier
+from core.number_format import get_number_formatter
 from core.utils import suppress_output
 
 logger = logging.getLogger(__name__)
@@ -85,11 +89,13 @@ class NotificationService(QObject):
     """
 
     notification_clicked = pyqtSignal(str)  # Emits pair name when notification clicked
+    paused_changed = pyqtSignal(bool)  # Emits new pause state
 
     def __init__(self, parent=None):
         super().__init__(parent)
         self._worker: AsyncLoopThread | None = None
         self._notifier: DesktopNotifier | None = None
+        self._paused = False
 
         if NOTIFIER_AVAILABLE:
             self._worker = AsyncLoopThread(self)
@@ -100,6 +106,19 @@ class NotificationService(QObject):
         self._audio_output = QAudioOutput()
         self._player.setAudioOutput(self._audio_output)
 
+    @property
+    def is_paused(self) -> bool:
+        """Whether notifications are held back."""
+        return self._paused
+
+    def set_paused(self, paused: bool):
+        """Pause or resume notifications; alerts keep being evaluated while paused."""
+        if paused == self._paused:
+            return
+        self._paused = paused
+        logger.info(f"Notifications {'paused' if paused else 'resumed'}")
+        self.paused_changed.emit(paused)
+
     def _get_okx_url(self, pair: str) -> str:
         """Get OKX trading page URL for a pair."""
         formatted_pair = pair.lower()
@@ -135,14 +154,20 @@ class NotificationService(QObject):
             logger.error(f"Error playing sound: {e}")
 
     async def _send_notification_task(
-        self, title: str, message: str, pair: str, urgency: "Urgency" = None
+        self, title: str, message: str, pair: str, urgency: "Urgency" = None, remote: bool = True
     ):
         """
         Coroutine to send notification.
-        Runs in the background thread's loop.
+        Runs in the background thread's loop. With remote False, it only shows on the desktop.
         """
         if urgency is None:
             urgency = Urgency.Normal
+        if self._paused:
+            logger.debug(f"Notification paused: {title}")
+            return
+
+        if remote:
+            get_remote_notifier().send(title, message)
 
         try:
             await self._ensure_notifier()
@@ -171,7 +196,7 @@ class NotificationService(QObject):
                     title=title,
                     message=message,
                     urgency=urgency,
-                    on_clicked=lambda: self._open_url(pair),
+                    on_clicked=(lambda: self._open_url(pair)) if pair else None,
                     sound=sound_file,
                 )
         except Exception as e:
@@ -186,6 +211,9 @@ class NotificationService(QObject):
         current_pct: float = 0.0,
         previous_price: float = None,
         previous_pct: float = None,
+        indicator_value: float = None,
+        remote: bool = True,
+        custom_message: str | None = None,
     ):
         """
         Send a price alert notification.
@@ -193,11 +221,15 @@ class NotificationService(QObject):
         Args:
             pair: Trading pair, e.g., "BTC-USDT"
             alert_type: Alert type ("price_above", "price_below", "price_touch")
-            target_price: The target price that triggered the alert
+            target_price: The target price that triggered the alert (step for ladders)
             current_price: The current price
             current_pct: The current 24h change percentage
-            previous_price: The previous price (for step alerts)
+            previous_price: The previous price (for step alerts; the rung for ladders, the
+                running high or low for trailing alerts)
             previous_pct: The previous percentage (for percentage step alerts)
+            indicator_value: The current indicator value (for indicator alerts)
+            remote: Whether to forward it to the remote channels too
+            custom_message: The alert's own text, replacing the built-in message
         """
         if not NOTIFIER_AVAILABLE or not self._worker:
             logger.warning(
@@ -205,31 +237,31 @@ class NotificationService(QObject):
             )
             return
 
-        from core.utils import format_price
+        fmt = get_number_formatter()
 
         # Build notification message
         symbol = pair.split("-")[0]
 
         # Format current price with smart precision and percentage change
         pct_sign = "+" if current_pct >= 0 else ""
-        current_display = f"${format_price(current_price)} ({pct_sign}{current_pct:.2f}%)"
+        current_display = f"${fmt.price(current_price)} ({pct_sign}{current_pct:.2f}%)"
 
         if alert_type == "price_above":
             title = f"{symbol} 📈 {_('Crossed Above Target')}"
             message = (
-                f"{_('Price rose above')} ${format_price(target_price)}\n"
+                f"{_('Price rose above')} ${fmt.price(target_price)}\n"
                 f"{_('Current:')} {current_display}"
             )
         elif alert_type == "price_below":
             title = f"{symbol} 📉 {_('Crossed Below Target')}"
             message = (
-                f"{_('Price fell below')} ${format_price(target_price)}\n"
+                f"{_('Price fell below')} ${fmt.price(target_price)}\n"
                 f"{_('Current:')} {current_display}"
             )
         elif alert_type == "price_touch":
             title = f"{symbol} 🎯 {_('Price Touched Target')}"
             message = (
-                f"{_('Price reached')} ${format_price(target_price)}\n"
+                f"{_('Price reached')} ${fmt.price(target_price)}\n"
                 f"{_('Current:')} {current_display}"
             )
         elif alert_type == "price_multiple":
@@ -252,7 +284,7 @@ class NotificationService(QObject):
                 reached_price = math.floor(current_price / step) * step
             title = f"{symbol} 🔢 {_('Price Step Reached')}"
             message = (
-                f"{_('Reached')} ${format_price(reached_price)}\n{_('Current:')} {current_display}"
+                f"{_('Reached')} ${fmt.price(reached_price)}\n{_('Current:')} {current_display}"
             )
         elif alert_type == "price_change_pct":
             # Calculate the crossed percentage step using previous_pct
@@ -284,11 +316,42 @@ class NotificationService(QObject):
             )
             title = f"{symbol} 📊 {_('Percentage Step Reached')}"
             message = f"{_('24h Change reached')} {pct_display}\n{_('Current:')} {current_display}"
+        elif alert_type == "price_ladder_pct":
+            # previous_price is the ladder rung the move is measured from
+            moved = (current_price / previous_price - 1) * 100 if previous_price else 0.0
+            title = f"{symbol} 🪜 {_('Price Ladder Step')}"
+            message = (
+                f"{_('Moved')} {moved:+.2f}% {_('from')} ${fmt.price(previous_price or 0)}\n"
+                f"{_('Current:')} {current_display}"
+            )
+        elif alert_type in ("trailing_high", "trailing_low"):
+            # previous_price is the running high or low the price retraced from
+            moved = (current_price / previous_price - 1) * 100 if previous_price else 0.0
+            if alert_type == "trailing_high":
+                title = f"{symbol} 📉 {_('Trailing Stop Hit')}"
+                extreme_label = _("from high")
+            else:
+                title = f"{symbol} 📈 {_('Trailing Rebound Hit')}"
+                extreme_label = _("from low")
+            message = (
+                f"{moved:+.2f}% {extreme_label} ${fmt.price(previous_price or 0)}\n"
+                f"{_('Current:')} {current_display}"
+            )
+        elif alert_type in ("rsi_above", "rsi_below"):
+            rsi_display = f"{indicator_value:.1f}" if indicator_value is not None else "-"
+            if alert_type == "rsi_above":
+                title = f"{symbol} 📈 {_('RSI Above Level')}"
+                message = f"RSI {rsi_display} > {target_price:g}\n{_('Current:')} {current_display}"
+            else:
+                title = f"{symbol} 📉 {_('RSI Below Level')}"
+                message = f"RSI {rsi_display} < {target_price:g}\n{_('Current:')} {current_display}"
         else:
             title = f"{symbol} 🔔 {_('Price Alert')}"
             message = (
-                f"{_('Target:')} {format_price(target_price)}\n{_('Current:')} {current_display}"
+                f"{_('Target:')} {fmt.price(target_price)}\n{_('Current:')} {current_display}"
             )
+        if custom_message:
+            message = custom_message
 
         # Schedule usage on the background loop
         loop = self._worker.get_loop()
@@ -296,7 +359,11 @@ class NotificationService(QObject):
             try:
                 asyncio.run_coroutine_threadsafe(
                     self._send_notification_task(
-                        title=title, message=message, pair=pair, urgency=Urgency.Normal
+                        title=title,
+                        message=message,
+                        pair=pair,
+                        urgency=Urgency.Normal,
+                        remote=remote,
                     ),
                     loop,
                 )
@@ -304,6 +371,828 @@ class NotificationService(QObject):
                 # Loop might be closed during execution
                 pass
 
+    def send_ma_cross_alert(
+        self,
+        pair: str,
+        alert_type: str,
+        timeframe: str,
+        ema_period: int,
+        current_price: float,
+        remote: bool = True,
+        custom_message: str | None = None,
+    ):
+        """
+        Send a moving average cross notification.
+
+        Args:
+            pair: Trading pair, e.g., "BTC-USDT"
+            alert_type: "golden_cross", "death_cross", "ema_cross_above" or "ema_cross_below"
+            timeframe: Candle interval of the moving averages, e.g., "1h"
+            ema_period: EMA period crossed by the price (price/EMA crosses only)
+            current_price: The current price
+            remote: Whether to forward it to the remote channels too
+            custom_message: The alert's own text, replacing the built-in message
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[MA Cross Fallback] {pair}: {alert_type} ({timeframe})")
+            return
+
+        from core.indicators import MA_CROSS_PERIODS
+        fmt = get_number_formatter()
+
+        symbol = pair.split("-")[0]
+        fast, slow = MA_CROSS_PERIODS
+        if alert_type == "golden_cross":
+            title = f"{symbol} ✨ {_('Golden Cross')}"
+            detail = f"EMA {fast} {_('crossed above')} EMA {slow}"
+        elif alert_type == "death_cross":
+            title = f"{symbol} ☠️ {_('Death Cross')}"
+            detail = f"EMA {fast} {_('crossed below')} EMA {slow}"
+        elif alert_type == "ema_cross_above":
+            title = f"{symbol} 📈 {_('Price Crossed Above EMA')}"
+            detail = f"{_('Price')} {_('crossed above')} EMA {ema_period}"
+        else:
+            title = f"{symbol} 📉 {_('Price Crossed Below EMA')}"
+            detail = f"{_('Price')} {_('crossed below')} EMA {ema_period}"
+        message = f"{detail} ({timeframe})\n{_('Current:')} ${fmt.price(current_price)}"
+        if custom_message:
+            message = custom_message
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(
+                        title=title, message=message, pair=pair, remote=remote
+                    ),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_bollinger_alert(
+        self,
+        pair: str,
+        side: str,
+        timeframe: str,
+        close: float,
+        band: float,
+        remote: bool = True,
+        custom_message: str | None = None,
+    ):
+        """
+        Send a notification for a candle closing outside the Bollinger Bands.
+
+        Args:
+            pair: Trading pair, e.g., "BTC-USDT"
+            side: "upper" or "lower"
+            timeframe: Candle interval, e.g., "1h"
+            close: Close of the candle
+            band: Value of the breached band
+            remote: Whether to forward it to the remote channels too
+            custom_message: The alert's own text, replacing the built-in message
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[Bollinger Fallback] {pair}: {side} band ({timeframe}) at {close}")
+            return
+
+        fmt = get_number_formatter()
+
+        symbol = pair.split("-")[0]
+        if side == "upper":
+            title = f"{symbol} 📈 {_('Closed Above Upper Band')}"
+        else:
+            title = f"{symbol} 📉 {_('Closed Below Lower Band')}"
+        message = (
+            f"{_('Close')} ${fmt.price(close)} ({timeframe})\n"
+            f"{_('Band')} ${fmt.price(band)}"
+        )
+        if custom_message:
+            message = custom_message
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(
+                        title=title, message=message, pair=pair, remote=remote
+                    ),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_anomaly_alert(self, pair: str, price: float, mean: float, z_score: float):
+        """
+        Send a notification for a tick far outside the recent price range.
+
+        Args:
+            pair: Trading pair, e.g., "BTC-USDT"
+            price: Price of the anomalous tick
+            mean: Short-term mean of the preceding ticks
+            z_score: Deviation in standard deviations
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[Anomaly Fallback] {pair}: {price} (mean {mean}, z={z_score:.1f})")
+            return
+
+        fmt = get_number_formatter()
+
+        symbol = pair.split("-")[0]
+        change = (price - mean) / mean * 100
+        title = f"{symbol} ⚡ {_('Price Spike Detected')}"
+        message = (
+            f"${fmt.price(price)} ({change:+.2f}% {_('vs recent average')})\n"
+            f"{abs(z_score):.1f}σ {_('from the short-term mean')}"
+        )
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(title=title, message=message, pair=pair),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_fear_greed_alert(self, value: int, classification: str):
+        """
+        Send a notification for an extreme Fear & Greed Index reading.
+
+        Args:
+            value: Index value (0-100)
+            classification: Sentiment label, e.g., "Extreme Fear"
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[Fear & Greed Fallback] {value} ({classification})")
+            return
+
+        title = f"{_('Fear & Greed Index')}: {value}"
+        message = f"{_('Market sentiment')}: {classification}"
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(title=title, message=message, pair=""),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_fee_alert(self, network: str, fee: float, target: float):
+        """
+        Send a notification for network fees dropping below the target.
+
+        Args:
+            network: "BTC" or "ETH"
+            fee: Current fee (sat/vB for BTC, gwei for ETH)
+            target: User's target fee
+        """
+        unit = "sat/vB" if network == "BTC" else "gwei"
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[Fee Fallback] {network}: {fee:g} {unit} (target {target:g})")
+            return
+
+        title = f"{network} ⛽ {_('Low Network Fees')}"
+        message = (
+            f"{fee:g} {unit} ({_('target')} {target:g} {unit})\n"
+            f"{_('A good time to move funds')}"
+        )
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(title=title, message=message, pair=""),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_listing_alert(self, exchange: str, title: str, symbols: list[str]):
+        """
+        Send a notification for a new exchange listing announcement.
+
+        Args:
+            exchange: Exchange name, e.g., "Binance"
+            title: Announcement title
+            symbols: Token symbols mentioned in the title
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[Listing Fallback] {exchange}: {title}")
+            return
+
+        tokens = ", ".join(symbols)
+        title_text = f"{exchange} 📢 {_('New Listing')}" + (f": {tokens}" if tokens else "")
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(title=title_text, message=title, pair=""),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_delisting_warning(
+        self, exchange: str, pair: str, state: str, delist_at: float | None = None
+    ):
+        """
+        Warn that a watched pair is suspended or being delisted.
+
+        Args:
+            exchange: Exchange name, e.g., "OKX"
+            pair: Trading pair, e.g., "BTC-USDT"
+            state: "suspended", "delisting" or "delisted"
+            delist_at: Scheduled delisting time in seconds, if known
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[Delisting Fallback] {exchange} {pair}: {state}")
+            return
+
+        if state == "suspended":
+            title = f"{pair} ⛔ {_('Trading Suspended')}"
+            message = _("{exchange} has suspended trading of this pair").format(exchange=exchange)
+        elif state == "delisting":
+            title = f"{pair} ⚠️ {_('Delisting Scheduled')}"
+            message = _("{exchange} will delist this pair").format(exchange=exchange)
+            if delist_at:
+                message += f": {time.strftime('%Y-%m-%d %H:%M', time.localtime(delist_at))}"
+        else:
+            title = f"{pair} ⛔ {_('Pair Delisted')}"
+            message = _("{exchange} no longer lists this pair").format(exchange=exchange)
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(title=title, message=message, pair=pair),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_funding_alert(
+        self,
+        pair: str,
+        high_exchange: str,
+        high_rate: float,
+        low_exchange: str,
+        low_rate: float,
+    ):
+        """
+        Send a notification for a cross-exchange funding rate spread.
+
+        Args:
+            pair: Trading pair whose perpetual is compared
+            high_exchange: Exchange with the highest funding rate
+            high_rate: Its rate in percent per 8 hours
+            low_exchange: Exchange with the lowest funding rate
+            low_rate: Its rate in percent per 8 hours
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(
+                f"[Funding Fallback] {pair}: {high_exchange} {high_rate:.4f}% / "
+                f"{low_exchange} {low_rate:.4f}%"
+            )
+            return
+
+        symbol = pair.split("-")[0]
+        title = f"{symbol} 💱 {_('Funding Spread')} {high_rate - low_rate:.4f}%"
+        message = (
+            f"{_('Short on')} {high_exchange}: {high_rate:+.4f}%\n"
+            f"{_('Long on')} {low_exchange}: {low_rate:+.4f}%\n"
+            f"({_('per 8h')})"
+        )
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(title=title, message=message, pair=pair),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_unlock_alert(
+        self,
+        pair: str,
+        amount: float,
+        supply_pct: float,
+        hours_until: float,
+        value: float | None = None,
+    ):
+        """
+        Send a notification ahead of a large token unlock.
+
+        Args:
+            pair: Trading pair of the unlocked token
+            amount: Tokens unlocked
+            supply_pct: Amount in percent of the circulating supply
+            hours_until: Hours until the unlock
+            value: USD value of the unlocked tokens, if known
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(
+                f"[Unlock Fallback] {pair}: {amount:,.0f} ({supply_pct:.2f}%) "
+                f"in {hours_until:.0f}h"
+            )
+            return
+
+        fmt = get_number_formatter()
+
+        symbol = pair.split("-")[0]
+        title = f"{symbol} 🔓 {_('Token Unlock')} {supply_pct:.2f}%"
+        message = f"{fmt.compact(amount)} {symbol}"
+        if value is not None:
+            message += f" (${fmt.compact(value)})"
+        message += f"\n{_('Unlocks in')} {hours_until:.0f}h"
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(title=title, message=message, pair=pair),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_macro_alert(
+        self, title: str, country: str, minutes_until: float, forecast: str = "", previous: str = ""
+    ):
+        """
+        Send a reminder ahead of a high-impact economic release.
+
+        Args:
+            title: Event name, e.g., "CPI m/m"
+            country: Currency code of the release, e.g., "USD"
+            minutes_until: Minutes until the release
+            forecast: Consensus forecast, if published
+            previous: Previous reading, if any
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[Macro Fallback] {country} {title} in {minutes_until:.0f} min")
+            return
+
+        title_text = f"📅 {country} {title}"
+        message = f"{_('Starts in')} {minutes_until:.0f} min"
+        details = []
+        if forecast:
+            details.append(f"{_('Forecast')}: {forecast}")
+        if previous:
+            details.append(f"{_('Previous')}: {previous}")
+        if details:
+            message += "\n" + " · ".join(details)
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(title=title_text, message=message, pair=""),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_stablecoin_alert(self, symbol: str, change: float, supply: float):
+        """
+        Send a notification for a large stablecoin mint or burn.
+
+        Args:
+            symbol: Stablecoin, e.g., "USDT"
+            change: Supply change in USD (negative for burns)
+            supply: Current circulating supply in USD
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[Stablecoin Fallback] {symbol}: {change:+,.0f} (supply {supply:,.0f})")
+            return
+
+        fmt = get_number_formatter()
+
+        icon, action = ("🖨️", _("Minted")) if change > 0 else ("🔥", _("Burned"))
+        title = f"{icon} {symbol} {action} ${fmt.compact(abs(change))}"
+        message = f"{_('Circulating Supply')}: ${fmt.compact(supply)}"
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(title=title, message=message, pair=""),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_address_alert(self, chain: str, address: str, label: str, amount: float):
+        """
+        Send a notification for a large transfer of a watched address.
+
+        Args:
+            chain: "BTC" or "ETH"
+            address: Watched address
+            label: User's name for the address, may be empty
+            amount: Net amount moved; negative when outgoing
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[Address Fallback] {label or address}: {amount:+,.4f} {chain}")
+            return
+
+        name = label or f"{address[:6]}...{address[-4:]}"
+        direction = _("Incoming") if amount > 0 else _("Outgoing")
+        title = f"🐋 {name}: {direction} {abs(amount):,.2f} {chain}"
+        message = address
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(title=title, message=message, pair=""),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_news_alert(self, source: str, title: str, symbols: list[str]):
+        """
+        Send a notification for a news item about monitored assets.
+
+        Args:
+            source: Feed name, e.g., "CoinDesk"
+            title: Headline
+            symbols: Monitored assets the item mentions
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[News Fallback] {source}: {title}")
+            return
+
+        title_text = f"📰 {', '.join(symbols)} · {source}"
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(title=title_text, message=title, pair=""),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_portfolio_alert(
+        self,
+        alert_type: str,
+        threshold: float,
+        value: float,
+        currency: str = "USD",
+        asset: str = "",
+        portfolio: str = "",
+    ):
+        """
+        Send a portfolio alert notification.
+
+        Args:
+            alert_type: "value_above", "value_below", "daily_drawdown" or "position_share"
+            threshold: The configured threshold
+            value: Metric value that triggered the alert (value, drawdown % or share %)
+            currency: Currency of value thresholds
+            asset: Asset concerned, for position share alerts
+            portfolio: Portfolio name, shown when several portfolios exist
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(
+                f"[Portfolio Alert Fallback] {alert_type}: {value} (threshold: {threshold})"
+            )
+            return
+
+        fmt = get_number_formatter()
+
+        if alert_type == "value_above":
+            title = f"💼 {_('Portfolio Value Above Target')}"
+            message = (
+                f"{_('Portfolio value rose above')} {fmt.fiat(threshold, currency)}\n"
+                f"{_('Current:')} {fmt.fiat(value, currency)}"
+            )
+        elif alert_type == "value_below":
+            title = f"💼 {_('Portfolio Value Below Target')}"
+            message = (
+                f"{_('Portfolio value fell below')} {fmt.fiat(threshold, currency)}\n"
+                f"{_('Current:')} {fmt.fiat(value, currency)}"
+            )
+        elif alert_type == "daily_drawdown":
+            title = f"📉 {_('Portfolio Drawdown')}"
+            label = _("Down from today's high:")
+            message = f"{label} {value:.2f}% (≥ {threshold:g}%)"
+        else:
+            title = f"⚖️ {_('Position Allocation Alert')}"
+            message = f"{asset} {_('share of portfolio:')} {value:.2f}% (> {threshold:g}%)"
+
+        if portfolio:
+            title += f" · {portfolio}"
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(title=title, message=message, pair=""),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_order_update(
+        self,
+        event: str,
+        inst_id: str,
+        side: str,
+        size: float,
+        filled_size: float,
+        price: float | None = None,
+    ):
+        """
+        Send an order fill/cancel notification.
+
+        Args:
+            event: "filled", "partially_filled" or "canceled"
+            inst_id: Instrument ID, e.g., "BTC-USDT"
+            side: "buy" or "sell"
+            size: Order size
+            filled_size: Accumulated filled size
+            price: Average fill price, or order price if nothing filled
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[Order Fallback] {inst_id}: {side} {event} {filled_size}/{size}")
+            return
+
+        fmt = get_number_formatter()
+
+        side_text = _("Buy") if side == "buy" else _("Sell")
+        price_text = f" @ {fmt.price(price)}" if price else ""
+
+        if event == "filled":
+            title = f"{inst_id} ✅ {_('Order Filled')}"
+            message = f"{side_text} {filled_size:g}{price_text}"
+        elif event == "partially_filled":
+            title = f"{inst_id} ⏳ {_('Order Partially Filled')}"
+            message = f"{side_text} {filled_size:g} / {size:g}{price_text}"
+        else:
+            title = f"{inst_id} ❌ {_('Order Cancelled')}"
+            message = f"{side_text} {size:g}{price_text}"
+            if filled_size:
+                message += f"\n{_('Filled')}: {filled_size:g}"
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(title=title, message=message, pair=inst_id),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_dca_reminder(
+        self,
+        pair: str,
+        amount: float,
+        price: float,
+        total_quantity: float,
+        average_price: float,
+    ):
+        """
+        Send a DCA plan reminder.
+
+        Args:
+            pair: Trading pair, e.g., "BTC-USDT"
+            amount: Amount to invest this run, in quote asset
+            price: Current price
+            total_quantity: Base asset accumulated by the plan so far
+            average_price: Average price of the accumulated position
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[DCA Fallback] {pair}: buy {amount:g} @ {price}")
+            return
+
+        fmt = get_number_formatter()
+
+        base, _sep, quote = pair.partition("-")
+        pnl_pct = (price - average_price) / average_price * 100 if average_price else 0.0
+
+        title = f"{pair} 🗓️ {_('DCA Reminder')}"
+        message = (
+            f"{_('Buy')} {amount:g} {quote} @ {fmt.price(price)}\n"
+            f"{_('Accumulated:')} {total_quantity:g} {base} "
+            f"({_('Avg')} {fmt.price(average_price)}, {pnl_pct:+.2f}%)"
+        )
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(title=title, message=message, pair=pair),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_digest(self, title: str, message: str):
+        """
+        Send a scheduled price digest.
+
+        Args:
+            title: Digest title, e.g. "Daily Digest · 08:00"
+            message: One rendered line per pair
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[Digest Fallback] {title}\n{message}")
+            return
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(title=title, message=message, pair=""),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_transfer_notification(
+        self,
+        kind: str,
+        status: str,
+        currency: str,
+        amount: float,
+        chain: str = "",
+    ):
+        """
+        Send a deposit/withdrawal notification.
+
+        Args:
+            kind: "deposit" or "withdrawal"
+            status: "arrived", "completed" or "failed"
+            currency: Currency code, e.g., "USDT"
+            amount: Transferred amount
+            chain: Chain name, e.g., "USDT-TRC20"
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[Transfer Fallback] {kind} {status}: {amount:g} {currency}")
+            return
+
+        if kind == "deposit":
+            title = f"{currency} 📥 {_('Deposit Arrived')}"
+        elif status == "completed":
+            title = f"{currency} 📤 {_('Withdrawal Completed')}"
+        else:
+            title = f"{currency} ❌ {_('Withdrawal Failed')}"
+
+        message = f"{amount:g} {currency}"
+        if chain:
+            message += f"\n{_('Chain')}: {chain}"
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(title=title, message=message, pair=""),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_liquidation_warning(
+        self,
+        inst_id: str,
+        pos_side: str,
+        distance: float,
+        mark_price: float,
+        liq_price: float,
+        critical: bool = False,
+    ):
+        """
+        Send a liquidation-risk warning for a position.
+
+        Args:
+            inst_id: Instrument ID, e.g., "BTC-USDT-SWAP"
+            pos_side: "long", "short" or "net"
+            distance: Distance from mark price to liquidation price, in percent
+            mark_price: Current mark price
+            liq_price: Estimated liquidation price
+            critical: Whether the tightest warning level was reached
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[Liquidation Fallback] {inst_id} {pos_side}: {distance:.2f}%")
+            return
+
+        fmt = get_number_formatter()
+
+        icon = "🚨" if critical else "⚠️"
+        title = f"{inst_id} {icon} {_('Liquidation Risk')}"
+        message = (
+            f"{_('Distance to liquidation:')} {distance:.2f}%\n"
+            f"{_('Mark:')} {fmt.price(mark_price)} • {_('Liq.:')} {fmt.price(liq_price)}"
+        )
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(
+                        title=title,
+                        message=message,
+                        pair=inst_id,
+                        urgency=Urgency.Critical if critical else Urgency.Normal,
+                    ),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_key_warning(self, risky: list[str]):
+        """
+        Warn that the OKX API key has permissions the app doesn't need.
+
+        Args:
+            risky: Unneeded permissions, e.g. ["withdraw"]
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[Key Warning Fallback] OKX API key can: {', '.join(risky)}")
+            return
+
+        title = f"OKX ⚠️ {_('API Key Permissions')}"
+        message = "\n".join(backend_message(f"key_can_{permission}") for permission in risky)
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(title=title, message=message, pair=""),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_pin_mismatch(self, hosts: list[str]):
+        """
+        Warn that exchange endpoints presented an unpinned certificate key.
+
+        Args:
+            hosts: Hosts whose key changed, e.g. ["www.okx.com"]
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[Pin Mismatch Fallback] {', '.join(hosts)}")
+            return
+
+        title = f"🛡️ {_('Certificate Changed')}"
+        message = (
+            f"{', '.join(hosts)}\n"
+            f"{_('The connection may be intercepted. Account access is paused.')}"
+        )
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(
+                        title=title, message=message, pair="", urgency=Urgency.Critical
+                    ),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
+    def send_clock_drift_warning(self, offset: float):
+        """
+        Warn that the system clock is off.
+
+        Args:
+            offset: Seconds the clock is behind (positive) or ahead (negative)
+        """
+        if not NOTIFIER_AVAILABLE or not self._worker:
+            logger.warning(f"[Clock Drift Fallback] {offset:+.1f}s")
+            return
+
+        seconds = f"{abs(offset):.0f}" if abs(offset) >= 10 else f"{abs(offset):.1f}"
+        if offset > 0:
+            drift = _("The system clock is {seconds}s behind.").format(seconds=seconds)
+        else:
+            drift = _("The system clock is {seconds}s ahead.").format(seconds=seconds)
+        title = f"⏱️ {_('System Clock Off')}"
+        message = f"{drift}\n{_('Daily changes and alert times may be wrong until it is synced.')}"
+
+        loop = self._worker.get_loop()
+        if loop and loop.is_running() and not loop.is_closed():
+            try:
+                asyncio.run_coroutine_threadsafe(
+                    self._send_notification_task(title=title, message=message, pair=""),
+                    loop,
+                )
+            except RuntimeError:
+                pass
+
     def send_test_notification(self):
         """Send a test notification."""
         logger.info("Manual test notification requested")
@@ -0,0 +1,87 @@
+"""
+Locale-aware number formatting.
+Prices, compact amounts and fiat values in notifications, the tray and
+exports get thousands and decimal separators of the chosen number format,
+which can follow the UI language.
+"""
+
+import re
+
+from config.settings import get_settings_manager
+from core.fx_rates import format_fiat
+from core.i18n import get_current_language
+from core.utils import format_compact, format_price
+
+# Number format -> (thousands separator, decimal separator)
+NUMBER_FORMATS = {
+    "plain": ("", "."),  # 1234567.89
+    "comma": (",", "."),  # 1,234,567.89
+    "dot": (".", ","),  # 1.234.567,89
+    "space": ("\u202f", ","),  # 1 234 567,89 with narrow no-break spaces
+}
+# Choices offered in the settings, "auto" following the UI language
+NUMBER_FORMAT_CHOICES = ("plain", "auto", "comma", "dot", "space")
+# Formats of the UI languages not using "comma"
+_LANGUAGE_FORMATS = {
+    "de_DE": "dot",
+    "es_ES": "dot",
+    "pt_BR": "dot",
+    "fr_FR": "space",
+    "ru_RU": "space",
+}
+
+_NUMBER = re.compile(r"(\d+)(?:\.(\d+))?")
+
+
+def resolve_number_format(number_format: str, language: str) -> str:
+    """The format to use; "auto" picks the language's."""
+    if number_format == "auto":
+        return _LANGUAGE_FORMATS.get(language, "comma")
+    return number_format if number_format in NUMBER_FORMATS else "plain"
+
+
+class NumberFormatter:
+    """Formats numbers with the separators of one number format."""
+
+    def __init__(self, number_format: str = "plain"):
+        self.number_format = number_format if number_format in NUMBER_FORMATS else "plain"
+        self._group, self._decimal = NUMBER_FORMATS[self.number_format]
+
+    def localize(self, text: str) -> str:
+        """Apply the separators to every plain number in text, e.g. "$1234.5 (+1.20%)"."""
+        if self.number_format == "plain":
+            return text
+        return _NUMBER.sub(self._localize_number, text)
+
+    def _localize_number(self, match: re.Match) -> str:
+        integer, fraction = match.group(1), match.group(2)
+        if self._group and len(integer) > 3:
+            head = len(integer) % 3 or 3
+            groups = [integer[:head]] + [
+                integer[i : i + 3] for i in range(head, len(integer), 3)
+            ]
+            integer = self._group.join(groups)
+        return f"{integer}{self._decimal}{fraction}" if fraction is not None else integer
+
+    def price(self, price: float | str, precision: int | None = None) -> str:
+        return self.localize(format_price(price, precision))
+
+    def compact(self, value: float) -> str:
+        return self.localize(format_compact(value))
+
+    def fiat(self, amount: float, currency: str, precision: int | None = None) -> str:
+        return self.localize(format_fiat(amount, currency, precision))
+
+
+# Global number formatter instance, rebuilt when the format changes
+_number_formatter: NumberFormatter | None = None
+
+
+def get_number_formatter() -> NumberFormatter:
+    """Get the number formatter of the current settings and language."""
+    global _number_formatter
+    settings = get_settings_manager().settings
+    number_format = resolve_number_format(settings.number_format, get_current_language())
+    if _number_formatter is None or _number_formatter.number_format != number_format:
+        _number_formatter = NumberFormatter(number_format)
+    return _number_formatter
@@ -0,0 +1,532 @@
+"""
+OKX private account integration.
+Uses user-supplied API credentials to show real balances, positions
+and margin ratio via the private REST API and WebSocket channels.
+"""
+
+import asyncio
+import base64
+import hashlib
+import hmac
+import json
+import logging
+import threading
+import time
+from dataclasses import dataclass, field
+from datetime import datetime, timezone
+from typing import Any
+
+import requests
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from config.settings import OkxApiConfig, get_settings_manager
+from core.rate_limiter import get_rate_limiter
+from core.utils.network import (
+    apply_socket_buffers,
+    get_proxy_config,
+    get_ws_tuning,
+    websockets_connect_options,
+)
+from core.websocket_worker import BaseWebSocketWorker
+from core.worker_controller import WorkerController
+
+logger = logging.getLogger(__name__)
+
+OKX_REST_BASE = "https://www.okx.com"
+OKX_WS_PRIVATE_URL = "wss://ws.okx.com:8443/ws/v5/private"
+OKX_DEMO_WS_PRIVATE_URL = "wss://wspap.okx.com:8443/ws/v5/private"
+
+# API key permissions reported by the account config endpoint
+PERMISSION_READ_ONLY = "read_only"
+PERMISSION_TRADE = "trade"
+PERMISSION_WITHDRAW = "withdraw"
+
+
+class OkxApiError(Exception):
+    """Error returned by the OKX private API."""
+
+    def __init__(self, code: str, message: str):
+        super().__init__(f"OKX API error {code}: {message}")
+        self.code = code
+        self.message = message
+
+
+def sign_message(prehash: str, secret_key: str) -> str:
+    """Sign a prehash string with HMAC-SHA256, base64-encoded as OKX requires."""
+    digest = hmac.new(secret_key.encode(), prehash.encode(), hashlib.sha256).digest()
+    return base64.b64encode(digest).decode()
+
+
+def private_ws_url(config: OkxApiConfig) -> str:
+    """Private WebSocket endpoint of the live or demo trading environment."""
+    return OKX_DEMO_WS_PRIVATE_URL if config.demo else OKX_WS_PRIVATE_URL
+
+
+def rest_timestamp() -> str:
+    """Current time in the ISO format used by REST signatures, e.g. 2024-01-01T00:00:00.000Z."""
+    now = datetime.now(timezone.utc)
+    return now.strftime("%Y-%m-%dT%H:%M:%S.") + f"{now.microsecond // 1000:03d}Z"
+
+
+def parse_float(value: Any) -> float | None:
+    """Parse an OKX numeric string; OKX uses "" for unavailable values."""
+    try:
+        return float(value) if value not in ("", None) else None
+    except (TypeError, ValueError):
+        return None
+
+
+def parse_permissions(perm: str) -> set[str]:
+    """Parse the comma-separated "perm" field, e.g. "read_only,trade"."""
+    return {p.strip() for p in (perm or "").split(",") if p.strip()}
+
+
+def risky_permissions(permissions: set[str], trading_enabled: bool) -> list[str]:
+    """
+    Permissions of a key beyond what the app needs.
+
+    Withdrawal is never needed; trading only when placing orders is allowed.
+    """
+    risky = []
+    if PERMISSION_WITHDRAW in permissions:
+        risky.append(PERMISSION_WITHDRAW)
+    if PERMISSION_TRADE in permissions and not trading_enabled:
+        risky.append(PERMISSION_TRADE)
+    return risky
+
+
+@dataclass
+class AccountBalance:
+    """Balance of one currency in the trading account."""
+
+    currency: str
+    equity: float  # Total equity of the currency
+    available: float  # Available balance
+    usd_value: float | None  # Equity valued in USD
+
+
+@dataclass
+class AccountPosition:
+    """An open derivatives or margin position."""
+
+    position_id: str
+    inst_id: str  # e.g., "BTC-USDT-SWAP"
+    inst_type: str  # "SWAP" | "FUTURES" | "MARGIN" | "OPTION"
+    pos_side: str  # "long" | "short" | "net"
+    size: float  # Signed for net mode
+    avg_price: float | None
+    mark_price: float | None
+    liq_price: float | None
+    unrealized_pnl: float | None
+    leverage: float | None
+    margin_ratio: float | None
+
+
+@dataclass
+class AccountSnapshot:
+    """Current state of the OKX account."""
+
+    total_equity_usd: float | None = None
+    margin_ratio: float | None = None  # Account-level margin ratio, None if not applicable
+    balances: list[AccountBalance] = field(default_factory=list)
+    positions: list[AccountPosition] = field(default_factory=list)
+    updated_at: float = 0.0
+
+
+def parse_account(item: dict) -> tuple[float | None, float | None, list[AccountBalance]]:
+    """
+    Parse one entry of the balance endpoint / account channel.
+
+    Returns:
+        (total equity in USD, margin ratio, balances)
+    """
+    balances = []
+    for detail in item.get("details", []):
+        equity = parse_float(detail.get("eq")) or 0.0
+        if equity == 0:
+            continue
+        balances.append(
+            AccountBalance(
+                currency=detail.get("ccy", ""),
+                equity=equity,
+                available=parse_float(detail.get("availBal")) or 0.0,
+                usd_value=parse_float(detail.get("eqUsd")),
+            )
+        )
+    balances.sort(key=lambda b: b.usd_value or 0.0, reverse=True)
+    return parse_float(item.get("totalEq")), parse_float(item.get("mgnRatio")), balances
+
+
+def parse_position(item: dict) -> AccountPosition:
+    """Parse one entry of the positions endpoint / channel."""
+    return AccountPosition(
+        position_id=item.get("posId", ""),
+        inst_id=item.get("instId", ""),
+        inst_type=item.get("instType", ""),
+        pos_side=item.get("posSide", "net"),
+        size=parse_float(item.get("pos")) or 0.0,
+        avg_price=parse_float(item.get("avgPx")),
+        mark_price=parse_float(item.get("markPx")),
+        liq_price=parse_float(item.get("liqPx")),
+        unrealized_pnl=parse_float(item.get("upl")),
+        leverage=parse_float(item.get("lever")),
+        margin_ratio=parse_float(item.get("mgnRatio")),
+    )
+
+
+class OkxRestClient:
+    """Minimal signed client for the OKX v5 private REST API."""
+
+    def __init__(self, config: OkxApiConfig, base_url: str = OKX_REST_BASE):
+        self._config = config
+        self._base_url = base_url
+
+    def _headers(self, method: str, request_path: str, body: str) -> dict[str, str]:
+        timestamp = rest_timestamp()
+        prehash = f"{timestamp}{method}{request_path}{body}"
+        headers = {
+            "OK-ACCESS-KEY": self._config.api_key,
+            "OK-ACCESS-SIGN": sign_message(prehash, self._config.secret_key),
+            "OK-ACCESS-TIMESTAMP": timestamp,
+            "OK-ACCESS-PASSPHRASE": self._config.passphrase,
+            "Content-Type": "application/json",
+        }
+        if self._config.demo:
+            # Demo trading shares the REST host and is selected per request
+            headers["x-simulated-trading"] = "1"
+        return headers
+
+    def request(
+        self, method: str, path: str, params: dict | None = None, body: dict | None = None
+    ) -> list[dict]:
+        """
+        Send a signed request.
+
+        Returns:
+            The "data" list of the response
+
+        Raises:
+            OkxApiError: If OKX returns a non-zero code
+            requests.RequestException: On network errors
+        """
+        method = method.upper()
+        request_path = path
+        if params:
+            query = "&".join(f"{k}={v}" for k, v in params.items())
+            request_path = f"{path}?{query}"
+        body_text = json.dumps(body) if body else ""
+
+        get_rate_limiter().acquire("OKX")
+        response = requests.request(
+            method,
+            self._base_url + request_path,
+            headers=self._headers(method, request_path, body_text),
+            data=body_text or None,
+            proxies=get_proxy_config(),
+            timeout=10,
+        )
+        payload = response.json()
+        if payload.get("code") != "0":
+            raise OkxApiError(payload.get("code", ""), payload.get("msg", ""))
+        return payload.get("data", [])
+
+    def get_account_config(self) -> list[dict]:
+        """GET /api/v5/account/config (includes the key's permissions)"""
+        return self.request("GET", "/api/v5/account/config")
+
+    def get_balance(self) -> list[dict]:
+        """GET /api/v5/account/balance"""
+        return self.request("GET", "/api/v5/account/balance")
+
+    def get_positions(self) -> list[dict]:
+        """GET /api/v5/account/positions"""
+        return self.request("GET", "/api/v5/account/positions")
+
+    def get_deposit_history(self) -> list[dict]:
+        """GET /api/v5/asset/deposit-history (latest 100 records)"""
+        return self.request("GET", "/api/v5/asset/deposit-history")
+
+    def get_withdrawal_history(self) -> list[dict]:
+        """GET /api/v5/asset/withdrawal-history (latest 100 records)"""
+        return self.request("GET", "/api/v5/asset/withdrawal-history")
+
+
+class OkxPrivateWebSocketWorker(BaseWebSocketWorker):
+    """
+    Worker thread for the OKX private WebSocket.
+    Logs in with the API credentials and relays channel pushes.
+    """
+
+    WS_PRIVATE_URL = OKX_WS_PRIVATE_URL
+    endpoint = WS_PRIVATE_URL
+    # OKX closes connections with 4004 after 30 seconds without data
+    ROUTINE_CLOSE_CODES = BaseWebSocketWorker.ROUTINE_CLOSE_CODES | {4004}
+    LOGIN_TIMEOUT = 10  # seconds
+
+    channel_data = pyqtSignal(str, list)  # channel, data items
+
+    def __init__(self, config: OkxApiConfig, channels: list[dict], parent: QObject | None = None):
+        # Private channels are not per-pair, so the pair list stays empty
+        super().__init__([], parent)
+        self._config = config
+        self._channels = channels
+        self.endpoint = private_ws_url(config)
+        self._ws = None
+        self._listen_task: asyncio.Task | None = None
+
+    def _login_args(self) -> dict:
+        timestamp = str(int(time.time()))
+        return {
+            "apiKey": self._config.api_key,
+            "passphrase": self._config.passphrase,
+            "timestamp": timestamp,
+            "sign": sign_message(f"{timestamp}GET/users/self/verify", self._config.secret_key),
+        }
+
+    async def _connect_and_subscribe(self):
+        """Connect, log in and subscribe to the private channels."""
+        import websockets
+
+        await self._close_socket()
+
+        tuning = get_ws_tuning("okx_private")
+        self._ws = await websockets.connect(self.endpoint, **websockets_connect_options(tuning))
+        apply_socket_buffers(self._ws.transport.get_extra_info("socket"), tuning)
+        await self._ws.send(json.dumps({"op": "login", "args": [self._login_args()]}))
+
+        response = json.loads(await asyncio.wait_for(self._ws.recv(), self.LOGIN_TIMEOUT))
+        if response.get("event") != "login" or response.get("code") not in ("0", None):
+            raise OkxApiError(response.get("code", ""), response.get("msg", "Login failed"))
+
+        await self._ws.send(json.dumps({"op": "subscribe", "args": self._channels}))
+        self._connection_start_time = time.time()
+        self._last_message_time = time.time()
+        self._listen_task = asyncio.create_task(self._listen(self._ws))
+
+    async def _listen(self, ws):
+        import websockets
+
+        try:
+            async for message in ws:
+                self._last_message_time = time.time()
+                if message == "pong":
+                    self._on_pong()
+                    continue
+                self._dispatch_message(message)
+        except websockets.exceptions.ConnectionClosed as e:
+            # The base class reconnects once it sees the close code
+            logger.info(f"OKX private WebSocket closed: {e}")
+
+    def _handle_message(self, message: str):
+        try:
+            data = json.loads(message)
+        except json.JSONDecodeError:
+            return
+
+        if data.get("event") == "error":
+            self._last_error = f"{data.get('code')}: {data.get('msg')}"
+            logger.error(f"OKX private channel error: {self._last_error}")
+            return
+
+        if "data" in data:
+            channel = data.get("arg", {}).get("channel", "")
+            self.channel_data.emit(channel, data["data"])
+
+    def _server_close_code(self) -> int | None:
+        return getattr(self._ws, "close_code", None)
+
+    async def _update_subscriptions(self):
+        """Channels are fixed for the lifetime of the worker."""
+        pass
+
+    async def _send_ping(self):
+        if self._ws is not None:
+            try:
+                await self._ws.send("ping")
+            except Exception as e:
+                logger.debug(f"Failed to send ping: {e}")
+
+    async def _close_socket(self):
+        if self._listen_task is not None:
+            self._listen_task.cancel()
+            self._listen_task = None
+        if self._ws is not None:
+            try:
+                await self._ws.close()
+            except Exception:
+                pass
+            self._ws = None
+
+
+class OkxAccountService(QObject):
+    """
+    Keeps an up-to-date view of the OKX account.
+
+    Loads a full snapshot over REST, then applies pushes from the private
+    WebSocket. A periodic REST refresh reconciles any missed updates.
+    """
+
+    account_updated = pyqtSignal(object)  # AccountSnapshot
+    channel_data = pyqtSignal(str, list)  # Raw private channel pushes, for other features
+    error_occurred = pyqtSignal(str)
+    # Risky permissions of the configured key, found when monitoring starts
+    key_warning = pyqtSignal(list)
+    # Result of verify_key(): risky permissions, or the error of the test call
+    key_checked = pyqtSignal(list)
+    key_check_failed = pyqtSignal(str)
+
+    _rest_loaded = pyqtSignal(list, list)  # balance data, position data (to main thread)
+
+    REFRESH_INTERVAL_MS = 5 * 60 * 1000
+    CHANNELS = [
+        {"channel": "account"},
+        {"channel": "positions", "instType": "ANY"},
+        {"channel": "orders", "instType": "ANY"},
+    ]
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._worker: OkxPrivateWebSocketWorker | None = None
+        self._snapshot = AccountSnapshot()
+        self._positions: dict[str, AccountPosition] = {}
+        self._refreshing = False
+
+        self._rest_loaded.connect(self._apply_rest_data)
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.REFRESH_INTERVAL_MS)
+        self._timer.timeout.connect(self.refresh)
+
+    @property
+    def is_configured(self) -> bool:
+        return self._settings_manager.settings.okx_api.is_configured()
+
+    @property
+    def snapshot(self) -> AccountSnapshot:
+        return self._snapshot
+
+    def start(self):
+        """Start account monitoring if credentials are configured."""
+        if not self.is_configured or self._worker is not None:
+            return
+
+        config = self._settings_manager.settings.okx_api
+        self.refresh()
+        self._start_key_check(config, automatic=True)
+
+        self._worker = OkxPrivateWebSocketWorker(config, self.get_channels(), self)
+        self._worker.channel_data.connect(self._on_channel_data)
+        WorkerController.get_instance().register_worker(self._worker)
+        self._worker.start()
+        self._timer.start()
+        logger.info(f"OKX account monitoring started{' (demo trading)' if config.demo else ''}")
+
+    def stop(self):
+        """Stop account monitoring."""
+        self._timer.stop()
+        if self._worker is not None:
+            WorkerController.get_instance().stop_worker(self._worker)
+            self._worker = None
+
+    def restart(self):
+        """Apply changed credentials."""
+        self.stop()
+        self._snapshot = AccountSnapshot()
+        self._positions.clear()
+        self.start()
+
+    def get_channels(self) -> list[dict]:
+        """Private channels to subscribe to."""
+        return list(self.CHANNELS)
+
+    def verify_key(self, config: OkxApiConfig):
+        """Check the permissions of a key with a test call; the result arrives as key_checked."""
+        self._start_key_check(config, automatic=False)
+
+    def _start_key_check(self, config: OkxApiConfig, automatic: bool):
+        threading.Thread(target=self._check_key, args=(config, automatic), daemon=True).start()
+
+    def _check_key(self, config: OkxApiConfig, automatic: bool):
+        try:
+            data = OkxRestClient(config).get_account_config()
+        except Exception as e:
+            logger.warning(f"Failed to check OKX API key permissions: {e}")
+            if not automatic:
+                self.key_check_failed.emit(str(e))
+            return
+
+        permissions = parse_permissions(data[0].get("perm", "") if data else "")
+        risky = risky_permissions(permissions, config.trading_enabled)
+        if risky:
+            logger.warning(f"OKX API key has unneeded permissions: {', '.join(risky)}")
+        if automatic:
+            if risky:
+                self.key_warning.emit(risky)
+        else:
+            self.key_checked.emit(risky)
+
+    def refresh(self):
+        """Reload balances and positions over REST in a background thread."""
+        if self._refreshing or not self.is_configured:
+            return
+        self._refreshing = True
+        threading.Thread(target=self._fetch_rest, daemon=True).start()
+
+    def _fetch_rest(self):
+        try:
+            client = OkxRestClient(self._settings_manager.settings.okx_api)
+            self._rest_loaded.emit(client.get_balance(), client.get_positions())
+        except Exception as e:
+            logger.error(f"Failed to load OKX account: {e}")
+            self.error_occurred.emit(str(e))
+        finally:
+            self._refreshing = False
+
+    def _apply_rest_data(self, balance_data: list, position_data: list):
+        if balance_data:
+            self._apply_account(balance_data[0])
+        self._positions = {}
+        self._apply_positions(position_data)
+        self._emit_snapshot()
+
+    def _on_channel_data(self, channel: str, data: list):
+        if channel == "account" and data:
+            self._apply_account(data[0])
+            self._emit_snapshot()
+        elif channel == "positions":
+            self._apply_positions(data)
+            self._emit_snapshot()
+        self.channel_data.emit(channel, data)
+
+    def _apply_account(self, item: dict):
+        total_eq, margin_ratio, balances = parse_account(item)
+        self._snapshot.total_equity_usd = total_eq
+        self._snapshot.margin_ratio = margin_ratio
+        self._snapshot.balances = balances
+
+    def _apply_positions(self, data: list):
+        for item in data:
+            position = parse_position(item)
+            if position.size == 0:
+                # Closed positions are pushed once with a zero size
+                self._positions.pop(position.position_id, None)
+            else:
+                self._positions[position.position_id] = position
+
+    def _emit_snapshot(self):
+        self._snapshot.positions = list(self._positions.values())
+        self._snapshot.updated_at = time.time()
+        self.account_updated.emit(self._snapshot)
+
+
+# Global OKX account service instance
+_okx_account_service: OkxAccountService | None = None
+
+
+def get_okx_account_service() -> OkxAccountService:
+    """Get the global OKX account service instance."""
+    global _okx_account_service
+    if _okx_account_service is None:
+        _okx_account_service = OkxAccountService()
+    return _okx_account_service
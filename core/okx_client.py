@@ -18,9 +18,19 @@ try:
 except ImportError:
     WsPublicAsync = None
 
+from config.settings import WsConnectionTuning, get_settings_manager
 from core.base_client import BaseExchangeClient
+from core.messages import message
 from core.models import TickerData
-from core.utils.network import get_aiohttp_proxy_url, get_proxy_config
+from core.rate_limiter import get_rate_limiter
+from core.sequence_tracker import SequenceGap
+from core.utils.network import (
+    apply_socket_buffers,
+    get_aiohttp_proxy_url,
+    get_proxy_config,
+    get_ws_tuning,
+    websockets_connect_options,
+)
 from core.websocket_worker import BaseWebSocketWorker
 from core.worker_controller import WorkerController
 
@@ -36,6 +46,9 @@ class OkxWebSocketWorker(BaseWebSocketWorker):
 
     # OKX WebSocket URL
     WS_PUBLIC_URL = "wss://ws.okx.com:8443/ws/v5/public"
+    endpoint = WS_PUBLIC_URL
+    # OKX closes connections with 4004 after 30 seconds without data
+    ROUTINE_CLOSE_CODES = BaseWebSocketWorker.ROUTINE_CLOSE_CODES | {4004}
 
     def __init__(self, pairs: list[str], parent: QObject | None = None):
         super().__init__(pairs, parent)
@@ -74,6 +87,7 @@ class OkxWebSocketWorker(BaseWebSocketWorker):
 
         try:
             proxy_url = get_aiohttp_proxy_url()
+            await get_rate_limiter().acquire_async("OKX")
 
             async with aiohttp.ClientSession(trust_env=True) as session:
                 async with session.get(url, params=params, proxy=proxy_url) as response:
@@ -101,12 +115,16 @@ class OkxWebSocketWorker(BaseWebSocketWorker):
 
     async def _connect_and_subscribe(self):
         """Connect to OKX WebSocket and subscribe to ticker channels."""
-        if WsPublicAsync is None:
-            # Fallback: use simple websocket implementation
-            await self._simple_websocket_subscribe()
+        tuning = get_ws_tuning("okx")
+        if WsPublicAsync is None or tuning != WsConnectionTuning():
+            # Fallback: use simple websocket implementation, also when the
+            # transport is tuned since python-okx takes no connection options
+            await self._close_sdk_client()
+            await self._simple_websocket_subscribe(tuning)
             return
 
         try:
+            await self._close_sdk_client()
             self._ws_client = WsPublicAsync(self.WS_PUBLIC_URL)
             await self._ws_client.start()
             self._connection_start_time = time.time()
@@ -118,26 +136,43 @@ class OkxWebSocketWorker(BaseWebSocketWorker):
             self._last_error = str(e)
             raise
 
+    async def _close_sdk_client(self):
+        """Close the socket of the previous python-okx client, if any."""
+        ws = getattr(self._ws_client, "websocket", None)
+        self._ws_client = None
+        if ws is not None:
+            try:
+                await ws.close()
+            except Exception:
+                pass
+
+    def _server_close_code(self) -> int | None:
+        ws = getattr(self._ws_client, "websocket", None)
+        return getattr(ws, "close_code", None)
+
     async def _update_subscriptions(self):
         """Update subscriptions incrementally (only changed pairs)."""
         current_pairs = set(self.pairs)
         new_pairs = current_pairs - self._subscribed_pairs
         removed_pairs = self._subscribed_pairs - current_pairs
 
-        if WsPublicAsync is None:
+        if self._ws_client is None:
             # Simple websocket implementation
             return
 
         # Subscribe to new pairs
         if new_pairs:
-            args = [{"channel": "tickers", "instId": pair} for pair in new_pairs]
-            await self._ws_client.subscribe(args, self._handle_message)
+            await self._send_batched(
+                self._subscription_args(new_pairs),
+                lambda args: self._ws_client.subscribe(args, self._dispatch_message),
+            )
 
         # Unsubscribe from removed pairs
         if removed_pairs:
-            args = [{"channel": "tickers", "instId": pair} for pair in removed_pairs]
             try:
-                await self._ws_client.unsubscribe(args)
+                await self._send_batched(
+                    self._subscription_args(removed_pairs), self._ws_client.unsubscribe
+                )
             except Exception:
                 # If unsubscribe fails, just ignore - will be cleaned up on reconnect
                 pass
@@ -146,55 +181,87 @@ class OkxWebSocketWorker(BaseWebSocketWorker):
         self._subscribed_pairs = current_pairs
         self._update_stats()
 
-    async def _simple_websocket_subscribe(self):
+    @staticmethod
+    def _subscription_args(pairs) -> list[dict]:
+        """Ticker and trades channels of the given pairs."""
+        return [
+            {"channel": channel, "instId": pair}
+            for pair in pairs
+            for channel in ("tickers", "trades")
+        ]
+
+    async def _simple_websocket_subscribe(self, tuning: WsConnectionTuning):
         """Simple WebSocket implementation without python-okx dependency."""
         import websockets
 
         try:
-            async with websockets.connect(self.WS_PUBLIC_URL) as ws:
-                self._simple_ws = ws
-                self.connection_status.emit(True, "Connected to OKX")
-
-                # We need to expose ws for update_subscriptions?
-                # The original simplified implementation didn't support incremental updates
-                # fully inside simple mode gracefully or it re-sent list.
-                # Original logic:
-                # subscribe_msg = ...
-                # await ws.send(...)
-                # while self._running: ...
-
-                # To support incremental updates here we'd need more complex logic.
-                # For refactoring, I should preserve original behavior.
-                # Original behavior:
-                # Just subscribed once at start.
-
-                subscribe_msg = {
-                    "op": "subscribe",
-                    "args": [{"channel": "tickers", "instId": pair} for pair in self.pairs],
-                }
-                await ws.send(json.dumps(subscribe_msg))
-
-                # Listen for messages
-                while self._running:
-                    try:
-                        message = await asyncio.wait_for(ws.recv(), timeout=1.0)
-                        self._handle_message(message)
-                    except asyncio.TimeoutError:
-                        continue
-                    except websockets.exceptions.ConnectionClosed:
-                        self.connection_status.emit(False, "Connection closed")
-                        break
+            while self._running:
+                # A new connection starts without sequences, as in the base loop
+                self._sequences.reset()
+                async with websockets.connect(
+                    self.WS_PUBLIC_URL, **websockets_connect_options(tuning)
+                ) as ws:
+                    apply_socket_buffers(ws.transport.get_extra_info("socket"), tuning)
+                    self._simple_ws = ws
+                    self.connection_status.emit(True, message("connected_to", exchange="OKX"))
+
+                    # We need to expose ws for update_subscriptions?
+                    # The original simplified implementation didn't support incremental updates
+                    # fully inside simple mode gracefully or it re-sent list.
+                    # Original logic:
+                    # subscribe_msg = ...
+                    # await ws.send(...)
+                    # while self._running: ...
+
+                    # To support incremental updates here we'd need more complex logic.
+                    # For refactoring, I should preserve original behavior.
+                    # Original behavior:
+                    # Just subscribed once at start.
+
+                    await self._send_batched(
+                        self._subscription_args(self.pairs),
+                        lambda args: ws.send(json.dumps({"op": "subscribe", "args": args})),
+                    )
+
+                    close_code = await self._simple_listen(ws)
+
+                if close_code in self.ROUTINE_CLOSE_CODES:
+                    # Resubscribed in place, like the SDK mode does in the base loop
+                    logger.info(f"OKX closed the connection ({close_code}), resubscribing")
+                    self._total_reconnect_count += 1
+                    continue
+                if close_code is not None:
+                    self.connection_status.emit(False, message("connection_closed"))
+                break
         except Exception as e:
-            self.connection_status.emit(False, f"WebSocket error: {e}")
+            self.connection_status.emit(False, message("websocket_error", error=e))
         finally:
             self._simple_ws = None
 
+    async def _simple_listen(self, ws) -> int | None:
+        """Listen for messages; returns the close code, or None when stopped."""
+        import websockets
+
+        while self._running:
+            try:
+                raw_message = await asyncio.wait_for(ws.recv(), timeout=1.0)
+                self._dispatch_message(raw_message)
+            except asyncio.TimeoutError:
+                continue
+            except websockets.exceptions.ConnectionClosed as e:
+                return e.rcvd.code if e.rcvd is not None else 1006
+        return None
+
     def _handle_message(self, message):
         """Handle incoming WebSocket message."""
         try:
             # Update last message time for heartbeat detection
             self._last_message_time = time.time()
 
+            if message == "pong":
+                self._on_pong()
+                return
+
             if isinstance(message, str):
                 data = json.loads(message)
             elif isinstance(message, bytes):
@@ -203,7 +270,7 @@ class OkxWebSocketWorker(BaseWebSocketWorker):
                 data = message
 
             # Update statistics
-            self._update_stats()
+            self._update_stats_throttled()
 
             # Skip non-data messages (like subscription confirmations)
             if "data" not in data:
@@ -213,27 +280,24 @@ class OkxWebSocketWorker(BaseWebSocketWorker):
                     return
                 return
 
-            for ticker in data.get("data", []):
+            if data.get("arg", {}).get("channel") == "trades":
+                self._handle_trades(data["arg"].get("instId", ""), data.get("data", []))
+                return
+
+            # Looked up once per message rather than per ticker; OKX sends the
+            # rolling 24h open as open24h and the UTC/UTC+8 day opens as sodUtc0/sodUtc8.
+            # Local midnight is computed from candles by the controller.
+            basis = get_settings_manager().settings.price_change_basis
+            open_key = {"utc_0": "sodUtc0", "utc_8": "sodUtc8"}.get(basis, "open24h")
+
+            for ticker in data["data"]:
                 pair = ticker.get("instId", "")
                 last_price = ticker.get("last", "0")
-                sod_utc0 = ticker.get("sodUtc0", "0")
 
                 # Calculate percentage
                 try:
-                    from config.settings import get_settings_manager
-
-                    settings = get_settings_manager().settings
-                    basis = settings.price_change_basis
-
                     last = float(last_price)
-
-                    if basis == "utc_0":
-                        open_price = float(sod_utc0)
-                    else:
-                        # For 24h rolling, we need open24h.
-                        # open24h is explicitly available in OKX ticker channel as 'open24h'
-                        open_price_str = ticker.get("open24h", "0")
-                        open_price = float(open_price_str)
+                    open_price = float(ticker.get(open_key, "0"))
 
                     if open_price > 0:
                         pct = (last - open_price) / open_price * 100
@@ -257,8 +321,8 @@ class OkxWebSocketWorker(BaseWebSocketWorker):
                     quote_volume_24h=quote_volume,
                 )
 
-                # Emit signal (thread-safe)
-                self.ticker_updated.emit(pair, ticker_obj)
+                # Buffered for the main thread (drop-oldest)
+                self._emit_ticker(pair, ticker_obj)
 
         except json.JSONDecodeError:
             pass
@@ -267,6 +331,43 @@ class OkxWebSocketWorker(BaseWebSocketWorker):
             logger.error(f"Error handling message: {e}")
             self._update_stats()
 
+    def _handle_trades(self, pair: str, trades: list[dict]):
+        parsed = []
+        for trade in trades:
+            try:
+                parsed.append((float(trade["px"]), float(trade["sz"]), int(trade["ts"]) / 1000))
+            except (KeyError, ValueError):
+                continue
+            try:
+                # tradeId is the last of the "count" trades aggregated into the push
+                last = int(trade["tradeId"])
+                first = last - max(int(trade.get("count") or 1), 1) + 1
+            except (KeyError, ValueError):
+                continue
+            if pair:
+                self._track_sequence("trades", pair, first, last)
+        if pair and parsed:
+            self.trades_received.emit(pair, parsed)
+
+    async def _fetch_missed_trades(self, gap: SequenceGap) -> list[tuple[float, float, float]]:
+        """Trades of the gap from the trade history, newest 100 before the gap's end."""
+        url = "https://www.okx.com/api/v5/market/history-trades"
+        params = {"instId": gap.pair, "type": "1", "after": str(gap.received), "limit": "100"}
+        proxy_url = get_aiohttp_proxy_url()
+        await get_rate_limiter().acquire_async("OKX")
+
+        async with aiohttp.ClientSession(trust_env=True) as session:
+            async with session.get(url, params=params, proxy=proxy_url) as response:
+                data = await response.json()
+        if data.get("code") != "0":
+            raise RuntimeError(data.get("msg") or data.get("code"))
+
+        trades = []
+        for item in reversed(data.get("data", [])):
+            if int(item["tradeId"]) >= gap.expected:
+                trades.append((float(item["px"]), float(item["sz"]), int(item["ts"]) / 1000))
+        return trades
+
     def update_pairs(self, pairs: list[str]):
         """Update subscription pairs (requires reconnection or incremental)."""
         self.pairs = pairs
@@ -344,6 +445,8 @@ class OkxClientManager(BaseExchangeClient):
         self._worker.connection_state_changed.connect(self.connection_state_changed)
         self._worker.stats_updated.connect(self.stats_updated)
         self._worker.klines_ready.connect(self.klines_ready)
+        self._worker.trades_received.connect(self.trades_received)
+        self._worker.data_integrity.connect(self.data_integrity)
 
         WorkerController.get_instance().register_worker(self._worker)
         self._worker.start()
@@ -401,6 +504,7 @@ class OkxClientManager(BaseExchangeClient):
                 "state": state,
                 "subscribed_pairs": len(self._pairs),
                 "worker_running": self._worker.isRunning(),
+                "dropped_tickers": self._worker.dropped_tickers,
             }
         return None
 
@@ -430,6 +534,7 @@ class OkxClientManager(BaseExchangeClient):
 
         try:
             proxies = get_proxy_config()
+            get_rate_limiter().acquire("OKX")
             response = requests.get(url, params=params, proxies=proxies, timeout=5)
             response.raise_for_status()
             data = response.json()
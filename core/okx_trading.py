@@ -0,0 +1,156 @@
+"""
+Order placement via the OKX trade API.
+Opt-in: every request is refused unless trading is enabled in settings,
+which acts as a global safety switch.
+"""
+
+import logging
+import threading
+from dataclasses import dataclass
+
+from PyQt6.QtCore import QObject, pyqtSignal
+
+from config.settings import get_settings_manager
+from core.okx_account import OkxApiError, OkxRestClient
+
+logger = logging.getLogger(__name__)
+
+ORDER_TYPES = ("limit", "market")
+
+
+class TradingDisabledError(Exception):
+    """Raised when an order is attempted while the safety switch is off."""
+
+
+@dataclass
+class OrderRequest:
+    """A simple spot order."""
+
+    inst_id: str  # e.g., "BTC-USDT"
+    side: str  # "buy" | "sell"
+    order_type: str  # "limit" | "market"
+    size: float  # Base asset amount (quote amount for market buys)
+    price: float | None = None  # Required for limit orders
+    td_mode: str = "cash"  # Trade mode; "cash" for spot without margin
+
+
+def validate_order(request: OrderRequest) -> str | None:
+    """
+    Check an order for obvious mistakes before sending it.
+
+    Returns:
+        An error message, or None if the order looks valid
+    """
+    if not request.inst_id:
+        return "Missing instrument"
+    if request.side not in ("buy", "sell"):
+        return f"Invalid side: {request.side}"
+    if request.order_type not in ORDER_TYPES:
+        return f"Invalid order type: {request.order_type}"
+    if request.size <= 0:
+        return "Size must be positive"
+    if request.order_type == "limit" and (request.price is None or request.price <= 0):
+        return "Limit orders need a positive price"
+    return None
+
+
+def _format_number(value: float) -> str:
+    """Format without exponent or trailing zeros, as OKX expects decimal strings."""
+    return f"{value:.12f}".rstrip("0").rstrip(".")
+
+
+def build_order_body(request: OrderRequest) -> dict:
+    """Build the POST /api/v5/trade/order body."""
+    body = {
+        "instId": request.inst_id,
+        "tdMode": request.td_mode,
+        "side": request.side,
+        "ordType": request.order_type,
+        "sz": _format_number(request.size),
+    }
+    if request.order_type == "limit":
+        body["px"] = _format_number(request.price)
+    return body
+
+
+def _check_result(data: list[dict]) -> dict:
+    """OKX reports per-order failures in sCode/sMsg even when code is "0"."""
+    result = data[0] if data else {}
+    if result.get("sCode", "0") != "0":
+        raise OkxApiError(result.get("sCode", ""), result.get("sMsg", ""))
+    return result
+
+
+class OkxTradingService(QObject):
+    """
+    Places and cancels orders in background threads.
+
+    Both methods re-check the safety switch, so a disabled switch blocks
+    orders even if a UI forgets to.
+    """
+
+    order_placed = pyqtSignal(str, object)  # order_id, OrderRequest
+    order_cancelled = pyqtSignal(str, str)  # inst_id, order_id
+    error_occurred = pyqtSignal(str)
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+
+    @property
+    def trading_enabled(self) -> bool:
+        config = self._settings_manager.settings.okx_api
+        return config.is_configured() and config.trading_enabled
+
+    def _client(self) -> OkxRestClient:
+        if not self.trading_enabled:
+            raise TradingDisabledError("Trading is disabled in settings")
+        return OkxRestClient(self._settings_manager.settings.okx_api)
+
+    def place_order(self, request: OrderRequest):
+        """Validate and submit an order. Results arrive via signals."""
+        error = validate_order(request)
+        if error:
+            self.error_occurred.emit(error)
+            return
+        threading.Thread(target=self._place_order, args=(request,), daemon=True).start()
+
+    def _place_order(self, request: OrderRequest):
+        try:
+            data = self._client().request(
+                "POST", "/api/v5/trade/order", body=build_order_body(request)
+            )
+            order_id = _check_result(data).get("ordId", "")
+            logger.info(f"Order placed: {request.inst_id} {request.side} ({order_id})")
+            self.order_placed.emit(order_id, request)
+        except Exception as e:
+            logger.error(f"Failed to place order: {e}")
+            self.error_occurred.emit(str(e))
+
+    def cancel_order(self, inst_id: str, order_id: str):
+        """Cancel an open order. Results arrive via signals."""
+        threading.Thread(target=self._cancel_order, args=(inst_id, order_id), daemon=True).start()
+
+    def _cancel_order(self, inst_id: str, order_id: str):
+        try:
+            data = self._client().request(
+                "POST", "/api/v5/trade/cancel-order", body={"instId": inst_id, "ordId": order_id}
+            )
+            _check_result(data)
+            logger.info(f"Order cancelled: {inst_id} ({order_id})")
+            self.order_cancelled.emit(inst_id, order_id)
+        except Exception as e:
+            logger.error(f"Failed to cancel order: {e}")
+            self.error_occurred.emit(str(e))
+
+
+# Global trading service instance
+_trading_service: OkxTradingService | None = None
+
+
+def get_okx_trading_service() -> OkxTradingService:
+    """Get the global trading service instance."""
+    global _trading_service
+    if _trading_service is None:
+        _trading_service = OkxTradingService()
+    return _trading_service
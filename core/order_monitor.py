@@ -0,0 +1,140 @@
+"""
+Order and fill monitoring.
+Watches the OKX private orders channel and notifies when resting
+orders fill or are cancelled.
+"""
+
+import logging
+from dataclasses import dataclass
+
+from PyQt6.QtCore import QObject, pyqtSignal
+
+from config.settings import get_settings_manager
+from core.notifier import get_notification_service
+from core.okx_account import get_okx_account_service, parse_float
+
+logger = logging.getLogger(__name__)
+
+# Order states that end an order's life
+FINAL_STATES = {"filled", "canceled", "mmp_canceled"}
+
+
+@dataclass
+class OrderUpdate:
+    """One push from the orders channel."""
+
+    order_id: str
+    inst_id: str
+    side: str  # "buy" | "sell"
+    order_type: str  # "limit", "market", "post_only", ...
+    state: str  # "live" | "partially_filled" | "filled" | "canceled" | "mmp_canceled"
+    size: float  # Order size
+    filled_size: float  # Accumulated fill size
+    price: float | None  # Order price (None for market orders)
+    avg_price: float | None  # Average fill price
+
+    @property
+    def is_final(self) -> bool:
+        return self.state in FINAL_STATES
+
+
+def parse_order(item: dict) -> OrderUpdate:
+    """Parse one entry of the orders channel."""
+    return OrderUpdate(
+        order_id=item.get("ordId", ""),
+        inst_id=item.get("instId", ""),
+        side=item.get("side", ""),
+        order_type=item.get("ordType", ""),
+        state=item.get("state", ""),
+        size=parse_float(item.get("sz")) or 0.0,
+        filled_size=parse_float(item.get("accFillSz")) or 0.0,
+        price=parse_float(item.get("px")),
+        avg_price=parse_float(item.get("avgPx")),
+    )
+
+
+def classify_order_event(previous: OrderUpdate | None, current: OrderUpdate) -> str | None:
+    """
+    Decide which notification an order update warrants.
+
+    Returns:
+        "filled", "partially_filled", "canceled" or None if nothing noteworthy happened
+    """
+    if previous is not None and previous.state == current.state:
+        if current.state != "partially_filled" or current.filled_size <= previous.filled_size:
+            return None
+
+    if current.state == "filled":
+        return "filled"
+    if current.state == "partially_filled":
+        return "partially_filled"
+    if current.state in ("canceled", "mmp_canceled"):
+        return "canceled"
+    return None
+
+
+class OrderMonitor(QObject):
+    """
+    Tracks open orders from the private orders channel.
+
+    Repeated pushes for an unchanged order state are ignored, so each
+    fill or cancellation is notified once.
+    """
+
+    order_event = pyqtSignal(str, object)  # event ("filled", ...), OrderUpdate
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._notification_service = get_notification_service()
+        self._orders: dict[str, OrderUpdate] = {}
+
+        get_okx_account_service().channel_data.connect(self._on_channel_data)
+
+    def get_open_orders(self) -> list[OrderUpdate]:
+        """Get orders currently resting on the book."""
+        return list(self._orders.values())
+
+    def _on_channel_data(self, channel: str, data: list):
+        if channel != "orders":
+            return
+        for item in data:
+            self.handle_update(parse_order(item))
+
+    def handle_update(self, order: OrderUpdate):
+        """Process one order update."""
+        previous = self._orders.get(order.order_id)
+        event = classify_order_event(previous, order)
+
+        if order.is_final:
+            self._orders.pop(order.order_id, None)
+        else:
+            self._orders[order.order_id] = order
+
+        if event is None:
+            return
+
+        logger.info(f"Order {order.order_id} {order.inst_id}: {event}")
+        self.order_event.emit(event, order)
+
+        if self._settings_manager.settings.okx_api.notify_orders:
+            self._notification_service.send_order_update(
+                event=event,
+                inst_id=order.inst_id,
+                side=order.side,
+                size=order.size,
+                filled_size=order.filled_size,
+                price=order.avg_price or order.price,
+            )
+
+
+# Global order monitor instance
+_order_monitor: OrderMonitor | None = None
+
+
+def get_order_monitor() -> OrderMonitor:
+    """Get the global order monitor instance."""
+    global _order_monitor
+    if _order_monitor is None:
+        _order_monitor = OrderMonitor()
+    return _order_monitor
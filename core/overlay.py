@@ -0,0 +1,119 @@
+"""
+Streaming overlay.
+Adds a minimal price overlay page to the local API, for streamers to embed the
+watched pairs in OBS as a browser source. The page updates itself from
+server-sent events.
+
+    GET /overlay          Overlay page; ?pairs=BTC-USDT,ETH-USDT limits the pairs shown
+    GET /overlay/events   Event stream of the pairs' quotes, one JSON list per update
+"""
+
+import json
+import time
+
+from core.local_api import ApiRequest, ApiResponse, LocalApiServer, get_local_api_server
+
+PUSH_INTERVAL = 1.0  # seconds between checks for new quotes
+KEEPALIVE_INTERVAL = 15.0  # seconds of silence before a comment keeps the connection open
+
+# The page is transparent, so only the prices show over the stream. Its elements
+# have classes (.pair, .name, .price, .change.up/.down) for OBS's custom CSS.
+OVERLAY_PAGE = """<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Crypto Monitor Overlay</title>
+<style>
+  body { margin: 0; background: transparent; font-family: "Segoe UI", sans-serif; }
+  #pairs { display: flex; gap: 24px; padding: 8px 12px; color: #fff;
+           text-shadow: 0 1px 3px rgba(0, 0, 0, 0.8); font-size: 22px; }
+  .name { font-weight: 600; margin-right: 6px; }
+  .change { margin-left: 6px; }
+  .up { color: #4caf50; }
+  .down { color: #f44336; }
+</style>
+</head>
+<body>
+<div id="pairs"></div>
+<script>
+  const container = document.getElementById("pairs");
+  const source = new EventSource("/overlay/events" + location.search);
+  source.onmessage = (event) => {
+    container.replaceChildren(...JSON.parse(event.data).map((quote) => {
+      const item = document.createElement("span");
+      item.className = "pair";
+      for (const [cls, text] of [["name", quote.name], ["price", quote.price_text],
+                                 ["change", quote.change_text]]) {
+        const part = document.createElement("span");
+        part.className = cls;
+        part.textContent = text;
+        item.appendChild(part);
+      }
+      const change = item.lastChild;
+      if (quote.change_24h > 0) change.classList.add("up");
+      if (quote.change_24h < 0) change.classList.add("down");
+      return item;
+    }));
+  };
+</script>
+</body>
+</html>
+"""
+
+
+def overlay_event(quotes: list[dict]) -> str:
+    """A server-sent event carrying quotes."""
+    return f"data: {json.dumps(quotes, ensure_ascii=False)}\n\n"
+
+
+class OverlayServer:
+    """Serves the overlay page and streams quotes to it."""
+
+    def __init__(self, server: LocalApiServer):
+        self._server = server
+        server.add_route("GET", "/overlay", self._get_page)
+        server.add_route("GET", "/overlay/events", self._get_events)
+
+    def _selected_quotes(self, pairs: list[str]) -> list[dict]:
+        quotes = self._server.quotes()
+        if not pairs:
+            return quotes
+        by_pair = {quote["pair"]: quote for quote in quotes}
+        return [by_pair[pair] for pair in pairs if pair in by_pair]
+
+    def _get_page(self, request: ApiRequest) -> ApiResponse:
+        return ApiResponse(200, OVERLAY_PAGE, content_type="text/html")
+
+    def _get_events(self, request: ApiRequest) -> ApiResponse:
+        pairs = [pair.strip().upper() for pair in request.query.get("pairs", "").split(",")]
+        return ApiResponse(200, events=self._events([pair for pair in pairs if pair]))
+
+    def _events(self, pairs: list[str]):
+        """Send the quotes whenever they change, until the server stops."""
+        sent = None
+        idle = 0.0
+        while True:
+            quotes = self._selected_quotes(pairs)
+            if quotes != sent:
+                yield overlay_event(quotes)
+                sent = quotes
+                idle = 0.0
+            elif idle >= KEEPALIVE_INTERVAL:
+                yield ": keepalive\n\n"
+                idle = 0.0
+            time.sleep(PUSH_INTERVAL)
+            idle += PUSH_INTERVAL
+            if not self._server.is_running:
+                return
+
+
+# Global overlay server instance
+_overlay_server: OverlayServer | None = None
+
+
+def get_overlay_server() -> OverlayServer:
+    """Get the global overlay server instance, serving on the local API."""
+    global _overlay_server
+    if _overlay_server is None:
+        _overlay_server = OverlayServer(get_local_api_server())
+    return _overlay_server
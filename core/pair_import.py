@@ -0,0 +1,80 @@
+"""
+Bulk import of trading pairs.
+Parses a pasted list or text file of symbols in common notations
+("BTC-USDT", "BTCUSDT", "btc/usdt", "ETH_USDC" or just "SOL"), resolves them
+against the exchange's instrument list and reports what can't be added.
+"""
+
+import re
+from dataclasses import dataclass, field
+
+from config.settings import SPECIAL_PAIR_PREFIXES, normalize_pair
+from core.symbol_search import SymbolSearchService
+
+# Separators between symbols: whitespace, commas and semicolons
+_SEPARATORS = re.compile(r"[\s,;]+")
+
+# Quote assets recognized at the end of an unseparated symbol, e.g. "ETHBTC"
+_QUOTE_SUFFIXES = ("USDT", "USDC", "FDUSD", "BUSD", "USD", "EUR", "BTC", "ETH", "BNB")
+
+
+@dataclass
+class PairImport:
+    """Outcome of resolving a pasted pair list."""
+
+    valid: list[str] = field(default_factory=list)  # New pairs, in input order
+    duplicates: list[str] = field(default_factory=list)  # Already monitored or repeated
+    rejected: list[str] = field(default_factory=list)  # Not listed on the exchange
+
+
+def split_pair_list(text: str) -> list[str]:
+    """Symbols of a pasted list or file, ignoring "#" comments."""
+    tokens = []
+    for line in text.splitlines():
+        line = line.split("#", 1)[0]
+        tokens.extend(token for token in _SEPARATORS.split(line) if token)
+    return tokens
+
+
+def resolve_symbol(token: str, search: SymbolSearchService) -> str | None:
+    """Listed pair a symbol refers to, or None if the exchange doesn't list it."""
+    if token.lower().startswith(SPECIAL_PAIR_PREFIXES):
+        # On-chain, virtual and index pairs aren't exchange instruments
+        return normalize_pair(token)
+    symbol = token.upper().replace("/", "-").replace("_", "-")
+    pair = search.format_symbol(symbol)
+    if pair is not None or "-" in symbol:
+        return pair
+    for quote in _QUOTE_SUFFIXES:
+        # Exchanges with dashed instrument names don't list "BTCUSDT" as such
+        if symbol.endswith(quote) and len(symbol) > len(quote):
+            pair = search.format_symbol(f"{symbol[: -len(quote)]}-{quote}")
+            if pair is not None:
+                return pair
+    # A bare asset resolves to its USDT/USDC pair
+    return search.find_pair(symbol)
+
+
+def resolve_pair_list(
+    text: str, search: SymbolSearchService, existing: list[str]
+) -> PairImport:
+    """
+    Resolve a pasted pair list against the loaded instrument list.
+
+    Args:
+        text: Symbols separated by new lines, spaces, commas or semicolons
+        search: Symbol search service with the data source's symbols loaded
+        existing: Pairs already monitored
+    """
+    result = PairImport()
+    seen = set(existing)
+    for token in split_pair_list(text):
+        pair = resolve_symbol(token, search)
+        if pair is None:
+            result.rejected.append(token)
+        elif pair in seen:
+            result.duplicates.append(pair)
+        else:
+            seen.add(pair)
+            result.valid.append(pair)
+    return result
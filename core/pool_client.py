@@ -0,0 +1,319 @@
+"""
+On-chain pool price source.
+Reads the price of Uniswap v3 style pools (Uniswap, PancakeSwap v3 and
+forks) straight from the chain over JSON-RPC, so tokens without a CEX
+listing or DexScreener coverage can be monitored.
+
+A pool is stored in the pair list as "pool:<network>:<address>", with an
+":inverse" suffix to quote token0 in token1's terms the other way round.
+"""
+
+import logging
+import threading
+from dataclasses import dataclass
+from datetime import datetime, timezone
+
+import requests
+from PyQt6.QtCore import QTimer
+
+from config.settings import get_settings_manager
+from core.base_client import BaseExchangeClient
+from core.messages import message
+from core.models import TickerData
+from core.utils.network import get_proxy_config
+
+logger = logging.getLogger(__name__)
+
+POOL_PREFIX = "pool:"
+
+# Public endpoints used when the user has not configured one for the network
+DEFAULT_RPC_URLS = {
+    "ethereum": "https://ethereum-rpc.publicnode.com",
+    "bsc": "https://bsc-rpc.publicnode.com",
+    "base": "https://base-rpc.publicnode.com",
+    "arbitrum": "https://arbitrum-one-rpc.publicnode.com",
+    "polygon": "https://polygon-bor-rpc.publicnode.com",
+}
+
+# Function selectors
+TOKEN0_SELECTOR = "0x0dfe1681"
+TOKEN1_SELECTOR = "0xd21220a7"
+SLOT0_SELECTOR = "0x3850c7bd"
+DECIMALS_SELECTOR = "0x313ce567"
+SYMBOL_SELECTOR = "0x95d89b41"
+
+Q96 = 2**96
+
+
+class RpcError(Exception):
+    """A JSON-RPC request failed or returned an error."""
+
+
+@dataclass
+class PoolInfo:
+    """Static token data of a pool."""
+
+    token0: str
+    token1: str
+    symbol0: str
+    symbol1: str
+    decimals0: int
+    decimals1: int
+
+
+def is_pool_pair(pair: str) -> bool:
+    return pair.lower().startswith(POOL_PREFIX)
+
+
+def make_pool_pair(network: str, address: str, inverse: bool = False) -> str:
+    """Build the pair ID of a pool."""
+    pair = f"{POOL_PREFIX}{network.strip().lower()}:{address.strip().lower()}"
+    return f"{pair}:inverse" if inverse else pair
+
+
+def parse_pool_pair(pair: str) -> tuple[str, str, bool]:
+    """
+    Split a pool pair ID.
+
+    Returns:
+        (network, address, inverse)
+    """
+    parts = pair.split(":")
+    network = parts[1] if len(parts) > 1 else ""
+    address = parts[2] if len(parts) > 2 else ""
+    return network, address, len(parts) > 3 and parts[3] == "inverse"
+
+
+def get_rpc_url(network: str) -> str:
+    """RPC endpoint of a network: the configured one, else the public default."""
+    configured = get_settings_manager().settings.rpc_endpoints.get(network, "")
+    return configured or DEFAULT_RPC_URLS.get(network, "")
+
+
+def sqrt_price_to_price(sqrt_price_x96: int, decimals0: int, decimals1: int) -> float:
+    """Price of token0 in token1 from a pool's sqrtPriceX96."""
+    return (sqrt_price_x96 / Q96) ** 2 * 10 ** (decimals0 - decimals1)
+
+
+def decode_uint(result: str, index: int = 0) -> int:
+    """The index-th 32-byte word of an ABI-encoded result as an integer."""
+    data = result.removeprefix("0x")
+    word = data[64 * index : 64 * (index + 1)]
+    if len(word) != 64:
+        raise RpcError("Result too short")
+    return int(word, 16)
+
+
+def decode_address(result: str) -> str:
+    return "0x" + result.removeprefix("0x")[24:64]
+
+
+def decode_string(result: str) -> str:
+    """ABI-encoded string, or a bytes32 as returned by some older tokens (e.g. MKR)."""
+    data = bytes.fromhex(result.removeprefix("0x"))
+    if len(data) == 32:
+        return data.rstrip(b"\0").decode("utf-8", errors="ignore")
+    offset = int.from_bytes(data[:32], "big")
+    length = int.from_bytes(data[offset : offset + 32], "big")
+    return data[offset + 32 : offset + 32 + length].decode("utf-8", errors="ignore")
+
+
+def eth_call_batch(url: str, calls: list[tuple[str, str]]) -> list[str]:
+    """
+    Run several eth_call requests in one JSON-RPC batch.
+
+    Args:
+        calls: (contract address, call data) pairs
+
+    Raises:
+        RpcError: If the request or any call failed
+    """
+    payload = [
+        {
+            "jsonrpc": "2.0",
+            "id": i,
+            "method": "eth_call",
+            "params": [{"to": to, "data": data}, "latest"],
+        }
+        for i, (to, data) in enumerate(calls)
+    ]
+    try:
+        response = requests.post(url, json=payload, proxies=get_proxy_config(), timeout=10)
+        response.raise_for_status()
+        replies = response.json()
+    except Exception as e:
+        raise RpcError(str(e)) from e
+    if not isinstance(replies, list):
+        raise RpcError(str(replies.get("error", replies)))
+
+    results = {reply.get("id"): reply for reply in replies}
+    values = []
+    for i in range(len(calls)):
+        reply = results.get(i, {})
+        result = reply.get("result")
+        if not result or result == "0x":
+            raise RpcError(str(reply.get("error", "Empty result")))
+        values.append(result)
+    return values
+
+
+def fetch_pool_info(url: str, address: str) -> PoolInfo:
+    """Read the tokens of a pool with their symbols and decimals."""
+    token0, token1 = (
+        decode_address(r)
+        for r in eth_call_batch(url, [(address, TOKEN0_SELECTOR), (address, TOKEN1_SELECTOR)])
+    )
+    decimals0, symbol0, decimals1, symbol1 = eth_call_batch(
+        url,
+        [
+            (token0, DECIMALS_SELECTOR),
+            (token0, SYMBOL_SELECTOR),
+            (token1, DECIMALS_SELECTOR),
+            (token1, SYMBOL_SELECTOR),
+        ],
+    )
+    return PoolInfo(
+        token0=token0,
+        token1=token1,
+        symbol0=decode_string(symbol0),
+        symbol1=decode_string(symbol1),
+        decimals0=decode_uint(decimals0),
+        decimals1=decode_uint(decimals1),
+    )
+
+
+class DailyStats:
+    """
+    Open, high and low of polled prices since UTC midnight, for on-chain
+    sources that only expose the current price.
+    """
+
+    def __init__(self):
+        # pair -> {"day": UTC midnight, "open": float, "high": float, "low": float}
+        self._stats: dict[str, dict] = {}
+
+    def to_ticker(self, pair: str, price: float, base: str, quote: str) -> TickerData:
+        """Record a price and build its ticker, with the change since the day's first price."""
+        day = (
+            datetime.now(timezone.utc)
+            .replace(hour=0, minute=0, second=0, microsecond=0)
+            .timestamp()
+        )
+        stats = self._stats.get(pair)
+        if stats is None or stats["day"] != day:
+            stats = {"day": day, "open": price, "high": price, "low": price}
+            self._stats[pair] = stats
+        stats["high"] = max(stats["high"], price)
+        stats["low"] = min(stats["low"], price)
+
+        pct = (price - stats["open"]) / stats["open"] * 100 if stats["open"] else 0.0
+        return TickerData(
+            pair=pair,
+            price=f"{price:.10g}",
+            percentage=f"+{pct:.2f}%" if pct >= 0 else f"{pct:.2f}%",
+            high_24h=f"{stats['high']:.10g}",
+            low_24h=f"{stats['low']:.10g}",
+            display_name=base,
+            quote_token=quote,
+        )
+
+
+class UniswapPoolClient(BaseExchangeClient):
+    """
+    Polls the current price of the subscribed pools over RPC.
+
+    The chain only exposes the current price, so the change percentage and
+    high/low are tracked by DailyStats.
+    """
+
+    POLL_INTERVAL_MS = 15000
+
+    def __init__(self, parent=None):
+        super().__init__(parent)
+        self._pairs: set[str] = set()
+        self._pool_info: dict[str, PoolInfo] = {}  # pair -> info
+        self._day_stats = DailyStats()
+        self._is_connected = False
+        self._fetching = False
+        self._timer = QTimer(self)
+        self._timer.timeout.connect(self._poll_data)
+        self._timer.setInterval(self.POLL_INTERVAL_MS)
+
+    def subscribe(self, pairs: list[str]):
+        self._pairs = {p for p in pairs if is_pool_pair(p)}
+        if not self._pairs:
+            self._timer.stop()
+            return
+
+        logger.info(f"Subscribing to pools: {sorted(self._pairs)}")
+        if not self._timer.isActive():
+            self._timer.start()
+        self._poll_data()
+
+    def stop(self):
+        self._timer.stop()
+        self._pairs.clear()
+        self._is_connected = False
+        self.stopped.emit()
+
+    def reconnect(self):
+        self._poll_data()
+
+    def get_stats(self):
+        return {"type": "RPC Polling", "interval": "15s", "pairs": len(self._pairs)}
+
+    def fetch_klines(self, pair: str, interval: str, limit: int) -> list[dict]:
+        # No history over plain RPC; candles are aggregated from the polled prices
+        return []
+
+    @property
+    def is_connected(self) -> bool:
+        return self._is_connected
+
+    def _poll_data(self):
+        if self._fetching or not self._pairs:
+            return
+        self._fetching = True
+        threading.Thread(target=self._poll, args=(sorted(self._pairs),), daemon=True).start()
+
+    def _poll(self, pairs: list[str]):
+        try:
+            updated = 0
+            for pair in pairs:
+                try:
+                    ticker = self._read_pool(pair)
+                except (RpcError, ValueError) as e:
+                    logger.warning(f"Failed to read pool {pair}: {e}")
+                    continue
+                self.ticker_updated.emit(pair, ticker)
+                updated += 1
+        finally:
+            self._fetching = False
+
+        connected = updated > 0
+        if connected != self._is_connected:
+            self._is_connected = connected
+            self.connection_status.emit(
+                connected, message("connected_rpc" if connected else "rpc_failed")
+            )
+
+    def _read_pool(self, pair: str) -> TickerData:
+        network, address, inverse = parse_pool_pair(pair)
+        url = get_rpc_url(network)
+        if not url:
+            raise ValueError(f"No RPC endpoint for network '{network}'")
+
+        info = self._pool_info.get(pair)
+        if info is None:
+            info = fetch_pool_info(url, address)
+            self._pool_info[pair] = info
+
+        (slot0,) = eth_call_batch(url, [(address, SLOT0_SELECTOR)])
+        price = sqrt_price_to_price(decode_uint(slot0), info.decimals0, info.decimals1)
+        if inverse:
+            if price == 0:
+                raise ValueError("Pool price is 0")
+            price = 1 / price
+        base, quote = (info.symbol1, info.symbol0) if inverse else (info.symbol0, info.symbol1)
+
+        return self._day_stats.to_ticker(pair, price, base, quote)
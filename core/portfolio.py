@@ -0,0 +1,390 @@
+"""
+Portfolio holdings and valuation.
+Derives positions from recorded transactions (average cost method)
+and values them with live prices in the user's chosen fiat currency.
+"""
+
+import logging
+import time
+from dataclasses import dataclass, field
+
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from config.settings import Portfolio, PortfolioTransaction, get_settings_manager
+from core.fx_rates import get_fx_rate_service
+from core.portfolio_history import PortfolioSnapshot, get_portfolio_history_store
+
+logger = logging.getLogger(__name__)
+
+
+@dataclass
+class Position:
+    """Holding of one trading pair's base asset."""
+
+    pair: str
+    quantity: float = 0.0  # Base asset amount held
+    cost_basis: float = 0.0  # Total cost of held quantity, in quote asset
+    realized_pnl: float = 0.0  # Realized profit/loss, in quote asset
+
+    @property
+    def asset(self) -> str:
+        return self.pair.split("-")[0]
+
+    @property
+    def quote_asset(self) -> str:
+        parts = self.pair.split("-")
+        return parts[1] if len(parts) > 1 else ""
+
+    @property
+    def average_cost(self) -> float:
+        return self.cost_basis / self.quantity if self.quantity > 0 else 0.0
+
+
+@dataclass
+class PositionValuation:
+    """A position valued at the current market price."""
+
+    pair: str
+    asset: str
+    quantity: float
+    price: float | None  # Latest price in quote asset
+    value: float | None  # Market value in target currency
+    cost: float | None  # Cost basis in target currency
+    unrealized_pnl: float | None  # value - cost
+
+    @property
+    def unrealized_pnl_pct(self) -> float | None:
+        if self.unrealized_pnl is None or not self.cost:
+            return None
+        return self.unrealized_pnl / self.cost * 100
+
+
+@dataclass
+class PortfolioValuation:
+    """Snapshot of the whole portfolio's value."""
+
+    currency: str
+    total_value: float = 0.0
+    total_cost: float = 0.0
+    positions: list[PositionValuation] = field(default_factory=list)
+    unpriced_pairs: list[str] = field(default_factory=list)  # Held pairs without a usable price
+
+    @property
+    def unrealized_pnl(self) -> float:
+        return self.total_value - self.total_cost
+
+
+@dataclass
+class AssetAllocation:
+    """Share of the portfolio held in one asset."""
+
+    asset: str
+    value: float  # Market value in valuation currency
+    percentage: float  # Share of total value, 0-100
+
+
+def compute_positions(transactions: list[PortfolioTransaction]) -> dict[str, Position]:
+    """
+    Build positions from transactions using the average cost method.
+
+    Fees paid in the quote asset are added to the cost of buys and deducted
+    from the proceeds of sells; fees paid in the base asset reduce quantity.
+    """
+    positions: dict[str, Position] = {}
+
+    for tx in sorted(transactions, key=lambda t: t.timestamp):
+        if not tx.pair or tx.quantity <= 0:
+            continue
+
+        pos = positions.setdefault(tx.pair, Position(pair=tx.pair))
+        quote_fee = tx.fee if tx.fee_asset == tx.quote_asset else 0.0
+        base_fee = tx.fee if tx.fee_asset == tx.base_asset else 0.0
+
+        if tx.side == "buy":
+            pos.quantity += tx.quantity - base_fee
+            pos.cost_basis += tx.quantity * tx.price + quote_fee
+        elif tx.side == "sell":
+            sold = min(tx.quantity, pos.quantity)
+            if sold <= 0:
+                logger.warning(f"Ignoring sell without holdings: {tx.pair} {tx.quantity}")
+                continue
+            cost_of_sold = pos.average_cost * sold
+            proceeds = sold * tx.price - quote_fee
+            pos.realized_pnl += proceeds - cost_of_sold
+            pos.cost_basis -= cost_of_sold
+            pos.quantity -= sold + base_fee
+
+        # Avoid float dust from repeated partial sells
+        if pos.quantity <= 1e-12:
+            pos.quantity = 0.0
+            pos.cost_basis = 0.0
+
+    return positions
+
+
+def compute_allocation(valuation: PortfolioValuation) -> list[AssetAllocation]:
+    """
+    Break down a valuation by asset, largest holding first.
+
+    Positions in the same asset across pairs (BTC-USDT, BTC-USDC) are merged;
+    unpriced positions are left out.
+    """
+    values: dict[str, float] = {}
+    for pos in valuation.positions:
+        if pos.value is not None:
+            values[pos.asset] = values.get(pos.asset, 0.0) + pos.value
+
+    total = sum(values.values())
+    allocation = [
+        AssetAllocation(asset=asset, value=value, percentage=value / total * 100 if total else 0.0)
+        for asset, value in values.items()
+    ]
+    allocation.sort(key=lambda a: a.value, reverse=True)
+    return allocation
+
+
+class PortfolioManager(QObject):
+    """
+    Tracks portfolio holdings and values them with live prices.
+
+    Prices are fed from MarketDataController; holdings are derived
+    from the transactions stored in settings, separately for each
+    named portfolio. Methods taking an optional portfolio_id default
+    to the active portfolio.
+    """
+
+    valuation_updated = pyqtSignal(object)  # PortfolioValuation
+    portfolio_changed = pyqtSignal(str)  # Active portfolio ID
+    portfolios_changed = pyqtSignal()  # Portfolio added, renamed or removed
+
+    SNAPSHOT_INTERVAL_MS = 5 * 60 * 1000
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._fx_service = get_fx_rate_service()
+        self._history_store = get_portfolio_history_store()
+        self._prices: dict[str, float] = {}
+        self._positions: dict[str, dict[str, Position]] = {}  # portfolio_id -> pair -> position
+        self.reload()
+
+        self._snapshot_timer = QTimer(self)
+        self._snapshot_timer.setInterval(self.SNAPSHOT_INTERVAL_MS)
+        self._snapshot_timer.timeout.connect(self.record_snapshot)
+
+    def start(self):
+        """Start periodic value snapshots."""
+        if not self._snapshot_timer.isActive():
+            self._snapshot_timer.start()
+
+    def stop(self):
+        """Stop periodic value snapshots."""
+        self._snapshot_timer.stop()
+
+    def reload(self):
+        """Recompute positions from stored transactions."""
+        grouped: dict[str, list[PortfolioTransaction]] = {}
+        for tx in self._settings_manager.settings.transactions:
+            grouped.setdefault(tx.portfolio_id, []).append(tx)
+        self._positions = {pid: compute_positions(txs) for pid, txs in grouped.items()}
+
+    # Portfolio management
+    @property
+    def active_portfolio_id(self) -> str:
+        """ID of the portfolio currently shown in the UI."""
+        settings = self._settings_manager.settings
+        if self._settings_manager.get_portfolio(settings.active_portfolio):
+            return settings.active_portfolio
+        return settings.portfolios[0].id
+
+    def get_portfolios(self) -> list[Portfolio]:
+        """Get all portfolios."""
+        return self._settings_manager.settings.portfolios
+
+    def set_active_portfolio(self, portfolio_id: str) -> bool:
+        """Switch the active portfolio. Returns True if switched."""
+        if portfolio_id == self.active_portfolio_id:
+            return False
+        if self._settings_manager.get_portfolio(portfolio_id) is None:
+            return False
+        self._settings_manager.update_active_portfolio(portfolio_id)
+        self.portfolio_changed.emit(portfolio_id)
+        self.valuation_updated.emit(self.get_valuation())
+        return True
+
+    def create_portfolio(self, name: str) -> Portfolio:
+        """Create a new, empty portfolio."""
+        portfolio = Portfolio(name=name.strip())
+        self._settings_manager.add_portfolio(portfolio)
+        self.portfolios_changed.emit()
+        return portfolio
+
+    def rename_portfolio(self, portfolio_id: str, name: str) -> bool:
+        """Rename a portfolio. Returns True if renamed."""
+        renamed = self._settings_manager.rename_portfolio(portfolio_id, name.strip())
+        if renamed:
+            self.portfolios_changed.emit()
+        return renamed
+
+    def remove_portfolio(self, portfolio_id: str) -> bool:
+        """Delete a portfolio with its transactions and alerts. Returns True if removed."""
+        was_active = portfolio_id == self.active_portfolio_id
+        if not self._settings_manager.remove_portfolio(portfolio_id):
+            return False
+        self.reload()
+        self.portfolios_changed.emit()
+        if was_active:
+            self.portfolio_changed.emit(self.active_portfolio_id)
+            self.valuation_updated.emit(self.get_valuation())
+        return True
+
+    def add_transactions(
+        self, transactions: list[PortfolioTransaction], portfolio_id: str | None = None
+    ) -> int:
+        """
+        Store new transactions and refresh holdings.
+
+        Args:
+            transactions: Transactions to add
+            portfolio_id: Target portfolio; defaults to the active portfolio
+
+        Returns:
+            Number of transactions actually added
+        """
+        portfolio_id = portfolio_id or self.active_portfolio_id
+        for tx in transactions:
+            tx.portfolio_id = portfolio_id
+
+        added = self._settings_manager.add_transactions(transactions)
+        if added:
+            self.reload()
+            self.valuation_updated.emit(self.get_valuation(portfolio_id=portfolio_id))
+        return added
+
+    def update_price(self, pair: str, price: float):
+        """Record the latest price of a pair."""
+        if price > 0:
+            self._prices[pair] = price
+
+    def get_price(self, pair: str) -> float | None:
+        return self._prices.get(pair)
+
+    def get_positions(self, portfolio_id: str | None = None) -> list[Position]:
+        """Get open positions (non-zero quantity)."""
+        positions = self._positions.get(portfolio_id or self.active_portfolio_id, {})
+        return [p for p in positions.values() if p.quantity > 0]
+
+    def _to_currency(self, amount: float, quote_asset: str, currency: str) -> float | None:
+        """Convert an amount in quote asset to the target currency."""
+        converted = self._fx_service.convert_from_quote(amount, quote_asset, currency)
+        if converted is not None:
+            return converted
+
+        # Crypto-quoted pairs (e.g., ETH-BTC): route through QUOTE-USDT price
+        for stable in ("USDT", "USDC"):
+            quote_price = self._prices.get(f"{quote_asset}-{stable}")
+            if quote_price:
+                return self._fx_service.convert_usd(amount * quote_price, currency)
+        return None
+
+    def get_valuation(
+        self, currency: str | None = None, portfolio_id: str | None = None
+    ) -> PortfolioValuation:
+        """
+        Value all open positions of a portfolio.
+
+        Args:
+            currency: Target fiat currency; defaults to the display currency setting
+            portfolio_id: Portfolio to value; defaults to the active portfolio
+        """
+        currency = (currency or self._settings_manager.settings.fiat_currency).upper()
+        valuation = PortfolioValuation(currency=currency)
+
+        for pos in self.get_positions(portfolio_id):
+            price = self._prices.get(pos.pair)
+            value = cost = pnl = None
+            if price is not None:
+                value = self._to_currency(pos.quantity * price, pos.quote_asset, currency)
+                cost = self._to_currency(pos.cost_basis, pos.quote_asset, currency)
+
+            if value is None or cost is None:
+                valuation.unpriced_pairs.append(pos.pair)
+            else:
+                pnl = value - cost
+                valuation.total_value += value
+                valuation.total_cost += cost
+
+            valuation.positions.append(
+                PositionValuation(
+                    pair=pos.pair,
+                    asset=pos.asset,
+                    quantity=pos.quantity,
+                    price=price,
+                    value=value,
+                    cost=cost,
+                    unrealized_pnl=pnl,
+                )
+            )
+
+        return valuation
+
+    def get_allocation(
+        self, currency: str | None = None, portfolio_id: str | None = None
+    ) -> list[AssetAllocation]:
+        """Get current allocation percentages per asset."""
+        return compute_allocation(self.get_valuation(currency, portfolio_id))
+
+    def record_snapshot(self):
+        """Store the current total value of every portfolio in the history database."""
+        for portfolio in self.get_portfolios():
+            self._record_portfolio_snapshot(portfolio.id)
+
+    def _record_portfolio_snapshot(self, portfolio_id: str):
+        if not self.get_positions(portfolio_id):
+            return
+
+        valuation = self.get_valuation(portfolio_id=portfolio_id)
+        # Skip until every holding has a price, otherwise the chart would dip
+        if valuation.unpriced_pairs:
+            logger.debug(f"Skipping snapshot, unpriced pairs: {valuation.unpriced_pairs}")
+            return
+
+        try:
+            self._history_store.record(
+                PortfolioSnapshot(
+                    timestamp=time.time(),
+                    currency=valuation.currency,
+                    total_value=valuation.total_value,
+                    total_cost=valuation.total_cost,
+                    portfolio_id=portfolio_id,
+                )
+            )
+        except Exception as e:
+            logger.error(f"Failed to record portfolio snapshot: {e}")
+            return
+        if portfolio_id == self.active_portfolio_id:
+            self.valuation_updated.emit(valuation)
+
+    def get_history(
+        self,
+        since: float | None = None,
+        currency: str | None = None,
+        portfolio_id: str | None = None,
+    ) -> list[PortfolioSnapshot]:
+        """Get recorded total value history, defaulting to the display currency."""
+        currency = currency or self._settings_manager.settings.fiat_currency
+        return self._history_store.get_history(
+            currency, since=since, portfolio_id=portfolio_id or self.active_portfolio_id
+        )
+
+
+# Global portfolio manager instance
+_portfolio_manager: PortfolioManager | None = None
+
+
+def get_portfolio_manager() -> PortfolioManager:
+    """Get the global portfolio manager instance."""
+    global _portfolio_manager
+    if _portfolio_manager is None:
+        _portfolio_manager = PortfolioManager()
+    return _portfolio_manager
@@ -0,0 +1,232 @@
+"""
+Portfolio-level alerts.
+Checks aggregate metrics (total value, daily drawdown, position share)
+against user-defined thresholds and sends notifications.
+"""
+
+import logging
+import time
+from dataclasses import dataclass
+from datetime import datetime
+
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from config.settings import PortfolioAlert, get_settings_manager
+from core.notifier import get_notification_service
+from core.portfolio import (
+    AssetAllocation,
+    PortfolioValuation,
+    compute_allocation,
+    get_portfolio_manager,
+)
+
+logger = logging.getLogger(__name__)
+
+
+@dataclass
+class PortfolioAlertHit:
+    """A triggered portfolio alert and the metric value that triggered it."""
+
+    alert: PortfolioAlert
+    value: float  # Total value, drawdown % or position share %
+    asset: str = ""  # Asset concerned, for position share alerts
+
+
+def evaluate_portfolio_alert(
+    alert: PortfolioAlert,
+    valuation: PortfolioValuation,
+    allocation: list[AssetAllocation],
+    day_peak: float | None = None,
+) -> PortfolioAlertHit | None:
+    """
+    Check a single alert against a valuation.
+
+    Args:
+        alert: Alert to evaluate
+        valuation: Current valuation, in the alert's currency
+        allocation: Allocation breakdown of the valuation
+        day_peak: Highest total value seen today, for drawdown alerts
+
+    Returns:
+        The hit if the alert condition is met, otherwise None
+    """
+    if alert.alert_type == "value_above":
+        if valuation.total_value > alert.threshold:
+            return PortfolioAlertHit(alert, valuation.total_value)
+
+    elif alert.alert_type == "value_below":
+        if valuation.total_value < alert.threshold:
+            return PortfolioAlertHit(alert, valuation.total_value)
+
+    elif alert.alert_type == "daily_drawdown":
+        if day_peak and day_peak > 0 and alert.threshold > 0:
+            drawdown = (day_peak - valuation.total_value) / day_peak * 100
+            if drawdown >= alert.threshold:
+                return PortfolioAlertHit(alert, drawdown)
+
+    elif alert.alert_type == "position_share":
+        for item in allocation:
+            if alert.asset and item.asset != alert.asset.upper():
+                continue
+            if item.percentage > alert.threshold:
+                return PortfolioAlertHit(alert, item.percentage, item.asset)
+
+    return None
+
+
+class PortfolioAlertManager(QObject):
+    """
+    Periodically evaluates portfolio alerts.
+
+    Each alert is evaluated against its own portfolio. The day's peak
+    value is tracked per portfolio and currency for drawdown alerts;
+    peaks reset at local midnight.
+    """
+
+    alert_triggered = pyqtSignal(str, float, str)  # alert_type, value, asset
+
+    CHECK_INTERVAL_MS = 30 * 1000
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._notification_service = get_notification_service()
+        self._portfolio_manager = get_portfolio_manager()
+        self._day_peaks: dict[tuple[str, str], float] = {}  # (portfolio, currency) -> peak
+        self._day_start = 0.0
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.CHECK_INTERVAL_MS)
+        self._timer.timeout.connect(self.check_alerts)
+
+    def start(self):
+        """Start periodic checks."""
+        if not self._timer.isActive():
+            self._timer.start()
+
+    def stop(self):
+        """Stop periodic checks."""
+        self._timer.stop()
+
+    def _update_day_peak(self, portfolio_id: str, currency: str, value: float) -> float:
+        """Track the highest value seen today and return it."""
+        day_start = datetime.now().replace(hour=0, minute=0, second=0, microsecond=0).timestamp()
+        if day_start != self._day_start:
+            self._day_start = day_start
+            self._day_peaks.clear()
+
+        key = (portfolio_id, currency)
+        if key not in self._day_peaks:
+            # Seed from today's recorded history so a restart doesn't hide a drawdown
+            history = self._portfolio_manager.get_history(
+                since=day_start, currency=currency, portfolio_id=portfolio_id
+            )
+            self._day_peaks[key] = max((s.total_value for s in history), default=value)
+
+        self._day_peaks[key] = max(self._day_peaks[key], value)
+        return self._day_peaks[key]
+
+    def check_alerts(self):
+        """Evaluate all enabled portfolio alerts against their portfolio's valuation."""
+        alerts = [a for a in self._settings_manager.settings.portfolio_alerts if a.enabled]
+        if not alerts:
+            return
+
+        valuations: dict[tuple[str, str], PortfolioValuation] = {}
+        for alert in alerts:
+            if alert.repeat_mode == "repeat" and alert.last_triggered:
+                if time.time() - alert.last_triggered < alert.cooldown_seconds:
+                    continue
+            if not self._portfolio_manager.get_positions(alert.portfolio_id):
+                continue
+
+            currency = alert.currency.upper()
+            key = (alert.portfolio_id, currency)
+            if key not in valuations:
+                valuations[key] = self._portfolio_manager.get_valuation(
+                    currency, alert.portfolio_id
+                )
+            valuation = valuations[key]
+
+            # Partial valuations would cause false value/drawdown alerts
+            if valuation.unpriced_pairs:
+                continue
+
+            hit = evaluate_portfolio_alert(
+                alert,
+                valuation,
+                compute_allocation(valuation),
+                self._update_day_peak(alert.portfolio_id, currency, valuation.total_value),
+            )
+            if hit:
+                self._trigger_alert(hit)
+
+    def _trigger_alert(self, hit: PortfolioAlertHit):
+        alert = hit.alert
+        logger.info(f"Portfolio alert triggered: {alert.alert_type} {hit.value:.2f} {hit.asset}")
+
+        # Only name the portfolio when there is more than one to tell apart
+        portfolio_name = ""
+        if len(self._portfolio_manager.get_portfolios()) > 1:
+            portfolio = self._settings_manager.get_portfolio(alert.portfolio_id)
+            portfolio_name = portfolio.name if portfolio else ""
+
+        self._notification_service.send_portfolio_alert(
+            alert_type=alert.alert_type,
+            threshold=alert.threshold,
+            value=hit.value,
+            currency=alert.currency,
+            asset=hit.asset,
+            portfolio=portfolio_name,
+        )
+
+        alert.last_triggered = time.time()
+        if alert.repeat_mode == "once":
+            alert.enabled = False
+        self._settings_manager.update_portfolio_alert(alert)
+
+        self.alert_triggered.emit(alert.alert_type, hit.value, hit.asset)
+
+    def add_alert(
+        self,
+        alert_type: str,
+        threshold: float,
+        currency: str | None = None,
+        asset: str = "",
+        repeat_mode: str = "once",
+        portfolio_id: str | None = None,
+    ) -> PortfolioAlert:
+        """Add a new portfolio alert, watching the active portfolio by default."""
+        alert = PortfolioAlert(
+            portfolio_id=portfolio_id or self._portfolio_manager.active_portfolio_id,
+            alert_type=alert_type,
+            threshold=threshold,
+            currency=(currency or self._settings_manager.settings.fiat_currency).upper(),
+            asset=asset.upper(),
+            repeat_mode=repeat_mode,
+        )
+        self._settings_manager.add_portfolio_alert(alert)
+        return alert
+
+    def remove_alert(self, alert_id: str) -> bool:
+        """Remove a portfolio alert by ID."""
+        return self._settings_manager.remove_portfolio_alert(alert_id)
+
+    def get_alerts(self, portfolio_id: str | None = None) -> list[PortfolioAlert]:
+        """Get portfolio alerts, optionally only those of one portfolio."""
+        alerts = self._settings_manager.settings.portfolio_alerts
+        if portfolio_id is None:
+            return alerts
+        return [a for a in alerts if a.portfolio_id == portfolio_id]
+
+
+# Global portfolio alert manager instance
+_portfolio_alert_manager: PortfolioAlertManager | None = None
+
+
+def get_portfolio_alert_manager() -> PortfolioAlertManager:
+    """Get the global portfolio alert manager instance."""
+    global _portfolio_alert_manager
+    if _portfolio_alert_manager is None:
+        _portfolio_alert_manager = PortfolioAlertManager()
+    return _portfolio_alert_manager
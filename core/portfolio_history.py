@@ -0,0 +1,144 @@
+"""
+Portfolio value history.
+Persists periodic snapshots of the portfolio's total value to a local
+SQLite database so value over time can be charted.
+"""
+
+import logging
+import sqlite3
+import threading
+from dataclasses import dataclass
+from pathlib import Path
+
+from config.settings import DEFAULT_PORTFOLIO_ID, get_settings_manager
+
+logger = logging.getLogger(__name__)
+
+
+@dataclass
+class PortfolioSnapshot:
+    """Total portfolio value at one point in time."""
+
+    timestamp: float
+    currency: str
+    total_value: float
+    total_cost: float
+    portfolio_id: str = DEFAULT_PORTFOLIO_ID
+
+    @property
+    def unrealized_pnl(self) -> float:
+        return self.total_value - self.total_cost
+
+
+class PortfolioHistoryStore:
+    """
+    SQLite-backed store of portfolio value snapshots.
+
+    The connection is shared across threads and guarded by a lock.
+    """
+
+    def __init__(self, db_path: Path):
+        self.db_path = db_path
+        self._lock = threading.Lock()
+        self.db_path.parent.mkdir(parents=True, exist_ok=True)
+        self._conn = sqlite3.connect(str(db_path), check_same_thread=False)
+        self._init_schema()
+
+    def _init_schema(self):
+        with self._lock, self._conn:
+            self._conn.execute(
+                """
+                CREATE TABLE IF NOT EXISTS portfolio_snapshots (
+                    timestamp REAL NOT NULL,
+                    currency TEXT NOT NULL,
+                    total_value REAL NOT NULL,
+                    total_cost REAL NOT NULL,
+                    portfolio_id TEXT NOT NULL DEFAULT 'default'
+                )
+                """
+            )
+            # Databases created before multiple portfolios lack the column
+            table_info = self._conn.execute("PRAGMA table_info(portfolio_snapshots)")
+            columns = {row[1] for row in table_info}
+            if "portfolio_id" not in columns:
+                self._conn.execute(
+                    "ALTER TABLE portfolio_snapshots "
+                    "ADD COLUMN portfolio_id TEXT NOT NULL DEFAULT 'default'"
+                )
+            self._conn.execute(
+                "CREATE INDEX IF NOT EXISTS idx_snapshots_portfolio_time "
+                "ON portfolio_snapshots (portfolio_id, currency, timestamp)"
+            )
+
+    def record(self, snapshot: PortfolioSnapshot):
+        """Append a snapshot."""
+        with self._lock, self._conn:
+            self._conn.execute(
+                "INSERT INTO portfolio_snapshots VALUES (?, ?, ?, ?, ?)",
+                (
+                    snapshot.timestamp,
+                    snapshot.currency,
+                    snapshot.total_value,
+                    snapshot.total_cost,
+                    snapshot.portfolio_id,
+                ),
+            )
+
+    def get_history(
+        self,
+        currency: str,
+        since: float | None = None,
+        until: float | None = None,
+        portfolio_id: str = DEFAULT_PORTFOLIO_ID,
+    ) -> list[PortfolioSnapshot]:
+        """
+        Get snapshots for a currency in chronological order.
+
+        Args:
+            currency: Fiat currency the snapshots were valued in
+            since: Optional start timestamp (inclusive)
+            until: Optional end timestamp (inclusive)
+            portfolio_id: Portfolio the snapshots belong to
+        """
+        query = (
+            "SELECT timestamp, currency, total_value, total_cost, portfolio_id "
+            "FROM portfolio_snapshots WHERE currency = ? AND portfolio_id = ?"
+        )
+        params: list = [currency.upper(), portfolio_id]
+        if since is not None:
+            query += " AND timestamp >= ?"
+            params.append(since)
+        if until is not None:
+            query += " AND timestamp <= ?"
+            params.append(until)
+        query += " ORDER BY timestamp"
+
+        with self._lock:
+            rows = self._conn.execute(query, params).fetchall()
+        return [PortfolioSnapshot(*row) for row in rows]
+
+    def prune(self, older_than: float) -> int:
+        """Delete snapshots older than a timestamp. Returns number deleted."""
+        with self._lock, self._conn:
+            cursor = self._conn.execute(
+                "DELETE FROM portfolio_snapshots WHERE timestamp < ?", (older_than,)
+            )
+        return cursor.rowcount
+
+    def close(self):
+        with self._lock:
+            self._conn.close()
+
+
+# Global portfolio history store instance
+_history_store: PortfolioHistoryStore | None = None
+
+
+def get_portfolio_history_store() -> PortfolioHistoryStore:
+    """Get the global portfolio history store, stored next to settings.json."""
+    global _history_store
+    if _history_store is None:
+        db_path = get_settings_manager().config_dir / "portfolio.db"
+        _history_store = PortfolioHistoryStore(db_path)
+    return _history_store
+
@@ -2,6 +2,9 @@ from dataclasses import dataclass
 
 from PyQt6.QtGui import QColor
 
+from core.display_prefs import DisplayHints
+from core.highlight_rules import Highlight
+from core.indicators import IndicatorSnapshot
 from core.models import TickerData
 
 
@@ -20,6 +23,31 @@ class PriceState:
     icon_url: str = ""
     display_name: str = ""
     quote_token: str = ""
+    alias: str = ""  # User-given display name, overriding display_name
+    # Rendering resolved from the pair's display preferences (None without any)
+    display_hints: DisplayHints | None = None
+    # Highlight rule the pair's move triggers (None when none does)
+    highlight: Highlight | None = None
+
+    # Price converted to the display fiat currency (None when not converted)
+    fiat_price: float | None = None
+    fiat_currency: str = "USD"
+
+    # Technical indicators of the pair (None until candles are loaded)
+    indicators: IndicatorSnapshot | None = None
+
+    # Session VWAP and the price's distance from it in percent (None without trade data)
+    vwap: float | None = None
+    vwap_distance_pct: float | None = None
+
+    # UTC day session range and the price's position in it, 0-100% (None until known)
+    session_high: float | None = None
+    session_low: float | None = None
+    range_position: float | None = None
+
+    # Latest Chainlink price of the asset and the premium over it in percent (None without a feed)
+    oracle_price: float | None = None
+    oracle_deviation_pct: float | None = None
 
 
 class PriceTracker:
@@ -0,0 +1,110 @@
+"""
+Per-exchange REST request budgeting.
+Every request to an exchange API takes a slot from that exchange's sliding
+window first; when the window is full the caller waits for the next free
+slot instead of firing, so candles, instruments, funding rates and account
+calls together can't exceed the documented IP limits.
+"""
+
+import asyncio
+import logging
+import threading
+import time
+from collections import deque
+from urllib.parse import urlparse
+
+logger = logging.getLogger(__name__)
+
+# (requests, window in seconds), kept well below each exchange's documented limit:
+# OKX 20 requests / 2 s per market endpoint, Binance 6000 weight / minute,
+# Bybit 600 requests / 5 s per IP
+RATE_LIMITS = {
+    "OKX": (10, 2.0),
+    "BINANCE": (20, 1.0),
+    "BYBIT": (10, 1.0),
+}
+
+# API host suffixes of each exchange
+EXCHANGE_HOSTS = {
+    "okx.com": "OKX",
+    "binance.com": "BINANCE",
+    "bybit.com": "BYBIT",
+}
+
+
+def exchange_of(url: str) -> str | None:
+    """Exchange an API URL belongs to, or None for other hosts."""
+    host = urlparse(url).hostname or ""
+    for suffix, exchange in EXCHANGE_HOSTS.items():
+        if host == suffix or host.endswith(f".{suffix}"):
+            return exchange
+    return None
+
+
+class RateLimiter:
+    """Thread-safe sliding window limiter with one window per exchange."""
+
+    def __init__(self, limits: dict[str, tuple[int, float]] | None = None):
+        self._limits = RATE_LIMITS if limits is None else limits
+        self._slots: dict[str, deque[float]] = {}
+        self._stats: dict[str, dict[str, float]] = {}
+        self._lock = threading.Lock()
+
+    def _reserve(self, exchange: str) -> float:
+        """Reserve the next free slot; returns how many seconds until it starts."""
+        limit = self._limits.get(exchange)
+        if limit is None:
+            return 0.0
+        count, window = limit
+        with self._lock:
+            now = time.monotonic()
+            slots = self._slots.setdefault(exchange, deque())
+            while slots and slots[0] <= now - window:
+                slots.popleft()
+            start = now if len(slots) < count else max(now, slots[-count] + window)
+            slots.append(start)
+
+            delay = start - now
+            stats = self._stats.setdefault(exchange, {"requests": 0, "delayed": 0, "waited": 0.0})
+            stats["requests"] += 1
+            if delay > 0:
+                stats["delayed"] += 1
+                stats["waited"] += delay
+        if delay > 1:
+            logger.debug(f"{exchange} request budget exhausted, queued for {delay:.1f}s")
+        return delay
+
+    def acquire(self, exchange: str | None):
+        """Block until a request to the exchange may be sent."""
+        if exchange:
+            delay = self._reserve(exchange)
+            if delay > 0:
+                time.sleep(delay)
+
+    async def acquire_async(self, exchange: str | None):
+        """Wait in the event loop until a request to the exchange may be sent."""
+        if exchange:
+            delay = self._reserve(exchange)
+            if delay > 0:
+                await asyncio.sleep(delay)
+
+    def acquire_url(self, url: str):
+        """Block until a request to the URL's exchange, if any, may be sent."""
+        self.acquire(exchange_of(url))
+
+    def stats(self) -> dict[str, dict[str, float]]:
+        """Requests, delayed requests and total seconds waited per exchange."""
+        with self._lock:
+            return {exchange: dict(stats) for exchange, stats in self._stats.items()}
+
+
+# Global rate limiter instance
+_rate_limiter: RateLimiter | None = None
+
+
+def get_rate_limiter() -> RateLimiter:
+    """Get the global rate limiter instance."""
+    global _rate_limiter
+    if _rate_limiter is None:
+        _rate_limiter = RateLimiter()
+    return _rate_limiter
@@ -0,0 +1,219 @@
+"""
+Recorded market data replay.
+A developer mode (--replay) that plays back a recording of exchange tickers in
+place of the live feed, so UI work gets realistic, repeatable ticks and alerts
+without network access. --record writes such a recording from a live session.
+
+Recordings are JSON Lines, one ticker per line:
+    {"t": 1.25, "ticker": {"pair": "BTC-USDT", "price": "60012.5", ...}}
+where "t" is the seconds since the recording started.
+"""
+
+import json
+import logging
+import os
+import sys
+import time
+from dataclasses import asdict, dataclass, fields
+
+from PyQt6.QtCore import QObject, QTimer
+
+from core.base_client import BaseExchangeClient
+from core.messages import message
+from core.models import TickerData
+
+logger = logging.getLogger(__name__)
+
+# Recording shipped with the app, used when --replay is given without a path. It sits
+# in the PyInstaller bundle when frozen, else in the source tree
+_APP_DIR = (
+    sys._MEIPASS
+    if getattr(sys, "frozen", False)
+    else os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+)
+DEFAULT_RECORDING = os.path.join(_APP_DIR, "assets", "replay", "sample.jsonl")
+
+_TICKER_FIELDS = {f.name for f in fields(TickerData)}
+
+
+@dataclass
+class RecordedTick:
+    """One ticker of a recording, at its offset from the start."""
+
+    offset: float
+    ticker: TickerData
+
+
+def load_recording(path: str) -> list[RecordedTick]:
+    """
+    Read a recording, sorted by offset.
+
+    Raises:
+        OSError: If the file can't be read
+        ValueError: On malformed lines
+    """
+    ticks = []
+    with open(path, encoding="utf-8") as f:
+        for line_no, line in enumerate(f, 1):
+            if not line.strip():
+                continue
+            try:
+                entry = json.loads(line)
+                data = {k: v for k, v in entry["ticker"].items() if k in _TICKER_FIELDS}
+                ticks.append(RecordedTick(float(entry["t"]), TickerData(**data)))
+            except (ValueError, KeyError, TypeError) as e:
+                raise ValueError(f"{path}:{line_no}: malformed tick: {e}") from e
+    ticks.sort(key=lambda tick: tick.offset)
+    return ticks
+
+
+class TickRecorder:
+    """Appends the tickers it's given to a recording file."""
+
+    def __init__(self, path: str):
+        self.path = path
+        self._started: float | None = None
+        self._file = open(path, "w", encoding="utf-8")
+
+    def record(self, pair: str, data: TickerData):
+        now = time.monotonic()
+        if self._started is None:
+            self._started = now
+        entry = {"t": round(now - self._started, 3), "ticker": asdict(data)}
+        self._file.write(json.dumps(entry, ensure_ascii=False) + "\n")
+        self._file.flush()
+
+    def close(self):
+        self._file.close()
+
+
+class ReplayClient(BaseExchangeClient):
+    """
+    Plays a recording back on a timer, looping at the end.
+
+    Only the subscribed pairs that appear in the recording get ticks.
+    """
+
+    TICK_INTERVAL_MS = 100
+
+    def __init__(self, path: str, speed: float = 1.0, parent: QObject | None = None):
+        super().__init__(parent)
+        self.path = path
+        self.speed = speed
+        self._ticks = load_recording(path)
+        self._duration = self._ticks[-1].offset if self._ticks else 0.0
+        self._pairs: set[str] = set()
+        self._position = 0
+        self._elapsed = 0.0
+        self._loops = 0
+        self._is_connected = False
+        self._timer = QTimer(self)
+        self._timer.timeout.connect(self._tick)
+        self._timer.setInterval(self.TICK_INTERVAL_MS)
+        logger.info(f"Replaying {len(self._ticks)} ticks over {self._duration:.0f}s from {path}")
+
+    @property
+    def recorded_pairs(self) -> set[str]:
+        return {tick.ticker.pair for tick in self._ticks}
+
+    def subscribe(self, pairs: list[str]):
+        self._pairs = set(pairs)
+        missing = self._pairs - self.recorded_pairs
+        if missing:
+            logger.info(f"No recorded ticks for {', '.join(sorted(missing))}")
+        if not self._pairs:
+            self._timer.stop()
+            return
+
+        if not self._timer.isActive():
+            self._timer.start()
+        if not self._is_connected:
+            self._is_connected = True
+            self.connection_status.emit(True, message("connected_to", exchange="Replay"))
+
+    def stop(self):
+        self._timer.stop()
+        self._pairs.clear()
+        self._is_connected = False
+        self.stopped.emit()
+
+    def reconnect(self):
+        self.rewind()
+
+    def rewind(self):
+        """Start the recording over."""
+        self._position = 0
+        self._elapsed = 0.0
+
+    def get_stats(self):
+        return {
+            "type": "Replay",
+            "file": os.path.basename(self.path),
+            "position": f"{self._elapsed:.0f}/{self._duration:.0f}s",
+            "loops": self._loops,
+        }
+
+    def fetch_klines(self, pair: str, interval: str, limit: int) -> list[dict]:
+        return []
+
+    @property
+    def is_connected(self) -> bool:
+        return self._is_connected
+
+    def advance(self, seconds: float):
+        """Emit the ticks recorded within the next period, wrapping at the end."""
+        if not self._ticks:
+            return
+        self._elapsed += seconds * self.speed
+        while True:
+            while (
+                self._position < len(self._ticks)
+                and self._ticks[self._position].offset <= self._elapsed
+            ):
+                tick = self._ticks[self._position]
+                self._position += 1
+                if tick.ticker.pair in self._pairs:
+                    self.ticker_updated.emit(tick.ticker.pair, tick.ticker)
+            if self._position < len(self._ticks) or self._elapsed <= self._duration:
+                return
+            self._elapsed -= self._duration
+            self._position = 0
+            self._loops += 1
+            if self._duration <= 0:
+                return
+
+    def _tick(self):
+        self.advance(self.TICK_INTERVAL_MS / 1000)
+
+
+# Replay and recording options from the command line, inactive unless set
+_replay_path: str | None = None
+_replay_speed = 1.0
+_recorder: TickRecorder | None = None
+
+
+def enable_replay(path: str, speed: float = 1.0) -> None:
+    """Replay a recording instead of the exchange feed for clients created from now on."""
+    global _replay_path, _replay_speed
+    _replay_path = path
+    _replay_speed = speed
+    logger.warning(f"Replay mode: exchange data comes from {path} at {speed:g}x")
+
+
+def get_replay_path() -> str | None:
+    return _replay_path
+
+
+def create_replay_client(parent: QObject | None = None) -> ReplayClient:
+    return ReplayClient(_replay_path, _replay_speed, parent)
+
+
+def enable_recording(path: str) -> None:
+    """Record the tickers of all clients created from now on."""
+    global _recorder
+    _recorder = TickRecorder(path)
+    logger.info(f"Recording tickers to {path}")
+
+
+def get_tick_recorder() -> TickRecorder | None:
+    return _recorder
@@ -0,0 +1,39 @@
+"""
+Session high/low.
+Derived from the indicator engine's hourly candles, which are seeded from
+klines and advanced by live prices, so the range covers the whole UTC day.
+"""
+
+from dataclasses import dataclass
+
+from core.indicators import Candle
+from core.vwap import session_start
+
+
+@dataclass
+class SessionRange:
+    """High and low of the current UTC day session."""
+
+    high: float
+    low: float
+
+    def position(self, price: float) -> float | None:
+        """Where price sits within the range, from 0 (at the low) to 100 (at the high)."""
+        if self.high <= self.low:
+            return None
+        position = (price - self.low) / (self.high - self.low) * 100
+        return min(max(position, 0.0), 100.0)
+
+
+def session_range(candles: list[Candle], now: float) -> SessionRange | None:
+    """
+    Range of the candles opened in the session containing now.
+
+    Returns:
+        The range, or None if no candle of the session exists yet
+    """
+    start = session_start(now)
+    today = [c for c in candles if c.open_time >= start]
+    if not today:
+        return None
+    return SessionRange(high=max(c.high for c in today), low=min(c.low for c in today))
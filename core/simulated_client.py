@@ -0,0 +1,215 @@
+"""
+Simulated market data source.
+Generates prices as a random walk with a configurable daily volatility per
+pair, so the UI, alerts and notifications can be exercised without network
+access or a proxy. Selected like an exchange, as the "Simulated" data source.
+"""
+
+import logging
+import math
+import random
+import time
+
+from PyQt6.QtCore import QTimer
+
+from config.settings import get_settings_manager
+from core.base_client import BaseExchangeClient
+from core.indicators import interval_seconds
+from core.messages import message
+from core.models import TickerData
+
+logger = logging.getLogger(__name__)
+
+DAY_SECONDS = 24 * 60 * 60
+
+# Rough starting prices, so familiar pairs look plausible; others get a random one
+START_PRICES = {
+    "BTC": 60000.0,
+    "ETH": 3000.0,
+    "SOL": 150.0,
+    "BNB": 550.0,
+    "XRP": 0.55,
+    "DOGE": 0.15,
+    "ADA": 0.45,
+    "TON": 6.5,
+    "LINK": 15.0,
+    "AVAX": 30.0,
+}
+
+
+def step_sigma(volatility: float, seconds: float) -> float:
+    """Standard deviation of the log return over a period, from a daily volatility in percent."""
+    return volatility / 100 * math.sqrt(seconds / DAY_SECONDS)
+
+
+def parse_volatility_overrides(text: str) -> dict[str, float]:
+    """
+    Parse per-pair volatilities written as "BTC-USDT=3, DOGE-USDT=12".
+
+    Malformed or non-positive entries are skipped.
+    """
+    overrides = {}
+    for entry in text.replace("\n", ",").split(","):
+        pair, sep, value = entry.partition("=")
+        pair = pair.strip().upper()
+        if not sep or not pair:
+            continue
+        try:
+            volatility = float(value.strip().rstrip("%"))
+        except ValueError:
+            continue
+        if volatility > 0:
+            overrides[pair] = volatility
+    return overrides
+
+
+def format_volatility_overrides(overrides: dict[str, float]) -> str:
+    return ", ".join(f"{pair}={volatility:g}" for pair, volatility in overrides.items())
+
+
+def start_price(pair: str, rng: random.Random) -> float:
+    base = pair.split("-")[0].upper()
+    if base in START_PRICES:
+        return START_PRICES[base]
+    return round(10 ** rng.uniform(-2, 3), 6)
+
+
+class SimulatedPair:
+    """Random-walk price of one pair, with its rolling-day open, high, low and volume."""
+
+    def __init__(self, pair: str, volatility: float, seed: int | None = None):
+        self.pair = pair
+        self.volatility = volatility
+        self._rng = random.Random(seed if seed is not None else pair)
+        self.price = start_price(pair, self._rng)
+        # Yesterday's move, so the change doesn't start at zero
+        self.open = self.price / math.exp(self._rng.gauss(0, step_sigma(volatility, DAY_SECONDS)))
+        self.high = max(self.price, self.open)
+        self.low = min(self.price, self.open)
+        self.quote_volume = self.price * self._rng.uniform(1e3, 1e5)
+
+    def step(self, seconds: float) -> float:
+        """Advance the walk by a period and return the new price."""
+        self.price *= math.exp(self._rng.gauss(0, step_sigma(self.volatility, seconds)))
+        self.high = max(self.high, self.price)
+        self.low = min(self.low, self.price)
+        self.quote_volume += self.price * self._rng.uniform(0, 10) * seconds
+        return self.price
+
+    def to_ticker(self) -> TickerData:
+        pct = (self.price - self.open) / self.open * 100
+        amplitude = (self.high - self.low) / self.open * 100
+        base, _sep, quote = self.pair.partition("-")
+        return TickerData(
+            pair=self.pair,
+            price=f"{self.price:.8g}",
+            percentage=f"+{pct:.2f}%" if pct >= 0 else f"{pct:.2f}%",
+            high_24h=f"{self.high:.8g}",
+            low_24h=f"{self.low:.8g}",
+            quote_volume_24h=f"{self.quote_volume:.2f}",
+            amplitude_24h=f"{amplitude:.2f}%",
+            display_name=base,
+            quote_token=quote,
+        )
+
+    def history(self, interval: str, limit: int, now: float | None = None) -> list[dict]:
+        """
+        Candles leading up to the current price, walked backwards from it.
+
+        The same pair and interval always produce the same shape.
+        """
+        seconds = interval_seconds(interval)
+        rng = random.Random(f"{self.pair}:{interval}")
+        sigma = step_sigma(self.volatility, seconds)
+        now = time.time() if now is None else now
+        open_time = int(now) // seconds * seconds
+
+        candles = []
+        close = self.price
+        for i in range(limit):
+            open_price = close / math.exp(rng.gauss(0, sigma))
+            wick = abs(rng.gauss(0, sigma / 2))
+            candles.append(
+                {
+                    "timestamp": (open_time - i * seconds) * 1000,
+                    "open": open_price,
+                    "high": max(open_price, close) * (1 + wick),
+                    "low": min(open_price, close) * (1 - wick),
+                    "close": close,
+                    "volume": rng.uniform(10, 1000),
+                }
+            )
+            close = open_price
+        candles.reverse()
+        return candles
+
+
+class SimulatedClient(BaseExchangeClient):
+    """Emits simulated tickers for the subscribed pairs on a timer."""
+
+    TICK_INTERVAL_MS = 1000
+
+    def __init__(self, parent=None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._pairs: dict[str, SimulatedPair] = {}
+        self._is_connected = False
+        self._timer = QTimer(self)
+        self._timer.timeout.connect(self._tick)
+        self._timer.setInterval(self.TICK_INTERVAL_MS)
+
+    def _volatility(self, pair: str) -> float:
+        settings = self._settings_manager.settings
+        return settings.simulation_volatility.get(pair, settings.simulation_default_volatility)
+
+    def subscribe(self, pairs: list[str]):
+        self._pairs = {
+            pair: self._pairs.get(pair) or SimulatedPair(pair, self._volatility(pair))
+            for pair in pairs
+        }
+        if not self._pairs:
+            self._timer.stop()
+            return
+
+        logger.info(f"Simulating {len(self._pairs)} pairs")
+        if not self._timer.isActive():
+            self._timer.start()
+        if not self._is_connected:
+            self._is_connected = True
+            self.connection_status.emit(True, message("connected_to", exchange="Simulated"))
+        self._tick(emit_only=True)
+
+    def stop(self):
+        self._timer.stop()
+        self._pairs.clear()
+        self._is_connected = False
+        self.stopped.emit()
+
+    def reconnect(self):
+        self._tick(emit_only=True)
+
+    def get_stats(self):
+        return {"type": "Simulated", "interval": "1s", "pairs": len(self._pairs)}
+
+    def fetch_klines(self, pair: str, interval: str, limit: int) -> list[dict]:
+        walk = self._pairs.get(pair)
+        if walk is None:
+            walk = SimulatedPair(pair, self._volatility(pair))
+        try:
+            return walk.history(interval, limit)
+        except ValueError as e:
+            logger.warning(f"Cannot simulate klines for {pair}: {e}")
+            return []
+
+    @property
+    def is_connected(self) -> bool:
+        return self._is_connected
+
+    def _tick(self, emit_only: bool = False):
+        seconds = self.TICK_INTERVAL_MS / 1000
+        for pair, walk in list(self._pairs.items()):
+            if not emit_only:
+                # Read every tick, so volatility changes apply without resubscribing
+                walk.volatility = self._volatility(pair)
+                walk.step(seconds)
+            self.ticker_updated.emit(pair, walk.to_ticker())
@@ -0,0 +1,92 @@
+"""
+Watchlist snapshot.
+Formats the monitored pairs' current prices as aligned text for the
+clipboard, or as CSV, for sharing in chat groups.
+"""
+
+import csv
+import logging
+from dataclasses import dataclass
+from datetime import datetime
+from pathlib import Path
+
+from core.number_format import NumberFormatter
+from core.price_tracker import PriceState
+from core.tray_summary import build_tray_entry
+
+logger = logging.getLogger(__name__)
+
+CSV_HEADER = ["Pair", "Name", "Price", "Change", "24h High", "24h Low", "24h Volume"]
+
+
+@dataclass(slots=True)
+class SnapshotRow:
+    """One pair's line in a snapshot, rendered as its card shows it."""
+
+    pair: str
+    name: str
+    price_text: str
+    percentage_text: str
+    high_24h: str
+    low_24h: str
+    volume_24h: str
+
+
+def build_snapshot_row(
+    pair: str, state: PriceState, formatter: NumberFormatter | None = None
+) -> SnapshotRow:
+    """Snapshot line of a pair's current state."""
+    entry = build_tray_entry(pair, state, formatter)
+    return SnapshotRow(
+        pair,
+        entry.name,
+        entry.price_text,
+        entry.percentage_text,
+        state.high_24h,
+        state.low_24h,
+        state.quote_volume_24h,
+    )
+
+
+def format_snapshot_text(
+    rows: list[SnapshotRow], timestamp: datetime | None = None, title: str = "Crypto Monitor"
+) -> str:
+    """Plain text table of name, price and change under a timestamped title."""
+    timestamp = timestamp or datetime.now()
+    lines = [f"{title} · {timestamp.strftime('%Y-%m-%d %H:%M')}"]
+    if rows:
+        name_width = max(len(row.name) for row in rows)
+        price_width = max(len(row.price_text) for row in rows)
+        for row in rows:
+            lines.append(
+                f"{row.name:<{name_width}}  {row.price_text:>{price_width}}  "
+                f"{row.percentage_text}".rstrip()
+            )
+    return "\n".join(lines)
+
+
+def write_snapshot_csv(path: Path, rows: list[SnapshotRow]) -> int:
+    """
+    Write a snapshot to a CSV file.
+
+    Returns:
+        Number of rows written
+    """
+    with open(path, "w", newline="", encoding="utf-8") as f:
+        writer = csv.writer(f)
+        writer.writerow(CSV_HEADER)
+        for row in rows:
+            writer.writerow(
+                [
+                    row.pair,
+                    row.name,
+                    row.price_text,
+                    row.percentage_text,
+                    row.high_24h,
+                    row.low_24h,
+                    row.volume_24h,
+                ]
+            )
+
+    logger.info(f"Wrote price snapshot with {len(rows)} rows to {path}")
+    return len(rows)
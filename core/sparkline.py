@@ -0,0 +1,119 @@
+"""
+In-memory sparkline buffers.
+Keeps the last 24 hours of prices per pair at 1-minute resolution so charts
+can render without fetching klines.
+"""
+
+import time
+from collections import deque
+
+RESOLUTION_SECONDS = 60
+BUFFER_SIZE = 24 * 60  # One day of minutes
+
+# Chart period settings (kline_period) and the seconds they span
+PERIOD_SECONDS = {
+    "1h": 60 * 60,
+    "4h": 4 * 60 * 60,
+    "12h": 12 * 60 * 60,
+    "24h": 24 * 60 * 60,
+    "7d": 7 * 24 * 60 * 60,
+}
+
+
+class SparklineBuffer:
+    """Ring buffer of (minute, last price) for one pair."""
+
+    def __init__(self, size: int = BUFFER_SIZE):
+        self._points: deque[tuple[int, float]] = deque(maxlen=size)
+
+    def __len__(self) -> int:
+        return len(self._points)
+
+    def resize(self, size: int):
+        """Change the capacity, dropping the oldest points beyond it."""
+        if self._points.maxlen != size:
+            self._points = deque(self._points, maxlen=size)
+
+    def add(self, price: float, timestamp: float):
+        minute = int(timestamp) // RESOLUTION_SECONDS * RESOLUTION_SECONDS
+        if self._points and self._points[-1][0] >= minute:
+            self._points[-1] = (self._points[-1][0], price)
+        else:
+            self._points.append((minute, price))
+
+    def covers(self, seconds: int, now: float) -> bool:
+        """Whether the buffer reaches back (almost) the full window."""
+        if not self._points:
+            return False
+        return self._points[0][0] <= now - seconds + RESOLUTION_SECONDS
+
+    def values(self, seconds: int, now: float, max_points: int = 0) -> list[float]:
+        """
+        Prices within the last seconds, oldest first.
+
+        Args:
+            max_points: Downsample to at most this many points (0 keeps all)
+        """
+        since = now - seconds
+        values = [price for minute, price in self._points if minute >= since]
+        if max_points and len(values) > max_points:
+            step = len(values) / max_points
+            values = [values[int(i * step)] for i in range(max_points - 1)] + [values[-1]]
+        return values
+
+
+class SparklineService:
+    """Sparkline buffers of all monitored pairs, fed from ticker updates."""
+
+    def __init__(self):
+        self._buffers: dict[str, SparklineBuffer] = {}
+        self._max_points = BUFFER_SIZE
+
+    def add_price(self, pair: str, price: float, timestamp: float | None = None):
+        if price <= 0:
+            return
+        buffer = self._buffers.get(pair)
+        if buffer is None:
+            buffer = self._buffers[pair] = SparklineBuffer(self._max_points)
+        buffer.add(price, time.time() if timestamp is None else timestamp)
+
+    def get_sparkline(self, pair: str, period: str = "24h", max_points: int = 120) -> list[float]:
+        """
+        Recent prices of a pair for a chart period.
+
+        Returns:
+            The prices, or an empty list if the buffer does not cover the period yet
+        """
+        buffer = self._buffers.get(pair)
+        seconds = PERIOD_SECONDS.get(period.lower())
+        now = time.time()
+        if buffer is None or seconds is None or not buffer.covers(seconds, now):
+            return []
+        return buffer.values(seconds, now, max_points)
+
+    def set_pairs(self, pairs: list[str]):
+        """Drop buffers of pairs no longer monitored."""
+        for pair in [pair for pair in self._buffers if pair not in pairs]:
+            del self._buffers[pair]
+
+    def set_max_points(self, max_points: int):
+        """Cap the points kept per pair, trimming existing buffers."""
+        self._max_points = max_points
+        for buffer in self._buffers.values():
+            buffer.resize(max_points)
+
+    def history_size(self) -> tuple[int, int]:
+        """Number of buffers and the points they hold."""
+        return len(self._buffers), sum(len(buffer) for buffer in self._buffers.values())
+
+
+# Global sparkline service instance
+_sparkline_service: SparklineService | None = None
+
+
+def get_sparkline_service() -> SparklineService:
+    """Get the global sparkline service instance."""
+    global _sparkline_service
+    if _sparkline_service is None:
+        _sparkline_service = SparklineService()
+    return _sparkline_service
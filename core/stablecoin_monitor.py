@@ -0,0 +1,184 @@
+"""
+Stablecoin supply monitor.
+Tracks the circulating supply of USDT and USDC across all chains, as
+published by the issuers and aggregated by DefiLlama, exposes it as index
+tickers and flags large mints and burns, which traders read as liquidity
+entering or leaving the market.
+"""
+
+import logging
+import threading
+from dataclasses import dataclass
+
+import requests
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from config.settings import get_settings_manager
+from core.market_indices import (
+    INDEX_NAMES,
+    USDC_SUPPLY_PAIR,
+    USDT_SUPPLY_PAIR,
+    change_percentage,
+    index_pairs_in_use,
+)
+from core.models import TickerData
+from core.utils.network import get_proxy_config
+
+logger = logging.getLogger(__name__)
+
+# Tracked stablecoins and their index tickers
+STABLECOIN_PAIRS = {
+    "USDT": USDT_SUPPLY_PAIR,
+    "USDC": USDC_SUPPLY_PAIR,
+}
+
+
+@dataclass
+class StablecoinSupply:
+    """Circulating supply of a stablecoin in USD."""
+
+    symbol: str
+    circulating: float
+    previous_day: float | None = None
+
+    def to_ticker(self) -> TickerData:
+        pair = STABLECOIN_PAIRS[self.symbol]
+        return TickerData(
+            pair=pair,
+            price=f"{self.circulating:.0f}",
+            percentage=change_percentage(self.circulating, self.previous_day),
+            display_name=INDEX_NAMES[pair],
+        )
+
+
+def parse_stablecoins(data: dict) -> dict[str, StablecoinSupply]:
+    """Supplies of the tracked stablecoins from the DefiLlama stablecoins list."""
+    supplies = {}
+    for asset in data.get("peggedAssets", []):
+        symbol = str(asset.get("symbol", "")).upper()
+        # Several small coins share a symbol; the issuer's coin is listed first
+        if symbol not in STABLECOIN_PAIRS or symbol in supplies:
+            continue
+        try:
+            circulating = float(asset["circulating"]["peggedUSD"])
+        except (KeyError, TypeError, ValueError):
+            continue
+        previous = (asset.get("circulatingPrevDay") or {}).get("peggedUSD")
+        supplies[symbol] = StablecoinSupply(
+            symbol=symbol,
+            circulating=circulating,
+            previous_day=float(previous) if previous else None,
+        )
+    return supplies
+
+
+class StablecoinMonitor(QObject):
+    """Periodically fetches stablecoin supplies in a background thread."""
+
+    supply_updated = pyqtSignal()
+
+    STABLECOINS_URL = "https://stablecoins.llama.fi/stablecoins"
+    REFRESH_INTERVAL_MS = 15 * 60 * 1000
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._supplies: dict[str, StablecoinSupply] = {}
+        # Supply at the last reported mint/burn (or the first reading)
+        self._baselines: dict[str, float] = {}
+        self._fetching = False
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.REFRESH_INTERVAL_MS)
+        self._timer.timeout.connect(self.refresh)
+
+    def start(self):
+        """Start periodic refresh, if supplies are watched or alerted on."""
+        if not self._is_needed():
+            return
+        if not self._timer.isActive():
+            self._timer.start()
+        self.refresh()
+
+    def stop(self):
+        """Stop periodic refresh."""
+        self._timer.stop()
+
+    def apply_settings(self):
+        """Start or stop refreshing after the alerts or watched pairs changed."""
+        if not self._is_needed():
+            self.stop()
+        elif not self._timer.isActive():
+            self.start()
+
+    def _is_needed(self) -> bool:
+        settings = self._settings_manager.settings
+        return settings.stablecoin_alerts or index_pairs_in_use(
+            settings, STABLECOIN_PAIRS.values()
+        )
+
+    def refresh(self):
+        """Fetch current supplies in a background thread."""
+        if self._fetching or not self._is_needed():
+            return
+        self._fetching = True
+        threading.Thread(target=self._fetch, daemon=True).start()
+
+    def _fetch(self):
+        try:
+            response = requests.get(
+                self.STABLECOINS_URL,
+                params={"includePrices": "false"},
+                proxies=get_proxy_config(),
+                timeout=10,
+            )
+            response.raise_for_status()
+            supplies = parse_stablecoins(response.json())
+        except Exception as e:
+            logger.warning(f"Stablecoin supply request failed: {e}")
+            return
+        finally:
+            self._fetching = False
+        if not supplies:
+            logger.warning("Stablecoin supply response had no tracked coins")
+            return
+        self._supplies = supplies
+        self.supply_updated.emit()
+
+    def check_changes(self, threshold: float) -> list[tuple[str, float, float]]:
+        """
+        Find supplies that moved by at least threshold USD since the last report.
+
+        Changes accumulate across readings, so a series of small mints is
+        reported once their total reaches the threshold.
+
+        Returns:
+            (symbol, change, current supply) for each mint (positive) or burn
+        """
+        changes = []
+        for symbol, supply in self._supplies.items():
+            baseline = self._baselines.get(symbol)
+            if baseline is None:
+                self._baselines[symbol] = supply.circulating
+                continue
+            change = supply.circulating - baseline
+            if abs(change) >= threshold:
+                changes.append((symbol, change, supply.circulating))
+                self._baselines[symbol] = supply.circulating
+        return changes
+
+    def get_tickers(self) -> list[TickerData]:
+        """Supplies as index tickers, with the change over the last day."""
+        return [supply.to_ticker() for supply in self._supplies.values()]
+
+
+# Global stablecoin monitor instance
+_stablecoin_monitor: StablecoinMonitor | None = None
+
+
+def get_stablecoin_monitor() -> StablecoinMonitor:
+    """Get the global stablecoin monitor instance."""
+    global _stablecoin_monitor
+    if _stablecoin_monitor is None:
+        _stablecoin_monitor = StablecoinMonitor()
+    return _stablecoin_monitor
@@ -0,0 +1,107 @@
+"""
+Stream Deck and Touch Portal support.
+Adds routes for macro key plugins to the local API: a ready-to-show key per
+pair, flagged while one of its alerts is unacknowledged, and acknowledging
+alerts from a key press, which acknowledges them in the app as well. Like
+the control webhooks, acknowledging needs control allowed and a token set.
+
+    GET  /api/deck              Keys of all monitored pairs
+    GET  /api/deck/{pair}       One pair's key
+    POST /api/deck/{pair}/ack   Acknowledge the pair's alert
+    POST /api/deck/ack          Acknowledge all alerts
+"""
+
+import threading
+import time
+
+from PyQt6.QtCore import QObject
+
+from core.alert_manager import get_alert_manager
+from core.local_api import ApiRequest, ApiResponse, LocalApiServer, get_local_api_server
+
+
+def deck_key(quote: dict, alert: dict | None) -> dict:
+    """A pair's key: its title lines, the direction of its move and any pending alert."""
+    change = quote["change_24h"]
+    return {
+        "pair": quote["pair"],
+        "title": f"{quote['name']}\n{quote['price_text']}\n{quote['change_text']}",
+        "price_text": quote["price_text"],
+        "change_text": quote["change_text"],
+        "direction": "up" if change > 0 else "down" if change < 0 else "flat",
+        "alert": alert,
+    }
+
+
+class StreamDeckApi(QObject):
+    """Keeps the triggered alerts until a key acknowledges them."""
+
+    def __init__(self, server: LocalApiServer, alert_manager, parent: QObject | None = None):
+        super().__init__(parent)
+        self._server = server
+        self._alert_manager = alert_manager
+        self._alerts: dict[str, dict] = {}  # pair -> latest unacknowledged alert
+        self._lock = threading.Lock()
+        alert_manager.alert_triggered.connect(self._on_alert_triggered)
+        alert_manager.alert_acknowledged.connect(self._on_alert_acknowledged)
+
+        server.add_route("GET", "/api/deck", self._list_keys)
+        server.add_route("GET", "/api/deck/{pair}", self._get_key)
+        server.add_control_route("POST", "/api/deck/{pair}/ack", self._acknowledge)
+        server.add_control_route("POST", "/api/deck/ack", self._acknowledge_all)
+
+    def _on_alert_triggered(self, pair: str, alert_type: str, target: float, current: float):
+        with self._lock:
+            self._alerts[pair] = {
+                "type": alert_type,
+                "target": target,
+                "price": current,
+                "triggered_at": time.strftime("%Y-%m-%dT%H:%M:%S%z"),
+            }
+
+    def _on_alert_acknowledged(self, alert_id: str, pair: str):
+        # Acknowledged in the app or by another key
+        with self._lock:
+            self._alerts.pop(pair, None)
+
+    def pending_alert(self, pair: str) -> dict | None:
+        with self._lock:
+            return self._alerts.get(pair)
+
+    def _list_keys(self, request: ApiRequest) -> ApiResponse:
+        quotes = self._server.quotes()
+        return ApiResponse(
+            200, {"keys": [deck_key(quote, self.pending_alert(quote["pair"])) for quote in quotes]}
+        )
+
+    def _get_key(self, request: ApiRequest) -> ApiResponse:
+        quote = self._server.quote(request.params["pair"])
+        if quote is None:
+            return ApiResponse(404, {"error": "unknown pair"})
+        return ApiResponse(200, deck_key(quote, self.pending_alert(quote["pair"])))
+
+    def _acknowledge(self, request: ApiRequest) -> ApiResponse:
+        pair = request.params["pair"].upper()
+        with self._lock:
+            alert = self._alerts.pop(pair, None)
+        self._server.call_in_main_thread(lambda: self._alert_manager.acknowledge_pair(pair))
+        return ApiResponse(200, {"acknowledged": 1 if alert else 0})
+
+    def _acknowledge_all(self, request: ApiRequest) -> ApiResponse:
+        with self._lock:
+            count = len(self._alerts)
+            self._alerts.clear()
+        self._server.call_in_main_thread(self._alert_manager.acknowledge_all)
+        return ApiResponse(200, {"acknowledged": count})
+
+
+# Global Stream Deck API instance
+_stream_deck_api: StreamDeckApi | None = None
+
+
+def get_stream_deck_api() -> StreamDeckApi:
+    """Get the global Stream Deck API instance, serving on the local API."""
+    global _stream_deck_api
+    if _stream_deck_api is None:
+        _stream_deck_api = StreamDeckApi(get_local_api_server(), get_alert_manager())
+    return _stream_deck_api
@@ -11,6 +11,8 @@ from dataclasses import dataclass
 import requests
 from PyQt6.QtCore import QObject, pyqtSignal
 
+from core.rate_limiter import get_rate_limiter
+
 logger = logging.getLogger(__name__)
 
 
@@ -149,10 +151,10 @@ class SymbolSearchService(QObject):
             # Get proxy settings
             from config.settings import get_settings_manager
 
-            settings = get_settings_manager().settings
+            proxy = get_settings_manager().proxy_store.get()
             proxies = {}
-            if settings.proxy.enabled:
-                proxy_url = settings.proxy.get_proxy_url()
+            if proxy.enabled:
+                proxy_url = proxy.get_proxy_url()
                 if proxy_url:
                     proxies = {"http": proxy_url, "https": proxy_url}
 
@@ -160,6 +162,14 @@ class SymbolSearchService(QObject):
                 symbols = self._fetch_binance_symbols(proxies)
             elif source == "OKX":
                 symbols = self._fetch_okx_symbols(proxies)
+            elif source == "SIMULATED":
+                from core.simulated_client import START_PRICES
+
+                # Any pair can be simulated; offer the ones with plausible prices
+                symbols = [
+                    SymbolInfo(f"{base}-USDT", f"{base}USDT", base, "USDT")
+                    for base in START_PRICES
+                ]
             else:
                 raise ValueError(f"Unknown data source: {source}")
 
@@ -180,6 +190,7 @@ class SymbolSearchService(QObject):
 
     def _fetch_binance_symbols(self, proxies: dict) -> list[SymbolInfo]:
         """Fetch symbols from Binance API."""
+        get_rate_limiter().acquire("BINANCE")
         response = requests.get(self.BINANCE_API, proxies=proxies, timeout=15)
         response.raise_for_status()
         data = response.json()
@@ -213,6 +224,7 @@ class SymbolSearchService(QObject):
 
     def _fetch_okx_symbols(self, proxies: dict) -> list[SymbolInfo]:
         """Fetch symbols from OKX API."""
+        get_rate_limiter().acquire("OKX")
         response = requests.get(
             self.OKX_API, params={"instType": "SPOT"}, proxies=proxies, timeout=15
         )
@@ -310,6 +322,26 @@ class SymbolSearchService(QObject):
 
         return None
 
+    def find_pair(self, base: str, quotes: tuple[str, ...] = ("USDT", "USDC")) -> str | None:
+        """
+        Find a listed pair of a base asset.
+
+        Args:
+            base: Base asset, e.g., "PEPE"
+            quotes: Preferred quote assets, in order
+
+        Returns:
+            Formatted symbol, preferring the given quotes, or None if not listed
+        """
+        base = base.upper().strip()
+        for quote in quotes:
+            if f"{base}-{quote}" in self._symbol_set:
+                return f"{base}-{quote}"
+        for s in self._symbols:
+            if s.base_asset.upper() == base:
+                return s.symbol
+        return None
+
     def clear(self) -> None:
         """Clear the cached symbols."""
         with self._lock:
@@ -0,0 +1,207 @@
+"""
+Windows taskbar badge.
+Shows the first pinned pair's trend on the app's taskbar button, so it is
+visible from the taskbar alone: an overlay icon with a green or red arrow and
+the 24h change, and the button's progress bar filled to where the price sits
+between the 24h low and high.
+"""
+
+import ctypes
+import logging
+import sys
+from dataclasses import dataclass
+
+from PyQt6.QtCore import QBuffer, QByteArray, QIODevice, QPointF, QRectF, Qt
+from PyQt6.QtGui import QColor, QFont, QPainter, QPixmap, QPolygonF
+
+from core.price_tracker import PriceState
+from core.session_range import SessionRange
+
+logger = logging.getLogger(__name__)
+
+BADGE_SIZE = 32  # Drawn at this size; Windows scales it to the overlay size
+
+# ITaskbarList3
+_CLSID_TASKBAR_LIST = "{56FDF344-FD6D-11d0-958A-006097C9A090}"
+_IID_ITASKBAR_LIST3 = "{EA1AFB91-9E28-4B86-90E9-9E9F8A5EEFAF}"
+_CLSCTX_INPROC_SERVER = 1
+_HR_INIT = 3
+_SET_PROGRESS_VALUE = 9
+_SET_PROGRESS_STATE = 10
+_SET_OVERLAY_ICON = 18
+_TBPF_NOPROGRESS = 0
+_TBPF_NORMAL = 2  # Green bar
+_TBPF_ERROR = 4  # Red bar
+_RED = "#F44336"
+_STATE_ARGTYPES = [ctypes.c_void_p, ctypes.c_int]
+
+
+@dataclass(slots=True)
+class TaskbarBadge:
+    """Trend of one pair as the taskbar shows it."""
+
+    pair: str
+    name: str
+    percentage_text: str  # e.g. "+2.50%"
+    color: str  # Up or down color of the color schema
+    trend: str  # "↑", "↓" or "" when unchanged
+    range_position: float | None  # 0 (24h low) to 100 (24h high), None when unknown
+
+    @property
+    def description(self) -> str:
+        """Text for screen readers and the overlay's accessible name."""
+        return f"{self.name} {self.percentage_text}"
+
+
+def _to_float(value: str) -> float | None:
+    try:
+        return float(value)
+    except (TypeError, ValueError):
+        return None
+
+
+def build_taskbar_badge(pair: str, name: str, state: PriceState) -> TaskbarBadge:
+    """Badge of a pair's state; the range comes from its 24h high and low."""
+    high, low = _to_float(state.high_24h), _to_float(state.low_24h)
+    position = None
+    if high is not None and low is not None:
+        position = SessionRange(high, low).position(state.current_price)
+    if position is None:
+        position = state.range_position
+    return TaskbarBadge(pair, name, state.percentage, state.color, state.trend, position)
+
+
+def badge_label(percentage_text: str) -> str:
+    """Change short enough for a 16px icon: "2.5" below 10%, whole numbers up to "99+"."""
+    change = _to_float(percentage_text.strip().rstrip("%").replace(",", "."))
+    if change is None:
+        return ""
+    change = abs(change)
+    if change < 10:
+        return f"{change:.1f}"
+    if change < 100:
+        return f"{change:.0f}"
+    return "99+"
+
+
+def render_badge(badge: TaskbarBadge, size: int = BADGE_SIZE) -> QPixmap:
+    """Rounded square in the trend color with the arrow over the change."""
+    pixmap = QPixmap(size, size)
+    pixmap.fill(Qt.GlobalColor.transparent)
+    painter = QPainter(pixmap)
+    painter.setRenderHint(QPainter.RenderHint.Antialiasing)
+    painter.setPen(Qt.PenStyle.NoPen)
+    painter.setBrush(QColor(badge.color))
+    painter.drawRoundedRect(0, 0, size, size, size / 5, size / 5)
+
+    painter.setBrush(QColor("white"))
+    if badge.trend:
+        # Arrow head in the top third, pointing the way the price moved
+        top, bottom = size * 0.08, size * 0.4
+        if badge.trend == "↓":
+            top, bottom = bottom, top
+        painter.drawPolygon(
+            QPolygonF(
+                [
+                    QPointF(size / 2, top),
+                    QPointF(size * 0.25, bottom),
+                    QPointF(size * 0.75, bottom),
+                ]
+            )
+        )
+
+    painter.setPen(QColor("white"))
+    font = QFont()
+    font.setBold(True)
+    font.setPixelSize(int(size * 0.42))
+    painter.setFont(font)
+    text_area = QRectF(0, size * 0.42, size, size * 0.58)
+    painter.drawText(text_area, Qt.AlignmentFlag.AlignCenter, badge_label(badge.percentage_text))
+    painter.end()
+    return pixmap
+
+
+class WindowsTaskbar:
+    """The ITaskbarList3 calls the badge needs; inert outside Windows or on failure."""
+
+    def __init__(self):
+        self._taskbar = None
+        if sys.platform != "win32":
+            return
+        try:
+            self._taskbar = self._create()
+            self._call(_HR_INIT, [])
+        except OSError as e:
+            logger.warning(f"Taskbar badge unavailable: {e}")
+
+    @property
+    def available(self) -> bool:
+        return self._taskbar is not None
+
+    @staticmethod
+    def _create():
+        ole32 = ctypes.windll.ole32
+        ole32.CoInitialize(None)  # Qt has usually done it; a repeat is harmless
+        clsid, iid = (ctypes.create_string_buffer(16) for _ in range(2))
+        ole32.CLSIDFromString(_CLSID_TASKBAR_LIST, clsid)
+        ole32.IIDFromString(_IID_ITASKBAR_LIST3, iid)
+        taskbar = ctypes.c_void_p()
+        result = ole32.CoCreateInstance(
+            clsid, None, _CLSCTX_INPROC_SERVER, iid, ctypes.byref(taskbar)
+        )
+        if result != 0 or not taskbar:
+            raise OSError(f"CoCreateInstance failed: {result & 0xFFFFFFFF:#010x}")
+        return taskbar
+
+    def _call(self, index: int, argtypes: list, *args) -> int:
+        vtable = ctypes.cast(
+            self._taskbar, ctypes.POINTER(ctypes.POINTER(ctypes.c_void_p))
+        ).contents
+        method = ctypes.WINFUNCTYPE(ctypes.c_long, ctypes.c_void_p, *argtypes)(vtable[index])
+        return method(self._taskbar, *args)
+
+    def set_overlay(self, hwnd: int, pixmap: QPixmap | None, description: str = ""):
+        """Put an icon over the taskbar button; None removes it."""
+        if not self.available:
+            return
+        user32 = ctypes.windll.user32
+        user32.CreateIconFromResourceEx.restype = ctypes.c_void_p
+        user32.DestroyIcon.argtypes = [ctypes.c_void_p]
+        icon = None
+        if pixmap is not None:
+            data = QByteArray()
+            buffer = QBuffer(data)
+            buffer.open(QIODevice.OpenModeFlag.WriteOnly)
+            pixmap.save(buffer, "PNG")
+            png = bytes(data)
+            # Icon resources may be PNG since Vista; 0x00030000 is the format version
+            icon = user32.CreateIconFromResourceEx(
+                png, len(png), True, 0x00030000, pixmap.width(), pixmap.height(), 0
+            )
+        argtypes = [ctypes.c_void_p, ctypes.c_void_p, ctypes.c_wchar_p]
+        self._call(_SET_OVERLAY_ICON, argtypes, hwnd, icon, description)
+        if icon:
+            # The taskbar keeps its own copy
+            user32.DestroyIcon(icon)
+
+    def set_progress(self, hwnd: int, percent: float | None, red: bool = False):
+        """Fill the button's progress bar, green or red; None removes it."""
+        if not self.available:
+            return
+        if percent is None:
+            self._call(_SET_PROGRESS_STATE, _STATE_ARGTYPES, hwnd, _TBPF_NOPROGRESS)
+            return
+        state = _TBPF_ERROR if red else _TBPF_NORMAL
+        self._call(_SET_PROGRESS_STATE, _STATE_ARGTYPES, hwnd, state)
+        argtypes = [ctypes.c_void_p, ctypes.c_ulonglong, ctypes.c_ulonglong]
+        self._call(_SET_PROGRESS_VALUE, argtypes, hwnd, round(percent), 100)
+
+    def show_badge(self, hwnd: int, badge: TaskbarBadge | None):
+        """Show a pair's badge on the window's button; None clears it."""
+        if badge is None:
+            self.set_overlay(hwnd, None)
+            self.set_progress(hwnd, None)
+            return
+        self.set_overlay(hwnd, render_badge(badge), badge.description)
+        # The bar takes the badge's color, which follows the color schema
+        self.set_progress(hwnd, badge.range_position, red=badge.color == _RED)
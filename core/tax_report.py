@@ -0,0 +1,187 @@
+"""
+Capital gains report.
+Matches sells against earlier buys with the FIFO or average cost method
+and exports the realized gains of a tax year as CSV.
+"""
+
+import csv
+import logging
+from collections import deque
+from dataclasses import dataclass
+from datetime import datetime
+from pathlib import Path
+
+from config.settings import PortfolioTransaction
+
+logger = logging.getLogger(__name__)
+
+TAX_METHODS = ("fifo", "average")
+
+CSV_HEADER = [
+    "Date Sold",
+    "Date Acquired",
+    "Asset",
+    "Quantity",
+    "Proceeds",
+    "Cost Basis",
+    "Gain",
+    "Currency",
+    "Pair",
+]
+
+
+@dataclass
+class RealizedGain:
+    """One sell matched against its cost basis."""
+
+    sold_at: float  # Sell timestamp
+    acquired_at: float | None  # Earliest matched buy (None for average cost)
+    pair: str
+    quantity: float  # Base asset sold
+    proceeds: float  # Sale proceeds after fees, in quote asset
+    cost_basis: float  # Cost of the sold quantity, in quote asset
+
+    @property
+    def asset(self) -> str:
+        return self.pair.split("-")[0]
+
+    @property
+    def quote_asset(self) -> str:
+        parts = self.pair.split("-")
+        return parts[1] if len(parts) > 1 else ""
+
+    @property
+    def gain(self) -> float:
+        return self.proceeds - self.cost_basis
+
+
+def _consume_lots(lots: deque, quantity: float) -> tuple[float, float | None]:
+    """Remove quantity from the oldest lots. Returns (cost, earliest acquisition time)."""
+    cost = 0.0
+    acquired_at = None
+    while quantity > 1e-12 and lots:
+        lot = lots[0]  # [quantity, unit_cost, timestamp]
+        if acquired_at is None:
+            acquired_at = lot[2]
+        used = min(quantity, lot[0])
+        cost += used * lot[1]
+        lot[0] -= used
+        quantity -= used
+        if lot[0] <= 1e-12:
+            lots.popleft()
+    return cost, acquired_at
+
+
+def compute_realized_gains(
+    transactions: list[PortfolioTransaction], method: str = "fifo"
+) -> list[RealizedGain]:
+    """
+    Match sells against buys per trading pair.
+
+    Fees follow compute_positions: quote-asset fees raise the cost of buys and
+    lower sale proceeds, base-asset fees reduce the quantity held.
+
+    Args:
+        transactions: Transactions in any order
+        method: "fifo" or "average"
+    """
+    if method not in TAX_METHODS:
+        raise ValueError(f"Unknown cost basis method: {method}")
+
+    gains: list[RealizedGain] = []
+    lots: dict[str, deque] = {}  # FIFO: pair -> lots
+    holdings: dict[str, list[float]] = {}  # Average: pair -> [quantity, cost_basis]
+
+    for tx in sorted(transactions, key=lambda t: t.timestamp):
+        if not tx.pair or tx.quantity <= 0:
+            continue
+
+        quote_fee = tx.fee if tx.fee_asset == tx.quote_asset else 0.0
+        base_fee = tx.fee if tx.fee_asset == tx.base_asset else 0.0
+        pair_lots = lots.setdefault(tx.pair, deque())
+        holding = holdings.setdefault(tx.pair, [0.0, 0.0])
+
+        if tx.side == "buy":
+            received = tx.quantity - base_fee
+            cost = tx.quantity * tx.price + quote_fee
+            if received > 0:
+                pair_lots.append([received, cost / received, tx.timestamp])
+            holding[0] += received
+            holding[1] += cost
+            continue
+
+        if tx.side != "sell":
+            continue
+
+        sold = min(tx.quantity, holding[0])
+        if sold <= 0:
+            logger.warning(f"Ignoring sell without holdings: {tx.pair} {tx.quantity}")
+            continue
+        proceeds = sold * tx.price - quote_fee
+
+        if method == "fifo":
+            cost_basis, acquired_at = _consume_lots(pair_lots, sold)
+            # Base-asset fees leave the holding without proceeds
+            _consume_lots(pair_lots, base_fee)
+        else:
+            acquired_at = None
+            cost_basis = holding[1] / holding[0] * sold
+
+        gains.append(RealizedGain(tx.timestamp, acquired_at, tx.pair, sold, proceeds, cost_basis))
+
+        average_cost = holding[1] / holding[0]
+        holding[0] -= sold + base_fee
+        holding[1] -= average_cost * sold
+        if holding[0] <= 1e-12:
+            holding[0] = holding[1] = 0.0
+            pair_lots.clear()
+
+    return gains
+
+
+def gains_for_year(gains: list[RealizedGain], year: int) -> list[RealizedGain]:
+    """Filter gains to sells within a calendar year (local time)."""
+    return [g for g in gains if datetime.fromtimestamp(g.sold_at).year == year]
+
+
+def write_tax_report(path: Path, gains: list[RealizedGain]) -> int:
+    """
+    Write gains as CSV for tax software.
+
+    Amounts are in each pair's quote asset, given in the Currency column.
+
+    Returns:
+        Number of rows written
+    """
+
+    def fmt_date(ts: float | None) -> str:
+        return datetime.fromtimestamp(ts).strftime("%Y-%m-%d") if ts else "Various"
+
+    with open(path, "w", newline="", encoding="utf-8") as f:
+        writer = csv.writer(f)
+        writer.writerow(CSV_HEADER)
+        for gain in gains:
+            writer.writerow(
+                [
+                    fmt_date(gain.sold_at),
+                    fmt_date(gain.acquired_at),
+                    gain.asset,
+                    f"{gain.quantity:.8f}",
+                    f"{gain.proceeds:.2f}",
+                    f"{gain.cost_basis:.2f}",
+                    f"{gain.gain:.2f}",
+                    gain.quote_asset,
+                    gain.pair,
+                ]
+            )
+
+    logger.info(f"Wrote tax report with {len(gains)} rows to {path}")
+    return len(gains)
+
+
+def export_tax_report(
+    path: Path, transactions: list[PortfolioTransaction], year: int, method: str = "fifo"
+) -> int:
+    """Compute a year's realized gains and write them to a CSV file."""
+    gains = gains_for_year(compute_realized_gains(transactions, method), year)
+    return write_tax_report(path, gains)
@@ -0,0 +1,289 @@
+"""
+Telegram bot commands.
+Lets the chat that receives the Telegram notifications talk back:
+
+    /price BTC          Current price of a monitored pair
+    /add ETH-USDT       Start monitoring a pair
+    /mute 1h            Pause alerts, for a while or until /unmute
+    /unmute             Resume alerts
+    /mute BTC 2h        Mute one pair's alerts; /unmute BTC ends it early
+    /ack BTC            Acknowledge alerts, stopping their escalation
+    /help               List the commands
+
+Updates are long-polled, so no public address is needed. Messages from any
+other chat are ignored.
+"""
+
+import logging
+import re
+import threading
+import time
+from datetime import timedelta
+
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from config.settings import get_settings_manager
+from core.alert_manager import get_alert_manager
+from core.automation import AutomationCommands, Command
+from core.control_api import check_pair
+from core.notification_channels import REQUEST_TIMEOUT, telegram_request
+from core.notifier import get_notification_service
+from core.redaction import redact
+
+logger = logging.getLogger(__name__)
+
+POLL_TIMEOUT = 30  # seconds Telegram holds a getUpdates call open
+RETRY_DELAY = 10  # seconds to wait after a failed poll
+MAX_MUTE = timedelta(days=7)
+PAIR_MUTE_DEFAULT = "1h"
+
+HELP_TEXT = (
+    "/price BTC - current price of a monitored pair\n"
+    "/add ETH-USDT - start monitoring a pair\n"
+    "/mute 1h - pause alerts (30m, 2h, 1d; no duration until /unmute)\n"
+    "/unmute - resume alerts\n"
+    "/mute BTC 2h - mute one pair's alerts (1h without a duration)\n"
+    "/unmute BTC - resume a pair's alerts\n"
+    "/ack BTC - acknowledge a pair's alerts (all without a pair)"
+)
+
+_DURATION = re.compile(r"^(\d+)([mhd]?)$")
+_DURATION_UNITS = {"": "minutes", "m": "minutes", "h": "hours", "d": "days"}
+
+
+def parse_duration(text: str) -> timedelta:
+    """
+    Duration like "30m", "2h" or "1d"; a bare number is minutes.
+
+    Raises:
+        ValueError: If it isn't a duration of at most MAX_MUTE
+    """
+    match = _DURATION.match(text.strip().lower())
+    if not match or int(match.group(1)) == 0:
+        raise ValueError(f"invalid duration: {text}")
+    duration = timedelta(**{_DURATION_UNITS[match.group(2)]: int(match.group(1))})
+    if duration > MAX_MUTE:
+        raise ValueError(f"mute for at most {MAX_MUTE.days} days")
+    return duration
+
+
+def resolve_pair(symbol: str, monitored: list[str]) -> str:
+    """
+    Pair meant by a command argument. A bare symbol like "BTC" is the first
+    monitored pair of that coin, or its USDT pair.
+
+    Raises:
+        ValueError: If it isn't shaped like a pair
+    """
+    if symbol.isalnum():
+        base = symbol.upper()
+        for pair in monitored:
+            if pair.split("-")[0] == base:
+                return pair
+        symbol = f"{base}-USDT"
+    return check_pair(symbol)
+
+
+def commands_of(updates: list[dict], chat_id: str) -> tuple[list[str], int | None]:
+    """
+    Commands sent from the given chat, and the offset acknowledging the updates.
+
+    Returns:
+        The command texts, and the next getUpdates offset (None without updates)
+    """
+    commands = []
+    offset = None
+    for update in updates:
+        offset = update["update_id"] + 1
+        message = update.get("message") or {}
+        if str(message.get("chat", {}).get("id")) != chat_id:
+            continue
+        text = message.get("text", "").strip()
+        if text.startswith("/"):
+            commands.append(text)
+    return commands, offset
+
+
+class TelegramBot(QObject):
+    """Polls the bot for commands and answers them in the chat."""
+
+    _command_received = pyqtSignal(str)  # Hands a command to the main thread
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._commands: AutomationCommands | None = None
+        # Bumped on every start and stop; a poll thread of an older generation exits
+        self._generation = 0
+        self._thread: threading.Thread | None = None
+        self._command_received.connect(self._on_command)
+
+        self._unmute_timer = QTimer(self)
+        self._unmute_timer.setSingleShot(True)
+        self._unmute_timer.timeout.connect(self._unmute)
+
+    def set_commands(self, commands: AutomationCommands):
+        self._commands = commands
+
+    def start(self):
+        """Start polling if commands are enabled and the bot is configured."""
+        config = self._settings_manager.settings.telegram
+        if not (config.enabled and config.commands_enabled and config.bot_token and config.chat_id):
+            return
+        self._generation += 1
+        self._thread = threading.Thread(
+            target=self._poll,
+            args=(self._generation, config.bot_token, config.chat_id, self._thread),
+            daemon=True,
+        )
+        self._thread.start()
+        logger.info("Telegram bot commands started")
+
+    def stop(self):
+        self._generation += 1
+
+    def apply_settings(self):
+        """Restart polling with the current settings."""
+        self.stop()
+        self.start()
+
+    def _poll(
+        self, generation: int, token: str, chat_id: str, previous: threading.Thread | None
+    ):
+        if previous is not None:
+            # A stopped poll may still hold a getUpdates call open, and Telegram
+            # answers a second one with 409 Conflict
+            previous.join()
+        offset = None
+        while generation == self._generation:
+            payload = {"timeout": POLL_TIMEOUT, "allowed_updates": ["message"]}
+            if offset is not None:
+                payload["offset"] = offset
+            try:
+                updates = telegram_request(
+                    token, "getUpdates", payload, timeout=POLL_TIMEOUT + REQUEST_TIMEOUT
+                )
+            except Exception as e:
+                logger.warning(f"Telegram polling failed: {redact(str(e))}")
+                time.sleep(RETRY_DELAY)
+                continue
+            commands, next_offset = commands_of(updates, chat_id)
+            if next_offset is not None:
+                offset = next_offset
+            for text in commands:
+                if generation == self._generation:
+                    self._command_received.emit(text)
+
+    def _on_command(self, text: str):
+        reply = self.answer(text)
+        threading.Thread(target=self._reply, args=(reply,), daemon=True).start()
+
+    def _reply(self, text: str):
+        config = self._settings_manager.settings.telegram
+        try:
+            telegram_request(
+                config.bot_token, "sendMessage", {"chat_id": config.chat_id, "text": text}
+            )
+        except Exception as e:
+            logger.warning(f"Telegram reply failed: {redact(str(e))}")
+
+    def answer(self, text: str) -> str:
+        """Reply to a command text like "/price BTC"."""
+        words = text.split()
+        # In groups commands may be addressed as /price@SomeBot
+        action = words[0].lstrip("/").split("@")[0].lower()
+        argument = words[1] if len(words) > 1 else ""
+        logger.info(f"Telegram command: {action} {argument}".rstrip())
+
+        if action in ("help", "start"):
+            return HELP_TEXT
+        if action == "mute":
+            # "/mute BTC" mutes a monitored pair, "/mute 1h" all alerts
+            if len(words) > 2 or (argument and self._is_monitored(argument)):
+                return self._mute_pair(argument, words[2] if len(words) > 2 else PAIR_MUTE_DEFAULT)
+            return self._mute(argument)
+        if action == "unmute":
+            if argument:
+                return self._unmute_pair(argument)
+            self._unmute()
+            return "alerts on"
+        if action == "ack":
+            return self._acknowledge(argument)
+        if action not in ("price", "add"):
+            return f"unknown command: /{action}, see /help"
+        if not argument:
+            return f"/{action} needs a pair, e.g. /{action} BTC"
+        if self._commands is None:
+            return "error: not ready yet"
+        try:
+            pair = resolve_pair(argument, self._settings_manager.settings.crypto_pairs)
+        except ValueError as e:
+            return f"error: {e}"
+        return self._commands.execute(Command(action, pair))
+
+    def _acknowledge(self, argument: str) -> str:
+        alert_manager = get_alert_manager()
+        if not argument:
+            count = alert_manager.acknowledge_all()
+        else:
+            try:
+                pair = resolve_pair(argument, self._settings_manager.settings.crypto_pairs)
+            except ValueError as e:
+                return f"error: {e}"
+            count = alert_manager.acknowledge_pair(pair)
+        return f"acknowledged {count} alert(s)" if count else "no alerts waiting"
+
+    def _is_monitored(self, symbol: str) -> bool:
+        monitored = self._settings_manager.settings.crypto_pairs
+        try:
+            return resolve_pair(symbol, monitored) in monitored
+        except ValueError:
+            return False
+
+    def _mute_pair(self, symbol: str, duration_text: str) -> str:
+        try:
+            pair = resolve_pair(symbol, self._settings_manager.settings.crypto_pairs)
+            duration = parse_duration(duration_text)
+        except ValueError as e:
+            return f"error: {e}"
+        get_alert_manager().mute_pair(pair, duration.total_seconds())
+        return f"{pair} alerts muted for {duration_text}"
+
+    def _unmute_pair(self, symbol: str) -> str:
+        try:
+            pair = resolve_pair(symbol, self._settings_manager.settings.crypto_pairs)
+        except ValueError as e:
+            return f"error: {e}"
+        if not get_alert_manager().unmute_pair(pair):
+            return f"{pair} alerts weren't muted"
+        return f"{pair} alerts on"
+
+    def _mute(self, argument: str) -> str:
+        duration = None
+        if argument:
+            try:
+                duration = parse_duration(argument)
+            except ValueError as e:
+                return f"error: {e}"
+        get_notification_service().set_paused(True)
+        if duration is None:
+            self._unmute_timer.stop()
+            return "alerts muted until /unmute"
+        self._unmute_timer.start(int(duration.total_seconds() * 1000))
+        return f"alerts muted for {argument}"
+
+    def _unmute(self):
+        self._unmute_timer.stop()
+        get_notification_service().set_paused(False)
+
+
+# Global Telegram bot instance
+_telegram_bot: TelegramBot | None = None
+
+
+def get_telegram_bot() -> TelegramBot:
+    """Get the global Telegram bot instance."""
+    global _telegram_bot
+    if _telegram_bot is None:
+        _telegram_bot = TelegramBot()
+    return _telegram_bot
@@ -0,0 +1,215 @@
+"""
+Token unlock calendar.
+Fetches scheduled token unlocks (vesting cliffs of team, investor and
+ecosystem allocations) from DefiLlama and reports large upcoming unlocks of
+the monitored assets, which often precede price moves.
+"""
+
+import logging
+import threading
+import time
+from dataclasses import dataclass
+
+import requests
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from config.settings import get_settings_manager
+from core.coingecko import get_coingecko_service
+from core.utils.network import get_proxy_config
+
+logger = logging.getLogger(__name__)
+
+
+@dataclass
+class TokenUnlock:
+    """The next scheduled unlock of a token."""
+
+    coin_id: str  # CoinGecko ID
+    name: str
+    timestamp: float  # Seconds
+    amount: float  # Tokens
+    price: float | None = None  # USD
+    circulating_supply: float | None = None
+
+    @property
+    def value(self) -> float | None:
+        """USD value of the unlocked tokens at the current price."""
+        return self.amount * self.price if self.price else None
+
+    @property
+    def supply_pct(self) -> float | None:
+        """Unlocked amount in percent of the circulating supply."""
+        if not self.circulating_supply:
+            return None
+        return self.amount / self.circulating_supply * 100
+
+    def hours_until(self, now: float) -> float:
+        return (self.timestamp - now) / 3600
+
+
+def _event_amount(event: dict) -> float:
+    tokens = event.get("noOfTokens") or []
+    if isinstance(tokens, (int, float)):
+        return float(tokens)
+    return float(sum(t for t in tokens if isinstance(t, (int, float))))
+
+
+def parse_unlocks(data: list[dict], now: float) -> dict[str, TokenUnlock]:
+    """
+    Parse the DefiLlama emissions list into the next unlock per CoinGecko ID.
+
+    Entries without a CoinGecko ID or future unlock are skipped.
+    """
+    unlocks = {}
+    for entry in data:
+        coin_id = entry.get("gecko_id")
+        if not coin_id and str(entry.get("token", "")).startswith("coingecko:"):
+            coin_id = entry["token"].split(":", 1)[1]
+        if not coin_id:
+            continue
+
+        timestamp, amount = 0.0, 0.0
+        next_event = entry.get("nextEvent") or {}
+        try:
+            if next_event.get("date") and next_event.get("toUnlock"):
+                timestamp = float(next_event["date"])
+                amount = float(next_event["toUnlock"])
+            else:
+                # Sum the tokens of the earliest future event date
+                events = [e for e in entry.get("events") or [] if e.get("timestamp", 0) > now]
+                if events:
+                    timestamp = float(min(e["timestamp"] for e in events))
+                    amount = sum(_event_amount(e) for e in events if e["timestamp"] == timestamp)
+        except (TypeError, ValueError):
+            continue
+        if timestamp <= now or amount <= 0:
+            continue
+
+        price = entry.get("tPrice")
+        supply = entry.get("circSupply")
+        unlocks[coin_id] = TokenUnlock(
+            coin_id=coin_id,
+            name=entry.get("name", coin_id),
+            timestamp=timestamp,
+            amount=amount,
+            price=float(price) if isinstance(price, (int, float)) else None,
+            circulating_supply=float(supply) if isinstance(supply, (int, float)) else None,
+        )
+    return unlocks
+
+
+class TokenUnlockService(QObject):
+    """
+    Keeps the unlock calendar fresh and finds large unlocks of the monitored
+    assets coming up within the alert lead time.
+
+    Pairs are matched to unlocks by the CoinGecko ID of their base asset, so
+    only assets the CoinGecko service has resolved are covered.
+    """
+
+    unlocks_updated = pyqtSignal()
+
+    UNLOCKS_URL = "https://api.llama.fi/emissions"
+    REFRESH_INTERVAL_MS = 60 * 60 * 1000
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._unlocks: dict[str, TokenUnlock] = {}  # CoinGecko ID -> next unlock
+        self._alerted: set[tuple[str, float]] = set()  # (CoinGecko ID, unlock time)
+        self._fetching = False
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.REFRESH_INTERVAL_MS)
+        self._timer.timeout.connect(self.refresh)
+
+    def start(self):
+        """Start periodic refresh, if unlock alerts are on."""
+        if not self._is_needed():
+            return
+        if not self._timer.isActive():
+            self._timer.start()
+        self.refresh()
+
+    def stop(self):
+        """Stop periodic refresh."""
+        self._timer.stop()
+
+    def apply_settings(self):
+        """Start or stop refreshing after unlock alerts were switched."""
+        if not self._is_needed():
+            self.stop()
+        elif not self._timer.isActive():
+            self.start()
+
+    def _is_needed(self) -> bool:
+        # The calendar is large; card tooltips only show unlocks while alerts are on
+        return self._settings_manager.settings.unlock_alerts
+
+    def refresh(self):
+        """Fetch the unlock calendar in a background thread."""
+        if self._fetching or not self._is_needed():
+            return
+        self._fetching = True
+        threading.Thread(target=self._fetch, daemon=True).start()
+
+    def _fetch(self):
+        try:
+            response = requests.get(self.UNLOCKS_URL, proxies=get_proxy_config(), timeout=10)
+            response.raise_for_status()
+            data = response.json()
+            if not isinstance(data, list):
+                raise ValueError("Unexpected response")
+            self._unlocks = parse_unlocks(data, time.time())
+        except Exception as e:
+            logger.warning(f"Token unlock calendar request failed: {e}")
+            return
+        finally:
+            self._fetching = False
+        logger.debug(f"Token unlock calendar updated ({len(self._unlocks)} tokens)")
+        self.unlocks_updated.emit()
+
+    def get_unlock(self, pair: str) -> TokenUnlock | None:
+        """Next unlock of a pair's base asset, or None if none is scheduled or known."""
+        market_data = get_coingecko_service().get_market_data(pair)
+        unlock = self._unlocks.get(market_data.coin_id) if market_data else None
+        if unlock is None or unlock.timestamp <= time.time():
+            return None
+        return unlock
+
+    def check_upcoming(
+        self, pairs: list[str], lead_hours: float, min_supply_pct: float
+    ) -> list[tuple[str, TokenUnlock]]:
+        """
+        Find unlocks of the given pairs that are within lead_hours and at least
+        min_supply_pct of the circulating supply.
+
+        Returns:
+            (pair, unlock) for unlocks not reported before
+        """
+        now = time.time()
+        upcoming = []
+        for pair in pairs:
+            unlock = self.get_unlock(pair)
+            if unlock is None or unlock.hours_until(now) > lead_hours:
+                continue
+            if unlock.supply_pct is None or unlock.supply_pct < min_supply_pct:
+                continue
+            key = (unlock.coin_id, unlock.timestamp)
+            if key in self._alerted:
+                continue
+            self._alerted.add(key)
+            upcoming.append((pair, unlock))
+        return upcoming
+
+
+# Global token unlock service instance
+_token_unlock_service: TokenUnlockService | None = None
+
+
+def get_token_unlock_service() -> TokenUnlockService:
+    """Get the global token unlock service instance."""
+    global _token_unlock_service
+    if _token_unlock_service is None:
+        _token_unlock_service = TokenUnlockService()
+    return _token_unlock_service
@@ -0,0 +1,183 @@
+"""
+Deposit and withdrawal monitor.
+Polls the OKX funding history and notifies when deposits arrive
+or withdrawals complete.
+"""
+
+import logging
+import threading
+from dataclasses import dataclass
+
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from config.settings import get_settings_manager
+from core.notifier import get_notification_service
+from core.okx_account import OkxRestClient, parse_float
+
+logger = logging.getLogger(__name__)
+
+# OKX deposit states: "1" credited (withdrawal locked), "2" successful
+DEPOSIT_ARRIVED_STATES = {"1", "2"}
+# OKX withdrawal states: "2" successful, "-1" failed, "-2" cancelled
+WITHDRAWAL_DONE_STATES = {"2"}
+WITHDRAWAL_FAILED_STATES = {"-1", "-2"}
+
+
+@dataclass
+class TransferEvent:
+    """A deposit or withdrawal that reached a notable state."""
+
+    kind: str  # "deposit" | "withdrawal"
+    status: str  # "arrived" | "completed" | "failed"
+    transfer_id: str
+    currency: str
+    amount: float
+    chain: str
+    tx_id: str
+
+
+def _event_status(kind: str, state: str) -> str | None:
+    if kind == "deposit":
+        return "arrived" if state in DEPOSIT_ARRIVED_STATES else None
+    if state in WITHDRAWAL_DONE_STATES:
+        return "completed"
+    if state in WITHDRAWAL_FAILED_STATES:
+        return "failed"
+    return None
+
+
+def detect_transfer_events(
+    known: dict[str, str], records: list[dict], kind: str
+) -> list[TransferEvent]:
+    """
+    Compare history records against known statuses and collect new events.
+
+    Args:
+        known: Transfer ID -> last notified status; updated in place
+        records: Raw deposit or withdrawal history records
+        kind: "deposit" or "withdrawal"
+
+    Returns:
+        Events for transfers whose status changed to a notable one
+    """
+    id_field = "depId" if kind == "deposit" else "wdId"
+    events = []
+
+    for record in records:
+        transfer_id = record.get(id_field, "")
+        if not transfer_id:
+            continue
+
+        # Pending states map to None; a deposit credited ("1") then
+        # unlocked ("2") maps to "arrived" both times and notifies once
+        status = _event_status(kind, str(record.get("state", "")))
+        if status is None or known.get(transfer_id) == status:
+            continue
+        known[transfer_id] = status
+
+        events.append(
+            TransferEvent(
+                kind=kind,
+                status=status,
+                transfer_id=transfer_id,
+                currency=record.get("ccy", ""),
+                amount=parse_float(record.get("amt")) or 0.0,
+                chain=record.get("chain", ""),
+                tx_id=record.get("txId", ""),
+            )
+        )
+
+    return events
+
+
+class TransferMonitor(QObject):
+    """
+    Periodically polls deposit and withdrawal history.
+
+    The first poll only records existing transfers, so past activity
+    is not re-notified on startup.
+    """
+
+    transfer_detected = pyqtSignal(object)  # TransferEvent
+
+    _history_loaded = pyqtSignal(list, list)  # deposits, withdrawals (to main thread)
+
+    POLL_INTERVAL_MS = 60 * 1000
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._notification_service = get_notification_service()
+        self._known_deposits: dict[str, str] = {}
+        self._known_withdrawals: dict[str, str] = {}
+        self._initialized = False
+        self._polling = False
+
+        self._history_loaded.connect(self._on_history_loaded)
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.POLL_INTERVAL_MS)
+        self._timer.timeout.connect(self.poll)
+
+    def start(self):
+        """Start polling if account credentials are configured."""
+        if not self._settings_manager.settings.okx_api.is_configured():
+            return
+        if not self._timer.isActive():
+            self._timer.start()
+        self.poll()
+
+    def stop(self):
+        """Stop polling and forget known transfers."""
+        self._timer.stop()
+        self._known_deposits.clear()
+        self._known_withdrawals.clear()
+        self._initialized = False
+
+    def poll(self):
+        """Fetch the latest funding history in a background thread."""
+        if self._polling:
+            return
+        self._polling = True
+        threading.Thread(target=self._fetch, daemon=True).start()
+
+    def _fetch(self):
+        try:
+            client = OkxRestClient(self._settings_manager.settings.okx_api)
+            self._history_loaded.emit(client.get_deposit_history(), client.get_withdrawal_history())
+        except Exception as e:
+            logger.warning(f"Failed to poll OKX funding history: {e}")
+        finally:
+            self._polling = False
+
+    def _on_history_loaded(self, deposits: list, withdrawals: list):
+        events = detect_transfer_events(self._known_deposits, deposits, "deposit")
+        events += detect_transfer_events(self._known_withdrawals, withdrawals, "withdrawal")
+
+        if not self._initialized:
+            self._initialized = True
+            return
+
+        for event in events:
+            logger.info(f"{event.kind} {event.status}: {event.amount} {event.currency}")
+            self.transfer_detected.emit(event)
+            if self._settings_manager.settings.okx_api.notify_transfers:
+                self._notification_service.send_transfer_notification(
+                    kind=event.kind,
+                    status=event.status,
+                    currency=event.currency,
+                    amount=event.amount,
+                    chain=event.chain,
+                )
+
+
+# Global transfer monitor instance
+_transfer_monitor: TransferMonitor | None = None
+
+
+def get_transfer_monitor() -> TransferMonitor:
+    """Get the global transfer monitor instance."""
+    global _transfer_monitor
+    if _transfer_monitor is None:
+        _transfer_monitor = TransferMonitor()
+    return _transfer_monitor
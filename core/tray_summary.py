@@ -0,0 +1,78 @@
+"""
+Tray price summary.
+Condenses the pinned pairs' price states into short lines for the system
+tray tooltip and menu, so prices stay visible while the window is hidden.
+One of them also feeds the mini ticker, an always-on-top single-pair window.
+"""
+
+from dataclasses import dataclass
+
+from core.highlight_rules import Highlight
+from core.number_format import NumberFormatter
+from core.price_tracker import PriceState
+from core.utils import get_display_name
+
+# Pairs shown when none are pinned: the top of the monitored list
+DEFAULT_TRAY_PAIR_COUNT = 3
+MAX_TRAY_PAIRS = 8
+
+
+@dataclass(slots=True)
+class TrayEntry:
+    """One pair's line in the tray."""
+
+    pair: str
+    name: str
+    price_text: str
+    percentage_text: str
+    highlight: Highlight | None = None  # Triggered highlight rule, marked in the text
+
+    def __str__(self) -> str:
+        marker = "● " if self.highlight else ""
+        return f"{marker}{self.name}  {self.price_text}  {self.percentage_text}"
+
+
+def tray_pairs(pinned: list[str], monitored: list[str]) -> list[str]:
+    """Pinned pairs still monitored, or the first monitored pairs if none are."""
+    pairs = [pair for pair in pinned if pair in monitored]
+    if not pairs:
+        pairs = monitored[:DEFAULT_TRAY_PAIR_COUNT]
+    return pairs[:MAX_TRAY_PAIRS]
+
+
+def mini_ticker_pair(selected: str, pairs: list[str], monitored: list[str]) -> str | None:
+    """The selected mini ticker pair while monitored, else the first tray pair."""
+    if selected and selected in monitored:
+        return selected
+    return pairs[0] if pairs else None
+
+
+def build_tray_entry(
+    pair: str, state: PriceState, formatter: NumberFormatter | None = None
+) -> TrayEntry:
+    """Render a pair's state the way its card shows it, with the formatter's separators."""
+    formatter = formatter or NumberFormatter()
+    hints = state.display_hints
+    name = state.alias or (hints.symbol if hints and hints.symbol else None)
+    if not name:
+        name = get_display_name(pair, state.display_name or None, short=True)
+    if hints:
+        return TrayEntry(
+            pair,
+            name,
+            formatter.localize(hints.price_text),
+            formatter.localize(hints.percentage_text),
+            state.highlight,
+        )
+    if state.fiat_price is not None:
+        price_text = formatter.fiat(state.fiat_price, state.fiat_currency)
+    else:
+        price_text = formatter.price(state.current_price)
+    return TrayEntry(
+        pair, name, price_text, formatter.localize(state.percentage), state.highlight
+    )
+
+
+def format_tray_tooltip(entries: list[TrayEntry], title: str = "Crypto Monitor") -> str:
+    """Tooltip listing every entry under the app name."""
+    return "\n".join([title, *(str(entry) for entry in entries)])
@@ -2,8 +2,15 @@ from PyQt6.QtCore import QObject
 
 from core.base_client import BaseExchangeClient
 from core.binance_client import BinanceClient
+from core.chainlink_client import ChainlinkClient, is_oracle_pair
 from core.dex_client import DexScreenerClient
+from core.market_indices import is_index_pair
 from core.okx_client import OkxClientManager
+from core.pool_client import UniswapPoolClient, is_pool_pair
+from core.rate_limiter import get_rate_limiter
+from core.replay_client import create_replay_client, get_replay_path, get_tick_recorder
+from core.simulated_client import SimulatedClient
+from core.virtual_pairs import is_virtual_pair
 
 
 class UnifiedExchangeClient(BaseExchangeClient):
@@ -11,54 +18,100 @@ class UnifiedExchangeClient(BaseExchangeClient):
         super().__init__(parent)
 
         self._dex_client = DexScreenerClient(self)
+        self._pool_client = UniswapPoolClient(self)
+        self._oracle_client = ChainlinkClient(self)
 
-        if source.upper() == "BINANCE":
+        if get_replay_path():
+            self._cex_client = create_replay_client(self)
+        elif source.upper() == "BINANCE":
             self._cex_client = BinanceClient(self)
+        elif source.upper() == "SIMULATED":
+            self._cex_client = SimulatedClient(self)
         else:
             self._cex_client = OkxClientManager(self)
 
         self._connect_signals(self._dex_client)
+        self._connect_signals(self._pool_client)
+        self._connect_signals(self._oracle_client)
         self._connect_signals(self._cex_client)
 
+        recorder = get_tick_recorder()
+        if recorder:
+            self.ticker_updated.connect(recorder.record)
+
     def _connect_signals(self, client: BaseExchangeClient):
         client.ticker_updated.connect(self.ticker_updated)
         client.connection_status.connect(self.connection_status)
         client.connection_state_changed.connect(self.connection_state_changed)
         client.stats_updated.connect(self.stats_updated)
         client.klines_ready.connect(self.klines_ready)
+        client.trades_received.connect(self.trades_received)
+        client.data_integrity.connect(self.data_integrity)
 
     def subscribe(self, pairs: list[str]):
         dex_pairs = []
+        pool_pairs = []
+        oracle_pairs = []
         cex_pairs = []
 
         for pair in pairs:
             if pair.lower().startswith("chain:"):
                 dex_pairs.append(pair)
+            elif is_pool_pair(pair):
+                pool_pairs.append(pair)
+            elif is_oracle_pair(pair):
+                oracle_pairs.append(pair)
             else:
                 cex_pairs.append(pair)
 
         self._dex_client.subscribe(dex_pairs)
+        self._pool_client.subscribe(pool_pairs)
+        self._oracle_client.subscribe(oracle_pairs)
         self._cex_client.subscribe(cex_pairs)
 
     def stop(self):
         self._dex_client.stop()
+        self._pool_client.stop()
+        self._oracle_client.stop()
         self._cex_client.stop()
         self.stopped.emit()
 
     def reconnect(self):
         self._dex_client.reconnect()
+        self._pool_client.reconnect()
+        self._oracle_client.reconnect()
         self._cex_client.reconnect()
 
     def get_stats(self):
         dex_stats = self._dex_client.get_stats() or {}
+        pool_stats = self._pool_client.get_stats() or {}
+        oracle_stats = self._oracle_client.get_stats() or {}
         cex_stats = self._cex_client.get_stats() or {}
-        return {"dex": dex_stats, "cex": cex_stats}
+        return {
+            "dex": dex_stats,
+            "pool": pool_stats,
+            "oracle": oracle_stats,
+            "cex": cex_stats,
+            "rate_limits": get_rate_limiter().stats(),
+        }
 
     def fetch_klines(self, pair: str, interval: str, limit: int) -> list[dict]:
+        if is_virtual_pair(pair) or is_index_pair(pair):
+            # Computed or fetched locally; candles are aggregated from its ticks
+            return []
         if pair.lower().startswith("chain:"):
             return self._dex_client.fetch_klines(pair, interval, limit)
+        if is_pool_pair(pair):
+            return self._pool_client.fetch_klines(pair, interval, limit)
+        if is_oracle_pair(pair):
+            return self._oracle_client.fetch_klines(pair, interval, limit)
         return self._cex_client.fetch_klines(pair, interval, limit)
 
     @property
     def is_connected(self) -> bool:
-        return self._cex_client.is_connected or self._dex_client.is_connected
+        return (
+            self._cex_client.is_connected
+            or self._dex_client.is_connected
+            or self._pool_client.is_connected
+            or self._oracle_client.is_connected
+        )
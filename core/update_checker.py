@@ -7,6 +7,8 @@ import logging
 import requests
 from PyQt6.QtCore import QThread, pyqtSignal
 
+from core.messages import message
+
 
 class UpdateChecker(QThread):
     """
@@ -34,7 +36,7 @@ class UpdateChecker(QThread):
             response = requests.get(self.GITHUB_API_URL, timeout=10)
 
             if response.status_code != 200:
-                self.check_failed.emit(f"GitHub API Error: {response.status_code}")
+                self.check_failed.emit(message("github_api_error", status=response.status_code))
                 return
 
             release_data = response.json()
@@ -66,6 +66,14 @@ def format_price(price: float | str, precision: int | None = None) -> str:
         return f"{val:.2f}"
 
 
+def format_compact(value: float) -> str:
+    """Format a large amount with K/M/B/T suffixes, e.g. "1.23B"."""
+    for threshold, suffix in ((1e12, "T"), (1e9, "B"), (1e6, "M"), (1e3, "K")):
+        if abs(value) >= threshold:
+            return f"{value / threshold:.2f}{suffix}"
+    return f"{value:.2f}"
+
+
 def get_display_name(pair: str, display_name: str | None = None, short: bool = False) -> str:
     """
     Get a user-friendly display name for a trading pair.
@@ -77,6 +85,9 @@ def get_display_name(pair: str, display_name: str | None = None, short: bool = F
     - DEX:
         - short=True: "Symbol" (e.g. "V2EX")
         - short=False: "Symbol (Network)" (e.g. "V2EX (Solana)")
+    - Pool: like DEX, with the base token symbol once read from the chain
+    - Virtual: the user-given name (e.g. "ETH/BTC")
+    - Index: the index name (e.g. "Fear & Greed")
 
     Args:
         pair: The raw pair string (e.g., "BTC-USDT" or "chain:solana:...")
@@ -86,6 +97,26 @@ def get_display_name(pair: str, display_name: str | None = None, short: bool = F
     Returns:
         Formatted display name.
     """
+    if pair.lower().startswith("virtual:"):
+        return pair.split(":")[1] or "Virtual"
+
+    if pair.lower().startswith("index:"):
+        return display_name or pair.split(":")[1]
+
+    if pair.lower().startswith("pool:"):
+        parts = pair.split(":")
+        network = parts[1].title() if len(parts) >= 2 else "Unknown"
+        addr = parts[2] if len(parts) >= 3 else ""
+        symbol = display_name or (f"{addr[:6]}...{addr[-4:]}" if addr else "Pool")
+        return symbol if short else f"{symbol} ({network})"
+
+    if pair.lower().startswith("oracle:"):
+        parts = pair.split(":")
+        network = parts[1].title() if len(parts) >= 2 else "Unknown"
+        addr = parts[2] if len(parts) >= 3 else ""
+        symbol = display_name or (f"{addr[:6]}...{addr[-4:]}" if addr else "Oracle")
+        return symbol if short else f"{symbol} (Chainlink {network})"
+
     if pair.lower().startswith("chain:"):
         parts = pair.split(":")
         network = parts[1].title() if len(parts) >= 2 else "Unknown"
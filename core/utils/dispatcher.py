@@ -0,0 +1,66 @@
+"""
+Shared background dispatcher.
+Per-pair background jobs (seeding candles, VWAP sessions, ...) go through one
+queue served by a few long-lived threads, instead of a new thread per pair,
+so large watchlists don't start dozens of threads and requests at once.
+"""
+
+import logging
+import queue
+import threading
+from collections.abc import Callable
+
+logger = logging.getLogger(__name__)
+
+
+class Dispatcher:
+    """Runs submitted jobs in submission order on a fixed set of daemon threads."""
+
+    def __init__(self, workers: int = 4, name: str = "dispatcher"):
+        self._workers = workers
+        self._name = name
+        self._queue: queue.Queue[Callable[[], None]] = queue.Queue()
+        self._threads: list[threading.Thread] = []
+        self._lock = threading.Lock()
+
+    def submit(self, job: Callable[[], None]):
+        """Queue a job, starting the worker threads on first use."""
+        self._queue.put(job)
+        with self._lock:
+            if not self._threads:
+                for index in range(self._workers):
+                    thread = threading.Thread(
+                        target=self._run, name=f"{self._name}-{index}", daemon=True
+                    )
+                    thread.start()
+                    self._threads.append(thread)
+
+    def pending(self) -> int:
+        """Number of jobs waiting for a worker."""
+        return self._queue.qsize()
+
+    def _run(self):
+        while True:
+            job = self._queue.get()
+            try:
+                job()
+            except Exception as e:
+                logger.error(f"Background job failed: {e}", exc_info=True)
+            finally:
+                self._queue.task_done()
+
+    def join(self):
+        """Block until every queued job has run."""
+        self._queue.join()
+
+
+# Global dispatcher instance
+_dispatcher: Dispatcher | None = None
+
+
+def get_dispatcher() -> Dispatcher:
+    """Get the global background dispatcher instance."""
+    global _dispatcher
+    if _dispatcher is None:
+        _dispatcher = Dispatcher()
+    return _dispatcher
@@ -1,16 +1,22 @@
-from config.settings import get_settings_manager
+import base64
+import socket
+
+from config.settings import ProxyConfig, WsConnectionTuning, get_settings_manager
+
+CONNECT_TIMEOUT = 10  # seconds
 
 
 def get_proxy_config() -> dict[str, str]:
-    settings = get_settings_manager().settings
+    # One snapshot, so a concurrent change can't mix old and new fields
+    proxy = get_settings_manager().proxy_store.get()
     proxies = {}
 
-    if settings.proxy.enabled:
-        scheme = settings.proxy.type.lower()
-        host = settings.proxy.host
-        port = settings.proxy.port
-        username = settings.proxy.username
-        password = settings.proxy.password
+    if proxy.enabled:
+        scheme = proxy.type.lower()
+        host = proxy.host
+        port = proxy.port
+        username = proxy.username
+        password = proxy.password
 
         if username and password:
             auth = f"{username}:{password}@"
@@ -30,3 +36,66 @@ def get_proxy_config() -> dict[str, str]:
 def get_aiohttp_proxy_url() -> str | None:
     proxies = get_proxy_config()
     return proxies.get("http") or proxies.get("https")
+
+
+def get_ws_tuning(connection: str) -> WsConnectionTuning:
+    """Transport options of an exchange WebSocket connection, e.g. "okx"."""
+    return get_settings_manager().settings.websocket.tuning(connection)
+
+
+def websockets_connect_options(tuning: WsConnectionTuning) -> dict:
+    """Keyword arguments of websockets.connect for the options."""
+    return {"compression": "deflate" if tuning.compression else None}
+
+
+def apply_socket_buffers(sock, tuning: WsConnectionTuning) -> None:
+    """Resize the buffers of a connected socket; sizes of 0 are left alone."""
+    if sock is None:
+        return
+    if tuning.read_buffer_kb > 0:
+        sock.setsockopt(socket.SOL_SOCKET, socket.SO_RCVBUF, tuning.read_buffer_kb * 1024)
+    if tuning.write_buffer_kb > 0:
+        sock.setsockopt(socket.SOL_SOCKET, socket.SO_SNDBUF, tuning.write_buffer_kb * 1024)
+
+
+def open_tunnel(
+    host: str, port: int, proxy: ProxyConfig, timeout: float = CONNECT_TIMEOUT
+) -> socket.socket:
+    """TCP connection to host:port, through the proxy if one is enabled."""
+    if not proxy.enabled:
+        return socket.create_connection((host, port), timeout=timeout)
+
+    if proxy.type == "socks5":
+        import socks
+
+        sock = socks.socksocket()
+        sock.set_proxy(
+            socks.SOCKS5,
+            proxy.host,
+            proxy.port,
+            rdns=True,
+            username=proxy.username or None,
+            password=proxy.password or None,
+        )
+        sock.settimeout(timeout)
+        sock.connect((host, port))
+        return sock
+
+    sock = socket.create_connection((proxy.host, proxy.port), timeout=timeout)
+    request = f"CONNECT {host}:{port} HTTP/1.1\r\nHost: {host}:{port}\r\n"
+    if proxy.username and proxy.password:
+        credentials = base64.b64encode(f"{proxy.username}:{proxy.password}".encode()).decode()
+        request += f"Proxy-Authorization: Basic {credentials}\r\n"
+    sock.sendall(f"{request}\r\n".encode())
+
+    response = b""
+    while b"\r\n\r\n" not in response:
+        chunk = sock.recv(4096)
+        if not chunk:
+            break
+        response += chunk
+    status_line = response.split(b"\r\n", 1)[0].decode("latin-1")
+    if " 200" not in status_line:
+        sock.close()
+        raise OSError(f"Proxy refused tunnel to {host}:{port}: {status_line}")
+    return sock
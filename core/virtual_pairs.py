@@ -0,0 +1,248 @@
+"""
+Virtual pairs.
+Synthetic tickers computed from real pairs, e.g. an ETH/BTC ratio from
+ETH-USDT / BTC-USDT or a basket average, and emitted like exchange tickers.
+
+A virtual pair is stored in the pair list as "virtual:<name>:<expression>".
+Expressions support numbers, pair symbols, + - * / and parentheses, and the
+functions avg, min, max and sum. Pair symbols bind hyphens, so subtraction
+needs spaces: "BTC-USDT - ETH-USDT".
+"""
+
+import logging
+import operator
+import re
+from collections.abc import Callable
+
+from PyQt6.QtCore import QObject, pyqtSignal
+
+from core.models import TickerData
+
+logger = logging.getLogger(__name__)
+
+VIRTUAL_PREFIX = "virtual:"
+
+FUNCTIONS: dict[str, Callable[[list[float]], float]] = {
+    "AVG": lambda values: sum(values) / len(values),
+    "MIN": min,
+    "MAX": max,
+    "SUM": sum,
+}
+
+BINARY_OPERATORS = {
+    "+": operator.add,
+    "-": operator.sub,
+    "*": operator.mul,
+    "/": operator.truediv,
+}
+
+_TOKEN_RE = re.compile(
+    r"\s*(?:(?P<number>\d+(?:\.\d*)?|\.\d+)"
+    r"|(?P<symbol>[A-Za-z][A-Za-z0-9]*(?:-[A-Za-z0-9]+)*)"
+    r"|(?P<op>[-+*/(),]))"
+)
+
+Node = Callable[[dict[str, float]], float]
+
+
+def is_virtual_pair(pair: str) -> bool:
+    return pair.lower().startswith(VIRTUAL_PREFIX)
+
+
+def make_virtual_pair(name: str, expression: str) -> str:
+    """Build the pair ID of a virtual pair."""
+    return f"{VIRTUAL_PREFIX}{name.strip().replace(':', ' ')}:{expression.strip()}"
+
+
+def parse_virtual_pair(pair: str) -> tuple[str, str]:
+    """
+    Split a virtual pair ID.
+
+    Returns:
+        (name, expression)
+    """
+    _prefix, name, expression = (pair.split(":", 2) + ["", ""])[:3]
+    return name, expression
+
+
+class VirtualExpression:
+    """
+    A parsed virtual pair expression.
+
+    Raises:
+        ValueError: If the expression is malformed
+    """
+
+    def __init__(self, text: str):
+        self.text = text
+        self.pairs: set[str] = set()
+        self._tokens = self._tokenize(text)
+        self._pos = 0
+        self._root = self._parse_sum()
+        if self._pos < len(self._tokens):
+            raise ValueError(f"Unexpected '{self._tokens[self._pos][1]}'")
+
+    @staticmethod
+    def _tokenize(text: str) -> list[tuple[str, str]]:
+        tokens = []
+        pos = 0
+        text = text.rstrip()
+        while pos < len(text):
+            match = _TOKEN_RE.match(text, pos)
+            if not match:
+                raise ValueError(f"Unexpected character '{text[pos:].strip()[:1]}'")
+            kind = match.lastgroup
+            tokens.append((kind, match.group(kind)))
+            pos = match.end()
+        if not tokens:
+            raise ValueError("Empty expression")
+        return tokens
+
+    def _peek(self) -> str | None:
+        return self._tokens[self._pos][1] if self._pos < len(self._tokens) else None
+
+    def _next(self) -> tuple[str, str]:
+        if self._pos >= len(self._tokens):
+            raise ValueError("Unexpected end of expression")
+        token = self._tokens[self._pos]
+        self._pos += 1
+        return token
+
+    def _expect(self, value: str):
+        if self._next()[1] != value:
+            raise ValueError(f"Expected '{value}'")
+
+    @staticmethod
+    def _binary(op: str, left: Node, right: Node) -> Node:
+        func = BINARY_OPERATORS[op]
+        return lambda p: func(left(p), right(p))
+
+    def _parse_sum(self) -> Node:
+        node = self._parse_product()
+        while self._peek() in ("+", "-"):
+            op = self._next()[1]
+            node = self._binary(op, node, self._parse_product())
+        return node
+
+    def _parse_product(self) -> Node:
+        node = self._parse_factor()
+        while self._peek() in ("*", "/"):
+            op = self._next()[1]
+            node = self._binary(op, node, self._parse_factor())
+        return node
+
+    def _parse_factor(self) -> Node:
+        kind, value = self._next()
+        if value == "-":
+            operand = self._parse_factor()
+            return lambda p: -operand(p)
+        if value == "(":
+            node = self._parse_sum()
+            self._expect(")")
+            return node
+        if kind == "number":
+            number = float(value)
+            return lambda p: number
+        if kind == "symbol":
+            symbol = value.upper()
+            if self._peek() == "(":
+                return self._parse_call(symbol)
+            self.pairs.add(symbol)
+            return lambda p: p[symbol]
+        raise ValueError(f"Unexpected '{value}'")
+
+    def _parse_call(self, name: str) -> Node:
+        func = FUNCTIONS.get(name)
+        if func is None:
+            raise ValueError(f"Unknown function '{name.lower()}'")
+        self._expect("(")
+        args = [self._parse_sum()]
+        while self._peek() == ",":
+            self._next()
+            args.append(self._parse_sum())
+        self._expect(")")
+        return lambda p: func([arg(p) for arg in args])
+
+    def evaluate(self, prices: dict[str, float]) -> float | None:
+        """Value for the given pair prices, or None if a price is missing or it divides by 0."""
+        try:
+            return self._root(prices)
+        except (KeyError, ZeroDivisionError):
+            return None
+
+
+class VirtualPairEngine(QObject):
+    """Evaluates the monitored virtual pairs whenever one of their components ticks."""
+
+    ticker_updated = pyqtSignal(str, object)  # virtual pair, TickerData
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._expressions: dict[str, VirtualExpression] = {}
+        self._prices: dict[str, float] = {}
+        self._open_prices: dict[str, float] = {}
+
+    def set_pairs(self, pairs: list[str]):
+        """Parse the virtual pairs among the monitored pairs."""
+        self._expressions.clear()
+        for pair in pairs:
+            if not is_virtual_pair(pair):
+                continue
+            try:
+                self._expressions[pair] = VirtualExpression(parse_virtual_pair(pair)[1])
+            except ValueError as e:
+                logger.warning(f"Invalid virtual pair {pair}: {e}")
+        components = set(self.component_pairs())
+        for prices in (self._prices, self._open_prices):
+            for pair in [pair for pair in prices if pair not in components]:
+                del prices[pair]
+
+    def component_pairs(self) -> list[str]:
+        """Real pairs the virtual pairs are computed from."""
+        return sorted({pair for expr in self._expressions.values() for pair in expr.pairs})
+
+    def update_component(self, pair: str, data: TickerData):
+        """Feed a real ticker and emit the virtual pairs that use it."""
+        pair = pair.upper()
+        dependents = [(vp, expr) for vp, expr in self._expressions.items() if pair in expr.pairs]
+        if not dependents:
+            return
+        try:
+            price = float(data.price)
+            change = float(data.percentage.strip("%").replace("+", "")) / 100
+        except ValueError:
+            return
+        self._prices[pair] = price
+        # Derive the reference (open) price back from the change percentage
+        self._open_prices[pair] = price / (1 + change) if change > -1 else 0.0
+
+        for virtual_pair, expr in dependents:
+            self._emit(virtual_pair, expr)
+
+    def _emit(self, virtual_pair: str, expr: VirtualExpression):
+        price = expr.evaluate(self._prices)
+        if price is None:
+            return
+        open_price = expr.evaluate(self._open_prices)
+        if open_price:
+            pct = (price - open_price) / abs(open_price) * 100
+            percentage = f"+{pct:.2f}%" if pct >= 0 else f"{pct:.2f}%"
+        else:
+            percentage = "0.00%"
+        name, _expression = parse_virtual_pair(virtual_pair)
+        ticker = TickerData(
+            pair=virtual_pair, price=repr(price), percentage=percentage, display_name=name
+        )
+        self.ticker_updated.emit(virtual_pair, ticker)
+
+
+# Global virtual pair engine instance
+_virtual_pair_engine: VirtualPairEngine | None = None
+
+
+def get_virtual_pair_engine() -> VirtualPairEngine:
+    """Get the global virtual pair engine instance."""
+    global _virtual_pair_engine
+    if _virtual_pair_engine is None:
+        _virtual_pair_engine = VirtualPairEngine()
+    return _virtual_pair_engine
@@ -0,0 +1,161 @@
+"""
+Session VWAP (volume-weighted average price).
+Accumulates trades from the exchange trades channel per UTC day session.
+Trades before the app started are filled in from 5-minute candles.
+"""
+
+import logging
+import time
+from dataclasses import dataclass
+
+from PyQt6.QtCore import QObject, pyqtSignal
+
+from core.utils.dispatcher import get_dispatcher
+
+logger = logging.getLogger(__name__)
+
+SESSION_SECONDS = 24 * 60 * 60
+SEED_INTERVAL = "5m"
+SEED_INTERVAL_SECONDS = 5 * 60
+
+
+def session_start(timestamp: float) -> int:
+    """Start of the UTC day session containing timestamp."""
+    return int(timestamp) // SESSION_SECONDS * SESSION_SECONDS
+
+
+@dataclass
+class SessionVwap:
+    """
+    Running sums of one pair's session.
+
+    Trades are counted from live_since on; the time before it is covered by
+    candles added with add_seed, so nothing is counted twice.
+    """
+
+    session_start: int
+    live_since: float
+    price_volume: float = 0.0
+    volume: float = 0.0
+    seed_price_volume: float = 0.0
+    seed_volume: float = 0.0
+
+    def add_trade(self, price: float, size: float, timestamp: float):
+        if timestamp < self.live_since or size <= 0:
+            return
+        self.price_volume += price * size
+        self.volume += size
+
+    def add_seed(self, klines: list[dict]):
+        """Set the seed from candles (timestamp in ms) between session start and live_since."""
+        self.seed_price_volume = 0.0
+        self.seed_volume = 0.0
+        for k in klines:
+            open_time = int(k["timestamp"]) / 1000
+            if open_time < self.session_start or open_time >= self.live_since:
+                continue
+            typical = (float(k["high"]) + float(k["low"]) + float(k["close"])) / 3
+            volume = float(k.get("volume", 0.0))
+            self.seed_price_volume += typical * volume
+            self.seed_volume += volume
+
+    @property
+    def value(self) -> float | None:
+        volume = self.volume + self.seed_volume
+        if volume <= 0:
+            return None
+        return (self.price_volume + self.seed_price_volume) / volume
+
+
+def vwap_distance_pct(price: float, vwap: float | None) -> float | None:
+    """Distance of price from VWAP in percent (positive when above)."""
+    if not vwap or price <= 0:
+        return None
+    return (price - vwap) / vwap * 100
+
+
+class VwapTracker(QObject):
+    """Tracks the session VWAP of the subscribed pairs."""
+
+    _seed_loaded = pyqtSignal(str, int, list)  # pair, session_start, klines
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._client = None
+        self._pairs: list[str] = []
+        self._sessions: dict[str, SessionVwap] = {}
+
+        self._seed_loaded.connect(self._on_seed_loaded)
+
+    def set_client(self, client):
+        """Use a new exchange client and restart every session."""
+        self._client = client
+        self._sessions.clear()
+
+    def set_pairs(self, pairs: list[str]):
+        self._pairs = list(pairs)
+        for pair in list(self._sessions):
+            if pair not in self._pairs:
+                del self._sessions[pair]
+
+    def add_trades(self, pair: str, trades: list):
+        """Add trades as (price, size, timestamp) tuples from the trades channel."""
+        if pair not in self._pairs or not trades:
+            return
+        session = self._session(pair, trades[-1][2])
+        for price, size, timestamp in trades:
+            session.add_trade(price, size, timestamp)
+
+    def _session(self, pair: str, timestamp: float) -> SessionVwap:
+        start = session_start(timestamp)
+        session = self._sessions.get(pair)
+        if session is None or session.session_start < start:
+            # Live trades count from the start of the current candle; earlier ones come from klines
+            now = int(time.time())
+            live_since = max(start, now - now % SEED_INTERVAL_SECONDS)
+            session = SessionVwap(session_start=start, live_since=live_since)
+            self._sessions[pair] = session
+            if live_since > start:
+                self._load_seed(pair, start)
+        return session
+
+    def _load_seed(self, pair: str, start: int):
+        client = self._client
+        if client is None:
+            return
+        limit = SESSION_SECONDS // SEED_INTERVAL_SECONDS
+
+        def fetch():
+            try:
+                klines = client.fetch_klines(pair, SEED_INTERVAL, limit)
+            except Exception as e:
+                logger.warning(f"Failed to load VWAP candles for {pair}: {e}")
+                return
+            if klines:
+                self._seed_loaded.emit(pair, start, klines)
+
+        get_dispatcher().submit(fetch)
+
+    def _on_seed_loaded(self, pair: str, start: int, klines: list):
+        session = self._sessions.get(pair)
+        if session and session.session_start == start:
+            session.add_seed(klines)
+
+    def get_vwap(self, pair: str) -> float | None:
+        """Session VWAP of a pair, or None without trades this session."""
+        session = self._sessions.get(pair)
+        if session is None or session.session_start < session_start(time.time()):
+            return None
+        return session.value
+
+
+# Global VWAP tracker instance
+_vwap_tracker: VwapTracker | None = None
+
+
+def get_vwap_tracker() -> VwapTracker:
+    """Get the global VWAP tracker instance."""
+    global _vwap_tracker
+    if _vwap_tracker is None:
+        _vwap_tracker = VwapTracker()
+    return _vwap_tracker
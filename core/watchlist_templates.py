@@ -0,0 +1,212 @@
+"""
+Starter watchlist templates.
+Ready-made groups ("Top 10 by Market Cap", "OKX Top Volume") a user can add
+with one click, and which seed a fresh install so it doesn't start empty.
+Templates ship with built-in pairs and are refreshed from CoinGecko and OKX
+in the background when available.
+"""
+
+import logging
+import threading
+import time
+from dataclasses import dataclass, field
+
+import requests
+from PyQt6.QtCore import QObject, pyqtSignal
+
+from core.coingecko import CoinGeckoClient
+from core.rate_limiter import get_rate_limiter
+from core.utils.network import get_proxy_config
+
+logger = logging.getLogger(__name__)
+
+TOP_MARKET_CAP = "top_market_cap"
+OKX_TOP_VOLUME = "okx_top_volume"
+
+TEMPLATE_SIZE = 10
+
+# Stablecoins and wrapped assets aren't worth watching against USDT
+EXCLUDED_ASSETS = {
+    "USDT",
+    "USDC",
+    "DAI",
+    "FDUSD",
+    "TUSD",
+    "USDE",
+    "USDS",
+    "PYUSD",
+    "BUSD",
+    "WBTC",
+    "WETH",
+    "STETH",
+    "WSTETH",
+    "WEETH",
+}
+
+
+@dataclass
+class WatchlistTemplate:
+    """A ready-made watchlist group."""
+
+    id: str
+    name: str
+    description: str
+    pairs: list[str] = field(default_factory=list)
+    updated_at: float = 0.0  # 0 for the built-in pairs
+
+
+# Built-in pairs, used until (or if never) the live lists are fetched
+BUILTIN_TEMPLATES = {
+    TOP_MARKET_CAP: WatchlistTemplate(
+        id=TOP_MARKET_CAP,
+        name="Top 10 by Market Cap",
+        description="Largest coins by market capitalization (CoinGecko)",
+        pairs=[
+            "BTC-USDT",
+            "ETH-USDT",
+            "XRP-USDT",
+            "BNB-USDT",
+            "SOL-USDT",
+            "DOGE-USDT",
+            "TRX-USDT",
+            "ADA-USDT",
+            "LINK-USDT",
+            "AVAX-USDT",
+        ],
+    ),
+    OKX_TOP_VOLUME: WatchlistTemplate(
+        id=OKX_TOP_VOLUME,
+        name="OKX Top Volume",
+        description="Most traded USDT spot pairs on OKX in the last 24h",
+        pairs=[
+            "BTC-USDT",
+            "ETH-USDT",
+            "SOL-USDT",
+            "XRP-USDT",
+            "DOGE-USDT",
+            "PEPE-USDT",
+            "SUI-USDT",
+            "OKB-USDT",
+            "TON-USDT",
+            "LTC-USDT",
+        ],
+    ),
+}
+
+
+def top_market_cap_pairs(symbols: list[str], limit: int = TEMPLATE_SIZE) -> list[str]:
+    """USDT pairs of the given coins (largest first), skipping stablecoins."""
+    pairs = []
+    for symbol in symbols:
+        symbol = symbol.upper()
+        pair = f"{symbol}-USDT"
+        if symbol in EXCLUDED_ASSETS or pair in pairs:
+            continue
+        pairs.append(pair)
+        if len(pairs) == limit:
+            break
+    return pairs
+
+
+def top_volume_pairs(tickers: list[dict], limit: int = TEMPLATE_SIZE) -> list[str]:
+    """Most traded USDT pairs of an OKX spot tickers response."""
+    volumes = []
+    for ticker in tickers:
+        inst_id = str(ticker.get("instId", ""))
+        base, _sep, quote = inst_id.partition("-")
+        if quote != "USDT" or base in EXCLUDED_ASSETS:
+            continue
+        try:
+            # volCcy24h is the quote volume for spot instruments
+            volumes.append((float(ticker.get("volCcy24h") or 0), inst_id))
+        except ValueError:
+            continue
+    volumes.sort(key=lambda item: item[0], reverse=True)
+    return [inst_id for _volume, inst_id in volumes[:limit]]
+
+
+class WatchlistTemplateService(QObject):
+    """Serves the starter templates, refreshing their pairs from the market."""
+
+    templates_updated = pyqtSignal()
+
+    OKX_TICKERS_URL = "https://www.okx.com/api/v5/market/tickers"
+    CACHE_TTL = 6 * 60 * 60
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._client = CoinGeckoClient()
+        self._templates = dict(BUILTIN_TEMPLATES)
+        self._fetching = False
+
+    def get_templates(self) -> list[WatchlistTemplate]:
+        """All templates, with the latest pairs known."""
+        return list(self._templates.values())
+
+    def get_template(self, template_id: str) -> WatchlistTemplate | None:
+        """A template by ID, or None if unknown."""
+        return self._templates.get(template_id)
+
+    def refresh(self):
+        """Fetch the live template pairs in a background thread unless they are fresh."""
+        if self._fetching:
+            return
+        oldest = min(template.updated_at for template in self._templates.values())
+        if time.time() - oldest < self.CACHE_TTL:
+            return
+        self._fetching = True
+        threading.Thread(target=self._fetch, daemon=True).start()
+
+    def _fetch(self):
+        try:
+            updated = False
+            markets = self._client.fetch_top_markets(TEMPLATE_SIZE * 2)
+            if markets:
+                updated |= self._update(
+                    TOP_MARKET_CAP, top_market_cap_pairs([m.symbol for m in markets])
+                )
+            try:
+                updated |= self._update(OKX_TOP_VOLUME, top_volume_pairs(self._fetch_okx_tickers()))
+            except Exception as e:
+                logger.warning(f"Failed to fetch OKX tickers for templates: {e}")
+            if updated:
+                self.templates_updated.emit()
+        finally:
+            self._fetching = False
+
+    def _fetch_okx_tickers(self) -> list[dict]:
+        get_rate_limiter().acquire_url(self.OKX_TICKERS_URL)
+        response = requests.get(
+            self.OKX_TICKERS_URL,
+            params={"instType": "SPOT"},
+            proxies=get_proxy_config(),
+            timeout=10,
+        )
+        response.raise_for_status()
+        return response.json().get("data") or []
+
+    def _update(self, template_id: str, pairs: list[str]) -> bool:
+        if not pairs:
+            return False
+        template = self._templates[template_id]
+        self._templates[template_id] = WatchlistTemplate(
+            id=template.id,
+            name=template.name,
+            description=template.description,
+            pairs=pairs,
+            updated_at=time.time(),
+        )
+        logger.debug(f"Template '{template.name}' updated with {len(pairs)} pairs")
+        return True
+
+
+# Global watchlist template service instance
+_template_service: WatchlistTemplateService | None = None
+
+
+def get_template_service() -> WatchlistTemplateService:
+    """Get the global watchlist template service instance."""
+    global _template_service
+    if _template_service is None:
+        _template_service = WatchlistTemplateService()
+    return _template_service
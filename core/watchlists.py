@@ -0,0 +1,111 @@
+"""
+Watchlist groups.
+Named groups of pairs ("Majors", "DeFi", "Memes") that are subscribed or
+unsubscribed as a whole. Subscribed groups are persisted and their pairs
+merged into the monitored pairs; pairs added on their own stay monitored.
+"""
+
+import logging
+
+from PyQt6.QtCore import QObject, pyqtSignal
+
+from config.settings import Watchlist, get_settings_manager
+from core.i18n import _
+from core.watchlist_templates import TOP_MARKET_CAP, get_template_service
+
+logger = logging.getLogger(__name__)
+
+
+class WatchlistManager(QObject):
+    """Creates, edits and (un)subscribes watchlist groups."""
+
+    watchlists_changed = pyqtSignal()  # Groups created, renamed, edited or removed
+    pairs_changed = pyqtSignal()  # Monitored pairs changed through a group
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+
+    def get_watchlists(self) -> list[Watchlist]:
+        """Get all watchlist groups."""
+        return self._settings_manager.settings.watchlists
+
+    def is_active(self, watchlist_id: str) -> bool:
+        """Whether the group is subscribed."""
+        return watchlist_id in self._settings_manager.settings.active_watchlists
+
+    def create_watchlist(self, name: str, pairs: list[str]) -> Watchlist:
+        """Create a new, unsubscribed group."""
+        watchlist = Watchlist(name=name.strip(), pairs=list(pairs))
+        self._settings_manager.add_watchlist(watchlist)
+        self.watchlists_changed.emit()
+        return watchlist
+
+    def create_from_template(self, template_id: str, subscribe: bool = False) -> Watchlist | None:
+        """Create a group from a starter template. Returns None if the template is unknown."""
+        template = get_template_service().get_template(template_id)
+        if template is None:
+            return None
+        watchlist = self.create_watchlist(_(template.name), template.pairs)
+        if subscribe:
+            self.subscribe(watchlist.id)
+        return watchlist
+
+    def seed_starter_watchlist(self) -> None:
+        """Give a fresh install a subscribed starter group instead of an empty list."""
+        if self._settings_manager.first_run and not self.get_watchlists():
+            self.create_from_template(TOP_MARKET_CAP, subscribe=True)
+
+    def rename_watchlist(self, watchlist_id: str, name: str) -> bool:
+        """Rename a group. Returns True if renamed."""
+        renamed = self._settings_manager.rename_watchlist(watchlist_id, name.strip())
+        if renamed:
+            self.watchlists_changed.emit()
+        return renamed
+
+    def set_pairs(self, watchlist_id: str, pairs: list[str]) -> bool:
+        """Replace a group's pairs. Returns True if the group exists."""
+        if not self._settings_manager.update_watchlist_pairs(watchlist_id, pairs):
+            return False
+        self.watchlists_changed.emit()
+        if self.is_active(watchlist_id):
+            self.pairs_changed.emit()
+        return True
+
+    def remove_watchlist(self, watchlist_id: str) -> bool:
+        """Delete a group, unsubscribing it first. Returns True if removed."""
+        was_active = self.is_active(watchlist_id)
+        if not self._settings_manager.remove_watchlist(watchlist_id):
+            return False
+        self.watchlists_changed.emit()
+        if was_active:
+            self.pairs_changed.emit()
+        return True
+
+    def subscribe(self, watchlist_id: str) -> bool:
+        """Monitor every pair of the group. Returns True if it wasn't subscribed."""
+        return self._set_active(watchlist_id, True)
+
+    def unsubscribe(self, watchlist_id: str) -> bool:
+        """Stop monitoring the group's pairs. Returns True if it was subscribed."""
+        return self._set_active(watchlist_id, False)
+
+    def _set_active(self, watchlist_id: str, active: bool) -> bool:
+        if not self._settings_manager.set_watchlist_active(watchlist_id, active):
+            return False
+        watchlist = self._settings_manager.get_watchlist(watchlist_id)
+        logger.info(f"Watchlist '{watchlist.name}' {'subscribed' if active else 'unsubscribed'}")
+        self.pairs_changed.emit()
+        return True
+
+
+# Global watchlist manager instance
+_watchlist_manager: WatchlistManager | None = None
+
+
+def get_watchlist_manager() -> WatchlistManager:
+    """Get the global watchlist manager instance."""
+    global _watchlist_manager
+    if _watchlist_manager is None:
+        _watchlist_manager = WatchlistManager()
+    return _watchlist_manager
@@ -5,14 +5,24 @@ Handles connection management, reconnection logic, and thread lifecycle.
 
 import asyncio
 import logging
+import threading
 import time
 from abc import abstractmethod
+from collections.abc import Awaitable, Callable
+from dataclasses import dataclass
 from enum import Enum
+from typing import Any
 
 from PyQt6.QtCore import QObject, QThread, pyqtSignal
 
+from core.crash_reporter import get_crash_reporter
+from core.fault_injection import get_fault_injector
+from core.messages import message
 from core.models import TickerData
 from core.reconnect_strategy import ReconnectStrategy
+from core.redaction import redact
+from core.sequence_tracker import SequenceGap, SequenceTracker
+from core.utils.network import get_aiohttp_proxy_url
 
 logger = logging.getLogger(__name__)
 
@@ -27,6 +37,23 @@ class ConnectionState(Enum):
     FAILED = "failed"
 
 
+@dataclass
+class ConnectionInfo:
+    """Snapshot of one WebSocket connection, for the diagnostics view."""
+
+    name: str
+    endpoint: str
+    proxy: str  # Proxy URL with credentials masked, or "direct"
+    state: str
+    uptime: float  # seconds, 0 while not connected
+    last_error: str
+    messages_per_second: float
+    ping_rtt_ms: float | None  # None until a pong has been timed
+    reconnect_count: int
+    subscribed_pairs: int
+    sequence_gaps: int  # Gaps found in sequenced channels
+
+
 class BaseWebSocketWorker(QThread):
     """
     Base worker thread for WebSocket connections.
@@ -41,6 +68,29 @@ class BaseWebSocketWorker(QThread):
     connection_state_changed = pyqtSignal(str, str, int)  # state, message, retry_count
     stats_updated = pyqtSignal(dict)  # connection statistics
     klines_ready = pyqtSignal(str, list)
+    trades_received = pyqtSignal(str, list)  # pair, [(price, size, timestamp)]
+    data_integrity = pyqtSignal(object)  # SequenceGap, once its resync is done
+    _tickers_buffered = pyqtSignal()  # Buffer went from empty to non-empty
+
+    # Minimum seconds between stats emitted from the message path
+    STATS_INTERVAL = 1.0
+
+    # Subscriptions go out in chunks, so large watchlists stay within the
+    # exchanges' message size and request rate limits
+    SUBSCRIBE_BATCH_SIZE = 40
+    SUBSCRIBE_BATCH_DELAY = 0.5  # seconds
+
+    # A crashed connection loop is restarted this many times before giving up
+    MAX_CRASH_RESTARTS = 5
+    CRASH_RESTART_DELAY = 5.0  # seconds
+
+    # WebSocket URL shown in diagnostics; subclasses set their own
+    endpoint = ""
+
+    # Close codes of routine server-side disconnects, such as an exchange
+    # dropping idle or day-old connections. These are reconnected and
+    # resubscribed right away without reporting an error.
+    ROUTINE_CLOSE_CODES = frozenset({1000, 1001})
 
     def __init__(self, pairs: list[str], parent: QObject | None = None):
         super().__init__(parent)
@@ -54,9 +104,104 @@ class BaseWebSocketWorker(QThread):
         self._connection_start_time = 0
         self._total_reconnect_count = 0
         self._last_error = ""
+        self._last_stats_time = 0.0
+        self._proxy_used = ""
+        self._message_count = 0
+        self._rate_window_start = time.time()
+        self._messages_per_second = 0.0
+        self._ping_sent_at = 0.0
+        self._ping_rtt: float | None = None
         self._connection_timeout = 60  # seconds
         self._ping_interval = 20  # seconds
         self._main_task = None
+        self._crash_count = 0
+        self._sequences = SequenceTracker()
+        self._sequence_gaps = 0
+        # Pending resyncs; the loop only keeps weak references to its tasks
+        self._resync_tasks: set[asyncio.Task] = set()
+
+        # Latest undelivered ticker per pair. A newer ticker replaces an older
+        # one still waiting, so a stalled main thread never backs up the read loop.
+        self._ticker_buffer: dict[str, TickerData] = {}
+        self._ticker_lock = threading.Lock()
+        self._dropped_tickers = 0
+        self._tickers_buffered.connect(self._deliver_tickers)
+
+    @property
+    def dropped_tickers(self) -> int:
+        """Tickers replaced by a newer one before reaching the main thread."""
+        return self._dropped_tickers
+
+    def _emit_ticker(self, pair: str, ticker: TickerData):
+        """Queue a ticker for delivery, dropping the pair's older undelivered one."""
+        with self._ticker_lock:
+            if pair in self._ticker_buffer:
+                self._dropped_tickers += 1
+            notify = not self._ticker_buffer
+            self._ticker_buffer[pair] = ticker
+        if notify:
+            self._tickers_buffered.emit()
+
+    def _deliver_tickers(self):
+        """Emit the buffered tickers; runs in the thread owning the worker."""
+        with self._ticker_lock:
+            tickers, self._ticker_buffer = self._ticker_buffer, {}
+        for pair, ticker in tickers.items():
+            self.ticker_updated.emit(pair, ticker)
+
+    def _dispatch_message(self, raw):
+        """Pass a received message to _handle_message, through the fault injector if enabled."""
+        self._message_count += 1
+        injector = get_fault_injector()
+        if not injector.enabled:
+            self._handle_message(raw)
+            return
+        raw = injector.corrupt(raw)
+        delay = injector.delay()
+        if delay and self._loop is not None:
+            # Held back messages can overtake each other, like on a congested link
+            self._loop.call_later(delay, self._handle_message, raw)
+        else:
+            self._handle_message(raw)
+
+    def _handle_message(self, raw):
+        """Process a received message. Subclasses that receive messages override this."""
+        pass
+
+    def _on_pong(self):
+        """Time the reply to the last ping; subclasses call this when a pong arrives."""
+        if self._ping_sent_at:
+            self._ping_rtt = time.time() - self._ping_sent_at
+            self._ping_sent_at = 0.0
+
+    def _sample_message_rate(self) -> float:
+        """Messages per second since the previous sample."""
+        now = time.time()
+        elapsed = now - self._rate_window_start
+        if elapsed >= self.STATS_INTERVAL:
+            self._messages_per_second = self._message_count / elapsed
+            self._message_count = 0
+            self._rate_window_start = now
+        return self._messages_per_second
+
+    def connection_info(self) -> ConnectionInfo:
+        """Current details of the connection; safe to call from the main thread."""
+        connected = self._connection_state == ConnectionState.CONNECTED
+        return ConnectionInfo(
+            name=self.__class__.__name__,
+            endpoint=self.endpoint,
+            proxy=self._proxy_used,
+            state=self._connection_state.value,
+            uptime=time.time() - self._connection_start_time
+            if connected and self._connection_start_time > 0
+            else 0.0,
+            last_error=redact(self._last_error),
+            messages_per_second=self._sample_message_rate(),
+            ping_rtt_ms=self._ping_rtt * 1000 if self._ping_rtt is not None else None,
+            reconnect_count=self._total_reconnect_count,
+            subscribed_pairs=len(self._subscribed_pairs),
+            sequence_gaps=self._sequence_gaps,
+        )
 
     def _update_connection_state(self, state: ConnectionState, message: str = ""):
         """Update connection state and emit signals."""
@@ -84,9 +229,23 @@ class BaseWebSocketWorker(QThread):
             if self._last_message_time > 0
             else 0,
             "last_error": self._last_error,
+            "dropped_tickers": self._dropped_tickers,
+            "sequence_gaps": self._sequence_gaps,
+            "messages_per_second": round(self._sample_message_rate(), 2),
+            "ping_rtt_ms": round(self._ping_rtt * 1000) if self._ping_rtt is not None else None,
         }
+        injector = get_fault_injector()
+        if injector.enabled:
+            stats["injected_faults"] = dict(injector.injected)
         self.stats_updated.emit(stats)
 
+    def _update_stats_throttled(self):
+        """Update statistics at most every STATS_INTERVAL, for per-message callers."""
+        now = time.time()
+        if now - self._last_stats_time >= self.STATS_INTERVAL:
+            self._last_stats_time = now
+            self._update_stats()
+
     def run(self):
         """Run the WebSocket client in asyncio event loop with auto-reconnect."""
         logger.info(
@@ -97,20 +256,22 @@ class BaseWebSocketWorker(QThread):
         self._loop = asyncio.new_event_loop()
         asyncio.set_event_loop(self._loop)
 
-        self._update_connection_state(ConnectionState.CONNECTING, "Initializing connection...")
+        self._update_connection_state(ConnectionState.CONNECTING, message("initializing"))
         self._reconnect_strategy.reset()
 
+        self._loop.set_exception_handler(self._on_loop_exception)
+
         try:
-            # Store task reference for cancellation
-            self._main_task = self._loop.create_task(self._maintain_connection())
-            self._loop.run_until_complete(self._main_task)
+            self._run_connection_loop()
         except asyncio.CancelledError:
             logger.info(f"[{self.__class__.__name__}] Main task cancelled")
-            self._update_connection_state(ConnectionState.DISCONNECTED, "Connection cancelled")
+            self._update_connection_state(
+                ConnectionState.DISCONNECTED, message("connection_cancelled")
+            )
         except Exception as e:
             logger.error(f"[{self.__class__.__name__}] Fatal error: {e}", exc_info=True)
             self._last_error = str(e)
-            self._update_connection_state(ConnectionState.FAILED, f"Fatal error: {e}")
+            self._update_connection_state(ConnectionState.FAILED, message("fatal_error", error=e))
         finally:
             logger.info(f"[{self.__class__.__name__}] Cleaning up loop...")
             # Clean up all tasks
@@ -125,7 +286,43 @@ class BaseWebSocketWorker(QThread):
             except Exception as e:
                 logger.error(f"Loop cleanup error: {e}")
 
-            self._update_connection_state(ConnectionState.DISCONNECTED, "Connection closed")
+            self._update_connection_state(
+                ConnectionState.DISCONNECTED, message("connection_closed")
+            )
+
+    def _run_connection_loop(self):
+        """Run _maintain_connection, restarting it after a crash up to MAX_CRASH_RESTARTS."""
+        while self._running:
+            # Store task reference for cancellation
+            self._main_task = self._loop.create_task(self._maintain_connection())
+            try:
+                self._loop.run_until_complete(self._main_task)
+                return
+            except asyncio.CancelledError:
+                raise
+            except Exception as e:
+                # Running out of retries is a failure, not a crash
+                if self._connection_state == ConnectionState.FAILED:
+                    raise
+                self._crash_count += 1
+                get_crash_reporter().report(e, self.__class__.__name__)
+                if self._crash_count > self.MAX_CRASH_RESTARTS:
+                    raise
+                self._last_error = str(e)
+                self._update_connection_state(
+                    ConnectionState.RECONNECTING,
+                    message("worker_restarting", seconds=f"{self.CRASH_RESTART_DELAY:g}", error=e),
+                )
+                self._main_task = self._loop.create_task(asyncio.sleep(self.CRASH_RESTART_DELAY))
+                self._loop.run_until_complete(self._main_task)
+
+    def _on_loop_exception(self, loop: asyncio.AbstractEventLoop, context: dict):
+        """Report exceptions of background tasks, which would otherwise only be printed."""
+        exc = context.get("exception")
+        if exc is None or isinstance(exc, asyncio.CancelledError):
+            loop.default_exception_handler(context)
+            return
+        get_crash_reporter().report(exc, f"{self.__class__.__name__} task")
 
     async def _maintain_connection(self):
         """
@@ -139,13 +336,17 @@ class BaseWebSocketWorker(QThread):
                     ConnectionState.CONNECTING
                     if self._reconnect_strategy.retry_count > 0
                     else ConnectionState.CONNECTING,
-                    f"Connecting... (attempt {self._reconnect_strategy.retry_count + 1})",
+                    message("connecting", attempt=self._reconnect_strategy.retry_count + 1),
                 )
 
+                self._proxy_used = redact(get_aiohttp_proxy_url() or "direct")
+                # A new connection starts without subscriptions or sequences
+                self._subscribed_pairs = set()
+                self._sequences.reset()
                 await self._connect_and_subscribe()
                 # If we reach here, connection was successful
                 self._reconnect_strategy.reset()
-                self._update_connection_state(ConnectionState.CONNECTED, "Connected")
+                self._update_connection_state(ConnectionState.CONNECTED, message("connected"))
                 self._update_stats()
 
                 # Keep connection alive with periodic checks
@@ -154,6 +355,14 @@ class BaseWebSocketWorker(QThread):
                 while self._running:
                     await asyncio.sleep(1)
 
+                    # 0. Closed by the server
+                    close_code = self._server_close_code()
+                    if close_code in self.ROUTINE_CLOSE_CODES:
+                        await self._resubscribe_after_close(close_code)
+                        continue
+                    if close_code is not None:
+                        raise ConnectionError(f"Connection closed by server ({close_code})")
+
                     # 1. Update subscriptions (Thread-safe copy)
                     # Create a copy to avoid modification during iteration
                     current_pairs = list(self.pairs)
@@ -162,17 +371,22 @@ class BaseWebSocketWorker(QThread):
 
                     # 2. Send active ping
                     if time.time() - last_ping_time > self._ping_interval:
+                        self._ping_sent_at = time.time()
                         await self._send_ping()
                         last_ping_time = time.time()
 
-                    # 3. Check heartbeat (Zombie detection)
+                    # 3. Developer fault injection
+                    if get_fault_injector().should_drop():
+                        raise ConnectionError("Injected connection drop")
+
+                    # 4. Check heartbeat (Zombie detection)
                     if self._last_message_time > 0:
                         time_since_last = time.time() - self._last_message_time
                         if time_since_last > self._connection_timeout:
                             self._last_error = f"Heartbeat timeout: {time_since_last:.1f}s"
                             self._update_connection_state(
                                 ConnectionState.RECONNECTING,
-                                f"Heartbeat timeout after {time_since_last:.1f}s, reconnecting...",
+                                message("heartbeat_timeout", seconds=f"{time_since_last:.1f}"),
                             )
                             break
 
@@ -180,7 +394,7 @@ class BaseWebSocketWorker(QThread):
                 raise  # Propagate cancellation to run()
             except Exception as e:
                 self._last_error = str(e)
-                error_msg = f"Connection failed: {e}"
+                error_msg = message("connection_failed", error=e)
 
                 # Check if we should retry
                 if self._reconnect_strategy.should_retry():
@@ -191,10 +405,64 @@ class BaseWebSocketWorker(QThread):
                     await asyncio.sleep(delay)
                 else:
                     self._update_connection_state(
-                        ConnectionState.FAILED, f"Max retries exceeded: {e}"
+                        ConnectionState.FAILED, message("max_retries_exceeded", error=e)
                     )
                     raise
 
+    def _server_close_code(self) -> int | None:
+        """
+        Close code of the current connection once the server has closed it.
+        Subclasses return their socket's close code; None while it is open.
+        """
+        return None
+
+    async def _resubscribe_after_close(self, code: int):
+        """Reopen a connection the server closed routinely and resubscribe all channels."""
+        logger.info(f"[{self.__class__.__name__}] Closed by server ({code}), resubscribing")
+        self._total_reconnect_count += 1
+        self._subscribed_pairs = set()
+        self._sequences.reset()
+        await self._connect_and_subscribe()
+        self._update_stats()
+
+    def _track_sequence(self, channel: str, pair: str, first: int, last: int):
+        """Check the IDs of a sequenced message and resync a gap before it."""
+        gap = self._sequences.check(channel, pair, first, last)
+        if gap is None:
+            return
+        self._sequence_gaps += 1
+        logger.warning(
+            f"[{self.__class__.__name__}] {pair} {channel}: {gap.missing} messages "
+            f"missing before {gap.received}, resyncing"
+        )
+        if self._loop is not None and self._loop.is_running():
+            task = self._loop.create_task(self._resync(gap))
+            self._resync_tasks.add(task)
+            task.add_done_callback(self._resync_tasks.discard)
+
+    async def _resync(self, gap: SequenceGap):
+        """Fetch the trades missed in a gap, pass them on and report the gap."""
+        try:
+            trades = await self._fetch_missed_trades(gap)
+        except Exception as e:
+            logger.warning(f"[{self.__class__.__name__}] Resync of {gap.pair} failed: {e}")
+            trades = []
+        if trades:
+            self.trades_received.emit(gap.pair, trades)
+        gap.recovered = len(trades)
+        logger.info(
+            f"[{self.__class__.__name__}] Resynced {gap.pair} {gap.channel}: "
+            f"{gap.recovered} of {gap.missing} missed messages recovered"
+        )
+        self.data_integrity.emit(gap)
+
+    async def _fetch_missed_trades(self, gap: SequenceGap) -> list[tuple[float, float, float]]:
+        """
+        Trades with IDs in the gap as (price, size, timestamp), oldest first.
+        Subclasses with sequenced channels fetch them over REST.
+        """
+        return []
+
     async def _send_ping(self):
         """
         Send a ping message to keep the connection alive.
@@ -202,6 +470,13 @@ class BaseWebSocketWorker(QThread):
         """
         pass
 
+    async def _send_batched(self, items: list, send: Callable[[list], Awaitable[Any]]):
+        """Pass items to send in chunks of SUBSCRIBE_BATCH_SIZE, pausing between chunks."""
+        for start in range(0, len(items), self.SUBSCRIBE_BATCH_SIZE):
+            if start:
+                await asyncio.sleep(self.SUBSCRIBE_BATCH_DELAY)
+            await send(items[start : start + self.SUBSCRIBE_BATCH_SIZE])
+
     @abstractmethod
     async def _connect_and_subscribe(self):
         """
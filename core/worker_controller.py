@@ -34,6 +34,10 @@ class WorkerController(QObject):
             worker.finished.connect(lambda: self._on_worker_finished(worker))
             logger.debug(f"Worker registered: {worker}")
 
+    def active_workers(self) -> list[QThread]:
+        """Workers registered and not yet stopped."""
+        return list(self._active_workers)
+
     def stop_worker(self, worker: QThread | None):
         """
         Safely stop and cleanup a worker.
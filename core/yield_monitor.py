@@ -0,0 +1,172 @@
+"""
+Mining and staking yields.
+Computes the Bitcoin hashprice (expected mining revenue per unit of hashrate)
+from mempool.space mining statistics and fetches the Ethereum staking APR
+from Lido, exposing both as index tickers so miners and stakers see their
+yield next to prices.
+"""
+
+import logging
+import threading
+from dataclasses import dataclass
+
+import requests
+from PyQt6.QtCore import QObject, QTimer, pyqtSignal
+
+from config.settings import get_settings_manager
+from core.market_indices import (
+    ETH_STAKING_PAIR,
+    HASHPRICE_PAIR,
+    INDEX_NAMES,
+    change_percentage,
+    index_pairs_in_use,
+)
+from core.models import TickerData
+from core.utils.network import get_proxy_config
+
+logger = logging.getLogger(__name__)
+
+BLOCKS_PER_DAY = 144
+SATS_PER_BTC = 100_000_000
+HASHES_PER_PETAHASH = 1e15
+
+
+@dataclass
+class Yields:
+    """Current yields; None where the source failed."""
+
+    hashprice: float | None = None  # USD per PH/s per day
+    eth_staking_apr: float | None = None  # Percent
+
+
+def compute_hashprice(reward_sats_per_day: float, hashrate: float, btc_price: float) -> float:
+    """
+    Hashprice in USD per PH/s per day.
+
+    Args:
+        reward_sats_per_day: Subsidy plus fees paid to miners per day, in sats
+        hashrate: Network hashrate in H/s
+        btc_price: BTC price in USD
+    """
+    return reward_sats_per_day / SATS_PER_BTC * btc_price / (hashrate / HASHES_PER_PETAHASH)
+
+
+class YieldMonitor(QObject):
+    """Periodically fetches mining and staking yields in a background thread."""
+
+    yields_updated = pyqtSignal()
+
+    MEMPOOL_API = "https://mempool.space/api"
+    LIDO_APR_URL = "https://eth-api.lido.fi/v1/protocol/steth/apr/sma"
+    REFRESH_INTERVAL_MS = 30 * 60 * 1000
+
+    def __init__(self, parent: QObject | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._yields: Yields | None = None
+        self._previous: Yields | None = None
+        self._fetching = False
+
+        self._timer = QTimer(self)
+        self._timer.setInterval(self.REFRESH_INTERVAL_MS)
+        self._timer.timeout.connect(self.refresh)
+
+    def start(self):
+        """Start periodic refresh, if a yield is watched or alerted on."""
+        if not self._is_needed():
+            return
+        if not self._timer.isActive():
+            self._timer.start()
+        self.refresh()
+
+    def stop(self):
+        """Stop periodic refresh."""
+        self._timer.stop()
+
+    def apply_settings(self):
+        """Start or stop refreshing after the alerts or watched pairs changed."""
+        if not self._is_needed():
+            self.stop()
+        elif not self._timer.isActive():
+            self.start()
+
+    def _is_needed(self) -> bool:
+        settings = self._settings_manager.settings
+        return index_pairs_in_use(settings, (HASHPRICE_PAIR, ETH_STAKING_PAIR))
+
+    def refresh(self):
+        """Fetch current yields in a background thread."""
+        if self._fetching or not self._is_needed():
+            return
+        self._fetching = True
+        threading.Thread(target=self._fetch, daemon=True).start()
+
+    def _fetch(self):
+        try:
+            yields = Yields(
+                hashprice=self._fetch_hashprice(), eth_staking_apr=self._fetch_eth_apr()
+            )
+        finally:
+            self._fetching = False
+        if yields.hashprice is None and yields.eth_staking_apr is None:
+            logger.warning("Failed to fetch mining and staking yields")
+            return
+        self._previous, self._yields = self._yields, yields
+        self.yields_updated.emit()
+
+    def _get(self, url: str):
+        response = requests.get(url, proxies=get_proxy_config(), timeout=10)
+        response.raise_for_status()
+        return response.json()
+
+    def _fetch_hashprice(self) -> float | None:
+        try:
+            # Rewards of the last day's blocks, subsidy and fees included
+            rewards = self._get(f"{self.MEMPOOL_API}/v1/mining/reward-stats/{BLOCKS_PER_DAY}")
+            hashrate = self._get(f"{self.MEMPOOL_API}/v1/mining/hashrate/3d")["currentHashrate"]
+            btc_price = self._get(f"{self.MEMPOOL_API}/v1/prices")["USD"]
+            return compute_hashprice(float(rewards["totalReward"]), float(hashrate), btc_price)
+        except Exception as e:
+            logger.debug(f"Hashprice request failed: {e}")
+            return None
+
+    def _fetch_eth_apr(self) -> float | None:
+        try:
+            return float(self._get(self.LIDO_APR_URL)["data"]["smaApr"])
+        except Exception as e:
+            logger.debug(f"Lido staking APR request failed: {e}")
+            return None
+
+    def get_tickers(self) -> list[TickerData]:
+        """Yields as index tickers, with the change since the previous reading."""
+        if self._yields is None:
+            return []
+        previous = self._previous or Yields()
+        tickers = []
+        for pair, value, previous_value in (
+            (HASHPRICE_PAIR, self._yields.hashprice, previous.hashprice),
+            (ETH_STAKING_PAIR, self._yields.eth_staking_apr, previous.eth_staking_apr),
+        ):
+            if value is None:
+                continue
+            tickers.append(
+                TickerData(
+                    pair=pair,
+                    price=f"{value:.2f}",
+                    percentage=change_percentage(value, previous_value),
+                    display_name=INDEX_NAMES[pair],
+                )
+            )
+        return tickers
+
+
+# Global yield monitor instance
+_yield_monitor: YieldMonitor | None = None
+
+
+def get_yield_monitor() -> YieldMonitor:
+    """Get the global yield monitor instance."""
+    global _yield_monitor
+    if _yield_monitor is None:
+        _yield_monitor = YieldMonitor()
+    return _yield_monitor
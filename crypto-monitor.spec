@@ -5,7 +5,7 @@ a = Analysis(
     ['main.py'],
     pathex=[],
     binaries=[],
-    datas=[('assets/icons', 'assets/icons'), ('assets/sounds', 'assets/sounds'), ('i18n', 'i18n')],
+    datas=[('assets/icons', 'assets/icons'), ('assets/sounds', 'assets/sounds'), ('assets/replay', 'assets/replay'), ('i18n', 'i18n')],
     hiddenimports=['winsdk', 'winsdk.windows.ui.notifications', 'winsdk.windows.data.xml.dom', 'winsdk.windows.foundation', 'winsdk.windows.foundation.collections', 'winsdk.windows.storage.streams', 'winsdk.windows.system', 'packaging', 'desktop_notifier.resources'],
     hookspath=[],
     hooksconfig={},
@@ -3,6 +3,7 @@ Crypto Monitor - PyQt6 Desktop Application
 Main entry point.
 """
 
+import argparse
 import logging
 import os
 import sys
@@ -11,17 +12,130 @@ from PyQt6.QtCore import Qt
 from PyQt6.QtWidgets import QApplication
 
 from config.settings import get_settings_manager
+from core.automation import SCHEME, parse_command, send_command
+from core.crash_reporter import get_crash_reporter
+from core.fault_injection import enable_fault_injection, parse_fault_spec
 from core.logger import setup_logging
+from core.replay_client import (
+    DEFAULT_RECORDING,
+    enable_recording,
+    enable_replay,
+    load_recording,
+)
+from core.watchlists import get_watchlist_manager
 from ui.main_window import MainWindow
+from ui.widgets.master_password_dialog import UnlockDialog
 
 log_level_env = os.environ.get("LOG_LEVEL", "INFO").upper()
 log_level = getattr(logging, log_level_env, logging.INFO)
 
 setup_logging(log_level=log_level)
+logger = logging.getLogger(__name__)
+
+
+def parse_args() -> argparse.Namespace:
+    """Parse our options, leaving Qt's own arguments in place."""
+    parser = argparse.ArgumentParser(description="Crypto Monitor")
+    parser.add_argument(
+        "--benchmark",
+        type=int,
+        metavar="MSG_PER_SEC",
+        help="push synthetic ticks through the data pipeline and report throughput/latency",
+    )
+    parser.add_argument("--benchmark-pairs", type=int, default=50, metavar="N")
+    parser.add_argument("--benchmark-seconds", type=float, default=10.0, metavar="S")
+    parser.add_argument(
+        "--minimized",
+        action="store_true",
+        help="start in the system tray (used when launched at login)",
+    )
+    parser.add_argument(
+        "--chaos",
+        metavar="SPEC",
+        help="developer mode injecting network faults, e.g. drop=0.01,delay=0.5,corrupt=0.02",
+    )
+    parser.add_argument(
+        "--replay",
+        nargs="?",
+        const=DEFAULT_RECORDING,
+        metavar="FILE",
+        help="play back recorded tickers instead of the exchange feed (default: bundled sample)",
+    )
+    parser.add_argument("--replay-speed", type=float, default=1.0, metavar="X")
+    parser.add_argument(
+        "--record",
+        metavar="FILE",
+        help="write the received tickers to a recording for --replay",
+    )
+    parser.add_argument(
+        "--command",
+        metavar="COMMAND",
+        help='automation command, e.g. "price BTC-USDT"; crypto-monitor:// links work as well',
+    )
+    args, unknown = parser.parse_known_args()
+    # Launchers pass links and may pass other arguments, such as files, which must not
+    # stop the app from starting
+    links = [arg for arg in unknown if arg.lower().startswith(f"{SCHEME}:")]
+    others = [arg for arg in unknown if arg not in links]
+    if others:
+        logger.info(f"Ignoring unknown arguments: {' '.join(others)}")
+    if args.command:
+        try:
+            args.automation_command = parse_command(args.command)
+        except ValueError as e:
+            parser.error(f"--command: {e}")
+    elif links:
+        try:
+            args.automation_command = parse_command(links[0])
+            args.command = links[0]
+        except ValueError as e:
+            logger.warning(f"Ignoring link {links[0]}: {e}")
+    if args.chaos:
+        try:
+            args.fault_injector = parse_fault_spec(args.chaos)
+        except ValueError as e:
+            parser.error(f"--chaos: {e}")
+    if args.benchmark is not None and (args.benchmark < 1 or args.benchmark_pairs < 1):
+        parser.error("--benchmark and --benchmark-pairs must be at least 1")
+    if args.replay_speed <= 0:
+        parser.error("--replay-speed must be positive")
+    if args.replay:
+        try:
+            load_recording(args.replay)
+        except (OSError, ValueError) as e:
+            parser.error(f"--replay: {e}")
+    return args
+
+
+def run_benchmark(app: QApplication, args: argparse.Namespace) -> int:
+    """Run the pipeline benchmark without showing the window."""
+    from core.benchmark import PipelineBenchmark
+    from core.market_data_controller import MarketDataController
+
+    controller = MarketDataController()
+    benchmark = PipelineBenchmark(
+        controller, args.benchmark, args.benchmark_pairs, args.benchmark_seconds
+    )
+
+    def report(result):
+        print(result.summary())
+        app.quit()
+
+    benchmark.finished.connect(report)
+    benchmark.start()
+    return app.exec()
 
 
 def main():
     """Main application entry point."""
+    args = parse_args()
+    if args.chaos:
+        enable_fault_injection(args.fault_injector)
+    if args.replay:
+        enable_replay(args.replay, args.replay_speed)
+    if args.record:
+        enable_recording(args.record)
+
     # Enable high DPI scaling
     QApplication.setHighDpiScaleFactorRoundingPolicy(
         Qt.HighDpiScaleFactorRoundingPolicy.PassThrough
@@ -30,18 +144,40 @@ def main():
     app = QApplication(sys.argv)
     app.setApplicationName("Crypto Monitor")
 
+    if args.command:
+        # A running instance carries out the command; otherwise it runs once started
+        reply = send_command(args.command)
+        if reply is not None:
+            print(reply)
+            sys.exit(1 if reply.startswith("error") else 0)
+
     from core.version import __version__
 
     app.setApplicationVersion(__version__)
 
     # Load settings (which initializes language loader)
     settings_manager = get_settings_manager()
+    if settings_manager.locked and not UnlockDialog.unlock(settings_manager):
+        sys.exit(0)
+    # Uncaught exceptions are logged instead of aborting the app
+    get_crash_reporter().install()
     if settings_manager.settings.proxy.enabled:
         settings_manager._apply_proxy_env()
 
+    if args.benchmark:
+        sys.exit(run_benchmark(app, args))
+
+    # A fresh install starts with a starter group instead of an empty list
+    get_watchlist_manager().seed_starter_watchlist()
+
     # Create and show main window
     window = MainWindow()
-    window.show()
+    if args.minimized:
+        window.start_minimized()
+    else:
+        window.show()
+    if args.command:
+        logger.info(window.run_automation_command(args.automation_command))
 
     sys.exit(app.exec())
 
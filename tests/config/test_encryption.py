@@ -0,0 +1,31 @@
+import pytest
+
+from config.encryption import DecryptionError, SettingsCipher, is_encrypted
+
+
+def test_round_trip_with_fresh_nonces():
+    cipher = SettingsCipher("secret")
+    first = cipher.encrypt('{"language": "en_US"}')
+    second = cipher.encrypt('{"language": "en_US"}')
+
+    assert is_encrypted(first)
+    assert first != second
+    assert cipher.decrypt(first) == '{"language": "en_US"}'
+    assert SettingsCipher.unlock("secret", second).decrypt(first) == '{"language": "en_US"}'
+
+
+def test_wrong_password_and_damaged_files_are_rejected():
+    text = SettingsCipher("secret").encrypt("{}")
+
+    with pytest.raises(DecryptionError):
+        SettingsCipher.unlock("guess", text)
+    with pytest.raises(DecryptionError):
+        SettingsCipher.unlock("secret", text.replace('"nonce": "', '"nonce": "AAAA'))
+    with pytest.raises(DecryptionError):
+        SettingsCipher.unlock("secret", '{"format": "crypto-monitor-encrypted"}')
+
+
+def test_is_encrypted_ignores_plain_settings():
+    assert not is_encrypted('{"language": "en_US"}')
+    assert not is_encrypted("not json")
+    assert not is_encrypted("[]")
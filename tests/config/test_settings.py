@@ -4,7 +4,20 @@ from unittest.mock import MagicMock, patch
 
 import pytest
 
-from config.settings import AppSettings, ProxyConfig, SettingsManager
+from config.encryption import is_encrypted
+from config.secrets import SERVICE_NAME, SecretStore
+from config.settings import (
+    DEFAULT_PORTFOLIO_ID,
+    AppSettings,
+    Portfolio,
+    PortfolioAlert,
+    PortfolioTransaction,
+    ProxyConfig,
+    SettingsManager,
+    Watchlist,
+    WsConnectionTuning,
+)
+from config.store import ConfigStore
 
 
 class TestSettingsManager:
@@ -21,12 +34,17 @@ class TestSettingsManager:
             manager.config_dir = temp_config_dir
             manager.config_file = temp_config_dir / "settings.json"
             manager.settings = AppSettings()
+            manager.proxy_store = ConfigStore(ProxyConfig())
             manager.migration_manager = MagicMock()
             manager.migration_manager.migrate_if_needed.return_value = (
                 False,
                 "No migration needed",
                 None,
             )
+            manager._disk_snapshot = None
+            manager._secret_store = SecretStore()
+            manager._cipher = None
+            manager.locked = False
             return manager
 
     def test_load_defaults_when_file_missing(self, settings_manager):
@@ -69,6 +87,39 @@ class TestSettingsManager:
 
         assert settings_manager.remove_pair("ETH-USDT") is False
 
+    def test_pair_update_intervals(self, settings_manager):
+        settings_manager.settings.crypto_pairs = ["BTC-USDT"]
+
+        settings_manager.update_pair_update_interval("BTC-USDT", 5)
+        assert settings_manager.settings.pair_update_intervals == {"BTC-USDT": 5}
+
+        settings_manager.settings = AppSettings()
+        loaded_settings = settings_manager.load(auto_migrate=False)
+        assert loaded_settings.pair_update_intervals == {"BTC-USDT": 5}
+
+        # Realtime is the default and not stored; removed pairs drop their interval
+        settings_manager.update_pair_update_interval("BTC-USDT", 0)
+        assert settings_manager.settings.pair_update_intervals == {}
+        settings_manager.update_pair_update_interval("BTC-USDT", 30)
+        settings_manager.remove_pair("BTC-USDT")
+        assert settings_manager.settings.pair_update_intervals == {}
+
+    def test_ws_connection_tuning_persists(self, settings_manager):
+        # Binance keeps aiohttp's default of no compression
+        assert settings_manager.settings.websocket.tuning("binance").compression is False
+        assert settings_manager.settings.websocket.tuning("okx").compression is True
+
+        settings_manager.update_ws_connections(
+            {"okx": WsConnectionTuning(compression=False, read_buffer_kb=256)}
+        )
+
+        settings_manager.settings = AppSettings()
+        loaded = settings_manager.load(auto_migrate=False).websocket
+        assert loaded.tuning("okx") == WsConnectionTuning(compression=False, read_buffer_kb=256)
+        # Connections missing from the file get their defaults
+        assert loaded.tuning("okx_private") == WsConnectionTuning()
+        assert loaded.tuning("binance").compression is False
+
     def test_load_handles_corrupted_file(self, settings_manager):
         with open(settings_manager.config_file, "w") as f:
             f.write("{invalid json")
@@ -100,3 +151,216 @@ class TestSettingsManager:
         assert settings.data_source == "Binance"
         assert settings.websocket.auto_reconnect is True
         assert settings.alerts == []
+
+    def test_add_transactions_skips_duplicates(self, settings_manager):
+        first = PortfolioTransaction(
+            pair="BTC-USDT", quantity=1, price=100, source="okx_csv", external_id="1"
+        )
+        duplicate = PortfolioTransaction(
+            pair="BTC-USDT", quantity=1, price=100, source="okx_csv", external_id="1"
+        )
+        manual = PortfolioTransaction(pair="BTC-USDT", quantity=2, price=90)
+
+        assert settings_manager.add_transactions([first, duplicate, manual]) == 2
+        assert settings_manager.add_transactions([duplicate]) == 0
+
+        settings = settings_manager.load(auto_migrate=False)
+        assert len(settings.transactions) == 2
+        assert {t.source for t in settings.transactions} == {"okx_csv", "manual"}
+
+    def test_remove_portfolio_drops_its_data(self, settings_manager):
+        trading = Portfolio(name="Trading")
+        settings_manager.add_portfolio(trading)
+        settings_manager.update_active_portfolio(trading.id)
+        settings_manager.add_transactions(
+            [
+                PortfolioTransaction(pair="BTC-USDT", quantity=1, price=100),
+                PortfolioTransaction(
+                    portfolio_id=trading.id, pair="ETH-USDT", quantity=1, price=10
+                ),
+            ]
+        )
+        settings_manager.add_portfolio_alert(PortfolioAlert(portfolio_id=trading.id))
+
+        assert settings_manager.remove_portfolio(trading.id)
+        assert not settings_manager.remove_portfolio(DEFAULT_PORTFOLIO_ID)
+
+        settings = settings_manager.load(auto_migrate=False)
+        assert [p.id for p in settings.portfolios] == [DEFAULT_PORTFOLIO_ID]
+        assert settings.active_portfolio == DEFAULT_PORTFOLIO_ID
+        assert [t.pair for t in settings.transactions] == ["BTC-USDT"]
+        assert settings.portfolio_alerts == []
+
+    def test_watchlist_subscriptions_merge_pairs(self, settings_manager):
+        settings_manager.update_pairs(["BTC-USDT", "DOGE-USDT"])
+        majors = Watchlist(name="Majors", pairs=["btc-usdt", "ETH-USDT"])
+        defi = Watchlist(name="DeFi", pairs=["UNI-USDT", "ETH-USDT"])
+        settings_manager.add_watchlist(majors)
+        settings_manager.add_watchlist(defi)
+        assert majors.pairs == ["BTC-USDT", "ETH-USDT"]
+
+        assert settings_manager.set_watchlist_active(majors.id, True)
+        assert settings_manager.set_watchlist_active(defi.id, True)
+        assert not settings_manager.set_watchlist_active(defi.id, True)
+        assert settings_manager.settings.crypto_pairs == [
+            "BTC-USDT",
+            "DOGE-USDT",
+            "ETH-USDT",
+            "UNI-USDT",
+        ]
+
+        # ETH-USDT is still held by the subscribed DeFi group
+        assert settings_manager.set_watchlist_active(majors.id, False)
+        assert settings_manager.settings.crypto_pairs == ["DOGE-USDT", "ETH-USDT", "UNI-USDT"]
+
+        settings = settings_manager.load(auto_migrate=False)
+        assert [w.name for w in settings.watchlists] == ["Majors", "DeFi"]
+        assert settings.active_watchlists == [defi.id]
+
+        assert settings_manager.remove_watchlist(defi.id)
+        assert settings_manager.settings.crypto_pairs == ["DOGE-USDT"]
+        assert settings_manager.settings.active_watchlists == []
+
+    def test_pair_aliases_persist_until_pair_removed(self, settings_manager):
+        settings_manager.update_pairs(["BTC-USDT", "ETH-USDT"])
+        settings_manager.update_pair_alias("BTC-USDT", "  Bitcoin ")
+        settings_manager.update_pair_alias("ETH-USDT", "Ether")
+        settings_manager.update_pair_alias("ETH-USDT", "")
+
+        settings = settings_manager.load(auto_migrate=False)
+        assert settings.pair_aliases == {"BTC-USDT": "Bitcoin"}
+
+        settings_manager.remove_pair("BTC-USDT")
+        assert settings_manager.settings.pair_aliases == {}
+
+    def test_update_proxy_publishes_snapshot(self, settings_manager):
+        changes = []
+        settings_manager.proxy_store.subscribe(changes.append)
+        proxy = ProxyConfig(enabled=True, host="10.0.0.1", port=1080)
+
+        with patch.dict(os.environ, {}, clear=False):
+            settings_manager.update_proxy(proxy)
+            settings_manager.update_proxy(ProxyConfig(enabled=True, host="10.0.0.1", port=1080))
+
+        assert changes == [proxy]
+        snapshot = settings_manager.proxy_store.get()
+        assert snapshot is not settings_manager.settings.proxy
+        # Editing the live settings doesn't touch the published snapshot
+        settings_manager.settings.proxy.port = 9999
+        assert settings_manager.proxy_store.get().port == 1080
+
+    def test_add_pairs_saves_once(self, settings_manager):
+        settings_manager.settings.crypto_pairs = ["BTC-USDT"]
+        with patch.object(settings_manager, "save") as save:
+            added = settings_manager.add_pairs(["btc-usdt", "eth-usdt", "SOL-USDT", "ETH-USDT"])
+
+        assert added == ["ETH-USDT", "SOL-USDT"]
+        assert settings_manager.settings.crypto_pairs == ["BTC-USDT", "ETH-USDT", "SOL-USDT"]
+        save.assert_called_once()
+
+    def test_pinned_pairs_dropped_with_pair(self, settings_manager):
+        settings_manager.update_pairs(["BTC-USDT", "ETH-USDT"])
+        assert settings_manager.update_pair_pinned("ETH-USDT", True)
+        assert not settings_manager.update_pair_pinned("ETH-USDT", True)
+        assert settings_manager.load(auto_migrate=False).pinned_pairs == ["ETH-USDT"]
+
+        settings_manager.remove_pair("ETH-USDT")
+        assert settings_manager.settings.pinned_pairs == []
+
+    def test_reload_if_changed_ignores_own_saves(self, settings_manager):
+        settings_manager.update_pairs(["BTC-USDT"])
+        assert not settings_manager.reload_if_changed()
+
+        data = json.loads(settings_manager.config_file.read_text(encoding="utf-8"))
+        data["crypto_pairs"] = ["BTC-USDT", "ETH-USDT"]
+        data["proxy"]["enabled"] = True
+        settings_manager.config_file.write_text(json.dumps(data), encoding="utf-8")
+
+        with patch.object(settings_manager, "_apply_proxy_env") as apply_proxy_env:
+            assert settings_manager.reload_if_changed()
+        apply_proxy_env.assert_called_once()
+        assert settings_manager.settings.crypto_pairs == ["BTC-USDT", "ETH-USDT"]
+        assert settings_manager.proxy_store.get().enabled
+        assert not settings_manager.reload_if_changed()
+
+    def test_reload_if_changed_keeps_settings_on_invalid_file(self, settings_manager):
+        settings_manager.update_pairs(["BTC-USDT"])
+
+        settings_manager.config_file.write_text('{"crypto_pairs": [', encoding="utf-8")
+        assert not settings_manager.reload_if_changed()
+
+        settings_manager.config_file.write_text('{"proxy": {"bogus": 1}}', encoding="utf-8")
+        assert not settings_manager.reload_if_changed()
+        assert settings_manager.settings.crypto_pairs == ["BTC-USDT"]
+
+    def test_secrets_moved_to_keychain(self, settings_manager):
+        keychain = {}
+        backend = MagicMock()
+        backend.get_password.side_effect = lambda service, name: keychain.get((service, name))
+        backend.set_password.side_effect = lambda service, name, value: keychain.update(
+            {(service, name): value}
+        )
+        settings_manager.config_file.write_text(
+            json.dumps({"proxy": {"password": "hunter2"}, "etherscan_api_key": "KEY"}),
+            encoding="utf-8",
+        )
+
+        settings_manager._secret_store = SecretStore(backend)
+        settings = settings_manager.load(auto_migrate=False)
+
+        assert settings.proxy.password == "hunter2"
+        assert keychain[(SERVICE_NAME, "proxy.password")] == "hunter2"
+        assert keychain[(SERVICE_NAME, "etherscan_api_key")] == "KEY"
+        data = json.loads(settings_manager.config_file.read_text(encoding="utf-8"))
+        assert data["proxy"]["password"] == ""
+        assert data["etherscan_api_key"] == ""
+
+        # A fresh start reads them back from the keychain
+        settings_manager._secret_store = SecretStore(backend)
+        assert settings_manager.load(auto_migrate=False).etherscan_api_key == "KEY"
+
+    def test_unreadable_secrets_are_kept(self, settings_manager):
+        keychain = {(SERVICE_NAME, "etherscan_api_key"): "KEY"}
+        backend = MagicMock()
+        backend.get_password.side_effect = RuntimeError("keychain is locked")
+        backend.set_password.side_effect = lambda service, name, value: keychain.update(
+            {(service, name): value}
+        )
+
+        settings_manager._secret_store = SecretStore(backend)
+        settings = settings_manager.load(auto_migrate=False)
+        assert settings.etherscan_api_key == ""
+        settings_manager.save()
+        backend.delete_password.assert_not_called()
+        assert keychain[(SERVICE_NAME, "etherscan_api_key")] == "KEY"
+
+        # A value entered meanwhile replaces it
+        settings.etherscan_api_key = "NEW-KEY"
+        settings_manager.save()
+        assert keychain[(SERVICE_NAME, "etherscan_api_key")] == "NEW-KEY"
+
+    def test_master_password_encrypts_settings(self, settings_manager):
+        settings_manager.settings.etherscan_api_key = "ETHERSCAN-KEY-123"
+        settings_manager.set_master_password("correct horse")
+
+        text = settings_manager.config_file.read_text(encoding="utf-8")
+        assert is_encrypted(text)
+        assert "ETHERSCAN-KEY-123" not in text
+
+        # A fresh start is locked and doesn't overwrite the file
+        settings_manager._cipher = None
+        settings_manager.settings = AppSettings()
+        settings_manager.load(auto_migrate=False)
+        assert settings_manager.locked
+        settings_manager.save()
+        assert settings_manager.config_file.read_text(encoding="utf-8") == text
+
+        assert not settings_manager.unlock("wrong")
+        assert settings_manager.locked
+        assert settings_manager.unlock("correct horse")
+        assert not settings_manager.locked
+        assert settings_manager.settings.etherscan_api_key == "ETHERSCAN-KEY-123"
+
+        settings_manager.set_master_password("")
+        data = json.loads(settings_manager.config_file.read_text(encoding="utf-8"))
+        assert data["etherscan_api_key"] == "ETHERSCAN-KEY-123"
@@ -0,0 +1,51 @@
+import threading
+
+from config.store import ConfigStore
+
+
+def test_set_notifies_only_on_change():
+    store = ConfigStore({"port": 1})
+    changes = []
+    store.subscribe(changes.append)
+
+    assert not store.set({"port": 1})
+    assert store.set({"port": 2})
+    assert changes == [{"port": 2}]
+    assert store.get() == {"port": 2}
+
+    store.unsubscribe(changes.append)
+    store.set({"port": 3})
+    assert changes == [{"port": 2}]
+
+
+def test_failing_subscriber_does_not_block_others():
+    store = ConfigStore(0)
+    seen = []
+
+    def fail(_value):
+        raise RuntimeError("boom")
+
+    store.subscribe(fail)
+    store.subscribe(seen.append)
+    store.set(1)
+
+    assert seen == [1]
+
+
+def test_concurrent_readers_see_whole_snapshots():
+    store = ConfigStore((0, 0))
+    torn = []
+
+    def read():
+        for _ in range(10_000):
+            a, b = store.get()
+            if a != b:
+                torn.append((a, b))
+
+    reader = threading.Thread(target=read)
+    reader.start()
+    for i in range(10_000):
+        store.set((i, i))
+    reader.join()
+
+    assert torn == []
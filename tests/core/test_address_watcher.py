@@ -0,0 +1,49 @@
+from core.address_watcher import (
+    AddressTransfer,
+    AddressWatcher,
+    btc_net_amount,
+    eth_net_amount,
+    is_valid_address,
+)
+
+BTC_ADDRESS = "bc1qgdjqv0av3q56jvd82tkdjpy7gdp9ut8tlqmgrpmv24sq90ecnvqqjwvw97"
+ETH_ADDRESS = "0x00000000219ab540356cBB839Cbe05303d7705Fa"
+
+
+def test_is_valid_address():
+    assert is_valid_address("BTC", BTC_ADDRESS)
+    assert is_valid_address("BTC", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa")
+    assert is_valid_address("ETH", ETH_ADDRESS)
+    assert not is_valid_address("ETH", BTC_ADDRESS)
+    assert not is_valid_address("BTC", "bc1-not-an-address")
+
+
+def test_btc_net_amount():
+    tx = {
+        "vin": [{"prevout": {"scriptpubkey_address": BTC_ADDRESS, "value": 5 * 10**8}}],
+        "vout": [
+            {"scriptpubkey_address": "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "value": 3 * 10**8},
+            {"scriptpubkey_address": BTC_ADDRESS, "value": 2 * 10**8 - 1000},
+        ],
+    }
+    assert btc_net_amount(tx, BTC_ADDRESS) == -(3 * 10**8 + 1000) / 10**8
+
+
+def test_eth_net_amount():
+    tx = {"from": "0xabc", "to": ETH_ADDRESS.lower(), "value": str(32 * 10**18), "isError": "0"}
+    assert eth_net_amount(tx, ETH_ADDRESS) == 32
+    assert eth_net_amount({**tx, "from": ETH_ADDRESS, "to": "0xabc"}, ETH_ADDRESS) == -32
+    assert eth_net_amount({**tx, "isError": "1"}, ETH_ADDRESS) == 0
+
+
+def test_process_seeds_first_batch_and_filters_small():
+    watcher = AddressWatcher()
+
+    def transfer(tx_id, amount):
+        return AddressTransfer("ETH", ETH_ADDRESS, "", tx_id, amount, 0)
+
+    assert watcher.process("ETH", ETH_ADDRESS, [transfer("a", 5000)], 1000) == []
+    large = watcher.process(
+        "ETH", ETH_ADDRESS, [transfer("a", 5000), transfer("b", -2000), transfer("c", 10)], 1000
+    )
+    assert [t.tx_id for t in large] == ["b"]
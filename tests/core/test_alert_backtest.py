@@ -0,0 +1,70 @@
+from config.settings import PriceAlert
+from core.alert_backtest import backtest_alert, preview_interval, price_path, simulate_alert
+
+
+def _kline(hour, open_, high, low, close):
+    return {
+        "timestamp": hour * 3600 * 1000,
+        "open": open_,
+        "high": high,
+        "low": low,
+        "close": close,
+    }
+
+
+KLINES = [
+    _kline(0, 100, 103, 99, 102),  # Rising: 100, 99, 103, 102
+    _kline(1, 102, 106, 101, 101.5),  # Falling: 102, 106, 101, 101.5
+    _kline(2, 101.5, 102, 96, 97),  # Falling: 101.5, 102, 96, 97
+]
+
+
+def test_preview_interval_fits_one_request():
+    alert = PriceAlert(alert_type="price_above")
+    assert preview_interval(alert, 1) == ("15m", 96)
+    assert preview_interval(alert, 7) == ("1h", 168)
+    assert preview_interval(alert, 30) == ("4h", 180)
+
+    alert.on_candle_close = True
+    alert.timeframe = "1d"
+    assert preview_interval(alert, 7) == ("1d", 7)
+
+
+def test_price_path_follows_the_candle_direction():
+    path = price_path(KLINES[:2], "1h", closes_only=False)
+    assert [price for _time, price in path] == [100, 99, 103, 102, 102, 106, 101, 101.5]
+    assert path[1][0] == 900
+
+    closes = price_path(KLINES, "1h", closes_only=True)
+    assert closes == [(3600, 102), (7200, 101.5), (10800, 97)]
+
+
+def test_wicks_count_unless_judged_on_close():
+    alert = PriceAlert(alert_type="price_above", target_price=105, repeat_mode="repeat")
+    assert len(backtest_alert(alert, KLINES, "1h", 1).fires) == 1
+
+    alert.on_candle_close = True
+    assert backtest_alert(alert, KLINES, "1h", 1).fires == []
+
+
+def test_repeat_mode_and_cooldown():
+    path = [(0, 110.0), (30, 111.0), (90, 112.0), (200, 90.0), (260, 113.0)]
+    alert = PriceAlert(alert_type="price_above", target_price=100, repeat_mode="once")
+    assert simulate_alert(alert, path) == [(0, 110.0)]
+
+    alert.repeat_mode = "repeat"
+    alert.cooldown_seconds = 60
+    assert simulate_alert(alert, path) == [(0, 110.0), (90, 112.0), (260, 113.0)]
+
+
+def test_trailing_and_ladder_alerts_arm_at_the_first_price():
+    trailing = PriceAlert(alert_type="trailing_high", target_price=5, repeat_mode="repeat")
+    trailing.cooldown_seconds = 0
+    # 5% below the 106 high is 100.7, first passed by the low of the last candle
+    assert backtest_alert(trailing, KLINES, "1h", 1).fires == [(9000, 96)]
+
+    ladder = PriceAlert(alert_type="price_ladder_pct", target_price=3, repeat_mode="repeat")
+    ladder.cooldown_seconds = 0
+    path = [(0, 100.0), (1, 102.0), (2, 103.5), (3, 104.0), (4, 100.0)]
+    assert simulate_alert(ladder, path) == [(2, 103.5), (4, 100.0)]
+    assert ladder.last_triggered_value is None
@@ -4,7 +4,8 @@ from unittest.mock import MagicMock, patch
 import pytest
 
 from config.settings import PriceAlert
-from core.alert_manager import AlertManager
+from core.alert_manager import AlertManager, ladder_rung
+from core.indicators import IndicatorSnapshot
 
 
 class TestAlertManager:
@@ -17,6 +18,7 @@ class TestAlertManager:
         ):
             mock_settings_mgr = MagicMock()
             mock_settings_mgr.settings.alerts = []
+            mock_settings_mgr.settings.muted_pairs = {}
             mock_settings_mgr.get_alerts_for_pair.return_value = []
             mock_get_settings.return_value = mock_settings_mgr
 
@@ -162,6 +164,39 @@ class TestAlertManager:
         assert alert_once.enabled is False
         alert_manager._settings_manager.update_alert.assert_called_with(alert_once)
 
+    def test_custom_message_replaces_the_built_in_text(self, alert_manager):
+        alert = self.create_alert("price_above", 100.0)
+        alert.message_template = "🚀 {symbol} {price} ({change}) · {rule}"
+        alert_manager._settings_manager.get_alerts_for_pair.return_value = [alert]
+
+        alert_manager.check_alerts("BTC-USDT", "90.00", "+0.0%")
+        alert_manager.check_alerts("BTC-USDT", "110.00", "+1.5%")
+
+        kwargs = alert_manager._notification_service.send_price_alert.call_args.kwargs
+        assert kwargs["custom_message"] == "🚀 BTC 110.00 (+1.50%) · Crosses Above 100.00"
+
+    def test_candle_close_alerts_ignore_wicks(self, alert_manager):
+        alert = self.create_alert("price_above", 100.0)
+        alert.on_candle_close = True
+        alert_manager._settings_manager.get_alerts_for_pair.return_value = [alert]
+        send = alert_manager._notification_service.send_price_alert
+
+        # A wick above the target between closes doesn't fire
+        alert_manager.check_alerts("BTC-USDT", "110.00", "+0.0%")
+        alert_manager._on_candle_closed("BTC-USDT", "1h", 99.0)
+        alert_manager.check_alerts("BTC-USDT", "105.00", "+0.0%")
+        assert send.call_count == 0
+
+        # Closes of another timeframe are not this alert's
+        alert_manager._on_candle_closed("BTC-USDT", "4h", 101.0)
+        alert_manager.check_alerts("BTC-USDT", "98.00", "+0.0%")
+        assert send.call_count == 0
+
+        alert_manager._on_candle_closed("BTC-USDT", "1h", 101.0)
+        alert_manager.check_alerts("BTC-USDT", "98.00", "+0.0%")
+        assert send.call_count == 1
+        assert send.call_args.kwargs["current_price"] == 101.0
+
     def test_check_alerts_string_parsing(self, alert_manager):
         """Test robust parsing of price strings (commas, symbols)."""
         pair = "ETH-USDT"
@@ -173,3 +208,137 @@ class TestAlertManager:
         assert alert_manager._notification_service.send_price_alert.call_count == 1
         call_args = alert_manager._notification_service.send_price_alert.call_args[1]
         assert call_args["current_price"] == 2500.0
+
+    def test_rsi_below_trigger(self, alert_manager):
+        alert = self.create_alert("rsi_below", 30.0)
+
+        assert alert_manager._should_trigger(alert, 100.0) is False
+        assert alert_manager._should_trigger(alert, 100.0, indicators=IndicatorSnapshot()) is False
+        assert (
+            alert_manager._should_trigger(alert, 100.0, indicators=IndicatorSnapshot(rsi=35.0))
+            is False
+        )
+        assert (
+            alert_manager._should_trigger(alert, 100.0, indicators=IndicatorSnapshot(rsi=25.0))
+            is True
+        )
+
+    def test_golden_cross_fires_once_per_cross(self, alert_manager):
+        alert = self.create_alert("golden_cross", 0.0, repeat_mode="repeat", cooldown=0)
+        alert_manager._settings_manager.get_alerts_for_pair.return_value = [alert]
+        emas = {50: 99.0, 200: 100.0}
+        alert_manager._indicator_engine = MagicMock()
+        alert_manager._indicator_engine.get_ema.side_effect = lambda pair, tf, period: emas[period]
+
+        alert_manager.check_alerts("BTC-USDT", 100.0)
+        emas[50] = 101.0
+        alert_manager.check_alerts("BTC-USDT", 100.0)
+        alert_manager.check_alerts("BTC-USDT", 100.0)
+
+        notifier = alert_manager._notification_service
+        assert notifier.send_ma_cross_alert.call_count == 1
+        assert notifier.send_ma_cross_alert.call_args[1]["timeframe"] == "1h"
+
+    def test_acknowledging_a_fired_alert(self, alert_manager):
+        alert = self.create_alert("price_above", 100.0)
+        alert_manager._settings_manager.settings.alerts = [alert]
+        alert_manager._settings_manager.get_alerts_for_pair.return_value = [alert]
+        acknowledged = []
+        alert_manager.alert_acknowledged.connect(lambda *args: acknowledged.append(args))
+
+        assert alert_manager.acknowledge_alert(alert.id) is False  # Hasn't fired
+        alert_manager.check_alerts("BTC-USDT", 99.0)
+        alert_manager.check_alerts("BTC-USDT", 101.0)
+        assert alert.awaiting_acknowledgement
+
+        assert alert_manager.acknowledge_pair("BTC-USDT") == 1
+        assert not alert.awaiting_acknowledgement
+        assert acknowledged == [(alert.id, "BTC-USDT")]
+        alert_manager._settings_manager.update_alert.assert_called_with(alert)
+        assert alert_manager.acknowledge_all() == 0
+
+    def test_muted_pairs_do_not_fire(self, alert_manager):
+        alert = self.create_alert("price_above", 100.0)
+        alert_manager._settings_manager.get_alerts_for_pair.return_value = [alert]
+        settings = alert_manager._settings_manager.settings
+        alert_manager._settings_manager.update_muted_pairs.side_effect = lambda muted: setattr(
+            settings, "muted_pairs", muted
+        )
+        changes = []
+        alert_manager.pair_mute_changed.connect(lambda *args: changes.append(args))
+
+        until = alert_manager.mute_pair("BTC-USDT", 3600)
+        assert alert_manager.muted_until("BTC-USDT") == until
+        alert_manager.check_alerts("BTC-USDT", 99.0)
+        alert_manager.check_alerts("BTC-USDT", 101.0)
+        alert_manager._notification_service.send_price_alert.assert_not_called()
+
+        assert alert_manager.unmute_pair("BTC-USDT")
+        assert not alert_manager.unmute_pair("BTC-USDT")
+        assert changes == [("BTC-USDT", until), ("BTC-USDT", 0.0)]
+
+        # Expired mutes end by themselves
+        settings.muted_pairs = {"BTC-USDT": time.time() - 1}
+        assert alert_manager.muted_until("BTC-USDT") is None
+        assert settings.muted_pairs == {}
+
+    def test_price_ladder_fires_on_every_step(self, alert_manager):
+        alert = self.create_alert("price_ladder_pct", 2.0, repeat_mode="repeat", cooldown=0)
+        alert_manager._settings_manager.get_alerts_for_pair.return_value = [alert]
+        notifier = alert_manager._notification_service
+
+        # Armed at the first price
+        alert_manager.check_alerts("BTC-USDT", 100.0)
+        assert alert.last_triggered_value == 100.0
+        alert_manager.check_alerts("BTC-USDT", 101.9)
+        notifier.send_price_alert.assert_not_called()
+
+        alert_manager.check_alerts("BTC-USDT", 102.0)
+        assert notifier.send_price_alert.call_args[1]["previous_price"] == 100.0
+        assert alert.last_triggered_value == pytest.approx(102.0)
+
+        # A jump over two rungs fires once and lands on the higher one
+        alert_manager.check_alerts("BTC-USDT", 106.5)
+        assert notifier.send_price_alert.call_count == 2
+        assert alert.last_triggered_value == pytest.approx(100.0 * 1.02**3)
+
+        alert_manager.check_alerts("BTC-USDT", 104.0)
+        assert notifier.send_price_alert.call_count == 3
+        assert alert.last_triggered_value == pytest.approx(100.0 * 1.02**2)
+
+
+def test_ladder_rung():
+    assert ladder_rung(100.0, 102.0, 2.0) == 1
+    assert ladder_rung(100.0, 101.99, 2.0) == 0
+    assert ladder_rung(100.0, 100.0 / 1.02, 2.0) == -1
+    assert ladder_rung(100.0, 90.0, 2.0) == -5
+    assert ladder_rung(0.0, 90.0, 2.0) == 0
+
+
+def _trailing_manager():
+    with (
+        patch("core.alert_manager.get_settings_manager") as get_settings,
+        patch("core.alert_manager.get_notification_service"),
+    ):
+        get_settings.return_value.settings.muted_pairs = {}
+        return AlertManager()
+
+
+def test_trailing_alerts_fire_on_a_retrace_from_the_extreme():
+    for alert_type, prices, extreme in (
+        ("trailing_high", [100.0, 110.0, 105.0, 104.0], 110.0),
+        ("trailing_low", [100.0, 90.0, 94.0, 94.6], 90.0),
+    ):
+        manager = _trailing_manager()
+        alert = PriceAlert(pair="BTC-USDT", alert_type=alert_type, target_price=5.0)
+        manager._settings_manager.get_alerts_for_pair.return_value = [alert]
+        notifier = manager._notification_service
+
+        for price in prices[:-1]:
+            manager.check_alerts("BTC-USDT", price)
+        notifier.send_price_alert.assert_not_called()
+        assert alert.last_triggered_value == extreme
+
+        manager.check_alerts("BTC-USDT", prices[-1])
+        assert notifier.send_price_alert.call_args[1]["previous_price"] == extreme
+        assert not alert.enabled  # Once
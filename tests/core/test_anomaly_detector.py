@@ -0,0 +1,40 @@
+from unittest.mock import MagicMock, patch
+
+import pytest
+
+from core.anomaly_detector import MIN_SAMPLES, AnomalyDetector, z_score
+
+
+@pytest.fixture
+def detector():
+    with patch("core.anomaly_detector.get_settings_manager") as mock_get_settings:
+        settings_manager = MagicMock()
+        settings_manager.settings.anomaly_threshold = 6.0
+        mock_get_settings.return_value = settings_manager
+        yield AnomalyDetector()
+
+
+def test_z_score():
+    assert z_score([1.0, 1.0], 2.0) is None
+    score, mean, std = z_score([1.0, 3.0], 5.0)
+    assert (score, mean, std) == (3.0, 2.0, 1.0)
+
+
+def test_spike_is_flagged_once_then_absorbed(detector):
+    for i in range(MIN_SAMPLES):
+        assert detector.check_price("BTC-USDT", 100.0 + (i % 2) * 0.1) is None
+
+    anomaly = detector.check_price("BTC-USDT", 90.0)
+    assert anomaly is not None
+    assert anomaly.z_score < -6
+    assert anomaly.deviation_pct == pytest.approx(-10.05, abs=0.01)
+
+    # Cooldown suppresses repeats
+    assert detector.check_price("BTC-USDT", 80.0) is None
+
+
+def test_small_moves_on_flat_feed_are_ignored(detector):
+    for i in range(MIN_SAMPLES):
+        detector.check_price("USDC-USDT", 1.0 + (i % 2) * 0.00001)
+
+    assert detector.check_price("USDC-USDT", 1.001) is None
@@ -0,0 +1,66 @@
+from unittest.mock import MagicMock, patch
+
+import pytest
+from PyQt6.QtNetwork import QLocalServer
+
+from core.automation import AutomationCommands, Command, CommandServer, parse_command
+from core.snapshot import SnapshotRow
+
+
+def test_links_and_words_parse_to_the_same_commands():
+    assert parse_command("crypto-monitor://price/btc-usdt") == Command("price", "BTC-USDT", True)
+    assert parse_command("crypto-monitor://add/ETH-USDT/") == Command("add", "ETH-USDT", True)
+    assert parse_command("price BTC-USDT") == Command("price", "BTC-USDT")
+    assert parse_command("crypto-monitor://alerts") == Command("alerts", "toggle", True)
+    assert parse_command("alerts OFF") == Command("alerts", "off")
+    assert parse_command("crypto-monitor://") == Command("show", from_link=True)
+
+    for text in ("price", "add BTC/USDT", "alerts later", "sell BTC-USDT"):
+        with pytest.raises(ValueError):
+            parse_command(text)
+
+
+@pytest.fixture
+def automation():
+    controller = MagicMock()
+    controller.get_snapshot_rows.return_value = [
+        SnapshotRow("BTC-USDT", "BTC", "61,250.50", "+2.50%", "62000", "59000", "1.2M")
+    ]
+    commands = AutomationCommands(controller)
+    commands._settings_manager = MagicMock()
+    commands._settings_manager.settings.crypto_pairs = ["BTC-USDT", "ETH-USDT"]
+    return commands
+
+
+def test_price_and_add(automation):
+    added = []
+    automation.add_pairs_requested.connect(added.append)
+
+    assert automation.execute(Command("price", "BTC-USDT")) == "BTC 61,250.50 +2.50%"
+    assert automation.execute(Command("price", "ETH-USDT")).startswith("error: no price")
+    assert automation.execute(Command("price", "SOL-USDT")).startswith("error:")
+
+    assert automation.execute(Command("add", "ETH-USDT")) == "ETH-USDT is already monitored"
+    assert automation.execute(Command("add", "SOL-USDT")) == "added SOL-USDT"
+    assert added == [["SOL-USDT"]]
+
+
+def test_alerts_switch(automation):
+    notifier = MagicMock(is_paused=False)
+    with patch("core.automation.get_notification_service", return_value=notifier):
+        assert automation.execute(Command("alerts", "toggle")) == "alerts off"
+        notifier.set_paused.assert_called_with(True)
+        assert automation.execute(Command("alerts", "on")) == "alerts on"
+        notifier.set_paused.assert_called_with(False)
+
+
+def test_server_answers_parse_errors():
+    server = CommandServer(lambda command: f"ran {command.action}")
+
+    assert server.answer("show\n") == "ran show"
+    assert server.answer("explode").startswith("error: unknown command")
+
+
+def test_server_only_accepts_the_same_user():
+    server = CommandServer(lambda command: "")
+    assert server._server.socketOptions() == QLocalServer.SocketOption.UserAccessOption
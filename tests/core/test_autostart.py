@@ -0,0 +1,47 @@
+import plistlib
+
+from core.autostart import (
+    LAUNCH_AGENT_LABEL,
+    MINIMIZED_ARG,
+    AutostartManager,
+    build_desktop_entry,
+    build_launch_agent,
+    windows_command_line,
+)
+
+
+def test_entry_builders():
+    command = ["/opt/crypto monitor/app", MINIMIZED_ARG]
+    entry = build_desktop_entry(command)
+    assert "Exec='/opt/crypto monitor/app' --minimized\n" in entry
+
+    plist = plistlib.loads(build_launch_agent(command))
+    assert plist["Label"] == LAUNCH_AGENT_LABEL
+    assert plist["ProgramArguments"] == command
+    assert plist["RunAtLoad"]
+
+    assert windows_command_line(command) == '"/opt/crypto monitor/app" --minimized'
+
+
+def test_linux_enable_disable(tmp_path, monkeypatch):
+    monkeypatch.setenv("XDG_CONFIG_HOME", str(tmp_path / ".config"))
+    manager = AutostartManager(home=tmp_path, platform="linux")
+    assert manager.entry_path == tmp_path / ".config" / "autostart" / "crypto-monitor.desktop"
+    assert not manager.is_enabled()
+
+    assert manager.enable(minimized=True)
+    assert manager.is_enabled()
+    assert manager.is_minimized()
+
+    assert manager.enable(minimized=False)
+    assert not manager.is_minimized()
+
+    assert manager.disable()
+    assert not manager.is_enabled()
+
+
+def test_unsupported_platform(tmp_path):
+    manager = AutostartManager(home=tmp_path, platform="sunos5")
+    assert not manager.is_supported
+    assert not manager.enable()
+    assert not manager.is_enabled()
@@ -0,0 +1,60 @@
+from PyQt6.QtCore import QObject, pyqtSignal
+
+from core.benchmark import PipelineBenchmark, SyntheticTickWorker, percentile, synthetic_pairs
+
+
+class FakeController(QObject):
+    tickers_updated = pyqtSignal(dict)
+
+    def __init__(self):
+        super().__init__()
+        self.ticks = []
+        self.sources = []
+
+    def attach_tick_source(self, source):
+        self.sources.append(source)
+        source.ticker_updated.connect(self._on_ticker_update)
+
+    def detach_tick_source(self, source):
+        self.sources.remove(source)
+        source.ticker_updated.disconnect(self._on_ticker_update)
+
+    def _on_ticker_update(self, pair, data):
+        self.ticks.append((pair, data.price))
+
+
+def test_synthetic_ticks_always_move_the_price():
+    worker = SyntheticTickWorker(synthetic_pairs(2), rate=100)
+    prices = []
+    worker.ticker_updated.connect(lambda pair, data: prices.append((pair, data.price)))
+
+    for _ in range(6):
+        worker.generate_tick()
+
+    assert [pair for pair, _ in prices] == ["BENCH0-USDT", "BENCH1-USDT"] * 3
+    bench0 = [price for pair, price in prices if pair == "BENCH0-USDT"]
+    assert all(a != b for a, b in zip(bench0, bench0[1:]))
+
+
+def test_benchmark_measures_processing_and_batches():
+    controller = FakeController()
+    benchmark = PipelineBenchmark(controller, rate=100, pairs=3, duration=1.0)
+    benchmark.start()
+    for _ in range(6):
+        benchmark.worker.generate_tick()
+    controller.tickers_updated.emit({"BENCH0-USDT": None, "BENCH1-USDT": None})
+
+    result = benchmark.finish()
+
+    assert len(controller.ticks) == 6
+    assert controller.sources == []
+    assert (result.generated, result.processed) == (6, 6)
+    assert (result.batches, result.batched_states) == (1, 2)
+    assert len(result.process_latencies) == 6
+    assert all(latency >= 0 for latency in result.batch_latencies)
+    assert "processed: 6" in result.summary()
+
+
+def test_percentile():
+    assert percentile([], 50) == 0.0
+    assert percentile([float(i) for i in range(1, 101)], 95) == 95.0
@@ -0,0 +1,52 @@
+from unittest.mock import MagicMock, patch
+
+import pytest
+
+from core.candle_aggregator import CandleAggregator
+from core.indicators import interval_seconds
+
+
+@pytest.fixture
+def aggregator():
+    with patch("core.candle_aggregator.get_settings_manager") as mock_get_settings:
+        settings_manager = MagicMock()
+        settings_manager.settings.tick_candle_intervals = ["1m", "5m", "bogus"]
+        mock_get_settings.return_value = settings_manager
+        aggregator = CandleAggregator()
+        aggregator.set_pairs(["chain:eth:0xabc"])
+        yield aggregator
+
+
+def test_interval_seconds():
+    assert interval_seconds("5m") == 300
+    assert interval_seconds("4H") == 4 * 60 * 60
+    with pytest.raises(ValueError):
+        interval_seconds("m")
+
+
+def test_ticks_and_trades_build_ohlcv(aggregator):
+    pair = "chain:eth:0xabc"
+    aggregator.add_price(pair, 10.0, 600)
+    aggregator.add_trades(pair, [(12.0, 2.0, 610), (9.0, 1.0, 620)])
+    aggregator.add_price(pair, 11.0, 660)
+
+    klines = aggregator.fetch_klines(pair, "1m", 10)
+    assert [k["timestamp"] for k in klines] == [600_000, 660_000]
+    first = klines[0]
+    assert (first["open"], first["high"], first["low"], first["close"]) == (10.0, 12.0, 9.0, 9.0)
+    assert first["volume"] == 3.0
+
+    five = aggregator.fetch_klines(pair, "5m")
+    assert len(five) == 1
+    assert five[0]["close"] == 11.0
+    assert aggregator.fetch_klines(pair, "1m", 1)[0]["timestamp"] == 660_000
+
+
+def test_unknown_pairs_and_intervals_are_empty(aggregator):
+    aggregator.add_price("BTC-USDT", 100.0, 600)
+    assert aggregator.fetch_klines("BTC-USDT", "1m") == []
+    aggregator.add_price("chain:eth:0xabc", 1.0, 600)
+    assert aggregator.fetch_klines("chain:eth:0xabc", "bogus") == []
+
+    aggregator.set_pairs([])
+    assert aggregator.fetch_klines("chain:eth:0xabc", "1m") == []
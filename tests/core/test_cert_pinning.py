@@ -0,0 +1,20 @@
+from core.cert_pinning import check_pins
+
+
+def test_check_pins_learns_new_hosts_and_flags_changed_keys():
+    current = {"www.okx.com": "pin-a", "ws.okx.com": "pin-b", "api.binance.com": "pin-c"}
+
+    def fetch(host, port):
+        if host not in current:
+            raise OSError("unreachable")
+        return current[host]
+
+    result = check_pins(
+        [("www.okx.com", 443), ("ws.okx.com", 8443), ("api.binance.com", 443), ("down", 443)],
+        {"www.okx.com": ["pin-a", "backup"], "ws.okx.com": ["old-pin"]},
+        fetch,
+    )
+
+    assert result.learned == {"api.binance.com": "pin-c"}
+    assert result.mismatched == ["ws.okx.com"]
+    assert result.failed == ["down"]
@@ -0,0 +1,46 @@
+from core.chainlink_client import (
+    FeedInfo,
+    OracleComparator,
+    decode_int,
+    get_explorer_url,
+    make_oracle_pair,
+    parse_oracle_pair,
+)
+from core.utils import get_display_name
+
+FEED = "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419"
+
+
+def test_oracle_pair_roundtrip():
+    pair = make_oracle_pair("Ethereum", FEED)
+    assert pair == f"oracle:ethereum:{FEED.lower()}"
+    assert parse_oracle_pair(pair) == ("ethereum", FEED.lower())
+    assert get_explorer_url(pair) == f"https://etherscan.io/address/{FEED.lower()}"
+    assert get_explorer_url(make_oracle_pair("unknown", FEED)) == ""
+    assert get_display_name(pair, "ETH") == "ETH (Chainlink Ethereum)"
+
+
+def test_decode_int_handles_sign():
+    # latestRoundData: roundId, answer, startedAt, updatedAt, answeredInRound
+    words = [1, 2000 * 10**8, 0, 1_700_000_000, 1]
+    result = "0x" + "".join(f"{w:064x}" for w in words)
+    assert decode_int(result, 1) == 2000 * 10**8
+    assert decode_int("0x" + "f" * 64) == -1
+
+
+def test_feed_description():
+    info = FeedInfo(description="ETH / USD", decimals=8)
+    assert (info.base, info.quote) == ("ETH", "USD")
+    assert FeedInfo(description="stETH", decimals=18).quote == ""
+
+
+def test_oracle_deviation():
+    comparator = OracleComparator()
+    assert comparator.deviation("ETH-USDT", 2000) is None
+
+    comparator.update("ETH", "USD", 2000)
+    oracle_price, deviation = comparator.deviation("ETH-USDT", 2010)
+    assert oracle_price == 2000
+    assert abs(deviation - 0.5) < 1e-9
+    # Not comparable across non-USD quotes
+    assert comparator.deviation("ETH-BTC", 0.05) is None
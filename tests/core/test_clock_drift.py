@@ -0,0 +1,43 @@
+import struct
+from unittest.mock import MagicMock, patch
+
+from core.clock_drift import NTP_EPOCH_OFFSET, ClockDriftChecker, parse_ntp_offset
+
+
+def _ntp(seconds):
+    whole = int(seconds)
+    return struct.pack("!II", whole + NTP_EPOCH_OFFSET, int((seconds - whole) * 2**32))
+
+
+def _reply(server_received, server_sent):
+    return bytes(32) + _ntp(server_received) + _ntp(server_sent)
+
+
+def test_offset_cancels_the_round_trip():
+    # Sent at 1000.0 local, the server (5s ahead) got it 0.1s later and answered at once
+    data = _reply(1005.1, 1005.1)
+    offset = parse_ntp_offset(data, sent_at=1000.0, received_at=1000.2)
+    assert abs(offset - 5.0) < 1e-6
+
+    data = _reply(996.05, 996.05)
+    assert abs(parse_ntp_offset(data, 1000.0, 1000.1) + 4.0) < 1e-6
+
+
+def test_drift_warns_above_the_threshold():
+    settings = MagicMock()
+    settings.settings.clock_drift_threshold = 2.0
+    with (
+        patch("core.clock_drift.get_settings_manager", return_value=settings),
+        patch("core.clock_drift.ntp_offset", side_effect=[OSError("timeout"), -3.5, 0.4]),
+    ):
+        checker = ClockDriftChecker()
+        warnings = []
+        checker.drift_detected.connect(warnings.append)
+
+        # The first server is unreachable, the second answers
+        assert checker.measure() == -3.5
+        assert warnings == [-3.5]
+
+        assert checker.measure() == 0.4
+        assert warnings == [-3.5]
+        assert checker.offset == 0.4
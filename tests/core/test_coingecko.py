@@ -0,0 +1,115 @@
+from unittest.mock import MagicMock, patch
+
+import pytest
+
+from core.coingecko import CoinGeckoClient, CoinMarketData, GlobalMetrics, get_base_symbol
+from core.market_indices import BTC_DOMINANCE_PAIR
+
+
+@pytest.fixture
+def client():
+    with (
+        patch("core.coingecko.requests"),
+        patch("core.coingecko.get_proxy_config", return_value={}),
+    ):
+        client = CoinGeckoClient()
+        client.MIN_REQUEST_INTERVAL = 0
+        yield client
+
+
+def _response(status: int, json_data=None, headers=None):
+    response = MagicMock()
+    response.status_code = status
+    response.headers = headers or {}
+    response.json.return_value = json_data
+    return response
+
+
+def test_base_symbol():
+    assert get_base_symbol("btc-usdt") == "BTC"
+    assert get_base_symbol("chain:solana:abc:WIF") == "WIF"
+    assert get_base_symbol("chain:solana:abc") is None
+    assert get_base_symbol("virtual:ETH/BTC:ETH-USDT / BTC-USDT") is None
+
+
+def test_market_data_from_dict():
+    data = CoinMarketData.from_dict(
+        {
+            "id": "bitcoin",
+            "symbol": "btc",
+            "name": "Bitcoin",
+            "market_cap": 1.2e12,
+            "market_cap_rank": 1,
+            "circulating_supply": 19_700_000,
+            "max_supply": 21_000_000,
+            "sparkline_in_7d": {"price": [1.0, None, 2.0]},
+        }
+    )
+    assert data.symbol == "BTC"
+    assert data.market_cap_rank == 1
+    assert data.sparkline_7d == [1.0, 2.0]
+
+
+def test_resolve_id_uses_search_and_caches(client):
+    client._session.get.return_value = _response(
+        200, {"coins": [{"id": "pepe", "symbol": "PEPE"}, {"id": "pepe-2", "symbol": "PEPE"}]}
+    )
+    assert client.resolve_id("BTC") == "bitcoin"
+    assert client.resolve_id("pepe") == "pepe"
+    assert client.resolve_id("PEPE") == "pepe"
+    assert client._session.get.call_count == 1
+
+
+def test_rate_limit_backs_off(client):
+    client._session.get.return_value = _response(429, headers={"Retry-After": "30"})
+    assert client.fetch_markets(["bitcoin"]) is None
+    assert client.fetch_markets(["bitcoin"]) is None
+    # The second call is skipped while blocked
+    assert client._session.get.call_count == 1
+
+
+def test_global_metrics_derive_previous_dominance():
+    metrics = GlobalMetrics.from_global(
+        {
+            "total_market_cap": {"usd": 2000.0},
+            "market_cap_percentage": {"btc": 50.0},
+            "market_cap_change_percentage_24h_usd": 25.0,
+        },
+        btc_change_pct=0.0,
+    )
+    # Yesterday: total 1600, BTC 1000 -> 62.5% dominance, alts 600
+    assert metrics.previous_total_market_cap == pytest.approx(1600.0)
+    assert metrics.previous_btc_dominance == pytest.approx(62.5)
+    assert metrics.alt_market_cap == pytest.approx(1000.0)
+
+    dominance, total, alts = metrics.to_tickers()
+    assert dominance.pair == BTC_DOMINANCE_PAIR
+    assert (dominance.price, dominance.percentage) == ("50.00", "-20.00%")
+    assert total.percentage == "+25.00%"
+    assert alts.percentage == "+66.67%"
+
+
+def test_fetch_trending(client):
+    client._session.get.return_value = _response(
+        200,
+        {
+            "coins": [
+                {
+                    "item": {
+                        "id": "pepe",
+                        "symbol": "pepe",
+                        "name": "Pepe",
+                        "market_cap_rank": 30,
+                        "data": {"price_change_percentage_24h": {"usd": 12.5}},
+                    }
+                },
+                {"item": {"symbol": "bad"}},
+                {"item": {"id": "new-coin", "symbol": "NEW", "name": "New Coin"}},
+            ]
+        },
+    )
+    trending = client.fetch_trending()
+    assert [coin.symbol for coin in trending] == ["PEPE", "NEW"]
+    assert trending[0].price_change_pct_24h == 12.5
+    assert trending[1].market_cap_rank is None
+    assert trending[1].price_change_pct_24h is None
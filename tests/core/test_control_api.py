@@ -0,0 +1,112 @@
+import json
+from pathlib import Path
+from unittest.mock import MagicMock, patch
+
+import pytest
+
+from config.settings import LocalApiConfig, PriceAlert
+from core.control_api import ControlApi, parse_pairs
+from core.local_api import LocalApiServer
+from core.snapshot import SnapshotRow
+
+AUTH = {"Authorization": "Bearer s3cret"}
+
+
+@pytest.fixture
+def control(tmp_path):
+    server = LocalApiServer()
+    settings_manager = server._settings_manager
+    saved_pairs = settings_manager.settings.crypto_pairs
+    settings_manager.settings.crypto_pairs = ["BTC-USDT", "ETH-USDT"]
+    settings_manager.settings.local_api = LocalApiConfig(token="s3cret", allow_control=True)
+    controller = MagicMock()
+    api = ControlApi(server, controller)
+    api._settings_manager = MagicMock(settings=settings_manager.settings, config_dir=tmp_path)
+    yield server, api, controller
+    settings_manager.settings.crypto_pairs = saved_pairs
+    settings_manager.settings.local_api = LocalApiConfig()
+
+
+def _post(server, path, body=None, headers=AUTH):
+    return server.dispatch("POST", path, headers, json.dumps(body).encode() if body else b"")
+
+
+def test_parse_pairs():
+    assert parse_pairs(b'{"pairs": ["btc-usdt", "BTC-USDT", "index:FEAR_GREED"]}') == [
+        "BTC-USDT",
+        "index:FEAR_GREED",
+    ]
+    for body in (b"", b"[]", b'{"pairs": "BTC-USDT"}', b'{"pairs": ["BTC USDT"]}', b"{"):
+        with pytest.raises(ValueError):
+            parse_pairs(body)
+
+
+def test_commands_need_control_allowed_and_a_token(control):
+    server, _, _ = control
+    settings = server._settings_manager.settings
+
+    assert _post(server, "/api/control/alerts/pause", headers={}).status == 401
+    settings.local_api = LocalApiConfig(allow_control=True)
+    assert _post(server, "/api/control/alerts/pause", headers={}).status == 403
+    settings.local_api = LocalApiConfig(token="s3cret")
+    assert _post(server, "/api/control/alerts/pause").status == 403
+
+
+def test_pairs_are_added_and_removed(control):
+    server, api, _ = control
+    added, removed = [], []
+    api.add_pairs_requested.connect(added.append)
+    api.remove_pair_requested.connect(removed.append)
+
+    response = _post(server, "/api/control/pairs/add", {"pairs": ["eth-usdt", "SOL-USDT"]})
+    assert response.body == {"added": ["SOL-USDT"]}
+    assert added == [["SOL-USDT"]]
+
+    response = _post(server, "/api/control/pairs/remove", {"pairs": ["BTC-USDT", "DOGE-USDT"]})
+    assert response.body == {"removed": ["BTC-USDT"]}
+    assert removed == ["BTC-USDT"]
+
+    assert _post(server, "/api/control/pairs/add", {"pairs": ["not a pair"]}).status == 400
+
+
+def test_alerts_are_paused_and_resumed(control):
+    server, _, _ = control
+    notifier = MagicMock()
+    with patch("core.control_api.get_notification_service", return_value=notifier):
+        assert _post(server, "/api/control/alerts/pause").body == {"paused": True}
+        assert _post(server, "/api/control/alerts/resume").body == {"paused": False}
+    assert [call.args for call in notifier.set_paused.call_args_list] == [(True,), (False,)]
+
+
+def test_snapshot_is_saved_and_returned(control):
+    server, _, controller = control
+    controller.get_snapshot_rows.return_value = []
+    assert _post(server, "/api/control/snapshot").status == 409
+
+    controller.get_snapshot_rows.return_value = [
+        SnapshotRow("BTC-USDT", "BTC", "61,250.50", "+2.50%", "62000", "59000", "1.2M")
+    ]
+    body = _post(server, "/api/control/snapshot").body
+    assert "61,250.50" in body["text"]
+    path = Path(body["file"])
+    assert path.parent.name == "snapshots"
+    assert "BTC-USDT" in path.read_text(encoding="utf-8")
+
+
+def test_alerts_are_acknowledged_and_pairs_muted(control):
+    server, api, _ = control
+    alert = PriceAlert(pair="BTC-USDT")
+    api._settings_manager.settings.alerts = [alert]
+    alerts = MagicMock()
+    alerts.acknowledge_alert.return_value = True
+    alerts.mute_pair.return_value = 1700003600.0
+    with patch("core.control_api.get_alert_manager", return_value=alerts):
+        assert _post(server, f"/api/control/alerts/{alert.id}/ack").body == {"acknowledged": 1}
+        assert _post(server, "/api/control/alerts/unknown/ack").status == 404
+
+        response = _post(server, "/api/control/pairs/btc-usdt/mute", {"minutes": 30})
+        assert response.body == {"pair": "BTC-USDT", "muted_until": 1700003600.0}
+        alerts.mute_pair.assert_called_with("BTC-USDT", 1800.0)
+        assert _post(server, "/api/control/pairs/BTC-USDT/mute", {"minutes": 0}).status == 400
+        assert _post(server, "/api/control/pairs/BTC-USDT/unmute").status == 200
+    alerts.unmute_pair.assert_called_with("BTC-USDT")
@@ -0,0 +1,72 @@
+import asyncio
+from pathlib import Path
+from urllib.parse import parse_qs, urlparse
+
+from core.benchmark import SyntheticTickWorker
+from core.crash_reporter import CrashReporter, build_report, issue_url
+from core.redaction import set_known_secrets
+
+
+def _raise(text):
+    try:
+        raise RuntimeError(text)
+    except RuntimeError as e:
+        return e
+
+
+def test_report_is_anonymized():
+    set_known_secrets(["s3cret-passphrase"])
+    try:
+        exc = _raise(f"failed reading {Path.home()}/notes with s3cret-passphrase")
+        report = build_report(type(exc), exc, exc.__traceback__, "OkxWebSocketWorker")
+    finally:
+        set_known_secrets([])
+
+    assert "Thread: OkxWebSocketWorker" in report
+    assert "RuntimeError: failed reading ~/notes with ***" in report
+    assert str(Path.home()) not in report
+
+    query = parse_qs(urlparse(issue_url(report)).query)
+    assert query["title"] == ["Crash: RuntimeError: failed reading ~/notes with ***"]
+    assert "Thread: OkxWebSocketWorker" in query["body"][0]
+
+
+def test_reports_are_kept_only_when_opted_in(tmp_path):
+    reporter = CrashReporter(tmp_path / "crashes")
+    reporter._settings_manager.settings.crash_reports = False
+    try:
+        reporter.report(_raise("boom"), "main")
+        assert reporter.pending_reports() == []
+
+        reporter._settings_manager.settings.crash_reports = True
+        reporter.report(_raise("boom"), "main")
+        reporter.report(_raise("again"), "main")
+        reports = reporter.pending_reports()
+        assert len(reports) == 2
+
+        reporter.discard(reports)
+        assert reporter.pending_reports() == []
+    finally:
+        reporter._settings_manager.settings.crash_reports = False
+
+
+def test_crashed_connection_loop_is_restarted():
+    worker = SyntheticTickWorker(["BENCH0-USDT"], 1)
+    worker.CRASH_RESTART_DELAY = 0
+    worker._running = True
+    worker._loop = asyncio.new_event_loop()
+    attempts = []
+
+    async def maintain():
+        attempts.append(1)
+        if len(attempts) < 3:
+            raise RuntimeError("boom")
+
+    worker._maintain_connection = maintain
+    try:
+        worker._run_connection_loop()
+    finally:
+        worker._loop.close()
+
+    assert len(attempts) == 3
+    assert worker._crash_count == 2
@@ -0,0 +1,105 @@
+import pytest
+
+from core.csv_import import (
+    CsvImportError,
+    import_csv_file,
+    normalize_pair,
+    parse_csv_text,
+    parse_timestamp,
+    split_amount,
+)
+
+OKX_CSV = """id,Order id,Time,Trade Type,Symbol,Action,Amount,Trading Unit,Filled Price,Fee,Fee Unit
+101,9001,2024-01-02 03:04:05,Spot,BTC-USDT,buy,0.5,BTC,42000,-0.0005,BTC
+102,9002,2024-01-03 03:04:05,Spot,BTC-USDT,sell,0.2,BTC,45000.5,-9.0001,USDT
+"""
+
+BINANCE_CSV = """Date(UTC),Pair,Side,Price,Executed,Amount,Fee
+2024-02-01 10:00:00,ETHUSDT,BUY,2300.00,1.5ETH,3450.00USDT,0.0015ETH
+2024-02-02 10:00:00,ETHUSDT,SELL,2400.00,0.5ETH,1200.00USDT,1.2USDT
+"""
+
+
+def test_normalize_pair_variants():
+    assert normalize_pair("BTC-USDT") == "BTC-USDT"
+    assert normalize_pair("btc/usdt") == "BTC-USDT"
+    assert normalize_pair("ETHUSDT") == "ETH-USDT"
+    assert normalize_pair("SOLFDUSD") == "SOL-FDUSD"
+    assert normalize_pair("BTC-USDT-SWAP") == "BTC-USDT"
+
+
+def test_split_amount_with_unit():
+    assert split_amount("0.0012BTC") == (0.0012, "BTC")
+    assert split_amount("1,234.5 USDT") == (1234.5, "USDT")
+    assert split_amount("42") == (42.0, "")
+
+    with pytest.raises(ValueError):
+        split_amount("abc")
+
+
+def test_parse_timestamp_formats():
+    assert parse_timestamp("2024-01-01 00:00:00") == 1704067200.0
+    assert parse_timestamp("1704067200000") == 1704067200.0
+    assert parse_timestamp("2024-01-01T00:00:00Z") == 1704067200.0
+
+
+def test_parse_okx_export():
+    result = parse_csv_text(OKX_CSV)
+
+    assert result.format_name == "okx"
+    assert result.skipped_rows == []
+    assert len(result.transactions) == 2
+
+    buy, sell = result.transactions
+    assert buy.pair == "BTC-USDT"
+    assert buy.side == "buy"
+    assert buy.quantity == 0.5
+    assert buy.price == 42000.0
+    assert buy.fee == 0.0005
+    assert buy.fee_asset == "BTC"
+    assert buy.external_id == "101"
+    assert buy.source == "okx_csv"
+    assert sell.side == "sell"
+    assert sell.fee_asset == "USDT"
+
+
+def test_parse_binance_export():
+    result = parse_csv_text(BINANCE_CSV)
+
+    assert result.format_name == "binance"
+    assert len(result.transactions) == 2
+
+    buy = result.transactions[0]
+    assert buy.pair == "ETH-USDT"
+    assert buy.quantity == 1.5
+    assert buy.price == 2300.0
+    assert buy.fee == 0.0015
+    assert buy.fee_asset == "ETH"
+
+
+def test_invalid_rows_are_skipped():
+    text = BINANCE_CSV + "2024-02-03 10:00:00,ETHUSDT,HOLD,2400.00,0.5ETH,1200USDT,0\n"
+
+    result = parse_csv_text(text)
+
+    assert len(result.transactions) == 2
+    assert len(result.skipped_rows) == 1
+    assert result.skipped_rows[0][0] == 4
+
+
+def test_unknown_format_raises():
+    with pytest.raises(CsvImportError):
+        parse_csv_text("foo,bar\n1,2\n")
+
+    with pytest.raises(CsvImportError):
+        parse_csv_text(OKX_CSV, format_name="kraken")
+
+
+def test_import_file_with_bom(tmp_path):
+    path = tmp_path / "okx.csv"
+    path.write_text("\ufeff" + OKX_CSV, encoding="utf-8")
+
+    result = import_csv_file(path)
+
+    assert result.format_name == "okx"
+    assert len(result.transactions) == 2
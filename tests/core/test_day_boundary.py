@@ -0,0 +1,33 @@
+from core.day_boundary import day_open, day_start, format_change, needs_candle_open
+from core.indicators import Candle
+
+# 2024-01-02 03:00:00 UTC, 11:00 in UTC+8
+NOW = 1704164400
+
+
+def test_day_start():
+    assert day_start("utc_0", NOW) == 1704153600  # 2024-01-02 00:00 UTC
+    assert day_start("utc_8", NOW) == 1704124800  # 2024-01-01 16:00 UTC
+    assert day_start("24h_rolling", NOW) is None
+
+
+def test_needs_candle_open():
+    assert not needs_candle_open("utc_8", "OKX")
+    assert needs_candle_open("utc_8", "Binance")
+    assert needs_candle_open("local", "OKX")
+    assert not needs_candle_open("utc_0", "Binance")
+
+
+def test_day_open_uses_candle_containing_start():
+    candles = [Candle(1704124800 + i * 3600, 100.0 + i, 0, 0, 0) for i in range(5)]
+    assert day_open(candles, 1704124800, 3600) == 100.0
+    # Half-hour offsets fall inside a candle
+    assert day_open(candles, 1704124800 + 2 * 3600 + 1800, 3600) == 102.0
+    # Before the first or after a gap past the last candle
+    assert day_open(candles, 1704124800 - 60, 3600) is None
+    assert day_open(candles, 1704124800 + 6 * 3600, 3600) is None
+
+
+def test_format_change():
+    assert format_change(105.0, 100.0) == "+5.00%"
+    assert format_change(97.5, 100.0) == "-2.50%"
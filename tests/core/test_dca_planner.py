@@ -0,0 +1,36 @@
+from datetime import datetime
+
+import pytest
+
+from config.settings import DcaPlan
+from core.dca_planner import apply_dca_run, next_run_time
+
+
+def test_next_run_weekly_and_daily():
+    after = datetime(2024, 5, 15, 10, 30)  # Wednesday
+
+    weekly = DcaPlan(cadence="weekly", weekday=0, hour=9)
+    assert next_run_time(weekly, after) == datetime(2024, 5, 20, 9, 0)
+
+    daily = DcaPlan(cadence="daily", hour=11)
+    assert next_run_time(daily, after) == datetime(2024, 5, 15, 11, 0)
+    assert next_run_time(daily, datetime(2024, 5, 15, 11, 0)) == datetime(2024, 5, 16, 11, 0)
+
+
+def test_next_run_monthly_rolls_over_year():
+    plan = DcaPlan(cadence="monthly", day_of_month=15, hour=9)
+
+    assert next_run_time(plan, datetime(2024, 12, 20)) == datetime(2025, 1, 15, 9, 0)
+    assert next_run_time(plan, datetime(2024, 12, 1)) == datetime(2024, 12, 15, 9, 0)
+
+
+def test_apply_dca_run_tracks_average_price():
+    plan = DcaPlan(pair="BTC-USDT", amount=100)
+
+    apply_dca_run(plan, 50000.0, 1.0)
+    apply_dca_run(plan, 25000.0, 2.0)
+
+    assert plan.runs == 2
+    assert plan.total_invested == 200
+    assert plan.total_quantity == pytest.approx(0.006)
+    assert plan.average_price == pytest.approx(200 / 0.006)
@@ -0,0 +1,78 @@
+from datetime import datetime
+from unittest.mock import MagicMock, patch
+
+from config.settings import DigestConfig
+from core.digest import DigestScheduler, next_digest_time, render_digest, render_line
+from core.snapshot import SnapshotRow
+
+
+def _row(pair="BTC-USDT", name="BTC", price="61,250.50", change="+2.50%"):
+    return SnapshotRow(pair, name, price, change, "62000", "58000", "1250000000")
+
+
+def test_next_digest_time():
+    after = datetime(2024, 5, 15, 10, 30)
+
+    daily = DigestConfig(cadence="daily", hour=8, minute=0)
+    assert next_digest_time(daily, after) == datetime(2024, 5, 16, 8, 0)
+    daily.hour = 18
+    assert next_digest_time(daily, after) == datetime(2024, 5, 15, 18, 0)
+
+    hourly = DigestConfig(cadence="hourly", minute=15)
+    assert next_digest_time(hourly, after) == datetime(2024, 5, 15, 11, 15)
+    hourly.minute = 45
+    assert next_digest_time(hourly, after) == datetime(2024, 5, 15, 10, 45)
+    assert next_digest_time(hourly, datetime(2024, 5, 15, 10, 45)) == datetime(
+        2024, 5, 15, 11, 45
+    )
+
+
+def test_lines_are_rendered_from_the_template():
+    assert render_line("{name}: {price} ({change})", _row()) == "BTC: 61,250.50 (+2.50%)"
+    assert render_line("{pair} {high}/{low} {rsi}", _row()) == "BTC-USDT 62000/58000 {rsi}"
+    # A malformed template falls back to the default
+    assert render_line("{name", _row()) == "BTC  61,250.50  +2.50%"
+
+    config = DigestConfig(cadence="hourly", template="")
+    title, message = render_digest(
+        config, [_row(), _row("ETH-USDT", "ETH", "3,000.00", "-1.00%")], datetime(2024, 5, 15, 9)
+    )
+    assert "09:00" in title
+    assert message == "BTC  61,250.50  +2.50%\nETH  3,000.00  -1.00%"
+
+
+def test_scheduler_sends_when_due_and_reschedules():
+    settings = MagicMock()
+    settings.settings.digest = DigestConfig(enabled=True, cadence="daily", hour=8)
+    rows = [_row()]
+    service = MagicMock()
+    with (
+        patch("core.digest.get_settings_manager", return_value=settings),
+        patch("core.digest.get_notification_service", return_value=service),
+    ):
+        scheduler = DigestScheduler(lambda: rows)
+        scheduler.check(datetime(2024, 5, 15, 7, 0))
+        assert scheduler.next_run == datetime(2024, 5, 15, 8, 0)
+
+        scheduler.check(datetime(2024, 5, 15, 7, 59))
+        service.send_digest.assert_not_called()
+
+        scheduler.check(datetime(2024, 5, 15, 8, 0))
+        service.send_digest.assert_called_once()
+        assert service.send_digest.call_args.args[1] == "BTC  61,250.50  +2.50%"
+        assert scheduler.next_run == datetime(2024, 5, 16, 8, 0)
+
+        # Changing the time reschedules instead of sending
+        settings.settings.digest.hour = 20
+        scheduler.check(datetime(2024, 5, 15, 9, 0))
+        assert scheduler.next_run == datetime(2024, 5, 15, 20, 0)
+
+        # Without prices the digest is skipped, not delayed
+        rows.clear()
+        scheduler.check(datetime(2024, 5, 15, 20, 0))
+        assert service.send_digest.call_count == 1
+        assert scheduler.next_run == datetime(2024, 5, 16, 20, 0)
+
+        settings.settings.digest.enabled = False
+        scheduler.check(datetime(2024, 5, 16, 20, 0))
+        assert scheduler.next_run is None
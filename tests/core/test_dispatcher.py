@@ -0,0 +1,35 @@
+import threading
+
+from core.utils.dispatcher import Dispatcher
+
+
+def test_runs_jobs_in_order_on_shared_threads():
+    dispatcher = Dispatcher(workers=1)
+    ran = []
+    threads = set()
+
+    def job(index):
+        ran.append(index)
+        threads.add(threading.current_thread().name)
+
+    for index in range(5):
+        dispatcher.submit(lambda i=index: job(i))
+    dispatcher.join()
+
+    assert ran == [0, 1, 2, 3, 4]
+    assert threads == {"dispatcher-0"}
+
+
+def test_failing_job_does_not_stop_worker():
+    dispatcher = Dispatcher(workers=1)
+    ran = []
+
+    def fail():
+        raise RuntimeError("boom")
+
+    dispatcher.submit(fail)
+    dispatcher.submit(lambda: ran.append(True))
+    dispatcher.join()
+
+    assert ran == [True]
+    assert dispatcher.pending() == 0
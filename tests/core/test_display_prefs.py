@@ -0,0 +1,38 @@
+from core.display_prefs import clean_display_prefs, resolve_display_hints
+
+
+def test_clean_display_prefs_keeps_non_defaults():
+    prefs = {"decimals": 2, "show_volume": False, "invert": True, "unknown": 1}
+    assert clean_display_prefs(prefs) == {"decimals": 2, "invert": True}
+
+
+def test_decimals_and_volume():
+    hints = resolve_display_hints(
+        "BTC-USDT", {"decimals": 0, "show_volume": True}, 65432.1, "+1.50%", "1234567890"
+    )
+    assert hints.price_text == "65432"
+    assert hints.percentage_text == "+1.50%"
+    assert hints.volume_text == "1.23B"
+    assert hints.symbol is None
+    assert not hints.neutral
+
+
+def test_invert_quote():
+    hints = resolve_display_hints("BTC-USDT", {"invert": True, "decimals": 8}, 50000.0, "+25.00%")
+    assert hints.price_text == "0.00002000"
+    # 1 / 1.25 - 1
+    assert hints.percentage_text == "-20.00%"
+    assert hints.symbol == "USDT/BTC"
+
+
+def test_color_threshold_and_fiat():
+    hints = resolve_display_hints(
+        "BTC-USDT",
+        {"color_threshold": 1.0, "decimals": 1},
+        100.0,
+        "-0.40%",
+        fiat_price=92.0,
+        fiat_currency="EUR",
+    )
+    assert hints.neutral
+    assert hints.price_text == "€92.0"
@@ -0,0 +1,64 @@
+from datetime import datetime
+from unittest.mock import MagicMock, patch
+
+from core.economic_calendar import EconomicCalendarService, parse_calendar
+
+CPI_TIME = "2026-10-14T08:30:00-04:00"
+
+
+def _entry(title, country="USD", impact="High", date=CPI_TIME, **kwargs):
+    return {"title": title, "country": country, "impact": impact, "date": date, **kwargs}
+
+
+def _events():
+    return parse_calendar(
+        [
+            _entry("CPI m/m", forecast="0.3%", previous="0.4%"),
+            _entry("German ZEW", country="EUR"),
+            _entry("Crude Oil Inventories", impact="Medium"),
+            _entry("Broken", date="not a date"),
+        ]
+    )
+
+
+def test_parse_calendar():
+    events = _events()
+    assert len(events) == 3
+    cpi = events[0]
+    assert cpi.title == "CPI m/m"
+    assert cpi.timestamp == datetime.fromisoformat(CPI_TIME).timestamp()
+    assert (cpi.forecast, cpi.previous) == ("0.3%", "0.4%")
+
+
+def test_due_reminders_once_for_high_impact_us_events():
+    service = EconomicCalendarService()
+    service._events = _events()
+    release = datetime.fromisoformat(CPI_TIME).timestamp()
+
+    assert service.due_reminders(release - 3600, 30) == []
+    due = service.due_reminders(release - 20 * 60, 30)
+    assert [event.title for event in due] == ["CPI m/m"]
+    assert service.due_reminders(release - 10 * 60, 30) == []
+    assert service.due_reminders(release + 60, 30) == []
+
+
+def test_calendar_is_only_fetched_for_alerts():
+    settings_manager = MagicMock()
+    settings_manager.settings.macro_alerts = False
+    with (
+        patch("core.economic_calendar.get_settings_manager", return_value=settings_manager),
+        patch("core.economic_calendar.threading.Thread") as thread,
+    ):
+        service = EconomicCalendarService()
+        service.start()
+        assert not service._check_timer.isActive()
+        thread.assert_not_called()
+
+        settings_manager.settings.macro_alerts = True
+        service.apply_settings()
+        assert service._check_timer.isActive()
+        thread.assert_called_once()
+
+        settings_manager.settings.macro_alerts = False
+        service.apply_settings()
+        assert not service._timer.isActive()
@@ -0,0 +1,60 @@
+from unittest.mock import MagicMock
+
+from config.settings import EscalationStep, PriceAlert
+from core.escalation import EscalationManager
+
+
+def _manager():
+    remote = MagicMock()
+    remote.send_to.return_value = True
+    notifications = MagicMock(is_paused=False)
+    return EscalationManager(remote, notifications), remote
+
+
+def _alert(pair="BTC-USDT"):
+    return PriceAlert(
+        pair=pair,
+        escalation=[
+            EscalationStep(channel="Email", after_minutes=10),
+            EscalationStep(channel="Telegram", after_minutes=2),
+        ],
+    )
+
+
+def test_steps_run_in_order_of_their_delay():
+    manager, remote = _manager()
+    manager.start(_alert(), 70000.0, now=1000.0)
+
+    manager.check(now=1000.0 + 60)
+    remote.send_to.assert_not_called()
+
+    manager.check(now=1000.0 + 120)
+    assert [call.args[0] for call in remote.send_to.call_args_list] == ["Telegram"]
+    title, message = remote.send_to.call_args.args[1:]
+    assert "BTC" in title
+    assert message.startswith("BTC-USDT")
+
+    manager.check(now=1000.0 + 600)
+    assert [call.args[0] for call in remote.send_to.call_args_list] == ["Telegram", "Email"]
+    assert manager.pending_pairs() == []
+
+
+def test_stopping_ends_the_chain():
+    manager, remote = _manager()
+    btc = _alert()
+    manager.start(btc, 70000.0, now=1000.0)
+    manager.start(_alert("ETH-USDT"), 3000.0, now=1000.0)
+
+    assert manager.stop(btc.id)
+    assert not manager.stop(btc.id)
+    assert manager.pending_pairs() == ["ETH-USDT"]
+    manager.check(now=1000.0 + 3600)
+    assert {call.args[0] for call in remote.send_to.call_args_list} == {"Telegram", "Email"}
+    assert all("ETH" in call.args[1] for call in remote.send_to.call_args_list)
+
+
+def test_settings_round_trip_the_steps():
+    alert = PriceAlert.from_dict(
+        {"pair": "BTC-USDT", "escalation": [{"channel": "Email", "after_minutes": 10}]}
+    )
+    assert alert.escalation == [EscalationStep(channel="Email", after_minutes=10)]
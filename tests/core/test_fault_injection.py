@@ -0,0 +1,38 @@
+import pytest
+
+from core.fault_injection import FaultInjector, parse_fault_spec
+
+
+def test_parse_fault_spec():
+    injector = parse_fault_spec("drop=0.01, delay=0.5,corrupt=0.02,seed=7")
+
+    assert injector.enabled
+    assert (injector.drop_rate, injector.max_delay, injector.corrupt_rate) == (0.01, 0.5, 0.02)
+    assert not parse_fault_spec("").enabled
+    with pytest.raises(ValueError):
+        parse_fault_spec("jitter=1")
+    with pytest.raises(ValueError):
+        parse_fault_spec("drop")
+    with pytest.raises(ValueError):
+        parse_fault_spec("delay=-1")
+
+
+def test_inert_injector_passes_messages_through():
+    injector = FaultInjector()
+
+    assert not injector.enabled
+    assert not any(injector.should_drop() for _ in range(100))
+    assert injector.delay() == 0.0
+    assert injector.corrupt('{"price": "1"}') == '{"price": "1"}'
+
+
+def test_faults_are_injected_and_counted():
+    injector = FaultInjector(drop_rate=1.0, max_delay=2.0, corrupt_rate=1.0, seed=1)
+    raw = '{"arg": {"channel": "tickers"}, "data": []}'
+
+    assert injector.should_drop()
+    assert 0.0 <= injector.delay() <= 2.0
+    corrupted = injector.corrupt(raw)
+    assert corrupted != raw
+    assert isinstance(injector.corrupt(raw.encode()), bytes)
+    assert injector.injected == {"drops": 1, "delays": 1, "corruptions": 2}
@@ -0,0 +1,52 @@
+from unittest.mock import MagicMock, patch
+
+import pytest
+
+from core.fee_monitor import FeeMonitor, NetworkFees
+from core.market_indices import BTC_FEE_PAIR, ETH_GAS_PAIR
+
+
+@pytest.fixture
+def monitor():
+    with patch("core.fee_monitor.get_settings_manager", return_value=MagicMock()):
+        yield FeeMonitor()
+
+
+def test_drop_below_target_is_reported_once(monitor):
+    assert monitor.check_targets(NetworkFees(12.0, 3.0), 5.0, 2.0) == []
+    assert monitor.check_targets(NetworkFees(4.0, None), 5.0, 2.0) == [("BTC", 4.0, 5.0)]
+    assert monitor.check_targets(NetworkFees(3.0, 1.5), 5.0, 2.0) == [("ETH", 1.5, 2.0)]
+    assert monitor.check_targets(NetworkFees(8.0, 1.0), 5.0, 2.0) == []
+    assert monitor.check_targets(NetworkFees(5.0, 1.0), 5.0, 2.0) == [("BTC", 5.0, 5.0)]
+
+
+def test_fee_tickers(monitor):
+    assert monitor.get_tickers() == []
+    monitor._fees = NetworkFees(btc_sat_vb=10.0, eth_gwei=None)
+    monitor._previous = NetworkFees(btc_sat_vb=8.0, eth_gwei=1.0)
+
+    tickers = monitor.get_tickers()
+    assert [t.pair for t in tickers] == [BTC_FEE_PAIR]
+    assert tickers[0].price == "10"
+    assert tickers[0].percentage == "+25.00%"
+    assert ETH_GAS_PAIR.startswith("index:")
+
+
+def test_fees_are_only_polled_while_alerted_or_watched(monitor):
+    settings = monitor._settings_manager.settings
+    settings.fee_alerts = False
+    settings.crypto_pairs = ["BTC-USDT"]
+    settings.alerts = []
+    with patch("core.fee_monitor.threading.Thread") as thread:
+        monitor.start()
+        assert not monitor._timer.isActive()
+        thread.assert_not_called()
+
+        settings.crypto_pairs = ["BTC-USDT", ETH_GAS_PAIR]
+        monitor.apply_settings()
+        assert monitor._timer.isActive()
+        thread.assert_called_once()
+
+    settings.crypto_pairs = ["BTC-USDT"]
+    monitor.apply_settings()
+    assert not monitor._timer.isActive()
@@ -0,0 +1,69 @@
+from unittest.mock import MagicMock, patch
+
+from core.funding_rates import (
+    FundingComparison,
+    FundingRate,
+    FundingRateClient,
+    FundingRateService,
+    get_perp_symbol,
+)
+
+
+def _response(data):
+    response = MagicMock()
+    response.json.return_value = data
+    return response
+
+
+def test_get_perp_symbol():
+    assert get_perp_symbol("BTC-USDT") == ("BTC", "USDT")
+    assert get_perp_symbol("eth-usdc") == ("ETH", "USDC")
+    assert get_perp_symbol("SOL-EUR") == ("SOL", "USDT")
+    assert get_perp_symbol("chain:eth:0xabc:PEPE") is None
+    assert get_perp_symbol("index:FNG") is None
+
+
+def test_spread_is_normalized_to_8h():
+    comparison = FundingComparison(
+        pair="BTC-USDT",
+        rates=[
+            FundingRate("OKX", 0.0001),
+            FundingRate("Binance", 0.0002, interval_hours=4),  # 0.04% per 8h
+            FundingRate("Bybit", -0.0001),
+        ],
+    )
+    assert comparison.highest.exchange == "Binance"
+    assert comparison.lowest.exchange == "Bybit"
+    assert round(comparison.spread * 100, 6) == 0.05
+    assert comparison.is_arbitrage(0.05)
+    assert not comparison.is_arbitrage(0.06)
+    assert not FundingComparison("BTC-USDT", [FundingRate("OKX", 0.01)]).is_arbitrage(0.05)
+
+
+def test_okx_interval_from_funding_times():
+    with patch("core.funding_rates.requests") as mock_requests:
+        mock_requests.get.return_value = _response(
+            {
+                "data": [
+                    {
+                        "fundingRate": "0.0003",
+                        "fundingTime": "1700000000000",
+                        "nextFundingTime": "1700014400000",
+                    }
+                ]
+            }
+        )
+        rate = FundingRateClient().fetch_okx("BTC", "USDT")
+    assert rate.interval_hours == 4
+    assert round(rate.normalized_rate, 6) == 0.0006
+
+
+def test_arbitrage_reported_once():
+    service = FundingRateService()
+    service._comparisons["BTC-USDT"] = FundingComparison(
+        "BTC-USDT", [FundingRate("OKX", 0.0001), FundingRate("Bybit", 0.0008)]
+    )
+    assert [c.pair for c in service.check_arbitrage(0.05)] == ["BTC-USDT"]
+    assert service.check_arbitrage(0.05) == []
+    assert service.check_arbitrage(0.1) == []
+    assert len(service.check_arbitrage(0.05)) == 1
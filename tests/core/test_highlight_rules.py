@@ -0,0 +1,39 @@
+from core.highlight_rules import HighlightRule, match_highlight, parse_rules
+
+
+def test_parse_rules_skips_malformed_entries():
+    rules = parse_rules(
+        [
+            {"direction": "down", "threshold": 5, "flash": True},
+            {"direction": "sideways", "threshold": 5},
+            {"direction": "up", "threshold": "x"},
+            {"direction": "up", "threshold": 0},
+            "up",
+        ]
+    )
+    assert rules == [HighlightRule("down", 5.0, flash=True)]
+    assert parse_rules(None) == []
+
+
+def test_rule_round_trips_without_defaults():
+    rule = HighlightRule("up", 2.0)
+    assert rule.to_dict() == {"direction": "up", "threshold": 2.0}
+    assert HighlightRule.from_dict(rule.to_dict()) == rule
+
+
+def test_match_highlight_picks_highest_triggered_threshold():
+    rules = [
+        HighlightRule("down", 2.0),
+        HighlightRule("down", 5.0, color="#FF00FF", flash=True),
+        HighlightRule("up", 5.0),
+    ]
+    assert match_highlight(rules, -1.0) is None
+    assert match_highlight(rules, -3.0).color == "#F44336"
+    highlight = match_highlight(rules, -7.5)
+    assert (highlight.threshold, highlight.color, highlight.flash) == (5.0, "#FF00FF", True)
+    assert match_highlight(rules, 5.0).color == "#4CAF50"
+
+
+def test_match_highlight_follows_color_schema():
+    rules = [HighlightRule("up", 1.0)]
+    assert match_highlight(rules, 1.5, "reverse").color == "#F44336"
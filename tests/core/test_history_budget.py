@@ -0,0 +1,49 @@
+from unittest.mock import patch
+
+from config.settings import AppSettings
+from core.candle_aggregator import CandleAggregator
+from core.history_budget import (
+    BYTES_PER_MB,
+    MIN_CANDLES,
+    POINT_BYTES,
+    HistoryBudget,
+)
+from core.indicators import IndicatorEngine
+from core.sparkline import SparklineService
+
+
+def _budget(settings):
+    with patch("core.history_budget.get_settings_manager") as get_manager:
+        get_manager.return_value.settings = settings
+        return HistoryBudget(SparklineService(), IndicatorEngine(), CandleAggregator())
+
+
+def _fill(sparklines, pairs, minutes):
+    for pair in pairs:
+        for minute in range(minutes):
+            sparklines.add_price(pair, 100.0 + minute, minute * 60)
+
+
+def test_caps_trim_oldest_points():
+    settings = AppSettings(sparkline_max_points=100)
+    budget = _budget(settings)
+    _fill(budget._sparklines, ["BTC-USDT"], 150)
+
+    usage = budget.enforce()
+    assert usage.sparkline_points == 100
+    assert usage.entries == 100
+    assert usage.bytes == 100 * POINT_BYTES
+    # The newest prices survive
+    assert budget._sparklines._buffers["BTC-USDT"].values(10**9, 150 * 60)[-1] == 249.0
+
+
+def test_budget_scales_caps_down():
+    # Two full 10080-point buffers need ~2.3 MB, so a 1 MB budget halves the caps
+    settings = AppSettings(sparkline_max_points=10080, history_budget_mb=1)
+    budget = _budget(settings)
+    _fill(budget._sparklines, ["BTC-USDT", "ETH-USDT"], 10)
+
+    usage = budget.enforce()
+    assert usage.sparkline_points == int(10080 * BYTES_PER_MB / (2 * 10080 * POINT_BYTES))
+    # Candle caps shrink by the same factor, but not below what the indicators need
+    assert usage.candles == MIN_CANDLES
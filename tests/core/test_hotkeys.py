@@ -0,0 +1,18 @@
+from core.hotkeys import normalize_shortcut, to_pynput
+
+
+def test_to_pynput():
+    assert to_pynput("Ctrl+Alt+M") == "<ctrl>+<alt>+m"
+    assert to_pynput("cmd + shift + f5") == "<cmd>+<shift>+<f5>"
+    assert to_pynput("Ctrl+Alt+Space") == "<ctrl>+<alt>+<space>"
+
+
+def test_invalid_shortcuts():
+    for shortcut in ("M", "Ctrl+Alt", "Ctrl++M", "Ctrl+Ctrl+M", "Hyper+M", "Ctrl+MM", ""):
+        assert to_pynput(shortcut) is None
+        assert normalize_shortcut(shortcut) is None
+
+
+def test_normalize_shortcut():
+    assert normalize_shortcut("alt + ctrl + m") == "Ctrl+Alt+M"
+    assert normalize_shortcut("shift+win+f12") == "Shift+Cmd+F12"
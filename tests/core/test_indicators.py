@@ -0,0 +1,109 @@
+import math
+
+import pytest
+
+from core.indicators import (
+    Candle,
+    CandleSeries,
+    IndicatorEngine,
+    atr,
+    band_breach_side,
+    bollinger_bands,
+    compute_indicators,
+    detect_cross,
+    ema,
+    macd,
+    realized_volatility,
+    rsi,
+)
+
+
+def test_ema_and_rsi_on_trending_series():
+    closes = [float(i) for i in range(1, 31)]
+
+    # EMA of a linear series lags the last value by (period - 1) / 2 steps
+    assert ema(closes, 5) == pytest.approx(28.0)
+    assert ema(closes[:4], 5) is None
+
+    assert rsi(closes) == 100.0
+    assert rsi(list(reversed(closes))) == 0.0
+    assert rsi(closes[:14]) is None
+
+
+def test_macd_needs_slow_and_signal_history():
+    assert macd([1.0] * 33) is None
+
+    line, signal, histogram = macd([1.0] * 40)
+    assert line == pytest.approx(0.0)
+    assert histogram == pytest.approx(line - signal)
+
+    snapshot = compute_indicators([Candle(i * 3600, 100, 100, 100, 100) for i in range(60)])
+    assert snapshot.rsi == 50.0
+    assert snapshot.ema_50 == pytest.approx(100.0)
+    assert snapshot.ema_200 is None
+
+
+def test_candle_series_rolls_on_interval_boundary():
+    series = CandleSeries("1h")
+    series.load([{"timestamp": 3600_000, "open": 1, "high": 2, "low": 1, "close": 2}])
+
+    assert series.update(3.0, 3600 + 1800) is False
+    assert series.candles()[-1].high == 3.0
+
+    assert series.update(2.5, 7200) is True
+    assert series.closes() == [3.0, 2.5]
+
+
+def test_detect_cross():
+    assert detect_cross(None, 1.0) is None
+    assert detect_cross(-1.0, 1.0) == "above"
+    assert detect_cross(0.0, 1.0) == "above"
+    assert detect_cross(1.0, -0.5) == "below"
+    assert detect_cross(1.0, 2.0) is None
+
+
+def test_bollinger_band_breach_on_candle_close():
+    flat = [100.0, 101.0] * 10
+
+    lower, middle, upper = bollinger_bands(flat)
+    assert middle == pytest.approx(100.5)
+    assert upper - middle == pytest.approx(1.0)
+    assert band_breach_side(flat) is None
+
+    side, band = band_breach_side(flat[1:] + [110.0])
+    assert side == "upper"
+    assert band < 110.0
+
+    engine = IndicatorEngine()
+    engine.set_pairs(["BTC-USDT"])
+    breaches = []
+    closes = []
+    engine.band_breached.connect(breaches.append)
+    engine.candle_closed.connect(lambda pair, interval, close: closes.append(close))
+    for i, close in enumerate(flat[1:] + [90.0]):
+        engine.update_price("BTC-USDT", close, i * 3600)
+    assert breaches == []
+
+    engine.update_price("BTC-USDT", 95.0, len(flat) * 3600)
+    assert len(breaches) == 1
+    assert breaches[0].side == "lower"
+    assert breaches[0].close == 90.0
+    assert closes[-1] == 90.0
+
+
+def test_atr_and_realized_volatility():
+    # Range of 2 per candle plus gaps of 1 between closes
+    candles = [Candle(i * 3600, 100 + i, 101 + i, 99 + i, 100 + i) for i in range(20)]
+
+    assert atr(candles[:14]) is None
+    assert atr(candles) == pytest.approx(2.0)
+
+    assert realized_volatility([100.0] * 25) == 0.0
+    assert realized_volatility([100.0] * 24) is None
+    step = math.log(101 / 100)
+    expected = math.sqrt(24 / 23 * step**2 * 365) * 100
+    assert realized_volatility([100.0, 101.0] * 13, "1d") == pytest.approx(expected)
+
+    snapshot = compute_indicators(candles)
+    assert snapshot.atr_pct == pytest.approx(2.0 / 119 * 100)
+    assert snapshot.move_atr == pytest.approx(0.5)
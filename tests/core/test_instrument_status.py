@@ -0,0 +1,77 @@
+from unittest.mock import MagicMock, patch
+
+from core.instrument_status import (
+    DELISTED,
+    DELISTING,
+    LIVE,
+    SUSPENDED,
+    InstrumentStatus,
+    InstrumentStatusMonitor,
+    exchange_pairs,
+    parse_binance_symbol,
+    parse_okx_instrument,
+)
+
+
+def test_okx_instrument_states():
+    assert parse_okx_instrument("BTC-USDT", {"state": "live", "expTime": ""}).state == LIVE
+    assert parse_okx_instrument("BTC-USDT", {"state": "suspend"}).state == SUSPENDED
+    assert parse_okx_instrument("BTC-USDT", None).state == DELISTED
+
+    status = parse_okx_instrument("LUNA-USDT", {"state": "live", "expTime": "1716000000000"})
+    assert status.state == DELISTING
+    assert status.delist_at == 1716000000
+
+
+def test_binance_symbol_states():
+    assert parse_binance_symbol("BTC-USDT", {"status": "TRADING"}).state == LIVE
+    status = parse_binance_symbol("BTC-USDT", {"status": "BREAK"})
+    assert status.state == SUSPENDED
+    assert status.raw_state == "BREAK"
+    assert parse_binance_symbol("BTC-USDT", None).state == DELISTED
+
+
+def test_only_exchange_pairs_are_checked():
+    pairs = ["BTC-USDT", "ETH-USDT-SWAP", "SOL:abc123", "BTCDOM"]
+    assert exchange_pairs(pairs) == ["BTC-USDT", "ETH-USDT-SWAP"]
+
+
+def test_each_risky_state_is_reported_once():
+    monitor = InstrumentStatusMonitor()
+
+    def status(state):
+        return InstrumentStatus("OKX", "LUNA-USDT", state)
+
+    assert monitor.process([status(LIVE)]) == []
+    assert monitor.process([status(SUSPENDED)]) == [status(SUSPENDED)]
+    assert monitor.process([status(SUSPENDED)]) == []
+    assert monitor.process([status(DELISTING)]) == [status(DELISTING)]
+    assert monitor.get_state("OKX", "LUNA-USDT") == DELISTING
+
+    # A pair that resumes trading is reported again if it is suspended again
+    monitor.process([status(LIVE)])
+    assert monitor.process([status(SUSPENDED)]) == [status(SUSPENDED)]
+
+
+def test_states_are_only_polled_for_alerts():
+    settings_manager = MagicMock()
+    settings_manager.settings.delisting_alerts = False
+    settings_manager.settings.data_source = "OKX"
+    with (
+        patch("core.instrument_status.get_settings_manager", return_value=settings_manager),
+        patch("core.instrument_status.threading.Thread") as thread,
+    ):
+        monitor = InstrumentStatusMonitor()
+        monitor.set_pairs(["BTC-USDT"])
+        monitor.start()
+        assert not monitor._timer.isActive()
+        thread.assert_not_called()
+
+        settings_manager.settings.delisting_alerts = True
+        monitor.apply_settings()
+        assert monitor._timer.isActive()
+        thread.assert_called_once()
+
+        settings_manager.settings.delisting_alerts = False
+        monitor.apply_settings()
+        assert not monitor._timer.isActive()
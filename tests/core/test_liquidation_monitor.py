@@ -0,0 +1,36 @@
+import pytest
+
+from core.liquidation_monitor import liquidation_distance, risk_level
+from core.okx_account import AccountPosition
+
+
+def _position(mark, liq):
+    return AccountPosition(
+        position_id="1",
+        inst_id="BTC-USDT-SWAP",
+        inst_type="SWAP",
+        pos_side="long",
+        size=1.0,
+        avg_price=40000.0,
+        mark_price=mark,
+        liq_price=liq,
+        unrealized_pnl=0.0,
+        leverage=10.0,
+        margin_ratio=None,
+    )
+
+
+def test_liquidation_distance():
+    assert liquidation_distance(_position(40000.0, 36000.0)) == pytest.approx(10.0)
+    # Shorts liquidate above mark price
+    assert liquidation_distance(_position(40000.0, 42000.0)) == pytest.approx(5.0)
+    assert liquidation_distance(_position(40000.0, None)) is None
+
+
+def test_risk_level_escalation():
+    levels = [20.0, 10.0, 5.0]
+
+    assert risk_level(25.0, levels) == 0
+    assert risk_level(15.0, levels) == 1
+    assert risk_level(10.0, levels) == 2
+    assert risk_level(2.0, levels) == 3
@@ -0,0 +1,73 @@
+from unittest.mock import MagicMock, patch
+
+from core.listing_watcher import (
+    ListingAnnouncement,
+    ListingWatcher,
+    extract_symbols,
+    is_listing_title,
+)
+
+
+def _announcement(exchange: str, id: str, title: str, published_at: float = 0.0):
+    return ListingAnnouncement(
+        exchange=exchange,
+        id=id,
+        title=title,
+        url="",
+        published_at=published_at,
+        symbols=extract_symbols(title),
+    )
+
+
+def test_extract_symbols():
+    assert extract_symbols("Binance Will List Foo Protocol (FOO) and Bar (BAR)") == ["FOO", "BAR"]
+    assert extract_symbols("OKX to list perpetual futures for WIF and PEPE") == ["WIF", "PEPE"]
+    assert extract_symbols("OKX to list BTC-margined futures") == []
+
+
+def test_is_listing_title():
+    assert is_listing_title("Binance Will List Foo (FOO)")
+    assert is_listing_title("OKX to list spot trading for BAR")
+    assert not is_listing_title("Binance Will Delist FOO")
+    assert not is_listing_title("OKX system upgrade notice")
+
+
+def test_first_batch_only_seeds():
+    watcher = ListingWatcher()
+    old = [_announcement("Binance", "1", "Binance Will List Foo (FOO)")]
+    assert watcher.process(old) == []
+    assert watcher.process(old) == []
+
+    new = _announcement("Binance", "2", "Binance Will List Bar (BAR)", published_at=10)
+    delisting = _announcement("Binance", "3", "Binance Will Delist Baz (BAZ)", published_at=11)
+    assert watcher.process(old + [new, delisting]) == [new]
+
+
+def test_exchange_seeded_on_its_first_successful_poll():
+    watcher = ListingWatcher()
+    watcher.process([_announcement("Binance", "1", "Binance Will List Foo (FOO)")])
+
+    okx_old = _announcement("OKX", "a", "OKX to list spot trading for QUX")
+    assert watcher.process([okx_old]) == []
+
+
+def test_announcements_are_only_polled_for_alerts():
+    settings_manager = MagicMock()
+    settings_manager.settings.listing_alerts = "off"
+    with (
+        patch("core.listing_watcher.get_settings_manager", return_value=settings_manager),
+        patch("core.listing_watcher.threading.Thread") as thread,
+    ):
+        watcher = ListingWatcher()
+        watcher.start()
+        assert not watcher._timer.isActive()
+        thread.assert_not_called()
+
+        settings_manager.settings.listing_alerts = "watched"
+        watcher.apply_settings()
+        assert watcher._timer.isActive()
+        thread.assert_called_once()
+
+        settings_manager.settings.listing_alerts = "off"
+        watcher.apply_settings()
+        assert not watcher._timer.isActive()
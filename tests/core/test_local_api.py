@@ -0,0 +1,80 @@
+import json
+import urllib.request
+from dataclasses import replace
+
+from config.settings import LocalApiConfig
+from core.local_api import LocalApiServer
+from core.price_tracker import PriceState
+
+
+def _api(**config) -> LocalApiServer:
+    api = LocalApiServer()
+    api._settings_manager.settings.local_api = LocalApiConfig(**config)
+    state = PriceState(
+        current_price=61250.5,
+        percentage="+2.50%",
+        high_24h="62000",
+        low_24h="59000.5",
+        quote_volume_24h="1234567.8",
+        quote_token="USDT",
+    )
+    api.update_quote("BTC-USDT", state)
+    api.update_quote("ETH-USDT", replace(state, current_price=3000.0, alias="Ether"))
+    return api
+
+
+def teardown_function():
+    LocalApiServer()._settings_manager.settings.local_api = LocalApiConfig()
+
+
+def test_pairs_are_listed_and_looked_up():
+    api = _api()
+
+    response = api.dispatch("GET", "/api/pairs")
+    assert response.status == 200
+    assert [quote["pair"] for quote in response.body["pairs"]] == ["BTC-USDT", "ETH-USDT"]
+
+    quote = api.dispatch("GET", "/api/pairs/btc-usdt").body
+    assert quote["price"] == 61250.5
+    assert quote["change_24h"] == 2.5
+    assert quote["low_24h"] == 59000.5
+    assert quote["quote"] == "USDT"
+
+    assert api.dispatch("GET", "/api/pairs/DOGE-USDT").status == 404
+    assert api.dispatch("GET", "/api/nothing").status == 404
+    assert api.dispatch("POST", "/api/pairs").status == 405
+
+    api.set_pairs(["ETH-USDT"])
+    assert api.dispatch("GET", "/api/pairs/BTC-USDT").status == 404
+
+
+def test_home_assistant_sensor():
+    body = _api().dispatch("GET", "/api/ha/ETH-USDT").body
+
+    assert body["state"] == 3000.0
+    assert body["attributes"]["unit_of_measurement"] == "USDT"
+    assert body["attributes"]["friendly_name"] == "Ether"
+    assert body["attributes"]["change_24h"] == 2.5
+    assert "price" not in body["attributes"]
+
+
+def test_token_is_required_when_set():
+    api = _api(token="s3cret")
+
+    assert api.dispatch("GET", "/api/pairs").status == 401
+    assert api.dispatch("GET", "/api/pairs", {"Authorization": "Bearer wrong"}).status == 401
+    assert api.dispatch("GET", "/api/pairs", {"Authorization": "Bearer s3cret"}).status == 200
+    assert api.dispatch("GET", "/api/pairs?token=s3cret").status == 200
+
+
+def test_server_answers_over_http():
+    api = _api(enabled=True, port=0)
+    api.start()
+    try:
+        port = api._server.server_address[1]
+        with urllib.request.urlopen(f"http://127.0.0.1:{port}/api/ha/BTC-USDT") as response:
+            assert response.headers["Content-Type"].startswith("application/json")
+            assert json.load(response)["state"] == 61250.5
+    finally:
+        api.stop()
+    assert not api.is_running
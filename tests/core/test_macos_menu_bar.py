@@ -0,0 +1,21 @@
+import sys
+
+from core.highlight_rules import Highlight
+from core.macos_menu_bar import MacMenuBarItem, menu_bar_text
+from core.tray_summary import TrayEntry
+
+
+def test_menu_bar_text():
+    entry = TrayEntry("BTC-USDT", "BTC", "61,250.50", "+2.50%")
+    assert menu_bar_text(entry) == "BTC 61,250.50 +2.50%"
+    entry.highlight = Highlight("up", 2.0, "#4CAF50", False)
+    assert menu_bar_text(entry) == "● BTC 61,250.50 +2.50%"
+
+
+def test_item_is_inert_off_macos(monkeypatch):
+    monkeypatch.setattr(sys, "platform", "win32")
+    item = MacMenuBarItem()
+    assert not item.show()
+    item.set_text("BTC 61,250.50 +2.50%")
+    item.hide()
+    assert not item.is_shown
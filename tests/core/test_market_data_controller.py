@@ -0,0 +1,17 @@
+from dataclasses import replace
+
+from core.market_data_controller import render_key
+from core.models import TickerData
+from core.price_tracker import PriceState
+
+
+def test_render_key_changes_with_derived_values():
+    data = TickerData(pair="BTC-USDT", price="61250.5", percentage="+1.20%")
+    state = PriceState(current_price=61250.5, fiat_price=440000.0, fiat_currency="CNY")
+    key = render_key(data, state)
+    assert render_key(data, replace(state)) == key
+
+    # Same tick after the FX rate, VWAP or oracle price moved
+    assert render_key(data, replace(state, fiat_price=441000.0)) != key
+    assert render_key(data, replace(state, vwap=61000.0)) != key
+    assert render_key(data, replace(state, oracle_price=61300.0)) != key
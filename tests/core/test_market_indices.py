@@ -0,0 +1,61 @@
+from unittest.mock import MagicMock, patch
+
+from core.market_indices import (
+    FEAR_GREED_PAIR,
+    FearGreedIndex,
+    FearGreedService,
+    change_percentage,
+    fear_greed_zone,
+    is_index_pair,
+)
+
+
+def test_fear_greed_ticker():
+    index = FearGreedIndex(value=22, classification="Extreme Fear", timestamp=0, previous_value=20)
+    ticker = index.to_ticker()
+    assert ticker.pair == FEAR_GREED_PAIR
+    assert ticker.price == "22"
+    assert ticker.percentage == "+10.00%"
+    assert is_index_pair(ticker.pair)
+    assert change_percentage(10, None) == "0.00%"
+
+
+def test_extreme_zone_is_reported_on_entry_only():
+    assert fear_greed_zone(20, 20, 80) == "fear"
+    assert fear_greed_zone(50, 20, 80) is None
+    assert fear_greed_zone(85, 20, 80) == "greed"
+
+    service = FearGreedService()
+    # Already extreme the day before: not a new extreme at startup
+    assert not service.check_extreme(FearGreedIndex(15, "", 0, previous_value=18), 20, 80)
+    assert not service.check_extreme(FearGreedIndex(40, "", 0), 20, 80)
+    assert service.check_extreme(FearGreedIndex(10, "", 0), 20, 80)
+    assert not service.check_extreme(FearGreedIndex(12, "", 0), 20, 80)
+    assert service.check_extreme(FearGreedIndex(90, "", 0), 20, 80)
+
+
+def test_index_is_only_polled_while_alerted_or_watched():
+    settings_manager = MagicMock()
+    settings_manager.settings.fear_greed_alerts = False
+    settings_manager.settings.crypto_pairs = ["BTC-USDT"]
+    settings_manager.settings.alerts = []
+    with (
+        patch("core.market_indices.get_settings_manager", return_value=settings_manager),
+        patch("core.market_indices.threading.Thread") as thread,
+    ):
+        service = FearGreedService()
+        service.start()
+        assert not service._timer.isActive()
+        thread.assert_not_called()
+
+        settings_manager.settings.crypto_pairs = ["BTC-USDT", FEAR_GREED_PAIR]
+        service.apply_settings()
+        assert service._timer.isActive()
+        thread.assert_called_once()
+
+        settings_manager.settings.crypto_pairs = ["BTC-USDT"]
+        service.apply_settings()
+        assert not service._timer.isActive()
+        settings_manager.settings.fear_greed_alerts = True
+        service.apply_settings()
+        assert service._timer.isActive()
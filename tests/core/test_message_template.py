@@ -0,0 +1,31 @@
+import pytest
+
+from config.settings import PriceAlert
+from core.message_template import describe_rule, fill_template, render_alert_message
+
+
+def test_unknown_placeholders_stay_as_typed():
+    assert fill_template("{pair} {oops}", {"pair": "BTC-USDT"}) == "BTC-USDT {oops}"
+    with pytest.raises(ValueError):
+        fill_template("{pair", {"pair": "BTC-USDT"})
+
+
+def test_rules_are_described_with_their_target():
+    trailing = PriceAlert(alert_type="trailing_high", target_price=5)
+    assert describe_rule(trailing) == "Trailing Stop 5%"
+    assert describe_rule(PriceAlert(alert_type="rsi_below", target_price=30)) == "RSI Below 30"
+    assert (
+        describe_rule(PriceAlert(alert_type="ema_cross_above", ema_period=50, timeframe="4h"))
+        == "Crosses Above EMA 50 · 4h"
+    )
+
+
+def test_alert_message_falls_back_to_the_built_in_text():
+    alert = PriceAlert(pair="ETH-USDT", alert_type="price_below", target_price=3000)
+    assert render_alert_message(alert, 2990.0, -3.2) is None
+
+    alert.message_template = "{symbol} {price} {change} < {target}"
+    assert render_alert_message(alert, 2990.0, -3.2) == "ETH 2990.00 -3.20% < 3000"
+
+    alert.message_template = "{symbol"
+    assert render_alert_message(alert, 2990.0, -3.2) is None
@@ -0,0 +1,28 @@
+import json
+from pathlib import Path
+
+from core.i18n import get_current_language, load_language
+from core.messages import MESSAGES, message
+
+I18N_DIR = Path(__file__).resolve().parents[2] / "i18n"
+
+
+def test_message_fills_in_fields():
+    assert message("connecting", attempt=2) == "Connecting... (attempt 2)"
+    assert message("unknown_code") == "unknown_code"
+
+
+def test_message_follows_language():
+    previous = get_current_language()
+    try:
+        load_language("zh_CN")
+        assert message("http_error", status=503) == "HTTP 错误：503"
+    finally:
+        load_language(previous)
+
+
+def test_catalog_translated_for_en_and_zh():
+    for lang in ("en_US", "zh_CN"):
+        translations = json.loads((I18N_DIR / f"{lang}.json").read_text(encoding="utf-8"))
+        for code, template in MESSAGES.items():
+            assert translations.get(template), (lang, code)
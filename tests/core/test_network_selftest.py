@@ -0,0 +1,72 @@
+import ssl
+
+from config.settings import ProxyConfig
+from core.network_selftest import (
+    DNS,
+    PROXY,
+    PROXY_TLS,
+    PROXY_WEBSOCKET,
+    TLS,
+    WEBSOCKET,
+    StepResult,
+    diagnose,
+    run_self_test,
+    run_steps,
+)
+
+
+def _results(*outcomes):
+    return [StepResult(step, ok) for step, ok in outcomes]
+
+
+def test_run_steps_stops_at_first_failure():
+    seen = []
+
+    def fail():
+        raise ssl.SSLCertVerificationError("certificate verify failed")
+
+    results = run_steps(
+        [(DNS, lambda: "1.2.3.4"), (TLS, fail), (WEBSOCKET, lambda: "101")], seen.append
+    )
+
+    assert [(r.step, r.ok) for r in results] == [(DNS, True), (TLS, False)]
+    assert results[0].detail == "1.2.3.4"
+    assert results[1].certificate_error
+    assert seen == results
+
+
+def test_diagnose_direct_failures():
+    assert diagnose(_results((DNS, False)), False) == "dns_blocked"
+    assert diagnose(_results((DNS, True), (TLS, False)), False) == "direct_blocked"
+    assert (
+        diagnose(_results((DNS, True), (TLS, True), (WEBSOCKET, False)), False)
+        == "websocket_blocked"
+    )
+    assert diagnose(_results((DNS, True), (TLS, True), (WEBSOCKET, True)), False) == "ok_direct"
+
+
+def test_diagnose_with_proxy():
+    direct_ok = [(DNS, True), (TLS, True), (WEBSOCKET, True)]
+    direct_blocked = [(DNS, True), (TLS, False)]
+
+    assert diagnose(_results(*direct_blocked, (PROXY, False)), True) == "proxy_unreachable"
+    assert (
+        diagnose(_results(*direct_blocked, (PROXY, True), (PROXY_TLS, False)), True)
+        == "proxy_tunnel_failed"
+    )
+    assert (
+        diagnose(_results(*direct_ok, (PROXY, True), (PROXY_TLS, False)), True)
+        == "disable_proxy"
+    )
+    proxy_ok = [(PROXY, True), (PROXY_TLS, True), (PROXY_WEBSOCKET, True)]
+    assert diagnose(_results(*direct_blocked, *proxy_ok), True) == "ok_proxy"
+
+    intercepted = _results(*direct_blocked)
+    intercepted[1].certificate_error = True
+    assert diagnose(intercepted, False) == "tls_intercepted"
+
+
+def test_sources_without_endpoint_skip_the_test():
+    report = run_self_test("Simulated", ProxyConfig())
+    assert report.results == []
+    assert report.diagnosis == "no_network"
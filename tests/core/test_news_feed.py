@@ -0,0 +1,97 @@
+from unittest.mock import MagicMock, patch
+
+import pytest
+
+from core.news_feed import NewsFeedService, NewsItem, feed_source_name, parse_feed, tag_symbols
+
+RSS = """<?xml version="1.0"?>
+<rss version="2.0"><channel><title>Feed</title>
+<item>
+  <title>Bitcoin tops $100K as ETF inflows surge</title>
+  <link>https://example.com/a</link>
+  <guid>a</guid>
+  <pubDate>Mon, 06 Jan 2025 10:00:00 +0000</pubDate>
+  <description>&lt;p&gt;Traders bid &lt;b&gt;BTC&lt;/b&gt; higher.&lt;/p&gt;</description>
+</item>
+</channel></rss>"""
+
+ATOM = """<?xml version="1.0"?>
+<feed xmlns="http://www.w3.org/2005/Atom"><title>Feed</title>
+<entry>
+  <id>urn:b</id>
+  <title>Solana validators ship upgrade</title>
+  <link rel="alternate" href="https://example.com/b"/>
+  <updated>2025-01-06T12:00:00Z</updated>
+  <summary>SOL fees drop</summary>
+</entry>
+</feed>"""
+
+
+@pytest.fixture
+def service():
+    settings_manager = MagicMock()
+    settings_manager.settings.news_feeds = []
+    settings_manager.settings.news_alerts = True
+    with patch("core.news_feed.get_settings_manager", return_value=settings_manager):
+        yield NewsFeedService()
+
+
+def test_parse_rss_and_atom():
+    (rss,) = parse_feed(RSS, "Test")
+    assert rss.id == "a"
+    assert rss.url == "https://example.com/a"
+    assert rss.published_at == 1736157600
+    assert rss.summary == "Traders bid BTC higher."
+
+    (atom,) = parse_feed(ATOM, "Test")
+    assert atom.id == "urn:b"
+    assert atom.url == "https://example.com/b"
+    assert atom.published_at == 1736164800
+
+    with pytest.raises(ValueError):
+        parse_feed("not xml", "Test")
+
+
+def test_tag_symbols():
+    symbols = ["BTC", "ETH", "SOL", "OP"]
+    assert tag_symbols("Ethereum gas hits record low", symbols) == ["ETH"]
+    assert tag_symbols("$SOL and BTC rally", symbols) == ["BTC", "SOL"]
+    assert tag_symbols("Options traders stop hedging", symbols) == []
+
+
+def test_feed_source_name():
+    assert feed_source_name("https://www.coindesk.com/arc/outboundfeeds/rss/") == "CoinDesk"
+    assert feed_source_name("https://www.example.org/feed.xml") == "example.org"
+
+
+def test_only_new_relevant_items_are_reported(service):
+    service.set_pairs(["BTC-USDT", "ETH-USDT"])
+    old = NewsItem("Test", "1", "Bitcoin slips", "", published_at=1)
+    assert service.process("feed", [old]) == []
+
+    relevant = NewsItem("Test", "2", "Ether ETF approved", "", published_at=3)
+    unrelated = NewsItem("Test", "3", "Dogecoin meme season", "", published_at=2)
+    assert service.process("feed", [old, relevant, unrelated]) == [relevant]
+    assert relevant.symbols == ["ETH"]
+
+    assert [item.id for item in service.get_news()] == ["2", "3", "1"]
+    assert [item.id for item in service.get_news("BTC-USDT")] == ["1"]
+
+
+def test_feeds_are_only_polled_for_alerts(service):
+    settings = service._settings_manager.settings
+    settings.news_alerts = False
+    with patch("core.news_feed.threading.Thread") as thread:
+        service.start()
+        assert not service._timer.isActive()
+        service.refresh()
+        thread.assert_not_called()
+
+        settings.news_alerts = True
+        service.apply_settings()
+        assert service._timer.isActive()
+        thread.assert_called_once()
+
+    settings.news_alerts = False
+    service.apply_settings()
+    assert not service._timer.isActive()
@@ -0,0 +1,183 @@
+from unittest.mock import MagicMock, patch
+
+import pytest
+
+from config.settings import (
+    AppSettings,
+    DingTalkConfig,
+    EmailConfig,
+    FeishuConfig,
+    GotifyConfig,
+    MatrixConfig,
+    TelegramConfig,
+    WeComConfig,
+    XmppConfig,
+)
+from core.notification_channels import (
+    DingTalkChannel,
+    EmailChannel,
+    FeishuChannel,
+    GotifyChannel,
+    MatrixChannel,
+    TelegramChannel,
+    WeComChannel,
+    XmppChannel,
+    configured_channels,
+    dingtalk_sign,
+    feishu_sign,
+)
+
+
+def _response(status=200, body=None):
+    return MagicMock(ok=status < 400, status_code=status, json=MagicMock(return_value=body or {}))
+
+
+def test_only_complete_enabled_channels_are_used():
+    settings = AppSettings()
+    settings.telegram = TelegramConfig(enabled=True, bot_token="123:abc")
+    settings.matrix = MatrixConfig(enabled=True, access_token="syt_x", room_id="!r:matrix.org")
+    settings.wecom = WeComConfig(enabled=False, webhook_url="https://example.com/hook")
+
+    assert [channel.name for channel in configured_channels(settings)] == ["Matrix"]
+
+
+def test_matrix_message():
+    channel = MatrixChannel(
+        MatrixConfig(homeserver="https://m.example/", access_token="syt_x", room_id="!r:m.example")
+    )
+    with patch("core.notification_channels.requests.put", return_value=_response()) as put:
+        channel.send("BTC-USDT", "Price above 70,000")
+
+    url = put.call_args.args[0]
+    assert url.startswith("https://m.example/_matrix/client/v3/rooms/%21r%3Am.example/send/")
+    assert put.call_args.kwargs["json"] == {
+        "msgtype": "m.text",
+        "body": "BTC-USDT\nPrice above 70,000",
+    }
+    assert put.call_args.kwargs["headers"] == {"Authorization": "Bearer syt_x"}
+
+    with patch(
+        "core.notification_channels.requests.put",
+        return_value=_response(403, {"errcode": "M_FORBIDDEN", "error": "Not in room"}),
+    ):
+        with pytest.raises(RuntimeError, match="Not in room"):
+            channel.send("BTC-USDT", "Price above 70,000")
+
+
+def test_wecom_message():
+    channel = WeComChannel(WeComConfig(webhook_url="https://qyapi.example/send?key=k"))
+    with patch(
+        "core.notification_channels.requests.post",
+        return_value=_response(body={"errcode": 0, "errmsg": "ok"}),
+    ) as post:
+        channel.send("BTC-USDT", "Price above 70,000")
+    assert post.call_args.args[0] == "https://qyapi.example/send?key=k"
+    assert post.call_args.kwargs["json"] == {
+        "msgtype": "text",
+        "text": {"content": "BTC-USDT\nPrice above 70,000"},
+    }
+
+    with patch(
+        "core.notification_channels.requests.post",
+        return_value=_response(body={"errcode": 93000, "errmsg": "invalid webhook url"}),
+    ):
+        with pytest.raises(RuntimeError, match="invalid webhook url"):
+            channel.send("BTC-USDT", "Price above 70,000")
+
+
+def test_telegram_rejection_is_raised():
+    channel = TelegramChannel(TelegramConfig(bot_token="123:abc", chat_id="42"))
+    with patch(
+        "core.notification_channels.requests.post",
+        return_value=_response(400, {"ok": False, "description": "chat not found"}),
+    ):
+        with pytest.raises(RuntimeError, match="chat not found"):
+            channel.send("BTC-USDT", "Price above 70,000")
+
+
+def test_signatures():
+    dingtalk_signature = "jcUpW0QmtKduN03n4JqQ0PBosVjqnM8gU7fIIvsDmCM%3D"
+    assert dingtalk_sign("SECabc", 1700000000000) == dingtalk_signature
+    assert feishu_sign("abc", 1700000000) == "VIS10b0EBvzzSdFnuk4tznEmK5wHaruvf/WnViv2yR4="
+
+
+def test_signed_dingtalk_and_feishu_messages():
+    ok = {"errcode": 0, "code": 0}
+    dingtalk_url = "https://oapi.example/robot/send?access_token=t"
+    dingtalk = DingTalkChannel(DingTalkConfig(webhook_url=dingtalk_url, secret="SECabc"))
+    feishu = FeishuChannel(FeishuConfig(webhook_url="https://open.example/hook/x", secret="abc"))
+    with (
+        patch("core.notification_channels.time.time", return_value=1700000000),
+        patch("core.notification_channels.requests.post", return_value=_response(body=ok)) as post,
+    ):
+        dingtalk.send("BTC-USDT", "Price above 70,000")
+        assert post.call_args.args[0] == (
+            "https://oapi.example/robot/send?access_token=t&timestamp=1700000000000"
+            "&sign=jcUpW0QmtKduN03n4JqQ0PBosVjqnM8gU7fIIvsDmCM%3D"
+        )
+
+        feishu.send("BTC-USDT", "Price above 70,000")
+        assert post.call_args.kwargs["json"] == {
+            "msg_type": "text",
+            "content": {"text": "BTC-USDT\nPrice above 70,000"},
+            "timestamp": "1700000000",
+            "sign": "VIS10b0EBvzzSdFnuk4tznEmK5wHaruvf/WnViv2yR4=",
+        }
+
+    with patch(
+        "core.notification_channels.requests.post",
+        return_value=_response(body={"code": 19021, "msg": "sign match fail"}),
+    ):
+        with pytest.raises(RuntimeError, match="sign match fail"):
+            feishu.send("BTC-USDT", "Price above 70,000")
+
+
+def test_gotify_message():
+    channel = GotifyChannel(
+        GotifyConfig(server_url="https://push.example/", app_token="A1b2", priority=8)
+    )
+    with patch("core.notification_channels.requests.post", return_value=_response()) as post:
+        channel.send("BTC-USDT", "Price above 70,000")
+    assert post.call_args.args[0] == "https://push.example/message"
+    assert post.call_args.kwargs["json"] == {
+        "title": "BTC-USDT",
+        "message": "Price above 70,000",
+        "priority": 8,
+    }
+    assert post.call_args.kwargs["headers"] == {"X-Gotify-Key": "A1b2"}
+
+    with patch(
+        "core.notification_channels.requests.post",
+        return_value=_response(401, {"error": "Unauthorized", "errorDescription": "bad token"}),
+    ):
+        with pytest.raises(RuntimeError, match="bad token"):
+            channel.send("BTC-USDT", "Price above 70,000")
+
+
+def test_xmpp_without_slixmpp_fails_with_a_hint():
+    channel = XmppChannel(XmppConfig(jid="a@example.com", password="pw", recipient="b@example.com"))
+    with patch("core.notification_channels.XMPP_AVAILABLE", False):
+        with pytest.raises(RuntimeError, match="slixmpp"):
+            channel.send("BTC-USDT", "Price above 70,000")
+
+
+def test_email_message():
+    channel = EmailChannel(
+        EmailConfig(
+            smtp_host="smtp.example.com",
+            smtp_port=587,
+            username="me@example.com",
+            password="pw",
+            recipient="phone@example.com",
+        )
+    )
+    with patch("core.notification_channels.smtplib.SMTP") as smtp:
+        channel.send("BTC-USDT", "Price above 70,000")
+    server = smtp.return_value
+    server.starttls.assert_called_once()
+    server.login.assert_called_once_with("me@example.com", "pw")
+    email = server.send_message.call_args.args[0]
+    assert email["Subject"] == "BTC-USDT"
+    assert email["From"] == "me@example.com"
+    assert email["To"] == "phone@example.com"
+    assert email.get_content().strip() == "Price above 70,000"
@@ -0,0 +1,26 @@
+from core.number_format import NumberFormatter, resolve_number_format
+
+
+def test_plain_format_keeps_text():
+    assert NumberFormatter().price(65432.1) == "65432.10"
+    assert NumberFormatter("bogus").localize("1234.5") == "1234.5"
+
+
+def test_separators():
+    assert NumberFormatter("comma").price(1234567.891) == "1,234,567.89"
+    assert NumberFormatter("dot").fiat(60000.0, "EUR") == "€60.000,00"
+    assert NumberFormatter("space").compact(69_400) == "69,40K"
+    assert NumberFormatter("space").price(1234.5) == "1\u202f234,50"
+
+
+def test_localize_leaves_small_numbers_and_signs():
+    formatter = NumberFormatter("dot")
+    assert formatter.localize("-0.000123 (+1.50%)") == "-0,000123 (+1,50%)"
+    assert formatter.localize("EMA 200") == "EMA 200"
+
+
+def test_resolve_number_format():
+    assert resolve_number_format("auto", "de_DE") == "dot"
+    assert resolve_number_format("auto", "zh_CN") == "comma"
+    assert resolve_number_format("space", "en_US") == "space"
+    assert resolve_number_format("unknown", "en_US") == "plain"
@@ -0,0 +1,78 @@
+from config.settings import OkxApiConfig
+from core.okx_account import (
+    OkxRestClient,
+    parse_account,
+    parse_permissions,
+    parse_position,
+    private_ws_url,
+    risky_permissions,
+    sign_message,
+)
+
+
+def test_sign_message():
+    prehash = "2024-01-01T00:00:00.000ZGET/api/v5/account/balance"
+    assert sign_message(prehash, "secret") == "dfI+ViVVBgfRPWcGyH3gM3bM/DTyiqUqZys/Y9UbsFQ="
+
+
+def test_parse_account_skips_empty_balances():
+    total_eq, margin_ratio, balances = parse_account(
+        {
+            "totalEq": "1500.5",
+            "mgnRatio": "",
+            "details": [
+                {"ccy": "USDT", "eq": "500", "availBal": "400", "eqUsd": "500"},
+                {"ccy": "BTC", "eq": "0.02", "availBal": "0.02", "eqUsd": "1000.5"},
+                {"ccy": "ETH", "eq": "0", "availBal": "0", "eqUsd": "0"},
+            ],
+        }
+    )
+
+    assert total_eq == 1500.5
+    assert margin_ratio is None
+    assert [b.currency for b in balances] == ["BTC", "USDT"]
+    assert balances[1].available == 400.0
+
+
+def test_parse_position():
+    position = parse_position(
+        {
+            "posId": "1",
+            "instId": "BTC-USDT-SWAP",
+            "instType": "SWAP",
+            "posSide": "long",
+            "pos": "2",
+            "avgPx": "40000",
+            "markPx": "41000",
+            "liqPx": "",
+            "upl": "20",
+            "lever": "10",
+            "mgnRatio": "5.5",
+        }
+    )
+
+    assert position.inst_id == "BTC-USDT-SWAP"
+    assert position.size == 2.0
+    assert position.liq_price is None
+    assert position.margin_ratio == 5.5
+
+
+def test_risky_permissions():
+    assert parse_permissions("read_only, trade") == {"read_only", "trade"}
+    assert parse_permissions("") == set()
+
+    assert risky_permissions({"read_only"}, trading_enabled=False) == []
+    assert risky_permissions({"read_only", "trade"}, trading_enabled=False) == ["trade"]
+    assert risky_permissions({"read_only", "trade"}, trading_enabled=True) == []
+    # Withdrawal is never needed, even with trading allowed
+    assert risky_permissions({"trade", "withdraw"}, trading_enabled=True) == ["withdraw"]
+
+
+def test_demo_trading_endpoints():
+    config = OkxApiConfig(api_key="key", secret_key="secret", passphrase="pass")
+    assert private_ws_url(config) == "wss://ws.okx.com:8443/ws/v5/private"
+    assert "x-simulated-trading" not in OkxRestClient(config)._headers("GET", "/", "")
+
+    config.demo = True
+    assert private_ws_url(config) == "wss://wspap.okx.com:8443/ws/v5/private"
+    assert OkxRestClient(config)._headers("GET", "/", "")["x-simulated-trading"] == "1"
@@ -0,0 +1,23 @@
+from core.okx_trading import OrderRequest, build_order_body, validate_order
+
+
+def test_validate_order():
+    assert validate_order(OrderRequest("BTC-USDT", "buy", "limit", 0.1, 50000)) is None
+    assert validate_order(OrderRequest("BTC-USDT", "buy", "market", 100)) is None
+    assert validate_order(OrderRequest("BTC-USDT", "buy", "limit", 0.1)) is not None
+    assert validate_order(OrderRequest("BTC-USDT", "hold", "market", 1)) is not None
+    assert validate_order(OrderRequest("BTC-USDT", "sell", "market", 0)) is not None
+
+
+def test_build_order_body_formats_decimals():
+    body = build_order_body(OrderRequest("BTC-USDT", "buy", "limit", 0.00000123, 65000.5))
+
+    assert body == {
+        "instId": "BTC-USDT",
+        "tdMode": "cash",
+        "side": "buy",
+        "ordType": "limit",
+        "sz": "0.00000123",
+        "px": "65000.5",
+    }
+    assert "px" not in build_order_body(OrderRequest("BTC-USDT", "sell", "market", 1))
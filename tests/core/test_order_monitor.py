@@ -0,0 +1,43 @@
+from core.order_monitor import classify_order_event, parse_order
+
+
+def _order(state, filled="0"):
+    return parse_order(
+        {
+            "ordId": "1",
+            "instId": "BTC-USDT",
+            "side": "buy",
+            "ordType": "limit",
+            "state": state,
+            "sz": "1",
+            "accFillSz": filled,
+            "px": "40000",
+            "avgPx": "",
+        }
+    )
+
+
+def test_classify_order_lifecycle():
+    live = _order("live")
+    partial = _order("partially_filled", "0.4")
+    filled = _order("filled", "1")
+
+    assert classify_order_event(None, live) is None
+    assert classify_order_event(live, partial) == "partially_filled"
+    assert classify_order_event(partial, filled) == "filled"
+
+
+def test_classify_ignores_duplicate_pushes():
+    partial = _order("partially_filled", "0.4")
+
+    assert classify_order_event(partial, _order("partially_filled", "0.4")) is None
+    assert classify_order_event(partial, _order("partially_filled", "0.6")) == "partially_filled"
+    assert classify_order_event(_order("canceled"), _order("canceled")) is None
+
+
+def test_parse_order_missing_avg_price():
+    order = _order("canceled")
+
+    assert order.avg_price is None
+    assert order.is_final
+    assert classify_order_event(_order("live"), order) == "canceled"
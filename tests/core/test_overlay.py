@@ -0,0 +1,50 @@
+import json
+import urllib.request
+
+from config.settings import LocalApiConfig
+from core.local_api import LocalApiServer
+from core.overlay import OverlayServer
+from core.price_tracker import PriceState
+
+
+def _server() -> LocalApiServer:
+    server = LocalApiServer()
+    OverlayServer(server)
+    server.update_quote("BTC-USDT", PriceState(current_price=61250.5, percentage="+2.50%"))
+    server.update_quote("ETH-USDT", PriceState(current_price=3000.0, percentage="-0.50%"))
+    return server
+
+
+def teardown_function():
+    LocalApiServer()._settings_manager.settings.local_api = LocalApiConfig()
+
+
+def test_page_listens_for_events():
+    response = _server().dispatch("GET", "/overlay")
+
+    assert response.content_type == "text/html"
+    assert 'new EventSource("/overlay/events" + location.search)' in response.body
+
+
+def test_events_carry_the_selected_pairs():
+    response = _server().dispatch("GET", "/overlay/events?pairs=eth-usdt,DOGE-USDT,BTC-USDT")
+
+    event = next(response.events)
+    response.events.close()
+    assert event.startswith("data: ") and event.endswith("\n\n")
+    assert [quote["pair"] for quote in json.loads(event[6:])] == ["ETH-USDT", "BTC-USDT"]
+
+
+def test_events_stream_over_http():
+    server = _server()
+    server._settings_manager.settings.local_api = LocalApiConfig(enabled=True, port=0)
+    server.start()
+    try:
+        port = server._server.server_address[1]
+        url = f"http://127.0.0.1:{port}/overlay/events?pairs=BTC-USDT"
+        with urllib.request.urlopen(url) as response:
+            assert response.headers["Content-Type"].startswith("text/event-stream")
+            quotes = json.loads(response.readline().decode()[6:])
+    finally:
+        server.stop()
+    assert quotes[0]["price"] == 61250.5
@@ -0,0 +1,33 @@
+from core.pair_import import resolve_pair_list, split_pair_list
+from core.symbol_search import SymbolInfo, SymbolSearchService
+
+
+def _search(*symbols: str) -> SymbolSearchService:
+    search = SymbolSearchService()
+    for symbol in symbols:
+        base, quote = symbol.split("-")
+        search._symbols.append(SymbolInfo(symbol, symbol, base, quote))
+        search._symbol_set.add(symbol)
+    return search
+
+
+def test_split_pair_list():
+    text = "BTC-USDT, eth/usdt\n# majors above\nSOL;DOGE  PEPE # memes\n\n"
+    assert split_pair_list(text) == ["BTC-USDT", "eth/usdt", "SOL", "DOGE", "PEPE"]
+
+
+def test_resolve_notations():
+    search = _search("BTC-USDT", "ETH-USDT", "ETH-BTC", "SOL-USDC")
+    result = resolve_pair_list("btc_usdt ETHBTC eth/usdt SOL", search, [])
+    assert result.valid == ["BTC-USDT", "ETH-BTC", "ETH-USDT", "SOL-USDC"]
+    assert result.rejected == []
+
+
+def test_duplicates_and_rejects():
+    search = _search("BTC-USDT", "ETH-USDT")
+    result = resolve_pair_list(
+        "BTC-USDT ETH-USDT ethusdt FOO-USDT chain:eth:0xABC", search, ["BTC-USDT"]
+    )
+    assert result.valid == ["ETH-USDT", "chain:eth:0xABC"]
+    assert result.duplicates == ["BTC-USDT", "ETH-USDT"]
+    assert result.rejected == ["FOO-USDT"]
@@ -0,0 +1,50 @@
+from unittest.mock import MagicMock, patch
+
+from core.pool_client import (
+    decode_string,
+    eth_call_batch,
+    make_pool_pair,
+    parse_pool_pair,
+    sqrt_price_to_price,
+)
+from core.utils import get_display_name
+
+POOL = "0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640"
+
+
+def test_pool_pair_roundtrip():
+    pair = make_pool_pair("Ethereum", POOL, inverse=True)
+    assert pair == f"pool:ethereum:{POOL.lower()}:inverse"
+    assert parse_pool_pair(pair) == ("ethereum", POOL.lower(), True)
+    assert parse_pool_pair(make_pool_pair("bsc", POOL)) == ("bsc", POOL.lower(), False)
+    assert get_display_name(pair) == "0x88e6...5640 (Ethereum)"
+    assert get_display_name(pair, "WETH", short=True) == "WETH"
+
+
+def test_sqrt_price_to_price():
+    # USDC (6 decimals) / WETH (18 decimals) pool at 2000 USDC per WETH
+    sqrt_price = int((1 / 2000 * 10**12) ** 0.5 * 2**96)
+    price = sqrt_price_to_price(sqrt_price, 6, 18)
+    assert abs(1 / price - 2000) < 1e-6
+
+
+def test_decode_string():
+    abi_string = "0x" + (
+        f"{32:064x}" + f"{4:064x}" + "WETH".encode().hex().ljust(64, "0")
+    )
+    assert decode_string(abi_string) == "WETH"
+    assert decode_string("0x" + "MKR".encode().hex().ljust(64, "0")) == "MKR"
+
+
+def test_eth_call_batch_orders_results_by_id():
+    response = MagicMock()
+    response.json.return_value = [
+        {"jsonrpc": "2.0", "id": 1, "result": "0x02"},
+        {"jsonrpc": "2.0", "id": 0, "result": "0x01"},
+    ]
+    with (
+        patch("core.pool_client.requests") as mock_requests,
+        patch("core.pool_client.get_proxy_config", return_value=None),
+    ):
+        mock_requests.post.return_value = response
+        assert eth_call_batch("http://rpc", [(POOL, "0xaa"), (POOL, "0xbb")]) == ["0x01", "0x02"]
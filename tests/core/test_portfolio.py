@@ -0,0 +1,65 @@
+import pytest
+
+from config.settings import PortfolioTransaction
+from core.fx_rates import FxRateService, format_fiat, get_quote_asset
+from core.portfolio import compute_positions
+
+
+def _tx(side, quantity, price, timestamp, fee=0.0, fee_asset=""):
+    return PortfolioTransaction(
+        pair="BTC-USDT",
+        side=side,
+        quantity=quantity,
+        price=price,
+        fee=fee,
+        fee_asset=fee_asset,
+        timestamp=timestamp,
+    )
+
+
+def test_compute_positions_average_cost():
+    positions = compute_positions(
+        [
+            _tx("buy", 1.0, 100.0, 1),
+            _tx("buy", 1.0, 200.0, 2),
+            _tx("sell", 0.5, 300.0, 3),
+        ]
+    )
+
+    pos = positions["BTC-USDT"]
+    assert pos.quantity == pytest.approx(1.5)
+    assert pos.average_cost == pytest.approx(150.0)
+    assert pos.realized_pnl == pytest.approx(75.0)
+
+
+def test_compute_positions_fees():
+    positions = compute_positions(
+        [
+            _tx("buy", 1.0, 100.0, 1, fee=0.01, fee_asset="BTC"),
+            _tx("buy", 1.0, 100.0, 2, fee=2.0, fee_asset="USDT"),
+        ]
+    )
+
+    pos = positions["BTC-USDT"]
+    assert pos.quantity == pytest.approx(1.99)
+    assert pos.cost_basis == pytest.approx(202.0)
+
+
+def test_compute_positions_closed_position():
+    positions = compute_positions([_tx("buy", 1.0, 100.0, 1), _tx("sell", 1.0, 90.0, 2)])
+
+    pos = positions["BTC-USDT"]
+    assert pos.quantity == 0
+    assert pos.cost_basis == 0
+    assert pos.realized_pnl == pytest.approx(-10.0)
+
+
+def test_fx_convert_from_quote():
+    service = FxRateService()
+    service.set_rates({"EUR": 0.9, "JPY": 150.0})
+
+    assert service.convert_from_quote(100.0, "USDT", "EUR") == pytest.approx(90.0)
+    assert service.convert_from_quote(90.0, "EUR", "JPY") == pytest.approx(15000.0)
+    assert service.convert_from_quote(1.0, "BTC", "USD") is None
+    assert get_quote_asset("chain:eth:0xabc") == "USD"
+    assert format_fiat(1234.5, "EUR").startswith("€")
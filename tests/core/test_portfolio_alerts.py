@@ -0,0 +1,52 @@
+import pytest
+
+from config.settings import PortfolioAlert
+from core.portfolio import PortfolioValuation, PositionValuation, compute_allocation
+from core.portfolio_alerts import evaluate_portfolio_alert
+
+
+@pytest.fixture
+def valuation():
+    return PortfolioValuation(
+        currency="USD",
+        total_value=900.0,
+        total_cost=800.0,
+        positions=[
+            PositionValuation("BTC-USDT", "BTC", 1, 600, 600.0, 500.0, 100.0),
+            PositionValuation("ETH-USDT", "ETH", 1, 300, 300.0, 300.0, 0.0),
+        ],
+    )
+
+
+def test_value_thresholds(valuation):
+    allocation = compute_allocation(valuation)
+
+    above = PortfolioAlert(alert_type="value_above", threshold=850)
+    below = PortfolioAlert(alert_type="value_below", threshold=850)
+
+    hit = evaluate_portfolio_alert(above, valuation, allocation)
+    assert hit is not None and hit.value == 900.0
+    assert evaluate_portfolio_alert(below, valuation, allocation) is None
+
+
+def test_daily_drawdown(valuation):
+    alert = PortfolioAlert(alert_type="daily_drawdown", threshold=10)
+    allocation = compute_allocation(valuation)
+
+    hit = evaluate_portfolio_alert(alert, valuation, allocation, day_peak=1000.0)
+    assert hit is not None
+    assert hit.value == pytest.approx(10.0)
+
+    assert evaluate_portfolio_alert(alert, valuation, allocation, day_peak=950.0) is None
+    assert evaluate_portfolio_alert(alert, valuation, allocation, day_peak=None) is None
+
+
+def test_position_share(valuation):
+    allocation = compute_allocation(valuation)
+
+    any_asset = PortfolioAlert(alert_type="position_share", threshold=60)
+    hit = evaluate_portfolio_alert(any_asset, valuation, allocation)
+    assert hit is not None and hit.asset == "BTC"
+
+    eth_only = PortfolioAlert(alert_type="position_share", threshold=60, asset="eth")
+    assert evaluate_portfolio_alert(eth_only, valuation, allocation) is None
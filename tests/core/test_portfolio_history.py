@@ -0,0 +1,69 @@
+import sqlite3
+
+import pytest
+
+from core.portfolio import PortfolioValuation, PositionValuation, compute_allocation
+from core.portfolio_history import PortfolioHistoryStore, PortfolioSnapshot
+
+
+@pytest.fixture
+def store(tmp_path):
+    store = PortfolioHistoryStore(tmp_path / "portfolio.db")
+    yield store
+    store.close()
+
+
+def test_history_filters_by_currency_and_time(store):
+    store.record(PortfolioSnapshot(300, "USD", 1300.0, 1000.0))
+    store.record(PortfolioSnapshot(100, "USD", 1100.0, 1000.0))
+    store.record(PortfolioSnapshot(200, "EUR", 1000.0, 900.0))
+
+    history = store.get_history("usd")
+    assert [s.timestamp for s in history] == [100, 300]
+    assert history[-1].unrealized_pnl == pytest.approx(300.0)
+
+    assert [s.timestamp for s in store.get_history("USD", since=200)] == [300]
+
+
+def test_history_prune(store):
+    store.record(PortfolioSnapshot(100, "USD", 1.0, 1.0))
+    store.record(PortfolioSnapshot(200, "USD", 2.0, 1.0))
+
+    assert store.prune(older_than=150) == 1
+    assert len(store.get_history("USD")) == 1
+
+
+def test_compute_allocation_merges_assets():
+    valuation = PortfolioValuation(
+        currency="USD",
+        positions=[
+            PositionValuation("BTC-USDT", "BTC", 1, 600, 600.0, 500.0, 100.0),
+            PositionValuation("BTC-USDC", "BTC", 1, 150, 150.0, 100.0, 50.0),
+            PositionValuation("ETH-USDT", "ETH", 1, 250, 250.0, 200.0, 50.0),
+            PositionValuation("ETH-BTC", "ETH", 1, None, None, None, None),
+        ],
+    )
+
+    allocation = compute_allocation(valuation)
+    assert [a.asset for a in allocation] == ["BTC", "ETH"]
+    assert allocation[0].percentage == pytest.approx(75.0)
+    assert allocation[1].percentage == pytest.approx(25.0)
+
+
+def test_history_migrates_and_separates_portfolios(tmp_path):
+    db_path = tmp_path / "portfolio.db"
+    conn = sqlite3.connect(str(db_path))
+    conn.execute(
+        "CREATE TABLE portfolio_snapshots "
+        "(timestamp REAL, currency TEXT, total_value REAL, total_cost REAL)"
+    )
+    conn.execute("INSERT INTO portfolio_snapshots VALUES (100, 'USD', 1.0, 1.0)")
+    conn.commit()
+    conn.close()
+
+    store = PortfolioHistoryStore(db_path)
+    store.record(PortfolioSnapshot(200, "USD", 2.0, 1.0, portfolio_id="trading"))
+
+    assert [s.timestamp for s in store.get_history("USD")] == [100]
+    assert [s.timestamp for s in store.get_history("USD", portfolio_id="trading")] == [200]
+    store.close()
@@ -0,0 +1,29 @@
+from unittest.mock import patch
+
+from core.rate_limiter import RateLimiter, exchange_of
+
+
+def test_exchange_of():
+    assert exchange_of("https://www.okx.com/api/v5/market/candles") == "OKX"
+    assert exchange_of("https://fapi.binance.com/fapi/v1/premiumIndex") == "BINANCE"
+    assert exchange_of("https://api.bybit.com/v5/market/tickers") == "BYBIT"
+    assert exchange_of("https://api.coingecko.com/api/v3/ping") is None
+    assert exchange_of("https://notokx.com/") is None
+
+
+def test_requests_beyond_the_window_are_queued():
+    limiter = RateLimiter({"OKX": (2, 1.0)})
+    with patch("core.rate_limiter.time.monotonic", return_value=100.0):
+        delays = [limiter._reserve("OKX") for _ in range(5)]
+    # Two per second: the third and fourth wait a second, the fifth two
+    assert delays == [0.0, 0.0, 1.0, 1.0, 2.0]
+    assert limiter._reserve("COINGECKO") == 0.0
+
+    stats = limiter.stats()["OKX"]
+    assert stats["requests"] == 5
+    assert stats["delayed"] == 3
+    assert stats["waited"] == 4.0
+
+    # Slots free up as the window slides
+    with patch("core.rate_limiter.time.monotonic", return_value=103.0):
+        assert limiter._reserve("OKX") == 0.0
@@ -0,0 +1,68 @@
+import pytest
+
+from core.models import TickerData
+from core.replay_client import (
+    DEFAULT_RECORDING,
+    ReplayClient,
+    TickRecorder,
+    load_recording,
+)
+
+
+def _record(path, ticks):
+    recorder = TickRecorder(str(path))
+    for pair, price in ticks:
+        recorder.record(pair, TickerData(pair=pair, price=price, percentage="+0.00%"))
+    recorder.close()
+
+
+def test_bundled_sample_loads(tmp_path, monkeypatch):
+    # Found wherever the app is started from
+    monkeypatch.chdir(tmp_path)
+    ticks = load_recording(DEFAULT_RECORDING)
+
+    assert len(ticks) > 100
+    assert {"BTC-USDT", "ETH-USDT"} <= {tick.ticker.pair for tick in ticks}
+    assert [tick.offset for tick in ticks] == sorted(tick.offset for tick in ticks)
+
+
+def test_recording_round_trip(tmp_path):
+    path = tmp_path / "ticks.jsonl"
+    _record(path, [("BTC-USDT", "60000"), ("ETH-USDT", "3000")])
+
+    ticks = load_recording(str(path))
+    assert [(t.ticker.pair, t.ticker.price) for t in ticks] == [
+        ("BTC-USDT", "60000"),
+        ("ETH-USDT", "3000"),
+    ]
+    assert ticks[0].offset == 0
+
+
+def test_malformed_recording_is_rejected(tmp_path):
+    path = tmp_path / "bad.jsonl"
+    path.write_text('{"t": 0, "ticker": {"pair": "BTC-USDT"}}\n', encoding="utf-8")
+
+    with pytest.raises(ValueError, match="bad.jsonl:1"):
+        load_recording(str(path))
+
+
+def test_replay_emits_subscribed_pairs_in_order_and_loops(tmp_path):
+    path = tmp_path / "ticks.jsonl"
+    path.write_text(
+        '{"t": 0, "ticker": {"pair": "BTC-USDT", "price": "1", "percentage": "+0%"}}\n'
+        '{"t": 1, "ticker": {"pair": "ETH-USDT", "price": "2", "percentage": "+0%"}}\n'
+        '{"t": 2, "ticker": {"pair": "BTC-USDT", "price": "3", "percentage": "+0%"}}\n',
+        encoding="utf-8",
+    )
+    client = ReplayClient(str(path), speed=2.0)
+    received = []
+    client.ticker_updated.connect(lambda pair, data: received.append(data.price))
+    client.subscribe(["BTC-USDT"])
+
+    client.advance(0.25)  # 0.5s in
+    assert received == ["1"]
+    client.advance(0.75)  # 2.0s in
+    assert received == ["1", "3"]
+    client.advance(0.25)  # wrapped to 0.5s
+    assert received == ["1", "3", "1"]
+    assert client.get_stats()["loops"] == 1
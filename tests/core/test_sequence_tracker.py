@@ -0,0 +1,25 @@
+from core.sequence_tracker import SequenceGap, SequenceTracker
+
+
+def test_gaps_are_reported_once():
+    tracker = SequenceTracker()
+    assert tracker.check("trades", "BTC-USDT", 100, 100) is None
+    assert tracker.check("trades", "BTC-USDT", 101, 103) is None
+
+    gap = tracker.check("trades", "BTC-USDT", 107, 107)
+    assert gap == SequenceGap("trades", "BTC-USDT", 104, 107)
+    assert gap.missing == 3
+    assert tracker.check("trades", "BTC-USDT", 108, 108) is None
+
+
+def test_replays_and_other_pairs_do_not_count():
+    tracker = SequenceTracker()
+    tracker.check("trades", "BTC-USDT", 100, 100)
+    # A repeated or late message neither gaps nor moves the sequence back
+    assert tracker.check("trades", "BTC-USDT", 99, 99) is None
+    assert tracker.check("trades", "BTC-USDT", 100, 100) is None
+    assert tracker.check("trades", "BTC-USDT", 101, 101) is None
+    assert tracker.check("trades", "ETH-USDT", 5000, 5000) is None
+
+    tracker.reset()
+    assert tracker.check("trades", "BTC-USDT", 200, 200) is None
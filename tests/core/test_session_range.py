@@ -0,0 +1,26 @@
+import pytest
+
+from core.indicators import Candle
+from core.session_range import SessionRange, session_range
+
+DAY = 24 * 60 * 60
+
+
+def test_session_range_uses_todays_candles():
+    candles = [
+        Candle(DAY - 3600, 50.0, 200.0, 10.0, 50.0),  # Previous session
+        Candle(DAY, 100.0, 110.0, 95.0, 105.0),
+        Candle(DAY + 3600, 105.0, 120.0, 100.0, 115.0),
+    ]
+
+    day = session_range(candles, DAY + 5000)
+    assert (day.high, day.low) == (120.0, 95.0)
+    assert day.position(115.0) == pytest.approx(80.0)
+    assert session_range(candles, 2 * DAY) is None
+
+
+def test_position_is_clamped():
+    day = SessionRange(high=110.0, low=100.0)
+    assert day.position(90.0) == 0.0
+    assert day.position(120.0) == 100.0
+    assert SessionRange(high=100.0, low=100.0).position(100.0) is None
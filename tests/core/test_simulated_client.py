@@ -0,0 +1,46 @@
+from core.simulated_client import (
+    SimulatedPair,
+    format_volatility_overrides,
+    parse_volatility_overrides,
+    step_sigma,
+)
+
+
+def test_step_sigma_scales_with_square_root_of_time():
+    assert step_sigma(5.0, 24 * 60 * 60) == 0.05
+    assert abs(step_sigma(5.0, 6 * 60 * 60) - 0.025) < 1e-12
+
+
+def test_walk_is_reproducible_and_tracks_range():
+    first = SimulatedPair("BTC-USDT", 5.0, seed=1)
+    second = SimulatedPair("BTC-USDT", 5.0, seed=1)
+    prices = [first.step(60) for _ in range(100)]
+
+    assert prices == [second.step(60) for _ in range(100)]
+    assert first.low <= min(prices) and first.high >= max(prices)
+
+    ticker = first.to_ticker()
+    assert ticker.display_name == "BTC"
+    assert ticker.quote_token == "USDT"
+    assert float(ticker.price) == float(f"{first.price:.8g}")
+
+
+def test_history_ends_at_current_price():
+    walk = SimulatedPair("ETH-USDT", 5.0, seed=1)
+    candles = walk.history("1h", 24, now=1_700_000_000)
+
+    assert len(candles) == 24
+    assert candles[-1]["close"] == walk.price
+    assert candles[-1]["timestamp"] == 1_699_999_200 * 1000
+    assert candles[0]["timestamp"] == candles[-1]["timestamp"] - 23 * 3600 * 1000
+    for previous, candle in zip(candles, candles[1:]):
+        assert candle["open"] == previous["close"]
+    assert all(c["low"] <= min(c["open"], c["close"]) for c in candles)
+    assert all(c["high"] >= max(c["open"], c["close"]) for c in candles)
+
+
+def test_volatility_overrides_round_trip():
+    overrides = parse_volatility_overrides("btc-usdt=3, DOGE-USDT = 12%, bad, ETH-USDT=x, X=-1")
+
+    assert overrides == {"BTC-USDT": 3.0, "DOGE-USDT": 12.0}
+    assert format_volatility_overrides(overrides) == "BTC-USDT=3, DOGE-USDT=12"
@@ -0,0 +1,34 @@
+import csv
+from datetime import datetime
+
+from core.price_tracker import PriceState
+from core.snapshot import CSV_HEADER, build_snapshot_row, format_snapshot_text, write_snapshot_csv
+
+
+def _rows():
+    btc = PriceState(current_price=65432.1, percentage="+1.50%", high_24h="66000", low_24h="64000")
+    xrp = PriceState(current_price=2.5, percentage="-0.20%", quote_volume_24h="1.2M")
+    return [build_snapshot_row("BTC-USDT", btc), build_snapshot_row("XRP-USDT", xrp)]
+
+
+def test_format_snapshot_text_aligns_columns():
+    text = format_snapshot_text(_rows(), datetime(2026, 1, 2, 9, 30))
+    assert text.splitlines() == [
+        "Crypto Monitor · 2026-01-02 09:30",
+        "BTC  65432.10  +1.50%",
+        "XRP    2.5000  -0.20%",
+    ]
+
+
+def test_format_snapshot_text_without_rows():
+    assert format_snapshot_text([], datetime(2026, 1, 2)) == "Crypto Monitor · 2026-01-02 00:00"
+
+
+def test_write_snapshot_csv(tmp_path):
+    path = tmp_path / "prices.csv"
+    assert write_snapshot_csv(path, _rows()) == 2
+    with open(path, newline="", encoding="utf-8") as f:
+        rows = list(csv.reader(f))
+    assert rows[0] == CSV_HEADER
+    assert rows[1] == ["BTC-USDT", "BTC", "65432.10", "+1.50%", "66000", "64000", "0"]
+    assert rows[2][-1] == "1.2M"
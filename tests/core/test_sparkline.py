@@ -0,0 +1,29 @@
+from core.sparkline import SparklineBuffer
+
+
+def test_buffer_keeps_last_price_per_minute():
+    buffer = SparklineBuffer(size=3)
+    buffer.add(1.0, 60)
+    buffer.add(2.0, 90)
+    buffer.add(3.0, 120)
+    buffer.add(4.0, 180)
+    buffer.add(5.0, 240)
+
+    assert len(buffer) == 3
+    assert buffer.values(600, 300) == [3.0, 4.0, 5.0]
+    assert buffer.values(120, 300) == [4.0, 5.0]
+
+
+def test_buffer_coverage_and_downsampling():
+    buffer = SparklineBuffer()
+    for minute in range(100):
+        buffer.add(float(minute), minute * 60)
+
+    now = 100 * 60
+    assert buffer.covers(100 * 60, now)
+    assert not buffer.covers(200 * 60, now)
+
+    values = buffer.values(100 * 60, now, max_points=10)
+    assert len(values) == 10
+    assert values[0] == 0.0
+    assert values[-1] == 99.0
@@ -0,0 +1,75 @@
+from unittest.mock import MagicMock, patch
+
+from core.market_indices import USDT_SUPPLY_PAIR
+from core.stablecoin_monitor import StablecoinMonitor, StablecoinSupply, parse_stablecoins
+
+
+def _asset(symbol, circulating, previous=None):
+    return {
+        "symbol": symbol,
+        "circulating": {"peggedUSD": circulating},
+        "circulatingPrevDay": {"peggedUSD": previous} if previous else {},
+    }
+
+
+def test_parse_stablecoins():
+    supplies = parse_stablecoins(
+        {
+            "peggedAssets": [
+                _asset("USDT", 120e9, 119e9),
+                _asset("DAI", 5e9),
+                _asset("USDC", 35e9),
+                # A later coin sharing the symbol is ignored
+                _asset("USDT", 1e6),
+            ]
+        }
+    )
+    assert set(supplies) == {"USDT", "USDC"}
+    assert supplies["USDT"].circulating == 120e9
+    assert supplies["USDC"].previous_day is None
+
+    ticker = supplies["USDT"].to_ticker()
+    assert ticker.pair == USDT_SUPPLY_PAIR
+    assert ticker.percentage == "+0.84%"
+
+
+def test_check_changes_accumulates_until_threshold():
+    monitor = StablecoinMonitor()
+    monitor._supplies = {"USDT": StablecoinSupply("USDT", 100e9)}
+    assert monitor.check_changes(1e9) == []
+
+    monitor._supplies["USDT"].circulating += 0.6e9
+    assert monitor.check_changes(1e9) == []
+    monitor._supplies["USDT"].circulating += 0.6e9
+    assert monitor.check_changes(1e9) == [("USDT", 1.2e9, 101.2e9)]
+
+    monitor._supplies["USDT"].circulating -= 2e9
+    [(symbol, change, _supply)] = monitor.check_changes(1e9)
+    assert symbol == "USDT" and change < 0
+
+
+def test_supplies_are_only_fetched_while_alerted_or_watched():
+    settings_manager = MagicMock()
+    settings_manager.settings.stablecoin_alerts = False
+    settings_manager.settings.crypto_pairs = ["BTC-USDT"]
+    settings_manager.settings.alerts = []
+    with (
+        patch("core.stablecoin_monitor.get_settings_manager", return_value=settings_manager),
+        patch("core.stablecoin_monitor.threading.Thread") as thread,
+    ):
+        monitor = StablecoinMonitor()
+        monitor.start()
+        assert not monitor._timer.isActive()
+        thread.assert_not_called()
+
+        settings_manager.settings.crypto_pairs = ["BTC-USDT", USDT_SUPPLY_PAIR]
+        monitor.apply_settings()
+        assert monitor._timer.isActive()
+        thread.assert_called_once()
+
+        settings_manager.settings.crypto_pairs = ["BTC-USDT"]
+        monitor.apply_settings()
+        assert not monitor._timer.isActive()
+        settings_manager.settings.stablecoin_alerts = True
+        monitor.apply_settings()
+        assert monitor._timer.isActive()
@@ -0,0 +1,84 @@
+from unittest.mock import MagicMock
+
+import pytest
+
+from config.settings import LocalApiConfig
+from core.local_api import LocalApiServer
+from core.price_tracker import PriceState
+from core.stream_deck import StreamDeckApi
+
+AUTH = {"Authorization": "Bearer s3cret"}
+
+
+@pytest.fixture(autouse=True)
+def control_allowed():
+    settings = LocalApiServer()._settings_manager.settings
+    settings.local_api = LocalApiConfig(token="s3cret", allow_control=True)
+    yield settings
+    settings.local_api = LocalApiConfig()
+
+
+def _deck(alert_manager=None):
+    server = LocalApiServer()
+    deck = StreamDeckApi(server, alert_manager or MagicMock())
+    server.update_quote("BTC-USDT", PriceState(current_price=61250.5, percentage="-1.20%"))
+    server.update_quote("ETH-USDT", PriceState(current_price=3000.0, percentage="+0.50%"))
+    return server, deck
+
+
+def test_key_shows_price_and_change():
+    server, _ = _deck()
+
+    key = server.dispatch("GET", "/api/deck/BTC-USDT", AUTH).body
+    name, price_text, change_text = key["title"].split("\n")
+    assert name == "BTC"
+    assert price_text == key["price_text"]
+    assert change_text == "-1.20%"
+    assert key["direction"] == "down"
+    assert key["alert"] is None
+
+    keys = server.dispatch("GET", "/api/deck", AUTH).body["keys"]
+    assert [key["direction"] for key in keys] == ["down", "up"]
+    assert server.dispatch("GET", "/api/deck/DOGE-USDT", AUTH).status == 404
+
+
+def test_alerts_stay_on_the_key_until_acknowledged():
+    alert_manager = MagicMock()
+    server, deck = _deck(alert_manager)
+
+    deck._on_alert_triggered("BTC-USDT", "price_below", 62000.0, 61250.5)
+    deck._on_alert_triggered("ETH-USDT", "price_above", 2900.0, 3000.0)
+    alert = server.dispatch("GET", "/api/deck/BTC-USDT", AUTH).body["alert"]
+    assert alert["type"] == "price_below"
+    assert alert["target"] == 62000.0
+
+    response = server.dispatch("POST", "/api/deck/btc-usdt/ack", AUTH)
+    assert response.body == {"acknowledged": 1}
+    assert server.dispatch("GET", "/api/deck/BTC-USDT", AUTH).body["alert"] is None
+    assert server.dispatch("GET", "/api/deck/ETH-USDT", AUTH).body["alert"] is not None
+    alert_manager.acknowledge_pair.assert_called_once_with("BTC-USDT")
+
+    assert server.dispatch("POST", "/api/deck/ack", AUTH).body == {"acknowledged": 1}
+    assert server.dispatch("GET", "/api/deck/ETH-USDT", AUTH).body["alert"] is None
+    alert_manager.acknowledge_all.assert_called_once()
+
+    # Acknowledged in the app
+    deck._on_alert_triggered("BTC-USDT", "price_below", 62000.0, 61250.5)
+    deck._on_alert_acknowledged("alert-id", "BTC-USDT")
+    assert server.dispatch("GET", "/api/deck/BTC-USDT", AUTH).body["alert"] is None
+
+
+def test_acknowledging_needs_control_allowed_and_a_token(control_allowed):
+    alert_manager = MagicMock()
+    server, deck = _deck(alert_manager)
+    deck._on_alert_triggered("BTC-USDT", "price_below", 62000.0, 61250.5)
+
+    control_allowed.local_api = LocalApiConfig()
+    assert server.dispatch("POST", "/api/deck/BTC-USDT/ack").status == 403
+    assert server.dispatch("POST", "/api/deck/ack").status == 403
+    control_allowed.local_api = LocalApiConfig(token="s3cret")
+    assert server.dispatch("POST", "/api/deck/ack", AUTH).status == 403
+    alert_manager.acknowledge_all.assert_not_called()
+
+    # Keys are still shown
+    assert server.dispatch("GET", "/api/deck/BTC-USDT", AUTH).body["alert"] is not None
@@ -0,0 +1,40 @@
+import sys
+
+from core.price_tracker import PriceState
+from core.taskbar_badge import WindowsTaskbar, badge_label, build_taskbar_badge
+
+
+def test_badge_label_fits_a_small_icon():
+    assert badge_label("+2.54%") == "2.5"
+    assert badge_label("-0.04%") == "0.0"
+    assert badge_label("-12.6%") == "13"
+    assert badge_label("+250.00%") == "99+"
+    assert badge_label("+1,75%") == "1.8"
+    assert badge_label("--") == ""
+
+
+def test_badge_places_the_price_in_the_24h_range():
+    state = PriceState(
+        current_price=61000.0,
+        percentage="+2.50%",
+        color="#4CAF50",
+        trend="↑",
+        high_24h="62000",
+        low_24h="58000",
+    )
+    badge = build_taskbar_badge("BTC-USDT", "BTC", state)
+    assert badge.range_position == 75.0
+    assert badge.description == "BTC +2.50%"
+
+    # Without a usable 24h range, the session range is used
+    state.high_24h = "0"
+    state.low_24h = "0"
+    state.range_position = 40.0
+    assert build_taskbar_badge("BTC-USDT", "BTC", state).range_position == 40.0
+
+
+def test_taskbar_is_inert_off_windows(monkeypatch):
+    monkeypatch.setattr(sys, "platform", "linux")
+    taskbar = WindowsTaskbar()
+    assert not taskbar.available
+    taskbar.show_badge(1, None)
@@ -0,0 +1,64 @@
+import csv
+from datetime import datetime
+
+import pytest
+
+from config.settings import PortfolioTransaction
+from core.tax_report import compute_realized_gains, gains_for_year, write_tax_report
+
+
+def _tx(side, quantity, price, ts, fee=0.0, fee_asset=""):
+    return PortfolioTransaction(
+        pair="BTC-USDT",
+        side=side,
+        quantity=quantity,
+        price=price,
+        timestamp=ts,
+        fee=fee,
+        fee_asset=fee_asset,
+    )
+
+
+TRANSACTIONS = [
+    _tx("buy", 1, 100, 1000),
+    _tx("buy", 1, 200, 2000),
+    _tx("sell", 1.5, 300, 3000, fee=5, fee_asset="USDT"),
+]
+
+
+def test_fifo_matches_oldest_lots_first():
+    [gain] = compute_realized_gains(TRANSACTIONS, "fifo")
+
+    assert gain.quantity == 1.5
+    assert gain.proceeds == pytest.approx(445.0)
+    assert gain.cost_basis == pytest.approx(100 + 0.5 * 200)
+    assert gain.acquired_at == 1000
+
+
+def test_average_cost_uses_mean_price():
+    [gain] = compute_realized_gains(TRANSACTIONS, "average")
+
+    assert gain.cost_basis == pytest.approx(1.5 * 150)
+    assert gain.gain == pytest.approx(445.0 - 225.0)
+    assert gain.acquired_at is None
+
+
+def test_write_tax_report_for_year(tmp_path):
+    sold_2023 = datetime(2023, 6, 1).timestamp()
+    sold_2024 = datetime(2024, 6, 1).timestamp()
+    transactions = [
+        _tx("buy", 2, 100, datetime(2023, 1, 1).timestamp()),
+        _tx("sell", 1, 150, sold_2023),
+        _tx("sell", 1, 50, sold_2024),
+    ]
+    gains = gains_for_year(compute_realized_gains(transactions, "fifo"), 2024)
+
+    path = tmp_path / "gains.csv"
+    assert write_tax_report(path, gains) == 1
+
+    with open(path, encoding="utf-8") as f:
+        rows = list(csv.DictReader(f))
+    assert rows[0]["Date Sold"] == "2024-06-01"
+    assert rows[0]["Date Acquired"] == "2023-01-01"
+    assert rows[0]["Gain"] == "-50.00"
+    assert rows[0]["Currency"] == "USDT"
@@ -0,0 +1,119 @@
+import threading
+import time
+from datetime import timedelta
+from unittest.mock import MagicMock, patch
+
+import pytest
+
+from core.automation import Command
+from core.telegram_bot import TelegramBot, commands_of, parse_duration, resolve_pair
+
+
+def test_parse_duration():
+    assert parse_duration("30") == timedelta(minutes=30)
+    assert parse_duration("2h") == timedelta(hours=2)
+    assert parse_duration("1D") == timedelta(days=1)
+
+    for text in ("", "0", "h", "1w", "-5m", "8d"):
+        with pytest.raises(ValueError):
+            parse_duration(text)
+
+
+def test_resolve_pair():
+    monitored = ["ETH-BTC", "BTC-USDC"]
+    assert resolve_pair("btc", monitored) == "BTC-USDC"
+    assert resolve_pair("SOL", monitored) == "SOL-USDT"
+    assert resolve_pair("eth-usdt", monitored) == "ETH-USDT"
+    with pytest.raises(ValueError):
+        resolve_pair("BTC/USDT/X", monitored)
+
+
+def test_only_commands_from_the_configured_chat_are_taken():
+    updates = [
+        {"update_id": 7, "message": {"chat": {"id": 42}, "text": "/price BTC"}},
+        {"update_id": 8, "message": {"chat": {"id": 99}, "text": "/mute"}},
+        {"update_id": 9, "message": {"chat": {"id": 42}, "text": "hello"}},
+        {"update_id": 10, "edited_message": {}},
+    ]
+    assert commands_of(updates, "42") == (["/price BTC"], 11)
+    assert commands_of([], "42") == ([], None)
+
+
+@pytest.fixture
+def bot():
+    bot = TelegramBot()
+    bot._settings_manager = MagicMock()
+    bot._settings_manager.settings.crypto_pairs = ["BTC-USDT"]
+    bot.set_commands(MagicMock())
+    bot._commands.execute.return_value = "BTC 61,250.50 +2.50%"
+    return bot
+
+
+def test_price_and_add_go_through_the_automation_commands(bot):
+    assert bot.answer("/price@SomeBot btc") == "BTC 61,250.50 +2.50%"
+    bot._commands.execute.assert_called_with(Command("price", "BTC-USDT"))
+    bot.answer("/add ETH")
+    bot._commands.execute.assert_called_with(Command("add", "ETH-USDT"))
+
+    assert bot.answer("/price").startswith("/price needs a pair")
+    assert bot.answer("/sell BTC").startswith("unknown command")
+
+
+def test_mute_and_unmute(bot):
+    notifier = MagicMock()
+    with patch("core.telegram_bot.get_notification_service", return_value=notifier):
+        assert bot.answer("/mute 1h") == "alerts muted for 1h"
+        notifier.set_paused.assert_called_with(True)
+        assert bot.answer("/mute soon").startswith("error: invalid duration")
+        assert bot.answer("/unmute") == "alerts on"
+        notifier.set_paused.assert_called_with(False)
+
+
+def test_ack_and_pair_mutes_go_through_the_alert_manager(bot):
+    alerts = MagicMock()
+    alerts.acknowledge_pair.return_value = 1
+    alerts.acknowledge_all.return_value = 0
+    alerts.unmute_pair.return_value = False
+    with patch("core.telegram_bot.get_alert_manager", return_value=alerts):
+        assert bot.answer("/ack btc") == "acknowledged 1 alert(s)"
+        alerts.acknowledge_pair.assert_called_with("BTC-USDT")
+        assert bot.answer("/ack") == "no alerts waiting"
+
+        assert bot.answer("/mute btc 2h") == "BTC-USDT alerts muted for 2h"
+        alerts.mute_pair.assert_called_with("BTC-USDT", 7200)
+        assert bot.answer("/mute BTC") == "BTC-USDT alerts muted for 1h"
+        assert bot.answer("/unmute BTC") == "BTC-USDT alerts weren't muted"
+
+
+def test_restart_waits_for_the_running_poll(bot):
+    config = bot._settings_manager.settings.telegram
+    config.enabled = config.commands_enabled = True
+    config.bot_token, config.chat_id = "TOKEN", "42"
+    release = threading.Event()
+    open_calls = []
+    concurrent = []
+
+    def get_updates(token, method, payload, timeout):
+        open_calls.append(method)
+        concurrent.append(len(open_calls))
+        release.wait(2)
+        open_calls.pop()
+        return []
+
+    def wait_for_calls(count):
+        deadline = time.time() + 2
+        while len(concurrent) < count and time.time() < deadline:
+            time.sleep(0.01)
+
+    with patch("core.telegram_bot.telegram_request", side_effect=get_updates):
+        bot.start()
+        wait_for_calls(1)
+        bot.apply_settings()
+        time.sleep(0.1)
+        assert concurrent == [1]
+
+        release.set()
+        wait_for_calls(2)
+        bot.stop()
+        bot._thread.join(2)
+    assert max(concurrent) == 1
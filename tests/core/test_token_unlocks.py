@@ -0,0 +1,77 @@
+from unittest.mock import MagicMock, patch
+
+from core.token_unlocks import TokenUnlockService, parse_unlocks
+
+NOW = 1_700_000_000
+
+
+def _entry(**kwargs):
+    entry = {"name": "Arbitrum", "gecko_id": "arbitrum", "tPrice": 2.0, "circSupply": 1_000_000}
+    entry.update(kwargs)
+    return entry
+
+
+def test_parse_next_event():
+    unlocks = parse_unlocks([_entry(nextEvent={"date": NOW + 3600, "toUnlock": 20_000})], NOW)
+    unlock = unlocks["arbitrum"]
+    assert unlock.amount == 20_000
+    assert unlock.value == 40_000
+    assert unlock.supply_pct == 2.0
+    assert unlock.hours_until(NOW) == 1
+
+
+def test_parse_falls_back_to_events():
+    events = [
+        {"timestamp": NOW - 100, "noOfTokens": [500]},
+        {"timestamp": NOW + 7200, "noOfTokens": [100, 200]},
+        {"timestamp": NOW + 7200, "noOfTokens": [300]},
+        {"timestamp": NOW + 9999, "noOfTokens": [1000]},
+    ]
+    entry = _entry(gecko_id=None, token="coingecko:arbitrum", events=events)
+    unlock = parse_unlocks([entry], NOW)["arbitrum"]
+    assert unlock.timestamp == NOW + 7200
+    assert unlock.amount == 600
+    # Past unlocks and entries without an ID are skipped
+    assert parse_unlocks([_entry(nextEvent={"date": NOW - 1, "toUnlock": 5})], NOW) == {}
+    assert parse_unlocks([_entry(gecko_id=None)], NOW) == {}
+
+
+def test_check_upcoming_reports_once():
+    service = TokenUnlockService()
+    service._unlocks = parse_unlocks(
+        [_entry(nextEvent={"date": NOW + 3600, "toUnlock": 20_000})], NOW
+    )
+    coingecko = MagicMock()
+    coingecko.get_market_data.return_value = MagicMock(coin_id="arbitrum")
+    with (
+        patch("core.token_unlocks.get_coingecko_service", return_value=coingecko),
+        patch("core.token_unlocks.time.time", return_value=NOW),
+    ):
+        # Too small, then too far ahead
+        assert service.check_upcoming(["ARB-USDT"], 24, 5.0) == []
+        assert service.check_upcoming(["ARB-USDT"], 0.5, 1.0) == []
+        upcoming = service.check_upcoming(["ARB-USDT", "ARB-USDC"], 24, 1.0)
+        assert [pair for pair, _unlock in upcoming] == ["ARB-USDT"]
+        assert service.check_upcoming(["ARB-USDT"], 24, 1.0) == []
+
+
+def test_calendar_is_only_fetched_for_alerts():
+    settings_manager = MagicMock()
+    settings_manager.settings.unlock_alerts = False
+    with (
+        patch("core.token_unlocks.get_settings_manager", return_value=settings_manager),
+        patch("core.token_unlocks.threading.Thread") as thread,
+    ):
+        service = TokenUnlockService()
+        service.start()
+        assert not service._timer.isActive()
+        thread.assert_not_called()
+
+        settings_manager.settings.unlock_alerts = True
+        service.apply_settings()
+        assert service._timer.isActive()
+        thread.assert_called_once()
+
+        settings_manager.settings.unlock_alerts = False
+        service.apply_settings()
+        assert not service._timer.isActive()
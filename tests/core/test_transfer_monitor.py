@@ -0,0 +1,32 @@
+from core.transfer_monitor import detect_transfer_events
+
+
+def _deposit(dep_id, state, amt="100"):
+    return {"depId": dep_id, "state": state, "ccy": "USDT", "amt": amt, "chain": "USDT-TRC20"}
+
+
+def test_deposit_notified_once_through_credit_and_unlock():
+    known = {}
+
+    assert detect_transfer_events(known, [_deposit("d1", "0")], "deposit") == []
+
+    events = detect_transfer_events(known, [_deposit("d1", "1")], "deposit")
+    assert len(events) == 1
+    assert events[0].status == "arrived"
+    assert events[0].amount == 100.0
+
+    assert detect_transfer_events(known, [_deposit("d1", "2")], "deposit") == []
+
+
+def test_withdrawal_completion_and_failure():
+    known = {}
+    records = [
+        {"wdId": "w1", "state": "2", "ccy": "BTC", "amt": "0.5"},
+        {"wdId": "w2", "state": "-1", "ccy": "ETH", "amt": "1"},
+        {"wdId": "w3", "state": "10", "ccy": "ETH", "amt": "2"},
+    ]
+
+    events = detect_transfer_events(known, records, "withdrawal")
+
+    assert [(e.transfer_id, e.status) for e in events] == [("w1", "completed"), ("w2", "failed")]
+    assert detect_transfer_events(known, records, "withdrawal") == []
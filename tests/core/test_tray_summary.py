@@ -0,0 +1,44 @@
+from core.display_prefs import DisplayHints
+from core.highlight_rules import Highlight
+from core.price_tracker import PriceState
+from core.tray_summary import build_tray_entry, format_tray_tooltip, mini_ticker_pair, tray_pairs
+
+
+def test_tray_pairs_prefers_pinned():
+    monitored = ["BTC-USDT", "ETH-USDT", "SOL-USDT", "DOGE-USDT"]
+    assert tray_pairs([], monitored) == ["BTC-USDT", "ETH-USDT", "SOL-USDT"]
+    assert tray_pairs(["DOGE-USDT", "PEPE-USDT"], monitored) == ["DOGE-USDT"]
+
+
+def test_mini_ticker_pair_falls_back_to_first_tray_pair():
+    monitored = ["BTC-USDT", "ETH-USDT"]
+    assert mini_ticker_pair("ETH-USDT", ["BTC-USDT"], monitored) == "ETH-USDT"
+    assert mini_ticker_pair("SOL-USDT", ["BTC-USDT"], monitored) == "BTC-USDT"
+    assert mini_ticker_pair("", [], []) is None
+
+
+def test_build_tray_entry():
+    state = PriceState(current_price=65432.1, percentage="+1.50%")
+    entry = build_tray_entry("BTC-USDT", state)
+    assert str(entry) == "BTC  65432.10  +1.50%"
+
+    state.alias = "Bitcoin"
+    state.fiat_price = 60000.0
+    state.fiat_currency = "EUR"
+    assert str(build_tray_entry("BTC-USDT", state)) == "Bitcoin  €60000.00  +1.50%"
+
+    state.alias = ""
+    state.display_hints = DisplayHints("0.0000153", "-1.48%", symbol="USDT/BTC")
+    assert str(build_tray_entry("BTC-USDT", state)) == "USDT/BTC  0.0000153  -1.48%"
+
+
+def test_format_tray_tooltip():
+    state = PriceState(current_price=2.5, percentage="-0.20%")
+    entries = [build_tray_entry("XRP-USDT", state)]
+    assert format_tray_tooltip(entries) == "Crypto Monitor\nXRP  2.5000  -0.20%"
+
+
+def test_tray_entry_marks_highlight():
+    state = PriceState(current_price=2.5, percentage="-6.00%")
+    state.highlight = Highlight("down", 5.0, "#F44336", False)
+    assert str(build_tray_entry("XRP-USDT", state)) == "● XRP  2.5000  -6.00%"
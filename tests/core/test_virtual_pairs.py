@@ -0,0 +1,51 @@
+import pytest
+
+from core.models import TickerData
+from core.virtual_pairs import (
+    VirtualExpression,
+    VirtualPairEngine,
+    is_virtual_pair,
+    make_virtual_pair,
+    parse_virtual_pair,
+)
+
+
+def test_expression_parsing():
+    expr = VirtualExpression("ETH-USDT / BTC-USDT")
+    assert expr.pairs == {"ETH-USDT", "BTC-USDT"}
+    assert expr.evaluate({"ETH-USDT": 3000.0, "BTC-USDT": 60000.0}) == pytest.approx(0.05)
+    assert expr.evaluate({"ETH-USDT": 3000.0}) is None
+    assert expr.evaluate({"ETH-USDT": 3000.0, "BTC-USDT": 0.0}) is None
+
+    basket = VirtualExpression("avg(btc-usdt, 2 * (eth-usdt - 1)) - -1")
+    assert basket.evaluate({"BTC-USDT": 10.0, "ETH-USDT": 6.0}) == pytest.approx(11.0)
+
+    for bad in ["", "BTC-USDT /", "foo(BTC-USDT)", "(BTC-USDT", "BTC-USDT $ 2"]:
+        with pytest.raises(ValueError):
+            VirtualExpression(bad)
+
+
+def test_pair_ids():
+    pair = make_virtual_pair("ETH/BTC", "ETH-USDT / BTC-USDT")
+    assert pair == "virtual:ETH/BTC:ETH-USDT / BTC-USDT"
+    assert is_virtual_pair(pair)
+    assert parse_virtual_pair(pair) == ("ETH/BTC", "ETH-USDT / BTC-USDT")
+
+
+def test_engine_emits_ratio_with_change():
+    engine = VirtualPairEngine()
+    pair = make_virtual_pair("ETH/BTC", "ETH-USDT / BTC-USDT")
+    engine.set_pairs(["BTC-USDT", pair, "virtual:Broken:BTC-USDT +"])
+    assert engine.component_pairs() == ["BTC-USDT", "ETH-USDT"]
+
+    emitted = []
+    engine.ticker_updated.connect(lambda p, data: emitted.append(data))
+
+    engine.update_component("ETH-USDT", TickerData("ETH-USDT", "3300", "+10.00%"))
+    assert emitted == []
+    engine.update_component("BTC-USDT", TickerData("BTC-USDT", "60000", "0.00%"))
+
+    assert len(emitted) == 1
+    assert float(emitted[0].price) == pytest.approx(0.055)
+    assert emitted[0].percentage == "+10.00%"
+    assert emitted[0].display_name == "ETH/BTC"
@@ -0,0 +1,34 @@
+import pytest
+
+from core.vwap import SessionVwap, session_start, vwap_distance_pct
+
+
+def test_session_vwap_weights_trades_by_size():
+    session = SessionVwap(session_start=0, live_since=0)
+    assert session.value is None
+
+    session.add_trade(100.0, 1.0, 10)
+    session.add_trade(110.0, 3.0, 20)
+
+    assert session.value == pytest.approx(107.5)
+    assert vwap_distance_pct(129.0, session.value) == pytest.approx(20.0)
+    assert vwap_distance_pct(100.0, None) is None
+
+
+def test_seed_covers_candles_before_live_trades():
+    start = session_start(2 * 86400 + 3600)
+    assert start == 2 * 86400
+
+    session = SessionVwap(session_start=start, live_since=start + 600)
+    session.add_seed(
+        [
+            # Previous session and the live candle are skipped
+            {"timestamp": (start - 300) * 1000, "high": 1, "low": 1, "close": 1, "volume": 5},
+            {"timestamp": start * 1000, "high": 12, "low": 9, "close": 9, "volume": 2},
+            {"timestamp": (start + 600) * 1000, "high": 1, "low": 1, "close": 1, "volume": 5},
+        ]
+    )
+    session.add_trade(50.0, 1.0, start + 300)  # Before live_since, already in the seed
+    session.add_trade(13.0, 2.0, start + 700)
+
+    assert session.value == pytest.approx(11.5)
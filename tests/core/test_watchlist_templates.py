@@ -0,0 +1,30 @@
+from core.watchlist_templates import (
+    BUILTIN_TEMPLATES,
+    TOP_MARKET_CAP,
+    top_market_cap_pairs,
+    top_volume_pairs,
+)
+
+
+def test_builtin_templates_are_full():
+    for template in BUILTIN_TEMPLATES.values():
+        assert len(template.pairs) == 10
+        assert len(set(template.pairs)) == 10
+    assert BUILTIN_TEMPLATES[TOP_MARKET_CAP].pairs[0] == "BTC-USDT"
+
+
+def test_top_market_cap_skips_stablecoins():
+    symbols = ["btc", "eth", "usdt", "xrp", "USDC", "sol", "steth"]
+    assert top_market_cap_pairs(symbols, limit=3) == ["BTC-USDT", "ETH-USDT", "XRP-USDT"]
+
+
+def test_top_volume_sorts_usdt_pairs():
+    tickers = [
+        {"instId": "ETH-USDT", "volCcy24h": "500"},
+        {"instId": "BTC-USDT", "volCcy24h": "900"},
+        {"instId": "BTC-USDC", "volCcy24h": "2000"},
+        {"instId": "USDC-USDT", "volCcy24h": "1000"},
+        {"instId": "PEPE-USDT", "volCcy24h": "bad"},
+        {"instId": "SOL-USDT", "volCcy24h": "300"},
+    ]
+    assert top_volume_pairs(tickers, limit=2) == ["BTC-USDT", "ETH-USDT"]
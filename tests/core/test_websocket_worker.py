@@ -0,0 +1,142 @@
+import asyncio
+from unittest.mock import AsyncMock, patch
+
+from core.models import TickerData
+from core.okx_client import OkxWebSocketWorker
+from core.websocket_worker import BaseWebSocketWorker
+
+
+def _ticker(price):
+    return TickerData(pair="BTC-USDT", price=price, percentage="0.00%")
+
+
+def test_buffered_tickers_drop_oldest():
+    worker = OkxWebSocketWorker(["BTC-USDT"])
+    # Simulate a stalled main thread: nothing drains the buffer
+    worker._tickers_buffered.disconnect(worker._deliver_tickers)
+    delivered = []
+    worker.ticker_updated.connect(lambda pair, ticker: delivered.append(ticker.price))
+
+    worker._emit_ticker("BTC-USDT", _ticker("100"))
+    worker._emit_ticker("BTC-USDT", _ticker("101"))
+    worker._emit_ticker("BTC-USDT", _ticker("102"))
+    assert worker.dropped_tickers == 2
+
+    worker._deliver_tickers()
+    assert delivered == ["102"]
+    worker._deliver_tickers()
+    assert delivered == ["102"]
+
+
+def test_send_batched_chunks_subscriptions():
+    worker = OkxWebSocketWorker(["BTC-USDT"])
+    worker.SUBSCRIBE_BATCH_SIZE = 4
+    worker.SUBSCRIBE_BATCH_DELAY = 0
+    sent = []
+
+    async def send(args):
+        sent.append(args)
+
+    args = worker._subscription_args([f"COIN{i}-USDT" for i in range(5)])
+    asyncio.run(worker._send_batched(args, send))
+
+    assert [len(batch) for batch in sent] == [4, 4, 2]
+    assert [arg for batch in sent for arg in batch] == args
+
+
+class _ClosingWorker(BaseWebSocketWorker):
+    """Reports the given close codes, one per keepalive check, then stops."""
+
+    def __init__(self, close_codes):
+        super().__init__(["BTC-USDT", "ETH-USDT"])
+        self.close_codes = list(close_codes)
+        self.subscribed_at_connect = []
+        self.states = []
+        self.connection_state_changed.connect(lambda state, *_: self.states.append(state))
+
+    async def _connect_and_subscribe(self):
+        self.subscribed_at_connect.append(set(self._subscribed_pairs))
+        self._subscribed_pairs = set(self.pairs)
+
+    async def _update_subscriptions(self):
+        pass
+
+    def _server_close_code(self):
+        if not self.close_codes:
+            self._running = False
+            return None
+        return self.close_codes.pop(0)
+
+
+def _run(worker):
+    worker._running = True
+    with patch("asyncio.sleep", new=AsyncMock()):
+        asyncio.run(worker._maintain_connection())
+
+
+def test_routine_close_resubscribes_quietly():
+    worker = _ClosingWorker([None, 1001, None])
+    _run(worker)
+
+    # Every connection subscribes all pairs again
+    assert worker.subscribed_at_connect == [set(), set()]
+    assert worker._total_reconnect_count == 1
+    assert "reconnecting" not in worker.states
+    assert 4004 in OkxWebSocketWorker.ROUTINE_CLOSE_CODES
+
+
+def test_other_close_codes_reconnect_with_an_error():
+    worker = _ClosingWorker([4008])
+    _run(worker)
+
+    assert worker.subscribed_at_connect == [set(), set()]
+    assert "reconnecting" in worker.states
+    assert "4008" in worker._last_error
+
+
+def test_trade_gap_is_resynced():
+    worker = OkxWebSocketWorker(["BTC-USDT"])
+    trades = []
+    events = []
+    worker.trades_received.connect(lambda pair, batch: trades.extend(batch))
+    worker.data_integrity.connect(events.append)
+
+    def push(trade_id, count="1"):
+        trade = {"px": "100", "sz": "1", "ts": "1700000000000", "tradeId": trade_id}
+        worker._handle_trades("BTC-USDT", [{**trade, "count": count}])
+
+    push("10")
+    push("13", count="3")  # Trades 11 to 13 aggregated
+    assert worker._sequence_gaps == 0
+    push("16")
+    assert worker._sequence_gaps == 1
+
+    gap = worker._sequences.check("trades", "BTC-USDT", 20, 20)
+    worker._fetch_missed_trades = AsyncMock(return_value=[(99.0, 0.5, 1700000000.0)] * 3)
+    trades.clear()
+    asyncio.run(worker._resync(gap))
+
+    assert len(trades) == 3
+    assert events == [gap]
+    assert gap.recovered == 3
+    assert gap.missing == 3
+
+
+def test_pending_resyncs_are_kept_until_done():
+    worker = OkxWebSocketWorker(["BTC-USDT"])
+    worker._fetch_missed_trades = AsyncMock(return_value=[])
+    events = []
+    worker.data_integrity.connect(events.append)
+
+    async def run():
+        worker._loop = asyncio.get_running_loop()
+        for trade_id in ("10", "16"):
+            trade = {"px": "100", "sz": "1", "ts": "1700000000000", "tradeId": trade_id}
+            worker._handle_trades("BTC-USDT", [trade])
+        assert len(worker._resync_tasks) == 1
+        await asyncio.gather(*worker._resync_tasks)
+        await asyncio.sleep(0)
+
+    asyncio.run(run())
+    assert len(events) == 1
+    assert not worker._resync_tasks
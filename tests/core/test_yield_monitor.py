@@ -0,0 +1,51 @@
+from unittest.mock import MagicMock, patch
+
+import pytest
+
+from core.market_indices import ETH_STAKING_PAIR, HASHPRICE_PAIR
+from core.yield_monitor import YieldMonitor, Yields, compute_hashprice
+
+
+def test_compute_hashprice():
+    # 450 BTC a day at $60k over 600 EH/s: $27M spread over 600,000 PH/s
+    hashprice = compute_hashprice(450 * 100_000_000, 600e18, 60_000)
+    assert hashprice == pytest.approx(45.0)
+
+
+def test_get_tickers_skips_failed_sources():
+    monitor = YieldMonitor()
+    assert monitor.get_tickers() == []
+
+    monitor._yields = Yields(hashprice=50.0, eth_staking_apr=None)
+    monitor._previous = Yields(hashprice=40.0, eth_staking_apr=3.0)
+    tickers = monitor.get_tickers()
+    assert [t.pair for t in tickers] == [HASHPRICE_PAIR]
+    assert tickers[0].price == "50.00"
+    assert tickers[0].percentage == "+25.00%"
+
+    monitor._yields = Yields(hashprice=None, eth_staking_apr=2.8)
+    assert monitor.get_tickers()[0].pair == ETH_STAKING_PAIR
+
+
+def test_yields_are_only_polled_while_watched():
+    settings_manager = MagicMock()
+    settings_manager.settings.crypto_pairs = ["BTC-USDT"]
+    settings_manager.settings.alerts = []
+    with (
+        patch("core.yield_monitor.get_settings_manager", return_value=settings_manager),
+        patch("core.yield_monitor.threading.Thread") as thread,
+    ):
+        monitor = YieldMonitor()
+        monitor.start()
+        assert not monitor._timer.isActive()
+        thread.assert_not_called()
+
+        # An alert on a yield counts as well
+        settings_manager.settings.alerts = [MagicMock(pair=HASHPRICE_PAIR)]
+        monitor.apply_settings()
+        assert monitor._timer.isActive()
+        thread.assert_called_once()
+
+        settings_manager.settings.alerts = []
+        monitor.apply_settings()
+        assert not monitor._timer.isActive()
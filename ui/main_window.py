@@ -5,32 +5,54 @@ Main application window using Fluent Design.
 import logging
 import webbrowser
 
-from PyQt6.QtCore import Qt, QTimer
-from PyQt6.QtGui import QIcon, QMouseEvent
+from PyQt6.QtCore import QEvent, Qt, QTimer, QUrl
+from PyQt6.QtGui import QDesktopServices, QIcon, QMouseEvent
 from PyQt6.QtWidgets import (
     QApplication,
     QMainWindow,
     QScrollArea,
+    QSystemTrayIcon,
     QVBoxLayout,
     QWidget,
 )
-from qfluentwidgets import Theme, setTheme
+from qfluentwidgets import MessageBox, Theme, setTheme
 
 from config.settings import get_settings_manager
+from core.automation import AutomationCommands, Command, CommandServer
+from core.chainlink_client import get_explorer_url, is_oracle_pair
+from core.config_watcher import get_config_watcher
+from core.control_api import ControlApi
+from core.crash_reporter import get_crash_reporter, issue_url
+from core.hotkeys import CYCLE_PAIR, PAUSE_ALERTS, TOGGLE_WINDOW, get_hotkey_service
 from core.i18n import _
+from core.local_api import get_local_api_server
+from core.macos_menu_bar import MacMenuBarItem, menu_bar_text
 from core.market_data_controller import MarketDataController
+from core.market_indices import INDEX_URLS, is_index_pair
+from core.network_selftest import WS_ENDPOINTS
+from core.notifier import get_notification_service
+from core.replay_client import get_replay_path
+from core.taskbar_badge import TaskbarBadge, WindowsTaskbar
+from core.telegram_bot import get_telegram_bot
+from core.virtual_pairs import is_virtual_pair
+from core.watchlists import get_watchlist_manager
 
 # New components
 from ui.behaviors.window_behavior import DraggableWindowBehavior
 from ui.managers.pagination_manager import PaginationManager
 from ui.managers.view_manager import ViewManager
 from ui.settings_window import SettingsWindow
+from ui.widgets.account_dialog import AccountDialog
 from ui.widgets.add_pair_dialog import AddPairDialog
 from ui.widgets.alert_dialog import AlertDialog
 from ui.widgets.alert_list_dialog import AlertListDialog
 from ui.widgets.crypto_card import CryptoCard
+from ui.widgets.mini_ticker import MiniTickerWindow
+from ui.widgets.network_selftest_dialog import NetworkSelfTestDialog
 from ui.widgets.pagination import Pagination
+from ui.widgets.pair_alias_dialog import PairAliasDialog
 from ui.widgets.toolbar import Toolbar
+from ui.widgets.tray_icon import PriceTrayIcon
 
 logger = logging.getLogger(__name__)
 
@@ -38,16 +60,23 @@ logger = logging.getLogger(__name__)
 class MainWindow(QMainWindow):
     """Main application window with Fluent Design components."""
 
+    # A fresh install not connected after this long is offered the network self-test
+    FIRST_RUN_CONNECT_GRACE_MS = 20000
+
     def __init__(self):
         super().__init__()
 
         self._settings_window: SettingsWindow | None = None
         self._cards: dict[str, CryptoCard] = {}
         self._edit_mode = False
+        self._connected = False
 
         # Core components
         self._settings_manager = get_settings_manager()
         self._market_controller = MarketDataController(self)
+        self._control_api = ControlApi(get_local_api_server(), self._market_controller, self)
+        self._automation = AutomationCommands(self._market_controller, self)
+        self._command_server = CommandServer(self.run_automation_command, self)
 
         # Initialize Managers and Behaviors
         self._window_behavior = DraggableWindowBehavior(self)
@@ -65,7 +94,17 @@ class MainWindow(QMainWindow):
         )
 
         self._view_manager.setup_animations(self.toolbar, self.pagination)
+        self._setup_tray()
+        self._setup_mini_ticker()
+        self._setup_taskbar_badge()
+        self._setup_menu_bar_price()
         self._connect_signals()
+        self._hotkey_service = get_hotkey_service()
+        self._hotkey_service.action_triggered.connect(self._on_hotkey)
+        self._hotkey_service.start()
+        self._config_watcher = get_config_watcher()
+        self._config_watcher.config_reloaded.connect(self._on_config_reloaded)
+        self._config_watcher.start()
 
         # Start data controller
         self._load_pairs()
@@ -74,6 +113,9 @@ class MainWindow(QMainWindow):
 
         # Initial size adjustment
         QTimer.singleShot(100, self._view_manager.adjust_window_height)
+        QTimer.singleShot(1000, self._offer_crash_reports)
+        if self._settings_manager.first_run:
+            QTimer.singleShot(self.FIRST_RUN_CONNECT_GRACE_MS, self._offer_self_test)
 
     def _setup_ui(self):
         """Setup the main window UI with Fluent Design components."""
@@ -108,6 +150,7 @@ class MainWindow(QMainWindow):
         layout.setSpacing(5)
 
         self.toolbar = Toolbar()
+        self.toolbar.set_account_visible(self._settings_manager.settings.okx_api.is_configured())
         layout.addWidget(self.toolbar)
 
         self.scroll_area = QScrollArea()
@@ -128,20 +171,158 @@ class MainWindow(QMainWindow):
         self.pagination = Pagination()
         layout.addWidget(self.pagination)
 
+    def _setup_tray(self):
+        """Show the pinned pairs' prices in the system tray, where there is one."""
+        self._tray_icon: PriceTrayIcon | None = None
+        if not QSystemTrayIcon.isSystemTrayAvailable():
+            logger.info("System tray not available")
+            return
+        self._tray_icon = PriceTrayIcon(self.windowIcon(), self)
+        self._tray_icon.open_requested.connect(self._show_from_tray)
+        self._tray_icon.quit_requested.connect(self._close_app)
+        self._market_controller.tray_updated.connect(self._tray_icon.update_entries)
+        self._tray_icon.show()
+
+    def _setup_mini_ticker(self):
+        """Always-on-top window streaming one pinned pair."""
+        self._mini_ticker = MiniTickerWindow()
+        self._mini_ticker.open_requested.connect(self._show_from_tray)
+        self._mini_ticker.pair_selected.connect(self._market_controller.set_mini_ticker_pair)
+        self._mini_ticker.close_requested.connect(lambda: self._set_mini_ticker_visible(False))
+        self._market_controller.mini_ticker_updated.connect(self._mini_ticker.update_entry)
+        self._market_controller.tray_updated.connect(self._update_mini_ticker_pairs)
+        if self._tray_icon:
+            self._tray_icon.mini_ticker_toggled.connect(self._set_mini_ticker_visible)
+        if self._settings_manager.settings.mini_ticker_enabled:
+            QTimer.singleShot(0, lambda: self._set_mini_ticker_visible(True))
+
+    def _setup_taskbar_badge(self):
+        """Show the first tray pair's trend on the taskbar button, on Windows."""
+        self._taskbar = WindowsTaskbar()
+        if not self._taskbar.available:
+            return
+        self._market_controller.taskbar_badge_updated.connect(self._show_taskbar_badge)
+        if self._tray_icon:
+            self._tray_icon.taskbar_badge_action.setChecked(
+                self._settings_manager.settings.taskbar_badge
+            )
+            self._tray_icon.taskbar_badge_toggled.connect(self._set_taskbar_badge_enabled)
+
+    def _show_taskbar_badge(self, badge: TaskbarBadge | None):
+        if self._settings_manager.settings.taskbar_badge:
+            self._taskbar.show_badge(int(self.winId()), badge)
+
+    def _set_taskbar_badge_enabled(self, enabled: bool):
+        self._settings_manager.update_taskbar_badge(enabled)
+        badge = self._market_controller.get_taskbar_badge() if enabled else None
+        self._taskbar.show_badge(int(self.winId()), badge)
+
+    def _setup_menu_bar_price(self):
+        """Show the first tray pair's price as text in the macOS menu bar."""
+        self._menu_bar_item = MacMenuBarItem(self)
+        if not self._menu_bar_item.available:
+            return
+        self._menu_bar_item.clicked.connect(self._show_from_tray)
+        self._market_controller.tray_updated.connect(self._update_menu_bar_price)
+        enabled = self._settings_manager.settings.menu_bar_price
+        if self._tray_icon:
+            self._tray_icon.menu_bar_price_action.setChecked(enabled)
+            self._tray_icon.menu_bar_price_toggled.connect(self._set_menu_bar_price_enabled)
+        if enabled:
+            self._menu_bar_item.show()
+
+    def _update_menu_bar_price(self, entries: list):
+        if entries:
+            self._menu_bar_item.set_text(menu_bar_text(entries[0]))
+
+    def _set_menu_bar_price_enabled(self, enabled: bool):
+        self._settings_manager.update_menu_bar_price(enabled)
+        if enabled:
+            self._menu_bar_item.show()
+        else:
+            self._menu_bar_item.hide()
+
+    def _update_mini_ticker_pairs(self, _entries: list):
+        self._mini_ticker.set_pairs(
+            self._market_controller.get_tray_pairs(),
+            self._market_controller.get_mini_ticker_pair(),
+        )
+
+    def _set_mini_ticker_visible(self, visible: bool):
+        settings = self._settings_manager.settings
+        if visible:
+            if settings.mini_ticker_x >= 0 and settings.mini_ticker_y >= 0:
+                self._mini_ticker.move(settings.mini_ticker_x, settings.mini_ticker_y)
+            else:
+                self._mini_ticker.move(self.x(), max(0, self.y() - 40))
+            self._update_mini_ticker_pairs([])
+            self._mini_ticker.show()
+        else:
+            self._mini_ticker.hide()
+        pos = self._mini_ticker.pos()
+        self._settings_manager.update_mini_ticker(visible, pos.x(), pos.y())
+        if self._tray_icon:
+            self._tray_icon.mini_ticker_action.setChecked(visible)
+
+    def start_minimized(self):
+        """Start in the tray without showing the window, or minimized without a tray."""
+        if self._tray_icon:
+            self._update_ui_paused()
+        else:
+            self.showMinimized()
+
+    def _show_from_tray(self):
+        self.showNormal()
+        self.raise_()
+        self.activateWindow()
+
+    def run_automation_command(self, command: Command) -> str:
+        """Carry out a command of a crypto-monitor:// link or a later launch; returns the reply."""
+        reply = self._automation.execute(command)
+        if command.from_link and command.action == "price" and not reply.startswith("error"):
+            # Links can't print, so the price is left on the clipboard for the caller
+            QApplication.clipboard().setText(reply)
+        return reply
+
+    def _on_hotkey(self, action: str):
+        if action == TOGGLE_WINDOW:
+            if self.isHidden() or self.isMinimized():
+                self._show_from_tray()
+            elif self._tray_icon:
+                self.hide()
+            else:
+                # Without a tray icon, a hidden window could only come back by hotkey
+                self.showMinimized()
+        elif action == PAUSE_ALERTS:
+            notifier = get_notification_service()
+            notifier.set_paused(not notifier.is_paused)
+        elif action == CYCLE_PAIR:
+            self._market_controller.cycle_mini_ticker_pair()
+
     def _connect_signals(self):
         """Connect signals to slots."""
         self.toolbar.settings_clicked.connect(self._open_settings)
         self.toolbar.add_clicked.connect(self._toggle_edit_mode)
+        self.toolbar.share_clicked.connect(self._show_share_menu)
+        self.toolbar.account_clicked.connect(self._open_account)
         self.toolbar.minimize_clicked.connect(self.showMinimized)
         self.toolbar.pin_clicked.connect(self._toggle_always_on_top)
         self.toolbar.close_clicked.connect(self._close_app)
 
         self.pagination.page_changed.connect(self._on_page_changed)
 
-        self._market_controller.ticker_updated.connect(self._on_ticker_update)
+        self._market_controller.tickers_updated.connect(self._on_tickers_updated)
         self._market_controller.connection_status_changed.connect(self._on_connection_status)
         self._market_controller.connection_state_changed.connect(self._on_connection_state_changed)
         self._market_controller.data_source_changed.connect(self._on_data_source_changed_complete)
+        get_watchlist_manager().pairs_changed.connect(self._load_pairs)
+        self._control_api.add_pairs_requested.connect(self._add_pairs)
+        self._control_api.remove_pair_requested.connect(self._remove_pair)
+        self._automation.show_requested.connect(self._show_from_tray)
+        self._automation.add_pairs_requested.connect(self._add_pairs)
+        self._command_server.listen()
+        get_telegram_bot().set_commands(self._automation)
+        get_telegram_bot().start()
 
     def _load_pairs(self):
         """Load pairs from settings and subscribe."""
@@ -170,6 +351,12 @@ class MainWindow(QMainWindow):
                 card.remove_clicked.connect(self._remove_pair)
                 card.add_alert_requested.connect(self._on_add_alert_requested)
                 card.view_alerts_requested.connect(self._on_view_alerts_requested)
+                card.rename_requested.connect(self._on_rename_requested)
+                card.display_prefs_changed.connect(self._market_controller.set_pair_display_prefs)
+                card.highlight_rules_changed.connect(
+                    self._market_controller.set_pair_highlight_rules
+                )
+                card.pin_toggled.connect(self._market_controller.set_pair_pinned)
                 self._cards[pair] = card
 
             card = self._cards[pair]
@@ -182,7 +369,6 @@ class MainWindow(QMainWindow):
         """Open settings window."""
         if self._settings_window is None or not self._settings_window.isVisible():
             self._settings_window = SettingsWindow(self._settings_manager)
-            self._settings_window.proxy_changed.connect(self._on_proxy_changed)
             self._settings_window.pairs_changed.connect(self._on_pairs_changed)
             self._settings_window.theme_changed.connect(self._on_theme_changed)
             self._settings_window.data_source_changed.connect(self._on_data_source_changed)
@@ -191,6 +377,7 @@ class MainWindow(QMainWindow):
             self._settings_window.display_limit_changed.connect(self._on_display_limit_changed)
             self._settings_window.minimalist_view_changed.connect(self._on_minimalist_view_changed)
             self._settings_window.price_change_basis_changed.connect(self._on_data_source_changed)
+            self._settings_window.account_changed.connect(self._on_account_changed)
             self._settings_window.show()
         else:
             self._settings_window.raise_()
@@ -199,20 +386,20 @@ class MainWindow(QMainWindow):
         """Handle page change."""
         self._update_cards_display()
 
-    def _on_ticker_update(self, pair: str, state: object):
-        if pair in self._cards:
-            self._cards[pair].update_state(state)
+    def _on_tickers_updated(self, states: dict):
+        for pair, state in states.items():
+            if pair in self._cards:
+                self._cards[pair].update_state(state)
 
     def _on_connection_status(self, connected: bool, message: str):
         logger.debug(f"Connection status: {connected}, {message}")
 
     def _on_connection_state_changed(self, state: str, message: str, retry_count: int):
+        if state == "connected":
+            self._connected = True
         for card in self._cards.values():
             card.set_connection_state(state)
 
-    def _on_proxy_changed(self):
-        self._market_controller.set_proxy()
-
     def _on_pairs_changed(self):
         self._load_pairs()
 
@@ -242,6 +429,65 @@ class MainWindow(QMainWindow):
     def _on_data_source_changed_complete(self):
         pass
 
+    def _on_account_changed(self):
+        self._market_controller.set_account()
+        self.toolbar.set_account_visible(self._settings_manager.settings.okx_api.is_configured())
+
+    def _on_config_reloaded(self):
+        """Apply settings edited outside the app; proxy and alerts pick them up themselves."""
+        self._load_pairs()
+        self._on_display_changed()
+        self._on_account_changed()
+        self._hotkey_service.restart()
+
+    def _offer_self_test(self):
+        """Offer the network self-test to a fresh install that hasn't connected yet."""
+        source = self._settings_manager.settings.data_source
+        if self._connected or source.upper() not in WS_ENDPOINTS or get_replay_path():
+            return
+        w = MessageBox(
+            _("Still Connecting?"),
+            _(
+                "Crypto Monitor hasn't reached {exchange} yet. Run a network self-test to "
+                "find out why?"
+            ).format(exchange=source),
+            self,
+        )
+        w.yesButton.setText(_("Run Test"))
+        w.cancelButton.setText(_("Later"))
+        if w.exec():
+            proxy = self._settings_manager.proxy_store.get()
+            NetworkSelfTestDialog(source, proxy, self).exec()
+
+    def _offer_crash_reports(self):
+        """Offer to send the reports of crashes since the last start, if opted in."""
+        reporter = get_crash_reporter()
+        reports = reporter.pending_reports()
+        if not reports or not reporter.enabled:
+            return
+        w = MessageBox(
+            _("Crypto Monitor Crashed"),
+            _(
+                "An error occurred last time. Send an anonymous crash report as a GitHub issue? "
+                "It contains the app version, system and error trace, but no settings or pairs."
+            ),
+            self,
+        )
+        w.yesButton.setText(_("Review and Send"))
+        w.cancelButton.setText(_("Dismiss"))
+        if w.exec():
+            try:
+                report = reports[-1].read_text(encoding="utf-8")
+            except OSError as e:
+                logger.warning(f"Failed to read crash report: {e}")
+            else:
+                QDesktopServices.openUrl(QUrl(issue_url(report)))
+        reporter.discard(reports)
+
+    def _open_account(self):
+        dialog = AccountDialog(parent=self)
+        dialog.exec()
+
     def _toggle_edit_mode(self):
         if self._edit_mode:
             self._edit_mode = False
@@ -249,14 +495,95 @@ class MainWindow(QMainWindow):
                 card.set_edit_mode(False)
         else:
             data_source = self._settings_manager.settings.data_source
-            pair = AddPairDialog.get_new_pair(data_source, self)
-            if pair:
-                self._add_pair(pair)
-
-    def _add_pair(self, pair: str):
-        if self._settings_manager.add_pair(pair):
+            pairs = AddPairDialog.get_new_pairs(data_source, self)
+            if pairs:
+                self._add_pairs(pairs)
+
+    def _add_pairs(self, pairs: list[str]):
+        added = self._settings_manager.add_pairs(pairs)
+        if added:
+            logger.info(f"Added {len(added)} pairs")
             self._load_pairs()
 
+    def _show_share_menu(self):
+        from qfluentwidgets import Action, RoundMenu
+        from qfluentwidgets import FluentIcon as FIF
+
+        menu = RoundMenu(parent=self)
+        copy_text = Action(FIF.COPY, _("Copy Prices"), self)
+        copy_text.triggered.connect(self._copy_snapshot_text)
+        copy_image = Action(FIF.PHOTO, _("Copy as Image"), self)
+        copy_image.triggered.connect(self._copy_snapshot_image)
+        save_image = Action(FIF.SAVE, _("Save Image..."), self)
+        save_image.triggered.connect(self._save_snapshot_image)
+        export_csv = Action(FIF.DOCUMENT, _("Export CSV..."), self)
+        export_csv.triggered.connect(self._export_snapshot_csv)
+        menu.addActions([copy_text, copy_image, save_image, export_csv])
+
+        button = self.toolbar.share_btn
+        menu.exec(button.mapToGlobal(button.rect().bottomLeft()))
+
+    def _copy_snapshot_text(self):
+        from qfluentwidgets import InfoBar
+
+        from core.snapshot import format_snapshot_text
+
+        rows = self._market_controller.get_snapshot_rows()
+        if not rows:
+            InfoBar.warning(_("Share Prices"), _("No prices yet"), parent=self)
+            return
+        QApplication.clipboard().setText(format_snapshot_text(rows))
+        InfoBar.success(_("Share Prices"), _("Prices copied to clipboard"), parent=self)
+
+    def _copy_snapshot_image(self):
+        from qfluentwidgets import InfoBar
+
+        QApplication.clipboard().setPixmap(self.centralWidget().grab())
+        InfoBar.success(_("Share Prices"), _("Image copied to clipboard"), parent=self)
+
+    def _save_snapshot_image(self):
+        from datetime import datetime
+
+        from PyQt6.QtWidgets import QFileDialog
+        from qfluentwidgets import InfoBar
+
+        pixmap = self.centralWidget().grab()
+        timestamp = datetime.now().strftime("%Y%m%d_%H%M%S")
+        filepath, _filter = QFileDialog.getSaveFileName(
+            self, _("Save Image"), f"crypto-prices_{timestamp}.png", "PNG Images (*.png)"
+        )
+        if not filepath:
+            return
+        if pixmap.save(filepath, "PNG"):
+            InfoBar.success(_("Share Prices"), _("Image saved"), parent=self)
+        else:
+            InfoBar.error(_("Export Failed"), filepath, parent=self)
+
+    def _export_snapshot_csv(self):
+        from datetime import datetime
+
+        from PyQt6.QtWidgets import QFileDialog
+        from qfluentwidgets import InfoBar
+
+        from core.snapshot import write_snapshot_csv
+
+        rows = self._market_controller.get_snapshot_rows()
+        if not rows:
+            InfoBar.warning(_("Share Prices"), _("No prices yet"), parent=self)
+            return
+        timestamp = datetime.now().strftime("%Y%m%d_%H%M%S")
+        filepath, _filter = QFileDialog.getSaveFileName(
+            self, _("Export CSV"), f"crypto-prices_{timestamp}.csv", "CSV Files (*.csv)"
+        )
+        if not filepath:
+            return
+        try:
+            count = write_snapshot_csv(filepath, rows)
+        except OSError as e:
+            InfoBar.error(_("Export Failed"), str(e), parent=self)
+            return
+        InfoBar.success(_("Share Prices"), f"{_('Pairs exported')}: {count}", parent=self)
+
     def _remove_pair(self, pair: str):
         if self._settings_manager.remove_pair(pair):
             if pair in self._cards:
@@ -266,7 +593,20 @@ class MainWindow(QMainWindow):
             self._load_pairs()
 
     def _open_pair_in_browser(self, pair: str):
-        if pair.lower().startswith("chain:"):
+        if is_virtual_pair(pair):
+            return
+        if is_index_pair(pair):
+            if pair in INDEX_URLS:
+                webbrowser.open(INDEX_URLS[pair])
+            return
+
+        if is_oracle_pair(pair):
+            url = get_explorer_url(pair)
+            if url:
+                webbrowser.open(url)
+            return
+
+        if pair.lower().startswith(("chain:", "pool:")):
             parts = pair.split(":")
             if len(parts) >= 3:
                 network = parts[1]
@@ -302,6 +642,13 @@ class MainWindow(QMainWindow):
         dialog = AlertListDialog(pair, parent=self)
         dialog.exec()
 
+    def _on_rename_requested(self, pair: str):
+        alias = PairAliasDialog.get_alias(
+            pair, self._settings_manager.settings.pair_aliases.get(pair, ""), self
+        )
+        if alias is not None:
+            self._market_controller.set_pair_alias(pair, alias)
+
     def _toggle_always_on_top(self, pinned: bool):
         self._settings_manager.settings.always_on_top = pinned
         self._settings_manager.save()
@@ -320,6 +667,16 @@ class MainWindow(QMainWindow):
         self._settings_manager.save()
         if self._market_controller:
             self._market_controller.stop()
+        self._hotkey_service.stop()
+        self._config_watcher.stop()
+        get_telegram_bot().stop()
+        self._menu_bar_item.hide()
+        if self._tray_icon:
+            self._tray_icon.hide()
+        if self._mini_ticker.isVisible():
+            pos = self._mini_ticker.pos()
+            self._settings_manager.update_mini_ticker(True, pos.x(), pos.y())
+        self._mini_ticker.close()
         QApplication.quit()
 
     # Events delegated to Managers/Behaviors
@@ -340,6 +697,24 @@ class MainWindow(QMainWindow):
         self._window_behavior.mouse_release_event(event)
         super().mouseReleaseEvent(event)
 
+    def showEvent(self, event):
+        self._update_ui_paused()
+        super().showEvent(event)
+
+    def hideEvent(self, event):
+        self._update_ui_paused()
+        super().hideEvent(event)
+
+    def changeEvent(self, event):
+        if event.type() == QEvent.Type.WindowStateChange:
+            self._update_ui_paused()
+        super().changeEvent(event)
+
+    def _update_ui_paused(self):
+        # Cards aren't visible, so stop pushing prices to them
+        if self._market_controller:
+            self._market_controller.set_ui_paused(self.isHidden() or self.isMinimized())
+
     def enterEvent(self, event):
         self._view_manager.handle_enter_event()
         super().enterEvent(event)
@@ -15,6 +15,7 @@ from qfluentwidgets import (
 
 from core.i18n import _
 from core.version import __version__
+from ui.widgets.setting_cards import CrashReportSettingCard
 
 
 class AboutPage(QWidget):
@@ -76,12 +77,22 @@ class AboutPage(QWidget):
         self.app_dir_card.button.clicked.connect(self._open_log_directory)
         self.about_group.addSettingCard(self.app_dir_card)
 
+        # Crash Reports Card
+        self.crash_report_card = CrashReportSettingCard(self.about_group)
+        self.about_group.addSettingCard(self.crash_report_card)
+
         self.scroll_layout.addWidget(self.about_group)
         self.scroll_layout.addStretch(1)
 
         self.scroll.setWidget(self.scroll_content)
         self.layout.addWidget(self.scroll)
 
+    def set_crash_reports(self, enabled):
+        self.crash_report_card.set_enabled(enabled)
+
+    def get_crash_reports(self):
+        return self.crash_report_card.is_enabled()
+
     def _open_log_directory(self):
         """Open the application log directory in file explorer."""
         import os
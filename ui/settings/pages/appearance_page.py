@@ -3,7 +3,10 @@ from qfluentwidgets import ScrollArea, SettingCardGroup
 
 from core.i18n import _
 from ui.widgets.setting_cards import (
+    AutostartSettingCard,
     DisplaySettingCard,
+    HistorySettingCard,
+    HotkeySettingCard,
     HoverSettingCard,
     LanguageSettingCard,
 )
@@ -50,6 +53,15 @@ class AppearancePage(QWidget):
         self.display_card = DisplaySettingCard(self.appearance_group)
         self.appearance_group.addSettingCard(self.display_card)
 
+        self.history_card = HistorySettingCard(self.appearance_group)
+        self.appearance_group.addSettingCard(self.history_card)
+
+        self.hotkey_card = HotkeySettingCard(self.appearance_group)
+        self.appearance_group.addSettingCard(self.hotkey_card)
+
+        self.autostart_card = AutostartSettingCard(self.appearance_group)
+        self.appearance_group.addSettingCard(self.autostart_card)
+
         self.scroll_layout.addWidget(self.appearance_group)
         self.scroll_layout.addStretch(1)
 
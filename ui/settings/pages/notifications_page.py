@@ -2,7 +2,32 @@ from PyQt6.QtWidgets import QVBoxLayout, QWidget
 from qfluentwidgets import ScrollArea, SettingCardGroup
 
 from core.i18n import _
+from core.notification_channels import (
+    DingTalkChannel,
+    EmailChannel,
+    FeishuChannel,
+    GotifyChannel,
+    MatrixChannel,
+    TelegramChannel,
+    WeComChannel,
+    XmppChannel,
+    get_remote_notifier,
+)
+from ui.widgets.address_setting_card import AddressSettingCard
 from ui.widgets.alert_setting_card import AlertSettingCard
+from ui.widgets.channel_setting_cards import (
+    DingTalkSettingCard,
+    EmailSettingCard,
+    FeishuSettingCard,
+    GotifySettingCard,
+    MatrixSettingCard,
+    TelegramSettingCard,
+    WeComSettingCard,
+    XmppSettingCard,
+)
+from ui.widgets.dca_setting_card import DcaSettingCard
+from ui.widgets.digest_setting_card import DigestSettingCard
+from ui.widgets.news_setting_card import NewsSettingCard
 
 
 class NotificationsPage(QWidget):
@@ -31,8 +56,111 @@ class NotificationsPage(QWidget):
         self.alerts_card = AlertSettingCard(self.alerts_group)
         self.alerts_group.addSettingCard(self.alerts_card)
 
+        self.remote_group = SettingCardGroup(_("Remote Notifications"), self.scroll_content)
+        self.telegram_card = TelegramSettingCard(self.remote_group)
+        self.matrix_card = MatrixSettingCard(self.remote_group)
+        self.wecom_card = WeComSettingCard(self.remote_group)
+        self.dingtalk_card = DingTalkSettingCard(self.remote_group)
+        self.feishu_card = FeishuSettingCard(self.remote_group)
+        self.gotify_card = GotifySettingCard(self.remote_group)
+        self.xmpp_card = XmppSettingCard(self.remote_group)
+        self.email_card = EmailSettingCard(self.remote_group)
+        # Channel name -> (card, channel class), for the test messages
+        self._channel_cards = {
+            TelegramChannel.name: (self.telegram_card, TelegramChannel),
+            MatrixChannel.name: (self.matrix_card, MatrixChannel),
+            WeComChannel.name: (self.wecom_card, WeComChannel),
+            DingTalkChannel.name: (self.dingtalk_card, DingTalkChannel),
+            FeishuChannel.name: (self.feishu_card, FeishuChannel),
+            GotifyChannel.name: (self.gotify_card, GotifyChannel),
+            XmppChannel.name: (self.xmpp_card, XmppChannel),
+            EmailChannel.name: (self.email_card, EmailChannel),
+        }
+        for name, (card, _channel) in self._channel_cards.items():
+            card.test_requested.connect(lambda name=name: self._test_channel(name))
+            self.remote_group.addSettingCard(card)
+        get_remote_notifier().test_finished.connect(self._on_test_finished)
+
+        self.digest_group = SettingCardGroup(_("Digests"), self.scroll_content)
+        self.digest_card = DigestSettingCard(self.digest_group)
+        self.digest_group.addSettingCard(self.digest_card)
+
+        self.dca_group = SettingCardGroup(_("DCA Plans"), self.scroll_content)
+        self.dca_card = DcaSettingCard(self.dca_group)
+        self.dca_group.addSettingCard(self.dca_card)
+
+        self.news_group = SettingCardGroup(_("News"), self.scroll_content)
+        self.news_card = NewsSettingCard(self.news_group)
+        self.news_group.addSettingCard(self.news_card)
+
+        self.onchain_group = SettingCardGroup(_("On-Chain"), self.scroll_content)
+        self.address_card = AddressSettingCard(self.onchain_group)
+        self.onchain_group.addSettingCard(self.address_card)
+
         self.scroll_layout.addWidget(self.alerts_group)
+        self.scroll_layout.addWidget(self.remote_group)
+        self.scroll_layout.addWidget(self.digest_group)
+        self.scroll_layout.addWidget(self.dca_group)
+        self.scroll_layout.addWidget(self.news_group)
+        self.scroll_layout.addWidget(self.onchain_group)
         self.scroll_layout.addStretch(1)
 
         self.scroll.setWidget(self.scroll_content)
         self.layout.addWidget(self.scroll)
+
+    def _test_channel(self, name: str):
+        """Send a test message with the entered, not yet saved, settings."""
+        card, channel = self._channel_cards[name]
+        get_remote_notifier().send_test(channel(card.get_config()))
+
+    def _on_test_finished(self, name: str, error: str):
+        if name in self._channel_cards:
+            self._channel_cards[name][0].show_test_result(error)
+
+    def set_telegram_config(self, config):
+        self.telegram_card.set_config(config)
+
+    def get_telegram_config(self):
+        return self.telegram_card.get_config()
+
+    def set_matrix_config(self, config):
+        self.matrix_card.set_config(config)
+
+    def get_matrix_config(self):
+        return self.matrix_card.get_config()
+
+    def set_wecom_config(self, config):
+        self.wecom_card.set_config(config)
+
+    def get_wecom_config(self):
+        return self.wecom_card.get_config()
+
+    def set_dingtalk_config(self, config):
+        self.dingtalk_card.set_config(config)
+
+    def get_dingtalk_config(self):
+        return self.dingtalk_card.get_config()
+
+    def set_feishu_config(self, config):
+        self.feishu_card.set_config(config)
+
+    def get_feishu_config(self):
+        return self.feishu_card.get_config()
+
+    def set_gotify_config(self, config):
+        self.gotify_card.set_config(config)
+
+    def get_gotify_config(self):
+        return self.gotify_card.get_config()
+
+    def set_xmpp_config(self, config):
+        self.xmpp_card.set_config(config)
+
+    def get_xmpp_config(self):
+        return self.xmpp_card.get_config()
+
+    def set_email_config(self, config):
+        self.email_card.set_config(config)
+
+    def get_email_config(self):
+        return self.email_card.get_config()
@@ -1,8 +1,12 @@
 from PyQt6.QtWidgets import QVBoxLayout, QWidget
 from qfluentwidgets import ScrollArea, SettingCardGroup
 
+from config.settings import get_settings_manager
 from core.i18n import _
+from core.watchlists import get_watchlist_manager
+from ui.widgets.portfolio_setting_card import PortfolioSettingCard
 from ui.widgets.setting_cards import PairsSettingCard
+from ui.widgets.watchlist_setting_card import WatchlistSettingCard
 
 
 class PairsPage(QWidget):
@@ -30,13 +34,25 @@ class PairsPage(QWidget):
         self.pairs_group = SettingCardGroup(_("Trading Pairs"), self.scroll_content)
         self.pairs_card = PairsSettingCard(self.pairs_group)
         self.pairs_group.addSettingCard(self.pairs_card)
+        self.watchlist_card = WatchlistSettingCard(self.pairs_card.get_pairs, self.pairs_group)
+        self.pairs_group.addSettingCard(self.watchlist_card)
+        # (Un)subscribing a group changes the saved pairs; keep the list above in sync
+        get_watchlist_manager().pairs_changed.connect(self._reload_pairs)
+
+        self.portfolio_group = SettingCardGroup(_("Portfolio"), self.scroll_content)
+        self.portfolio_card = PortfolioSettingCard(self.portfolio_group)
+        self.portfolio_group.addSettingCard(self.portfolio_card)
 
         self.scroll_layout.addWidget(self.pairs_group)
+        self.scroll_layout.addWidget(self.portfolio_group)
         self.scroll_layout.addStretch(1)
 
         self.scroll.setWidget(self.scroll_content)
         self.layout.addWidget(self.scroll)
 
+    def _reload_pairs(self):
+        self.set_pairs(get_settings_manager().settings.crypto_pairs)
+
     def set_pairs(self, pairs):
         self.pairs_card.set_pairs(pairs)
 
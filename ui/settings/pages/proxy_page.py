@@ -1,10 +1,22 @@
 from PyQt6.QtCore import pyqtSignal
 from PyQt6.QtWidgets import QVBoxLayout, QWidget
-from qfluentwidgets import ScrollArea, SettingCardGroup
+from qfluentwidgets import FluentIcon, PrimaryPushSettingCard, ScrollArea, SettingCardGroup
 
+from core.cert_pinning import get_cert_pinning_service
 from core.i18n import _
+from core.okx_account import get_okx_account_service
 from ui.widgets.data_source_setting_card import DataSourceSettingCard
-from ui.widgets.setting_cards import ProxySettingCard
+from ui.widgets.diagnostics_dialog import DiagnosticsDialog
+from ui.widgets.network_selftest_dialog import NetworkSelfTestDialog
+from ui.widgets.setting_cards import (
+    CertPinningSettingCard,
+    LocalApiSettingCard,
+    OkxAccountSettingCard,
+    ProxySettingCard,
+    RpcSettingCard,
+    SimulationSettingCard,
+    WsTuningSettingCard,
+)
 
 
 class ProxyPage(QWidget):
@@ -40,12 +52,68 @@ class ProxyPage(QWidget):
         self.data_source_card = DataSourceSettingCard(self.proxy_group)
         self.proxy_group.addSettingCard(self.data_source_card)
 
+        # Simulated data source
+        self.simulation_card = SimulationSettingCard(self.proxy_group)
+        self.proxy_group.addSettingCard(self.simulation_card)
+
         # Proxy
         self.proxy_card = ProxySettingCard(self.proxy_group)
         self.proxy_card.test_requested.connect(self._test_connection)
         self.proxy_group.addSettingCard(self.proxy_card)
 
+        # Certificate pinning
+        self.cert_pinning_card = CertPinningSettingCard(self.proxy_group)
+        self.cert_pinning_card.trust_requested.connect(self._trust_certificates)
+        self.proxy_group.addSettingCard(self.cert_pinning_card)
+        get_cert_pinning_service().pins_updated.connect(self.cert_pinning_card.show_trusted)
+
+        # On-chain RPC
+        self.rpc_card = RpcSettingCard(self.proxy_group)
+        self.proxy_group.addSettingCard(self.rpc_card)
+
+        # WebSocket transport
+        self.ws_tuning_card = WsTuningSettingCard(self.proxy_group)
+        self.proxy_group.addSettingCard(self.ws_tuning_card)
+
+        # Connection diagnostics
+        self.diagnostics_card = PrimaryPushSettingCard(
+            _("Inspect"),
+            FluentIcon.DEVELOPER_TOOLS,
+            _("Connection Diagnostics"),
+            _("Endpoint, proxy, uptime, message rate and ping of each live connection"),
+            self.proxy_group,
+        )
+        self.diagnostics_card.button.clicked.connect(self._open_diagnostics)
+        self.proxy_group.addSettingCard(self.diagnostics_card)
+
+        # Network self-test
+        self.selftest_card = PrimaryPushSettingCard(
+            _("Run Test"),
+            FluentIcon.SPEED_HIGH,
+            _("Network Self-Test"),
+            _("Find out why the connection keeps connecting, using the settings above"),
+            self.proxy_group,
+        )
+        self.selftest_card.button.clicked.connect(self._run_self_test)
+        self.proxy_group.addSettingCard(self.selftest_card)
+
         self.scroll_layout.addWidget(self.proxy_group)
+
+        # Account Group
+        self.account_group = SettingCardGroup(_("Exchange Account"), self.scroll_content)
+        self.okx_account_card = OkxAccountSettingCard(self.account_group)
+        self.okx_account_card.verify_requested.connect(self._verify_okx_key)
+        self.account_group.addSettingCard(self.okx_account_card)
+        account_service = get_okx_account_service()
+        account_service.key_checked.connect(self.okx_account_card.show_key_check)
+        account_service.key_check_failed.connect(self.okx_account_card.show_key_check_error)
+        self.scroll_layout.addWidget(self.account_group)
+
+        # Integrations Group
+        self.integrations_group = SettingCardGroup(_("Integrations"), self.scroll_content)
+        self.local_api_card = LocalApiSettingCard(self.integrations_group)
+        self.integrations_group.addSettingCard(self.local_api_card)
+        self.scroll_layout.addWidget(self.integrations_group)
         self.scroll_layout.addStretch(1)
 
         self.scroll.setWidget(self.scroll_content)
@@ -77,6 +145,25 @@ class ProxyPage(QWidget):
         except Exception as e:
             self.proxy_card.show_test_result(False, f"{_('Unexpected error')}: {str(e)}")
 
+    def _run_self_test(self):
+        """Test the entered data source and proxy, before they're saved."""
+        NetworkSelfTestDialog(
+            self.get_data_source(), self.get_proxy_config(), self.window()
+        ).exec()
+
+    def _open_diagnostics(self):
+        DiagnosticsDialog(self.window()).exec()
+
+    def _trust_certificates(self):
+        """Pin the keys the exchange presents now."""
+        self.cert_pinning_card.set_trusting(True)
+        get_cert_pinning_service().trust_current()
+
+    def _verify_okx_key(self):
+        """Check the entered key's permissions with a test call."""
+        self.okx_account_card.set_verifying(True)
+        get_okx_account_service().verify_key(self.okx_account_card.get_config())
+
     def set_data_source(self, source):
         self.data_source_card.set_data_source(source)
 
@@ -88,3 +175,39 @@ class ProxyPage(QWidget):
 
     def get_proxy_config(self):
         return self.proxy_card.get_proxy_config()
+
+    def set_okx_api_config(self, config):
+        self.okx_account_card.set_config(config)
+
+    def get_okx_api_config(self):
+        return self.okx_account_card.get_config()
+
+    def set_simulation(self, default_volatility, overrides):
+        self.simulation_card.set_values(default_volatility, overrides)
+
+    def get_simulation(self):
+        return self.simulation_card.get_values()
+
+    def set_cert_pinning(self, enabled):
+        self.cert_pinning_card.set_enabled(enabled)
+
+    def get_cert_pinning(self):
+        return self.cert_pinning_card.is_enabled()
+
+    def set_rpc_endpoints(self, endpoints):
+        self.rpc_card.set_endpoints(endpoints)
+
+    def get_rpc_endpoints(self):
+        return self.rpc_card.get_endpoints()
+
+    def set_ws_connections(self, connections):
+        self.ws_tuning_card.set_connections(connections)
+
+    def get_ws_connections(self):
+        return self.ws_tuning_card.get_connections()
+
+    def set_local_api_config(self, config):
+        self.local_api_card.set_config(config)
+
+    def get_local_api_config(self):
+        return self.local_api_card.get_config()
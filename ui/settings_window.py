@@ -27,13 +27,21 @@ from qfluentwidgets import (
     setTheme,
 )
 
+from config.encryption import ENCRYPTION_AVAILABLE
 from config.settings import ProxyConfig, SettingsManager
+from core.autostart import get_autostart_manager
+from core.cert_pinning import get_cert_pinning_service
+from core.history_budget import BYTES_PER_MB, get_history_budget
+from core.hotkeys import get_hotkey_service
 from core.i18n import _
+from core.local_api import get_local_api_server
+from core.telegram_bot import get_telegram_bot
 from ui.settings.pages.about_page import AboutPage
 from ui.settings.pages.appearance_page import AppearancePage
 from ui.settings.pages.notifications_page import NotificationsPage
 from ui.settings.pages.pairs_page import PairsPage
 from ui.settings.pages.proxy_page import ProxyPage
+from ui.widgets.master_password_dialog import MasterPasswordDialog
 
 logger = logging.getLogger(__name__)
 
@@ -51,6 +59,7 @@ class SettingsWindow(QMainWindow):
     data_source_changed = pyqtSignal()
     minimalist_view_changed = pyqtSignal()
     price_change_basis_changed = pyqtSignal()
+    account_changed = pyqtSignal()
 
     def __init__(self, settings_manager: SettingsManager, parent: QWidget | None = None):
         super().__init__(parent)
@@ -199,6 +208,11 @@ class SettingsWindow(QMainWindow):
         self.import_btn.clicked.connect(self._import_settings)
         btn_layout.addWidget(self.import_btn)
 
+        self.password_btn = PushButton(FluentIcon.FINGERPRINT, _("Master Password"))
+        self.password_btn.setEnabled(ENCRYPTION_AVAILABLE)
+        self.password_btn.clicked.connect(self._set_master_password)
+        btn_layout.addWidget(self.password_btn)
+
         btn_layout.addStretch()
 
         self.save_btn = PrimaryPushButton(FluentIcon.SAVE, _("Save"))
@@ -225,6 +239,7 @@ class SettingsWindow(QMainWindow):
         self.appearance_page.display_card.set_display_limit(s.display_limit)
         self.appearance_page.display_card.set_minimalist_view(s.minimalist_view)
         self.appearance_page.display_card.set_auto_scroll(s.auto_scroll, s.scroll_interval)
+        self.appearance_page.display_card.set_update_interval(s.update_interval_ms)
         self.appearance_page.hover_card.set_values(
             s.hover_enabled,
             s.hover_show_stats,
@@ -233,10 +248,44 @@ class SettingsWindow(QMainWindow):
             s.chart_cache_ttl,
         )
         self.appearance_page.display_card.set_price_change_basis(s.price_change_basis)
+        self.appearance_page.display_card.set_fiat_currency(s.fiat_currency)
+        self.appearance_page.display_card.set_number_format(s.number_format)
+        self.appearance_page.history_card.set_values(
+            s.sparkline_max_points, s.candle_max_count, s.history_budget_mb
+        )
+        usage = get_history_budget().usage()
+        self.appearance_page.history_card.set_usage(
+            usage.bytes / BYTES_PER_MB, s.history_budget_mb, usage.buffers
+        )
+        self.appearance_page.hotkey_card.set_values(s.hotkeys_enabled, s.hotkeys)
+        autostart = get_autostart_manager()
+        self.appearance_page.autostart_card.set_supported(autostart.is_supported)
+        self.appearance_page.autostart_card.set_values(
+            autostart.is_enabled(), autostart.is_minimized()
+        )
 
         # Proxy Page
         self.proxy_page.set_data_source(s.data_source)
         self.proxy_page.set_proxy_config(s.proxy)
+        self.proxy_page.set_okx_api_config(s.okx_api)
+        self.proxy_page.set_rpc_endpoints(s.rpc_endpoints)
+        self.proxy_page.set_ws_connections(s.websocket.connections)
+        self.proxy_page.set_cert_pinning(s.cert_pinning)
+        self.proxy_page.set_local_api_config(s.local_api)
+        self.proxy_page.set_simulation(s.simulation_default_volatility, s.simulation_volatility)
+
+        # Notifications Page
+        self.notifications_page.set_telegram_config(s.telegram)
+        self.notifications_page.set_matrix_config(s.matrix)
+        self.notifications_page.set_wecom_config(s.wecom)
+        self.notifications_page.set_dingtalk_config(s.dingtalk)
+        self.notifications_page.set_feishu_config(s.feishu)
+        self.notifications_page.set_gotify_config(s.gotify)
+        self.notifications_page.set_xmpp_config(s.xmpp)
+        self.notifications_page.set_email_config(s.email)
+
+        # About Page
+        self.about_page.set_crash_reports(s.crash_reports)
 
         # Pairs Page
         self.pairs_page.set_pairs(s.crypto_pairs)
@@ -262,16 +311,39 @@ class SettingsWindow(QMainWindow):
         new_limit = self.appearance_page.display_card.get_display_limit()
         new_mini_view = self.appearance_page.display_card.get_minimalist_view()
         new_auto_scroll, new_scroll_int = self.appearance_page.display_card.get_auto_scroll()
+        new_update_interval = self.appearance_page.display_card.get_update_interval()
         new_basis = self.appearance_page.display_card.get_price_change_basis()
+        new_currency = self.appearance_page.display_card.get_fiat_currency()
+        new_number_format = self.appearance_page.display_card.get_number_format()
         hover_vals = self.appearance_page.hover_card.get_values()
+        history_vals = self.appearance_page.history_card.get_values()
+        hotkeys_enabled, hotkeys = self.appearance_page.hotkey_card.get_values()
+        autostart_vals = self.appearance_page.autostart_card.get_values()
 
         # --- Network ---
         new_source = self.proxy_page.get_data_source()
         new_proxy = self.proxy_page.get_proxy_config()
+        new_okx_api = self.proxy_page.get_okx_api_config()
+        new_rpc_endpoints = self.proxy_page.get_rpc_endpoints()
+        new_ws_connections = self.proxy_page.get_ws_connections()
+        new_cert_pinning = self.proxy_page.get_cert_pinning()
+        new_local_api = self.proxy_page.get_local_api_config()
+        new_simulation = self.proxy_page.get_simulation()
+        new_telegram = self.notifications_page.get_telegram_config()
+        new_matrix = self.notifications_page.get_matrix_config()
+        new_wecom = self.notifications_page.get_wecom_config()
+        new_dingtalk = self.notifications_page.get_dingtalk_config()
+        new_feishu = self.notifications_page.get_feishu_config()
+        new_gotify = self.notifications_page.get_gotify_config()
+        new_xmpp = self.notifications_page.get_xmpp_config()
+        new_email = self.notifications_page.get_email_config()
 
         # --- Pairs ---
         new_pairs = self.pairs_page.get_pairs()
 
+        # --- About ---
+        new_crash_reports = self.about_page.get_crash_reports()
+
         # Change detection
         theme_changed = s.theme_mode != new_theme
         lang_changed = s.language != new_lang
@@ -283,6 +355,8 @@ class SettingsWindow(QMainWindow):
             s.scroll_interval != new_scroll_int
         )
         basis_changed = s.price_change_basis != new_basis
+        account_changed = s.okx_api != new_okx_api
+        connections_changed = s.websocket.connections != new_ws_connections
 
         # Updates
         self._settings_manager.update_theme(new_theme)
@@ -292,7 +366,16 @@ class SettingsWindow(QMainWindow):
         self._settings_manager.update_display_limit(new_limit)
         self._settings_manager.update_minimalist_view(new_mini_view)
         self._settings_manager.update_auto_scroll(new_auto_scroll, new_scroll_int)
+        self._settings_manager.update_update_interval(new_update_interval)
         self._settings_manager.update_price_change_basis(new_basis)
+        self._settings_manager.update_fiat_currency(new_currency)
+        self._settings_manager.update_number_format(new_number_format)
+        self._settings_manager.update_history_limits(*history_vals)
+        get_history_budget().enforce()
+        if (hotkeys_enabled, hotkeys) != (s.hotkeys_enabled, s.hotkeys):
+            self._settings_manager.update_hotkeys(hotkeys_enabled, hotkeys)
+            get_hotkey_service().restart()
+        self._apply_autostart(*autostart_vals)
 
         self._settings_manager.update_hover_settings(
             hover_vals["enabled"], hover_vals["show_stats"], hover_vals["show_chart"]
@@ -302,7 +385,36 @@ class SettingsWindow(QMainWindow):
 
         self._settings_manager.update_data_source(new_source)
         self._settings_manager.update_proxy(new_proxy)
+        self._settings_manager.update_okx_api(new_okx_api)
+        self._settings_manager.update_rpc_endpoints(new_rpc_endpoints)
+        if connections_changed:
+            self._settings_manager.update_ws_connections(new_ws_connections)
+        self._settings_manager.update_simulation(*new_simulation)
+        if new_cert_pinning != s.cert_pinning:
+            self._settings_manager.update_cert_pinning(new_cert_pinning)
+            get_cert_pinning_service().check()
+        if new_local_api != s.local_api:
+            self._settings_manager.update_local_api(new_local_api)
+            get_local_api_server().apply_settings()
+        if new_telegram != s.telegram:
+            self._settings_manager.update_telegram(new_telegram)
+            get_telegram_bot().apply_settings()
+        if new_matrix != s.matrix:
+            self._settings_manager.update_matrix(new_matrix)
+        if new_wecom != s.wecom:
+            self._settings_manager.update_wecom(new_wecom)
+        if new_dingtalk != s.dingtalk:
+            self._settings_manager.update_dingtalk(new_dingtalk)
+        if new_feishu != s.feishu:
+            self._settings_manager.update_feishu(new_feishu)
+        if new_gotify != s.gotify:
+            self._settings_manager.update_gotify(new_gotify)
+        if new_xmpp != s.xmpp:
+            self._settings_manager.update_xmpp(new_xmpp)
+        if new_email != s.email:
+            self._settings_manager.update_email(new_email)
         self._settings_manager.update_pairs(new_pairs)
+        self._settings_manager.update_crash_reports(new_crash_reports)
 
         # Notifications
         # AlertSettingCard handles its own saving via internal logic if I recall correctly?
@@ -341,7 +453,8 @@ class SettingsWindow(QMainWindow):
         QTimer.singleShot(100, lambda: self.pairs_changed.emit())
         if theme_changed:
             QTimer.singleShot(100, lambda: self.theme_changed.emit(new_theme))
-        if source_changed:
+        if source_changed or connections_changed:
+            # Reconnecting applies changed transport options
             QTimer.singleShot(100, lambda: self.data_source_changed.emit())
         if dynamic_bg_changed:
             QTimer.singleShot(100, lambda: self.display_changed.emit())
@@ -354,9 +467,20 @@ class SettingsWindow(QMainWindow):
             )
         if basis_changed:
             QTimer.singleShot(100, lambda: self.price_change_basis_changed.emit())
+        if account_changed or connections_changed:
+            QTimer.singleShot(100, lambda: self.account_changed.emit())
         if limit_changed:
             self.display_limit_changed.emit(new_limit)
 
+    def _apply_autostart(self, enabled: bool, minimized: bool):
+        autostart = get_autostart_manager()
+        if not autostart.is_supported:
+            return
+        if (enabled, minimized) == (autostart.is_enabled(), autostart.is_minimized()):
+            return
+        if not (autostart.enable(minimized) if enabled else autostart.disable()):
+            InfoBar.error(_("Error"), _("Failed to update launch at login"), parent=self)
+
     def _reset_settings(self):
         default_proxy = ProxyConfig()
         self.proxy_page.set_proxy_config(default_proxy)
@@ -427,6 +551,21 @@ class SettingsWindow(QMainWindow):
                         parent=self,
                     )
 
+    def _set_master_password(self):
+        has_password = self._settings_manager.has_master_password
+        password = MasterPasswordDialog.get_password(has_password, self)
+        if password is None:
+            return
+        try:
+            self._settings_manager.set_master_password(password)
+        except Exception as e:
+            InfoBar.error(_("Error"), f"{_('Failed to set master password')}: {e}", parent=self)
+            return
+        if password:
+            InfoBar.success(_("Success"), _("Settings are now encrypted"), parent=self)
+        else:
+            InfoBar.success(_("Success"), _("Master password removed"), parent=self)
+
     def _restart_app(self):
         """Restart the application."""
         import sys
@@ -0,0 +1,223 @@
+"""
+Dialog showing the OKX account: equity, margin ratio, balances and positions.
+"""
+
+from PyQt6.QtCore import Qt
+from PyQt6.QtWidgets import (
+    QDialog,
+    QHBoxLayout,
+    QHeaderView,
+    QTableWidgetItem,
+    QVBoxLayout,
+)
+from qfluentwidgets import (
+    BodyLabel,
+    FluentIcon,
+    PrimaryPushButton,
+    PushButton,
+    StrongBodyLabel,
+    SubtitleLabel,
+    TableWidget,
+)
+
+from core.i18n import _
+from core.okx_account import AccountSnapshot, get_okx_account_service
+from core.okx_trading import get_okx_trading_service
+from core.order_monitor import get_order_monitor
+from core.utils import format_price
+
+from .order_dialog import OrderDialog
+
+
+def _fmt(value: float | None) -> str:
+    return format_price(value) if value is not None else "-"
+
+
+class AccountDialog(QDialog):
+    """Read-only view of the OKX account, updated live."""
+
+    def __init__(self, parent=None):
+        super().__init__(parent)
+        self._service = get_okx_account_service()
+        self._trading_service = get_okx_trading_service()
+        self._order_monitor = get_order_monitor()
+        self.setWindowTitle(_("OKX Account"))
+        self.resize(640, 640)
+        self._setup_ui()
+
+        self._service.account_updated.connect(self._update_snapshot)
+        self._service.error_occurred.connect(self._show_error)
+        self._trading_service.order_placed.connect(self._on_order_placed)
+        self._trading_service.error_occurred.connect(self._show_error)
+        self._order_monitor.order_event.connect(self._update_orders)
+        self.finished.connect(self._disconnect_service)
+        self._update_snapshot(self._service.snapshot)
+        self._update_orders()
+
+    def _setup_ui(self):
+        layout = QVBoxLayout(self)
+        layout.setContentsMargins(20, 20, 20, 20)
+        layout.setSpacing(12)
+
+        # Summary
+        header = QHBoxLayout()
+        self.equity_label = SubtitleLabel("-")
+        self.margin_label = BodyLabel("")
+        header.addWidget(self.equity_label)
+        header.addStretch(1)
+        header.addWidget(self.margin_label)
+        self.refresh_btn = PushButton(FluentIcon.SYNC, _("Refresh"))
+        self.refresh_btn.clicked.connect(self._service.refresh)
+        header.addWidget(self.refresh_btn)
+        layout.addLayout(header)
+
+        self.status_label = BodyLabel("")
+        self.status_label.setWordWrap(True)
+        layout.addWidget(self.status_label)
+
+        # Balances
+        layout.addWidget(StrongBodyLabel(_("Balances")))
+        self.balance_table = self._create_table(
+            [_("Currency"), _("Equity"), _("Available"), _("Value (USD)")]
+        )
+        layout.addWidget(self.balance_table, 1)
+
+        # Positions
+        layout.addWidget(StrongBodyLabel(_("Positions")))
+        self.position_table = self._create_table(
+            [
+                _("Instrument"),
+                _("Side"),
+                _("Size"),
+                _("Avg Price"),
+                _("Mark Price"),
+                _("Liq. Price"),
+                _("Unrealized PnL"),
+            ]
+        )
+        layout.addWidget(self.position_table, 1)
+
+        # Open orders (trading only)
+        orders_header = QHBoxLayout()
+        orders_header.addWidget(StrongBodyLabel(_("Open Orders")))
+        orders_header.addStretch(1)
+        self.cancel_order_btn = PushButton(FluentIcon.CLOSE, _("Cancel Order"))
+        self.cancel_order_btn.clicked.connect(self._cancel_selected_order)
+        orders_header.addWidget(self.cancel_order_btn)
+        self.place_order_btn = PrimaryPushButton(FluentIcon.ADD, _("Place Order"))
+        self.place_order_btn.clicked.connect(self._place_order)
+        orders_header.addWidget(self.place_order_btn)
+        layout.addLayout(orders_header)
+
+        self.order_table = self._create_table(
+            [_("Instrument"), _("Side"), _("Type"), _("Size"), _("Filled"), _("Price")]
+        )
+        self.order_table.setSelectionBehavior(TableWidget.SelectionBehavior.SelectRows)
+        layout.addWidget(self.order_table, 1)
+
+        trading = self._trading_service.trading_enabled
+        self.place_order_btn.setVisible(trading)
+        self.cancel_order_btn.setVisible(trading)
+
+    def _create_table(self, headers: list[str]) -> TableWidget:
+        table = TableWidget(self)
+        table.setColumnCount(len(headers))
+        table.setHorizontalHeaderLabels(headers)
+        table.verticalHeader().hide()
+        table.horizontalHeader().setSectionResizeMode(QHeaderView.ResizeMode.Stretch)
+        table.setEditTriggers(TableWidget.EditTrigger.NoEditTriggers)
+        return table
+
+    def _set_row(self, table: TableWidget, row: int, values: list[str]):
+        for col, value in enumerate(values):
+            item = QTableWidgetItem(value)
+            if col > 0:
+                item.setTextAlignment(Qt.AlignmentFlag.AlignRight | Qt.AlignmentFlag.AlignVCenter)
+            table.setItem(row, col, item)
+
+    def _update_snapshot(self, snapshot: AccountSnapshot):
+        if not self._service.is_configured:
+            self.status_label.setText(
+                _("Enable OKX account data and enter your API key in Settings > Network.")
+            )
+        elif snapshot.updated_at == 0:
+            self.status_label.setText(_("Loading account..."))
+        else:
+            self.status_label.setText("")
+
+        self.equity_label.setText(f"{_('Total Equity')}: ${_fmt(snapshot.total_equity_usd)}")
+        if snapshot.margin_ratio is not None:
+            self.margin_label.setText(f"{_('Margin Ratio')}: {snapshot.margin_ratio * 100:.2f}%")
+        else:
+            self.margin_label.setText("")
+
+        self.balance_table.setRowCount(len(snapshot.balances))
+        for row, balance in enumerate(snapshot.balances):
+            self._set_row(
+                self.balance_table,
+                row,
+                [
+                    balance.currency,
+                    _fmt(balance.equity),
+                    _fmt(balance.available),
+                    _fmt(balance.usd_value),
+                ],
+            )
+
+        self.position_table.setRowCount(len(snapshot.positions))
+        for row, pos in enumerate(snapshot.positions):
+            self._set_row(
+                self.position_table,
+                row,
+                [
+                    pos.inst_id,
+                    pos.pos_side,
+                    _fmt(pos.size),
+                    _fmt(pos.avg_price),
+                    _fmt(pos.mark_price),
+                    _fmt(pos.liq_price),
+                    _fmt(pos.unrealized_pnl),
+                ],
+            )
+
+    def _update_orders(self, *args):
+        self._orders = self._order_monitor.get_open_orders()
+        self.order_table.setRowCount(len(self._orders))
+        for row, order in enumerate(self._orders):
+            self._set_row(
+                self.order_table,
+                row,
+                [
+                    order.inst_id,
+                    order.side,
+                    order.order_type,
+                    _fmt(order.size),
+                    _fmt(order.filled_size),
+                    _fmt(order.price),
+                ],
+            )
+
+    def _place_order(self):
+        request = OrderDialog.create_order(parent=self)
+        if request:
+            self.status_label.setText(_("Sending order..."))
+            self._trading_service.place_order(request)
+
+    def _on_order_placed(self, order_id: str, request):
+        self.status_label.setText(f"{_('Order placed')}: {request.inst_id} ({order_id})")
+
+    def _cancel_selected_order(self):
+        row = self.order_table.currentRow()
+        if 0 <= row < len(self._orders):
+            order = self._orders[row]
+            self._trading_service.cancel_order(order.inst_id, order.order_id)
+
+    def _show_error(self, message: str):
+        self.status_label.setText(f"{_('Error')}: {message}")
+
+    def _disconnect_service(self):
+        self._service.account_updated.disconnect(self._update_snapshot)
+        self._service.error_occurred.disconnect(self._show_error)
+        self._trading_service.order_placed.disconnect(self._on_order_placed)
+        self._trading_service.error_occurred.disconnect(self._show_error)
+        self._order_monitor.order_event.disconnect(self._update_orders)
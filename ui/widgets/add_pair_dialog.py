@@ -13,11 +13,39 @@ from PyQt6.QtWidgets import (
     QVBoxLayout,
     QWidget,
 )
-from qfluentwidgets import Dialog, ProgressRing, SearchLineEdit, SegmentedWidget, isDarkTheme
+from qfluentwidgets import (
+    CheckBox,
+    ComboBox,
+    Dialog,
+    LineEdit,
+    PlainTextEdit,
+    ProgressRing,
+    PushButton,
+    SearchLineEdit,
+    SegmentedWidget,
+    isDarkTheme,
+)
 
 from config.settings import get_settings_manager
+from core.chainlink_client import make_oracle_pair
+from core.coingecko import TrendingCoin, get_coingecko_service
 from core.i18n import _
+from core.market_indices import (
+    ALT_MCAP_PAIR,
+    BTC_DOMINANCE_PAIR,
+    BTC_FEE_PAIR,
+    ETH_GAS_PAIR,
+    ETH_STAKING_PAIR,
+    FEAR_GREED_PAIR,
+    HASHPRICE_PAIR,
+    TOTAL_MCAP_PAIR,
+    USDC_SUPPLY_PAIR,
+    USDT_SUPPLY_PAIR,
+)
+from core.pair_import import resolve_pair_list
+from core.pool_client import DEFAULT_RPC_URLS, make_pool_pair
 from core.symbol_search import SymbolInfo, get_symbol_search_service
+from core.virtual_pairs import VirtualExpression, make_virtual_pair
 
 logger = logging.getLogger(__name__)
 
@@ -26,12 +54,14 @@ class AddPairDialog(Dialog):
     def __init__(self, data_source: str = "OKX", parent: QWidget | None = None):
         super().__init__(title=_("Add Trading Pair"), content="", parent=parent)
         self._pair: str | None = None
+        self._bulk_pairs: list[str] = []
         self._data_source = data_source
         self._search_service = get_symbol_search_service()
         self._search_timer = QTimer()
         self._search_timer.setSingleShot(True)
         self._search_timer.timeout.connect(self._do_search)
         self._updating_from_selection = False
+        self._trending: list[TrendingCoin] | None = None
 
         self._dex_manager = QNetworkAccessManager(self)
         self._dex_manager.finished.connect(self._on_dex_response)
@@ -57,6 +87,8 @@ class AddPairDialog(Dialog):
 
         self._search_service.load_symbols(self._data_source)
 
+        get_coingecko_service().trending_updated.connect(self._on_trending_updated)
+
     def _configure_proxy(self):
         settings = get_settings_manager().settings
         if settings.proxy.enabled:
@@ -89,6 +121,9 @@ class AddPairDialog(Dialog):
         self.segment = SegmentedWidget()
         self.segment.addItem("cex", _("Exchange (CEX)"))
         self.segment.addItem("dex", _("On-Chain (DEX)"))
+        self.segment.addItem("virtual", _("Virtual"))
+        self.segment.addItem("trending", _("Trending"))
+        self.segment.addItem("bulk", _("Bulk"))
         self.segment.setCurrentItem("cex")
         self.segment.currentItemChanged.connect(self._on_tab_changed)
         main_layout.addWidget(self.segment)
@@ -104,6 +139,18 @@ class AddPairDialog(Dialog):
         self._setup_dex_tab(self.dex_widget)
         self.stack.addWidget(self.dex_widget)
 
+        self.virtual_widget = QWidget()
+        self._setup_virtual_tab(self.virtual_widget)
+        self.stack.addWidget(self.virtual_widget)
+
+        self.trending_widget = QWidget()
+        self._setup_trending_tab(self.trending_widget)
+        self.stack.addWidget(self.trending_widget)
+
+        self.bulk_widget = QWidget()
+        self._setup_bulk_tab(self.bulk_widget)
+        self.stack.addWidget(self.bulk_widget)
+
         self.textLayout.addLayout(main_layout)
 
         self.yesButton.setText(_("Add"))
@@ -112,9 +159,20 @@ class AddPairDialog(Dialog):
         self.yesButton.clicked.connect(self._on_confirm)
 
     def _on_tab_changed(self, key: str):
-        self.stack.setCurrentIndex(0 if key == "cex" else 1)
+        self.stack.setCurrentIndex(
+            {"cex": 0, "dex": 1, "virtual": 2, "trending": 3, "bulk": 4}[key]
+        )
         self.yesButton.setEnabled(False)
         self._pair = None
+        self._bulk_pairs = []
+        if key == "virtual":
+            self._validate_virtual_pair()
+        elif key == "bulk":
+            self._resolve_bulk_pairs()
+        elif key == "trending" and self._trending is None:
+            self.trending_spinner.setVisible(True)
+            self.trending_status.setText(_("Loading trending coins..."))
+            get_coingecko_service().load_trending()
 
     def _setup_cex_tab(self, parent_widget):
         layout = QVBoxLayout(parent_widget)
@@ -187,7 +245,7 @@ class AddPairDialog(Dialog):
         layout.addLayout(input_row)
 
         self.dex_results = QListWidget()
-        self.dex_results.setFixedHeight(200)
+        self.dex_results.setFixedHeight(160)
         self.dex_results.itemClicked.connect(self._on_dex_item_clicked)
         self.dex_results.itemDoubleClicked.connect(self._on_item_double_clicked)
         self._style_list_widget(self.dex_results)
@@ -198,8 +256,308 @@ class AddPairDialog(Dialog):
         self.dex_status.setAlignment(Qt.AlignmentFlag.AlignCenter)
         layout.addWidget(self.dex_status)
 
+        pool_label = QLabel(_("Or read a price on-chain:"))
+        pool_label.setStyleSheet("font-size: 14px;")
+        layout.addWidget(pool_label)
+
+        pool_row = QHBoxLayout()
+        pool_row.setSpacing(8)
+        self.onchain_type_combo = ComboBox()
+        self.onchain_type_combo.addItem(_("Uniswap v3 Pool"), userData="pool")
+        self.onchain_type_combo.addItem(_("Chainlink Feed"), userData="oracle")
+        self.onchain_type_combo.currentIndexChanged.connect(self._on_onchain_type_changed)
+        pool_row.addWidget(self.onchain_type_combo)
+        self.pool_network_combo = ComboBox()
+        for network in DEFAULT_RPC_URLS:
+            self.pool_network_combo.addItem(network.title(), userData=network)
+        pool_row.addWidget(self.pool_network_combo)
+        self.pool_address_input = LineEdit()
+        self.pool_address_input.setPlaceholderText(_("Pool address (0x...)"))
+        self.pool_address_input.textChanged.connect(self._validate_pool_address)
+        pool_row.addWidget(self.pool_address_input, 1)
+        layout.addLayout(pool_row)
+
+        pool_options_row = QHBoxLayout()
+        self.pool_inverse_check = CheckBox(_("Invert price"))
+        self.pool_inverse_check.setToolTip(_("Quote the pool's second token in the first"))
+        pool_options_row.addWidget(self.pool_inverse_check)
+        pool_options_row.addStretch()
+        self.pool_add_btn = PushButton(_("Add Pool"))
+        self.pool_add_btn.setEnabled(False)
+        self.pool_add_btn.clicked.connect(self._add_pool)
+        pool_options_row.addWidget(self.pool_add_btn)
+        layout.addLayout(pool_options_row)
+
+        layout.addStretch()
+
+    def _on_onchain_type_changed(self, _index: int):
+        is_pool = self.onchain_type_combo.currentData() == "pool"
+        self.pool_address_input.setPlaceholderText(
+            _("Pool address (0x...)") if is_pool else _("Feed address (0x...)")
+        )
+        # Oracle feeds already quote the asset in the feed's currency
+        self.pool_inverse_check.setVisible(is_pool)
+        self.pool_add_btn.setText(_("Add Pool") if is_pool else _("Add Feed"))
+
+    def _validate_pool_address(self, text: str):
+        self.pool_add_btn.setEnabled(bool(re.fullmatch(r"0x[0-9a-fA-F]{40}", text.strip())))
+
+    def _add_pool(self):
+        network = self.pool_network_combo.currentData()
+        address = self.pool_address_input.text()
+        if self.onchain_type_combo.currentData() == "oracle":
+            self._pair = make_oracle_pair(network, address)
+        else:
+            self._pair = make_pool_pair(network, address, self.pool_inverse_check.isChecked())
+        self.accept()
+
+    def _setup_virtual_tab(self, parent_widget):
+        layout = QVBoxLayout(parent_widget)
+        layout.setContentsMargins(0, 0, 0, 0)
+        layout.setSpacing(12)
+
+        label = QLabel(_("Compute a ticker from other pairs:"))
+        label.setStyleSheet("font-size: 14px;")
+        layout.addWidget(label)
+
+        self.virtual_name_input = LineEdit()
+        self.virtual_name_input.setPlaceholderText(_("Name (e.g., ETH/BTC)"))
+        self.virtual_name_input.setFixedHeight(36)
+        self.virtual_name_input.textChanged.connect(self._validate_virtual_pair)
+        layout.addWidget(self.virtual_name_input)
+
+        self.virtual_expr_input = LineEdit()
+        self.virtual_expr_input.setPlaceholderText(_("Expression (e.g., ETH-USDT / BTC-USDT)"))
+        self.virtual_expr_input.setFixedHeight(36)
+        self.virtual_expr_input.textChanged.connect(self._validate_virtual_pair)
+        self.virtual_expr_input.returnPressed.connect(self._on_confirm)
+        layout.addWidget(self.virtual_expr_input)
+
+        hint = QLabel(
+            _(
+                "Use + - * / and parentheses, or avg(), min(), max() and sum() for baskets. "
+                "Put spaces around minus signs between pairs."
+            )
+        )
+        hint.setWordWrap(True)
+        hint.setStyleSheet("color: #888; font-size: 12px;")
+        layout.addWidget(hint)
+
+        self.virtual_error = QLabel()
+        self.virtual_error.setStyleSheet("color: #D13438; font-size: 12px;")
+        self.virtual_error.setVisible(False)
+        layout.addWidget(self.virtual_error)
+
+        index_label = QLabel(_("Or add a market index:"))
+        index_label.setStyleSheet("font-size: 14px;")
+        layout.addWidget(index_label)
+
+        index_row = QHBoxLayout()
+        index_row.setSpacing(8)
+        fear_greed_btn = PushButton(_("Fear & Greed Index"))
+        fear_greed_btn.clicked.connect(lambda: self._add_index(FEAR_GREED_PAIR))
+        index_row.addWidget(fear_greed_btn)
+        btc_fee_btn = PushButton(_("BTC Fee (sat/vB)"))
+        btc_fee_btn.clicked.connect(lambda: self._add_index(BTC_FEE_PAIR))
+        index_row.addWidget(btc_fee_btn)
+        eth_gas_btn = PushButton(_("ETH Gas (gwei)"))
+        eth_gas_btn.clicked.connect(lambda: self._add_index(ETH_GAS_PAIR))
+        index_row.addWidget(eth_gas_btn)
+        index_row.addStretch()
+        layout.addLayout(index_row)
+
+        metrics_row = QHBoxLayout()
+        metrics_row.setSpacing(8)
+        for pair, text in (
+            (BTC_DOMINANCE_PAIR, _("BTC Dominance")),
+            (TOTAL_MCAP_PAIR, _("Total Market Cap")),
+            (ALT_MCAP_PAIR, _("Altcoin Market Cap")),
+        ):
+            button = PushButton(text)
+            button.clicked.connect(lambda _checked, p=pair: self._add_index(p))
+            metrics_row.addWidget(button)
+        metrics_row.addStretch()
+        layout.addLayout(metrics_row)
+
+        supply_row = QHBoxLayout()
+        supply_row.setSpacing(8)
+        for pair, text in (
+            (USDT_SUPPLY_PAIR, _("USDT Supply")),
+            (USDC_SUPPLY_PAIR, _("USDC Supply")),
+            (HASHPRICE_PAIR, _("Hashprice ($/PH/day)")),
+            (ETH_STAKING_PAIR, _("ETH Staking APR")),
+        ):
+            button = PushButton(text)
+            button.clicked.connect(lambda _checked, p=pair: self._add_index(p))
+            supply_row.addWidget(button)
+        supply_row.addStretch()
+        layout.addLayout(supply_row)
+
+        layout.addStretch()
+
+    def _add_index(self, pair: str):
+        self._pair = pair
+        self.accept()
+
+    def _setup_trending_tab(self, parent_widget):
+        layout = QVBoxLayout(parent_widget)
+        layout.setContentsMargins(0, 0, 0, 0)
+        layout.setSpacing(12)
+
+        label_row = QHBoxLayout()
+        label = QLabel(_("Most searched coins on CoinGecko (24h):"))
+        label.setStyleSheet("font-size: 14px;")
+        label_row.addWidget(label)
+        label_row.addStretch()
+        self.trending_spinner = ProgressRing()
+        self.trending_spinner.setFixedSize(24, 24)
+        self.trending_spinner.setVisible(False)
+        label_row.addWidget(self.trending_spinner)
+        layout.addLayout(label_row)
+
+        self.trending_results = QListWidget()
+        self.trending_results.setFixedHeight(300)
+        self.trending_results.itemClicked.connect(self._on_trending_item_clicked)
+        self.trending_results.itemDoubleClicked.connect(self._on_item_double_clicked)
+        self._style_list_widget(self.trending_results)
+        layout.addWidget(self.trending_results)
+
+        self.trending_status = QLabel()
+        self.trending_status.setStyleSheet("color: #888; font-size: 12px;")
+        self.trending_status.setAlignment(Qt.AlignmentFlag.AlignCenter)
+        layout.addWidget(self.trending_status)
+
+        layout.addStretch()
+
+    def _setup_bulk_tab(self, parent_widget):
+        layout = QVBoxLayout(parent_widget)
+        layout.setContentsMargins(0, 0, 0, 0)
+        layout.setSpacing(12)
+
+        label_row = QHBoxLayout()
+        label = QLabel(_("Paste symbols, one per line or comma separated:"))
+        label.setStyleSheet("font-size: 14px;")
+        label_row.addWidget(label)
+        label_row.addStretch()
+        self.bulk_file_btn = PushButton(_("Load File..."))
+        self.bulk_file_btn.clicked.connect(self._load_bulk_file)
+        label_row.addWidget(self.bulk_file_btn)
+        layout.addLayout(label_row)
+
+        self.bulk_input = PlainTextEdit()
+        self.bulk_input.setPlaceholderText("BTC-USDT\nETHUSDT\nsol/usdc, DOGE")
+        self.bulk_input.setFixedHeight(260)
+        self.bulk_input.textChanged.connect(self._resolve_bulk_pairs)
+        layout.addWidget(self.bulk_input)
+
+        self.bulk_status = QLabel()
+        self.bulk_status.setStyleSheet("color: #888; font-size: 12px;")
+        self.bulk_status.setWordWrap(True)
+        layout.addWidget(self.bulk_status)
+
+        self.bulk_rejected_label = QLabel()
+        self.bulk_rejected_label.setStyleSheet("color: #D13438; font-size: 12px;")
+        self.bulk_rejected_label.setWordWrap(True)
+        self.bulk_rejected_label.setVisible(False)
+        layout.addWidget(self.bulk_rejected_label)
+
         layout.addStretch()
 
+    def _load_bulk_file(self):
+        from PyQt6.QtWidgets import QFileDialog
+
+        filepath, _filter = QFileDialog.getOpenFileName(
+            self, _("Load Pair List"), "", "Text Files (*.txt *.csv);;All Files (*)"
+        )
+        if not filepath:
+            return
+        try:
+            with open(filepath, encoding="utf-8") as f:
+                self.bulk_input.setPlainText(f.read())
+        except (OSError, UnicodeDecodeError) as e:
+            logger.error(f"Failed to read pair list {filepath}: {e}")
+            self.bulk_status.setText(_("Failed to read file"))
+
+    def _resolve_bulk_pairs(self):
+        if self.segment.currentItem() != "bulk":
+            return
+        result = resolve_pair_list(
+            self.bulk_input.toPlainText(),
+            self._search_service,
+            get_settings_manager().settings.crypto_pairs,
+        )
+        self._bulk_pairs = result.valid
+
+        if self._search_service.symbols_count == 0:
+            self.bulk_status.setText(_("Loading symbols..."))
+        else:
+            self.bulk_status.setText(
+                _("{valid} new pairs, {duplicates} already added").format(
+                    valid=len(result.valid), duplicates=len(result.duplicates)
+                )
+            )
+        self.bulk_rejected_label.setText(
+            _("Not listed on {source}: {symbols}").format(
+                source=self._data_source, symbols=", ".join(result.rejected)
+            )
+        )
+        self.bulk_rejected_label.setVisible(bool(result.rejected))
+        self.yesButton.setEnabled(bool(self._bulk_pairs))
+
+    def _on_trending_updated(self, coins: list[TrendingCoin]):
+        self._trending = coins
+        self.trending_spinner.setVisible(False)
+        self._populate_trending()
+
+    def _populate_trending(self):
+        """List the trending coins with the exchange pair each one would add."""
+        self.trending_results.clear()
+        if not self._trending:
+            self.trending_status.setText(_("Failed to load trending coins"))
+            return
+
+        watched = get_settings_manager().settings.crypto_pairs
+        for coin in self._trending:
+            pair = self._search_service.find_pair(coin.symbol)
+            text = f"{coin.symbol}  ·  {coin.name}"
+            if coin.market_cap_rank:
+                text += f"  #{coin.market_cap_rank}"
+            if coin.price_change_pct_24h is not None:
+                text += f"  {coin.price_change_pct_24h:+.2f}%"
+            item = QListWidgetItem(text)
+            if pair is None:
+                item.setFlags(item.flags() & ~Qt.ItemFlag.ItemIsEnabled)
+                item.setToolTip(_("Not listed on {source}").format(source=self._data_source))
+            elif pair in watched:
+                item.setFlags(item.flags() & ~Qt.ItemFlag.ItemIsEnabled)
+                item.setToolTip(_("{pair} is already in the watchlist").format(pair=pair))
+            else:
+                item.setData(Qt.ItemDataRole.UserRole, pair)
+                item.setToolTip(_("Adds {pair}").format(pair=pair))
+            self.trending_results.addItem(item)
+        self.trending_status.setText(_("Double-click a coin to add it"))
+
+    def _on_trending_item_clicked(self, item: QListWidgetItem):
+        pair = item.data(Qt.ItemDataRole.UserRole)
+        if pair:
+            self._pair = pair
+            self.yesButton.setEnabled(True)
+
+    def _validate_virtual_pair(self):
+        name = self.virtual_name_input.text().strip()
+        expression = self.virtual_expr_input.text().strip()
+        self._pair = None
+        self.virtual_error.setVisible(False)
+        if name and expression:
+            try:
+                VirtualExpression(expression)
+            except ValueError as e:
+                self.virtual_error.setText(str(e))
+                self.virtual_error.setVisible(True)
+            else:
+                self._pair = make_virtual_pair(name, expression)
+        self.yesButton.setEnabled(self._pair is not None)
+
     def _style_list_widget(self, widget: QListWidget):
         is_dark = isDarkTheme()
         bg_color = "#2d2d2d" if is_dark else "#ffffff"
@@ -240,6 +598,10 @@ class AddPairDialog(Dialog):
         self.loading_spinner.setVisible(False)
         self.status_label.setText(_("{count} symbols available").format(count=len(symbols)))
         self._do_search()
+        if self._trending:
+            # Exchange pairs of the trending coins are known now
+            self._populate_trending()
+        self._resolve_bulk_pairs()
 
     def _on_loading_error(self, error: str):
         self.loading_spinner.setVisible(False)
@@ -422,8 +784,11 @@ class AddPairDialog(Dialog):
             self.yesButton.setEnabled(True)
 
     def _on_item_double_clicked(self, item: QListWidgetItem):
-        if self.segment.currentItem() == "cex":
+        segment = self.segment.currentItem()
+        if segment == "cex":
             self._on_item_clicked(item)
+        elif segment == "trending":
+            self._on_trending_item_clicked(item)
         else:
             self._on_dex_item_clicked(item)
         self._on_confirm()
@@ -451,15 +816,26 @@ class AddPairDialog(Dialog):
             self._on_confirm()
 
     def _on_confirm(self):
-        if self._pair:
+        if self._pair or self._bulk_pairs:
             self.accept()
 
     def get_pair(self) -> str | None:
         return self._pair
 
+    def get_pairs(self) -> list[str]:
+        if self._bulk_pairs:
+            return list(self._bulk_pairs)
+        return [self._pair] if self._pair else []
+
     def keyPressEvent(self, event):
+        if self.segment.currentItem() == "bulk":
+            super().keyPressEvent(event)
+            return
         key = event.key()
-        active_list = self.results_list if self.segment.currentItem() == "cex" else self.dex_results
+        active_list = {
+            "cex": self.results_list,
+            "trending": self.trending_results,
+        }.get(self.segment.currentItem(), self.dex_results)
 
         if key == Qt.Key.Key_Down:
             current = active_list.currentRow()
@@ -485,3 +861,11 @@ class AddPairDialog(Dialog):
         if dialog.exec():
             return dialog.get_pair()
         return None
+
+    @staticmethod
+    def get_new_pairs(data_source: str = "OKX", parent: QWidget | None = None) -> list[str]:
+        """Show the dialog and return the pairs to add: one, or a pasted list."""
+        dialog = AddPairDialog(data_source, parent)
+        if dialog.exec():
+            return dialog.get_pairs()
+        return []
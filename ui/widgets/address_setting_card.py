@@ -0,0 +1,162 @@
+"""
+Setting card for watched whale addresses.
+"""
+
+from PyQt6.QtCore import Qt, QUrl
+from PyQt6.QtGui import QDesktopServices
+from PyQt6.QtWidgets import QHBoxLayout, QListWidgetItem, QVBoxLayout, QWidget
+from qfluentwidgets import (
+    ComboBox,
+    DoubleSpinBox,
+    ExpandGroupSettingCard,
+    FluentIcon,
+    LineEdit,
+    PrimaryPushButton,
+    PushButton,
+)
+from qfluentwidgets import ListWidget as FluentListWidget
+
+from config.settings import get_settings_manager
+from core.address_watcher import (
+    ADDRESS_URLS,
+    DEFAULT_THRESHOLDS,
+    SUPPORTED_CHAINS,
+    get_address_watcher,
+    is_valid_address,
+)
+from core.i18n import _
+
+
+class AddressSettingCard(ExpandGroupSettingCard):
+    """Manages the BTC/ETH addresses watched for large transfers."""
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.VIEW,
+            _("Whale Addresses"),
+            _("Notify on large transfers into or out of these addresses"),
+            parent,
+        )
+        self._settings_manager = get_settings_manager()
+
+        self._setup_ui()
+        self._load_addresses()
+
+    def _setup_ui(self):
+        container = QWidget()
+        layout = QVBoxLayout(container)
+        layout.setContentsMargins(48, 18, 48, 18)
+        layout.setSpacing(16)
+
+        self.addresses_list = FluentListWidget()
+        self.addresses_list.setSelectionMode(FluentListWidget.SelectionMode.SingleSelection)
+        self.addresses_list.setMinimumHeight(120)
+        self.addresses_list.setMaximumHeight(240)
+        self.addresses_list.itemSelectionChanged.connect(self._on_selection_changed)
+        self.addresses_list.itemDoubleClicked.connect(self._open_in_explorer)
+        layout.addWidget(self.addresses_list)
+
+        input_layout = QHBoxLayout()
+        self.chain_combo = ComboBox()
+        for chain in SUPPORTED_CHAINS:
+            self.chain_combo.addItem(chain, userData=chain)
+        self.chain_combo.currentIndexChanged.connect(self._on_chain_changed)
+        input_layout.addWidget(self.chain_combo)
+
+        self.address_edit = LineEdit()
+        self.address_edit.setPlaceholderText(_("Address"))
+        self.address_edit.textChanged.connect(self._validate)
+        input_layout.addWidget(self.address_edit, 1)
+
+        self.label_edit = LineEdit()
+        self.label_edit.setPlaceholderText(_("Label (optional)"))
+        self.label_edit.setFixedWidth(140)
+        input_layout.addWidget(self.label_edit)
+        layout.addLayout(input_layout)
+
+        button_layout = QHBoxLayout()
+        self.threshold_spin = DoubleSpinBox()
+        self.threshold_spin.setRange(0.01, 1_000_000.0)
+        self.threshold_spin.setDecimals(2)
+        self.threshold_spin.setToolTip(_("Minimum transfer size to notify about"))
+        button_layout.addWidget(self.threshold_spin)
+        button_layout.addStretch(1)
+
+        self.add_btn = PrimaryPushButton(FluentIcon.ADD, _("Add"))
+        self.add_btn.setFixedWidth(100)
+        self.add_btn.setEnabled(False)
+        self.add_btn.clicked.connect(self._add_address)
+        button_layout.addWidget(self.add_btn)
+
+        self.remove_btn = PushButton(FluentIcon.DELETE, _("Delete"))
+        self.remove_btn.setFixedWidth(100)
+        self.remove_btn.setEnabled(False)
+        self.remove_btn.clicked.connect(self._remove_address)
+        button_layout.addWidget(self.remove_btn)
+        layout.addLayout(button_layout)
+
+        self._on_chain_changed()
+        self.addGroupWidget(container)
+
+    def _load_addresses(self):
+        self.addresses_list.clear()
+        for index, entry in enumerate(self._settings_manager.settings.watched_addresses):
+            chain = entry.get("chain", "")
+            address = entry.get("address", "")
+            name = entry.get("label") or f"{address[:8]}...{address[-6:]}"
+            threshold = entry.get("threshold", DEFAULT_THRESHOLDS.get(chain, 0.0))
+            item = QListWidgetItem(f"{chain} · {name} (≥ {threshold:g} {chain})")
+            item.setToolTip(address)
+            item.setData(Qt.ItemDataRole.UserRole, index)
+            self.addresses_list.addItem(item)
+
+    def _on_chain_changed(self, _index: int = 0):
+        chain = self.chain_combo.currentData()
+        self.threshold_spin.setSuffix(f" {chain}")
+        self.threshold_spin.setValue(DEFAULT_THRESHOLDS.get(chain, 1.0))
+        self._validate()
+
+    def _validate(self):
+        chain = self.chain_combo.currentData()
+        self.add_btn.setEnabled(is_valid_address(chain, self.address_edit.text().strip()))
+
+    def _on_selection_changed(self):
+        self.remove_btn.setEnabled(self.addresses_list.currentItem() is not None)
+
+    def _open_in_explorer(self, item: QListWidgetItem):
+        entry = self._settings_manager.settings.watched_addresses[
+            item.data(Qt.ItemDataRole.UserRole)
+        ]
+        base_url = ADDRESS_URLS.get(entry.get("chain", ""))
+        if base_url:
+            QDesktopServices.openUrl(QUrl(f"{base_url}{entry.get('address', '')}"))
+
+    def _add_address(self):
+        chain = self.chain_combo.currentData()
+        address = self.address_edit.text().strip()
+        if not is_valid_address(chain, address):
+            return
+        addresses = self._settings_manager.settings.watched_addresses
+        if any(a.get("chain") == chain and a.get("address") == address for a in addresses):
+            return
+        entry = {
+            "chain": chain,
+            "address": address,
+            "label": self.label_edit.text().strip(),
+            "threshold": self.threshold_spin.value(),
+        }
+        self._settings_manager.update_watched_addresses(addresses + [entry])
+        self.address_edit.clear()
+        self.label_edit.clear()
+        self._load_addresses()
+        get_address_watcher().refresh()
+
+    def _remove_address(self):
+        item = self.addresses_list.currentItem()
+        if item is None:
+            return
+        index = item.data(Qt.ItemDataRole.UserRole)
+        addresses = list(self._settings_manager.settings.watched_addresses)
+        del addresses[index]
+        self._settings_manager.update_watched_addresses(addresses)
+        self._load_addresses()
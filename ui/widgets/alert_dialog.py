@@ -6,15 +6,24 @@ from PyQt6.QtCore import Qt
 from PyQt6.QtWidgets import QHBoxLayout, QLabel, QVBoxLayout, QWidget
 from qfluentwidgets import (
     BodyLabel,
+    CheckBox,
     ComboBox,
     Dialog,
     LineEdit,
+    PushButton,
     RadioButton,
     SpinBox,
 )
 
-from config.settings import PriceAlert, get_settings_manager
+from config.settings import EscalationStep, PriceAlert, get_settings_manager
+from core.alert_backtest import BACKTEST_ALERT_TYPES, PREVIEW_DAYS, AlertBacktester
+from core.alert_manager import CANDLE_CLOSE_ALERT_TYPES, MA_CROSS_ALERT_TYPES
 from core.i18n import _
+from core.indicators import INTERVAL_SECONDS
+from core.message_template import ALERT_VARIABLES
+from core.notification_channels import CHANNEL_TYPES
+
+ESCALATION_STEPS = 3  # Rows offered in the dialog
 
 
 class AlertDialog(Dialog):
@@ -42,7 +51,7 @@ class AlertDialog(Dialog):
         self._load_edit_values()
 
         # Set dialog size - Increased height to accommodate new radio buttons
-        self.setFixedSize(420, 520)
+        self.setFixedSize(420, 970)
         # Set window flags
         flags = (
             Qt.WindowType.Dialog
@@ -112,6 +121,13 @@ class AlertDialog(Dialog):
         self.type_touch = RadioButton(_("Price touches target"))
         self.type_multiple = RadioButton(_("Price hits multiple of (Step)"))
         self.type_change = RadioButton(_("24h Change hits multiple of (Step %)"))
+        self.type_ladder = RadioButton(_("Price moves by a step (Ladder %)"))
+        self.type_trailing_high = RadioButton(_("Price drops from its high (Trailing %)"))
+        self.type_trailing_low = RadioButton(_("Price rebounds from its low (Trailing %)"))
+        self.type_rsi_above = RadioButton(_("RSI (1h) rises above level"))
+        self.type_rsi_below = RadioButton(_("RSI (1h) falls below level"))
+        self.type_ma_cross = RadioButton(_("Moving average cross"))
+        self.type_bollinger = RadioButton(_("Price closes outside Bollinger Bands"))
 
         self.type_above.setChecked(True)
         self.type_above.toggled.connect(self._on_type_changed)
@@ -119,16 +135,62 @@ class AlertDialog(Dialog):
         self.type_touch.toggled.connect(self._on_type_changed)
         self.type_multiple.toggled.connect(self._on_type_changed)
         self.type_change.toggled.connect(self._on_type_changed)
+        self.type_ladder.toggled.connect(self._on_type_changed)
+        self.type_trailing_high.toggled.connect(self._on_type_changed)
+        self.type_trailing_low.toggled.connect(self._on_type_changed)
+        self.type_rsi_above.toggled.connect(self._on_type_changed)
+        self.type_rsi_below.toggled.connect(self._on_type_changed)
+        self.type_ma_cross.toggled.connect(self._on_type_changed)
+        self.type_bollinger.toggled.connect(self._on_type_changed)
 
         type_layout.addWidget(self.type_above)
         type_layout.addWidget(self.type_below)
         type_layout.addWidget(self.type_touch)
         type_layout.addWidget(self.type_multiple)
         type_layout.addWidget(self.type_change)
+        type_layout.addWidget(self.type_ladder)
+        type_layout.addWidget(self.type_trailing_high)
+        type_layout.addWidget(self.type_trailing_low)
+        type_layout.addWidget(self.type_rsi_above)
+        type_layout.addWidget(self.type_rsi_below)
+        type_layout.addWidget(self.type_ma_cross)
+        type_layout.addWidget(self.type_bollinger)
         content_layout.addWidget(type_container)
 
+        # Candle options (moving average cross, Bollinger Bands, candle-close price alerts)
+        self.signal_container = QWidget()
+        signal_layout = QVBoxLayout(self.signal_container)
+        signal_layout.setContentsMargins(0, 0, 0, 0)
+        signal_layout.setSpacing(8)
+
+        self.cross_combo = ComboBox()
+        self.cross_combo.addItem(_("Golden cross (EMA 50 over 200)"), userData="golden_cross")
+        self.cross_combo.addItem(_("Death cross (EMA 50 under 200)"), userData="death_cross")
+        self.cross_combo.addItem(_("Price crosses above EMA"), userData="ema_cross_above")
+        self.cross_combo.addItem(_("Price crosses below EMA"), userData="ema_cross_below")
+        self.cross_combo.currentIndexChanged.connect(self._on_type_changed)
+        self.cross_row = self._labeled_row(_("Cross:"), self.cross_combo)
+        signal_layout.addWidget(self.cross_row)
+
+        self.timeframe_combo = ComboBox()
+        for interval in INTERVAL_SECONDS:
+            self.timeframe_combo.addItem(interval, userData=interval)
+        self.timeframe_combo.setCurrentIndex(self.timeframe_combo.findData("1h"))
+        signal_layout.addWidget(self._labeled_row(_("Timeframe:"), self.timeframe_combo))
+
+        self.ema_spin = SpinBox()
+        self.ema_spin.setRange(2, 200)
+        self.ema_spin.setValue(20)
+        self.ema_row = self._labeled_row(_("EMA Period:"), self.ema_spin)
+        signal_layout.addWidget(self.ema_row)
+
+        self.signal_container.setVisible(False)
+        content_layout.addWidget(self.signal_container)
+
         # Target price input
-        price_layout = QHBoxLayout()
+        self.price_container = QWidget()
+        price_layout = QHBoxLayout(self.price_container)
+        price_layout.setContentsMargins(0, 0, 0, 0)
         self.price_label = BodyLabel(_("Target Price:"))
         self.price_label.setFixedWidth(100)
         self.price_input = LineEdit()
@@ -138,7 +200,12 @@ class AlertDialog(Dialog):
         self.price_input.textChanged.connect(self._validate_input)
         price_layout.addWidget(self.price_label)
         price_layout.addWidget(self.price_input, 1)
-        content_layout.addLayout(price_layout)
+        content_layout.addWidget(self.price_container)
+
+        # Price alerts can wait for the candle close, so wicks don't fire them
+        self.close_check = CheckBox(_("Only on candle close"))
+        self.close_check.toggled.connect(self._on_type_changed)
+        content_layout.addWidget(self.close_check)
 
         # Repeat mode selection
         mode_label = BodyLabel(_("Reminder Mode:"))
@@ -173,6 +240,60 @@ class AlertDialog(Dialog):
         mode_layout.addLayout(repeat_layout)
         content_layout.addWidget(mode_container)
 
+        # Backtest preview: how often the alert would have fired recently
+        self.preview_container = QWidget()
+        preview_layout = QHBoxLayout(self.preview_container)
+        preview_layout.setContentsMargins(0, 0, 0, 0)
+        self.preview_days_combo = ComboBox()
+        for days in PREVIEW_DAYS:
+            self.preview_days_combo.addItem(_("Last {days} days").format(days=days), userData=days)
+        self.preview_days_combo.setCurrentIndex(PREVIEW_DAYS.index(7))
+        preview_layout.addWidget(self.preview_days_combo)
+        self.preview_btn = PushButton(_("Preview"))
+        self.preview_btn.clicked.connect(self._preview)
+        preview_layout.addWidget(self.preview_btn)
+        self.preview_label = BodyLabel()
+        preview_layout.addWidget(self.preview_label, 1)
+        content_layout.addWidget(self.preview_container)
+
+        from core.exchange_factory import ExchangeFactory
+
+        # Not parented: a preview still loading may finish after the dialog is gone
+        self._backtester = AlertBacktester(lambda: ExchangeFactory.create_client(None))
+        self._backtester.finished.connect(self._on_preview_finished)
+
+        # Own notification text, sent the same way to every channel
+        self.message_input = LineEdit()
+        self.message_input.setPlaceholderText(_("Built-in text"))
+        self.message_input.setClearButtonEnabled(True)
+        content_layout.addWidget(self._labeled_row(_("Message:"), self.message_input))
+        variables = " ".join("{" + name + "}" for name in ALERT_VARIABLES)
+        message_hint = BodyLabel(f"{_('Variables:')} {variables}")
+        message_hint.setWordWrap(True)
+        message_hint.setStyleSheet("color: gray; font-size: 12px;")
+        content_layout.addWidget(message_hint)
+
+        # Escalation chain: remote channels tried in turn until acknowledged
+        content_layout.addWidget(BodyLabel(_("Escalate if not acknowledged:")))
+        self._escalation_rows: list[tuple[ComboBox, SpinBox]] = []
+        for _index in range(ESCALATION_STEPS):
+            row = QHBoxLayout()
+            row.setContentsMargins(20, 0, 0, 0)
+            channel_combo = ComboBox()
+            channel_combo.addItem(_("Off"), userData="")
+            for channel in CHANNEL_TYPES:
+                channel_combo.addItem(_(channel.name), userData=channel.name)
+            row.addWidget(channel_combo, 1)
+            after_spin = SpinBox()
+            after_spin.setRange(1, 1440)
+            after_spin.setValue(EscalationStep.after_minutes)
+            after_spin.setPrefix(f"{_('after')} ")
+            after_spin.setSuffix(f" {_('min')}")
+            after_spin.setFixedWidth(150)
+            row.addWidget(after_spin)
+            content_layout.addLayout(row)
+            self._escalation_rows.append((channel_combo, after_spin))
+
         # Error label
         self.error_label = QLabel()
         self.error_label.setStyleSheet("color: #D13438; font-size: 12px;")
@@ -209,9 +330,31 @@ class AlertDialog(Dialog):
             self.type_multiple.setChecked(True)
         elif self._edit_alert.alert_type == "price_change_pct":
             self.type_change.setChecked(True)
+        elif self._edit_alert.alert_type == "price_ladder_pct":
+            self.type_ladder.setChecked(True)
+        elif self._edit_alert.alert_type == "trailing_high":
+            self.type_trailing_high.setChecked(True)
+        elif self._edit_alert.alert_type == "trailing_low":
+            self.type_trailing_low.setChecked(True)
+        elif self._edit_alert.alert_type == "rsi_above":
+            self.type_rsi_above.setChecked(True)
+        elif self._edit_alert.alert_type == "rsi_below":
+            self.type_rsi_below.setChecked(True)
+        elif self._edit_alert.alert_type in MA_CROSS_ALERT_TYPES:
+            self.type_ma_cross.setChecked(True)
+            self.cross_combo.setCurrentIndex(self.cross_combo.findData(self._edit_alert.alert_type))
+            self.ema_spin.setValue(self._edit_alert.ema_period)
+        elif self._edit_alert.alert_type == "bollinger_breach":
+            self.type_bollinger.setChecked(True)
         else:
             self.type_touch.setChecked(True)
 
+        self.close_check.setChecked(self._edit_alert.on_candle_close)
+
+        timeframe_index = self.timeframe_combo.findData(self._edit_alert.timeframe)
+        if timeframe_index >= 0:
+            self.timeframe_combo.setCurrentIndex(timeframe_index)
+
         # Set target price
         self.price_input.setText(f"{self._edit_alert.target_price:.2f}")
 
@@ -222,26 +365,114 @@ class AlertDialog(Dialog):
         else:
             self.mode_once.setChecked(True)
 
+        self.message_input.setText(self._edit_alert.message_template)
+
+        # Set escalation steps
+        for (channel_combo, after_spin), step in zip(
+            self._escalation_rows, self._edit_alert.escalation
+        ):
+            channel_combo.setCurrentIndex(max(channel_combo.findData(step.channel), 0))
+            after_spin.setValue(step.after_minutes)
+
+    def _escalation_steps(self) -> list[EscalationStep]:
+        return [
+            EscalationStep(channel=channel_combo.currentData(), after_minutes=after_spin.value())
+            for channel_combo, after_spin in self._escalation_rows
+            if channel_combo.currentData()
+        ]
+
     def _on_repeat_toggled(self, checked: bool):
         """Handle repeat mode toggle."""
         self.cooldown_spin.setEnabled(checked)
 
+    def _labeled_row(self, text: str, widget: QWidget) -> QWidget:
+        row = QWidget()
+        layout = QHBoxLayout(row)
+        layout.setContentsMargins(0, 0, 0, 0)
+        label = BodyLabel(text)
+        label.setFixedWidth(100)
+        layout.addWidget(label)
+        layout.addWidget(widget, 1)
+        return row
+
+    def _is_signal_type(self) -> bool:
+        return self.type_ma_cross.isChecked() or self.type_bollinger.isChecked()
+
     def _on_type_changed(self):
         """Handle alert type change to update UI hints."""
+        is_cross = self.type_ma_cross.isChecked()
+        on_close = self.close_check.isChecked() and self._closes_candles()
+        self.signal_container.setVisible(self._is_signal_type() or on_close)
+        self.price_container.setVisible(not self._is_signal_type())
+        self.close_check.setVisible(self._closes_candles())
+        self.preview_container.setVisible(self._selected_alert_type() in BACKTEST_ALERT_TYPES)
+        self.preview_label.clear()
+        self.cross_row.setVisible(is_cross)
+        self.ema_row.setVisible(is_cross)
+        self.ema_spin.setEnabled(
+            self.cross_combo.currentData() in ("ema_cross_above", "ema_cross_below")
+        )
+
         if self.type_multiple.isChecked():
             self.price_label.setText(_("Step Value:"))
             self.price_input.setPlaceholderText(_("e.g. 1000"))
-        elif self.type_change.isChecked():
+        elif self.type_change.isChecked() or self.type_ladder.isChecked():
             self.price_label.setText(_("Step %:"))
             self.price_input.setPlaceholderText(_("e.g. 2.0"))
+        elif self._is_trailing_type():
+            self.price_label.setText(_("Retrace %:"))
+            self.price_input.setPlaceholderText(_("e.g. 5"))
+        elif self._is_rsi_type():
+            self.price_label.setText(_("RSI Level:"))
+            self.price_input.setPlaceholderText(_("e.g. 30"))
         else:
             self.price_label.setText(_("Target Price:"))
             self.price_input.setPlaceholderText("0.00")
 
         self._validate_input()
 
+    def _closes_candles(self) -> bool:
+        """Whether the selected type can be judged on candle closes."""
+        return self._selected_alert_type() in CANDLE_CLOSE_ALERT_TYPES
+
+    def _selected_alert_type(self) -> str:
+        if self.type_ma_cross.isChecked():
+            return self.cross_combo.currentData()
+        if self.type_bollinger.isChecked():
+            return "bollinger_breach"
+        if self.type_above.isChecked():
+            return "price_above"
+        if self.type_below.isChecked():
+            return "price_below"
+        if self.type_multiple.isChecked():
+            return "price_multiple"
+        if self.type_change.isChecked():
+            return "price_change_pct"
+        if self.type_ladder.isChecked():
+            return "price_ladder_pct"
+        if self.type_trailing_high.isChecked():
+            return "trailing_high"
+        if self.type_trailing_low.isChecked():
+            return "trailing_low"
+        if self.type_rsi_above.isChecked():
+            return "rsi_above"
+        if self.type_rsi_below.isChecked():
+            return "rsi_below"
+        return "price_touch"
+
+    def _is_trailing_type(self) -> bool:
+        return self.type_trailing_high.isChecked() or self.type_trailing_low.isChecked()
+
+    def _is_rsi_type(self) -> bool:
+        return self.type_rsi_above.isChecked() or self.type_rsi_below.isChecked()
+
     def _validate_input(self, text: str = None):
         """Validate the price input."""
+        if self._is_signal_type():
+            self.error_label.setVisible(False)
+            self.yesButton.setEnabled(True)
+            return
+
         try:
             price_text = self.price_input.text().strip()
             if not price_text:
@@ -256,6 +487,14 @@ class AlertDialog(Dialog):
                 self.error_label.setText(_("Value must be greater than 0"))
                 self.error_label.setVisible(True)
                 self.yesButton.setEnabled(False)
+            elif self._is_rsi_type() and price >= 100:
+                self.error_label.setText(_("RSI level must be below 100"))
+                self.error_label.setVisible(True)
+                self.yesButton.setEnabled(False)
+            elif self._is_trailing_type() and price >= 100:
+                self.error_label.setText(_("Retrace must be below 100%"))
+                self.error_label.setVisible(True)
+                self.yesButton.setEnabled(False)
             else:
                 self.error_label.setVisible(False)
                 self.yesButton.setEnabled(True)
@@ -264,24 +503,42 @@ class AlertDialog(Dialog):
             self.error_label.setVisible(True)
             self.yesButton.setEnabled(False)
 
+    def _preview(self):
+        alert = self._build_alert()
+        if alert is None or self._backtester.running:
+            return
+        self.preview_btn.setEnabled(False)
+        self.preview_label.setText(_("Loading..."))
+        self._backtester.start(alert, self.preview_days_combo.currentData())
+
+    def _on_preview_finished(self, result, error: str):
+        self.preview_btn.setEnabled(True)
+        if result is None:
+            self.preview_label.setText(f"{_('Preview failed')}: {error}")
+            return
+        self.preview_label.setText(
+            _("Would have fired {count} times ({interval} candles)").format(
+                count=len(result.fires), interval=result.interval
+            )
+        )
+
     def _on_confirm(self):
         """Handle confirm button click."""
-        try:
-            price = float(self.price_input.text().strip().replace(",", ""))
-            if price <= 0:
-                return
+        self._alert = self._build_alert()
 
-            # Determine alert type
-            if self.type_above.isChecked():
-                alert_type = "price_above"
-            elif self.type_below.isChecked():
-                alert_type = "price_below"
-            elif self.type_multiple.isChecked():
-                alert_type = "price_multiple"
-            elif self.type_change.isChecked():
-                alert_type = "price_change_pct"
+    def _build_alert(self) -> PriceAlert | None:
+        """Alert of the current inputs, or None while they are invalid."""
+        try:
+            if self._is_signal_type():
+                # Candle signal alerts have no target value
+                price = 0.0
             else:
-                alert_type = "price_touch"
+                price = float(self.price_input.text().strip().replace(",", ""))
+                if price <= 0:
+                    return None
+
+            alert_type = self._selected_alert_type()
+            on_candle_close = self.close_check.isChecked() and self._closes_candles()
 
             # Determine repeat mode
             repeat_mode = "repeat" if self.mode_repeat.isChecked() else "once"
@@ -289,7 +546,7 @@ class AlertDialog(Dialog):
 
             # Create or update alert
             if self._edit_alert:
-                self._alert = PriceAlert(
+                alert = PriceAlert(
                     id=self._edit_alert.id,
                     pair=self.pair_combo.currentData(),
                     alert_type=alert_type,
@@ -299,18 +556,29 @@ class AlertDialog(Dialog):
                     cooldown_seconds=cooldown,
                     last_triggered=self._edit_alert.last_triggered,
                     created_at=self._edit_alert.created_at,
+                    timeframe=self.timeframe_combo.currentData(),
+                    ema_period=self.ema_spin.value(),
+                    escalation=self._escalation_steps(),
+                    message_template=self.message_input.text().strip(),
+                    on_candle_close=on_candle_close,
                 )
             else:
-                self._alert = PriceAlert(
+                alert = PriceAlert(
                     pair=self.pair_combo.currentData(),
                     alert_type=alert_type,
                     target_price=price,
                     repeat_mode=repeat_mode,
                     cooldown_seconds=cooldown,
+                    timeframe=self.timeframe_combo.currentData(),
+                    ema_period=self.ema_spin.value(),
+                    escalation=self._escalation_steps(),
+                    message_template=self.message_input.text().strip(),
+                    on_candle_close=on_candle_close,
                 )
 
         except ValueError:
-            pass
+            return None
+        return alert
 
     def get_alert(self) -> PriceAlert | None:
         """Get the created/edited alert, or None if cancelled."""
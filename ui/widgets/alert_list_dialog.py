@@ -22,7 +22,7 @@ from qfluentwidgets import (
 )
 
 from config.settings import PriceAlert, get_settings_manager
-from core.alert_manager import get_alert_manager
+from core.alert_manager import CANDLE_CLOSE_ALERT_TYPES, get_alert_manager
 from core.i18n import _
 from ui.widgets.alert_dialog import AlertDialog
 
@@ -59,10 +59,22 @@ class AlertItem(CardWidget):
         # Main text (Target)
         if self.alert.alert_type == "price_multiple":
             target_text = f"{_('Step')}: ${self.alert.target_price:,.0f}"
-        elif self.alert.alert_type == "price_change_pct":
+        elif self.alert.alert_type in ("price_change_pct", "price_ladder_pct"):
             target_text = f"{_('Step')}: {self.alert.target_price:.2f}%"
+        elif self.alert.alert_type in ("trailing_high", "trailing_low"):
+            target_text = f"{_('Retrace')}: {self.alert.target_price:g}%"
+        elif self.alert.alert_type in ("rsi_above", "rsi_below"):
+            target_text = f"RSI: {self.alert.target_price:g}"
+        elif self.alert.alert_type in ("golden_cross", "death_cross"):
+            target_text = f"EMA 50/200 · {self.alert.timeframe}"
+        elif self.alert.alert_type in ("ema_cross_above", "ema_cross_below"):
+            target_text = f"EMA {self.alert.ema_period} · {self.alert.timeframe}"
+        elif self.alert.alert_type == "bollinger_breach":
+            target_text = f"{_('Bollinger Bands')} · {self.alert.timeframe}"
         else:
             target_text = f"{_('Target')}: ${self.alert.target_price:,.2f}"
+        if self.alert.on_candle_close and self.alert.alert_type in CANDLE_CLOSE_ALERT_TYPES:
+            target_text += f" · {self.alert.timeframe} {_('close')}"
 
         target_label = StrongBodyLabel(target_text)
         info_layout.addWidget(target_label)
@@ -118,6 +130,22 @@ class AlertItem(CardWidget):
             return FluentIcon.TILES
         elif alert_type == "price_change_pct":
             return FluentIcon.SYNC
+        elif alert_type == "price_ladder_pct":
+            return FluentIcon.ALIGNMENT
+        elif alert_type == "trailing_high":
+            return FluentIcon.DOWN
+        elif alert_type == "trailing_low":
+            return FluentIcon.UP
+        elif alert_type == "rsi_above":
+            return FluentIcon.UP
+        elif alert_type == "rsi_below":
+            return FluentIcon.DOWN
+        elif alert_type in ("golden_cross", "ema_cross_above"):
+            return FluentIcon.UP
+        elif alert_type in ("death_cross", "ema_cross_below"):
+            return FluentIcon.DOWN
+        elif alert_type == "bollinger_breach":
+            return FluentIcon.SCROLL
         return FluentIcon.ALERT
 
     def _get_desc_for_type(self, alert_type: str) -> str:
@@ -131,6 +159,26 @@ class AlertItem(CardWidget):
             return _("Price Multiple")
         elif alert_type == "price_change_pct":
             return _("Change Step")
+        elif alert_type == "price_ladder_pct":
+            return _("Price Ladder")
+        elif alert_type == "trailing_high":
+            return _("Trailing Stop")
+        elif alert_type == "trailing_low":
+            return _("Trailing Rebound")
+        elif alert_type == "rsi_above":
+            return _("RSI Above")
+        elif alert_type == "rsi_below":
+            return _("RSI Below")
+        elif alert_type == "golden_cross":
+            return _("Golden Cross")
+        elif alert_type == "death_cross":
+            return _("Death Cross")
+        elif alert_type == "ema_cross_above":
+            return _("Crosses Above EMA")
+        elif alert_type == "ema_cross_below":
+            return _("Crosses Below EMA")
+        elif alert_type == "bollinger_breach":
+            return _("Band Breach")
         return _("Alert")
 
 
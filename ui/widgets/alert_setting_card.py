@@ -3,10 +3,12 @@ from PyQt6.QtWidgets import QHBoxLayout, QVBoxLayout, QWidget
 from qfluentwidgets import (
     BodyLabel,
     ComboBox,
+    DoubleSpinBox,
     ExpandGroupSettingCard,
     FluentIcon,
     PrimaryPushButton,
     PushButton,
+    SpinBox,
     SwitchButton,
     ToolButton,
     isDarkTheme,
@@ -14,7 +16,14 @@ from qfluentwidgets import (
 from qfluentwidgets import ListWidget as FluentListWidget
 
 from config.settings import PriceAlert, get_settings_manager
+from core.economic_calendar import get_economic_calendar
+from core.fee_monitor import get_fee_monitor
 from core.i18n import _
+from core.instrument_status import get_instrument_status_monitor
+from core.listing_watcher import get_listing_watcher
+from core.market_indices import get_fear_greed_service
+from core.stablecoin_monitor import get_stablecoin_monitor
+from core.token_unlocks import get_token_unlock_service
 
 from .alert_dialog import AlertDialog
 
@@ -58,7 +67,19 @@ class AlertListItem(QWidget):
             if self.alert.repeat_mode == "once"
             else f"{_('Repeat')} ({self.alert.cooldown_seconds}s)"
         )
-        self.details = BodyLabel(f"{type_text} ${self.alert.target_price:,.2f} | {mode_text}")
+        if self.alert.alert_type in ("rsi_above", "rsi_below"):
+            target_text = f"{self.alert.target_price:g}"
+        elif self.alert.alert_type in ("golden_cross", "death_cross"):
+            target_text = f"EMA 50/200 · {self.alert.timeframe}"
+        elif self.alert.alert_type in ("ema_cross_above", "ema_cross_below"):
+            target_text = f"EMA {self.alert.ema_period} · {self.alert.timeframe}"
+        elif self.alert.alert_type == "bollinger_breach":
+            target_text = self.alert.timeframe
+        elif self.alert.alert_type in ("price_ladder_pct", "trailing_high", "trailing_low"):
+            target_text = f"{self.alert.target_price:g}%"
+        else:
+            target_text = f"${self.alert.target_price:,.2f}"
+        self.details = BodyLabel(f"{type_text} {target_text} | {mode_text}")
 
         details_color = "#AAAAAA" if is_dark else "#555555"
         self.details.setStyleSheet(f"font-size: 11px; color: {details_color};")
@@ -80,6 +101,26 @@ class AlertListItem(QWidget):
             return _("Step")
         elif self.alert.alert_type == "price_change_pct":
             return _("Change %")
+        elif self.alert.alert_type == "price_ladder_pct":
+            return _("Ladder")
+        elif self.alert.alert_type == "trailing_high":
+            return _("Trailing Stop")
+        elif self.alert.alert_type == "trailing_low":
+            return _("Trailing Rebound")
+        elif self.alert.alert_type == "rsi_above":
+            return _("RSI Above")
+        elif self.alert.alert_type == "rsi_below":
+            return _("RSI Below")
+        elif self.alert.alert_type == "golden_cross":
+            return _("Golden Cross")
+        elif self.alert.alert_type == "death_cross":
+            return _("Death Cross")
+        elif self.alert.alert_type == "ema_cross_above":
+            return _("Crosses Above EMA")
+        elif self.alert.alert_type == "ema_cross_below":
+            return _("Crosses Below EMA")
+        elif self.alert.alert_type == "bollinger_breach":
+            return _("Band Breach")
         else:
             return _("Touch")
 
@@ -137,6 +178,253 @@ class AlertSettingCard(ExpandGroupSettingCard):
 
         layout.addWidget(sound_container)
 
+        anomaly_container = QWidget()
+        anomaly_layout = QHBoxLayout(anomaly_container)
+        anomaly_layout.setContentsMargins(0, 0, 0, 0)
+
+        settings = self._settings_manager.settings
+        self.anomaly_label = BodyLabel(_("Price Spike Alerts"))
+        self.anomaly_label.setToolTip(
+            _("Notify when a tick deviates far from the short-term mean (flash crash, bad print)")
+        )
+        self.anomaly_threshold_spin = DoubleSpinBox()
+        self.anomaly_threshold_spin.setRange(3.0, 20.0)
+        self.anomaly_threshold_spin.setSingleStep(0.5)
+        self.anomaly_threshold_spin.setSuffix(" σ")
+        self.anomaly_threshold_spin.setValue(settings.anomaly_threshold)
+        self.anomaly_threshold_spin.valueChanged.connect(self._on_anomaly_changed)
+        self.anomaly_switch = SwitchButton()
+        self.anomaly_switch.setOnText(_("On"))
+        self.anomaly_switch.setOffText(_("Off"))
+        self.anomaly_switch.setChecked(settings.anomaly_alerts)
+        self.anomaly_switch.checkedChanged.connect(self._on_anomaly_changed)
+
+        anomaly_layout.addWidget(self.anomaly_label)
+        anomaly_layout.addStretch(1)
+        anomaly_layout.addWidget(self.anomaly_threshold_spin)
+        anomaly_layout.addWidget(self.anomaly_switch)
+
+        layout.addWidget(anomaly_container)
+
+        fear_greed_container = QWidget()
+        fear_greed_layout = QHBoxLayout(fear_greed_container)
+        fear_greed_layout.setContentsMargins(0, 0, 0, 0)
+
+        self.fear_greed_label = BodyLabel(_("Fear & Greed Alerts"))
+        self.fear_greed_label.setToolTip(
+            _("Notify when the Fear & Greed Index falls to the lower or rises to the upper value")
+        )
+        self.fear_greed_low_spin = SpinBox()
+        self.fear_greed_low_spin.setRange(0, 49)
+        self.fear_greed_low_spin.setValue(settings.fear_greed_low)
+        self.fear_greed_low_spin.valueChanged.connect(self._on_fear_greed_changed)
+        self.fear_greed_high_spin = SpinBox()
+        self.fear_greed_high_spin.setRange(51, 100)
+        self.fear_greed_high_spin.setValue(settings.fear_greed_high)
+        self.fear_greed_high_spin.valueChanged.connect(self._on_fear_greed_changed)
+        self.fear_greed_switch = SwitchButton()
+        self.fear_greed_switch.setOnText(_("On"))
+        self.fear_greed_switch.setOffText(_("Off"))
+        self.fear_greed_switch.setChecked(settings.fear_greed_alerts)
+        self.fear_greed_switch.checkedChanged.connect(self._on_fear_greed_changed)
+
+        fear_greed_layout.addWidget(self.fear_greed_label)
+        fear_greed_layout.addStretch(1)
+        fear_greed_layout.addWidget(self.fear_greed_low_spin)
+        fear_greed_layout.addWidget(self.fear_greed_high_spin)
+        fear_greed_layout.addWidget(self.fear_greed_switch)
+
+        layout.addWidget(fear_greed_container)
+
+        fee_container = QWidget()
+        fee_layout = QHBoxLayout(fee_container)
+        fee_layout.setContentsMargins(0, 0, 0, 0)
+
+        self.fee_label = BodyLabel(_("Low Fee Alerts"))
+        self.fee_label.setToolTip(_("Notify when BTC or ETH network fees drop to the targets"))
+        self.btc_fee_spin = DoubleSpinBox()
+        self.btc_fee_spin.setRange(1.0, 500.0)
+        self.btc_fee_spin.setSuffix(" sat/vB")
+        self.btc_fee_spin.setValue(settings.btc_fee_target)
+        self.btc_fee_spin.valueChanged.connect(self._on_fee_alerts_changed)
+        self.eth_gas_spin = DoubleSpinBox()
+        self.eth_gas_spin.setRange(0.1, 500.0)
+        self.eth_gas_spin.setSingleStep(0.5)
+        self.eth_gas_spin.setSuffix(" gwei")
+        self.eth_gas_spin.setValue(settings.eth_gas_target)
+        self.eth_gas_spin.valueChanged.connect(self._on_fee_alerts_changed)
+        self.fee_switch = SwitchButton()
+        self.fee_switch.setOnText(_("On"))
+        self.fee_switch.setOffText(_("Off"))
+        self.fee_switch.setChecked(settings.fee_alerts)
+        self.fee_switch.checkedChanged.connect(self._on_fee_alerts_changed)
+
+        fee_layout.addWidget(self.fee_label)
+        fee_layout.addStretch(1)
+        fee_layout.addWidget(self.btc_fee_spin)
+        fee_layout.addWidget(self.eth_gas_spin)
+        fee_layout.addWidget(self.fee_switch)
+
+        layout.addWidget(fee_container)
+
+        funding_container = QWidget()
+        funding_layout = QHBoxLayout(funding_container)
+        funding_layout.setContentsMargins(0, 0, 0, 0)
+
+        self.funding_label = BodyLabel(_("Funding Arbitrage Alerts"))
+        self.funding_label.setToolTip(
+            _("Notify when the funding rate spread between OKX, Binance and Bybit reaches this")
+        )
+        self.funding_spin = DoubleSpinBox()
+        self.funding_spin.setRange(0.001, 1.0)
+        self.funding_spin.setDecimals(3)
+        self.funding_spin.setSingleStep(0.01)
+        self.funding_spin.setSuffix("% / 8h")
+        self.funding_spin.setValue(settings.funding_spread_threshold)
+        self.funding_spin.valueChanged.connect(self._on_funding_alerts_changed)
+        self.funding_switch = SwitchButton()
+        self.funding_switch.setOnText(_("On"))
+        self.funding_switch.setOffText(_("Off"))
+        self.funding_switch.setChecked(settings.funding_alerts)
+        self.funding_switch.checkedChanged.connect(self._on_funding_alerts_changed)
+
+        funding_layout.addWidget(self.funding_label)
+        funding_layout.addStretch(1)
+        funding_layout.addWidget(self.funding_spin)
+        funding_layout.addWidget(self.funding_switch)
+
+        layout.addWidget(funding_container)
+
+        unlock_container = QWidget()
+        unlock_layout = QHBoxLayout(unlock_container)
+        unlock_layout.setContentsMargins(0, 0, 0, 0)
+
+        self.unlock_label = BodyLabel(_("Token Unlock Alerts"))
+        self.unlock_label.setToolTip(
+            _("Notify this long before watched tokens unlock at least this share of their supply")
+        )
+        self.unlock_lead_spin = SpinBox()
+        self.unlock_lead_spin.setRange(1, 168)
+        self.unlock_lead_spin.setSuffix(" h")
+        self.unlock_lead_spin.setValue(settings.unlock_lead_hours)
+        self.unlock_lead_spin.valueChanged.connect(self._on_unlock_alerts_changed)
+        self.unlock_pct_spin = DoubleSpinBox()
+        self.unlock_pct_spin.setRange(0.1, 100.0)
+        self.unlock_pct_spin.setSingleStep(0.5)
+        self.unlock_pct_spin.setSuffix("%")
+        self.unlock_pct_spin.setValue(settings.unlock_min_supply_pct)
+        self.unlock_pct_spin.valueChanged.connect(self._on_unlock_alerts_changed)
+        self.unlock_switch = SwitchButton()
+        self.unlock_switch.setOnText(_("On"))
+        self.unlock_switch.setOffText(_("Off"))
+        self.unlock_switch.setChecked(settings.unlock_alerts)
+        self.unlock_switch.checkedChanged.connect(self._on_unlock_alerts_changed)
+
+        unlock_layout.addWidget(self.unlock_label)
+        unlock_layout.addStretch(1)
+        unlock_layout.addWidget(self.unlock_lead_spin)
+        unlock_layout.addWidget(self.unlock_pct_spin)
+        unlock_layout.addWidget(self.unlock_switch)
+
+        layout.addWidget(unlock_container)
+
+        stablecoin_container = QWidget()
+        stablecoin_layout = QHBoxLayout(stablecoin_container)
+        stablecoin_layout.setContentsMargins(0, 0, 0, 0)
+
+        self.stablecoin_label = BodyLabel(_("Stablecoin Mint/Burn Alerts"))
+        self.stablecoin_label.setToolTip(
+            _("Notify when the USDT or USDC supply changes by at least this amount")
+        )
+        self.stablecoin_spin = DoubleSpinBox()
+        self.stablecoin_spin.setRange(10.0, 10000.0)
+        self.stablecoin_spin.setDecimals(0)
+        self.stablecoin_spin.setSingleStep(50.0)
+        self.stablecoin_spin.setSuffix(" M USD")
+        self.stablecoin_spin.setValue(settings.stablecoin_threshold)
+        self.stablecoin_spin.valueChanged.connect(self._on_stablecoin_alerts_changed)
+        self.stablecoin_switch = SwitchButton()
+        self.stablecoin_switch.setOnText(_("On"))
+        self.stablecoin_switch.setOffText(_("Off"))
+        self.stablecoin_switch.setChecked(settings.stablecoin_alerts)
+        self.stablecoin_switch.checkedChanged.connect(self._on_stablecoin_alerts_changed)
+
+        stablecoin_layout.addWidget(self.stablecoin_label)
+        stablecoin_layout.addStretch(1)
+        stablecoin_layout.addWidget(self.stablecoin_spin)
+        stablecoin_layout.addWidget(self.stablecoin_switch)
+
+        layout.addWidget(stablecoin_container)
+
+        macro_container = QWidget()
+        macro_layout = QHBoxLayout(macro_container)
+        macro_layout.setContentsMargins(0, 0, 0, 0)
+
+        self.macro_label = BodyLabel(_("Economic Calendar Reminders"))
+        self.macro_label.setToolTip(
+            _("Remind this long before high-impact US releases such as FOMC, CPI and payrolls")
+        )
+        self.macro_lead_spin = SpinBox()
+        self.macro_lead_spin.setRange(5, 240)
+        self.macro_lead_spin.setSingleStep(5)
+        self.macro_lead_spin.setSuffix(" min")
+        self.macro_lead_spin.setValue(settings.macro_lead_minutes)
+        self.macro_lead_spin.valueChanged.connect(self._on_macro_alerts_changed)
+        self.macro_switch = SwitchButton()
+        self.macro_switch.setOnText(_("On"))
+        self.macro_switch.setOffText(_("Off"))
+        self.macro_switch.setChecked(settings.macro_alerts)
+        self.macro_switch.checkedChanged.connect(self._on_macro_alerts_changed)
+
+        macro_layout.addWidget(self.macro_label)
+        macro_layout.addStretch(1)
+        macro_layout.addWidget(self.macro_lead_spin)
+        macro_layout.addWidget(self.macro_switch)
+
+        layout.addWidget(macro_container)
+
+        listing_container = QWidget()
+        listing_layout = QHBoxLayout(listing_container)
+        listing_layout.setContentsMargins(0, 0, 0, 0)
+
+        self.listing_label = BodyLabel(_("New Listing Alerts"))
+        self.listing_label.setToolTip(_("Notify when OKX or Binance announces a new listing"))
+        self.listing_combo = ComboBox()
+        self.listing_combo.addItem(_("Off"), userData="off")
+        self.listing_combo.addItem(_("Watched Tokens"), userData="watched")
+        self.listing_combo.addItem(_("All Tokens"), userData="all")
+        self.listing_combo.setMinimumWidth(150)
+        modes = ["off", "watched", "all"]
+        if settings.listing_alerts in modes:
+            self.listing_combo.setCurrentIndex(modes.index(settings.listing_alerts))
+        self.listing_combo.currentIndexChanged.connect(self._on_listing_alerts_changed)
+
+        listing_layout.addWidget(self.listing_label)
+        listing_layout.addStretch(1)
+        listing_layout.addWidget(self.listing_combo)
+
+        layout.addWidget(listing_container)
+
+        delisting_container = QWidget()
+        delisting_layout = QHBoxLayout(delisting_container)
+        delisting_layout.setContentsMargins(0, 0, 0, 0)
+
+        self.delisting_label = BodyLabel(_("Delisting Warnings"))
+        self.delisting_label.setToolTip(
+            _("Warn when a watched pair is suspended or scheduled for delisting")
+        )
+        self.delisting_switch = SwitchButton()
+        self.delisting_switch.setOnText(_("On"))
+        self.delisting_switch.setOffText(_("Off"))
+        self.delisting_switch.setChecked(settings.delisting_alerts)
+        self.delisting_switch.checkedChanged.connect(self._on_delisting_alerts_changed)
+
+        delisting_layout.addWidget(self.delisting_label)
+        delisting_layout.addStretch(1)
+        delisting_layout.addWidget(self.delisting_switch)
+
+        layout.addWidget(delisting_container)
+
         btn_layout = QHBoxLayout()
         btn_layout.setSpacing(10)
 
@@ -230,6 +518,58 @@ class AlertSettingCard(ExpandGroupSettingCard):
             mode = "off"
         self._settings_manager.update_sound_mode(mode)
 
+    def _on_anomaly_changed(self):
+        self._settings_manager.update_anomaly_detection(
+            self.anomaly_switch.isChecked(), self.anomaly_threshold_spin.value()
+        )
+
+    def _on_fear_greed_changed(self):
+        self._settings_manager.update_fear_greed_alerts(
+            self.fear_greed_switch.isChecked(),
+            self.fear_greed_low_spin.value(),
+            self.fear_greed_high_spin.value(),
+        )
+        get_fear_greed_service().apply_settings()
+
+    def _on_fee_alerts_changed(self):
+        self._settings_manager.update_fee_alerts(
+            self.fee_switch.isChecked(), self.btc_fee_spin.value(), self.eth_gas_spin.value()
+        )
+        get_fee_monitor().apply_settings()
+
+    def _on_funding_alerts_changed(self):
+        self._settings_manager.update_funding_alerts(
+            self.funding_switch.isChecked(), self.funding_spin.value()
+        )
+
+    def _on_unlock_alerts_changed(self):
+        self._settings_manager.update_unlock_alerts(
+            self.unlock_switch.isChecked(),
+            self.unlock_lead_spin.value(),
+            self.unlock_pct_spin.value(),
+        )
+        get_token_unlock_service().apply_settings()
+
+    def _on_macro_alerts_changed(self):
+        self._settings_manager.update_macro_alerts(
+            self.macro_switch.isChecked(), self.macro_lead_spin.value()
+        )
+        get_economic_calendar().apply_settings()
+
+    def _on_stablecoin_alerts_changed(self):
+        self._settings_manager.update_stablecoin_alerts(
+            self.stablecoin_switch.isChecked(), self.stablecoin_spin.value()
+        )
+        get_stablecoin_monitor().apply_settings()
+
+    def _on_listing_alerts_changed(self, index: int):
+        self._settings_manager.update_listing_alerts(self.listing_combo.itemData(index))
+        get_listing_watcher().apply_settings()
+
+    def _on_delisting_alerts_changed(self):
+        self._settings_manager.update_delisting_alerts(self.delisting_switch.isChecked())
+        get_instrument_status_monitor().apply_settings()
+
     def _clear_all_alerts(self):
         self.alerts_list.clear()
         self._alert_widgets.clear()
@@ -0,0 +1,452 @@
+"""
+Setting cards for the remote notification channels.
+"""
+
+from PyQt6.QtCore import pyqtSignal
+from PyQt6.QtWidgets import QHBoxLayout, QVBoxLayout, QWidget
+from qfluentwidgets import (
+    BodyLabel,
+    ExpandGroupSettingCard,
+    FluentIcon,
+    InfoBar,
+    InfoBarPosition,
+    PushButton,
+    SwitchButton,
+)
+
+from config.settings import (
+    DingTalkConfig,
+    EmailConfig,
+    FeishuConfig,
+    GotifyConfig,
+    MatrixConfig,
+    TelegramConfig,
+    WeComConfig,
+    XmppConfig,
+)
+from core.i18n import _
+from core.notification_channels import XMPP_AVAILABLE
+
+from .fields import LabeledCheckBox, LabeledLineEdit, LabeledSpinBox
+
+
+class ChannelSettingCard(ExpandGroupSettingCard):
+    """Common part of the channel cards: an on switch, fields and a test button."""
+
+    test_requested = pyqtSignal()
+
+    def __init__(self, icon, title: str, content: str, parent: QWidget | None = None):
+        super().__init__(icon, title, content, parent)
+        self._title = title
+
+        self._container = QWidget()
+        self._layout = QVBoxLayout(self._container)
+        self._layout.setContentsMargins(48, 18, 48, 18)
+        self._layout.setSpacing(16)
+
+        switch_layout = QHBoxLayout()
+        switch_layout.addWidget(BodyLabel(_("Send notifications here")))
+        switch_layout.addStretch(1)
+        self.enable_switch = SwitchButton()
+        self.enable_switch.setOffText(_("Off"))
+        self.enable_switch.setOnText(_("On"))
+        self.enable_switch.checkedChanged.connect(self._on_enabled_changed)
+        switch_layout.addWidget(self.enable_switch)
+        self._layout.addLayout(switch_layout)
+
+        self._fields: list[QWidget] = []
+        self._setup_fields()
+
+        self.test_btn = PushButton(FluentIcon.SEND, _("Send Test Message"))
+        self.test_btn.setFixedWidth(200)
+        self.test_btn.clicked.connect(self._request_test)
+        self._layout.addWidget(self.test_btn)
+
+        self.hint = BodyLabel(self._hint_text())
+        self.hint.setWordWrap(True)
+        self.hint.setStyleSheet("color: gray; font-size: 12px;")
+        self._layout.addWidget(self.hint)
+
+        self.addGroupWidget(self._container)
+        self._on_enabled_changed(False)
+
+    def _setup_fields(self):
+        """Add the channel's fields with _add_field."""
+        raise NotImplementedError
+
+    def _hint_text(self) -> str:
+        return ""
+
+    def _add_field(self, widget: QWidget):
+        self._fields.append(widget)
+        self._layout.addWidget(widget)
+
+    def _on_enabled_changed(self, enabled: bool):
+        for widget in self._fields:
+            widget.setEnabled(enabled)
+        self.test_btn.setEnabled(enabled)
+
+    def _request_test(self):
+        self.test_btn.setEnabled(False)
+        self.test_requested.emit()
+
+    def show_test_result(self, error: str):
+        self.test_btn.setEnabled(self.enable_switch.isChecked())
+        if not error:
+            InfoBar.success(
+                title=_("Test Message Sent"),
+                content=_("Check {channel} for the message.").format(channel=self._title),
+                position=InfoBarPosition.TOP,
+                duration=3000,
+                parent=self.window(),
+            )
+            return
+        InfoBar.error(
+            title=_("Test Message Failed"),
+            content=error,
+            position=InfoBarPosition.TOP,
+            duration=5000,
+            parent=self.window(),
+        )
+
+
+class TelegramSettingCard(ChannelSettingCard):
+    """Telegram bot that receives notifications and, optionally, takes commands."""
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.SEND,
+            "Telegram",
+            _("Alerts on your phone through a Telegram bot"),
+            parent,
+        )
+
+    def _setup_fields(self):
+        self.token_field = LabeledLineEdit(_("Bot Token"), is_password=True, min_width=300)
+        self._add_field(self.token_field)
+        self.chat_field = LabeledLineEdit(_("Chat ID"), min_width=300)
+        self._add_field(self.chat_field)
+        self.commands_check = LabeledCheckBox(
+            _("Accept commands from the chat: /price, /add, /mute, /unmute")
+        )
+        self._add_field(self.commands_check)
+
+    def _hint_text(self) -> str:
+        return _(
+            "Create a bot with @BotFather, send it a message, then find the chat ID "
+            "in https://api.telegram.org/bot<token>/getUpdates."
+        )
+
+    def get_config(self) -> TelegramConfig:
+        return TelegramConfig(
+            enabled=self.enable_switch.isChecked(),
+            bot_token=self.token_field.text().strip(),
+            chat_id=self.chat_field.text().strip(),
+            commands_enabled=self.commands_check.is_checked(),
+        )
+
+    def set_config(self, config: TelegramConfig):
+        self.enable_switch.setChecked(config.enabled)
+        self.token_field.set_text(config.bot_token)
+        self.chat_field.set_text(config.chat_id)
+        self.commands_check.set_checked(config.commands_enabled)
+        self._on_enabled_changed(config.enabled)
+
+
+class MatrixSettingCard(ChannelSettingCard):
+    """Matrix room that receives notifications."""
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.CHAT,
+            "Matrix",
+            _("Alerts in a Matrix room"),
+            parent,
+        )
+
+    def _setup_fields(self):
+        self.homeserver_field = LabeledLineEdit(
+            _("Homeserver"), placeholder=MatrixConfig.homeserver, min_width=300
+        )
+        self._add_field(self.homeserver_field)
+        self.token_field = LabeledLineEdit(_("Access Token"), is_password=True, min_width=300)
+        self._add_field(self.token_field)
+        self.room_field = LabeledLineEdit(
+            _("Room ID"), placeholder="!abc123:matrix.org", min_width=300
+        )
+        self._add_field(self.room_field)
+
+    def _hint_text(self) -> str:
+        return _(
+            "Use a separate account for the bot and invite it to the room. In Element, "
+            "the access token is under Settings > Help & About and the room ID under "
+            "Room Settings > Advanced."
+        )
+
+    def get_config(self) -> MatrixConfig:
+        return MatrixConfig(
+            enabled=self.enable_switch.isChecked(),
+            homeserver=self.homeserver_field.text().strip() or MatrixConfig.homeserver,
+            access_token=self.token_field.text().strip(),
+            room_id=self.room_field.text().strip(),
+        )
+
+    def set_config(self, config: MatrixConfig):
+        self.enable_switch.setChecked(config.enabled)
+        self.homeserver_field.set_text(config.homeserver)
+        self.token_field.set_text(config.access_token)
+        self.room_field.set_text(config.room_id)
+        self._on_enabled_changed(config.enabled)
+
+
+class WeComSettingCard(ChannelSettingCard):
+    """WeChat Work group robot that receives notifications."""
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.PEOPLE,
+            _("WeChat Work"),
+            _("Alerts in a WeChat Work group through a group robot"),
+            parent,
+        )
+
+    def _setup_fields(self):
+        self.webhook_field = LabeledLineEdit(
+            _("Webhook URL"),
+            placeholder="https://qyapi.weixin.qq.com/cgi-bin/webhook/send?key=...",
+            is_password=True,
+            min_width=300,
+        )
+        self._add_field(self.webhook_field)
+
+    def _hint_text(self) -> str:
+        return _("Add a group robot in the group's settings and copy its webhook URL.")
+
+    def get_config(self) -> WeComConfig:
+        return WeComConfig(
+            enabled=self.enable_switch.isChecked(),
+            webhook_url=self.webhook_field.text().strip(),
+        )
+
+    def set_config(self, config: WeComConfig):
+        self.enable_switch.setChecked(config.enabled)
+        self.webhook_field.set_text(config.webhook_url)
+        self._on_enabled_changed(config.enabled)
+
+
+class SignedWebhookSettingCard(ChannelSettingCard):
+    """A robot webhook with an optional signing secret, as DingTalk and Feishu use."""
+
+    config_class = DingTalkConfig
+    webhook_placeholder = ""
+
+    def _setup_fields(self):
+        self.webhook_field = LabeledLineEdit(
+            _("Webhook URL"),
+            placeholder=self.webhook_placeholder,
+            is_password=True,
+            min_width=300,
+        )
+        self._add_field(self.webhook_field)
+        self.secret_field = LabeledLineEdit(
+            _("Signing Secret"), placeholder=_("Optional"), is_password=True, min_width=300
+        )
+        self._add_field(self.secret_field)
+
+    def get_config(self):
+        return self.config_class(
+            enabled=self.enable_switch.isChecked(),
+            webhook_url=self.webhook_field.text().strip(),
+            secret=self.secret_field.text().strip(),
+        )
+
+    def set_config(self, config):
+        self.enable_switch.setChecked(config.enabled)
+        self.webhook_field.set_text(config.webhook_url)
+        self.secret_field.set_text(config.secret)
+        self._on_enabled_changed(config.enabled)
+
+
+class DingTalkSettingCard(SignedWebhookSettingCard):
+    """DingTalk custom robot that receives notifications."""
+
+    config_class = DingTalkConfig
+    webhook_placeholder = "https://oapi.dingtalk.com/robot/send?access_token=..."
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.MEGAPHONE,
+            _("DingTalk"),
+            _("Alerts in a DingTalk group through a custom robot"),
+            parent,
+        )
+
+    def _hint_text(self) -> str:
+        return _(
+            "Add a custom robot in the group's settings. With the signing security "
+            "setting, also enter the secret starting with SEC."
+        )
+
+
+class FeishuSettingCard(SignedWebhookSettingCard):
+    """Feishu (Lark) custom bot that receives notifications."""
+
+    config_class = FeishuConfig
+    webhook_placeholder = "https://open.feishu.cn/open-apis/bot/v2/hook/..."
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.MESSAGE,
+            _("Feishu (Lark)"),
+            _("Alerts in a Feishu or Lark group through a custom bot"),
+            parent,
+        )
+
+    def _hint_text(self) -> str:
+        return _(
+            "Add a custom bot in the group's settings. With signature verification on, "
+            "also enter its secret."
+        )
+
+
+class GotifySettingCard(ChannelSettingCard):
+    """Self-hosted Gotify server that receives notifications."""
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.CLOUD,
+            "Gotify",
+            _("Alerts through your own Gotify push server"),
+            parent,
+        )
+
+    def _setup_fields(self):
+        self.server_field = LabeledLineEdit(
+            _("Server URL"), placeholder="https://push.example.com", min_width=300
+        )
+        self._add_field(self.server_field)
+        self.token_field = LabeledLineEdit(_("App Token"), is_password=True, min_width=300)
+        self._add_field(self.token_field)
+        self.priority_spin = LabeledSpinBox(_("Priority"), 0, 10, GotifyConfig.priority)
+        self._add_field(self.priority_spin)
+
+    def _hint_text(self) -> str:
+        return _("Create an application in the Gotify web UI and copy its token.")
+
+    def get_config(self) -> GotifyConfig:
+        return GotifyConfig(
+            enabled=self.enable_switch.isChecked(),
+            server_url=self.server_field.text().strip(),
+            app_token=self.token_field.text().strip(),
+            priority=self.priority_spin.value(),
+        )
+
+    def set_config(self, config: GotifyConfig):
+        self.enable_switch.setChecked(config.enabled)
+        self.server_field.set_text(config.server_url)
+        self.token_field.set_text(config.app_token)
+        self.priority_spin.set_value(config.priority)
+        self._on_enabled_changed(config.enabled)
+
+
+class XmppSettingCard(ChannelSettingCard):
+    """XMPP account that sends notifications as chat messages."""
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.CHAT,
+            "XMPP",
+            _("Alerts as chat messages on your own XMPP server"),
+            parent,
+        )
+
+    def _setup_fields(self):
+        self.jid_field = LabeledLineEdit(
+            _("Sending Account"), placeholder="monitor@example.com", min_width=300
+        )
+        self._add_field(self.jid_field)
+        self.password_field = LabeledLineEdit(_("Password"), is_password=True, min_width=300)
+        self._add_field(self.password_field)
+        self.recipient_field = LabeledLineEdit(
+            _("Recipient"), placeholder="me@example.com", min_width=300
+        )
+        self._add_field(self.recipient_field)
+
+    def _hint_text(self) -> str:
+        if not XMPP_AVAILABLE:
+            return _("XMPP needs the slixmpp package: uv pip install slixmpp")
+        return _("Use a separate account for sending; the proxy setting doesn't apply to XMPP.")
+
+    def get_config(self) -> XmppConfig:
+        return XmppConfig(
+            enabled=self.enable_switch.isChecked(),
+            jid=self.jid_field.text().strip(),
+            password=self.password_field.text(),
+            recipient=self.recipient_field.text().strip(),
+        )
+
+    def set_config(self, config: XmppConfig):
+        self.enable_switch.setChecked(config.enabled)
+        self.jid_field.set_text(config.jid)
+        self.password_field.set_text(config.password)
+        self.recipient_field.set_text(config.recipient)
+        self._on_enabled_changed(config.enabled)
+
+
+class EmailSettingCard(ChannelSettingCard):
+    """SMTP account that sends notifications as emails."""
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.MAIL,
+            _("Email"),
+            _("Alerts as emails through an SMTP server"),
+            parent,
+        )
+
+    def _setup_fields(self):
+        self.host_field = LabeledLineEdit(
+            _("SMTP Server"), placeholder="smtp.example.com", min_width=300
+        )
+        self._add_field(self.host_field)
+        self.port_spin = LabeledSpinBox(_("Port"), 1, 65535, EmailConfig.smtp_port)
+        self._add_field(self.port_spin)
+        self.username_field = LabeledLineEdit(_("Username"), min_width=300)
+        self._add_field(self.username_field)
+        self.password_field = LabeledLineEdit(_("Password"), is_password=True, min_width=300)
+        self._add_field(self.password_field)
+        self.sender_field = LabeledLineEdit(
+            _("From"), placeholder=_("Username when empty"), min_width=300
+        )
+        self._add_field(self.sender_field)
+        self.recipient_field = LabeledLineEdit(
+            _("Recipient"), placeholder="me@example.com", min_width=300
+        )
+        self._add_field(self.recipient_field)
+
+    def _hint_text(self) -> str:
+        return _(
+            "Port 465 uses SSL, other ports STARTTLS. Many providers need an app password; "
+            "the proxy setting doesn't apply to email."
+        )
+
+    def get_config(self) -> EmailConfig:
+        return EmailConfig(
+            enabled=self.enable_switch.isChecked(),
+            smtp_host=self.host_field.text().strip(),
+            smtp_port=self.port_spin.value(),
+            username=self.username_field.text().strip(),
+            password=self.password_field.text(),
+            sender=self.sender_field.text().strip(),
+            recipient=self.recipient_field.text().strip(),
+        )
+
+    def set_config(self, config: EmailConfig):
+        self.enable_switch.setChecked(config.enabled)
+        self.host_field.set_text(config.smtp_host)
+        self.port_spin.set_value(config.smtp_port)
+        self.username_field.set_text(config.username)
+        self.password_field.set_text(config.password)
+        self.sender_field.set_text(config.sender)
+        self.recipient_field.set_text(config.recipient)
+        self._on_enabled_changed(config.enabled)
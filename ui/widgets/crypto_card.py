@@ -14,7 +14,17 @@ from PyQt6.QtWidgets import (
 from qfluentwidgets import CardWidget, TransparentToolButton
 from qfluentwidgets import FluentIcon as FIF
 
+from config.settings import PAIR_UPDATE_INTERVALS, get_settings_manager
+from core.chainlink_client import get_explorer_url, is_oracle_pair
+from core.coingecko import get_coingecko_service
+from core.display_prefs import COLOR_THRESHOLDS, DECIMAL_CHOICES, DEFAULT_DISPLAY_PREFS
+from core.funding_rates import get_funding_rate_service
+from core.highlight_rules import RULE_THRESHOLDS, HighlightRule, parse_rules
 from core.i18n import _
+from core.market_indices import is_index_pair
+from core.pool_client import is_pool_pair
+from core.token_unlocks import get_token_unlock_service
+from core.virtual_pairs import is_virtual_pair
 from ui.widgets.hover_card import HoverCard
 
 logger = logging.getLogger(__name__)
@@ -27,12 +37,22 @@ class CryptoCard(CardWidget):
     add_alert_requested = pyqtSignal(str)
     view_alerts_requested = pyqtSignal(str)
     browser_opened_requested = pyqtSignal(str)
+    rename_requested = pyqtSignal(str)
+    display_prefs_changed = pyqtSignal(str, dict)  # pair, display preferences
+    highlight_rules_changed = pyqtSignal(str, list)  # pair, highlight rule dicts
+    pin_toggled = pyqtSignal(str, bool)  # pair, pinned to the system tray
 
     def __init__(self, pair: str, parent: QWidget | None = None):
         super().__init__(parent)
         self.pair = pair
         self._edit_mode = False
         self._current_percentage = "0.00%"
+        # Highlight rule the pair triggers; the background blinks while it flashes
+        self._highlight = None
+        self._flash_on = False
+        self._flash_timer = QTimer(self)
+        self._flash_timer.setInterval(500)
+        self._flash_timer.timeout.connect(self._toggle_flash)
         self._loaded_icon_url = None
         self._icon_source_index = 0
         self._icon_sources_to_try = []
@@ -49,17 +69,65 @@ class CryptoCard(CardWidget):
         self.hover_card.update_theme(self._theme_mode)
 
     def update_state(self, state):
-        self.update_price(state.current_price, state.trend, state.color)
-        self.update_percentage(state.percentage)
+        hints = state.display_hints
+        price = state.current_price
+        if hints is not None:
+            price = hints.price_text
+        elif state.fiat_price is not None:
+            from core.fx_rates import format_fiat
+
+            price = format_fiat(state.fiat_price, state.fiat_currency)
+        elif is_index_pair(self.pair) and price >= 1_000_000:
+            from core.utils import format_compact
+
+            # Market cap indices
+            price = f"${format_compact(price)}"
+        if hints is not None and hints.neutral:
+            self.update_price(price, "", "#333333" if self._theme_mode == "light" else "#FFFFFF")
+        else:
+            self.update_price(price, state.trend, state.color)
+        self._set_highlight(state.highlight)
+        self.update_percentage(
+            hints.percentage_text if hints else state.percentage,
+            neutral=hints is not None and hints.neutral,
+        )
+        if state.highlight is not None:
+            self.percentage_label.setStyleSheet(
+                f"font-size: 11px; font-weight: 600; color: {state.highlight.color};"
+            )
+        if hints is not None and hints.volume_text:
+            self.percentage_label.setText(
+                f"{self.percentage_label.text()} · {_('Vol')} {hints.volume_text}"
+            )
 
         self._hover_data["high"] = state.high_24h
         self._hover_data["low"] = state.low_24h
         self._hover_data["quote_volume"] = state.quote_volume_24h
         self._hover_data["amplitude"] = state.amplitude_24h
+        if state.vwap is not None and state.vwap_distance_pct is not None:
+            from core.utils import format_price
+
+            distance = state.vwap_distance_pct
+            self._hover_data["vwap"] = f"{format_price(state.vwap)} ({distance:+.2f}%)"
+        if state.oracle_price is not None and state.oracle_deviation_pct is not None:
+            from core.utils import format_price
+
+            deviation = state.oracle_deviation_pct
+            self._hover_data["oracle"] = (
+                f"Chainlink {format_price(state.oracle_price)} ({deviation:+.2f}%)"
+            )
+        else:
+            self._hover_data["oracle"] = None
+        self._hover_data["volatility"] = self._format_volatility(state.indicators)
+        self._hover_data["day_range"] = self._format_day_range(state)
 
         from core.utils import get_display_name
 
-        display_text = get_display_name(self.pair, state.display_name, short=True)
+        display_text = (
+            state.alias
+            or (hints and hints.symbol)
+            or get_display_name(self.pair, state.display_name, short=True)
+        )
         self.symbol_label.setText(display_text)
 
         if state.icon_url and state.icon_url != self._loaded_icon_url:
@@ -68,6 +136,30 @@ class CryptoCard(CardWidget):
         if self.hover_card.isVisible():
             self._update_hover_card()
 
+    @staticmethod
+    def _format_volatility(indicators) -> str:
+        """ATR, realized volatility and the current candle's move in ATRs."""
+        if indicators is None or indicators.atr_pct is None:
+            return "-"
+        parts = [f"ATR ({indicators.interval}) {indicators.atr_pct:.2f}%"]
+        if indicators.realized_volatility is not None:
+            parts.append(f"RV {indicators.realized_volatility:.0f}%")
+        if indicators.move_atr is not None:
+            parts.append(f"{_('Move')} {indicators.move_atr:+.1f}× ATR")
+        return " · ".join(parts)
+
+    @staticmethod
+    def _format_day_range(state) -> str:
+        """Session low/high and the price's position between them."""
+        if state.session_low is None or state.session_high is None:
+            return "-"
+        from core.utils import format_price
+
+        text = f"{format_price(state.session_low)} – {format_price(state.session_high)}"
+        if state.range_position is not None:
+            text += f" ({state.range_position:.0f}%)"
+        return text
+
     def enterEvent(self, event):
         from config.settings import get_settings_manager
 
@@ -103,11 +195,20 @@ class CryptoCard(CardWidget):
         super().leaveEvent(event)
 
     def _update_hover_card(self):
+        from config.settings import get_settings_manager
+
         parts = self.pair.split("-")
         quote_currency = parts[1] if len(parts) > 1 else ""
 
         if self.pair.startswith("chain:"):
             quote_currency = "USD"
+        elif (
+            is_virtual_pair(self.pair)
+            or is_index_pair(self.pair)
+            or is_pool_pair(self.pair)
+            or is_oracle_pair(self.pair)
+        ):
+            quote_currency = ""
 
         self.hover_card.update_data(
             high=self._hover_data["high"],
@@ -115,6 +216,14 @@ class CryptoCard(CardWidget):
             volume=self._hover_data["quote_volume"],
             quote_currency=quote_currency,
             amplitude=self._hover_data.get("amplitude", "0.00%"),
+            vwap=self._hover_data.get("vwap", "-"),
+            volatility=self._hover_data.get("volatility", "-"),
+            day_range=self._hover_data.get("day_range", "-"),
+            market_data=get_coingecko_service().get_market_data(self.pair),
+            funding=get_funding_rate_service().get_comparison(self.pair),
+            funding_threshold=get_settings_manager().settings.funding_spread_threshold,
+            oracle=self._hover_data.get("oracle"),
+            unlock=get_token_unlock_service().get_unlock(self.pair),
         )
 
     def _setup_ui(self):
@@ -152,7 +261,8 @@ class CryptoCard(CardWidget):
 
         from core.utils import get_display_name
 
-        symbol = get_display_name(self.pair, short=True)
+        alias = get_settings_manager().settings.pair_aliases.get(self.pair)
+        symbol = alias or get_display_name(self.pair, short=True)
 
         self.symbol_label = QLabel(symbol)
         self.symbol_label.setStyleSheet(f"font-weight: bold; font-size: 12px; color: {text_color};")
@@ -184,7 +294,7 @@ class CryptoCard(CardWidget):
         cache_dir = settings_manager.config_dir / "icon_cache"
         cache_dir.mkdir(exist_ok=True)
 
-        if self.pair.startswith("chain:"):
+        if self.pair.startswith(("chain:", "pool:", "oracle:")):
             parts = self.pair.split(":")
             filename = parts[2] if len(parts) >= 3 else "unknown"
             return str(cache_dir / f"{filename}{ext}")
@@ -219,7 +329,10 @@ class CryptoCard(CardWidget):
         return False
 
     def _load_icon(self, url_override: str = None):
-        if self.pair.startswith("chain:") and not url_override:
+        if is_virtual_pair(self.pair) or is_index_pair(self.pair):
+            return
+
+        if self.pair.startswith(("chain:", "pool:", "oracle:")) and not url_override:
             if self._load_from_cache():
                 return
             return
@@ -420,6 +533,17 @@ class CryptoCard(CardWidget):
 
         settings = get_settings_manager().settings
 
+        if self._highlight is not None:
+            if self._flash_on:
+                self.setStyleSheet("")
+                return
+            c = QColor(self._highlight.color)
+            self.setStyleSheet(
+                f"CryptoCard {{ background-color: rgba({c.red()}, {c.green()}, {c.blue()}, 0.40); "
+                f"border: 1px solid {self._highlight.color}; border-radius: 10px; }}"
+            )
+            return
+
         if not settings.dynamic_background:
             self.setStyleSheet("")
             return
@@ -449,11 +573,27 @@ class CryptoCard(CardWidget):
             f"border: 1px solid rgba(0,0,0,0.05); border-radius: 10px; }}"
         )
 
-    def update_percentage(self, percentage: str):
+    def _set_highlight(self, highlight):
+        self._highlight = highlight
+        if highlight is not None and highlight.flash:
+            if not self._flash_timer.isActive():
+                self._flash_timer.start()
+        else:
+            self._flash_timer.stop()
+            self._flash_on = False
+
+    def _toggle_flash(self):
+        self._flash_on = not self._flash_on
+        self.refresh_style()
+
+    def update_percentage(self, percentage: str, neutral: bool = False):
         self._current_percentage = percentage
         self.percentage_label.setText(percentage)
 
-        if percentage.startswith("+"):
+        if neutral:
+            neutral_color = "#333333" if self._theme_mode == "light" else "#FFFFFF"
+            self.percentage_label.setStyleSheet(f"font-size: 11px; color: {neutral_color};")
+        elif percentage.startswith("+"):
             self.percentage_label.setStyleSheet(f"font-size: 11px; color: {self._color_up};")
         elif percentage.startswith("-"):
             self.percentage_label.setStyleSheet(f"font-size: 11px; color: {self._color_down};")
@@ -469,7 +609,11 @@ class CryptoCard(CardWidget):
 
     def mouseDoubleClickEvent(self, event: QMouseEvent):
         if event.button() == Qt.MouseButton.LeftButton:
-            if self.pair.startswith("chain:"):
+            if is_oracle_pair(self.pair):
+                url = get_explorer_url(self.pair)
+                if url:
+                    QDesktopServices.openUrl(QUrl(url))
+            elif self.pair.startswith(("chain:", "pool:")):
                 parts = self.pair.split(":")
                 if len(parts) >= 3:
                     net = parts[1]
@@ -493,6 +637,36 @@ class CryptoCard(CardWidget):
         view_alerts_action.triggered.connect(lambda: self.view_alerts_requested.emit(self.pair))
         menu.addAction(view_alerts_action)
 
+        settings_manager = get_settings_manager()
+        current_interval = settings_manager.settings.pair_update_intervals.get(self.pair, 0)
+        frequency_menu = RoundMenu(_("Update Frequency"), self)
+        frequency_menu.setIcon(FIF.SPEED_HIGH)
+        for seconds in PAIR_UPDATE_INTERVALS:
+            text = _("Realtime") if seconds == 0 else _("Every {seconds}s").format(seconds=seconds)
+            action = Action(text, self)
+            action.setCheckable(True)
+            action.setChecked(seconds == current_interval)
+            action.triggered.connect(
+                lambda _checked, s=seconds: settings_manager.update_pair_update_interval(
+                    self.pair, s
+                )
+            )
+            frequency_menu.addAction(action)
+        menu.addMenu(frequency_menu)
+
+        menu.addMenu(self._display_menu(settings_manager.settings.pair_display_prefs))
+        menu.addMenu(self._highlight_menu(settings_manager.settings.pair_highlight_rules))
+
+        rename_action = Action(FIF.EDIT, _("Rename..."), self)
+        rename_action.triggered.connect(lambda: self.rename_requested.emit(self.pair))
+        menu.addAction(rename_action)
+
+        pin_action = Action(FIF.PIN, _("Pin to Tray"), self)
+        pin_action.setCheckable(True)
+        pin_action.setChecked(self.pair in settings_manager.settings.pinned_pairs)
+        pin_action.triggered.connect(lambda checked: self.pin_toggled.emit(self.pair, checked))
+        menu.addAction(pin_action)
+
         menu.addSeparator()
 
         open_browser_action = Action(FIF.GLOBE, _("Open in Browser"), self)
@@ -505,6 +679,90 @@ class CryptoCard(CardWidget):
 
         menu.exec(event.globalPos())
 
+    def _display_menu(self, all_prefs: dict):
+        """Submenu editing the pair's display preferences."""
+        from qfluentwidgets import Action, RoundMenu
+
+        prefs = {**DEFAULT_DISPLAY_PREFS, **all_prefs.get(self.pair, {})}
+
+        def set_pref(key, value):
+            self.display_prefs_changed.emit(self.pair, {**prefs, key: value})
+
+        menu = RoundMenu(_("Display"), self)
+        menu.setIcon(FIF.FONT)
+
+        decimals_menu = RoundMenu(_("Decimal Places"), self)
+        for decimals in DECIMAL_CHOICES:
+            action = Action(_("Auto") if decimals is None else str(decimals), self)
+            action.setCheckable(True)
+            action.setChecked(decimals == prefs["decimals"])
+            action.triggered.connect(lambda _checked, d=decimals: set_pref("decimals", d))
+            decimals_menu.addAction(action)
+        menu.addMenu(decimals_menu)
+
+        threshold_menu = RoundMenu(_("Color Threshold"), self)
+        for threshold in COLOR_THRESHOLDS:
+            action = Action(_("Off") if threshold == 0 else f"±{threshold:g}%", self)
+            action.setCheckable(True)
+            action.setChecked(threshold == prefs["color_threshold"])
+            action.triggered.connect(
+                lambda _checked, t=threshold: set_pref("color_threshold", t)
+            )
+            threshold_menu.addAction(action)
+        menu.addMenu(threshold_menu)
+
+        for key, text in (("show_volume", _("Show Volume")), ("invert", _("Invert Quote"))):
+            action = Action(text, self)
+            action.setCheckable(True)
+            action.setChecked(prefs[key])
+            action.triggered.connect(lambda checked, k=key: set_pref(k, checked))
+            menu.addAction(action)
+        return menu
+
+    def _highlight_menu(self, all_rules: dict):
+        """Submenu toggling the pair's highlight rules."""
+        from dataclasses import replace
+
+        from qfluentwidgets import Action, RoundMenu
+
+        rules = parse_rules(all_rules.get(self.pair))
+        active = {(rule.direction, rule.threshold) for rule in rules}
+        flash = any(rule.flash for rule in rules)
+
+        def set_rules(new_rules):
+            self.highlight_rules_changed.emit(self.pair, [rule.to_dict() for rule in new_rules])
+
+        def toggle_rule(direction, threshold, checked):
+            key = (direction, threshold)
+            kept = [rule for rule in rules if (rule.direction, rule.threshold) != key]
+            if checked:
+                kept.append(HighlightRule(direction, threshold, flash=flash))
+            set_rules(kept)
+
+        menu = RoundMenu(_("Highlight"), self)
+        menu.setIcon(FIF.PALETTE)
+        directions = (("up", _("Up ≥ {threshold}%")), ("down", _("Down ≥ {threshold}%")))
+        for direction, text in directions:
+            for threshold in RULE_THRESHOLDS:
+                action = Action(text.format(threshold=f"{threshold:g}"), self)
+                action.setCheckable(True)
+                action.setChecked((direction, threshold) in active)
+                action.triggered.connect(
+                    lambda checked, d=direction, t=threshold: toggle_rule(d, t, checked)
+                )
+                menu.addAction(action)
+            menu.addSeparator()
+
+        flash_action = Action(_("Flash"), self)
+        flash_action.setCheckable(True)
+        flash_action.setChecked(flash)
+        flash_action.setEnabled(bool(rules))
+        flash_action.triggered.connect(
+            lambda checked: set_rules([replace(rule, flash=checked) for rule in rules])
+        )
+        menu.addAction(flash_action)
+        return menu
+
     def _fetch_history_data(self):
         import time
 
@@ -527,6 +785,15 @@ class CryptoCard(CardWidget):
                     self.hover_card.update_chart(data, current_period)
                     return
 
+        if settings.hover_show_chart:
+            from core.sparkline import get_sparkline_service
+
+            # Prices recorded since startup render instantly once they cover the period
+            data = get_sparkline_service().get_sparkline(self.pair, settings.kline_period)
+            if data:
+                self.hover_card.update_chart(data, current_period)
+                return
+
         self.hover_card.set_chart_loading()
 
         if not settings.hover_show_chart:
@@ -574,8 +841,22 @@ class CryptoCard(CardWidget):
                         limit = 42
 
                     klines = client.fetch_klines(self.pair, interval, limit)
-
                     if not klines:
+                        from core.candle_aggregator import get_candle_aggregator
+
+                        # Sources without klines fall back to candles built from ticks
+                        klines = get_candle_aggregator().fetch_klines(self.pair, interval, limit)
+
+                    market_data = get_coingecko_service().get_market_data(self.pair)
+                    if (
+                        not klines
+                        and period_setting == "7d"
+                        and market_data
+                        and market_data.sparkline_7d
+                    ):
+                        # CoinGecko's 7-day sparkline (in USD) covers sources without klines
+                        self.signals.data_ready.emit(market_data.sparkline_7d, "")
+                    elif not klines:
                         self.signals.data_ready.emit([], "No data")
                     else:
                         closes = [k["close"] for k in klines]
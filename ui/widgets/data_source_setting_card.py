@@ -20,6 +20,7 @@ class DataSourceSettingCard(SettingCard):
         self.combo = ComboBox(self)
         self.combo.addItem("OKX", "OKX")
         self.combo.addItem("Binance", "Binance")
+        self.combo.addItem(_("Simulated"), "Simulated")
 
         self.hBoxLayout.addWidget(self.combo, 0, Qt.AlignmentFlag.AlignRight)
         self.hBoxLayout.addSpacing(16)
@@ -28,12 +29,7 @@ class DataSourceSettingCard(SettingCard):
         self.combo.currentIndexChanged.connect(self._on_changed)
 
     def _load_setting(self):
-        settings = get_settings_manager().settings
-        source = settings.data_source
-        if source.upper() == "BINANCE":
-            self.combo.setCurrentIndex(1)
-        else:
-            self.combo.setCurrentIndex(0)
+        self.set_data_source(get_settings_manager().settings.data_source)
 
     def _on_changed(self, index):
         # We don't save immediately here, we let the save button in settings window handle it
@@ -41,10 +37,12 @@ class DataSourceSettingCard(SettingCard):
         pass
 
     def get_data_source(self) -> str:
-        return self.combo.currentText()  # "OKX" or "Binance"
+        return self.combo.currentData()  # "OKX", "Binance" or "Simulated"
 
     def set_data_source(self, source: str):
         if source.upper() == "BINANCE":
             self.combo.setCurrentIndex(1)
+        elif source.upper() == "SIMULATED":
+            self.combo.setCurrentIndex(2)
         else:
             self.combo.setCurrentIndex(0)
@@ -0,0 +1,308 @@
+"""
+Setting card and dialog for DCA (recurring buy) plans.
+"""
+
+from PyQt6.QtCore import Qt, pyqtSignal
+from PyQt6.QtWidgets import QHBoxLayout, QListWidgetItem, QVBoxLayout, QWidget
+from qfluentwidgets import (
+    BodyLabel,
+    ComboBox,
+    Dialog,
+    ExpandGroupSettingCard,
+    FluentIcon,
+    LineEdit,
+    PrimaryPushButton,
+    SpinBox,
+    SwitchButton,
+    ToolButton,
+    isDarkTheme,
+)
+from qfluentwidgets import ListWidget as FluentListWidget
+
+from config.settings import DcaPlan, get_settings_manager
+from core.dca_planner import CADENCES, get_dca_planner
+from core.i18n import _
+
+
+def _weekday_names() -> list[str]:
+    return [
+        _("Monday"),
+        _("Tuesday"),
+        _("Wednesday"),
+        _("Thursday"),
+        _("Friday"),
+        _("Saturday"),
+        _("Sunday"),
+    ]
+
+
+def _cadence_names() -> dict[str, str]:
+    return {"daily": _("Daily"), "weekly": _("Weekly"), "monthly": _("Monthly")}
+
+
+class DcaPlanDialog(Dialog):
+    """Fluent Design dialog for adding a DCA plan."""
+
+    def __init__(self, parent: QWidget | None = None, available_pairs: list[str] | None = None):
+        super().__init__(title=_("Add DCA Plan"), content="", parent=parent)
+        self._plan: DcaPlan | None = None
+        self._available_pairs = available_pairs or get_settings_manager().settings.crypto_pairs
+
+        self._setup_content()
+
+        self.setFixedSize(420, 400)
+        flags = (
+            Qt.WindowType.Dialog
+            | Qt.WindowType.WindowTitleHint
+            | Qt.WindowType.WindowCloseButtonHint
+        )
+        if parent and (parent.windowFlags() & Qt.WindowType.WindowStaysOnTopHint):
+            flags |= Qt.WindowType.WindowStaysOnTopHint
+        self.setWindowFlags(flags)
+
+    def _add_row(self, layout: QVBoxLayout, label: str, widget: QWidget):
+        row = QHBoxLayout()
+        row_label = BodyLabel(label)
+        row_label.setFixedWidth(100)
+        row.addWidget(row_label)
+        row.addWidget(widget, 1)
+        layout.addLayout(row)
+
+    def _setup_content(self):
+        content_layout = QVBoxLayout()
+        content_layout.setSpacing(16)
+
+        from core.utils import get_display_name
+
+        self.pair_combo = ComboBox()
+        for pair in self._available_pairs:
+            self.pair_combo.addItem(get_display_name(pair), userData=pair)
+        self._add_row(content_layout, _("Trading Pair:"), self.pair_combo)
+
+        self.amount_edit = LineEdit()
+        self.amount_edit.setPlaceholderText(_("Amount in quote currency"))
+        self.amount_edit.textChanged.connect(self._validate_input)
+        self._add_row(content_layout, _("Amount:"), self.amount_edit)
+
+        self.cadence_combo = ComboBox()
+        for cadence, name in _cadence_names().items():
+            self.cadence_combo.addItem(name, userData=cadence)
+        self.cadence_combo.setCurrentIndex(CADENCES.index("weekly"))
+        self.cadence_combo.currentIndexChanged.connect(self._on_cadence_changed)
+        self._add_row(content_layout, _("Repeat:"), self.cadence_combo)
+
+        self.day_combo = ComboBox()
+        self._add_row(content_layout, _("Day:"), self.day_combo)
+
+        self.hour_spin = SpinBox()
+        self.hour_spin.setRange(0, 23)
+        self.hour_spin.setValue(9)
+        self.hour_spin.setSuffix(":00")
+        self._add_row(content_layout, _("Time:"), self.hour_spin)
+
+        self.textLayout.addLayout(content_layout)
+        self._on_cadence_changed()
+
+        self.yesButton.setText(_("Add"))
+        self.yesButton.setEnabled(False)
+        self.cancelButton.setText(_("Cancel"))
+        self.yesButton.clicked.connect(self._on_confirm)
+
+    def _on_cadence_changed(self):
+        cadence = self.cadence_combo.currentData()
+        self.day_combo.clear()
+        if cadence == "weekly":
+            self.day_combo.addItems(_weekday_names())
+        elif cadence == "monthly":
+            # Capped at 28 so every month has the day
+            self.day_combo.addItems([str(day) for day in range(1, 29)])
+        self.day_combo.setEnabled(cadence != "daily")
+
+    def _amount(self) -> float | None:
+        try:
+            amount = float(self.amount_edit.text().replace(",", ""))
+        except ValueError:
+            return None
+        return amount if amount > 0 else None
+
+    def _validate_input(self, text: str = ""):
+        self.yesButton.setEnabled(self._amount() is not None and self.pair_combo.count() > 0)
+
+    def _on_confirm(self):
+        cadence = self.cadence_combo.currentData()
+        day_index = max(self.day_combo.currentIndex(), 0)
+        self._plan = DcaPlan(
+            pair=self.pair_combo.currentData(),
+            amount=self._amount() or 0.0,
+            cadence=cadence,
+            hour=self.hour_spin.value(),
+            weekday=day_index if cadence == "weekly" else 0,
+            day_of_month=day_index + 1 if cadence == "monthly" else 1,
+        )
+
+    def get_plan(self) -> DcaPlan | None:
+        return self._plan
+
+    @staticmethod
+    def create_plan(
+        parent: QWidget | None = None, available_pairs: list[str] | None = None
+    ) -> DcaPlan | None:
+        """
+        Show dialog and return the configured plan.
+
+        Returns:
+            The new DcaPlan (not yet saved), or None if cancelled.
+        """
+        dialog = DcaPlanDialog(parent=parent, available_pairs=available_pairs)
+        if dialog.exec():
+            return dialog.get_plan()
+        return None
+
+
+class DcaPlanListItem(QWidget):
+    delete_clicked = pyqtSignal(str)
+    toggle_clicked = pyqtSignal(str)
+
+    def __init__(self, plan: DcaPlan, parent: QWidget | None = None):
+        super().__init__(parent)
+        self.plan = plan
+        self._setup_ui()
+
+    def _setup_ui(self):
+        layout = QHBoxLayout(self)
+        layout.setContentsMargins(8, 4, 8, 4)
+        layout.setSpacing(12)
+
+        self.switch = SwitchButton()
+        self.switch.setChecked(self.plan.enabled)
+        self.switch.setOnText("")
+        self.switch.setOffText("")
+        self.switch.checkedChanged.connect(lambda: self.toggle_clicked.emit(self.plan.id))
+        layout.addWidget(self.switch)
+
+        info_layout = QVBoxLayout()
+        info_layout.setSpacing(2)
+
+        from core.utils import format_price, get_display_name
+
+        is_dark = isDarkTheme()
+        quote = self.plan.pair.partition("-")[2]
+        name = get_display_name(self.plan.pair)
+        self.title = BodyLabel(f"{name} · {self.plan.amount:g} {quote}")
+        title_color = "#FFFFFF" if is_dark else "#333333"
+        self.title.setStyleSheet(f"font-weight: bold; font-size: 13px; color: {title_color};")
+        info_layout.addWidget(self.title)
+
+        details = f"{self._schedule_text()} | {_('Runs')}: {self.plan.runs}"
+        if self.plan.runs:
+            details += f" | {_('Avg')} {format_price(self.plan.average_price)}"
+        self.details = BodyLabel(details)
+        details_color = "#AAAAAA" if is_dark else "#555555"
+        self.details.setStyleSheet(f"font-size: 11px; color: {details_color};")
+        info_layout.addWidget(self.details)
+
+        layout.addLayout(info_layout, 1)
+
+        self.delete_btn = ToolButton(FluentIcon.DELETE)
+        self.delete_btn.setFixedSize(28, 28)
+        self.delete_btn.clicked.connect(lambda: self.delete_clicked.emit(self.plan.id))
+        layout.addWidget(self.delete_btn)
+
+    def _schedule_text(self) -> str:
+        time_text = f"{self.plan.hour:02d}:00"
+        cadence_text = _cadence_names().get(self.plan.cadence, self.plan.cadence)
+        if self.plan.cadence == "weekly":
+            return f"{cadence_text} {_weekday_names()[self.plan.weekday % 7]} {time_text}"
+        if self.plan.cadence == "monthly":
+            return f"{cadence_text} {self.plan.day_of_month} {time_text}"
+        return f"{cadence_text} {time_text}"
+
+
+class DcaSettingCard(ExpandGroupSettingCard):
+    plans_changed = pyqtSignal()
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.CALENDAR,
+            _("DCA Plans"),
+            _("Get reminded of recurring buys and track the accumulated position"),
+            parent,
+        )
+        self._settings_manager = get_settings_manager()
+        self._dca_planner = get_dca_planner()
+
+        self._setup_ui()
+        self._load_plans()
+
+    def _setup_ui(self):
+        container = QWidget()
+        layout = QVBoxLayout(container)
+        layout.setContentsMargins(48, 18, 48, 18)
+        layout.setSpacing(16)
+
+        self.plans_list = FluentListWidget()
+        self.plans_list.setMinimumHeight(150)
+        self.plans_list.setMaximumHeight(300)
+        layout.addWidget(self.plans_list)
+
+        btn_layout = QHBoxLayout()
+        self.add_btn = PrimaryPushButton(FluentIcon.ADD, _("Add Plan"))
+        self.add_btn.setFixedWidth(120)
+        self.add_btn.clicked.connect(self._add_plan)
+        btn_layout.addWidget(self.add_btn)
+        btn_layout.addStretch()
+        layout.addLayout(btn_layout)
+
+        self.addGroupWidget(container)
+
+    def _load_plans(self):
+        self.plans_list.clear()
+        for plan in self._dca_planner.get_plans():
+            self._add_plan_item(plan)
+
+    def _add_plan_item(self, plan: DcaPlan):
+        item = QListWidgetItem()
+        item.setData(256, plan.id)
+
+        widget = DcaPlanListItem(plan)
+        widget.delete_clicked.connect(self._on_delete_plan)
+        widget.toggle_clicked.connect(self._on_toggle_plan)
+
+        item.setSizeHint(widget.sizeHint())
+        self.plans_list.addItem(item)
+        self.plans_list.setItemWidget(item, widget)
+
+    def _add_plan(self):
+        plan = DcaPlanDialog.create_plan(
+            parent=self.window(),
+            available_pairs=self._settings_manager.settings.crypto_pairs,
+        )
+        if plan:
+            plan = self._dca_planner.add_plan(
+                plan.pair,
+                plan.amount,
+                plan.cadence,
+                plan.hour,
+                plan.weekday,
+                plan.day_of_month,
+            )
+            self._add_plan_item(plan)
+            self.plans_changed.emit()
+
+    def _on_delete_plan(self, plan_id: str):
+        for i in range(self.plans_list.count()):
+            if self.plans_list.item(i).data(256) == plan_id:
+                self.plans_list.takeItem(i)
+                break
+        self._dca_planner.remove_plan(plan_id)
+        self.plans_changed.emit()
+
+    def _on_toggle_plan(self, plan_id: str):
+        for plan in self._dca_planner.get_plans():
+            if plan.id == plan_id:
+                self._dca_planner.set_plan_enabled(plan_id, not plan.enabled)
+                self.plans_changed.emit()
+                break
+
+    def refresh(self):
+        self._load_plans()
@@ -0,0 +1,107 @@
+"""
+Dialog listing the live WebSocket connections and their health.
+"""
+
+from PyQt6.QtCore import QTimer
+from PyQt6.QtGui import QGuiApplication
+from PyQt6.QtWidgets import QDialog, QHBoxLayout, QHeaderView, QTableWidgetItem, QVBoxLayout
+from qfluentwidgets import BodyLabel, FluentIcon, PushButton, TableWidget
+
+from core.diagnostics import connection_details, format_rtt, format_uptime
+from core.i18n import _
+
+
+class DiagnosticsDialog(QDialog):
+    """Connection inspector, refreshed every second while open."""
+
+    REFRESH_INTERVAL_MS = 1000
+
+    def __init__(self, parent=None):
+        super().__init__(parent)
+        self.setWindowTitle(_("Connection Diagnostics"))
+        self.resize(860, 320)
+        self._details = []
+        self._setup_ui()
+
+        self._timer = QTimer(self)
+        self._timer.timeout.connect(self._refresh)
+        self._timer.start(self.REFRESH_INTERVAL_MS)
+        self.finished.connect(self._timer.stop)
+        self._refresh()
+
+    def _setup_ui(self):
+        layout = QVBoxLayout(self)
+        layout.setContentsMargins(20, 20, 20, 20)
+        layout.setSpacing(12)
+
+        self.status_label = BodyLabel("")
+        self.status_label.setWordWrap(True)
+        layout.addWidget(self.status_label)
+
+        self.table = TableWidget(self)
+        headers = [
+            _("Connection"),
+            _("Endpoint"),
+            _("Proxy"),
+            _("State"),
+            _("Uptime"),
+            _("Messages/s"),
+            _("Ping"),
+            _("Reconnects"),
+            _("Gaps"),
+            _("Last Error"),
+        ]
+        self.table.setColumnCount(len(headers))
+        self.table.setHorizontalHeaderLabels(headers)
+        self.table.verticalHeader().hide()
+        self.table.horizontalHeader().setSectionResizeMode(
+            QHeaderView.ResizeMode.ResizeToContents
+        )
+        self.table.horizontalHeader().setStretchLastSection(True)
+        self.table.setEditTriggers(TableWidget.EditTrigger.NoEditTriggers)
+        layout.addWidget(self.table, 1)
+
+        buttons = QHBoxLayout()
+        buttons.addStretch(1)
+        self.copy_btn = PushButton(FluentIcon.COPY, _("Copy Report"))
+        self.copy_btn.clicked.connect(self._copy_report)
+        buttons.addWidget(self.copy_btn)
+        layout.addLayout(buttons)
+
+    def _rows(self) -> list[list[str]]:
+        return [
+            [
+                info.name,
+                info.endpoint,
+                info.proxy,
+                info.state,
+                format_uptime(info.uptime),
+                f"{info.messages_per_second:.1f}",
+                format_rtt(info.ping_rtt_ms),
+                str(info.reconnect_count),
+                str(info.sequence_gaps),
+                info.last_error,
+            ]
+            for info in self._details
+        ]
+
+    def _refresh(self):
+        self._details = connection_details()
+        self.status_label.setText(
+            "" if self._details else _("No WebSocket connections are running.")
+        )
+        rows = self._rows()
+        self.table.setRowCount(len(rows))
+        for row, values in enumerate(rows):
+            for col, value in enumerate(values):
+                item = QTableWidgetItem(value)
+                item.setToolTip(value)
+                self.table.setItem(row, col, item)
+
+    def _copy_report(self):
+        """Copy the table as text, e.g. for a bug report; credentials are already masked."""
+        headers = [
+            self.table.horizontalHeaderItem(col).text() for col in range(self.table.columnCount())
+        ]
+        lines = [" | ".join(headers)] + [" | ".join(values) for values in self._rows()]
+        QGuiApplication.clipboard().setText("\n".join(lines))
@@ -0,0 +1,117 @@
+"""
+Setting card for scheduled price digests.
+"""
+
+from PyQt6.QtWidgets import QHBoxLayout, QVBoxLayout, QWidget
+from qfluentwidgets import (
+    BodyLabel,
+    ComboBox,
+    ExpandGroupSettingCard,
+    FluentIcon,
+    LineEdit,
+    SpinBox,
+    SwitchButton,
+)
+
+from config.settings import DigestConfig, get_settings_manager
+from core.digest import CADENCES, DEFAULT_TEMPLATE, PLACEHOLDERS
+from core.i18n import _
+
+
+def _cadence_names() -> dict[str, str]:
+    return {"hourly": _("Hourly"), "daily": _("Daily")}
+
+
+class DigestSettingCard(ExpandGroupSettingCard):
+    """Schedule and line template of the price digest; changes are saved right away."""
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.DATE_TIME,
+            _("Price Digest"),
+            _("A scheduled summary of the monitored pairs' prices and changes"),
+            parent,
+        )
+        self._settings_manager = get_settings_manager()
+
+        self._setup_ui()
+        self._load_config(self._settings_manager.settings.digest)
+        # Connected after loading, so loading doesn't save
+        self.enabled_switch.checkedChanged.connect(self._save)
+        self.cadence_combo.currentIndexChanged.connect(self._save)
+        self.hour_spin.valueChanged.connect(self._save)
+        self.minute_spin.valueChanged.connect(self._save)
+        self.template_edit.editingFinished.connect(self._save)
+
+    def _add_row(self, layout: QVBoxLayout, label: str, widget: QWidget):
+        row = QHBoxLayout()
+        row.addWidget(BodyLabel(label))
+        row.addStretch(1)
+        row.addWidget(widget)
+        layout.addLayout(row)
+
+    def _setup_ui(self):
+        container = QWidget()
+        layout = QVBoxLayout(container)
+        layout.setContentsMargins(48, 18, 48, 18)
+        layout.setSpacing(16)
+
+        self.enabled_switch = SwitchButton()
+        self._add_row(layout, _("Send price digests"), self.enabled_switch)
+
+        self.cadence_combo = ComboBox()
+        for cadence, name in _cadence_names().items():
+            self.cadence_combo.addItem(name, userData=cadence)
+        self._add_row(layout, _("Repeat:"), self.cadence_combo)
+
+        time_widget = QWidget()
+        time_layout = QHBoxLayout(time_widget)
+        time_layout.setContentsMargins(0, 0, 0, 0)
+        self.hour_spin = SpinBox()
+        self.hour_spin.setRange(0, 23)
+        self.hour_spin.setSuffix(" h")
+        time_layout.addWidget(self.hour_spin)
+        self.minute_spin = SpinBox()
+        self.minute_spin.setRange(0, 59)
+        self.minute_spin.setSuffix(" min")
+        time_layout.addWidget(self.minute_spin)
+        self._add_row(layout, _("Time:"), time_widget)
+
+        self.template_edit = LineEdit()
+        self.template_edit.setPlaceholderText(DEFAULT_TEMPLATE)
+        self.template_edit.setMinimumWidth(280)
+        self._add_row(layout, _("Line per pair:"), self.template_edit)
+
+        placeholders = " ".join("{" + name + "}" for name in PLACEHOLDERS)
+        hint = BodyLabel(f"{_('Placeholders:')} {placeholders}")
+        hint.setWordWrap(True)
+        hint.setStyleSheet("color: gray; font-size: 12px;")
+        layout.addWidget(hint)
+
+        self.addGroupWidget(container)
+
+    def _load_config(self, config: DigestConfig):
+        self.enabled_switch.setChecked(config.enabled)
+        cadence = config.cadence if config.cadence in CADENCES else "daily"
+        self.cadence_combo.setCurrentIndex(CADENCES.index(cadence))
+        self.hour_spin.setValue(config.hour)
+        self.minute_spin.setValue(config.minute)
+        self.template_edit.setText(config.template)
+        self._update_enabled_state()
+
+    def _update_enabled_state(self):
+        # Hourly digests go out at the chosen minute of every hour
+        self.hour_spin.setEnabled(self.cadence_combo.currentData() == "daily")
+
+    def get_config(self) -> DigestConfig:
+        return DigestConfig(
+            enabled=self.enabled_switch.isChecked(),
+            cadence=self.cadence_combo.currentData(),
+            hour=self.hour_spin.value(),
+            minute=self.minute_spin.value(),
+            template=self.template_edit.text().strip() or DEFAULT_TEMPLATE,
+        )
+
+    def _save(self):
+        self._update_enabled_state()
+        self._settings_manager.update_digest(self.get_config())
@@ -1,3 +1,5 @@
+from datetime import datetime
+
 from PyQt6.QtCore import Qt
 from PyQt6.QtGui import QColor
 from PyQt6.QtWidgets import (
@@ -25,6 +27,7 @@ class HoverCard(QWidget):
         self.setWindowFlags(Qt.WindowType.ToolTip | Qt.WindowType.FramelessWindowHint)
         self.setAttribute(Qt.WidgetAttribute.WA_TranslucentBackground)
         self.setAttribute(Qt.WidgetAttribute.WA_ShowWithoutActivating)
+        self._show_stats = True
 
         self._setup_ui()
 
@@ -52,12 +55,28 @@ class HoverCard(QWidget):
         self.low_label = self._create_label()
         self.amplitude_label = self._create_label()
         self.vol_label = self._create_label()
+        self.market_cap_label = self._create_label()
+        self.supply_label = self._create_label()
+        self.unlock_label = self._create_label()
+        self.funding_label = self._create_label()
+        self.oracle_label = self._create_label()
+        self.vwap_label = self._create_label()
+        self.range_label = self._create_label()
+        self.volatility_label = self._create_label()
 
         self.content_layout.addWidget(self.high_label)
         self.content_layout.addWidget(self.low_label)
         # Add amplitude between Low and Volume
         self.content_layout.addWidget(self.amplitude_label)
         self.content_layout.addWidget(self.vol_label)
+        self.content_layout.addWidget(self.market_cap_label)
+        self.content_layout.addWidget(self.supply_label)
+        self.content_layout.addWidget(self.unlock_label)
+        self.content_layout.addWidget(self.funding_label)
+        self.content_layout.addWidget(self.oracle_label)
+        self.content_layout.addWidget(self.vwap_label)
+        self.content_layout.addWidget(self.range_label)
+        self.content_layout.addWidget(self.volatility_label)
 
         # Chart Section
         self.chart_container = QStackedWidget()
@@ -95,6 +114,14 @@ class HoverCard(QWidget):
         volume: str,
         quote_currency: str,
         amplitude: str = "0.00%",
+        vwap: str = "-",
+        volatility: str = "-",
+        day_range: str = "-",
+        market_data=None,
+        funding=None,
+        funding_threshold: float = 0.0,
+        oracle: str | None = None,
+        unlock=None,
     ):
         """Update the displayed data."""
         # Use bold for keys
@@ -104,11 +131,75 @@ class HoverCard(QWidget):
         self.vol_label.setText(
             f"<b>{_('24h Vol')}:</b> {self._format_volume(volume)} {quote_currency}"
         )
+        self._update_market_data(market_data)
+        self._update_unlock(unlock)
+        self._update_funding(funding, funding_threshold)
+        # Oracle price of the asset (hidden when no feed is monitored)
+        self.oracle_label.setVisible(oracle is not None and self._show_stats)
+        self.oracle_label.setText(f"<b>{_('Oracle')}:</b> {oracle}")
+        self.vwap_label.setText(f"<b>{_('Session VWAP')}:</b> {vwap}")
+        self.range_label.setText(f"<b>{_('Day Range')}:</b> {day_range}")
+        self.volatility_label.setText(f"<b>{_('Volatility')}:</b> {volatility}")
 
         # Adjust size to fit content
         # Adjust size to fit content
         self.adjustSize()
 
+    def _update_market_data(self, market_data):
+        """Show CoinGecko market cap, rank and supply (hidden when unavailable)."""
+        self.market_cap_label.setVisible(market_data is not None and self._show_stats)
+        self.supply_label.setVisible(market_data is not None and self._show_stats)
+        if market_data is None:
+            return
+
+        market_cap = "-"
+        if market_data.market_cap:
+            market_cap = f"${self._format_volume(str(market_data.market_cap))}"
+        if market_data.market_cap_rank:
+            market_cap += f" (#{market_data.market_cap_rank})"
+        self.market_cap_label.setText(f"<b>{_('Market Cap')}:</b> {market_cap}")
+
+        supply = "-"
+        if market_data.circulating_supply:
+            supply = f"{self._format_volume(str(market_data.circulating_supply))} "
+            supply += market_data.symbol
+            if market_data.max_supply:
+                share = market_data.circulating_supply / market_data.max_supply * 100
+                supply += f" ({share:.0f}% {_('of max')})"
+        self.supply_label.setText(f"<b>{_('Circulating Supply')}:</b> {supply}")
+
+    def _update_unlock(self, unlock):
+        """Show the next scheduled token unlock (hidden when none is known)."""
+        self.unlock_label.setVisible(unlock is not None and self._show_stats)
+        if unlock is None:
+            return
+
+        text = self._format_volume(str(unlock.amount))
+        if unlock.supply_pct is not None:
+            text += f" ({unlock.supply_pct:.2f}%)"
+        if unlock.value is not None:
+            text += f" ≈ ${self._format_volume(str(unlock.value))}"
+        date = datetime.fromtimestamp(unlock.timestamp).strftime("%Y-%m-%d")
+        self.unlock_label.setText(f"<b>{_('Next Unlock')}:</b> {text} · {date}")
+
+    def _update_funding(self, funding, threshold: float):
+        """Show the perpetual's funding rate per exchange (hidden when unavailable)."""
+        self.funding_label.setVisible(funding is not None and self._show_stats)
+        if funding is None:
+            return
+
+        rates = " · ".join(
+            f"{rate.exchange} {rate.normalized_rate * 100:+.4f}%" for rate in funding.rates
+        )
+        text = f"<b>{_('Funding (8h)')}:</b> {rates}"
+        if len(funding.rates) > 1:
+            spread = f"{_('Spread')} {funding.spread * 100:.4f}%"
+            if funding.is_arbitrage(threshold):
+                # Highlight cross-venue funding arbitrage
+                spread = f"<span style='color: #FF9800;'>{spread}</span>"
+            text += f"<br>{spread}"
+        self.funding_label.setText(text)
+
     def update_chart(self, data: list[float], period: str = "24H", error: str = None):
         """Update the mini chart with historical data."""
         if error:
@@ -138,9 +229,13 @@ class HoverCard(QWidget):
             self.low_label,
             self.vol_label,
             self.amplitude_label,
+            self.vwap_label,
+            self.range_label,
+            self.volatility_label,
         ]
         for w in stats_widgets:
             w.setVisible(show_stats)
+        self._show_stats = show_stats
 
         # Chart
         self.chart_container.setVisible(show_chart)
@@ -177,11 +272,6 @@ class HoverCard(QWidget):
         except ValueError:
             return volume_str
 
-        if vol >= 1_000_000_000:
-            return f"{vol / 1_000_000_000:.2f}B"
-        elif vol >= 1_000_000:
-            return f"{vol / 1_000_000:.2f}M"
-        elif vol >= 1_000:
-            return f"{vol / 1_000:.2f}K"
-        else:
-            return f"{vol:.2f}"
+        from core.utils import format_compact
+
+        return format_compact(vol)
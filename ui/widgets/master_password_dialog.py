@@ -0,0 +1,117 @@
+"""
+Dialogs for the master password that encrypts the settings file.
+"""
+
+from PyQt6.QtCore import Qt
+from PyQt6.QtWidgets import QWidget
+from qfluentwidgets import CaptionLabel, Dialog, PasswordLineEdit
+
+from config.settings import SettingsManager
+from core.i18n import _
+
+
+def _dialog_flags(parent: QWidget | None) -> Qt.WindowType:
+    flags = (
+        Qt.WindowType.Dialog | Qt.WindowType.WindowTitleHint | Qt.WindowType.WindowCloseButtonHint
+    )
+    if parent and (parent.windowFlags() & Qt.WindowType.WindowStaysOnTopHint):
+        flags |= Qt.WindowType.WindowStaysOnTopHint
+    return flags
+
+
+class UnlockDialog(Dialog):
+    """Dialog asking for the master password on startup."""
+
+    def __init__(self, error: str = "", parent: QWidget | None = None):
+        super().__init__(
+            title=_("Unlock Crypto Monitor"),
+            content=_("Your settings are encrypted. Enter the master password to continue."),
+            parent=parent,
+        )
+
+        self.password_edit = PasswordLineEdit()
+        self.password_edit.setPlaceholderText(_("Master Password"))
+        self.textLayout.addWidget(self.password_edit)
+
+        self.error_label = CaptionLabel(error)
+        self.error_label.setTextColor("#D13438", "#FF99A4")
+        self.error_label.setVisible(bool(error))
+        self.textLayout.addWidget(self.error_label)
+
+        self.yesButton.setText(_("Unlock"))
+        self.cancelButton.setText(_("Quit"))
+
+        self.setFixedWidth(380)
+        self.setWindowFlags(_dialog_flags(parent))
+
+    @staticmethod
+    def unlock(settings_manager: SettingsManager, parent: QWidget | None = None) -> bool:
+        """
+        Ask for the master password until the settings unlock.
+
+        Returns:
+            True once unlocked, False if the user quit
+        """
+        error = ""
+        while True:
+            dialog = UnlockDialog(error, parent)
+            if not dialog.exec():
+                return False
+            if settings_manager.unlock(dialog.password_edit.text()):
+                return True
+            error = _("Wrong password, please try again.")
+
+
+class MasterPasswordDialog(Dialog):
+    """Dialog setting, changing or removing the master password."""
+
+    def __init__(self, has_password: bool, error: str = "", parent: QWidget | None = None):
+        content = (
+            _("Enter a new master password, or leave both fields empty to remove it.")
+            if has_password
+            else _(
+                "Encrypt your settings, alert rules and API keys with a password that is "
+                "asked for on every start. A forgotten password can't be recovered."
+            )
+        )
+        super().__init__(title=_("Master Password"), content=content, parent=parent)
+
+        self.password_edit = PasswordLineEdit()
+        self.password_edit.setPlaceholderText(_("New Password"))
+        self.textLayout.addWidget(self.password_edit)
+
+        self.confirm_edit = PasswordLineEdit()
+        self.confirm_edit.setPlaceholderText(_("Confirm Password"))
+        self.textLayout.addWidget(self.confirm_edit)
+
+        self.error_label = CaptionLabel(error)
+        self.error_label.setTextColor("#D13438", "#FF99A4")
+        self.error_label.setVisible(bool(error))
+        self.textLayout.addWidget(self.error_label)
+
+        self.yesButton.setText(_("Save"))
+        self.cancelButton.setText(_("Cancel"))
+
+        self.setFixedWidth(420)
+        self.setWindowFlags(_dialog_flags(parent))
+
+    @staticmethod
+    def get_password(has_password: bool, parent: QWidget | None = None) -> str | None:
+        """
+        Show the dialog and return the entered password.
+
+        Returns:
+            The new password (empty to remove it), or None if cancelled.
+        """
+        error = ""
+        while True:
+            dialog = MasterPasswordDialog(has_password, error, parent)
+            if not dialog.exec():
+                return None
+            password = dialog.password_edit.text()
+            if password != dialog.confirm_edit.text():
+                error = _("The passwords don't match.")
+            elif not password and not has_password:
+                error = _("Please enter a password.")
+            else:
+                return password
@@ -0,0 +1,177 @@
+"""
+Always-on-top mini ticker showing a single pair's live price.
+"""
+
+from PyQt6.QtCore import Qt, QTimer, pyqtSignal
+from PyQt6.QtGui import QContextMenuEvent, QMouseEvent
+from PyQt6.QtWidgets import QHBoxLayout, QLabel, QWidget
+
+from config.settings import get_settings_manager
+from core.i18n import _
+from core.tray_summary import TrayEntry
+from core.utils import get_display_name
+from ui.behaviors.window_behavior import DraggableWindowBehavior
+
+
+class MiniTickerWindow(QWidget):
+    """Small frameless window streaming the mini ticker pair."""
+
+    open_requested = pyqtSignal()
+    pair_selected = pyqtSignal(str)  # Pair to stream
+    close_requested = pyqtSignal()
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(parent)
+        self._settings_manager = get_settings_manager()
+        self._window_behavior = DraggableWindowBehavior(self)
+        self._pairs: list[str] = []
+        self._current_pair: str | None = None
+        # Border color of the triggered highlight rule, blinking while it flashes
+        self._highlight_color: str | None = None
+        self._flash_on = False
+        self._flash_timer = QTimer(self)
+        self._flash_timer.setInterval(500)
+        self._flash_timer.timeout.connect(self._toggle_flash)
+
+        self.setWindowFlags(
+            Qt.WindowType.FramelessWindowHint
+            | Qt.WindowType.WindowStaysOnTopHint
+            | Qt.WindowType.Tool
+        )
+        self.setAttribute(Qt.WidgetAttribute.WA_TranslucentBackground)
+        self._setup_ui()
+
+    def _setup_ui(self):
+        dark = self._settings_manager.settings.theme_mode == "dark"
+        self._text_color = "#FFFFFF" if dark else "#333333"
+        self._bg_color = "rgba(27, 38, 54, 220)" if dark else "rgba(250, 250, 250, 220)"
+
+        self.container = QWidget(self)
+        self.container.setObjectName("miniTickerContainer")
+        self._apply_container_style(None)
+        layout = QHBoxLayout(self.container)
+        layout.setContentsMargins(10, 4, 10, 4)
+        layout.setSpacing(8)
+
+        self.name_label = QLabel("--")
+        self.name_label.setStyleSheet(
+            f"font-weight: bold; font-size: 12px; color: {self._text_color};"
+        )
+        layout.addWidget(self.name_label)
+
+        self.price_label = QLabel("--")
+        self.price_label.setStyleSheet(
+            f"font-weight: 600; font-size: 13px; color: {self._text_color};"
+        )
+        layout.addWidget(self.price_label)
+
+        self.percentage_label = QLabel()
+        self.percentage_label.setStyleSheet(f"font-size: 11px; color: {self._text_color};")
+        layout.addWidget(self.percentage_label)
+
+        outer = QHBoxLayout(self)
+        outer.setContentsMargins(0, 0, 0, 0)
+        outer.addWidget(self.container)
+
+    def set_pairs(self, pairs: list[str], current: str | None):
+        """Pairs offered in the context menu and the one streamed."""
+        self._pairs = pairs
+        if current != self._current_pair:
+            self._current_pair = current
+            self.name_label.setText(get_display_name(current, short=True) if current else "--")
+            self.price_label.setText("--")
+            self.percentage_label.clear()
+
+    def update_entry(self, entry: TrayEntry | None):
+        """Show the latest price of the streamed pair."""
+        if entry is None:
+            self.price_label.setText("--")
+            self.percentage_label.clear()
+            self._set_highlight(None, False)
+            return
+        self._current_pair = entry.pair
+        self.name_label.setText(entry.name)
+        self.price_label.setText(entry.price_text)
+        self.percentage_label.setText(entry.percentage_text)
+
+        settings = self._settings_manager.settings
+        up, down = "#4CAF50", "#F44336"
+        if settings.color_schema != "standard":
+            up, down = down, up
+        if entry.highlight:
+            color = entry.highlight.color
+        elif entry.percentage_text.startswith("+"):
+            color = up
+        elif entry.percentage_text.startswith("-"):
+            color = down
+        else:
+            color = self._text_color
+        self.percentage_label.setStyleSheet(f"font-size: 11px; color: {color};")
+        if entry.highlight:
+            self._set_highlight(entry.highlight.color, entry.highlight.flash)
+        else:
+            self._set_highlight(None, False)
+        self.adjustSize()
+
+    def _set_highlight(self, color: str | None, flash: bool):
+        if flash:
+            if not self._flash_timer.isActive():
+                self._flash_timer.start()
+        else:
+            self._flash_timer.stop()
+        if color != self._highlight_color:
+            self._highlight_color = color
+            self._flash_on = False
+            self._apply_container_style(color)
+
+    def _toggle_flash(self):
+        self._flash_on = not self._flash_on
+        self._apply_container_style(None if self._flash_on else self._highlight_color)
+
+    def _apply_container_style(self, border_color: str | None):
+        # A transparent border keeps the size when the highlight blinks off
+        self.container.setStyleSheet(
+            f"QWidget {{ background-color: {self._bg_color}; border-radius: 6px; }}"
+            f"#miniTickerContainer {{ border: 2px solid {border_color or 'transparent'}; }}"
+        )
+
+    def mousePressEvent(self, event: QMouseEvent):
+        self._window_behavior.mouse_press_event(event)
+        super().mousePressEvent(event)
+
+    def mouseMoveEvent(self, event: QMouseEvent):
+        self._window_behavior.mouse_move_event(event)
+        super().mouseMoveEvent(event)
+
+    def mouseReleaseEvent(self, event: QMouseEvent):
+        self._window_behavior.mouse_release_event(event)
+        super().mouseReleaseEvent(event)
+
+    def mouseDoubleClickEvent(self, event: QMouseEvent):
+        if event.button() == Qt.MouseButton.LeftButton:
+            self.open_requested.emit()
+        super().mouseDoubleClickEvent(event)
+
+    def contextMenuEvent(self, event: QContextMenuEvent):
+        from qfluentwidgets import Action, RoundMenu
+        from qfluentwidgets import FluentIcon as FIF
+
+        menu = RoundMenu(parent=self)
+        for pair in self._pairs:
+            action = Action(get_display_name(pair, short=True), self)
+            action.setCheckable(True)
+            action.setChecked(pair == self._current_pair)
+            action.triggered.connect(lambda _checked, p=pair: self.pair_selected.emit(p))
+            menu.addAction(action)
+        if self._pairs:
+            menu.addSeparator()
+
+        open_action = Action(FIF.HOME, _("Open Crypto Monitor"), self)
+        open_action.triggered.connect(self.open_requested)
+        menu.addAction(open_action)
+
+        close_action = Action(FIF.CLOSE, _("Hide Mini Ticker"), self)
+        close_action.triggered.connect(self.close_requested)
+        menu.addAction(close_action)
+
+        menu.exec(event.globalPos())
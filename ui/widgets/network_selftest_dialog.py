@@ -0,0 +1,123 @@
+"""
+Guided network self-test, checking the way to the exchange hop by hop.
+"""
+
+from PyQt6.QtWidgets import QDialog, QHBoxLayout, QVBoxLayout, QWidget
+from qfluentwidgets import (
+    BodyLabel,
+    CaptionLabel,
+    FluentIcon,
+    IconWidget,
+    IndeterminateProgressRing,
+    PrimaryPushButton,
+    PushButton,
+    StrongBodyLabel,
+    SubtitleLabel,
+)
+
+from config.settings import ProxyConfig
+from core.i18n import _
+from core.network_selftest import STEP_LABELS, NetworkSelfTest, SelfTestReport, StepResult
+
+
+class StepRow(QWidget):
+    """One finished check: pass/fail icon, name, detail and duration."""
+
+    def __init__(self, result: StepResult, parent=None):
+        super().__init__(parent)
+        layout = QHBoxLayout(self)
+        layout.setContentsMargins(0, 0, 0, 0)
+        layout.setSpacing(10)
+
+        icon = IconWidget(FluentIcon.ACCEPT if result.ok else FluentIcon.CLOSE)
+        icon.setFixedSize(16, 16)
+        layout.addWidget(icon)
+
+        text = QVBoxLayout()
+        text.setSpacing(2)
+        text.addWidget(BodyLabel(_(STEP_LABELS[result.step])))
+        detail = CaptionLabel(f"{result.detail} ({result.seconds * 1000:.0f} ms)")
+        detail.setWordWrap(True)
+        detail.setStyleSheet("color: gray;")
+        text.addWidget(detail)
+        layout.addLayout(text, 1)
+
+
+class NetworkSelfTestDialog(QDialog):
+    """Runs the self-test for a data source and proxy and shows what to change."""
+
+    def __init__(self, data_source: str, proxy: ProxyConfig, parent=None):
+        super().__init__(parent)
+        self._data_source = data_source
+        self._proxy = proxy
+        self.setWindowTitle(_("Network Self-Test"))
+        self.resize(520, 460)
+
+        self._test = NetworkSelfTest(self)
+        self._test.step_finished.connect(self._add_step)
+        self._test.finished.connect(self._show_report)
+        self._setup_ui()
+        self._run()
+
+    def _setup_ui(self):
+        layout = QVBoxLayout(self)
+        layout.setContentsMargins(24, 24, 24, 24)
+        layout.setSpacing(12)
+
+        layout.addWidget(SubtitleLabel(_("Network Self-Test")))
+        intro = BodyLabel(
+            _(
+                "Checks each hop to {exchange}: the address lookup, the secure connection and "
+                "the WebSocket handshake, directly and through the proxy if one is enabled."
+            ).format(exchange=self._data_source)
+        )
+        intro.setWordWrap(True)
+        layout.addWidget(intro)
+
+        self.steps_layout = QVBoxLayout()
+        self.steps_layout.setSpacing(10)
+        layout.addLayout(self.steps_layout)
+
+        self.progress_ring = IndeterminateProgressRing()
+        self.progress_ring.setFixedSize(28, 28)
+        layout.addWidget(self.progress_ring)
+
+        self.result_title = StrongBodyLabel("")
+        layout.addWidget(self.result_title)
+        self.suggestion_label = BodyLabel("")
+        self.suggestion_label.setWordWrap(True)
+        layout.addWidget(self.suggestion_label)
+        layout.addStretch(1)
+
+        buttons = QHBoxLayout()
+        buttons.addStretch(1)
+        self.rerun_btn = PushButton(FluentIcon.SYNC, _("Run Again"))
+        self.rerun_btn.clicked.connect(self._run)
+        buttons.addWidget(self.rerun_btn)
+        self.close_btn = PrimaryPushButton(_("Close"))
+        self.close_btn.clicked.connect(self.accept)
+        buttons.addWidget(self.close_btn)
+        layout.addLayout(buttons)
+
+    def _run(self):
+        if self._test.running:
+            return
+        while self.steps_layout.count():
+            widget = self.steps_layout.takeAt(0).widget()
+            if widget:
+                widget.deleteLater()
+        self.result_title.setText("")
+        self.suggestion_label.setText("")
+        self.progress_ring.show()
+        self.rerun_btn.setEnabled(False)
+        self._test.start(self._data_source, self._proxy)
+
+    def _add_step(self, result: StepResult):
+        self.steps_layout.addWidget(StepRow(result, self))
+
+    def _show_report(self, report: SelfTestReport):
+        self.progress_ring.hide()
+        self.rerun_btn.setEnabled(True)
+        healthy = report.diagnosis in ("ok_direct", "ok_proxy", "no_network")
+        self.result_title.setText(_("All checks passed") if healthy else _("Suggested fix"))
+        self.suggestion_label.setText(_(report.suggestion))
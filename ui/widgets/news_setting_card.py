@@ -0,0 +1,131 @@
+"""
+Setting card for crypto news feeds and news notifications.
+"""
+
+from urllib.parse import urlparse
+
+from PyQt6.QtCore import Qt
+from PyQt6.QtWidgets import QHBoxLayout, QListWidgetItem, QVBoxLayout, QWidget
+from qfluentwidgets import (
+    BodyLabel,
+    ExpandGroupSettingCard,
+    FluentIcon,
+    LineEdit,
+    PrimaryPushButton,
+    PushButton,
+    SwitchButton,
+)
+from qfluentwidgets import ListWidget as FluentListWidget
+
+from config.settings import get_settings_manager
+from core.i18n import _
+from core.news_feed import DEFAULT_FEEDS, get_news_feed_service
+
+
+class NewsSettingCard(ExpandGroupSettingCard):
+    """Manages the RSS/Atom news feeds and the news notification switch."""
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.DOCUMENT,
+            _("News Feeds"),
+            _("Crypto news from RSS/Atom feeds, tagged by asset"),
+            parent,
+        )
+        self._settings_manager = get_settings_manager()
+
+        self._setup_ui()
+        self._load_feeds()
+
+    def _setup_ui(self):
+        container = QWidget()
+        layout = QVBoxLayout(container)
+        layout.setContentsMargins(48, 18, 48, 18)
+        layout.setSpacing(16)
+
+        alert_layout = QHBoxLayout()
+        alert_layout.addWidget(BodyLabel(_("Notify on news about watched pairs")))
+        alert_layout.addStretch(1)
+        self.alert_switch = SwitchButton()
+        self.alert_switch.setChecked(self._settings_manager.settings.news_alerts)
+        self.alert_switch.checkedChanged.connect(self._on_alerts_changed)
+        alert_layout.addWidget(self.alert_switch)
+        layout.addLayout(alert_layout)
+
+        self.feeds_list = FluentListWidget()
+        self.feeds_list.setSelectionMode(FluentListWidget.SelectionMode.SingleSelection)
+        self.feeds_list.setMinimumHeight(120)
+        self.feeds_list.setMaximumHeight(240)
+        self.feeds_list.itemSelectionChanged.connect(self._on_selection_changed)
+        layout.addWidget(self.feeds_list)
+
+        add_layout = QHBoxLayout()
+        self.url_edit = LineEdit()
+        self.url_edit.setPlaceholderText(_("RSS or Atom feed URL"))
+        self.url_edit.textChanged.connect(self._on_url_changed)
+        self.url_edit.returnPressed.connect(self._add_feed)
+        add_layout.addWidget(self.url_edit, 1)
+
+        self.add_btn = PrimaryPushButton(FluentIcon.ADD, _("Add"))
+        self.add_btn.setFixedWidth(100)
+        self.add_btn.setEnabled(False)
+        self.add_btn.clicked.connect(self._add_feed)
+        add_layout.addWidget(self.add_btn)
+
+        self.remove_btn = PushButton(FluentIcon.DELETE, _("Delete"))
+        self.remove_btn.setFixedWidth(100)
+        self.remove_btn.setEnabled(False)
+        self.remove_btn.clicked.connect(self._remove_feed)
+        add_layout.addWidget(self.remove_btn)
+        layout.addLayout(add_layout)
+
+        self.addGroupWidget(container)
+
+    def _load_feeds(self):
+        self.feeds_list.clear()
+        for name, url in DEFAULT_FEEDS.items():
+            item = QListWidgetItem(f"{name} ({_('built-in')})")
+            item.setToolTip(url)
+            self.feeds_list.addItem(item)
+        for url in self._settings_manager.settings.news_feeds:
+            item = QListWidgetItem(url)
+            item.setToolTip(url)
+            item.setData(Qt.ItemDataRole.UserRole, url)
+            self.feeds_list.addItem(item)
+
+    @staticmethod
+    def _is_valid_url(url: str) -> bool:
+        parsed = urlparse(url)
+        return parsed.scheme in ("http", "https") and bool(parsed.netloc)
+
+    def _on_alerts_changed(self, enabled: bool):
+        self._settings_manager.update_news_alerts(enabled)
+        get_news_feed_service().apply_settings()
+
+    def _on_url_changed(self, text: str):
+        self.add_btn.setEnabled(self._is_valid_url(text.strip()))
+
+    def _on_selection_changed(self):
+        item = self.feeds_list.currentItem()
+        # Built-in feeds can't be removed
+        self.remove_btn.setEnabled(
+            item is not None and item.data(Qt.ItemDataRole.UserRole) is not None
+        )
+
+    def _add_feed(self):
+        url = self.url_edit.text().strip()
+        feeds = self._settings_manager.settings.news_feeds
+        if not self._is_valid_url(url) or url in feeds or url in DEFAULT_FEEDS.values():
+            return
+        self._settings_manager.update_news_feeds(feeds + [url])
+        self.url_edit.clear()
+        self._load_feeds()
+
+    def _remove_feed(self):
+        item = self.feeds_list.currentItem()
+        url = item.data(Qt.ItemDataRole.UserRole) if item else None
+        if url is None:
+            return
+        feeds = [feed for feed in self._settings_manager.settings.news_feeds if feed != url]
+        self._settings_manager.update_news_feeds(feeds)
+        self._load_feeds()
@@ -0,0 +1,151 @@
+"""
+Dialog for placing a simple limit or market order on OKX.
+"""
+
+from PyQt6.QtCore import Qt
+from PyQt6.QtWidgets import QHBoxLayout, QVBoxLayout, QWidget
+from qfluentwidgets import BodyLabel, ComboBox, Dialog, LineEdit, MessageBox
+
+from config.settings import get_settings_manager
+from core.i18n import _
+from core.okx_trading import OrderRequest, validate_order
+
+
+class OrderDialog(Dialog):
+    """Fluent Design dialog to build an order, confirmed before it is returned."""
+
+    def __init__(self, parent: QWidget | None = None, inst_id: str | None = None):
+        super().__init__(title=_("Place Order"), content="", parent=parent)
+        self._request: OrderRequest | None = None
+        self._inst_id = inst_id
+
+        self._setup_content()
+
+        self.setFixedSize(420, 400)
+        flags = (
+            Qt.WindowType.Dialog
+            | Qt.WindowType.WindowTitleHint
+            | Qt.WindowType.WindowCloseButtonHint
+        )
+        if parent and (parent.windowFlags() & Qt.WindowType.WindowStaysOnTopHint):
+            flags |= Qt.WindowType.WindowStaysOnTopHint
+        self.setWindowFlags(flags)
+
+    def _add_row(self, layout: QVBoxLayout, label: str, widget: QWidget):
+        row = QHBoxLayout()
+        row_label = BodyLabel(label)
+        row_label.setFixedWidth(100)
+        row.addWidget(row_label)
+        row.addWidget(widget, 1)
+        layout.addLayout(row)
+
+    def _setup_content(self):
+        content_layout = QVBoxLayout()
+        content_layout.setSpacing(16)
+
+        self.pair_combo = ComboBox()
+        for pair in get_settings_manager().settings.crypto_pairs:
+            self.pair_combo.addItem(pair, userData=pair)
+        if self._inst_id:
+            index = self.pair_combo.findData(self._inst_id)
+            if index >= 0:
+                self.pair_combo.setCurrentIndex(index)
+        self._add_row(content_layout, _("Trading Pair:"), self.pair_combo)
+
+        self.side_combo = ComboBox()
+        self.side_combo.addItem(_("Buy"), userData="buy")
+        self.side_combo.addItem(_("Sell"), userData="sell")
+        self._add_row(content_layout, _("Side:"), self.side_combo)
+
+        self.type_combo = ComboBox()
+        self.type_combo.addItem(_("Limit"), userData="limit")
+        self.type_combo.addItem(_("Market"), userData="market")
+        self.type_combo.currentIndexChanged.connect(self._on_type_changed)
+        self._add_row(content_layout, _("Type:"), self.type_combo)
+
+        self.size_edit = LineEdit()
+        self.size_edit.textChanged.connect(self._validate_input)
+        self._add_row(content_layout, _("Size:"), self.size_edit)
+
+        self.price_edit = LineEdit()
+        self.price_edit.textChanged.connect(self._validate_input)
+        self._add_row(content_layout, _("Price:"), self.price_edit)
+
+        self.hint_label = BodyLabel("")
+        self.hint_label.setWordWrap(True)
+        self.hint_label.setStyleSheet("color: gray; font-size: 12px;")
+        content_layout.addWidget(self.hint_label)
+
+        self.textLayout.addLayout(content_layout)
+
+        self.yesButton.setText(_("Review"))
+        self.yesButton.setEnabled(False)
+        self.cancelButton.setText(_("Cancel"))
+        self._on_type_changed()
+
+    def _on_type_changed(self):
+        is_limit = self.type_combo.currentData() == "limit"
+        self.price_edit.setEnabled(is_limit)
+        self.hint_label.setText(
+            "" if is_limit else _("Market buys are sized in the quote currency (e.g. USDT).")
+        )
+        self._validate_input()
+
+    @staticmethod
+    def _parse(text: str) -> float | None:
+        try:
+            return float(text.replace(",", ""))
+        except ValueError:
+            return None
+
+    def _build_request(self) -> OrderRequest:
+        order_type = self.type_combo.currentData()
+        return OrderRequest(
+            inst_id=self.pair_combo.currentData() or "",
+            side=self.side_combo.currentData(),
+            order_type=order_type,
+            size=self._parse(self.size_edit.text()) or 0.0,
+            price=self._parse(self.price_edit.text()) if order_type == "limit" else None,
+        )
+
+    def _validate_input(self, text: str = ""):
+        self.yesButton.setEnabled(validate_order(self._build_request()) is None)
+
+    def _confirm(self, request: OrderRequest) -> bool:
+        """Ask for explicit confirmation with a summary of the order."""
+        side = _("Buy") if request.side == "buy" else _("Sell")
+        price = f" @ {request.price:g}" if request.price else f" @ {_('Market')}"
+        box = MessageBox(
+            _("Confirm Order"),
+            f"{side} {request.size:g} {request.inst_id}{price}\n\n"
+            + _("This sends a real order to OKX."),
+            self,
+        )
+        box.yesButton.setText(_("Place Order"))
+        box.cancelButton.setText(_("Cancel"))
+        return bool(box.exec())
+
+    def accept(self):
+        request = self._build_request()
+        if not self._confirm(request):
+            return
+        self._request = request
+        super().accept()
+
+    def get_request(self) -> OrderRequest | None:
+        return self._request
+
+    @staticmethod
+    def create_order(
+        parent: QWidget | None = None, inst_id: str | None = None
+    ) -> OrderRequest | None:
+        """
+        Show dialog and return a confirmed order.
+
+        Returns:
+            The confirmed OrderRequest, or None if cancelled.
+        """
+        dialog = OrderDialog(parent=parent, inst_id=inst_id)
+        if dialog.exec():
+            return dialog.get_request()
+        return None
@@ -0,0 +1,53 @@
+"""
+Dialog for renaming a pair on its card.
+"""
+
+from PyQt6.QtCore import Qt
+from PyQt6.QtWidgets import QWidget
+from qfluentwidgets import Dialog, LineEdit
+
+from core.i18n import _
+from core.utils import get_display_name
+
+
+class PairAliasDialog(Dialog):
+    """Dialog asking for the name a pair is displayed with."""
+
+    def __init__(self, pair: str, alias: str = "", parent: QWidget | None = None):
+        super().__init__(
+            title=_("Rename Pair"),
+            content=_("Leave empty to show the default name."),
+            parent=parent,
+        )
+
+        self.alias_edit = LineEdit()
+        self.alias_edit.setPlaceholderText(get_display_name(pair, short=True))
+        self.alias_edit.setText(alias)
+        self.alias_edit.setMaxLength(32)
+        self.textLayout.addWidget(self.alias_edit)
+
+        self.yesButton.setText(_("Save"))
+        self.cancelButton.setText(_("Cancel"))
+
+        self.setFixedWidth(360)
+        flags = (
+            Qt.WindowType.Dialog
+            | Qt.WindowType.WindowTitleHint
+            | Qt.WindowType.WindowCloseButtonHint
+        )
+        if parent and (parent.windowFlags() & Qt.WindowType.WindowStaysOnTopHint):
+            flags |= Qt.WindowType.WindowStaysOnTopHint
+        self.setWindowFlags(flags)
+
+    @staticmethod
+    def get_alias(pair: str, alias: str = "", parent: QWidget | None = None) -> str | None:
+        """
+        Show the dialog and return the entered alias.
+
+        Returns:
+            The alias (empty to reset), or None if cancelled.
+        """
+        dialog = PairAliasDialog(pair, alias, parent)
+        if dialog.exec():
+            return dialog.alias_edit.text().strip()
+        return None
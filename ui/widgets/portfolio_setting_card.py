@@ -0,0 +1,265 @@
+"""
+Setting card for managing named portfolios.
+"""
+
+from datetime import datetime
+
+from PyQt6.QtCore import Qt, pyqtSignal
+from PyQt6.QtWidgets import QHBoxLayout, QVBoxLayout, QWidget
+from qfluentwidgets import (
+    BodyLabel,
+    ComboBox,
+    Dialog,
+    ExpandGroupSettingCard,
+    FluentIcon,
+    InfoBar,
+    LineEdit,
+    MessageBox,
+    PrimaryPushButton,
+    PushButton,
+)
+
+from config.settings import get_settings_manager
+from core.i18n import _
+from core.portfolio import get_portfolio_manager
+
+
+class PortfolioNameDialog(Dialog):
+    """Dialog asking for a portfolio name."""
+
+    def __init__(self, title: str, name: str = "", parent: QWidget | None = None):
+        super().__init__(title=title, content="", parent=parent)
+        self._name: str | None = None
+
+        self.name_edit = LineEdit()
+        self.name_edit.setPlaceholderText(_("e.g. Long-term, Trading, DCA bot"))
+        self.name_edit.setText(name)
+        self.name_edit.textChanged.connect(self._validate_input)
+        self.textLayout.addWidget(self.name_edit)
+
+        self.yesButton.setText(_("Save"))
+        self.cancelButton.setText(_("Cancel"))
+        self.yesButton.clicked.connect(self._on_confirm)
+        self._validate_input(name)
+
+        self.setFixedWidth(360)
+        flags = (
+            Qt.WindowType.Dialog
+            | Qt.WindowType.WindowTitleHint
+            | Qt.WindowType.WindowCloseButtonHint
+        )
+        if parent and (parent.windowFlags() & Qt.WindowType.WindowStaysOnTopHint):
+            flags |= Qt.WindowType.WindowStaysOnTopHint
+        self.setWindowFlags(flags)
+
+    def _validate_input(self, text: str):
+        self.yesButton.setEnabled(bool(text.strip()))
+
+    def _on_confirm(self):
+        self._name = self.name_edit.text().strip()
+
+    @staticmethod
+    def get_name(title: str, name: str = "", parent: QWidget | None = None) -> str | None:
+        """
+        Show the dialog and return the entered name.
+
+        Returns:
+            The name, or None if cancelled.
+        """
+        dialog = PortfolioNameDialog(title, name, parent)
+        if dialog.exec():
+            return dialog._name
+        return None
+
+
+class TaxReportDialog(Dialog):
+    """Dialog choosing the tax year and cost basis method of a gains report."""
+
+    def __init__(self, years: list[int], parent: QWidget | None = None):
+        super().__init__(title=_("Export Tax Report"), content="", parent=parent)
+
+        content_layout = QVBoxLayout()
+        content_layout.setSpacing(16)
+
+        year_layout = QHBoxLayout()
+        year_layout.addWidget(BodyLabel(_("Tax Year:")))
+        self.year_combo = ComboBox()
+        for year in years:
+            self.year_combo.addItem(str(year), userData=year)
+        year_layout.addWidget(self.year_combo, 1)
+        content_layout.addLayout(year_layout)
+
+        method_layout = QHBoxLayout()
+        method_layout.addWidget(BodyLabel(_("Cost Basis Method:")))
+        self.method_combo = ComboBox()
+        self.method_combo.addItem(_("FIFO"), userData="fifo")
+        self.method_combo.addItem(_("Average Cost"), userData="average")
+        method_layout.addWidget(self.method_combo, 1)
+        content_layout.addLayout(method_layout)
+
+        hint = BodyLabel(
+            _("Includes sells from all portfolios. Amounts are in the quote currency.")
+        )
+        hint.setWordWrap(True)
+        hint.setStyleSheet("color: gray; font-size: 12px;")
+        content_layout.addWidget(hint)
+
+        self.textLayout.addLayout(content_layout)
+        self.yesButton.setText(_("Export"))
+        self.cancelButton.setText(_("Cancel"))
+        self.setFixedWidth(380)
+
+    def get_options(self) -> tuple[int, str]:
+        return self.year_combo.currentData(), self.method_combo.currentData()
+
+
+class PortfolioSettingCard(ExpandGroupSettingCard):
+    """Expandable setting card to switch, create, rename and delete portfolios."""
+
+    portfolio_changed = pyqtSignal(str)  # Active portfolio ID
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.FOLDER,
+            _("Portfolios"),
+            _("Keep separate holdings and alerts, e.g. for long-term and trading"),
+            parent,
+        )
+        self._portfolio_manager = get_portfolio_manager()
+
+        self._setup_ui()
+        self._load_portfolios()
+
+    def _setup_ui(self):
+        container = QWidget()
+        layout = QVBoxLayout(container)
+        layout.setContentsMargins(48, 18, 48, 18)
+        layout.setSpacing(16)
+
+        active_layout = QHBoxLayout()
+        active_layout.addWidget(BodyLabel(_("Active Portfolio")))
+        active_layout.addStretch(1)
+        self.portfolio_combo = ComboBox()
+        self.portfolio_combo.setMinimumWidth(200)
+        self.portfolio_combo.currentIndexChanged.connect(self._on_portfolio_selected)
+        active_layout.addWidget(self.portfolio_combo)
+        layout.addLayout(active_layout)
+
+        btn_layout = QHBoxLayout()
+        btn_layout.setSpacing(10)
+
+        self.add_btn = PrimaryPushButton(FluentIcon.ADD, _("New Portfolio"))
+        self.add_btn.clicked.connect(self._add_portfolio)
+        btn_layout.addWidget(self.add_btn)
+
+        self.rename_btn = PushButton(FluentIcon.EDIT, _("Rename"))
+        self.rename_btn.clicked.connect(self._rename_portfolio)
+        btn_layout.addWidget(self.rename_btn)
+
+        self.tax_btn = PushButton(FluentIcon.DOCUMENT, _("Export Tax Report"))
+        self.tax_btn.clicked.connect(self._export_tax_report)
+        btn_layout.addWidget(self.tax_btn)
+
+        btn_layout.addStretch()
+
+        self.delete_btn = PushButton(FluentIcon.DELETE, _("Delete"))
+        self.delete_btn.clicked.connect(self._delete_portfolio)
+        btn_layout.addWidget(self.delete_btn)
+
+        layout.addLayout(btn_layout)
+
+        self.addGroupWidget(container)
+
+    def _load_portfolios(self):
+        active_id = self._portfolio_manager.active_portfolio_id
+        portfolios = self._portfolio_manager.get_portfolios()
+
+        self.portfolio_combo.blockSignals(True)
+        self.portfolio_combo.clear()
+        for portfolio in portfolios:
+            self.portfolio_combo.addItem(portfolio.name, userData=portfolio.id)
+            if portfolio.id == active_id:
+                self.portfolio_combo.setCurrentIndex(self.portfolio_combo.count() - 1)
+        self.portfolio_combo.blockSignals(False)
+
+        self.delete_btn.setEnabled(len(portfolios) > 1)
+
+    def _current_portfolio_id(self) -> str:
+        return self.portfolio_combo.currentData() or self._portfolio_manager.active_portfolio_id
+
+    def _on_portfolio_selected(self, index: int):
+        portfolio_id = self.portfolio_combo.itemData(index)
+        if portfolio_id and self._portfolio_manager.set_active_portfolio(portfolio_id):
+            self.portfolio_changed.emit(portfolio_id)
+
+    def _add_portfolio(self):
+        name = PortfolioNameDialog.get_name(_("New Portfolio"), parent=self.window())
+        if not name:
+            return
+        portfolio = self._portfolio_manager.create_portfolio(name)
+        self._portfolio_manager.set_active_portfolio(portfolio.id)
+        self._load_portfolios()
+        self.portfolio_changed.emit(portfolio.id)
+
+    def _rename_portfolio(self):
+        portfolio_id = self._current_portfolio_id()
+        name = PortfolioNameDialog.get_name(
+            _("Rename Portfolio"), self.portfolio_combo.currentText(), self.window()
+        )
+        if name and self._portfolio_manager.rename_portfolio(portfolio_id, name):
+            self._load_portfolios()
+
+    def _delete_portfolio(self):
+        portfolio_id = self._current_portfolio_id()
+        box = MessageBox(
+            _("Delete Portfolio"),
+            _("Delete this portfolio together with its transactions and alerts?"),
+            self.window(),
+        )
+        if not box.exec():
+            return
+        if self._portfolio_manager.remove_portfolio(portfolio_id):
+            self._load_portfolios()
+            self.portfolio_changed.emit(self._portfolio_manager.active_portfolio_id)
+
+    def _export_tax_report(self):
+        from PyQt6.QtWidgets import QFileDialog
+
+        from core.tax_report import export_tax_report
+
+        transactions = get_settings_manager().settings.transactions
+        years = sorted(
+            {datetime.fromtimestamp(t.timestamp).year for t in transactions if t.side == "sell"},
+            reverse=True,
+        )
+        if not years:
+            InfoBar.warning(
+                _("Export Tax Report"), _("No sells recorded yet"), parent=self.window()
+            )
+            return
+
+        dialog = TaxReportDialog(years, self.window())
+        if not dialog.exec():
+            return
+        year, method = dialog.get_options()
+
+        filepath, _filter = QFileDialog.getSaveFileName(
+            self.window(),
+            _("Export Tax Report"),
+            f"capital-gains-{year}-{method}.csv",
+            "CSV Files (*.csv)",
+        )
+        if not filepath:
+            return
+
+        try:
+            rows = export_tax_report(filepath, transactions, year, method)
+        except OSError as e:
+            InfoBar.error(_("Export Failed"), str(e), parent=self.window())
+            return
+        InfoBar.success(
+            _("Export Tax Report"), f"{_('Sells exported')}: {rows}", parent=self.window()
+        )
+
+    def refresh(self):
+        self._load_portfolios()
@@ -3,11 +3,14 @@ Custom setting cards for the settings window.
 Uses QFluentWidgets components for a modern Fluent Design interface.
 """
 
+from dataclasses import replace
+
 from PyQt6.QtCore import Qt, pyqtSignal
 from PyQt6.QtWidgets import QHBoxLayout, QListWidgetItem, QVBoxLayout, QWidget
 from qfluentwidgets import (
     BodyLabel,
     ComboBox,
+    DoubleSpinBox,
     ExpandGroupSettingCard,
     FluentIcon,
     InfoBar,
@@ -19,10 +22,15 @@ from qfluentwidgets import (
     ToolButton,
 )
 
-from config.settings import ProxyConfig
+from config.settings import LocalApiConfig, OkxApiConfig, ProxyConfig, WsConnectionTuning
+from core.day_boundary import PRICE_CHANGE_BASES
 from core.i18n import _
+from core.messages import message
+from core.number_format import NUMBER_FORMAT_CHOICES
+from core.simulated_client import format_volatility_overrides, parse_volatility_overrides
 
 from .add_pair_dialog import AddPairDialog
+from .fields import LabeledCheckBox, LabeledLineEdit, LabeledSpinBox
 from .proxy_form import ProxyForm
 
 
@@ -146,6 +154,382 @@ class ProxySettingCard(ExpandGroupSettingCard):
             )
 
 
+class OkxAccountSettingCard(ExpandGroupSettingCard):
+    """Expandable setting card for OKX account API credentials."""
+
+    verify_requested = pyqtSignal()  # Emitted when the key's permissions should be checked
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.PEOPLE,
+            _("OKX Account"),
+            _("Show balances and positions using your OKX API key"),
+            parent,
+        )
+        self._config = OkxApiConfig()  # Keeps fields not edited on this card
+        self._setup_ui()
+
+    def _setup_ui(self):
+        container = QWidget()
+        layout = QVBoxLayout(container)
+        layout.setContentsMargins(48, 18, 48, 18)
+        layout.setSpacing(16)
+
+        # Enable switch
+        switch_container = QWidget()
+        switch_layout = QHBoxLayout(switch_container)
+        switch_layout.setContentsMargins(0, 0, 0, 0)
+
+        self.enable_label = BodyLabel(_("Enable Account Data"))
+        self.enable_switch = SwitchButton()
+        self.enable_switch.setOffText(_("Off"))
+        self.enable_switch.setOnText(_("On"))
+        self.enable_switch.checkedChanged.connect(self._on_enabled_changed)
+
+        switch_layout.addWidget(self.enable_label)
+        switch_layout.addStretch(1)
+        switch_layout.addWidget(self.enable_switch)
+        layout.addWidget(switch_container)
+
+        # Credentials
+        self.api_key_field = LabeledLineEdit(_("API Key"), min_width=300)
+        self.secret_key_field = LabeledLineEdit(_("Secret Key"), is_password=True, min_width=300)
+        self.passphrase_field = LabeledLineEdit(_("Passphrase"), is_password=True, min_width=300)
+        layout.addWidget(self.api_key_field)
+        layout.addWidget(self.secret_key_field)
+        layout.addWidget(self.passphrase_field)
+
+        self.notify_orders_check = LabeledCheckBox(_("Notify when orders fill or are cancelled"))
+        layout.addWidget(self.notify_orders_check)
+
+        self.notify_transfers_check = LabeledCheckBox(
+            _("Notify when deposits arrive or withdrawals complete")
+        )
+        layout.addWidget(self.notify_transfers_check)
+
+        self.trading_check = LabeledCheckBox(_("Allow placing and cancelling orders"))
+        layout.addWidget(self.trading_check)
+
+        self.demo_check = LabeledCheckBox(_("Use the demo trading environment"))
+        layout.addWidget(self.demo_check)
+
+        self.verify_btn = PushButton(FluentIcon.CERTIFICATE, _("Check Key Permissions"))
+        self.verify_btn.setFixedWidth(200)
+        self.verify_btn.clicked.connect(self.verify_requested.emit)
+        layout.addWidget(self.verify_btn)
+
+        hint = BodyLabel(
+            _(
+                "Use a read-only API key unless you allow trading. "
+                "Keys are stored in the system keychain when available. "
+                "Demo trading needs a key created in OKX's demo trading mode."
+            )
+        )
+        hint.setWordWrap(True)
+        hint.setStyleSheet("color: gray; font-size: 12px;")
+        layout.addWidget(hint)
+
+        self.addGroupWidget(container)
+        self._on_enabled_changed(False)
+
+    def _on_enabled_changed(self, enabled: bool):
+        self.api_key_field.setEnabled(enabled)
+        self.secret_key_field.setEnabled(enabled)
+        self.passphrase_field.setEnabled(enabled)
+        self.notify_orders_check.setEnabled(enabled)
+        self.notify_transfers_check.setEnabled(enabled)
+        self.trading_check.setEnabled(enabled)
+        self.demo_check.setEnabled(enabled)
+        self.verify_btn.setEnabled(enabled)
+
+    def set_verifying(self, verifying: bool):
+        self.verify_btn.setEnabled(not verifying and self.enable_switch.isChecked())
+
+    def show_key_check(self, risky: list[str]):
+        """Show the permission check result: warnings for each unneeded permission."""
+        self.set_verifying(False)
+        if not risky:
+            InfoBar.success(
+                title=_("Key Permissions OK"),
+                content=_("The key has no permissions beyond what is needed."),
+                position=InfoBarPosition.TOP,
+                duration=3000,
+                parent=self.window(),
+            )
+            return
+        InfoBar.warning(
+            title=_("Key Has Extra Permissions"),
+            content="\n".join(message(f"key_can_{permission}") for permission in risky),
+            position=InfoBarPosition.TOP,
+            duration=-1,
+            parent=self.window(),
+        )
+
+    def show_key_check_error(self, error: str):
+        self.set_verifying(False)
+        InfoBar.error(
+            title=_("Key Check Failed"),
+            content=error,
+            position=InfoBarPosition.TOP,
+            duration=5000,
+            parent=self.window(),
+        )
+
+    def get_config(self) -> OkxApiConfig:
+        """Get current credentials."""
+        return replace(
+            self._config,
+            enabled=self.enable_switch.isChecked(),
+            api_key=self.api_key_field.text().strip(),
+            secret_key=self.secret_key_field.text().strip(),
+            passphrase=self.passphrase_field.text(),
+            notify_orders=self.notify_orders_check.is_checked(),
+            notify_transfers=self.notify_transfers_check.is_checked(),
+            trading_enabled=self.trading_check.is_checked(),
+            demo=self.demo_check.is_checked(),
+        )
+
+    def set_config(self, config: OkxApiConfig):
+        """Set credentials."""
+        self._config = config
+        self.enable_switch.setChecked(config.enabled)
+        self.api_key_field.set_text(config.api_key)
+        self.secret_key_field.set_text(config.secret_key)
+        self.passphrase_field.set_text(config.passphrase)
+        self.notify_orders_check.set_checked(config.notify_orders)
+        self.notify_transfers_check.set_checked(config.notify_transfers)
+        self.trading_check.set_checked(config.trading_enabled)
+        self.demo_check.set_checked(config.demo)
+        self._on_enabled_changed(config.enabled)
+
+
+class CertPinningSettingCard(ExpandGroupSettingCard):
+    """Expandable setting card for pinning the exchanges' TLS certificates."""
+
+    trust_requested = pyqtSignal()  # Emitted when the current certificates should be pinned
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.CERTIFICATE,
+            _("Certificate Pinning"),
+            _("Detect intercepted connections to the exchange"),
+            parent,
+        )
+        self._setup_ui()
+
+    def _setup_ui(self):
+        container = QWidget()
+        layout = QVBoxLayout(container)
+        layout.setContentsMargins(48, 18, 48, 18)
+        layout.setSpacing(16)
+
+        self.enable_check = LabeledCheckBox(_("Check exchange certificates on start"))
+        layout.addWidget(self.enable_check)
+
+        self.trust_btn = PushButton(FluentIcon.ACCEPT, _("Trust Current Certificates"))
+        self.trust_btn.setFixedWidth(220)
+        self.trust_btn.clicked.connect(self.trust_requested.emit)
+        layout.addWidget(self.trust_btn)
+
+        hint = BodyLabel(
+            _(
+                "Keys are remembered when first seen. If your network uses a TLS-inspecting "
+                "proxy, or the exchange renews its keys, trust the current certificates again."
+            )
+        )
+        hint.setWordWrap(True)
+        hint.setStyleSheet("color: gray; font-size: 12px;")
+        layout.addWidget(hint)
+
+        self.addGroupWidget(container)
+
+    def set_enabled(self, enabled: bool):
+        self.enable_check.set_checked(enabled)
+
+    def is_enabled(self) -> bool:
+        return self.enable_check.is_checked()
+
+    def set_trusting(self, trusting: bool):
+        self.trust_btn.setEnabled(not trusting)
+
+    def show_trusted(self, hosts: list[str]):
+        """Show which hosts were pinned by trusting the current certificates."""
+        self.set_trusting(False)
+        if hosts:
+            InfoBar.success(
+                title=_("Certificates Trusted"),
+                content=", ".join(hosts),
+                position=InfoBarPosition.TOP,
+                duration=3000,
+                parent=self.window(),
+            )
+        else:
+            InfoBar.error(
+                title=_("Certificates Not Trusted"),
+                content=_("The exchange could not be reached"),
+                position=InfoBarPosition.TOP,
+                duration=5000,
+                parent=self.window(),
+            )
+
+
+class SimulationSettingCard(ExpandGroupSettingCard):
+    """Expandable setting card for the volatility of the simulated data source."""
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.ROBOT,
+            _("Simulated Market"),
+            _("Random-walk prices used by the Simulated data source"),
+            parent,
+        )
+        self._setup_ui()
+
+    def _setup_ui(self):
+        container = QWidget()
+        layout = QVBoxLayout(container)
+        layout.setContentsMargins(48, 18, 48, 18)
+        layout.setSpacing(16)
+
+        volatility_container = QWidget()
+        volatility_layout = QHBoxLayout(volatility_container)
+        volatility_layout.setContentsMargins(0, 0, 0, 0)
+        volatility_layout.addWidget(BodyLabel(_("Daily Volatility")))
+        volatility_layout.addStretch(1)
+        self.volatility_spin = DoubleSpinBox()
+        self.volatility_spin.setRange(0.1, 500.0)
+        self.volatility_spin.setSingleStep(1.0)
+        self.volatility_spin.setSuffix(" %")
+        volatility_layout.addWidget(self.volatility_spin)
+        layout.addWidget(volatility_container)
+
+        self.overrides_field = LabeledLineEdit(
+            _("Per Pair"), placeholder="BTC-USDT=3, DOGE-USDT=12", min_width=300
+        )
+        layout.addWidget(self.overrides_field)
+
+        self.addGroupWidget(container)
+
+    def set_values(self, default_volatility: float, overrides: dict[str, float]):
+        self.volatility_spin.setValue(default_volatility)
+        self.overrides_field.set_text(format_volatility_overrides(overrides))
+
+    def get_values(self) -> tuple[float, dict[str, float]]:
+        return (
+            self.volatility_spin.value(),
+            parse_volatility_overrides(self.overrides_field.text()),
+        )
+
+
+class RpcSettingCard(ExpandGroupSettingCard):
+    """Expandable setting card for the JSON-RPC endpoints of on-chain price sources."""
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.GLOBE,
+            _("RPC Endpoints"),
+            _("Nodes used to read on-chain pool prices; leave empty for public defaults"),
+            parent,
+        )
+        self._fields: dict[str, LabeledLineEdit] = {}
+        self._setup_ui()
+
+    def _setup_ui(self):
+        from core.pool_client import DEFAULT_RPC_URLS
+
+        container = QWidget()
+        layout = QVBoxLayout(container)
+        layout.setContentsMargins(48, 18, 48, 18)
+        layout.setSpacing(16)
+
+        for network, default_url in DEFAULT_RPC_URLS.items():
+            field = LabeledLineEdit(network.title(), placeholder=default_url, min_width=300)
+            self._fields[network] = field
+            layout.addWidget(field)
+
+        self.addGroupWidget(container)
+
+    def get_endpoints(self) -> dict[str, str]:
+        """Get the configured endpoints; empty fields are omitted."""
+        endpoints = {}
+        for network, field in self._fields.items():
+            url = field.text().strip()
+            if url:
+                endpoints[network] = url
+        return endpoints
+
+    def set_endpoints(self, endpoints: dict[str, str]):
+        """Set the configured endpoints."""
+        for network, field in self._fields.items():
+            field.set_text(endpoints.get(network, ""))
+
+
+class WsTuningSettingCard(ExpandGroupSettingCard):
+    """Expandable setting card for the transport options of each exchange connection."""
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.SPEED_HIGH,
+            _("Connection Tuning"),
+            _("Compression and socket buffers of the exchange WebSocket connections"),
+            parent,
+        )
+        self._rows: dict[str, tuple[LabeledCheckBox, LabeledSpinBox, LabeledSpinBox]] = {}
+        self._setup_ui()
+
+    def _setup_ui(self):
+        container = QWidget()
+        layout = QVBoxLayout(container)
+        layout.setContentsMargins(48, 18, 48, 18)
+        layout.setSpacing(16)
+
+        connections = {
+            "okx": _("OKX Market Data"),
+            "okx_private": _("OKX Account"),
+            "binance": _("Binance Market Data"),
+        }
+        for connection, title in connections.items():
+            layout.addWidget(BodyLabel(title))
+            compression = LabeledCheckBox(_("Compression (permessage-deflate)"))
+            read_buffer = LabeledSpinBox(_("Read Buffer (KB, 0 = default)"), 0, 16384, 0)
+            write_buffer = LabeledSpinBox(_("Write Buffer (KB, 0 = default)"), 0, 16384, 0)
+            layout.addWidget(compression)
+            layout.addWidget(read_buffer)
+            layout.addWidget(write_buffer)
+            self._rows[connection] = (compression, read_buffer, write_buffer)
+
+        hint = BodyLabel(
+            _(
+                "Turn compression off if a proxy garbles or drops messages. "
+                "Changes apply when the connection is reopened."
+            )
+        )
+        hint.setWordWrap(True)
+        hint.setStyleSheet("color: gray; font-size: 12px;")
+        layout.addWidget(hint)
+
+        self.addGroupWidget(container)
+
+    def get_connections(self) -> dict[str, WsConnectionTuning]:
+        """Get the options of each connection."""
+        return {
+            connection: WsConnectionTuning(
+                compression=compression.is_checked(),
+                read_buffer_kb=read_buffer.value(),
+                write_buffer_kb=write_buffer.value(),
+            )
+            for connection, (compression, read_buffer, write_buffer) in self._rows.items()
+        }
+
+    def set_connections(self, connections: dict[str, WsConnectionTuning]):
+        """Set the options of each connection."""
+        for connection, (compression, read_buffer, write_buffer) in self._rows.items():
+            tuning = connections.get(connection) or WsConnectionTuning()
+            compression.set_checked(tuning.compression)
+            read_buffer.set_value(tuning.read_buffer_kb)
+            write_buffer.set_value(tuning.write_buffer_kb)
+
+
 class PairsSettingCard(ExpandGroupSettingCard):
     """Expandable setting card for crypto pairs management."""
 
@@ -233,13 +617,19 @@ class PairsSettingCard(ExpandGroupSettingCard):
         from core.utils import get_display_name
 
         data_source = get_settings_manager().settings.data_source
-        pair = AddPairDialog.get_new_pair(data_source, self.window())
-        if pair:
+        pairs = AddPairDialog.get_new_pairs(data_source, self.window())
+        listed = set(self.get_pairs())
+        added = False
+        for pair in pairs:
+            if pair in listed:
+                continue
             display = get_display_name(pair)
             item = QListWidgetItem(display)
             item.setToolTip(pair)
             item.setData(Qt.ItemDataRole.UserRole, pair)
             self.pairs_list.addItem(item)
+            added = True
+        if added:
             self.pairs_changed.emit()
 
     def _remove_pair(self):
@@ -502,8 +892,10 @@ class DisplaySettingCard(ExpandGroupSettingCard):
 
         self.basis_label = BodyLabel(_("Price Change Basis"))
         self.basis_combo = ComboBox()
-        # Index 0: 24h Rolling, Index 1: UTC-0
-        self.basis_combo.addItems([_("24h Rolling"), _("UTC-0 (Daily)")])
+        # Same order as PRICE_CHANGE_BASES
+        self.basis_combo.addItems(
+            [_("24h Rolling"), _("UTC-0 (Daily)"), _("UTC+8 (Daily)"), _("Local Midnight")]
+        )
         self.basis_combo.currentTextChanged.connect(self._on_basis_changed)
 
         basis_layout.addWidget(self.basis_label)
@@ -512,6 +904,41 @@ class DisplaySettingCard(ExpandGroupSettingCard):
 
         layout.addWidget(basis_container)
 
+        # Display Currency
+        currency_container = QWidget()
+        currency_layout = QHBoxLayout(currency_container)
+        currency_layout.setContentsMargins(0, 0, 0, 0)
+
+        from core.fx_rates import SUPPORTED_FIAT_CURRENCIES
+
+        self.currency_label = BodyLabel(_("Display Currency"))
+        self.currency_combo = ComboBox()
+        self.currency_combo.addItems(list(SUPPORTED_FIAT_CURRENCIES))
+
+        currency_layout.addWidget(self.currency_label)
+        currency_layout.addStretch(1)
+        currency_layout.addWidget(self.currency_combo)
+
+        layout.addWidget(currency_container)
+
+        # Number Format
+        number_format_container = QWidget()
+        number_format_layout = QHBoxLayout(number_format_container)
+        number_format_layout.setContentsMargins(0, 0, 0, 0)
+
+        self.number_format_label = BodyLabel(_("Number Format"))
+        self.number_format_combo = ComboBox()
+        # Same order as NUMBER_FORMAT_CHOICES
+        self.number_format_combo.addItems(
+            ["1234567.89", _("Follow Language"), "1,234,567.89", "1.234.567,89", "1 234 567,89"]
+        )
+
+        number_format_layout.addWidget(self.number_format_label)
+        number_format_layout.addStretch(1)
+        number_format_layout.addWidget(self.number_format_combo)
+
+        layout.addWidget(number_format_container)
+
         # Dynamic Background
         bg_container = QWidget()
         bg_layout = QHBoxLayout(bg_container)
@@ -562,6 +989,27 @@ class DisplaySettingCard(ExpandGroupSettingCard):
 
         layout.addWidget(limit_container)
 
+        # Update Interval
+        interval_container = QWidget()
+        interval_layout = QHBoxLayout(interval_container)
+        interval_layout.setContentsMargins(0, 0, 0, 0)
+
+        self.update_interval_label = BodyLabel(_("Price Update Interval"))
+        self.update_interval_label.setToolTip(
+            _("Cards refresh at most this often; longer intervals use less CPU")
+        )
+        self.update_interval_spin = SpinBox()
+        self.update_interval_spin.setRange(250, 2000)
+        self.update_interval_spin.setSingleStep(250)
+        self.update_interval_spin.setSuffix(" ms")
+        self.update_interval_spin.setFixedWidth(150)
+
+        interval_layout.addWidget(self.update_interval_label)
+        interval_layout.addStretch(1)
+        interval_layout.addWidget(self.update_interval_spin)
+
+        layout.addWidget(interval_container)
+
         # Auto Scroll
         scroll_container = QWidget()
         scroll_layout = QHBoxLayout(scroll_container)
@@ -655,19 +1103,37 @@ class DisplaySettingCard(ExpandGroupSettingCard):
 
     def _on_basis_changed(self, text: str):
         """Handle basis change."""
-        basis = "24h_rolling" if self.basis_combo.currentIndex() == 0 else "utc_0"
-        self.price_change_basis_changed.emit(basis)
+        self.price_change_basis_changed.emit(self.get_price_change_basis())
 
     def set_price_change_basis(self, basis: str):
         """Set price change basis."""
-        if basis == "24h_rolling":
-            self.basis_combo.setCurrentIndex(0)
+        if basis in PRICE_CHANGE_BASES:
+            self.basis_combo.setCurrentIndex(PRICE_CHANGE_BASES.index(basis))
         else:
-            self.basis_combo.setCurrentIndex(1)
+            self.basis_combo.setCurrentIndex(0)
 
     def get_price_change_basis(self) -> str:
         """Get current price change basis."""
-        return "24h_rolling" if self.basis_combo.currentIndex() == 0 else "utc_0"
+        return PRICE_CHANGE_BASES[max(self.basis_combo.currentIndex(), 0)]
+
+    def set_fiat_currency(self, currency: str):
+        """Set display fiat currency."""
+        self.currency_combo.setCurrentText(currency.upper())
+
+    def get_fiat_currency(self) -> str:
+        """Get display fiat currency."""
+        return self.currency_combo.currentText() or "USD"
+
+    def set_number_format(self, number_format: str):
+        """Set number format."""
+        if number_format in NUMBER_FORMAT_CHOICES:
+            self.number_format_combo.setCurrentIndex(NUMBER_FORMAT_CHOICES.index(number_format))
+        else:
+            self.number_format_combo.setCurrentIndex(0)
+
+    def get_number_format(self) -> str:
+        """Get number format."""
+        return NUMBER_FORMAT_CHOICES[max(self.number_format_combo.currentIndex(), 0)]
 
     def set_dynamic_background(self, enabled: bool):
         """Set dynamic background state."""
@@ -686,6 +1152,14 @@ class DisplaySettingCard(ExpandGroupSettingCard):
         """Get current display limit."""
         return self.limit_spin.value()
 
+    def set_update_interval(self, interval_ms: int):
+        """Set batched card refresh interval."""
+        self.update_interval_spin.setValue(interval_ms)
+
+    def get_update_interval(self) -> int:
+        """Get batched card refresh interval."""
+        return self.update_interval_spin.value()
+
     def set_auto_scroll(self, enabled: bool, interval: int):
         """Set auto scroll settings."""
         self.scroll_switch.setChecked(enabled)
@@ -884,3 +1358,391 @@ class HoverSettingCard(ExpandGroupSettingCard):
             "period": self.period_combo.currentText(),
             "cache_ttl": self.cache_spin.value(),
         }
+
+
+class HistorySettingCard(ExpandGroupSettingCard):
+    """Expandable setting card for the in-memory price history limits."""
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.HISTORY,
+            _("Price History"),
+            _("Limit the chart and candle history kept in memory"),
+            parent,
+        )
+        self._setup_ui()
+
+    def _setup_ui(self):
+        """Setup the history limits UI."""
+        from qfluentwidgets import CaptionLabel
+
+        container = QWidget()
+        layout = QVBoxLayout(container)
+        layout.setContentsMargins(48, 18, 48, 18)
+        layout.setSpacing(16)
+
+        self.points_spin = self._add_spin_row(
+            layout,
+            _("Chart Minutes per Pair"),
+            _("One-minute prices kept per pair for the mini chart"),
+            60,
+            10080,
+            "",
+        )
+        self.candles_spin = self._add_spin_row(
+            layout,
+            _("Candles per Pair"),
+            _("Candles kept per pair and interval for indicators"),
+            200,
+            1000,
+            "",
+        )
+        self.budget_spin = self._add_spin_row(
+            layout,
+            _("Memory Budget"),
+            _("History is trimmed evenly when the caps above would exceed this"),
+            8,
+            1024,
+            " MB",
+        )
+
+        self.usage_label = CaptionLabel()
+        layout.addWidget(self.usage_label)
+
+        self.addGroupWidget(container)
+
+    def _add_spin_row(
+        self, layout: QVBoxLayout, text: str, tooltip: str, minimum: int, maximum: int, suffix: str
+    ) -> SpinBox:
+        row = QWidget()
+        row_layout = QHBoxLayout(row)
+        row_layout.setContentsMargins(0, 0, 0, 0)
+
+        label = BodyLabel(text)
+        label.setToolTip(tooltip)
+        spin = SpinBox()
+        spin.setRange(minimum, maximum)
+        spin.setSuffix(suffix)
+        spin.setFixedWidth(150)
+
+        row_layout.addWidget(label)
+        row_layout.addStretch(1)
+        row_layout.addWidget(spin)
+        layout.addWidget(row)
+        return spin
+
+    def set_values(self, sparkline_points: int, candles: int, budget_mb: int):
+        """Set all values."""
+        self.points_spin.setValue(sparkline_points)
+        self.candles_spin.setValue(candles)
+        self.budget_spin.setValue(budget_mb)
+
+    def get_values(self) -> tuple[int, int, int]:
+        """Get (chart minutes, candles, budget in MB)."""
+        return self.points_spin.value(), self.candles_spin.value(), self.budget_spin.value()
+
+    def set_usage(self, usage_mb: float, budget_mb: int, buffers: int):
+        """Show the current estimated usage."""
+        self.usage_label.setText(
+            _("In use: {usage:.1f} MB of {budget} MB across {buffers} buffers").format(
+                usage=usage_mb, budget=budget_mb, buffers=buffers
+            )
+        )
+
+
+class HotkeySettingCard(ExpandGroupSettingCard):
+    """Expandable setting card for global hotkeys."""
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.COMMAND_PROMPT,
+            _("Global Hotkeys"),
+            _("Shortcuts that work while the app is in the background"),
+            parent,
+        )
+        self._shortcuts: dict[str, str] = {}  # Last valid shortcut of each field
+        self._setup_ui()
+
+    def _setup_ui(self):
+        """Setup the hotkeys UI."""
+        from core.hotkeys import (
+            CYCLE_PAIR,
+            DEFAULT_HOTKEYS,
+            HOTKEYS_AVAILABLE,
+            PAUSE_ALERTS,
+            TOGGLE_WINDOW,
+        )
+
+        container = QWidget()
+        layout = QVBoxLayout(container)
+        layout.setContentsMargins(48, 18, 48, 18)
+        layout.setSpacing(16)
+
+        switch_container = QWidget()
+        switch_layout = QHBoxLayout(switch_container)
+        switch_layout.setContentsMargins(0, 0, 0, 0)
+        switch_layout.addWidget(BodyLabel(_("Enable Global Hotkeys")))
+        switch_layout.addStretch(1)
+        self.enable_switch = SwitchButton()
+        self.enable_switch.setOffText(_("Off"))
+        self.enable_switch.setOnText(_("On"))
+        self.enable_switch.checkedChanged.connect(self._on_enabled_changed)
+        switch_layout.addWidget(self.enable_switch)
+        layout.addWidget(switch_container)
+
+        self.fields: dict[str, LabeledLineEdit] = {}
+        for action, label in (
+            (TOGGLE_WINDOW, _("Show/Hide Window")),
+            (PAUSE_ALERTS, _("Pause/Resume Alerts")),
+            (CYCLE_PAIR, _("Next Mini Ticker Pair")),
+        ):
+            field = LabeledLineEdit(label, placeholder=DEFAULT_HOTKEYS[action], min_width=200)
+            field.get_widget().editingFinished.connect(
+                lambda a=action: self._on_shortcut_edited(a)
+            )
+            self.fields[action] = field
+            layout.addWidget(field)
+
+        self.hint_label = BodyLabel(
+            _("Write shortcuts like Ctrl+Alt+M; clear a field to turn its shortcut off.")
+            if HOTKEYS_AVAILABLE
+            else _("Global hotkeys are not supported on this system.")
+        )
+        self.hint_label.setWordWrap(True)
+        self.hint_label.setStyleSheet("color: gray; font-size: 12px;")
+        layout.addWidget(self.hint_label)
+
+        if not HOTKEYS_AVAILABLE:
+            self.enable_switch.setEnabled(False)
+
+        self.addGroupWidget(container)
+        self._on_enabled_changed(False)
+
+    def _on_enabled_changed(self, enabled: bool):
+        for field in self.fields.values():
+            field.setEnabled(enabled)
+
+    def _on_shortcut_edited(self, action: str):
+        from core.hotkeys import normalize_shortcut
+
+        field = self.fields[action]
+        text = field.text().strip()
+        if not text:
+            self._shortcuts[action] = ""
+            return
+        shortcut = normalize_shortcut(text)
+        if shortcut is None:
+            # Keep the last valid shortcut
+            field.set_text(self._shortcuts.get(action, ""))
+            InfoBar.warning(
+                _("Invalid Shortcut"),
+                _("Use modifiers and one key, e.g. Ctrl+Alt+M"),
+                position=InfoBarPosition.TOP,
+                parent=self.window(),
+            )
+            return
+        self._shortcuts[action] = shortcut
+        field.set_text(shortcut)
+
+    def set_values(self, enabled: bool, hotkeys: dict):
+        """Set the switch and shortcuts; missing shortcuts show their default."""
+        from core.hotkeys import DEFAULT_HOTKEYS
+
+        self.enable_switch.setChecked(enabled)
+        for action, field in self.fields.items():
+            shortcut = hotkeys.get(action, DEFAULT_HOTKEYS[action])
+            self._shortcuts[action] = shortcut
+            field.set_text(shortcut)
+        self._on_enabled_changed(enabled)
+
+    def get_values(self) -> tuple[bool, dict]:
+        """Get (enabled, action -> shortcut)."""
+        return self.enable_switch.isChecked(), dict(self._shortcuts)
+
+
+class AutostartSettingCard(ExpandGroupSettingCard):
+    """Expandable setting card for launching the app at login."""
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.POWER_BUTTON,
+            _("Launch at Login"),
+            _("Start Crypto Monitor when you sign in"),
+            parent,
+        )
+        self._setup_ui()
+
+    def _setup_ui(self):
+        """Setup the autostart UI."""
+        container = QWidget()
+        layout = QVBoxLayout(container)
+        layout.setContentsMargins(48, 18, 48, 18)
+        layout.setSpacing(16)
+
+        switch_container = QWidget()
+        switch_layout = QHBoxLayout(switch_container)
+        switch_layout.setContentsMargins(0, 0, 0, 0)
+        switch_layout.addWidget(BodyLabel(_("Launch at Login")))
+        switch_layout.addStretch(1)
+        self.enable_switch = SwitchButton()
+        self.enable_switch.setOffText(_("Off"))
+        self.enable_switch.setOnText(_("On"))
+        self.enable_switch.checkedChanged.connect(self._on_enabled_changed)
+        switch_layout.addWidget(self.enable_switch)
+        layout.addWidget(switch_container)
+
+        self.minimized_check = LabeledCheckBox(_("Start minimized to the system tray"))
+        layout.addWidget(self.minimized_check)
+
+        self.addGroupWidget(container)
+        self._on_enabled_changed(False)
+
+    def _on_enabled_changed(self, enabled: bool):
+        self.minimized_check.setEnabled(enabled)
+
+    def set_supported(self, supported: bool):
+        """Disable the card where autostart can't be managed."""
+        self.enable_switch.setEnabled(supported)
+        if not supported:
+            self.setContent(_("Not supported on this system"))
+
+    def set_values(self, enabled: bool, minimized: bool):
+        """Set the current autostart state."""
+        self.enable_switch.setChecked(enabled)
+        self.minimized_check.set_checked(minimized)
+        self._on_enabled_changed(enabled)
+
+    def get_values(self) -> tuple[bool, bool]:
+        """Get (enabled, start minimized)."""
+        return self.enable_switch.isChecked(), self.minimized_check.is_checked()
+
+
+class CrashReportSettingCard(ExpandGroupSettingCard):
+    """Expandable setting card for opting in to anonymous crash reports."""
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.FEEDBACK,
+            _("Crash Reports"),
+            _("Help fix crashes by sending anonymous reports"),
+            parent,
+        )
+        self._setup_ui()
+
+    def _setup_ui(self):
+        container = QWidget()
+        layout = QVBoxLayout(container)
+        layout.setContentsMargins(48, 18, 48, 18)
+        layout.setSpacing(16)
+
+        self.enable_check = LabeledCheckBox(_("Offer to send a report after a crash"))
+        layout.addWidget(self.enable_check)
+
+        hint = BodyLabel(
+            _(
+                "Reports hold the app version, system and error trace, with credentials and "
+                "your user name removed. You review each one as a GitHub issue before sending."
+            )
+        )
+        hint.setWordWrap(True)
+        hint.setStyleSheet("color: gray; font-size: 12px;")
+        layout.addWidget(hint)
+
+        self.addGroupWidget(container)
+
+    def set_enabled(self, enabled: bool):
+        self.enable_check.set_checked(enabled)
+
+    def is_enabled(self) -> bool:
+        return self.enable_check.is_checked()
+
+
+class LocalApiSettingCard(ExpandGroupSettingCard):
+    """Expandable setting card for the local HTTP API."""
+
+    def __init__(self, parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.IOT,
+            _("Local API"),
+            _("Serve prices over HTTP, e.g. to Home Assistant sensors"),
+            parent,
+        )
+        self._setup_ui()
+
+    def _setup_ui(self):
+        container = QWidget()
+        layout = QVBoxLayout(container)
+        layout.setContentsMargins(48, 18, 48, 18)
+        layout.setSpacing(16)
+
+        self.enable_check = LabeledCheckBox(_("Enable the local API"))
+        self.enable_check.checkbox.stateChanged.connect(self._on_enabled_changed)
+        layout.addWidget(self.enable_check)
+
+        self.port_spin = LabeledSpinBox(_("Port"), 1024, 65535, LocalApiConfig.port)
+        self.port_spin.get_widget().valueChanged.connect(self._update_hint)
+        layout.addWidget(self.port_spin)
+
+        self.lan_check = LabeledCheckBox(_("Allow devices on the local network"))
+        layout.addWidget(self.lan_check)
+
+        self.token_field = LabeledLineEdit(
+            _("Access Token"), placeholder=_("Optional"), is_password=True, min_width=300
+        )
+        layout.addWidget(self.token_field)
+
+        self.control_check = LabeledCheckBox(
+            _("Accept commands: add or remove pairs, pause alerts, take snapshots (needs a token)")
+        )
+        layout.addWidget(self.control_check)
+
+        self.hint = BodyLabel()
+        self.hint.setWordWrap(True)
+        self.hint.setStyleSheet("color: gray; font-size: 12px;")
+        layout.addWidget(self.hint)
+
+        self.overlay_hint = BodyLabel()
+        self.overlay_hint.setWordWrap(True)
+        self.overlay_hint.setStyleSheet("color: gray; font-size: 12px;")
+        layout.addWidget(self.overlay_hint)
+
+        self.addGroupWidget(container)
+        self._on_enabled_changed()
+
+    def _on_enabled_changed(self, *_args):
+        enabled = self.enable_check.is_checked()
+        self.port_spin.setEnabled(enabled)
+        self.lan_check.setEnabled(enabled)
+        self.token_field.setEnabled(enabled)
+        self.control_check.setEnabled(enabled)
+        self._update_hint()
+
+    def _update_hint(self, *_args):
+        self.hint.setText(
+            _(
+                "Home Assistant RESTful sensor resource: {url}. "
+                "Set a token before allowing the local network."
+            ).format(url=f"http://<host>:{self.port_spin.value()}/api/ha/BTC-USDT")
+        )
+        self.overlay_hint.setText(
+            _("OBS browser source for a price overlay: {url}").format(
+                url=f"http://127.0.0.1:{self.port_spin.value()}/overlay?pairs=BTC-USDT,ETH-USDT"
+            )
+        )
+
+    def get_config(self) -> LocalApiConfig:
+        return LocalApiConfig(
+            enabled=self.enable_check.is_checked(),
+            port=self.port_spin.value(),
+            allow_lan=self.lan_check.is_checked(),
+            token=self.token_field.text().strip(),
+            allow_control=self.control_check.is_checked(),
+        )
+
+    def set_config(self, config: LocalApiConfig):
+        self.enable_check.set_checked(config.enabled)
+        self.port_spin.set_value(config.port)
+        self.lan_check.set_checked(config.allow_lan)
+        self.token_field.set_text(config.token)
+        self.control_check.set_checked(config.allow_control)
+        self._on_enabled_changed()
@@ -15,6 +15,8 @@ class Toolbar(QWidget):
 
     settings_clicked = pyqtSignal()
     add_clicked = pyqtSignal()
+    share_clicked = pyqtSignal()
+    account_clicked = pyqtSignal()
     minimize_clicked = pyqtSignal()
     pin_clicked = pyqtSignal(bool)  # Emits new pin state
     close_clicked = pyqtSignal()
@@ -47,6 +49,21 @@ class Toolbar(QWidget):
         self.add_btn.clicked.connect(self.add_clicked)
         layout.addWidget(self.add_btn)
 
+        # Share button - copies or exports the current prices
+        self.share_btn = TransparentToolButton(FIF.SHARE, self)
+        self.share_btn.setFixedSize(24, 24)
+        self.share_btn.setToolTip(_("Share Prices"))
+        self.share_btn.clicked.connect(self.share_clicked)
+        layout.addWidget(self.share_btn)
+
+        # Account button - only shown when OKX account data is enabled
+        self.account_btn = TransparentToolButton(FIF.PEOPLE, self)
+        self.account_btn.setFixedSize(24, 24)
+        self.account_btn.setToolTip(_("OKX Account"))
+        self.account_btn.clicked.connect(self.account_clicked)
+        self.account_btn.setVisible(False)
+        layout.addWidget(self.account_btn)
+
         # Minimize button - using Fluent Icon
         self.minimize_btn = TransparentToolButton(FIF.MINIMIZE, self)
         self.minimize_btn.setFixedSize(24, 24)
@@ -78,6 +95,10 @@ class Toolbar(QWidget):
         self.pin_btn.setToolTip(_("Unpin Window") if self._pinned else _("Pin Window"))
         self.pin_clicked.emit(self._pinned)
 
+    def set_account_visible(self, visible: bool):
+        """Show or hide the account button."""
+        self.account_btn.setVisible(visible)
+
     def is_pinned(self) -> bool:
         """Check if window is pinned."""
         return self._pinned
@@ -0,0 +1,104 @@
+"""
+System tray icon showing the pinned pairs' live prices.
+"""
+
+import sys
+
+from PyQt6.QtCore import pyqtSignal
+from PyQt6.QtGui import QIcon
+from PyQt6.QtWidgets import QSystemTrayIcon
+from qfluentwidgets import Action, SystemTrayMenu
+from qfluentwidgets import FluentIcon as FIF
+
+from core.i18n import _
+from core.notifier import get_notification_service
+from core.tray_summary import TrayEntry, format_tray_tooltip
+
+
+class PriceTrayIcon(QSystemTrayIcon):
+    """Tray icon whose tooltip and menu list the pinned pairs' prices."""
+
+    open_requested = pyqtSignal()
+    mini_ticker_toggled = pyqtSignal(bool)
+    taskbar_badge_toggled = pyqtSignal(bool)
+    menu_bar_price_toggled = pyqtSignal(bool)
+    quit_requested = pyqtSignal()
+
+    def __init__(self, icon: QIcon, parent=None):
+        super().__init__(icon, parent)
+        self._notifier = get_notification_service()
+        self._entries: list[TrayEntry] = []
+
+        self._menu = SystemTrayMenu(parent=parent)
+        self._price_actions: list[Action] = []
+
+        self.pause_action = Action(FIF.PAUSE, _("Pause Alerts"))
+        self.pause_action.setCheckable(True)
+        self.pause_action.setChecked(self._notifier.is_paused)
+        self.pause_action.triggered.connect(self._notifier.set_paused)
+        self._notifier.paused_changed.connect(self._on_paused_changed)
+
+        self.mini_ticker_action = Action(FIF.MINIMIZE, _("Mini Ticker"))
+        self.mini_ticker_action.setCheckable(True)
+        self.mini_ticker_action.triggered.connect(self.mini_ticker_toggled)
+
+        self.taskbar_badge_action = Action(FIF.TAG, _("Taskbar Badge"))
+        self.taskbar_badge_action.setCheckable(True)
+        self.taskbar_badge_action.triggered.connect(self.taskbar_badge_toggled)
+
+        self.menu_bar_price_action = Action(FIF.FONT, _("Price in Menu Bar"))
+        self.menu_bar_price_action.setCheckable(True)
+        self.menu_bar_price_action.triggered.connect(self.menu_bar_price_toggled)
+
+        self.open_action = Action(FIF.HOME, _("Open Crypto Monitor"))
+        self.open_action.triggered.connect(self.open_requested)
+
+        self.quit_action = Action(FIF.CLOSE, _("Quit"))
+        self.quit_action.triggered.connect(self.quit_requested)
+
+        self._rebuild_menu()
+        self.setContextMenu(self._menu)
+        self.setToolTip("Crypto Monitor")
+        self.activated.connect(self._on_activated)
+
+    def update_entries(self, entries: list[TrayEntry]):
+        """Show the latest prices of the pinned pairs."""
+        self.setToolTip(format_tray_tooltip(entries))
+        if [e.pair for e in entries] != [e.pair for e in self._entries]:
+            self._entries = entries
+            self._rebuild_menu()
+            return
+        self._entries = entries
+        for action, entry in zip(self._price_actions, entries, strict=True):
+            action.setText(str(entry))
+
+    def _rebuild_menu(self):
+        self._menu.clear()
+        self._price_actions = []
+        for entry in self._entries:
+            action = Action(str(entry))
+            action.triggered.connect(self.open_requested)
+            self._menu.addAction(action)
+            self._price_actions.append(action)
+        if self._entries:
+            self._menu.addSeparator()
+        self._menu.addActions([self.pause_action, self.mini_ticker_action])
+        if sys.platform == "win32":
+            # Only Windows taskbar buttons take an overlay icon
+            self._menu.addAction(self.taskbar_badge_action)
+        elif sys.platform == "darwin":
+            # The tray icon can't show text, so the price gets its own status item
+            self._menu.addAction(self.menu_bar_price_action)
+        self._menu.addAction(self.open_action)
+        self._menu.addSeparator()
+        self._menu.addAction(self.quit_action)
+
+    def _on_paused_changed(self, paused: bool):
+        self.pause_action.setChecked(paused)
+
+    def _on_activated(self, reason: QSystemTrayIcon.ActivationReason):
+        if reason in (
+            QSystemTrayIcon.ActivationReason.Trigger,
+            QSystemTrayIcon.ActivationReason.DoubleClick,
+        ):
+            self.open_requested.emit()
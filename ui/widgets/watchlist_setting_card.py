@@ -0,0 +1,180 @@
+"""
+Setting card for watchlist groups.
+"""
+
+from collections.abc import Callable
+
+from PyQt6.QtCore import Qt
+from PyQt6.QtWidgets import QHBoxLayout, QListWidgetItem, QVBoxLayout, QWidget
+from qfluentwidgets import (
+    ComboBox,
+    ExpandGroupSettingCard,
+    FluentIcon,
+    LineEdit,
+    MessageBox,
+    PrimaryPushButton,
+    PushButton,
+)
+from qfluentwidgets import ListWidget as FluentListWidget
+
+from core.i18n import _
+from core.watchlist_templates import get_template_service
+from core.watchlists import get_watchlist_manager
+
+
+class WatchlistSettingCard(ExpandGroupSettingCard):
+    """Creates watchlist groups from the pair list and (un)subscribes them."""
+
+    def __init__(self, current_pairs: Callable[[], list[str]], parent: QWidget | None = None):
+        super().__init__(
+            FluentIcon.TAG,
+            _("Watchlist Groups"),
+            _("Checked groups are monitored; e.g. Majors, DeFi, Memes"),
+            parent,
+        )
+        self._watchlist_manager = get_watchlist_manager()
+        self._template_service = get_template_service()
+        self._current_pairs = current_pairs
+
+        self._setup_ui()
+        self._load_watchlists()
+        self._load_templates()
+        self._watchlist_manager.watchlists_changed.connect(self._load_watchlists)
+        self._template_service.templates_updated.connect(self._load_templates)
+        self._template_service.refresh()
+
+    def _setup_ui(self):
+        container = QWidget()
+        layout = QVBoxLayout(container)
+        layout.setContentsMargins(48, 18, 48, 18)
+        layout.setSpacing(16)
+
+        self.watchlists_list = FluentListWidget()
+        self.watchlists_list.setSelectionMode(FluentListWidget.SelectionMode.SingleSelection)
+        self.watchlists_list.setMinimumHeight(120)
+        self.watchlists_list.setMaximumHeight(240)
+        self.watchlists_list.itemSelectionChanged.connect(self._on_selection_changed)
+        self.watchlists_list.itemChanged.connect(self._on_item_changed)
+        layout.addWidget(self.watchlists_list)
+
+        input_layout = QHBoxLayout()
+        self.name_edit = LineEdit()
+        self.name_edit.setPlaceholderText(_("Group name"))
+        self.name_edit.textChanged.connect(self._validate)
+        input_layout.addWidget(self.name_edit, 1)
+
+        self.add_btn = PrimaryPushButton(FluentIcon.ADD, _("Save Pairs as Group"))
+        self.add_btn.setToolTip(_("Create a group from the trading pairs listed above"))
+        self.add_btn.setEnabled(False)
+        self.add_btn.clicked.connect(self._add_watchlist)
+        input_layout.addWidget(self.add_btn)
+        layout.addLayout(input_layout)
+
+        template_layout = QHBoxLayout()
+        self.template_combo = ComboBox()
+        self.template_combo.currentIndexChanged.connect(self._on_template_changed)
+        template_layout.addWidget(self.template_combo, 1)
+
+        self.template_btn = PushButton(FluentIcon.ADD, _("Add Template"))
+        self.template_btn.setToolTip(_("Add a ready-made group and monitor its pairs"))
+        self.template_btn.clicked.connect(self._add_template)
+        template_layout.addWidget(self.template_btn)
+        layout.addLayout(template_layout)
+
+        button_layout = QHBoxLayout()
+        button_layout.addStretch(1)
+
+        self.update_btn = PushButton(FluentIcon.SYNC, _("Replace Pairs"))
+        self.update_btn.setToolTip(_("Replace the group's pairs with the trading pairs above"))
+        self.update_btn.setEnabled(False)
+        self.update_btn.clicked.connect(self._update_watchlist)
+        button_layout.addWidget(self.update_btn)
+
+        self.remove_btn = PushButton(FluentIcon.DELETE, _("Delete"))
+        self.remove_btn.setFixedWidth(100)
+        self.remove_btn.setEnabled(False)
+        self.remove_btn.clicked.connect(self._remove_watchlist)
+        button_layout.addWidget(self.remove_btn)
+        layout.addLayout(button_layout)
+
+        self.addGroupWidget(container)
+
+    def _load_watchlists(self):
+        self.watchlists_list.blockSignals(True)
+        self.watchlists_list.clear()
+        for watchlist in self._watchlist_manager.get_watchlists():
+            item = QListWidgetItem(f"{watchlist.name} ({len(watchlist.pairs)})")
+            item.setToolTip(", ".join(watchlist.pairs))
+            item.setData(Qt.ItemDataRole.UserRole, watchlist.id)
+            item.setFlags(item.flags() | Qt.ItemFlag.ItemIsUserCheckable)
+            item.setCheckState(
+                Qt.CheckState.Checked
+                if self._watchlist_manager.is_active(watchlist.id)
+                else Qt.CheckState.Unchecked
+            )
+            self.watchlists_list.addItem(item)
+        self.watchlists_list.blockSignals(False)
+        self._on_selection_changed()
+
+    def _load_templates(self):
+        current = self.template_combo.currentData()
+        self.template_combo.clear()
+        for template in self._template_service.get_templates():
+            self.template_combo.addItem(_(template.name), userData=template.id)
+        if current is not None:
+            index = self.template_combo.findData(current)
+            if index >= 0:
+                self.template_combo.setCurrentIndex(index)
+        self._on_template_changed()
+
+    def _on_template_changed(self, _index: int = -1):
+        template = self._template_service.get_template(self.template_combo.currentData())
+        self.template_combo.setToolTip(
+            f"{_(template.description)}\n{', '.join(template.pairs)}" if template else ""
+        )
+
+    def _validate(self):
+        self.add_btn.setEnabled(bool(self.name_edit.text().strip()))
+
+    def _on_selection_changed(self):
+        selected = self.watchlists_list.currentItem() is not None
+        self.update_btn.setEnabled(selected)
+        self.remove_btn.setEnabled(selected)
+
+    def _on_item_changed(self, item: QListWidgetItem):
+        watchlist_id = item.data(Qt.ItemDataRole.UserRole)
+        if item.checkState() == Qt.CheckState.Checked:
+            self._watchlist_manager.subscribe(watchlist_id)
+        else:
+            self._watchlist_manager.unsubscribe(watchlist_id)
+
+    def _add_watchlist(self):
+        name = self.name_edit.text().strip()
+        if not name:
+            return
+        self._watchlist_manager.create_watchlist(name, self._current_pairs())
+        self.name_edit.clear()
+
+    def _add_template(self):
+        template_id = self.template_combo.currentData()
+        if template_id is not None:
+            self._watchlist_manager.create_from_template(template_id, subscribe=True)
+
+    def _update_watchlist(self):
+        item = self.watchlists_list.currentItem()
+        if item is not None:
+            self._watchlist_manager.set_pairs(
+                item.data(Qt.ItemDataRole.UserRole), self._current_pairs()
+            )
+
+    def _remove_watchlist(self):
+        item = self.watchlists_list.currentItem()
+        if item is None:
+            return
+        box = MessageBox(
+            _("Delete Group"),
+            _("Delete this group? If it is checked, its pairs stop being monitored."),
+            self.window(),
+        )
+        if box.exec():
+            self._watchlist_manager.remove_watchlist(item.data(Qt.ItemDataRole.UserRole))